@@ -0,0 +1,279 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/serverlessworkflow/sdk-go/v2/internal/document"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+// categories of the features of the specification
+const (
+	// FeatureSpecVersion specification version of the workflow
+	FeatureSpecVersion = "specVersion"
+	// FeatureExpressionLang expression language of the workflow, 'jq' by default
+	FeatureExpressionLang = "expressionLang"
+	// FeatureStateType type of a state
+	FeatureStateType = "stateType"
+	// FeatureFunctionType type of a function, 'rest' by default
+	FeatureFunctionType = "functionType"
+	// FeatureAuthScheme scheme of an authentication definition, 'basic' by default
+	FeatureAuthScheme = "authScheme"
+	// FeatureExtension extension of the workflow, named after its extensionId
+	FeatureExtension = "extension"
+	// FeatureShorthand short form of a definition, e.g. a transition as the next state name
+	FeatureShorthand = "shorthand"
+	// FeatureDefinitionsURI definitions declared in another file, e.g. 'functions: functions.json'
+	FeatureDefinitionsURI = "definitionsUri"
+	// FeatureProperty optional property the runtime must support, e.g. 'compensatedBy'
+	FeatureProperty = "property"
+)
+
+// shorthands properties having a short form, the one of their values that aren't objects
+var shorthands = map[string]bool{
+	"start": true, "transition": true, "end": true, "functionRef": true, "subFlowRef": true, "schedule": true, "cron": true,
+}
+
+// definitionsURIs workflow properties holding either the definitions or the URI of a file declaring them
+var definitionsURIs = map[string]bool{
+	"functions": true, "events": true, "errors": true, "retries": true, "auth": true, "secrets": true, "constants": true, "timeouts": true,
+}
+
+// properties optional properties reported as features wherever they are defined
+var properties = map[string]bool{
+	"annotations": true, "autoRetries": true, "compensatedBy": true, "constants": true, "continueAs": true,
+	"correlation": true, "dataInputSchema": true, "eventRef": true, "keepActive": true, "metadata": true, "onErrors": true,
+	"produceEvents": true, "retryRef": true, "schedule": true, "secrets": true, "subFlowRef": true, "timeouts": true,
+	"usedForCompensation": true,
+}
+
+// freeForm properties holding free-form values, not walked for the shorthands and properties
+var freeForm = map[string]bool{
+	"arguments": true, "constants": true, "contextAttributes": true, "data": true, "metadata": true, "parameters": true,
+	"properties": true,
+}
+
+// Feature feature of the specification used by a workflow
+type Feature struct {
+	// Category of the feature, e.g. FeatureStateType
+	Category string `json:"category"`
+	// Name of the feature in its category, e.g. 'operation' for a state type
+	Name string `json:"name"`
+	// Count number of uses of the feature
+	Count int `json:"count"`
+	// Paths of the uses in the workflow document, e.g. 'states[0].type'
+	Paths []string `json:"paths"`
+}
+
+// FeatureReport features of the specification used by a workflow, sorted by category and name
+type FeatureReport struct {
+	Features []Feature `json:"features"`
+}
+
+// Uses tells whether the workflow uses the feature
+func (r *FeatureReport) Uses(category, name string) bool {
+	for _, feature := range r.Features {
+		if feature.Category == category && feature.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Text returns the features, one per line with the number of uses
+func (r *FeatureReport) Text() string {
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 0, 8, 2, ' ', 0)
+	for _, feature := range r.Features {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", feature.Category, feature.Name, feature.Count)
+	}
+	w.Flush()
+	return buf.String()
+}
+
+// Features reports the features of the specification used by the workflow document: the state types, function types,
+// auth schemes, extensions, shorthands and the like, with the defaults the runtime must support, e.g. the 'jq'
+// expression language. The document is read as is, the shorthands being lost once parsed.
+func Features(data []byte, format serializer.Format) (*FeatureReport, error) {
+	var tree interface{}
+	var err error
+	switch format {
+	case serializer.FormatJSON:
+		tree, err = document.Decode(data)
+	case serializer.FormatYAML:
+		tree, err = document.DecodeYAML(data)
+	default:
+		err = fmt.Errorf("format %s not supported", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	root, ok := tree.(document.Object)
+	if !ok {
+		return nil, fmt.Errorf("workflow document must be an object")
+	}
+
+	u := usage{}
+	if version, ok := root.Get("specVersion"); ok {
+		u.use(FeatureSpecVersion, fmt.Sprint(version), "specVersion")
+	}
+	u.use(FeatureExpressionLang, stringOr(root, "expressionLang", "jq"), "expressionLang")
+	for _, m := range root {
+		if _, ok := m.Value.(string); ok && definitionsURIs[m.Key] {
+			u.use(FeatureDefinitionsURI, m.Key, m.Key)
+		}
+	}
+	u.each(root, "states", func(path string, state document.Object) {
+		u.use(FeatureStateType, stringOr(state, "type", ""), path+".type")
+	})
+	u.each(root, "functions", func(path string, function document.Object) {
+		u.use(FeatureFunctionType, stringOr(function, "type", "rest"), path+".type")
+	})
+	u.each(root, "auth", func(path string, auth document.Object) {
+		u.use(FeatureAuthScheme, stringOr(auth, "scheme", "basic"), path+".scheme")
+	})
+	u.each(root, "extensions", func(path string, extension document.Object) {
+		u.use(FeatureExtension, stringOr(extension, "extensionId", ""), path)
+	})
+	u.walk("", root)
+	return u.report(), nil
+}
+
+// usage paths of the uses of the features by category and name
+type usage map[string]map[string][]string
+
+func (u usage) use(category, name, path string) {
+	if len(name) == 0 {
+		return
+	}
+	if u[category] == nil {
+		u[category] = map[string][]string{}
+	}
+	u[category][name] = append(u[category][name], path)
+}
+
+// each calls fn with every object of the array of the workflow with the given key and its path
+func (u usage) each(root document.Object, key string, fn func(path string, o document.Object)) {
+	value, _ := root.Get(key)
+	array, _ := value.([]interface{})
+	for i, item := range array {
+		if o, ok := item.(document.Object); ok {
+			fn(fmt.Sprintf("%s[%d]", key, i), o)
+		}
+	}
+}
+
+// walk collects the shorthands and properties of the value at the path
+func (u usage) walk(path string, value interface{}) {
+	switch v := value.(type) {
+	case document.Object:
+		for _, m := range v {
+			memberPath := m.Key
+			if len(path) > 0 {
+				memberPath = path + "." + m.Key
+			}
+			if _, ok := m.Value.(document.Object); !ok && shorthands[m.Key] {
+				u.use(FeatureShorthand, m.Key, memberPath)
+			}
+			if properties[m.Key] {
+				u.use(FeatureProperty, m.Key, memberPath)
+			}
+			if !freeForm[m.Key] {
+				u.walk(memberPath, m.Value)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			u.walk(fmt.Sprintf("%s[%d]", path, i), item)
+		}
+	}
+}
+
+func (u usage) report() *FeatureReport {
+	report := &FeatureReport{Features: []Feature{}}
+	for category, names := range u {
+		for name, paths := range names {
+			report.Features = append(report.Features, Feature{Category: category, Name: name, Count: len(paths), Paths: paths})
+		}
+	}
+	sort.Slice(report.Features, func(i, j int) bool {
+		a, b := report.Features[i], report.Features[j]
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
+
+// Adoption use of a feature across workflows
+type Adoption struct {
+	Category string `json:"category"`
+	Name     string `json:"name"`
+	// Workflows number of workflows using the feature
+	Workflows int `json:"workflows"`
+	// Uses number of uses of the feature by all the workflows
+	Uses int `json:"uses"`
+}
+
+// FeatureAdoption aggregates the features used by the workflows of the reports, e.g. those of a repository, the most
+// adopted first, then by category and name
+func FeatureAdoption(reports []*FeatureReport) []Adoption {
+	index := map[[2]string]*Adoption{}
+	adoptions := []*Adoption{}
+	for _, report := range reports {
+		for _, feature := range report.Features {
+			key := [2]string{feature.Category, feature.Name}
+			adoption, ok := index[key]
+			if !ok {
+				adoption = &Adoption{Category: feature.Category, Name: feature.Name}
+				index[key] = adoption
+				adoptions = append(adoptions, adoption)
+			}
+			adoption.Workflows++
+			adoption.Uses += feature.Count
+		}
+	}
+	sort.Slice(adoptions, func(i, j int) bool {
+		a, b := adoptions[i], adoptions[j]
+		if a.Workflows != b.Workflows {
+			return a.Workflows > b.Workflows
+		}
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+		return a.Name < b.Name
+	})
+	result := make([]Adoption, len(adoptions))
+	for i, adoption := range adoptions {
+		result[i] = *adoption
+	}
+	return result
+}
+
+// stringOr returns the string value of the member of the object, the default value if it has none
+func stringOr(o document.Object, key, defaultValue string) string {
+	if value, ok := o.Get(key); ok {
+		if s, ok := value.(string); ok && len(s) > 0 {
+			return s
+		}
+	}
+	return defaultValue
+}
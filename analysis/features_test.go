@@ -0,0 +1,110 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const featuresWorkflow = `id: order
+specVersion: '0.8'
+start: Check
+auth:
+  - name: token
+    scheme: bearer
+    properties:
+      token: ${ $SECRETS.token }
+  - name: user
+    properties:
+      username: user
+functions: functions.json
+extensions:
+  - extensionId: ratelimiting
+    path: ratelimiting.json
+states:
+  - name: Check
+    type: switch
+    dataConditions:
+      - condition: ${ .paid }
+        transition: Store
+    defaultCondition:
+      transition:
+        nextState: Cancel
+  - name: Store
+    type: operation
+    actions:
+      - functionRef: store
+      - subFlowRef: audit
+    compensatedBy: Cancel
+    end: true
+  - name: Cancel
+    type: inject
+    usedForCompensation: true
+    data:
+      metadata: not a property
+    end:
+      terminate: true
+`
+
+func TestFeatures(t *testing.T) {
+	report, err := Features([]byte(featuresWorkflow), serializer.FormatYAML)
+	require.NoError(t, err)
+	assert.Equal(t, []Feature{
+		{Category: FeatureAuthScheme, Name: "basic", Count: 1, Paths: []string{"auth[1].scheme"}},
+		{Category: FeatureAuthScheme, Name: "bearer", Count: 1, Paths: []string{"auth[0].scheme"}},
+		{Category: FeatureDefinitionsURI, Name: "functions", Count: 1, Paths: []string{"functions"}},
+		{Category: FeatureExpressionLang, Name: "jq", Count: 1, Paths: []string{"expressionLang"}},
+		{Category: FeatureExtension, Name: "ratelimiting", Count: 1, Paths: []string{"extensions[0]"}},
+		{Category: FeatureProperty, Name: "compensatedBy", Count: 1, Paths: []string{"states[1].compensatedBy"}},
+		{Category: FeatureProperty, Name: "subFlowRef", Count: 1, Paths: []string{"states[1].actions[1].subFlowRef"}},
+		{Category: FeatureProperty, Name: "usedForCompensation", Count: 1, Paths: []string{"states[2].usedForCompensation"}},
+		{Category: FeatureShorthand, Name: "end", Count: 1, Paths: []string{"states[1].end"}},
+		{Category: FeatureShorthand, Name: "functionRef", Count: 1, Paths: []string{"states[1].actions[0].functionRef"}},
+		{Category: FeatureShorthand, Name: "start", Count: 1, Paths: []string{"start"}},
+		{Category: FeatureShorthand, Name: "subFlowRef", Count: 1, Paths: []string{"states[1].actions[1].subFlowRef"}},
+		{Category: FeatureShorthand, Name: "transition", Count: 1, Paths: []string{"states[0].dataConditions[0].transition"}},
+		{Category: FeatureSpecVersion, Name: "0.8", Count: 1, Paths: []string{"specVersion"}},
+		{Category: FeatureStateType, Name: "inject", Count: 1, Paths: []string{"states[2].type"}},
+		{Category: FeatureStateType, Name: "operation", Count: 1, Paths: []string{"states[1].type"}},
+		{Category: FeatureStateType, Name: "switch", Count: 1, Paths: []string{"states[0].type"}},
+	}, report.Features)
+	assert.True(t, report.Uses(FeatureStateType, "switch"))
+	assert.False(t, report.Uses(FeatureStateType, "parallel"))
+	assert.Contains(t, report.Text(), "stateType       switch               1\n")
+
+	_, err = Features([]byte(`[]`), serializer.FormatJSON)
+	assert.Error(t, err)
+}
+
+func TestFeatureAdoption(t *testing.T) {
+	order, err := Features([]byte(featuresWorkflow), serializer.FormatYAML)
+	require.NoError(t, err)
+	greeting, err := Features([]byte(`{"id": "greeting", "specVersion": "0.7", "expressionLang": "jsonpath", "functions": [{"name": "greet", "operation": "greet.json#greet"}],
+"states": [{"name": "Greet", "type": "operation", "actions": [{"functionRef": "greet"}, {"functionRef": "greet"}], "end": true}]}`), serializer.FormatJSON)
+	require.NoError(t, err)
+
+	adoption := FeatureAdoption([]*FeatureReport{order, greeting})
+	assert.Equal(t, []Adoption{
+		{Category: FeatureShorthand, Name: "end", Workflows: 2, Uses: 2},
+		{Category: FeatureShorthand, Name: "functionRef", Workflows: 2, Uses: 3},
+		{Category: FeatureStateType, Name: "operation", Workflows: 2, Uses: 2},
+	}, adoption[:3])
+	assert.Contains(t, adoption, Adoption{Category: FeatureExpressionLang, Name: "jsonpath", Workflows: 1, Uses: 1})
+	assert.Contains(t, adoption, Adoption{Category: FeatureFunctionType, Name: "rest", Workflows: 1, Uses: 1})
+}
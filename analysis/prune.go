@@ -0,0 +1,107 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v2/internal/document"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+// sections document properties holding the definitions of each kind
+var sections = map[Kind]string{
+	KindFunction: "functions",
+	KindEvent:    "events",
+	KindError:    "errors",
+	KindRetry:    "retries",
+	KindSecret:   "secrets",
+}
+
+// Prune removes the given definitions, usually found with UnusedDefinitions, from the workflow document, keeping the
+// order and the format of the rest. Definitions declared in another file, e.g. 'functions: functions.json', can't be
+// pruned from the workflow document and are returned as skipped. Sections left empty are removed.
+func Prune(data []byte, format serializer.Format, definitions []Definition) (pruned []byte, skipped []Definition, err error) {
+	var tree interface{}
+	switch format {
+	case serializer.FormatJSON:
+		tree, err = document.Decode(data)
+	case serializer.FormatYAML:
+		tree, err = document.DecodeYAML(data)
+	default:
+		err = fmt.Errorf("format %s not supported", format)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	root, ok := tree.(document.Object)
+	if !ok {
+		return nil, nil, fmt.Errorf("workflow document must be an object")
+	}
+
+	remove := map[Kind]map[string]bool{}
+	for _, definition := range definitions {
+		section, _ := root.Get(sections[definition.Kind])
+		if _, ok := section.([]interface{}); !ok {
+			skipped = append(skipped, definition)
+			continue
+		}
+		if remove[definition.Kind] == nil {
+			remove[definition.Kind] = map[string]bool{}
+		}
+		remove[definition.Kind][definition.Name] = true
+	}
+	for kind, names := range remove {
+		key := sections[kind]
+		section, _ := root.Get(key)
+		var kept []interface{}
+		for _, item := range section.([]interface{}) {
+			if !names[definitionName(item)] {
+				kept = append(kept, item)
+			}
+		}
+		if len(kept) == 0 {
+			root = root.Without(key)
+		} else {
+			root = root.Set(key, kept)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if format == serializer.FormatYAML {
+		err = document.WriteYAML(buf, root)
+	} else {
+		err = document.WriteJSON(buf, root, "  ", 0)
+		buf.WriteString("\n")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), skipped, nil
+}
+
+// definitionName name of a definition in the document, secrets are declared by their bare name
+func definitionName(item interface{}) string {
+	switch v := item.(type) {
+	case string:
+		return v
+	case document.Object:
+		name, _ := v.Get("name")
+		s, _ := name.(string)
+		return s
+	}
+	return ""
+}
@@ -0,0 +1,235 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// Kind of a workflow definition that can be referenced
+type Kind string
+
+const (
+	// KindFunction ...
+	KindFunction Kind = "function"
+	// KindEvent ...
+	KindEvent Kind = "event"
+	// KindError ...
+	KindError Kind = "error"
+	// KindRetry ...
+	KindRetry Kind = "retry"
+	// KindSecret ...
+	KindSecret Kind = "secret"
+)
+
+// Definition named workflow definition
+type Definition struct {
+	Kind Kind
+	Name string
+	// Path of the definition in the workflow document, e.g. 'functions[2]'
+	Path string
+}
+
+// String ...
+func (d Definition) String() string {
+	return fmt.Sprintf("%s: %s %s is never used", d.Path, d.Kind, d.Name)
+}
+
+var (
+	// functionExpression reference to an expression function, e.g. '${ fn:isAdult }'
+	functionExpression = regexp.MustCompile(`fn:([\w.-]+)`)
+	// secretExpression reference to a secret, e.g. '${ $SECRETS.token }' or '${ $SECRETS["token"] }'
+	secretExpression = regexp.MustCompile(`\$SECRETS(?:\.([\w-]+)|\[\s*["']([^"']+)["']\s*\])`)
+)
+
+// UnusedDefinitions finds the functions, events, errors, retries and secrets declared in the workflow but never
+// referenced, in the order they are declared. Functions and secrets referenced from expressions count as used.
+func UnusedDefinitions(workflow *model.Workflow) ([]Definition, error) {
+	refs, err := collectReferences(workflow)
+	if err != nil {
+		return nil, err
+	}
+	var unused []Definition
+	check := func(kind Kind, path string, name string) {
+		if !refs[kind][name] {
+			unused = append(unused, Definition{Kind: kind, Name: name, Path: path})
+		}
+	}
+	for i, function := range workflow.Functions {
+		check(KindFunction, fmt.Sprintf("functions[%d]", i), function.Name)
+	}
+	for i, event := range workflow.Events {
+		check(KindEvent, fmt.Sprintf("events[%d]", i), event.Name)
+	}
+	for i, err := range workflow.Errors {
+		check(KindError, fmt.Sprintf("errors[%d]", i), err.Name)
+	}
+	for i, retry := range workflow.Retries {
+		check(KindRetry, fmt.Sprintf("retries[%d]", i), retry.Name)
+	}
+	for i, secret := range workflow.Secrets {
+		check(KindSecret, fmt.Sprintf("secrets[%d]", i), secret)
+	}
+	return unused, nil
+}
+
+type references map[Kind]map[string]bool
+
+func (r references) add(kind Kind, name string) {
+	if len(name) > 0 {
+		r[kind][name] = true
+	}
+}
+
+func collectReferences(workflow *model.Workflow) (references, error) {
+	refs := references{}
+	for _, kind := range []Kind{KindFunction, KindEvent, KindError, KindRetry, KindSecret} {
+		refs[kind] = map[string]bool{}
+	}
+	for _, state := range workflow.States {
+		collectStateReferences(refs, state)
+	}
+
+	// expressions can be anywhere in the document, look for them in every string
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	eachString(tree, func(s string) {
+		for _, match := range functionExpression.FindAllStringSubmatch(s, -1) {
+			refs.add(KindFunction, match[1])
+		}
+		for _, match := range secretExpression.FindAllStringSubmatch(s, -1) {
+			refs.add(KindSecret, match[1]+match[2])
+		}
+	})
+	return refs, nil
+}
+
+func collectStateReferences(refs references, state model.State) {
+	if transition := state.GetTransition(); transition != nil {
+		collectTransitionReferences(refs, transition)
+	}
+	if end := state.GetEnd(); end != nil {
+		collectEndReferences(refs, end)
+	}
+	for _, onError := range state.GetOnErrors() {
+		refs.add(KindError, onError.ErrorRef)
+		for _, ref := range onError.ErrorRefs {
+			refs.add(KindError, ref)
+		}
+		if onError.Transition != nil {
+			collectTransitionReferences(refs, onError.Transition)
+		}
+		if onError.End != nil {
+			collectEndReferences(refs, onError.End)
+		}
+	}
+
+	switch s := state.(type) {
+	case *model.OperationState:
+		collectActionsReferences(refs, s.Actions)
+	case *model.ForEachState:
+		collectActionsReferences(refs, s.Actions)
+	case *model.ParallelState:
+		for _, branch := range s.Branches {
+			collectActionsReferences(refs, branch.Actions)
+		}
+	case *model.EventState:
+		for _, onEvent := range s.OnEvents {
+			for _, ref := range onEvent.EventRefs {
+				refs.add(KindEvent, ref)
+			}
+			collectActionsReferences(refs, onEvent.Actions)
+		}
+	case *model.CallbackState:
+		collectActionsReferences(refs, []model.Action{s.Action})
+		refs.add(KindEvent, s.EventRef)
+	case *model.EventBasedSwitchState:
+		collectTransitionReferences(refs, &s.DefaultCondition.Transition)
+		collectEndReferences(refs, &s.DefaultCondition.End)
+		for _, condition := range s.EventConditions {
+			refs.add(KindEvent, condition.GetEventRef())
+			switch c := condition.(type) {
+			case *model.TransitionEventCondition:
+				collectTransitionReferences(refs, &c.Transition)
+			case *model.EndEventCondition:
+				collectEndReferences(refs, &c.End)
+			}
+		}
+	case *model.DataBasedSwitchState:
+		collectTransitionReferences(refs, &s.DefaultCondition.Transition)
+		collectEndReferences(refs, &s.DefaultCondition.End)
+		for _, condition := range s.DataConditions {
+			switch c := condition.(type) {
+			case *model.TransitionDataCondition:
+				collectTransitionReferences(refs, &c.Transition)
+			case *model.EndDataCondition:
+				collectEndReferences(refs, &c.End)
+			}
+		}
+	}
+}
+
+func collectTransitionReferences(refs references, transition *model.Transition) {
+	for _, produce := range transition.ProduceEvents {
+		refs.add(KindEvent, produce.EventRef)
+	}
+}
+
+func collectEndReferences(refs references, end *model.End) {
+	for _, produce := range end.ProduceEvents {
+		refs.add(KindEvent, produce.EventRef)
+	}
+}
+
+func collectActionsReferences(refs references, actions []model.Action) {
+	for _, action := range actions {
+		refs.add(KindFunction, action.FunctionRef.RefName)
+		refs.add(KindEvent, action.EventRef.GetTriggerEventRef())
+		refs.add(KindEvent, action.EventRef.GetResultEventRef())
+		refs.add(KindRetry, action.RetryRef)
+		for _, ref := range action.RetryableErrors {
+			refs.add(KindError, ref)
+		}
+		for _, ref := range action.NonRetryableErrors {
+			refs.add(KindError, ref)
+		}
+	}
+}
+
+// eachString calls fn with every string in the decoded JSON value
+func eachString(value interface{}, fn func(string)) {
+	switch v := value.(type) {
+	case string:
+		fn(v)
+	case []interface{}:
+		for _, item := range v {
+			eachString(item, fn)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			eachString(item, fn)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const definitionsWorkflow = `id: order
+name: Order
+version: '1.0'
+specVersion: '0.7'
+start: Check
+secrets:
+  - token
+  - password
+functions:
+  - name: storeOrder
+    operation: http://orders.json#store
+  - name: isPaid
+    type: expression
+    operation: .paid
+  - name: legacy
+    operation: http://legacy.json#store
+events:
+  - name: OrderStored
+    type: order.stored
+    kind: produced
+  - name: OrderCancelled
+    type: order.cancelled
+errors:
+  - name: StoreFailed
+    code: '500'
+  - name: Timeout
+    code: '408'
+retries:
+  - name: threeTimes
+    maxAttempts: 3
+states:
+  - name: Check
+    type: switch
+    dataConditions:
+      - condition: ${ fn:isPaid }
+        transition: Store
+    defaultCondition:
+      end: true
+  - name: Store
+    type: operation
+    actions:
+      - functionRef:
+          refName: storeOrder
+          arguments:
+            token: ${ $SECRETS.token }
+    onErrors:
+      - errorRef: StoreFailed
+        end: true
+    end:
+      produceEvents:
+        - eventRef: OrderStored
+`
+
+func TestUnusedDefinitions(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(definitionsWorkflow))
+	require.NoError(t, err)
+	unused, err := UnusedDefinitions(workflow)
+	require.NoError(t, err)
+	assert.Equal(t, []Definition{
+		{Kind: KindFunction, Name: "legacy", Path: "functions[2]"},
+		{Kind: KindEvent, Name: "OrderCancelled", Path: "events[1]"},
+		{Kind: KindError, Name: "Timeout", Path: "errors[1]"},
+		{Kind: KindRetry, Name: "threeTimes", Path: "retries[0]"},
+		{Kind: KindSecret, Name: "password", Path: "secrets[1]"},
+	}, unused)
+	assert.Equal(t, "functions[2]: function legacy is never used", unused[0].String())
+}
+
+func TestPrune(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(definitionsWorkflow))
+	require.NoError(t, err)
+	unused, err := UnusedDefinitions(workflow)
+	require.NoError(t, err)
+
+	pruned, skipped, err := Prune([]byte(definitionsWorkflow), serializer.FormatYAML, unused)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+	workflow, err = parser.FromYAMLSource(pruned)
+	require.NoError(t, err)
+	assert.Len(t, workflow.Functions, 2)
+	assert.Len(t, workflow.Events, 1)
+	assert.Len(t, workflow.Errors, 1)
+	assert.Empty(t, workflow.Retries)
+	assert.Equal(t, []string{"token"}, []string(workflow.Secrets))
+	assert.NotContains(t, string(pruned), "retries")
+	unused, err = UnusedDefinitions(workflow)
+	require.NoError(t, err)
+	assert.Empty(t, unused)
+
+	source := `{"id": "order", "functions": "functions.json", "events": [{"name": "a"}, {"name": "b"}]}`
+	pruned, skipped, err = Prune([]byte(source), serializer.FormatJSON, []Definition{
+		{Kind: KindFunction, Name: "legacy", Path: "functions[2]"},
+		{Kind: KindEvent, Name: "a", Path: "events[0]"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Definition{{Kind: KindFunction, Name: "legacy", Path: "functions[2]"}}, skipped)
+	assert.Equal(t, `{
+  "id": "order",
+  "functions": "functions.json",
+  "events": [
+    {
+      "name": "b"
+    }
+  ]
+}
+`, string(pruned))
+}
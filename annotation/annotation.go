@@ -0,0 +1,127 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package annotation reports the problems found in workflow files to CI systems, as GitHub Actions workflow
+// commands or GitLab Code Quality reports, so they show up on the lines of the pull or merge requests.
+package annotation
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity ...
+type Severity string
+
+const (
+	// SeverityError problems failing the check
+	SeverityError Severity = "error"
+	// SeverityWarning problems that should be fixed
+	SeverityWarning Severity = "warning"
+	// SeverityNotice informative messages
+	SeverityNotice Severity = "notice"
+)
+
+// Annotation problem found in a workflow file
+type Annotation struct {
+	File string
+	// Path JSON path of the property with the problem if known, e.g. 'states[0].transition.nextState'
+	Path string
+	// Line and Column of the property, starting at 1. Zero if unknown, see Locator.
+	Line   int
+	Column int
+	// Severity SeverityError if empty
+	Severity Severity
+	// Title name of the rule or check reporting the problem, if any
+	Title   string
+	Message string
+}
+
+// String ...
+func (a Annotation) String() string {
+	message := a.Message
+	if len(a.Path) > 0 {
+		message = a.Path + ": " + message
+	}
+	if len(a.Title) > 0 {
+		message += " (" + a.Title + ")"
+	}
+	return message
+}
+
+func (a Annotation) severity() Severity {
+	if len(a.Severity) == 0 {
+		return SeverityError
+	}
+	return a.Severity
+}
+
+type position struct {
+	line, column int
+}
+
+// Locator finds the positions of the properties in the source of a workflow file. JSON sources are parsed as YAML,
+// which keeps the positions of the nodes.
+type Locator struct {
+	positions map[string]position
+}
+
+// NewLocator indexes the positions of the properties in the source. Sources that can't be parsed have no positions.
+func NewLocator(source []byte) *Locator {
+	l := &Locator{positions: map[string]position{}}
+	var root yaml.Node
+	if err := yaml.Unmarshal(source, &root); err == nil && len(root.Content) > 0 {
+		l.index("", root.Content[0])
+	}
+	return l
+}
+
+func (l *Locator) index(path string, node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPath := key.Value
+			if len(path) > 0 {
+				childPath = path + "." + key.Value
+			}
+			l.positions[childPath] = position{line: key.Line, column: key.Column}
+			l.index(childPath, value)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			childPath := fmt.Sprintf("%s[%d]", path, i)
+			l.positions[childPath] = position{line: item.Line, column: item.Column}
+			l.index(childPath, item)
+		}
+	}
+}
+
+// Locate returns the line and column of the property with the given path or, if it's not in the source, of its
+// closest parent. Zero if none is found.
+func (l *Locator) Locate(path string) (int, int) {
+	for len(path) > 0 {
+		if p, ok := l.positions[path]; ok {
+			return p.line, p.column
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return 0, 0
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotation
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderSource = `id: order
+states:
+  - name: Store
+    type: operation
+    transition: Notify
+  - name: Notify
+    type: sleep
+`
+
+func TestLocator(t *testing.T) {
+	locator := NewLocator([]byte(orderSource))
+	line, column := locator.Locate("states[0].transition")
+	assert.Equal(t, []int{5, 5}, []int{line, column})
+	// missing properties are located on their closest parent
+	line, column = locator.Locate("states[1].transition.nextState")
+	assert.Equal(t, []int{6, 5}, []int{line, column})
+	line, column = locator.Locate("Auth.name")
+	assert.Equal(t, []int{0, 0}, []int{line, column})
+
+	line, _ = NewLocator([]byte(`{"id": "order", "states": [{"name": "Store"}]}`)).Locate("states[0].name")
+	assert.Equal(t, 1, line)
+	line, _ = NewLocator([]byte("{invalid")).Locate("id")
+	assert.Equal(t, 0, line)
+}
+
+func TestGitHub(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, GitHub(buf, []Annotation{
+		{File: "order.sw.yaml", Path: "states[0].transition", Line: 5, Column: 5, Message: "state Notfy is not defined"},
+		{File: "order.sw.yaml", Severity: SeverityWarning, Title: "naming: states", Message: "multi\nline, 100%"},
+	}))
+	assert.Equal(t, "::error file=order.sw.yaml,line=5,col=5::states[0].transition: state Notfy is not defined\n"+
+		"::warning file=order.sw.yaml,title=naming%3A states::multi%0Aline, 100%25\n", buf.String())
+}
+
+func TestGitLab(t *testing.T) {
+	annotations := []Annotation{
+		{File: "order.sw.yaml", Path: "states[0].transition", Line: 5, Message: "state Notfy is not defined"},
+		{File: "order.sw.yaml", Severity: SeverityWarning, Title: "max-states", Message: "too many states"},
+	}
+	report, err := GitLab(annotations)
+	require.NoError(t, err)
+	var issues []map[string]interface{}
+	require.NoError(t, json.Unmarshal(report, &issues))
+	require.Len(t, issues, 2)
+	assert.Equal(t, "states[0].transition: state Notfy is not defined", issues[0]["description"])
+	assert.Equal(t, defaultCheckName, issues[0]["check_name"])
+	assert.Equal(t, "major", issues[0]["severity"])
+	assert.Equal(t, map[string]interface{}{"path": "order.sw.yaml", "lines": map[string]interface{}{"begin": 5.0}}, issues[0]["location"])
+	assert.Equal(t, "minor", issues[1]["severity"])
+	assert.Equal(t, 1.0, issues[1]["location"].(map[string]interface{})["lines"].(map[string]interface{})["begin"])
+	assert.Len(t, issues[0]["fingerprint"], 32)
+	assert.NotEqual(t, issues[0]["fingerprint"], issues[1]["fingerprint"])
+
+	// the fingerprint doesn't depend on the line
+	annotations[0].Line = 7
+	moved, err := GitLab(annotations)
+	require.NoError(t, err)
+	var movedIssues []map[string]interface{}
+	require.NoError(t, json.Unmarshal(moved, &movedIssues))
+	assert.Equal(t, issues[0]["fingerprint"], movedIssues[0]["fingerprint"])
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GitHub writes the annotations as GitHub Actions workflow commands, e.g.
+// '::error file=order.sw.yaml,line=12,col=7,title=integrity::event Paid is not defined'
+func GitHub(w io.Writer, annotations []Annotation) error {
+	for _, a := range annotations {
+		properties := []string{"file=" + githubProperty(a.File)}
+		if a.Line > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", a.Line))
+			if a.Column > 0 {
+				properties = append(properties, fmt.Sprintf("col=%d", a.Column))
+			}
+		}
+		if len(a.Title) > 0 {
+			properties = append(properties, "title="+githubProperty(a.Title))
+		}
+		message := a.Message
+		if len(a.Path) > 0 {
+			message = a.Path + ": " + message
+		}
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", a.severity(), strings.Join(properties, ","), githubData(message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	githubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func githubData(s string) string {
+	return githubDataEscaper.Replace(s)
+}
+
+func githubProperty(s string) string {
+	return githubPropertyEscaper.Replace(s)
+}
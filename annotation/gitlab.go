@@ -0,0 +1,77 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// defaultCheckName check name of the issues of annotations without title
+const defaultCheckName = "serverless-workflow"
+
+// codeQualityIssue issue of a GitLab Code Quality report
+type codeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeQualityLocation `json:"location"`
+}
+
+type codeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines codeQualityLines `json:"lines"`
+}
+
+type codeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+var codeQualitySeverities = map[Severity]string{
+	SeverityError:   "major",
+	SeverityWarning: "minor",
+	SeverityNotice:  "info",
+}
+
+// GitLab returns the annotations as a GitLab Code Quality report. Issues are fingerprinted by file, path, title and
+// message, so they are tracked across commits even if their line changes.
+func GitLab(annotations []Annotation) ([]byte, error) {
+	issues := make([]codeQualityIssue, len(annotations))
+	for i, a := range annotations {
+		line := a.Line
+		if line == 0 {
+			line = 1
+		}
+		description := a.Message
+		if len(a.Path) > 0 {
+			description = a.Path + ": " + description
+		}
+		checkName := a.Title
+		if len(checkName) == 0 {
+			checkName = defaultCheckName
+		}
+		fingerprint := sha256.Sum256([]byte(a.File + "\x00" + a.Path + "\x00" + a.Title + "\x00" + a.Message))
+		issues[i] = codeQualityIssue{
+			Description: description,
+			CheckName:   checkName,
+			Fingerprint: hex.EncodeToString(fingerprint[:16]),
+			Severity:    codeQualitySeverities[a.severity()],
+			Location:    codeQualityLocation{Path: a.File, Lines: codeQualityLines{Begin: line}},
+		}
+	}
+	return json.MarshalIndent(issues, "", "  ")
+}
@@ -0,0 +1,214 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apidoc
+
+import (
+	"sort"
+
+	"github.com/serverlessworkflow/sdk-go/v2/codegen"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+const (
+	// AsyncAPIVersion version of the generated AsyncAPI documents
+	AsyncAPIVersion = "2.4.0"
+	// CloudEventsContentType content type of the messages, CloudEvents in structured mode
+	CloudEventsContentType = "application/cloudevents+json"
+	// DescriptionKey metadata key of the event definitions holding a human-readable description of the event
+	DescriptionKey = "description"
+
+	defaultVersion = "1.0"
+)
+
+// AsyncAPIDocument AsyncAPI 2 document describing the event contract of a workflow
+type AsyncAPIDocument struct {
+	AsyncAPI   string                     `json:"asyncapi"`
+	ID         string                     `json:"id,omitempty"`
+	Info       AsyncAPIInfo               `json:"info"`
+	Servers    map[string]AsyncAPIServer  `json:"servers,omitempty"`
+	Channels   map[string]AsyncAPIChannel `json:"channels"`
+	Components *AsyncAPIComponents        `json:"components,omitempty"`
+}
+
+// AsyncAPIInfo ...
+type AsyncAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// AsyncAPIServer ...
+type AsyncAPIServer struct {
+	URL         string `json:"url"`
+	Protocol    string `json:"protocol"`
+	Description string `json:"description,omitempty"`
+}
+
+// AsyncAPIChannel channel the events of a CloudEvent type are exchanged on
+type AsyncAPIChannel struct {
+	Description string `json:"description,omitempty"`
+	// Publish messages the workflow receives, i.e. consumed events
+	Publish *AsyncAPIOperation `json:"publish,omitempty"`
+	// Subscribe messages the workflow sends, i.e. produced events
+	Subscribe *AsyncAPIOperation `json:"subscribe,omitempty"`
+}
+
+// AsyncAPIOperation ...
+type AsyncAPIOperation struct {
+	OperationID string       `json:"operationId"`
+	Summary     string       `json:"summary,omitempty"`
+	Message     AsyncAPIRefs `json:"message"`
+}
+
+// AsyncAPIRefs either a single reference or a oneOf list of references
+type AsyncAPIRefs struct {
+	Ref   string        `json:"$ref,omitempty"`
+	OneOf []AsyncAPIRef `json:"oneOf,omitempty"`
+}
+
+// AsyncAPIRef ...
+type AsyncAPIRef struct {
+	Ref string `json:"$ref"`
+}
+
+// AsyncAPIComponents ...
+type AsyncAPIComponents struct {
+	Messages map[string]AsyncAPIMessage `json:"messages,omitempty"`
+}
+
+// AsyncAPIMessage message describing a workflow event definition
+type AsyncAPIMessage struct {
+	Name        string                 `json:"name"`
+	Title       string                 `json:"title,omitempty"`
+	Summary     string                 `json:"summary,omitempty"`
+	ContentType string                 `json:"contentType"`
+	Headers     map[string]interface{} `json:"headers,omitempty"`
+	Payload     map[string]interface{} `json:"payload,omitempty"`
+}
+
+// AsyncAPIOptions options to generate the AsyncAPI document
+type AsyncAPIOptions struct {
+	// Version of the API. Default is the workflow version, or '1.0' if the workflow doesn't declare any
+	Version string
+	// Servers the events are exchanged through
+	Servers map[string]AsyncAPIServer
+}
+
+// NewAsyncAPI generates the AsyncAPI document describing the events consumed and produced by the given workflow.
+// Each CloudEvent type is a channel, consumed events are publish operations and produced events subscribe operations.
+// The messages describe the CloudEvent context attributes, including the correlation ones, and reference the event
+// data schema declared in the 'dataSchema' metadata of the event definition, if any.
+func NewAsyncAPI(workflow *model.Workflow, opts AsyncAPIOptions) *AsyncAPIDocument {
+	doc := &AsyncAPIDocument{
+		AsyncAPI: AsyncAPIVersion,
+		ID:       "urn:serverlessworkflow:" + workflowID(workflow),
+		Info: AsyncAPIInfo{
+			Title:       workflow.Name,
+			Version:     opts.Version,
+			Description: workflow.Description,
+		},
+		Servers:  opts.Servers,
+		Channels: map[string]AsyncAPIChannel{},
+	}
+	if len(doc.Info.Title) == 0 {
+		doc.Info.Title = workflowID(workflow)
+	}
+	if len(doc.Info.Version) == 0 {
+		doc.Info.Version = workflow.Version
+	}
+	if len(doc.Info.Version) == 0 {
+		doc.Info.Version = defaultVersion
+	}
+	if len(workflow.Events) == 0 {
+		return doc
+	}
+
+	doc.Components = &AsyncAPIComponents{Messages: map[string]AsyncAPIMessage{}}
+	for _, event := range workflow.Events {
+		doc.Components.Messages[event.Name] = newAsyncAPIMessage(event)
+		channel := doc.Channels[event.Type]
+		ref := AsyncAPIRef{Ref: "#/components/messages/" + event.Name}
+		if event.Kind == model.EventKindProduced {
+			channel.Subscribe = addMessage(channel.Subscribe, "produce"+codegen.GoName(event.Type), ref)
+		} else {
+			channel.Publish = addMessage(channel.Publish, "consume"+codegen.GoName(event.Type), ref)
+		}
+		doc.Channels[event.Type] = channel
+	}
+	return doc
+}
+
+func addMessage(operation *AsyncAPIOperation, operationID string, ref AsyncAPIRef) *AsyncAPIOperation {
+	if operation == nil {
+		return &AsyncAPIOperation{OperationID: operationID, Message: AsyncAPIRefs{Ref: ref.Ref}}
+	}
+	// several definitions of the same type, e.g. from different sources
+	if len(operation.Message.Ref) > 0 {
+		operation.Message.OneOf = []AsyncAPIRef{{Ref: operation.Message.Ref}}
+		operation.Message.Ref = ""
+	}
+	operation.Message.OneOf = append(operation.Message.OneOf, ref)
+	return operation
+}
+
+func newAsyncAPIMessage(event model.Event) AsyncAPIMessage {
+	message := AsyncAPIMessage{
+		Name:        event.Name,
+		Title:       event.Type,
+		ContentType: CloudEventsContentType,
+	}
+	if description, ok := event.Metadata[DescriptionKey].(string); ok {
+		message.Summary = description
+	}
+
+	properties := map[string]interface{}{
+		"specversion": map[string]interface{}{"type": "string", "const": "1.0"},
+		"id":          map[string]interface{}{"type": "string"},
+		"type":        map[string]interface{}{"type": "string", "const": event.Type},
+	}
+	required := []string{"specversion", "id", "type", "source"}
+	if len(event.Source) > 0 {
+		properties["source"] = map[string]interface{}{"type": "string", "const": event.Source}
+	} else {
+		properties["source"] = map[string]interface{}{"type": "string"}
+	}
+	for _, correlation := range event.Correlation {
+		attribute := map[string]interface{}{"type": "string"}
+		if len(correlation.ContextAttributeValue) > 0 {
+			attribute["const"] = correlation.ContextAttributeValue
+		} else {
+			attribute["description"] = "correlation attribute"
+		}
+		properties[correlation.ContextAttributeName] = attribute
+		required = append(required, correlation.ContextAttributeName)
+	}
+	sort.Strings(required[4:])
+	message.Headers = map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+	if schema, ok := event.Metadata[codegen.EventDataSchemaKey].(string); ok && len(schema) > 0 {
+		message.Payload = map[string]interface{}{"$ref": schema}
+	}
+	return message
+}
+
+func workflowID(workflow *model.Workflow) string {
+	if len(workflow.ID) > 0 {
+		return workflow.ID
+	}
+	return workflow.Key
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apidoc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var orderWorkflow = &model.Workflow{
+	BaseWorkflow: model.BaseWorkflow{ID: "order", Name: "Order Workflow", Version: "2.1"},
+	Events: []model.Event{
+		{
+			Name: "OrderCreated", Type: "order.created", Source: "/shop",
+			Correlation: []model.Correlation{{ContextAttributeName: "orderid"}},
+			Common:      model.Common{Metadata: model.Metadata{DescriptionKey: "A new order", "dataSchema": "order.json"}},
+		},
+		{Name: "OrderImported", Type: "order.created", Source: "/legacy"},
+		{Name: "OrderShipped", Type: "order.shipped", Kind: model.EventKindProduced},
+	},
+}
+
+func TestNewAsyncAPI(t *testing.T) {
+	doc := NewAsyncAPI(orderWorkflow, AsyncAPIOptions{Servers: map[string]AsyncAPIServer{"broker": {URL: "kafka:9092", Protocol: "kafka"}}})
+	assert.Equal(t, "Order Workflow", doc.Info.Title)
+	assert.Equal(t, "2.1", doc.Info.Version)
+	assert.Len(t, doc.Channels, 2)
+
+	created := doc.Channels["order.created"]
+	assert.Nil(t, created.Subscribe)
+	assert.Equal(t, "consumeOrderCreated", created.Publish.OperationID)
+	assert.Equal(t, []AsyncAPIRef{{Ref: "#/components/messages/OrderCreated"}, {Ref: "#/components/messages/OrderImported"}}, created.Publish.Message.OneOf)
+
+	shipped := doc.Channels["order.shipped"]
+	assert.Nil(t, shipped.Publish)
+	assert.Equal(t, "#/components/messages/OrderShipped", shipped.Subscribe.Message.Ref)
+
+	message := doc.Components.Messages["OrderCreated"]
+	assert.Equal(t, "A new order", message.Summary)
+	assert.Equal(t, CloudEventsContentType, message.ContentType)
+	assert.Equal(t, map[string]interface{}{"$ref": "order.json"}, message.Payload)
+	assert.Equal(t, []string{"specversion", "id", "type", "source", "orderid"}, message.Headers["required"])
+
+	data, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"asyncapi":"2.4.0"`)
+
+	doc = NewAsyncAPI(&model.Workflow{BaseWorkflow: model.BaseWorkflow{ID: "noevents"}}, AsyncAPIOptions{})
+	assert.Equal(t, "noevents", doc.Info.Title)
+	assert.Equal(t, "1.0", doc.Info.Version)
+	assert.Nil(t, doc.Components)
+}
+
+func TestEventCatalog(t *testing.T) {
+	pages, err := EventCatalog(orderWorkflow)
+	assert.NoError(t, err)
+	assert.Len(t, pages, 3)
+	assert.Equal(t, "---\nconsumers:\n- order\nname: OrderCreated\nsummary: A new order\nversion: \"2.1\"\n---\n\n<Schema />\n\nCloudEvent type `order.created` from source `/shop`.\n", string(pages["events/OrderCreated/index.md"]))
+	assert.Contains(t, string(pages["events/OrderShipped/index.md"]), "producers:\n- order\n")
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apidoc
+
+import (
+	"bytes"
+	"path"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"sigs.k8s.io/yaml"
+)
+
+// eventCatalogEvent front matter of an EventCatalog event page
+type eventCatalogEvent struct {
+	Name      string   `json:"name"`
+	Version   string   `json:"version"`
+	Summary   string   `json:"summary,omitempty"`
+	Producers []string `json:"producers,omitempty"`
+	Consumers []string `json:"consumers,omitempty"`
+}
+
+// EventCatalog generates the EventCatalog pages of the events consumed and produced by the given workflow, keyed by
+// their path relative to the catalog root, e.g. 'events/OrderCreated/index.md'. The workflow is listed as the
+// consumer or the producer of each event.
+func EventCatalog(workflow *model.Workflow) (map[string][]byte, error) {
+	pages := map[string][]byte{}
+	version := workflow.Version
+	if len(version) == 0 {
+		version = defaultVersion
+	}
+	for _, event := range workflow.Events {
+		page := eventCatalogEvent{Name: event.Name, Version: version}
+		if description, ok := event.Metadata[DescriptionKey].(string); ok {
+			page.Summary = description
+		}
+		if event.Kind == model.EventKindProduced {
+			page.Producers = []string{workflowID(workflow)}
+		} else {
+			page.Consumers = []string{workflowID(workflow)}
+		}
+		frontMatter, err := yaml.Marshal(page)
+		if err != nil {
+			return nil, err
+		}
+		buf := new(bytes.Buffer)
+		buf.WriteString("---\n")
+		buf.Write(frontMatter)
+		buf.WriteString("---\n\n<Schema />\n\n")
+		buf.WriteString("CloudEvent type `" + event.Type + "`")
+		if len(event.Source) > 0 {
+			buf.WriteString(" from source `" + event.Source + "`")
+		}
+		buf.WriteString(".\n")
+		pages[path.Join("events", event.Name, "index.md")] = buf.Bytes()
+	}
+	return pages, nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apidoc
+
+import (
+	"github.com/serverlessworkflow/sdk-go/v2/codegen"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+const (
+	// OpenAPIVersion version of the generated OpenAPI documents
+	OpenAPIVersion = "3.0.3"
+	// JSONContentType content type of the workflow data
+	JSONContentType = "application/json"
+)
+
+// OpenAPIDocument OpenAPI 3 document describing the HTTP endpoints of a deployed workflow
+type OpenAPIDocument struct {
+	OpenAPI string                     `json:"openapi"`
+	Info    OpenAPIInfo                `json:"info"`
+	Servers []OpenAPIServer            `json:"servers,omitempty"`
+	Paths   map[string]OpenAPIPathItem `json:"paths"`
+}
+
+// OpenAPIInfo ...
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPIServer ...
+type OpenAPIServer struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPIPathItem ...
+type OpenAPIPathItem struct {
+	Post *OpenAPIOperation `json:"post,omitempty"`
+}
+
+// OpenAPIOperation ...
+type OpenAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+}
+
+// OpenAPIRequestBody ...
+type OpenAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIMediaType ...
+type OpenAPIMediaType struct {
+	Schema map[string]interface{} `json:"schema"`
+}
+
+// OpenAPIResponse ...
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIOptions options to generate the OpenAPI document
+type OpenAPIOptions struct {
+	// Version of the API. Default is the workflow version, or '1.0' if the workflow doesn't declare any
+	Version string
+	// Servers the workflow is deployed on
+	Servers []OpenAPIServer
+	// BasePath path of the start endpoint, the event endpoints being below it. Default is '/<workflow id>'
+	BasePath string
+}
+
+// NewOpenAPI generates the OpenAPI document describing the HTTP surface of the given workflow once deployed:
+//
+//	POST <base path>                   starts an instance, the request body typed by the dataInputSchema if any
+//	POST <base path>/events/<event>    sends a consumed event, in CloudEvents structured mode
+//
+// The event endpoints deliver the events the instances wait for, e.g. the callbacks, correlated with the correlation
+// attributes of the event definitions. The event data is typed by the 'dataSchema' metadata of the event definition,
+// if any.
+func NewOpenAPI(workflow *model.Workflow, opts OpenAPIOptions) *OpenAPIDocument {
+	doc := &OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info: OpenAPIInfo{
+			Title:       workflow.Name,
+			Version:     opts.Version,
+			Description: workflow.Description,
+		},
+		Servers: opts.Servers,
+		Paths:   map[string]OpenAPIPathItem{},
+	}
+	if len(doc.Info.Title) == 0 {
+		doc.Info.Title = workflowID(workflow)
+	}
+	if len(doc.Info.Version) == 0 {
+		doc.Info.Version = workflow.Version
+	}
+	if len(doc.Info.Version) == 0 {
+		doc.Info.Version = defaultVersion
+	}
+	basePath := opts.BasePath
+	if len(basePath) == 0 {
+		basePath = "/" + workflowID(workflow)
+	}
+
+	input := map[string]interface{}{"type": "object"}
+	if workflow.DataInputSchema != nil && len(workflow.DataInputSchema.Schema) > 0 {
+		input = map[string]interface{}{"$ref": workflow.DataInputSchema.Schema}
+	}
+	doc.Paths[basePath] = OpenAPIPathItem{Post: &OpenAPIOperation{
+		OperationID: "start" + codegen.GoName(workflowID(workflow)),
+		Summary:     "Starts an instance of the workflow",
+		Tags:        []string{workflowID(workflow)},
+		RequestBody: &OpenAPIRequestBody{Required: true, Content: map[string]OpenAPIMediaType{JSONContentType: {Schema: input}}},
+		Responses: map[string]OpenAPIResponse{
+			"201": {
+				Description: "Instance started",
+				Content: map[string]OpenAPIMediaType{JSONContentType: {Schema: map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"id":           map[string]interface{}{"type": "string", "description": "instance identifier"},
+						"workflowdata": map[string]interface{}{"type": "object", "description": "workflow data"},
+					},
+				}}},
+			},
+			"400": {Description: "Invalid workflow data"},
+		},
+	}}
+
+	for _, event := range workflow.Events {
+		if event.Kind == model.EventKindProduced {
+			continue
+		}
+		message := newAsyncAPIMessage(event)
+		// the CloudEvent context attributes with the data
+		properties := map[string]interface{}{}
+		for name, property := range message.Headers["properties"].(map[string]interface{}) {
+			properties[name] = property
+		}
+		data := map[string]interface{}{}
+		if len(message.Payload) > 0 {
+			data = message.Payload
+		}
+		properties["data"] = data
+		schema := map[string]interface{}{"type": "object", "properties": properties, "required": message.Headers["required"]}
+
+		doc.Paths[basePath+"/events/"+event.Name] = OpenAPIPathItem{Post: &OpenAPIOperation{
+			OperationID: "send" + codegen.GoName(event.Name),
+			Summary:     "Sends the " + event.Type + " event to the workflow instances waiting for it",
+			Description: message.Summary,
+			Tags:        []string{workflowID(workflow)},
+			RequestBody: &OpenAPIRequestBody{Required: true, Content: map[string]OpenAPIMediaType{CloudEventsContentType: {Schema: schema}}},
+			Responses: map[string]OpenAPIResponse{
+				"202": {Description: "Event accepted"},
+				"400": {Description: "Invalid event"},
+			},
+		}}
+	}
+	return doc
+}
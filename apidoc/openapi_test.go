@@ -0,0 +1,59 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apidoc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOpenAPI(t *testing.T) {
+	workflow := orderWorkflow.DeepCopy()
+	workflow.DataInputSchema = &model.DataInputSchema{Schema: "schemas/order-input.json"}
+	doc := NewOpenAPI(workflow, OpenAPIOptions{Servers: []OpenAPIServer{{URL: "https://order.example.com"}}})
+	assert.Equal(t, "Order Workflow", doc.Info.Title)
+	assert.Equal(t, "2.1", doc.Info.Version)
+	assert.Len(t, doc.Paths, 3)
+
+	start := doc.Paths["/order"].Post
+	require.NotNil(t, start)
+	assert.Equal(t, "startOrder", start.OperationID)
+	assert.Equal(t, map[string]interface{}{"$ref": "schemas/order-input.json"}, start.RequestBody.Content[JSONContentType].Schema)
+	assert.Contains(t, start.Responses, "201")
+
+	created := doc.Paths["/order/events/OrderCreated"].Post
+	require.NotNil(t, created)
+	assert.Equal(t, "sendOrderCreated", created.OperationID)
+	assert.Equal(t, "A new order", created.Description)
+	schema := created.RequestBody.Content[CloudEventsContentType].Schema
+	assert.Equal(t, []string{"specversion", "id", "type", "source", "orderid"}, schema["required"])
+	properties := schema["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "order.json"}, properties["data"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "const": "order.created"}, properties["type"])
+	assert.NotContains(t, doc.Paths, "/order/events/OrderShipped")
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"openapi":"3.0.3"`)
+
+	doc = NewOpenAPI(&model.Workflow{BaseWorkflow: model.BaseWorkflow{ID: "noevents"}}, OpenAPIOptions{BasePath: "/api/noevents"})
+	assert.Equal(t, "1.0", doc.Info.Version)
+	require.Len(t, doc.Paths, 1)
+	assert.Equal(t, map[string]interface{}{"type": "object"}, doc.Paths["/api/noevents"].Post.RequestBody.Content[JSONContentType].Schema)
+}
@@ -0,0 +1,62 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build js && wasm
+// +build js,wasm
+
+// Command sdk-wasm is the WebAssembly build of the validation, conversion and diagrams of the SDK for the browsers,
+// loaded by serverlessworkflow.js. It defines the global serverlessWorkflow object, whose validate, convert and graph
+// functions take the workflow and the JSON encoded options and return the JSON encoded wasm.Response.
+//
+//	GOOS=js GOARCH=wasm go build -o serverlessworkflow.wasm ./cmd/sdk-wasm
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/serverlessworkflow/sdk-go/v2/wasm"
+)
+
+func main() {
+	js.Global().Set("serverlessWorkflow", js.ValueOf(map[string]interface{}{
+		"validate": js.FuncOf(func(_ js.Value, args []js.Value) interface{} {
+			return encode(wasm.Validate(arg(args, 0)))
+		}),
+		"convert": js.FuncOf(func(_ js.Value, args []js.Value) interface{} {
+			return encode(wasm.Convert(arg(args, 0), arg(args, 1)))
+		}),
+		"graph": js.FuncOf(func(_ js.Value, args []js.Value) interface{} {
+			return encode(wasm.Graph(arg(args, 0), arg(args, 1)))
+		}),
+	}))
+	// the functions are called until the page is closed
+	select {}
+}
+
+// arg returns the string argument at the index, empty if missing
+func arg(args []js.Value, index int) string {
+	if index >= len(args) || args[index].Type() != js.TypeString {
+		return ""
+	}
+	return args[index].String()
+}
+
+func encode(response *wasm.Response) interface{} {
+	data, err := json.Marshal(response)
+	if err != nil {
+		data, _ = json.Marshal(&wasm.Response{Results: []wasm.ValidationResult{}, Error: err.Error()})
+	}
+	return string(data)
+}
@@ -0,0 +1,43 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Loads the WebAssembly build of the SDK, the wasm_exec.js of the Go distribution having been loaded first:
+//
+//   const sdk = await loadServerlessWorkflow("serverlessworkflow.wasm");
+//   const { valid, results } = sdk.validate(source);
+//
+// validate returns the validation results of a JSON or YAML workflow, with their path, message, line and column.
+// convert and graph validate the workflow too, and return the workflow converted or the diagram as the output, see
+// wasm.ConvertOptions and wasm.GraphOptions for their options.
+async function loadServerlessWorkflow(url) {
+  const go = new Go();
+  const response = fetch(url || "serverlessworkflow.wasm");
+  const { instance } = WebAssembly.instantiateStreaming
+    ? await WebAssembly.instantiateStreaming(response, go.importObject)
+    : await WebAssembly.instantiate(await (await response).arrayBuffer(), go.importObject);
+  // run never returns, the Go program keeps serving the calls
+  go.run(instance);
+
+  const sdk = globalThis.serverlessWorkflow;
+  const call = (name, source, options) => JSON.parse(sdk[name](String(source), JSON.stringify(options || {})));
+  return {
+    validate: (source) => call("validate", source),
+    convert: (source, options) => call("convert", source, options),
+    graph: (source, options) => call("graph", source, options),
+  };
+}
+
+if (typeof module !== "undefined" && module.exports) {
+  module.exports = { loadServerlessWorkflow };
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/serverlessworkflow/sdk-go/v2/annotation"
+)
+
+// output formats of the commands reporting problems in workflow files
+const (
+	formatText   = "text"
+	formatGitHub = "github"
+	formatGitLab = "gitlab"
+)
+
+func validFormat(format string) bool {
+	return format == formatText || format == formatGitHub || format == formatGitLab
+}
+
+// writeAnnotations writes the problems found in the workflow files in the given format. The GitHub and GitLab formats
+// locate them in the files.
+func writeAnnotations(w io.Writer, format string, annotations []annotation.Annotation) error {
+	if format == formatText {
+		for _, a := range annotations {
+			if _, err := fmt.Fprintf(w, "%s: %s\n", a.File, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	locators := map[string]*annotation.Locator{}
+	for i, a := range annotations {
+		locator, ok := locators[a.File]
+		if !ok {
+			// files that can't be read have no positions, the annotations are reported on the whole file
+			source, _ := ioutil.ReadFile(a.File)
+			locator = annotation.NewLocator(source)
+			locators[a.File] = locator
+		}
+		annotations[i].Line, annotations[i].Column = locator.Locate(a.Path)
+	}
+	if format == formatGitHub {
+		return annotation.GitHub(w, annotations)
+	}
+	report, err := annotation.GitLab(annotations)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(report))
+	return err
+}
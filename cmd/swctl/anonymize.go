@@ -0,0 +1,83 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+	"github.com/serverlessworkflow/sdk-go/v2/transform"
+)
+
+func init() {
+	registerCommand(&command{name: "anonymize", summary: "strip the names, secrets, URLs and data of a workflow to attach it to a bug report", run: runAnonymize})
+}
+
+func runAnonymize(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("anonymize", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("o", "", "output file. Default is the standard output, in the format of the input")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl anonymize [flags] <file>")
+		fmt.Fprintln(stderr, "The states, functions and events are renamed to generic names, the metadata, secrets, URLs and inline data are stripped.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	input := flags.Arg(0)
+	format, err := serializer.FormatFromPath(input)
+	if len(*output) > 0 {
+		format, err = serializer.FormatFromPath(*output)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitUsage
+	}
+
+	workflow, err := parser.FromFile(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, fileError(input, err))
+		return exitError
+	}
+	anonymized, err := transform.Anonymize(workflow)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, err)
+		return exitError
+	}
+	data, err := serializer.Marshal(anonymized, serializer.CanonicalOptions(format))
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, err)
+		return exitError
+	}
+	if len(*output) == 0 {
+		_, err = stdout.Write(data)
+	} else {
+		err = ioutil.WriteFile(*output, data, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunAnonymize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	input := filepath.Join(dir, "order.sw.yaml")
+	require.NoError(t, ioutil.WriteFile(input, []byte(`id: order
+name: Order
+version: '1.0'
+specVersion: '0.7'
+start: Pay
+functions:
+- name: charge
+  operation: https://payments.internal/openapi.json#charge
+states:
+- name: Pay
+  type: operation
+  actions:
+  - functionRef:
+      refName: charge
+      arguments:
+        card: 4242-4242
+  end: true
+`), 0600))
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitOK, run([]string{"anonymize", input}, stdout, stderr), stderr.String())
+	assert.Contains(t, stdout.String(), "name: state1")
+	assert.NotContains(t, stdout.String(), "payments.internal")
+	assert.NotContains(t, stdout.String(), "4242")
+
+	output := filepath.Join(dir, "anonymized.json")
+	assert.Equal(t, exitOK, run([]string{"anonymize", "-o", output, input}, stdout, stderr), stderr.String())
+	workflow, err := parser.FromFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, "state1", workflow.Start.StateName)
+	assert.Equal(t, "function1", workflow.Functions[0].Name)
+
+	assert.Equal(t, exitUsage, run([]string{"anonymize"}, stdout, stderr))
+	assert.Equal(t, exitError, run([]string{"anonymize", filepath.Join(dir, "missing.sw.yaml")}, stdout, stderr))
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/profile"
+)
+
+func init() {
+	registerCommand(&command{name: "compat", summary: "check workflows fit the capability profile of a runtime", run: runCompat})
+}
+
+func runCompat(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("compat", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	profilePath := flags.String("profile", "", "runtime capability profile file (required)")
+	include := flags.String("include", "", "file name pattern of the workflows to check in directories, e.g. '*.sw.yaml'")
+	quiet := flags.Bool("q", false, "only print the incompatibilities")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl compat -profile <file> [flags] <file|dir>...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() == 0 || len(*profilePath) == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	runtime, err := profile.Load(*profilePath)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	files, err := collectFiles(flags.Args(), *include)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+
+	incompatible := 0
+	for _, file := range files {
+		workflow, err := parser.FromFile(file)
+		if err != nil {
+			fmt.Fprintf(stdout, "%s: %v\n", file, fileError(file, err))
+			incompatible++
+			continue
+		}
+		incompatibilities := profile.Check(workflow, runtime)
+		for _, incompatibility := range incompatibilities {
+			fmt.Fprintf(stdout, "%s: %s\n", file, incompatibility)
+		}
+		if len(incompatibilities) > 0 {
+			incompatible++
+		}
+	}
+	if !*quiet {
+		fmt.Fprintf(stdout, "%d file(s) checked, %d incompatible\n", len(files), incompatible)
+	}
+	if incompatible > 0 {
+		return exitError
+	}
+	return exitOK
+}
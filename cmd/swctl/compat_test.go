@@ -0,0 +1,48 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCompat(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	profile := filepath.Join(dir, "engine.yaml")
+	require.NoError(t, ioutil.WriteFile(profile, []byte("name: engine\nstateTypes: [switch, operation]\n"), 0600))
+	file := "../../parser/testdata/workflows/applicationrequest.json"
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitOK, run([]string{"compat", "-profile", profile, file}, stdout, stderr))
+	assert.Equal(t, "1 file(s) checked, 0 incompatible\n", stdout.String())
+
+	require.NoError(t, ioutil.WriteFile(profile, []byte("name: engine\nstateTypes: [switch]\n"), 0600))
+	stdout.Reset()
+	assert.Equal(t, exitError, run([]string{"compat", "-profile", profile, "-q", file}, stdout, stderr))
+	assert.Equal(t, file+": states[1].type: state type operation is not supported by engine\n"+
+		file+": states[2].type: state type operation is not supported by engine\n", stdout.String())
+
+	assert.Equal(t, exitError, run([]string{"compat", "-profile", filepath.Join(dir, "missing.yaml"), file}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"compat", file}, stdout, stderr))
+}
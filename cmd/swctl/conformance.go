@@ -0,0 +1,84 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/conformance"
+)
+
+func init() {
+	registerCommand(&command{name: "conformance", summary: "run the specification examples through the SDK", run: runConformance})
+}
+
+func runConformance(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("conformance", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	specVersion := flags.String("spec-version", "0.8", "specification version of the examples, one of "+strings.Join(suiteVersions(), ", "))
+	format := flags.String("format", "text", "output format, text or json")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl conformance [flags] [example documents...]")
+		fmt.Fprintln(stderr, "Parses, validates and round trips the examples of the specification repository, or those of the given")
+		fmt.Fprintln(stderr, "documents, e.g. a vendored copy: markdown documents or workflow files. Exits with code 1 if an example fails.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	suite, ok := conformance.Suites[*specVersion]
+	if *format != "text" && *format != "json" || (!ok && flags.NArg() == 0) {
+		flags.Usage()
+		return exitUsage
+	}
+	if flags.NArg() > 0 {
+		suite = conformance.Suite{SpecVersion: *specVersion, URIs: flags.Args()}
+	}
+	report, err := conformance.Run(suite)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+
+	if *format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitError
+		}
+		fmt.Fprintln(stdout, string(data))
+	} else {
+		fmt.Fprint(stdout, report.Text())
+	}
+	if !report.Compliant() {
+		return exitError
+	}
+	return exitOK
+}
+
+// suiteVersions versions of the specification with examples
+func suiteVersions() []string {
+	versions := make([]string, 0, len(conformance.Suites))
+	for version := range conformance.Suites {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
@@ -0,0 +1,40 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunConformance(t *testing.T) {
+	examples := "../../conformance/testdata/0.8/README.md"
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitError, run([]string{"conformance", examples}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "PASS hello-world-example.json\n")
+	assert.Contains(t, stdout.String(), "specification 0.8: 3/4 examples passed\n")
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"conformance", "-spec-version", "0.7", "-format", "json",
+		"../../conformance/testdata/0.7/provisionorder.sw.yaml"}, stdout, stderr))
+	assert.Contains(t, stdout.String(), `"specVersion": "0.7"`)
+
+	assert.Equal(t, exitError, run([]string{"conformance", "missing.md"}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"conformance", "-spec-version", "0.1"}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"conformance", "-format", "xml", examples}, stdout, stderr))
+}
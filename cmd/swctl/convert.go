@@ -0,0 +1,102 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+func init() {
+	registerCommand(&command{name: "convert", summary: "convert a workflow between JSON and YAML", run: runConvert})
+}
+
+func runConvert(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("o", "", "output file. Default is the standard output")
+	format := flags.String("format", "", "output format, json or yaml. Default is the output file format or, if writing to the standard output, the other format than the input")
+	shorthand := flags.Bool("shorthand", false, "write the short form of the definitions that support it")
+	normalize := flags.Bool("normalize", false, "omit the properties set to their default value")
+	specVersion := flags.String("spec-version", "", "target specification version. Default is the workflow version")
+	indent := flags.Int("indent", 2, "number of spaces used to indent JSON")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl convert [flags] <file>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	input := flags.Arg(0)
+
+	opts := serializer.Options{
+		Format:      serializer.Format(*format),
+		Indent:      *indent,
+		Shorthand:   *shorthand,
+		Normalize:   *normalize,
+		SpecVersion: *specVersion,
+	}
+	if len(opts.Format) == 0 {
+		var err error
+		if opts.Format, err = outputFormat(input, *output); err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitUsage
+		}
+	}
+	workflow, err := parser.FromFile(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, fileError(input, err))
+		return exitError
+	}
+	data, err := serializer.Marshal(workflow, opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, err)
+		return exitError
+	}
+	if len(*output) == 0 {
+		_, err = stdout.Write(data)
+	} else {
+		err = ioutil.WriteFile(*output, data, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// outputFormat the format of the output file or, if none, the other format than the input
+func outputFormat(input, output string) (serializer.Format, error) {
+	if len(output) > 0 {
+		return serializer.FormatFromPath(output)
+	}
+	format, err := serializer.FormatFromPath(input)
+	if err != nil {
+		return "", err
+	}
+	if format == serializer.FormatJSON {
+		return serializer.FormatYAML, nil
+	}
+	return serializer.FormatJSON, nil
+}
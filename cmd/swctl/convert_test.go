@@ -0,0 +1,56 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunConvert(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	code := run([]string{"convert", "-shorthand", "-normalize", "../../parser/testdata/workflows/eventbasedswitch.sw.json"}, stdout, stderr)
+	assert.Equal(t, exitOK, code, stderr.String())
+	assert.Contains(t, stdout.String(), "id: eventbasedswitch\n")
+	assert.NotContains(t, stdout.String(), "expressionLang")
+	_, err := parser.FromYAMLSource(stdout.Bytes())
+	assert.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "swctl")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "greetings.json")
+	stdout.Reset()
+	code = run([]string{"convert", "-o", output, "../../parser/testdata/workflows/greetings.sw.yaml"}, stdout, stderr)
+	assert.Equal(t, exitOK, code, stderr.String())
+	assert.Empty(t, stdout.String())
+	_, err = parser.FromFile(output)
+	assert.NoError(t, err)
+
+	code = run([]string{"convert", "-format", "json", "-indent", "4", "../../parser/testdata/workflows/greetings.sw.yaml"}, stdout, stderr)
+	assert.Equal(t, exitOK, code)
+	assert.Contains(t, stdout.String(), "{\n    \"id\": \"greeting\"")
+
+	assert.Equal(t, exitError, run([]string{"convert", "-spec-version", "0.8", "../../parser/testdata/workflows/greetings.sw.yaml"}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"convert", "-o", "out.toml", "../../parser/testdata/workflows/greetings.sw.yaml"}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"convert"}, stdout, stderr))
+	assert.Equal(t, exitError, run([]string{"convert", "missing.json"}, stdout, stderr))
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/serverlessworkflow/sdk-go/v2/coverage"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+)
+
+func init() {
+	registerCommand(&command{name: "coverage", summary: "report the coverage of a workflow by execution traces", run: runCoverage})
+}
+
+func runCoverage(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("coverage", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	minimum := flags.Float64("min", 0, "minimum branch coverage percentage, the exit status is 1 below it")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl coverage [flags] <workflow file> <trace file>...")
+		fmt.Fprintln(stderr, "Trace files hold JSON traces, one per line, or arrays of traces:")
+		fmt.Fprintln(stderr, `  {"name": "adult", "steps": [{"state": "Check", "condition": "adult"}, {"state": "Start"}], "ended": true}`)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() < 2 {
+		flags.Usage()
+		return exitUsage
+	}
+	input := flags.Arg(0)
+	workflow, err := parser.FromFile(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, fileError(input, err))
+		return exitError
+	}
+	var traces []coverage.Trace
+	for _, file := range flags.Args()[1:] {
+		read, err := readTraces(file)
+		if err != nil {
+			fmt.Fprintf(stderr, "swctl: %s: %v\n", file, err)
+			return exitError
+		}
+		traces = append(traces, read...)
+	}
+	report := coverage.Analyze(workflow, traces)
+	fmt.Fprint(stdout, coverage.Text(report))
+	if 100*report.BranchRatio() < *minimum {
+		fmt.Fprintf(stderr, "swctl: %s: branch coverage %.1f%% is below %.1f%%\n", input, 100*report.BranchRatio(), *minimum)
+		return exitError
+	}
+	return exitOK
+}
+
+func readTraces(file string) ([]coverage.Trace, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return coverage.ReadTraces(f)
+}
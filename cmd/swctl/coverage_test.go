@@ -0,0 +1,49 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCoverage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	traces := filepath.Join(dir, "traces.jsonl")
+	require.NoError(t, ioutil.WriteFile(traces, []byte(`{"name": "adult", "steps": [{"state": "CheckApplication"}, {"state": "StartApplication"}], "ended": true}
+{"name": "minor", "steps": [{"state": "CheckApplication", "condition": "{{ $.applicants[?(@.age < 18)] }}"}, {"state": "RejectApplication"}], "ended": true}
+`), 0600))
+	workflow := "../../parser/testdata/workflows/applicationrequest.json"
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitOK, run([]string{"coverage", workflow, traces}, stdout, stderr))
+	assert.Equal(t, `states: 3/3 (100.0%)
+branches: 5/6 (83.3%)
+condition default of state CheckApplication is never taken
+`, stdout.String())
+
+	assert.Equal(t, exitError, run([]string{"coverage", "-min", "90", workflow, traces}, stdout, stderr))
+	assert.Contains(t, stderr.String(), "branch coverage 83.3% is below 90.0%")
+	assert.Equal(t, exitError, run([]string{"coverage", workflow, filepath.Join(dir, "missing.jsonl")}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"coverage", workflow}, stdout, stderr))
+}
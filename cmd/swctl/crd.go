@@ -0,0 +1,81 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/serverlessworkflow/sdk-go/v2/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+func init() {
+	registerCommand(&command{name: "crd", summary: "generate the CustomResourceDefinition of a resource embedding a workflow", run: runCRD})
+}
+
+func runCRD(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("crd", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var opts kubernetes.CRDOptions
+	flags.StringVar(&opts.Group, "group", "", "API group of the resource, e.g. flows.example.com")
+	flags.StringVar(&opts.Kind, "kind", "", "kind of the resource, e.g. Flow")
+	flags.StringVar(&opts.Version, "version", "", "API version of the resource. Default is v1alpha1")
+	flags.StringVar(&opts.Plural, "plural", "", "plural name of the resource. Default is the lower case kind followed by an 's'")
+	flags.StringVar(&opts.Field, "field", "", "property of the resource spec holding the workflow. Default is the whole spec")
+	flags.BoolVar(&opts.Namespaced, "namespaced", true, "whether the resources are namespaced, otherwise cluster scoped")
+	schemaOnly := flags.Bool("schema", false, "write only the OpenAPI v3 schema of the workflow")
+	output := flags.String("o", "", "output file. Default is the standard output")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl crd [flags]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	var document interface{}
+	if *schemaOnly {
+		document = kubernetes.WorkflowSchema()
+	} else {
+		crd, err := kubernetes.WorkflowCRD(opts)
+		if err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitUsage
+		}
+		document = crd
+	}
+	data, err := yaml.Marshal(document)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	if len(*output) == 0 {
+		_, err = stdout.Write(data)
+	} else {
+		err = ioutil.WriteFile(*output, data, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
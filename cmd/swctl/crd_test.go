@@ -0,0 +1,44 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/kubernetes"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRunCRD(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	assert.Equal(t, exitOK, run([]string{"crd", "-group", "flows.example.com", "-kind", "Flow", "-field", "flow"}, stdout, stderr))
+	crd := &kubernetes.CustomResourceDefinition{}
+	assert.NoError(t, yaml.UnmarshalStrict(stdout.Bytes(), crd))
+	assert.Equal(t, "flows.flows.example.com", crd.Name)
+	assert.Equal(t, "Namespaced", crd.Spec.Scope)
+	assert.Contains(t, crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"].Properties["flow"].Properties, "states")
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"crd", "-schema"}, stdout, stderr))
+	schema := &kubernetes.JSONSchemaProps{}
+	assert.NoError(t, yaml.UnmarshalStrict(stdout.Bytes(), schema))
+	assert.Equal(t, kubernetes.WorkflowSchema(), *schema)
+
+	assert.Equal(t, exitUsage, run([]string{"crd", "-kind", "Flow"}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"crd", "extra"}, stdout, stderr))
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/workspace"
+)
+
+func init() {
+	registerCommand(&command{name: "deps", summary: "show the subflow dependencies between workflows", run: runDeps})
+}
+
+func runDeps(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("deps", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("f", "text", "output format, text or dot")
+	include := flags.String("include", "", "file name pattern of the workflows to load from directories, e.g. '*.sw.yaml'")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl deps [flags] <file|dir>...")
+		fmt.Fprintln(stderr, "Subflow cycles, missing and version mismatched dependencies are printed to the standard error and the exit status is 1.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() == 0 || (*format != "text" && *format != "dot") {
+		flags.Usage()
+		return exitUsage
+	}
+	files, err := collectFiles(flags.Args(), *include)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+
+	status := exitOK
+	w, err := workspace.Load(files)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		status = exitError
+	}
+	graph := w.DependencyGraph()
+	if *format == "dot" {
+		stdout.Write(graph.DOT())
+	} else {
+		for _, dependency := range graph.Dependencies {
+			fmt.Fprintf(stdout, "%s -> %s: %s\n", dependency.From.Key(), dependencyTarget(dependency), dependency.Path)
+		}
+	}
+	for _, problem := range graph.Problems() {
+		fmt.Fprintf(stderr, "%s: %s\n", problem.Dependency.From.File, problem)
+		status = exitError
+	}
+	for _, cycle := range graph.Cycles() {
+		fmt.Fprintf(stderr, "%s: %s: subflow cycle %s\n", cycle[0].From.File, cycle[0].Path, cycle)
+		status = exitError
+	}
+	return status
+}
+
+// dependencyTarget describes the workflows the dependency resolves to, or the reference if it's missing
+func dependencyTarget(dependency *workspace.Dependency) string {
+	if len(dependency.To) == 0 {
+		ref := dependency.Ref.WorkflowID
+		if len(dependency.Ref.Version) > 0 {
+			ref += "@" + dependency.Ref.Version
+		}
+		return ref + " (missing)"
+	}
+	keys := make([]string, len(dependency.To))
+	for i, workflow := range dependency.To {
+		keys[i] = workflow.Key()
+	}
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunDeps(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	write := func(name, source string) {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(source), 0600))
+	}
+	write("order.sw.yaml", `id: order
+name: Order
+version: '1.0'
+specVersion: '0.7'
+start: Pay
+states:
+- name: Pay
+  type: operation
+  actions:
+  - subFlowRef:
+      workflowId: payment
+      version: '1.0'
+  - subFlowRef: shipping
+  end: true
+`)
+	write("payment.sw.yaml", `id: payment
+name: Payment
+version: '1.0'
+specVersion: '0.7'
+start: Charge
+states:
+- name: Charge
+  type: inject
+  data:
+    charged: true
+  end: true
+`)
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitError, run([]string{"deps", dir}, stdout, stderr))
+	assert.Equal(t, `order@1.0 -> payment@1.0: states[0].actions[0].subFlowRef
+order@1.0 -> shipping (missing): states[0].actions[1].subFlowRef
+`, stdout.String())
+	assert.Equal(t, filepath.Join(dir, "order.sw.yaml")+": states[0].actions[1].subFlowRef: workflow shipping is not in the workspace\n", stderr.String())
+
+	stdout.Reset()
+	assert.Equal(t, exitError, run([]string{"deps", "-f", "dot", dir}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "digraph workspace {\n")
+
+	assert.Equal(t, exitOK, run([]string{"deps", filepath.Join(dir, "payment.sw.yaml")}, stdout, stderr))
+
+	stderr.Reset()
+	write("shipping.sw.yaml", `id: shipping
+name: Shipping
+version: '1.0'
+specVersion: '0.7'
+start: Ship
+states:
+- name: Ship
+  type: operation
+  actions:
+  - subFlowRef: order
+  end: true
+`)
+	assert.Equal(t, exitError, run([]string{"deps", dir}, stdout, stderr))
+	assert.Equal(t, filepath.Join(dir, "order.sw.yaml")+": states[0].actions[1].subFlowRef: subflow cycle order@1.0 -> shipping@1.0 -> order@1.0\n", stderr.String())
+	assert.Equal(t, exitUsage, run([]string{"deps", "-f", "svg", dir}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"deps"}, stdout, stderr))
+}
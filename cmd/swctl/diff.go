@@ -0,0 +1,80 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/serverlessworkflow/sdk-go/v2/diff"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+)
+
+func init() {
+	registerCommand(&command{name: "diff", summary: "show the changes between two workflow versions", run: runDiff})
+}
+
+func runDiff(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "text", "output format, text or json")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl diff [flags] <old> <new>")
+		fmt.Fprintln(stderr, "Exits with code 1 if the workflows differ.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 2 || (*format != "text" && *format != "json") {
+		flags.Usage()
+		return exitUsage
+	}
+	from, err := parser.FromFile(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", flags.Arg(0), fileError(flags.Arg(0), err))
+		return exitUsage
+	}
+	to, err := parser.FromFile(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", flags.Arg(1), fileError(flags.Arg(1), err))
+		return exitUsage
+	}
+	changes, err := diff.Workflows(from, to)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitUsage
+	}
+
+	if *format == "json" {
+		if changes == nil {
+			changes = []diff.Change{}
+		}
+		data, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitUsage
+		}
+		fmt.Fprintln(stdout, string(data))
+	} else {
+		fmt.Fprint(stdout, diff.Text(changes))
+	}
+	if len(changes) > 0 {
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunDiff(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	exclusive := "../../parser/testdata/workflows/eventbasedgreetingexclusive.sw.json"
+	nonExclusive := "../../parser/testdata/workflows/eventbasedgreetingnonexclusive.sw.json"
+
+	assert.Equal(t, exitOK, run([]string{"diff", exclusive, exclusive}, stdout, stderr))
+	assert.Empty(t, stdout.String())
+
+	assert.Equal(t, exitError, run([]string{"diff", exclusive, nonExclusive}, stdout, stderr))
+	assert.Equal(t, `~ id: "eventbasedgreetingexclusive" -> "eventbasedgreetingnonexclusive"
++ states[Greet].exclusive: false
++ states[Greet].onEvents[0].eventRefs[1]: "GreetingEvent2"
+- states[Greet].onEvents[1]: {actions, eventDataFilter, eventRefs}
+`, stdout.String())
+
+	stdout.Reset()
+	assert.Equal(t, exitError, run([]string{"diff", "-format", "json", exclusive, nonExclusive}, stdout, stderr))
+	assert.Contains(t, stdout.String(), `"path": "states[Greet].exclusive"`)
+
+	assert.Equal(t, exitUsage, run([]string{"diff", exclusive}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"diff", "-format", "xml", exclusive, nonExclusive}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"diff", exclusive, "missing.json"}, stdout, stderr))
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunDowngrade(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "greeting.sw.yaml")
+	assert.NoError(t, ioutil.WriteFile(file, []byte(`id: greeting
+specVersion: '0.8'
+start: Greet
+states:
+- name: Greet
+  type: sleep
+  duration: PT1S
+  end:
+    continueAs: greeting
+`), 0600))
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitError, run([]string{"downgrade", file}, stdout, stderr))
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, file+": states[0].end.continueAs: continuing as a new execution is not supported by 0.7, the workflow ends\n"+
+		"swctl: "+file+": 1 features not supported by 0.7, set -lossy to remove them\n", stderr.String())
+
+	stderr.Reset()
+	assert.Equal(t, exitOK, run([]string{"downgrade", "-lossy", "-to", "0.6", "-changes", file}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "specVersion: \"0.6\"\n")
+	assert.Contains(t, stdout.String(), "  type: delay\n  timeDelay: PT1S\n  end: true\n")
+	assert.Contains(t, stderr.String(), file+": states[0]: sleep state replaced by a delay state, its duration by the timeDelay (0.6)\n")
+
+	assert.Equal(t, exitOK, run([]string{"downgrade", "-lossy", "-w", file}, stdout, stderr))
+	data, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "specVersion: \"0.7\"\n")
+
+	assert.Equal(t, exitError, run([]string{"downgrade", "-to", "0.8", file}, stdout, stderr))
+	assert.Contains(t, stderr.String(), "downgrade from spec version 0.7 to 0.8 is not supported")
+	assert.Equal(t, exitUsage, run([]string{"downgrade", "-w", "-o", "out.yaml", file}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"downgrade", "workflow.txt"}, stdout, stderr))
+}
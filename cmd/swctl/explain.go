@@ -0,0 +1,52 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/serverlessworkflow/sdk-go/v2/explain"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+)
+
+func init() {
+	registerCommand(&command{name: "explain", summary: "describe a workflow in plain English", run: runExplain})
+}
+
+func runExplain(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("explain", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl explain <file>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	input := flags.Arg(0)
+	workflow, err := parser.FromFile(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, fileError(input, err))
+		return exitError
+	}
+	fmt.Fprint(stdout, explain.Workflow(workflow))
+	return exitOK
+}
@@ -0,0 +1,33 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunExplain(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	assert.Equal(t, exitOK, run([]string{"explain", "../../parser/testdata/workflows/applicationrequest.json"}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "Starts at CheckApplication.\n")
+	assert.Contains(t, stdout.String(), "RejectApplication (operation): Call sendRejectionEmailFunction, then terminate the workflow.\n")
+
+	assert.Equal(t, exitError, run([]string{"explain", "missing.sw.json"}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"explain"}, stdout, stderr))
+}
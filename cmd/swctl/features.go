@@ -0,0 +1,114 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"text/tabwriter"
+
+	"github.com/serverlessworkflow/sdk-go/v2/analysis"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+func init() {
+	registerCommand(&command{name: "features", summary: "report the specification features used by workflows", run: runFeatures})
+}
+
+// featuresReport features of the workflow files and their adoption, the JSON output of the features command
+type featuresReport struct {
+	Workflows []workflowFeatures  `json:"workflows"`
+	Adoption  []analysis.Adoption `json:"adoption"`
+}
+
+type workflowFeatures struct {
+	File string `json:"file"`
+	*analysis.FeatureReport
+}
+
+func runFeatures(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("features", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "text", "output format, text or json")
+	include := flags.String("include", "", "file name pattern of the workflows to load from directories, e.g. '*.sw.yaml'")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl features [flags] <file|dir>...")
+		fmt.Fprintln(stderr, "Reports the state types, function types, auth schemes, extensions, shorthands and properties used by the")
+		fmt.Fprintln(stderr, "workflows, the features of a single workflow or the number of workflows using each feature.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() == 0 || (*format != "text" && *format != "json") {
+		flags.Usage()
+		return exitUsage
+	}
+	files, err := collectFiles(flags.Args(), *include)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+
+	status := exitOK
+	report := featuresReport{Workflows: []workflowFeatures{}}
+	var reports []*analysis.FeatureReport
+	for _, file := range files {
+		features, err := fileFeatures(file)
+		if err != nil {
+			fmt.Fprintf(stderr, "swctl: %s: %v\n", file, err)
+			status = exitError
+			continue
+		}
+		report.Workflows = append(report.Workflows, workflowFeatures{File: file, FeatureReport: features})
+		reports = append(reports, features)
+	}
+	report.Adoption = analysis.FeatureAdoption(reports)
+
+	if *format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitError
+		}
+		fmt.Fprintln(stdout, string(data))
+	} else if len(files) == 1 && len(reports) == 1 {
+		fmt.Fprint(stdout, reports[0].Text())
+	} else {
+		w := tabwriter.NewWriter(stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintf(w, "CATEGORY\tFEATURE\tWORKFLOWS\tUSES\n")
+		for _, adoption := range report.Adoption {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", adoption.Category, adoption.Name, adoption.Workflows, adoption.Uses)
+		}
+		w.Flush()
+	}
+	return status
+}
+
+// fileFeatures reports the features used by the workflow file
+func fileFeatures(file string) (*analysis.FeatureReport, error) {
+	format, err := serializer.FormatFromPath(file)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return analysis.Features(data, format)
+}
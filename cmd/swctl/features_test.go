@@ -0,0 +1,74 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunFeatures(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	write := func(name, source string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(source), 0600))
+		return path
+	}
+	greeting := write("greeting.sw.yaml", `id: greeting
+specVersion: '0.7'
+start: Greet
+states:
+- name: Greet
+  type: inject
+  data:
+    greeting: Hello
+  end: true
+`)
+	write("order.sw.json", `{"id": "order", "specVersion": "0.7", "start": {"stateName": "Wait"},
+"states": [{"name": "Wait", "type": "sleep", "duration": "PT1S", "end": true}]}`)
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitOK, run([]string{"features", greeting}, stdout, stderr), stderr.String())
+	assert.Contains(t, stdout.String(), "stateType       inject  1\n")
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"features", dir}, stdout, stderr), stderr.String())
+	assert.Contains(t, stdout.String(), "CATEGORY")
+	assert.Contains(t, stdout.String(), "specVersion     0.7      2          2\n")
+	assert.Contains(t, stdout.String(), "stateType       sleep    1          1\n")
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"features", "-format", "json", dir}, stdout, stderr), stderr.String())
+	var report featuresReport
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+	require.Len(t, report.Workflows, 2)
+	assert.Equal(t, greeting, report.Workflows[0].File)
+	assert.True(t, report.Workflows[1].Uses("stateType", "sleep"))
+
+	write("invalid.sw.json", `[]`)
+	assert.Equal(t, exitError, run([]string{"features", dir}, stdout, stderr))
+	assert.Contains(t, stderr.String(), "invalid.sw.json: workflow document must be an object")
+	assert.Equal(t, exitUsage, run([]string{"features", "-format", "xml", dir}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"features"}, stdout, stderr))
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// workflowExtensions extensions of the files considered workflow definitions when walking directories
+var workflowExtensions = []string{".json", ".yaml", ".yml"}
+
+// collectFiles expands the given paths into the workflow files to process. Directories are walked recursively
+// and only the files matching the include pattern, if any, and a workflow extension are returned.
+func collectFiles(paths []string, include string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !isWorkflowFile(file) {
+				return nil
+			}
+			if len(include) > 0 {
+				if ok, err := filepath.Match(include, info.Name()); err != nil || !ok {
+					return err
+				}
+			}
+			files = append(files, file)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func isWorkflowFile(file string) bool {
+	ext := strings.ToLower(filepath.Ext(file))
+	for _, supported := range workflowExtensions {
+		if ext == supported {
+			return true
+		}
+	}
+	return false
+}
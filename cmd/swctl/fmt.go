@@ -0,0 +1,99 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+func init() {
+	registerCommand(&command{name: "fmt", summary: "rewrite workflow files in the canonical format", run: runFmt})
+}
+
+func runFmt(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	include := flags.String("include", "", "file name pattern of the workflows to format in directories, e.g. '*.sw.yaml'")
+	check := flags.Bool("check", false, "don't write the files, list the ones that are not formatted and fail if any")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl fmt [flags] <file|dir>...")
+		fmt.Fprintln(stderr, "Properties unknown to the SDK model are not written back.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	files, err := collectFiles(flags.Args(), *include)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+
+	code := exitOK
+	for _, file := range files {
+		changed, err := formatFile(file, !*check)
+		if err != nil {
+			fmt.Fprintf(stderr, "swctl: %s: %v\n", file, fileError(file, err))
+			code = exitError
+			continue
+		}
+		if changed {
+			fmt.Fprintln(stdout, file)
+			if *check {
+				code = exitError
+			}
+		}
+	}
+	return code
+}
+
+// formatFile formats the workflow file and returns whether it was not in the canonical format. The file is
+// rewritten only if write is true.
+func formatFile(file string, write bool) (bool, error) {
+	format, err := serializer.FormatFromPath(file)
+	if err != nil {
+		return false, err
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	workflow, err := parser.FromFile(file)
+	if err != nil {
+		return false, err
+	}
+	formatted, err := serializer.Marshal(workflow, serializer.CanonicalOptions(format))
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(data, formatted) {
+		return false, nil
+	}
+	if write {
+		return true, ioutil.WriteFile(file, formatted, 0644)
+	}
+	return true, nil
+}
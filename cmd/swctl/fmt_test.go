@@ -0,0 +1,58 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunFmt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	data, err := ioutil.ReadFile("../../parser/testdata/workflows/greetings.sw.json")
+	assert.NoError(t, err)
+	file := filepath.Join(dir, "greetings.sw.json")
+	assert.NoError(t, ioutil.WriteFile(file, data, 0600))
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitError, run([]string{"fmt", "-check", dir}, stdout, stderr))
+	assert.Equal(t, file+"\n", stdout.String())
+	unchanged, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, data, unchanged)
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"fmt", dir}, stdout, stderr))
+	assert.Equal(t, file+"\n", stdout.String())
+	formatted, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Contains(t, string(formatted), "\"start\": \"Greet\"")
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"fmt", "-check", file}, stdout, stderr))
+	assert.Empty(t, stdout.String())
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "invalid.json"), []byte("{"), 0600))
+	assert.Equal(t, exitError, run([]string{"fmt", dir}, stdout, stderr))
+	assert.Contains(t, stderr.String(), "invalid.json")
+	assert.Equal(t, exitUsage, run([]string{"fmt"}, stdout, stderr))
+}
@@ -0,0 +1,99 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/diagram"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+)
+
+func init() {
+	registerCommand(&command{name: "graph", summary: "render the diagram of a workflow", run: runGraph})
+}
+
+func runGraph(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("graph", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("f", "mermaid", "diagram format, mermaid, dot or svg. svg requires the Graphviz dot command")
+	output := flags.String("o", "", "output file. Default is the standard output")
+	highlight := flags.String("highlight", "", "comma separated states to highlight, with the transitions between consecutive ones, e.g. 'start,Check,Store,end'")
+	states := flags.String("states", "", "comma separated states to render. Default is all of them")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl graph [flags] <file>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	var render func(*model.Workflow, diagram.Options) ([]byte, error)
+	switch *format {
+	case "mermaid":
+		render = diagram.Mermaid
+	case "dot":
+		render = diagram.DOT
+	case "svg":
+		render = func(workflow *model.Workflow, opts diagram.Options) ([]byte, error) {
+			return diagram.SVG(context.Background(), workflow, opts)
+		}
+	default:
+		fmt.Fprintf(stderr, "swctl: unknown diagram format %s\n", *format)
+		return exitUsage
+	}
+
+	input := flags.Arg(0)
+	workflow, err := parser.FromFile(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, fileError(input, err))
+		return exitError
+	}
+	data, err := render(workflow, diagram.Options{Highlight: splitList(*highlight), States: splitList(*states)})
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, err)
+		return exitError
+	}
+	if len(*output) == 0 {
+		_, err = stdout.Write(data)
+	} else {
+		err = ioutil.WriteFile(*output, data, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// splitList splits a comma separated flag value, ignoring blanks
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunGraph(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	file := "../../parser/testdata/workflows/applicationrequest.json"
+
+	assert.Equal(t, exitOK, run([]string{"graph", "-highlight", "start, CheckApplication", file}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "flowchart TD\n")
+	assert.Contains(t, stdout.String(), "    class n0,n1 highlight\n")
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"graph", "-f", "dot", "-states", "StartApplication", file}, stdout, stderr))
+	assert.Equal(t, `digraph "applicantrequest" {
+    node [shape=box, style=rounded];
+    n0 [label="StartApplication"];
+    n1 [label="End", shape=doublecircle];
+    n0 -> n1;
+}
+`, stdout.String())
+
+	assert.Equal(t, exitError, run([]string{"graph", "-highlight", "StartApplication,CheckApplication", file}, stdout, stderr))
+	assert.Contains(t, stderr.String(), "there is no transition from StartApplication to CheckApplication")
+	assert.Equal(t, exitUsage, run([]string{"graph", "-f", "png", file}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"graph"}, stdout, stderr))
+}
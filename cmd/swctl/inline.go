@@ -0,0 +1,106 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/registry"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+	"github.com/serverlessworkflow/sdk-go/v2/transform"
+	"github.com/serverlessworkflow/sdk-go/v2/workspace"
+)
+
+// registryTokenEnv environment variable holding the token of the workflow registry
+const registryTokenEnv = "SWCTL_REGISTRY_TOKEN"
+
+func init() {
+	registerCommand(&command{name: "inline", summary: "inline the subflows of a workflow into a single flat workflow", run: runInline})
+}
+
+func runInline(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("inline", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("o", "", "output file. Default is the standard output, in the format of the input")
+	include := flags.String("include", "", "file name pattern of the subflows to load from directories, e.g. '*.sw.yaml'")
+	registryURL := flags.String("registry", "", "URL of the workflow registry to fetch the subflows missing from the files, authenticated with the token of the "+registryTokenEnv+" environment variable if set")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl inline [flags] <file> [<subflow file|dir>...]")
+		fmt.Fprintln(stderr, "The subflows are looked up in the given files and directories, by default the directory of the workflow, then in the registry if set.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	input := flags.Arg(0)
+	format, err := serializer.FormatFromPath(input)
+	if len(*output) > 0 {
+		format, err = serializer.FormatFromPath(*output)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitUsage
+	}
+
+	paths := flags.Args()[1:]
+	if len(paths) == 0 {
+		paths = []string{filepath.Dir(input)}
+	}
+	files, err := collectFiles(paths, *include)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	workflow, err := parser.FromFile(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, fileError(input, err))
+		return exitError
+	}
+	// files that aren't workflows are expected in the directories, subflows missing because of them are reported
+	w, _ := workspace.Load(files)
+	if len(*registryURL) > 0 {
+		w.Remote = (&registry.Catalog{URL: *registryURL, Token: os.Getenv(registryTokenEnv)}).Resolve
+	}
+	flat, err := transform.InlineSubflows(workflow, w.Resolve)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, err)
+		return exitError
+	}
+	data, err := serializer.Marshal(flat, serializer.CanonicalOptions(format))
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, err)
+		return exitError
+	}
+	if len(*output) == 0 {
+		_, err = stdout.Write(data)
+	} else {
+		err = ioutil.WriteFile(*output, data, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunInline(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	write := func(name, source string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(source), 0600))
+	}
+	write("order.sw.yaml", `id: order
+name: Order
+version: '1.0'
+specVersion: '0.7'
+start: Pay
+states:
+- name: Pay
+  type: operation
+  actions:
+  - subFlowRef: payment
+  end: true
+`)
+	write("payment.sw.yaml", `id: payment
+name: Payment
+version: '1.0'
+specVersion: '0.7'
+start: Charge
+states:
+- name: Charge
+  type: inject
+  data:
+    charged: true
+  end: true
+`)
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	output := filepath.Join(dir, "flat.json")
+	assert.Equal(t, exitOK, run([]string{"inline", "-o", output, filepath.Join(dir, "order.sw.yaml")}, stdout, stderr), stderr.String())
+	flat, err := parser.FromFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, "Pay.payment.Charge", flat.Start.StateName)
+	assert.Len(t, flat.States, 1)
+
+	assert.Equal(t, exitError, run([]string{"inline", filepath.Join(dir, "order.sw.yaml"), output}, stdout, stderr))
+	assert.Contains(t, stderr.String(), "states[0].actions[0].subFlowRef: workflow payment is not in the workspace")
+	assert.Equal(t, exitUsage, run([]string{"inline"}, stdout, stderr))
+}
+
+func TestRunInlineRegistry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "order.sw.yaml"), []byte(`id: order
+name: Order
+version: '1.0'
+specVersion: '0.7'
+start: Pay
+states:
+- name: Pay
+  type: operation
+  actions:
+  - subFlowRef: payment
+  end: true
+`), 0600))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/workflows/payment" || req.Header.Get("Authorization") != "Bearer t0k3n" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "payment", "name": "Payment", "version": "1.0", "specVersion": "0.7", "start": "Charge",
+			"states": [{"name": "Charge", "type": "inject", "data": {"charged": true}, "end": true}]}`))
+	}))
+	defer server.Close()
+	os.Setenv(registryTokenEnv, "t0k3n")
+	defer os.Unsetenv(registryTokenEnv)
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitOK, run([]string{"inline", "-registry", server.URL, filepath.Join(dir, "order.sw.yaml")}, stdout, stderr), stderr.String())
+	assert.Contains(t, stdout.String(), "Pay.payment.Charge")
+}
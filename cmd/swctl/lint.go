@@ -0,0 +1,119 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/serverlessworkflow/sdk-go/v2/annotation"
+	"github.com/serverlessworkflow/sdk-go/v2/lint"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+)
+
+func init() {
+	registerCommand(&command{name: "lint", summary: "check workflow files against the lint rules", run: runLint})
+}
+
+func runLint(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configPath := flags.String("config", "", "lint configuration file. Default is the "+lint.ConfigFileName+" found in the working directory or its parents")
+	include := flags.String("include", "", "file name pattern of the workflows to lint in directories, e.g. '*.sw.yaml'")
+	strict := flags.Bool("strict", false, "fail on warnings as well as errors")
+	quiet := flags.Bool("q", false, "only print the issues")
+	format := flags.String("format", formatText, "output format, text, github for GitHub Actions annotations or gitlab for a GitLab Code Quality report")
+	messagesFile := flags.String("messages", "", "YAML or JSON message catalog translating the messages, their templates by message ID")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl lint [flags] <file|dir>...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() == 0 || !validFormat(*format) {
+		flags.Usage()
+		return exitUsage
+	}
+	config, err := loadLintConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	translator, err := loadMessages(*messagesFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	files, err := collectFiles(flags.Args(), *include)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+
+	failed := 0
+	var annotations []annotation.Annotation
+	for _, file := range files {
+		workflow, err := parser.FromFile(file)
+		if err != nil {
+			if *format == formatText {
+				fmt.Fprintf(stdout, "%s: %v\n", file, fileError(file, err))
+			}
+			annotations = append(annotations, annotation.Annotation{File: file, Message: fileError(file, err).Error()})
+			failed++
+			continue
+		}
+		issues := lint.Lint(workflow, config)
+		for _, issue := range issues {
+			issue.Message = issue.Localize(translator)
+			if *format == formatText {
+				fmt.Fprintf(stdout, "%s: %s\n", file, issue)
+			}
+			severity := annotation.SeverityError
+			if issue.Severity == lint.SeverityWarning {
+				severity = annotation.SeverityWarning
+			}
+			annotations = append(annotations, annotation.Annotation{File: file, Path: issue.Path, Severity: severity, Title: issue.Rule, Message: issue.Message})
+		}
+		if lint.HasErrors(issues) || (*strict && len(issues) > 0) {
+			failed++
+		}
+	}
+	if *format != formatText {
+		if err := writeAnnotations(stdout, *format, annotations); err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitError
+		}
+	}
+	if !*quiet && *format == formatText {
+		fmt.Fprintf(stdout, "%d file(s) linted, %d failed\n", len(files), failed)
+	}
+	if failed > 0 {
+		return exitError
+	}
+	return exitOK
+}
+
+func loadLintConfig(path string) (*lint.Config, error) {
+	if len(path) == 0 {
+		found, err := lint.FindConfig(".")
+		if err != nil || len(found) == 0 {
+			return lint.DefaultConfig(), err
+		}
+		path = found
+	}
+	return lint.LoadConfig(path)
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunLint(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	code := run([]string{"lint", "../../parser/testdata/workflows/greetings.sw.json"}, stdout, stderr)
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "../../parser/testdata/workflows/greetings.sw.json: states[0].actions[0].retryRef: warning: action invoking REST function greetingFunction has no retry policy (rest-retries)\n"+
+		"1 file(s) linted, 0 failed\n", stdout.String())
+
+	stdout.Reset()
+	code = run([]string{"lint", "-strict", "-q", "../../parser/testdata/workflows/greetings.sw.json"}, stdout, stderr)
+	assert.Equal(t, exitError, code)
+	assert.NotContains(t, stdout.String(), "linted")
+
+	stdout.Reset()
+	code = run([]string{"lint", "../../parser/testdata/workflows/applicationrequest.multiauth.json"}, stdout, stderr)
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stdout.String(), "auth[1].properties.password: error: inline credential, use a workflow secret instead (no-inline-secrets)\n")
+
+	dir, err := ioutil.TempDir("", "swctl")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	messages := filepath.Join(dir, "fr.yaml")
+	assert.NoError(t, ioutil.WriteFile(messages, []byte("lint.rest-retries: \"la fonction REST {{.function}} n'a pas de politique de retry\"\n"), 0600))
+	stdout.Reset()
+	code = run([]string{"lint", "-messages", messages, "../../parser/testdata/workflows/greetings.sw.json"}, stdout, stderr)
+	assert.Equal(t, exitOK, code)
+	assert.Contains(t, stdout.String(), "states[0].actions[0].retryRef: warning: la fonction REST greetingFunction n'a pas de politique de retry (rest-retries)\n")
+
+	config := filepath.Join(dir, ".swlint.yaml")
+	assert.NoError(t, ioutil.WriteFile(config, []byte("disabled: [no-inline-secrets, rest-retries]\n"), 0600))
+	stdout.Reset()
+	code = run([]string{"lint", "-config", config, "../../parser/testdata/workflows/applicationrequest.multiauth.json"}, stdout, stderr)
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "1 file(s) linted, 0 failed\n", stdout.String())
+
+	assert.Equal(t, exitError, run([]string{"lint", "-config", "missing.yaml", "../../parser/testdata/workflows/greetings.sw.json"}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"lint"}, stdout, stderr))
+}
+
+func TestRunLintFormat(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	file := "../../parser/testdata/workflows/greetings.sw.json"
+	assert.Equal(t, exitOK, run([]string{"lint", "-format", "github", file}, stdout, stderr))
+	assert.Equal(t, "::warning file="+file+",line=21,col=9,title=rest-retries::states[0].actions[0].retryRef: action invoking REST function greetingFunction has no retry policy\n", stdout.String())
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"lint", "-format", "gitlab", file}, stdout, stderr))
+	assert.Contains(t, stdout.String(), `"check_name": "rest-retries"`)
+	assert.Contains(t, stdout.String(), `"severity": "minor"`)
+	assert.Equal(t, exitUsage, run([]string{"lint", "-format", "xml", file}, stdout, stderr))
+}
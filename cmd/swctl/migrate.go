@@ -0,0 +1,88 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/serverlessworkflow/sdk-go/v2/migration"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+func init() {
+	registerCommand(&command{name: "migrate", summary: "migrate a workflow to a newer specification version", run: runMigrate})
+}
+
+func runMigrate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	to := flags.String("to", migration.LatestVersion, "target specification version")
+	output := flags.String("o", "", "output file. Default is the standard output")
+	write := flags.Bool("w", false, "write the result to the input file instead of the standard output")
+	changes := flags.Bool("changes", false, "print the changes made by the migration to the standard error")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl migrate [flags] <file>")
+		fmt.Fprintln(stderr, "The changes that need manual attention are printed to the standard error.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 || (*write && len(*output) > 0) {
+		flags.Usage()
+		return exitUsage
+	}
+	input := flags.Arg(0)
+	if *write {
+		*output = input
+	}
+
+	format, err := serializer.FormatFromPath(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitUsage
+	}
+	data, err := ioutil.ReadFile(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	result, err := migration.Migrate(data, format, *to)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, err)
+		return exitError
+	}
+	if *changes {
+		for _, change := range result.Changes {
+			fmt.Fprintf(stderr, "%s: %s (%s)\n", input, change, change.To)
+		}
+	}
+	for _, note := range result.Notes {
+		fmt.Fprintf(stderr, "%s: %s\n", input, note)
+	}
+	if len(*output) == 0 {
+		_, err = stdout.Write(result.Document)
+	} else {
+		err = ioutil.WriteFile(*output, result.Document, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "greeting.sw.yaml")
+	assert.NoError(t, ioutil.WriteFile(file, []byte(`id: greeting
+specVersion: '0.6'
+start: Greet
+states:
+- name: Greet
+  type: delay
+  timeDelay: PT1S
+  onErrors:
+  - error: Timeout
+    end: true
+  end: true
+`), 0600))
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitOK, run([]string{"migrate", "-to", "0.7", file}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "specVersion: \"0.7\"\n")
+	assert.Contains(t, stdout.String(), "  type: sleep\n  duration: PT1S\n")
+	assert.Equal(t, file+": states[0].onErrors[0].errorRef: error Timeout must be declared in the workflow errors since 0.7\n", stderr.String())
+
+	stdout.Reset()
+	stderr.Reset()
+	assert.Equal(t, exitOK, run([]string{"migrate", "-to", "0.7", "-changes", file}, stdout, stderr))
+	assert.Contains(t, stderr.String(), file+": states[0]: delay state replaced by a sleep state, its timeDelay by the duration (0.7)\n")
+	assert.Contains(t, stderr.String(), file+": states[0].onErrors[0].errorRef: error renamed to errorRef (0.7)\n")
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"migrate", "-w", file}, stdout, stderr))
+	assert.Empty(t, stdout.String())
+	data, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "specVersion: \"0.8\"\n")
+
+	assert.Equal(t, exitError, run([]string{"migrate", "-to", "0.7", file}, stdout, stderr))
+	assert.Contains(t, stderr.String(), "migration from spec version 0.8 to 0.7 is not supported")
+	assert.Equal(t, exitUsage, run([]string{"migrate", "-w", "-o", "out.yaml", file}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"migrate", "workflow.txt"}, stdout, stderr))
+}
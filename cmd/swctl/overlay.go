@@ -0,0 +1,81 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/serverlessworkflow/sdk-go/v2/overlay"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+func init() {
+	registerCommand(&command{name: "overlay", summary: "build the environment variant of a workflow from an overlay", run: runOverlay})
+}
+
+func runOverlay(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("overlay", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("o", "", "output file. Default is the standard output")
+	format := flags.String("format", "", "output format, json or yaml. Default is the output file format or, if writing to the standard output, the overlay file format")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl overlay [flags] <overlay>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	input := flags.Arg(0)
+
+	outputFormat := serializer.Format(*format)
+	if len(outputFormat) == 0 {
+		path := *output
+		if len(path) == 0 {
+			path = input
+		}
+		var err error
+		if outputFormat, err = serializer.FormatFromPath(path); err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitUsage
+		}
+	}
+	workflow, err := overlay.Build(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	data, err := serializer.Marshal(workflow, serializer.CanonicalOptions(outputFormat))
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, err)
+		return exitError
+	}
+	if len(*output) == 0 {
+		_, err = stdout.Write(data)
+	} else {
+		err = ioutil.WriteFile(*output, data, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
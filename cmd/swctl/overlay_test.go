@@ -0,0 +1,40 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunOverlay(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	assert.Equal(t, exitOK, run([]string{"overlay", "../../overlay/testdata/prod-eu/overlay.yaml"}, stdout, stderr))
+	workflow, err := parser.FromYAMLSource(stdout.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "https://orders.eu.example.com/openapi.json#storeOrder", workflow.Functions[0].Operation)
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"overlay", "-format", "json", "../../overlay/testdata/prod/overlay.yaml"}, stdout, stderr))
+	assert.Contains(t, stdout.String(), `"operation": "https://orders.example.com/openapi.json#storeOrder"`)
+
+	assert.Equal(t, exitError, run([]string{"overlay", "missing.yaml"}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"overlay"}, stdout, stderr))
+}
@@ -0,0 +1,140 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/resolver"
+	"github.com/serverlessworkflow/sdk-go/v2/schemaregistry"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// schemaRegistryTokenEnv environment variable holding the bearer token of the schema registries
+	schemaRegistryTokenEnv = "SWCTL_SCHEMA_REGISTRY_TOKEN"
+	// schemaRegistryUserEnv environment variable holding the 'user:password' credentials of the schema registries,
+	// e.g. the API key and secret of Confluent Cloud
+	schemaRegistryUserEnv = "SWCTL_SCHEMA_REGISTRY_USER"
+)
+
+func init() {
+	registerCommand(&command{name: "payloads", summary: "validate sample event payloads against the data schemas of the events", run: runPayloads})
+}
+
+func runPayloads(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("payloads", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var samples assignments
+	flags.Var(&samples, "sample", "sample payload of an event as <event>=<JSON or YAML file>, e.g. 'OrderCreated=samples/order.json'. Can be repeated")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl payloads -sample <event>=<file>... <file>")
+		fmt.Fprintln(stderr, "The data schemas are referenced by the dataSchema metadata of the events, schemas of Confluent or Apicurio registries or")
+		fmt.Fprintln(stderr, "files relative to the workflow, authenticated with the "+schemaRegistryTokenEnv+" or "+schemaRegistryUserEnv+" environment variables if set.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 || len(samples) == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	input := flags.Arg(0)
+	payloads := map[string][]interface{}{}
+	var files []string
+	for _, sample := range samples {
+		parts := strings.SplitN(sample, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			fmt.Fprintf(stderr, "swctl: invalid sample %s, expected <event>=<file>\n", sample)
+			return exitUsage
+		}
+		payload, err := readPayload(parts[1])
+		if err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitError
+		}
+		payloads[parts[0]] = append(payloads[parts[0]], payload)
+		files = append(files, parts[1])
+	}
+	workflow, err := parser.FromFile(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, fileError(input, err))
+		return exitError
+	}
+	client := &schemaregistry.Client{
+		Token:  os.Getenv(schemaRegistryTokenEnv),
+		Loader: resolver.NewLoader(filepath.Dir(input), nil),
+	}
+	if user := os.Getenv(schemaRegistryUserEnv); len(user) > 0 {
+		parts := strings.SplitN(user, ":", 2)
+		client.Username = parts[0]
+		if len(parts) == 2 {
+			client.Password = parts[1]
+		}
+	}
+	problems, err := client.ValidateSamples(context.Background(), workflow, payloads)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, err)
+		return exitError
+	}
+	for _, problem := range problems {
+		fmt.Fprintf(stdout, "%s: %s\n", sampleFile(files, samples, problem), problem.ValidationError.Error())
+	}
+	if len(problems) > 0 {
+		return exitError
+	}
+	return exitOK
+}
+
+// readPayload reads a sample payload from a JSON or YAML file
+func readPayload(path string) (interface{}, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	var payload interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return payload, nil
+}
+
+// sampleFile returns the file of the sample of the problem, the samples of an event being indexed in flag order
+func sampleFile(files []string, samples assignments, problem schemaregistry.Problem) string {
+	index := 0
+	for i, sample := range samples {
+		if strings.HasPrefix(sample, problem.Event+"=") {
+			if index == problem.Sample {
+				return files[i]
+			}
+			index++
+		}
+	}
+	return problem.Event
+}
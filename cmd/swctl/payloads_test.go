@@ -0,0 +1,72 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPayloads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	write := func(name, source string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(source), 0600))
+		return path
+	}
+	workflow := write("order.sw.yaml", `id: order
+name: Order
+version: '1.0'
+specVersion: '0.8'
+start: Wait
+events:
+- name: OrderCreated
+  source: orders
+  type: order.created
+  metadata:
+    dataSchema: order.avsc
+states:
+- name: Wait
+  type: event
+  onEvents:
+  - eventRefs: [OrderCreated]
+  end: true
+`)
+	write("order.avsc", `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}]}`)
+	valid := write("valid.yaml", "id: '1'\n")
+	invalid := write("invalid.json", `{"id": 1}`)
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitOK, run([]string{"payloads", "-sample", "OrderCreated=" + valid, workflow}, stdout, stderr), stderr.String())
+	assert.Empty(t, stdout.String())
+
+	assert.Equal(t, exitError, run([]string{"payloads", "-sample", "OrderCreated=" + valid, "-sample", "OrderCreated=" + invalid, workflow}, stdout, stderr))
+	assert.Equal(t, invalid+": id: expected string, got a number\n", stdout.String())
+
+	stderr.Reset()
+	assert.Equal(t, exitError, run([]string{"payloads", "-sample", "OrderShipped=" + valid, workflow}, stdout, stderr))
+	assert.Contains(t, stderr.String(), "event OrderShipped not defined in workflow order")
+
+	assert.Equal(t, exitUsage, run([]string{"payloads", "-sample", "OrderCreated", workflow}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"payloads", workflow}, stdout, stderr))
+}
@@ -0,0 +1,44 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunRender(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	values := "../../values/testdata/values.yaml,../../values/testdata/values-prod.yaml"
+	assert.Equal(t, exitOK, run([]string{"render", "-values", values, "-set", "retries.maxAttempts=5", "-set", "version='2.0'", "../../values/testdata/order.sw.yaml"}, stdout, stderr))
+	workflow, err := parser.FromYAMLSource(stdout.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "https://orders.example.com/openapi.json#storeOrder", workflow.Functions[0].Operation)
+	assert.Equal(t, 5, workflow.Retries[0].MaxAttempts.IntValue())
+	assert.Equal(t, "2.0", workflow.Version)
+
+	stderr.Reset()
+	assert.Equal(t, exitError, run([]string{"render", "-values", "../../values/testdata/values.yaml", "-set", "retries.maxAttempts=true", "../../values/testdata/order.sw.yaml"}, stdout, stderr))
+	assert.Contains(t, stderr.String(), "retries[0].maxAttempts: {{ .Values.retries.maxAttempts }} is the boolean true, expected an integer or a string")
+
+	assert.Equal(t, exitUsage, run([]string{"render", "-set", "version", "../../values/testdata/order.sw.yaml"}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"render", "-values", "missing.yaml", "../../values/testdata/order.sw.yaml"}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"render"}, stdout, stderr))
+}
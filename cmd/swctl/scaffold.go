@@ -0,0 +1,95 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/serverlessworkflow/sdk-go/v2/manifest"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+)
+
+func init() {
+	registerCommand(&command{name: "scaffold", summary: "scaffold the Kubernetes resources running the functions of a workflow", run: runScaffold})
+}
+
+func runScaffold(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("scaffold", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	kind := flags.String("kind", string(manifest.ScaffoldKnativeService), "kind of the resources, knative for a Knative Service or deployment for a Deployment and a Service per function")
+	templatePath := flags.String("template", "", "Go template file of the resources of a function, overriding the template of the kind")
+	namespace := flags.String("namespace", "", "namespace of the resources")
+	image := flags.String("image", "", "Go template of the container images, e.g. 'ghcr.io/acme/{{ .Name }}:latest'. Default is '{{ .Name }}:latest'")
+	port := flags.Int("port", 0, "port the containers listen on. Default is 8080")
+	output := flags.String("o", "", "output directory, one file per function. Default is the standard output")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl scaffold [flags] <file>")
+		fmt.Fprintln(stderr, "The resources are scaffolded for the functions backed by a service, all but the expression and AsyncAPI functions.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	opts := manifest.ScaffoldOptions{Kind: manifest.ScaffoldKind(*kind), Namespace: *namespace, Image: *image, Port: int32(*port)}
+	if len(*templatePath) > 0 {
+		data, err := ioutil.ReadFile(*templatePath)
+		if err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitUsage
+		}
+		opts.Template = string(data)
+	}
+	input := flags.Arg(0)
+	workflow, err := parser.FromFile(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, fileError(input, err))
+		return exitError
+	}
+	scaffolds, err := manifest.ScaffoldFunctions(workflow, opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, err)
+		return exitError
+	}
+	if len(*output) > 0 {
+		if err := os.MkdirAll(*output, 0755); err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitError
+		}
+	}
+	for i, scaffold := range scaffolds {
+		if len(*output) > 0 {
+			err = ioutil.WriteFile(filepath.Join(*output, scaffold.Name+".yaml"), scaffold.Manifest, 0644)
+		} else {
+			if i > 0 {
+				fmt.Fprintln(stdout, "---")
+			}
+			_, err = stdout.Write(scaffold.Manifest)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitError
+		}
+	}
+	return exitOK
+}
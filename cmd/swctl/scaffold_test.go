@@ -0,0 +1,69 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunScaffold(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	workflow := filepath.Join(dir, "order.sw.yaml")
+	require.NoError(t, ioutil.WriteFile(workflow, []byte(`id: order
+name: Order
+version: '1.0'
+specVersion: '0.8'
+start: Store
+functions:
+- name: storeOrder
+  operation: https://orders.example.com/openapi.json#storeOrder
+- name: price
+  type: rpc
+  operation: pricing.proto#Pricing#Price
+states:
+- name: Store
+  type: operation
+  actions:
+  - functionRef: storeOrder
+  - functionRef: price
+  end: true
+`), 0600))
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitOK, run([]string{"scaffold", "-namespace", "orders", workflow}, stdout, stderr), stderr.String())
+	assert.Contains(t, stdout.String(), "name: storeorder\n")
+	assert.Contains(t, stdout.String(), "---\napiVersion: serving.knative.dev/v1\nkind: Service\nmetadata:\n  name: price\n")
+
+	output := filepath.Join(dir, "deploy")
+	assert.Equal(t, exitOK, run([]string{"scaffold", "-kind", "deployment", "-o", output, workflow}, stdout, stderr), stderr.String())
+	data, err := ioutil.ReadFile(filepath.Join(output, "price.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "kind: Deployment")
+
+	stderr.Reset()
+	assert.Equal(t, exitError, run([]string{"scaffold", "-kind", "lambda", workflow}, stdout, stderr))
+	assert.Contains(t, stderr.String(), "unknown scaffold kind lambda")
+	assert.Equal(t, exitUsage, run([]string{"scaffold", "-template", filepath.Join(dir, "missing.tmpl"), workflow}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"scaffold"}, stdout, stderr))
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/scenario"
+)
+
+func init() {
+	registerCommand(&command{name: "scenarios", summary: "generate the test scenarios of a workflow from its switch conditions", run: runScenarios})
+}
+
+func runScenarios(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("scenarios", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("f", "json", "output format, json or go")
+	output := flags.String("o", "", "output file. Default is the standard output")
+	pkg := flags.String("package", "main_test", "package of the generated Go test")
+	errors := flags.Bool("errors", false, "generate the scenarios where states fail and their error handlers are taken")
+	max := flags.Int("max", scenario.DefaultMaxScenarios, "maximum number of scenarios")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl scenarios [flags] <file>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	if *format != "json" && *format != "go" {
+		fmt.Fprintf(stderr, "swctl: unknown scenarios format %s\n", *format)
+		return exitUsage
+	}
+
+	input := flags.Arg(0)
+	workflow, err := parser.FromFile(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, fileError(input, err))
+		return exitError
+	}
+	scenarios := scenario.Generate(workflow, scenario.Options{Errors: *errors, MaxScenarios: *max})
+	var data []byte
+	if *format == "go" {
+		data, err = scenario.GoTest(workflow, scenarios, *pkg)
+	} else {
+		if scenarios == nil {
+			scenarios = []scenario.Scenario{}
+		}
+		data, err = json.MarshalIndent(scenarios, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, err)
+		return exitError
+	}
+	if len(*output) == 0 {
+		_, err = stdout.Write(data)
+	} else {
+		err = ioutil.WriteFile(*output, data, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
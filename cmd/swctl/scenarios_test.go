@@ -0,0 +1,46 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/scenario"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunScenarios(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	file := "../../parser/testdata/workflows/applicationrequest.json"
+
+	assert.Equal(t, exitOK, run([]string{"scenarios", file}, stdout, stderr))
+	var scenarios []scenario.Scenario
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &scenarios))
+	require.Len(t, scenarios, 3)
+	assert.Equal(t, []string{"CheckApplication", "StartApplication"}, scenarios[0].Path)
+	assert.Equal(t, "CheckApplication=default", scenarios[2].Name)
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"scenarios", "-f", "go", "-package", "app_test", "-max", "1", file}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "package app_test\n")
+	assert.Contains(t, stdout.String(), "func TestApplicantrequestScenarios(t *testing.T) {\n")
+	assert.Equal(t, 1, bytes.Count(stdout.Bytes(), []byte("name: ")))
+
+	assert.Equal(t, exitUsage, run([]string{"scenarios", "-f", "yaml", file}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"scenarios"}, stdout, stderr))
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/index"
+)
+
+func init() {
+	registerCommand(&command{name: "search", summary: "search workflows by id, event type, operation or metadata", run: runSearch})
+}
+
+func runSearch(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	indexFile := flags.String("index", "", "index file, created or updated with the new and modified workflows. Default is to parse all the workflows")
+	include := flags.String("include", "", "file name pattern of the workflows to index in directories, e.g. '*.sw.yaml'")
+	id := flags.String("id", "", "workflow id")
+	eventType := flags.String("event-type", "", "CloudEvent type of an event the workflows consume or produce")
+	operation := flags.String("operation", "", "part of a function operation, e.g. 'orders.json'")
+	labels := flags.String("label", "", "comma separated metadata the workflows must have, e.g. 'team=checkout,tier=1'")
+	text := flags.String("text", "", "text the workflow id, name or description contains, ignoring the case")
+	asJSON := flags.Bool("json", false, "print the matching index entries as JSON")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl search [flags] <file|dir>...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	query := index.Query{ID: *id, EventType: *eventType, Operation: *operation, Text: *text}
+	for _, label := range splitList(*labels) {
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) != 2 {
+			fmt.Fprintf(stderr, "swctl: invalid label %s, expected key=value\n", label)
+			return exitUsage
+		}
+		if query.Labels == nil {
+			query.Labels = map[string]string{}
+		}
+		query.Labels[parts[0]] = parts[1]
+	}
+
+	files, err := collectFiles(flags.Args(), *include)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	idx, err := openIndex(*indexFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", *indexFile, err)
+		return exitError
+	}
+	if _, err := idx.Update(files); err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	if len(*indexFile) > 0 {
+		buf := new(bytes.Buffer)
+		if err := idx.Save(buf); err == nil {
+			err = ioutil.WriteFile(*indexFile, buf.Bytes(), 0644)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitError
+		}
+	}
+
+	entries := idx.Search(query)
+	if *asJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if entries == nil {
+			entries = []*index.Entry{}
+		}
+		if err := encoder.Encode(entries); err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitError
+		}
+		return exitOK
+	}
+	for _, entry := range entries {
+		key := entry.ID
+		if len(entry.Version) > 0 {
+			key += "@" + entry.Version
+		}
+		fmt.Fprintf(stdout, "%s: %s %s\n", entry.File, key, entry.Name)
+	}
+	return exitOK
+}
+
+// openIndex loads the index file, or returns an empty index if there is none
+func openIndex(file string) (*index.Index, error) {
+	if len(file) == 0 {
+		return index.New(), nil
+	}
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return index.New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return index.Load(f)
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSearch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	indexFile := filepath.Join(dir, "index.json")
+	workflows := "../../parser/testdata/workflows"
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitOK, run([]string{"search", "-index", indexFile, "-include", "*.json", "-event-type", "greetingEventType", workflows}, stdout, stderr))
+	assert.Contains(t, stdout.String(), workflows+"/eventbasedgreeting.sw.json: eventbasedgreeting@1.0 Event Based Greeting Workflow\n")
+	assert.NotContains(t, stdout.String(), "applicationrequest")
+	assert.FileExists(t, indexFile)
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"search", "-index", indexFile, "-id", "applicantrequest", "-json", workflows + "/applicationrequest.json"}, stdout, stderr))
+	assert.Contains(t, stdout.String(), `"id": "applicantrequest"`)
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"search", "-include", "*.json", "-text", "no such workflow", workflows}, stdout, stderr))
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, exitUsage, run([]string{"search", "-label", "team", workflows}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"search"}, stdout, stderr))
+}
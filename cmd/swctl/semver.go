@@ -0,0 +1,80 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/serverlessworkflow/sdk-go/v2/diff"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+)
+
+func init() {
+	registerCommand(&command{name: "semver", summary: "suggest the version bump between two workflow versions", run: runSemver})
+}
+
+func runSemver(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("semver", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	check := flags.Bool("check", false, "exit with code 1 if the version of the new workflow is lower than the suggested one")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl semver [flags] <old> <new>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return exitUsage
+	}
+	from, err := parser.FromFile(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", flags.Arg(0), fileError(flags.Arg(0), err))
+		return exitError
+	}
+	to, err := parser.FromFile(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", flags.Arg(1), fileError(flags.Arg(1), err))
+		return exitError
+	}
+	changes, err := diff.Workflows(from, to)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+
+	impact, classified := diff.ClassifyAll(changes)
+	for _, change := range classified {
+		if change.Impact != diff.ImpactNone {
+			fmt.Fprintf(stdout, "%-5s %s\n", change.Impact, change.Change)
+		}
+	}
+	suggested, err := diff.SuggestVersion(from.Version, impact)
+	if err != nil {
+		fmt.Fprintf(stdout, "bump: %s\n", impact)
+	} else {
+		fmt.Fprintf(stdout, "bump: %s, %s -> %s\n", impact, from.Version, suggested)
+	}
+	if *check {
+		if err := diff.CheckVersion(from.Version, to.Version, impact); err != nil {
+			fmt.Fprintf(stderr, "swctl: %s: %v\n", flags.Arg(1), err)
+			return exitError
+		}
+	}
+	return exitOK
+}
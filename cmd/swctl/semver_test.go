@@ -0,0 +1,55 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSemver(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	from := "../../parser/testdata/workflows/applicationrequest.json"
+	data, err := ioutil.ReadFile(from)
+	require.NoError(t, err)
+	to := filepath.Join(dir, "applicationrequest.json")
+	// change the operation of the rejection e-mail function, but bump the minor version only
+	data = []byte(strings.Replace(string(data), `"version": "1.0"`, `"version": "1.1"`, 1))
+	data = []byte(strings.Replace(string(data), `"operation": "http://myapis.org/applicationapi.json#emailRejection"`, `"operation": "http://myapis.org/applicationapi.json#rejection"`, 1))
+	require.NoError(t, ioutil.WriteFile(to, data, 0600))
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitOK, run([]string{"semver", from, to}, stdout, stderr))
+	assert.Equal(t, `major ~ functions[sendRejectionEmailFunction].operation: "http://myapis.org/applicationapi.json#emailRejection" -> "http://myapis.org/applicationapi.json#rejection"
+bump: major, 1.0 -> 2.0
+`, stdout.String())
+
+	assert.Equal(t, exitError, run([]string{"semver", "-check", from, to}, stdout, stderr))
+	assert.Contains(t, stderr.String(), "version 1.1 is lower than 2.0 required by a major change from 1.0")
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"semver", "-check", from, from}, stdout, stderr))
+	assert.Equal(t, "bump: none, 1.0 -> 1.0\n", stdout.String())
+	assert.Equal(t, exitUsage, run([]string{"semver", from}, stdout, stderr))
+}
@@ -0,0 +1,134 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"expvar"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/metrics"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/policy"
+	"github.com/serverlessworkflow/sdk-go/v2/service"
+	"github.com/serverlessworkflow/sdk-go/v2/service/servicepb"
+	"google.golang.org/grpc"
+)
+
+// grpcMessageOverhead room left in the gRPC messages for the request fields besides the workflow
+const grpcMessageOverhead = 64 << 10
+
+// servedMetrics metrics of the last serve with -metrics, published once as the workflows expvar
+var (
+	servedMetrics      atomic.Value
+	publishMetricsOnce sync.Once
+)
+
+func init() {
+	registerCommand(&command{name: "serve", summary: "serve the validation, conversion and diagrams over HTTP and gRPC", run: runServe})
+}
+
+func runServe(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	addr := flags.String("addr", ":8080", "address to listen on")
+	grpcAddr := flags.String("grpc-addr", "", "address to serve the gRPC service on, not served if empty")
+	policyFiles := flags.String("policy", "", "comma separated Rego or CUE policy files the workflows must follow, evaluated with the opa or cue command")
+	maxBodySize := flags.Int64("max-size", service.DefaultMaxBodySize, "maximum size in bytes of the workflows posted")
+	maxDepth := flags.Int("max-depth", 0, "maximum nesting depth of the workflows posted, unlimited if 0")
+	maxStates := flags.Int("max-states", 0, "maximum number of states of the workflows posted, unlimited if 0")
+	maxActions := flags.Int("max-actions", 0, "maximum number of actions of the workflows posted, unlimited if 0")
+	timeout := flags.Duration("parse-timeout", 0, "maximum duration of the parsing of the workflows posted, unlimited if 0")
+	serveMetrics := flags.Bool("metrics", false, "serve the metrics of the parsing of the workflows on /metrics for Prometheus and /debug/vars for expvar")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl serve [flags]")
+		fmt.Fprintln(stderr, "Serves POST /validate, /convert and /graph, and the WorkflowService over gRPC with -grpc-addr, until interrupted.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() > 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	policies, err := policy.FromFiles(splitList(*policyFiles))
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitUsage
+	}
+	opts := service.Options{Policies: policies, MaxBodySize: *maxBodySize, Limits: parser.Limits{
+		MaxDepth: *maxDepth, MaxStates: *maxStates, MaxActions: *maxActions, Timeout: *timeout}}
+	var registry *metrics.Registry
+	if *serveMetrics {
+		registry = metrics.NewRegistry()
+		opts.Metrics = registry
+	}
+	errs := make(chan error, 2)
+	if len(*grpcAddr) > 0 {
+		listener, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			fmt.Fprintf(stderr, "swctl: %v\n", err)
+			return exitError
+		}
+		maxMessageSize := *maxBodySize
+		if maxMessageSize <= 0 {
+			maxMessageSize = service.DefaultMaxBodySize
+		}
+		grpcServer := grpc.NewServer(grpc.MaxRecvMsgSize(int(maxMessageSize) + grpcMessageOverhead))
+		servicepb.RegisterWorkflowServiceServer(grpcServer, service.NewGRPCServer(opts))
+		fmt.Fprintf(stdout, "serving gRPC on %s\n", *grpcAddr)
+		go func() { errs <- grpcServer.Serve(listener) }()
+	}
+	fmt.Fprintf(stdout, "serving on %s\n", *addr)
+	handler := service.NewHandler(opts)
+	if registry != nil {
+		publishMetrics(registry)
+		mux := http.NewServeMux()
+		mux.Handle("/", handler)
+		mux.Handle("/metrics", registry.PrometheusHandler())
+		mux.Handle("/debug/vars", expvar.Handler())
+		handler = mux
+	}
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() { errs <- server.ListenAndServe() }()
+	if err := <-errs; err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// publishMetrics publishes the metrics of the registry as the workflows expvar, replacing the ones of a previous serve
+// since an expvar can only be published once
+func publishMetrics(registry *metrics.Registry) {
+	servedMetrics.Store(registry.Expvar())
+	publishMetricsOnce.Do(func() {
+		expvar.Publish("workflows", expvar.Func(func() interface{} {
+			return json.RawMessage(servedMetrics.Load().(expvar.Var).String())
+		}))
+	})
+}
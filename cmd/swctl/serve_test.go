@@ -0,0 +1,34 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"expvar"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishMetrics(t *testing.T) {
+	first, second := metrics.NewRegistry(), metrics.NewRegistry()
+	first.Add(metrics.Parses, 1)
+	publishMetrics(first)
+	assert.Contains(t, expvar.Get("workflows").String(), `"workflow_parses_total":1`)
+
+	// a second serve publishes its own metrics instead of panicking
+	assert.NotPanics(t, func() { publishMetrics(second) })
+	assert.Equal(t, "{}", expvar.Get("workflows").String())
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/serverlessworkflow/sdk-go/v2/analysis"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+func init() {
+	registerCommand(&command{name: "unused", summary: "find and prune unused workflow definitions", run: runUnused})
+}
+
+func runUnused(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("unused", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	prune := flags.Bool("prune", false, "print the workflow without the unused definitions")
+	write := flags.Bool("w", false, "with -prune, write the result to the input file instead of the standard output")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl unused [flags] <file>")
+		fmt.Fprintln(stderr, "The unused functions, events, errors, retries and secrets are printed to the standard error with -prune,")
+		fmt.Fprintln(stderr, "otherwise to the standard output and the exit status is 1 if there are any.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 || (*write && !*prune) {
+		flags.Usage()
+		return exitUsage
+	}
+	input := flags.Arg(0)
+	workflow, err := parser.FromFile(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, fileError(input, err))
+		return exitError
+	}
+	unused, err := analysis.UnusedDefinitions(workflow)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, err)
+		return exitError
+	}
+	if !*prune {
+		for _, definition := range unused {
+			fmt.Fprintf(stdout, "%s: %s\n", input, definition)
+		}
+		if len(unused) > 0 {
+			return exitError
+		}
+		return exitOK
+	}
+
+	format, err := serializer.FormatFromPath(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitUsage
+	}
+	data, err := ioutil.ReadFile(input)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	pruned, skipped, err := analysis.Prune(data, format, unused)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %s: %v\n", input, err)
+		return exitError
+	}
+	for _, definition := range unused {
+		fmt.Fprintf(stderr, "%s: %s\n", input, definition)
+	}
+	for _, definition := range skipped {
+		fmt.Fprintf(stderr, "%s: %s: declared in another file, not pruned\n", input, definition.Path)
+	}
+	if *write {
+		err = ioutil.WriteFile(input, pruned, 0644)
+	} else {
+		_, err = stdout.Write(pruned)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunUnused(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "greeting.sw.yaml")
+	assert.NoError(t, ioutil.WriteFile(file, []byte(`id: greeting
+name: Greeting
+version: '1.0'
+specVersion: '0.7'
+start: Greet
+functions:
+- name: greet
+  operation: http://greet.json#greet
+- name: legacy
+  operation: http://greet.json#legacy
+states:
+- name: Greet
+  type: operation
+  actions:
+  - functionRef: greet
+  end: true
+`), 0600))
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitError, run([]string{"unused", file}, stdout, stderr))
+	assert.Equal(t, file+": functions[1]: function legacy is never used\n", stdout.String())
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"unused", "-prune", "-w", file}, stdout, stderr))
+	assert.Empty(t, stdout.String())
+	data, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "legacy")
+
+	assert.Equal(t, exitOK, run([]string{"unused", file}, stdout, stderr))
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, exitUsage, run([]string{"unused", "-w", file}, stdout, stderr))
+}
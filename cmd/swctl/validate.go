@@ -0,0 +1,234 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/annotation"
+	"github.com/serverlessworkflow/sdk-go/v2/integrity"
+	"github.com/serverlessworkflow/sdk-go/v2/internal/pool"
+	"github.com/serverlessworkflow/sdk-go/v2/message"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/policy"
+	"github.com/serverlessworkflow/sdk-go/v2/watch"
+)
+
+func init() {
+	registerCommand(&command{name: "validate", summary: "validate workflow files or directories", run: runValidate})
+}
+
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	include := flags.String("include", "", "file name pattern of the workflows to validate in directories, e.g. '*.sw.yaml'")
+	quiet := flags.Bool("q", false, "only print the errors")
+	format := flags.String("format", formatText, "output format, text, github for GitHub Actions annotations or gitlab for a GitLab Code Quality report")
+	watchFiles := flags.Bool("watch", false, "keep watching the files and print the errors found and fixed on every change, until interrupted")
+	interval := flags.Duration("interval", watch.DefaultInterval, "interval between two scans of the watched files")
+	workers := flags.Int("workers", 0, "number of files validated in parallel, default is the number of CPUs")
+	policyFiles := flags.String("policy", "", "comma separated Rego or CUE policy files the workflows must follow, evaluated with the opa or cue command")
+	messagesFile := flags.String("messages", "", "YAML or JSON message catalog translating the messages, their templates by message ID")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: swctl validate [flags] <file|dir>...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() == 0 || !validFormat(*format) {
+		flags.Usage()
+		return exitUsage
+	}
+	policies, err := policy.FromFiles(splitList(*policyFiles))
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitUsage
+	}
+	translator, err := loadMessages(*messagesFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitUsage
+	}
+	validateFile := fileValidator(policies, translator)
+	if *watchFiles {
+		return watchValidate(flags.Args(), *include, *interval, messages(validateFile), stdout, stderr)
+	}
+	files, err := collectFiles(flags.Args(), *include)
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+
+	// only the errors found are kept, the workflows are dropped once validated
+	found := make([][]annotation.Annotation, len(files))
+	pool.Run(len(files), *workers, func(i int) {
+		found[i] = validateFile(files[i])
+	})
+	invalid := 0
+	var annotations []annotation.Annotation
+	for _, fileAnnotations := range found {
+		if len(fileAnnotations) > 0 {
+			invalid++
+		}
+		annotations = append(annotations, fileAnnotations...)
+	}
+	if err := writeAnnotations(stdout, *format, annotations); err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	if !*quiet && *format == formatText {
+		fmt.Fprintf(stdout, "%d file(s) validated, %d invalid\n", len(files), invalid)
+	}
+	if invalid > 0 {
+		return exitError
+	}
+	return exitOK
+}
+
+// watchContext returns the context of the watch, done when the process is interrupted
+var watchContext = func() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		select {
+		case <-interrupt:
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(interrupt)
+	}()
+	return ctx, cancel
+}
+
+func watchValidate(paths []string, include string, interval time.Duration, validateFile func(string) []string, stdout, stderr io.Writer) int {
+	ctx, cancel := watchContext()
+	defer cancel()
+	watcher := &watch.Watcher{
+		List:     func() ([]string, error) { return collectFiles(paths, include) },
+		Interval: interval,
+	}
+	diagnostics := watch.NewDiagnostics(validateFile)
+	err := watcher.Watch(ctx, func(changes []watch.Change) {
+		for _, delta := range diagnostics.Update(changes) {
+			if delta.Removed {
+				fmt.Fprintf(stdout, "%s: removed\n", delta.Path)
+				continue
+			}
+			for _, message := range delta.Fixed {
+				fmt.Fprintf(stdout, "%s: fixed: %s\n", delta.Path, message)
+			}
+			for _, message := range delta.Added {
+				fmt.Fprintf(stdout, "%s: %s\n", delta.Path, message)
+			}
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "swctl: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// fileValidator returns a function parsing and validating a workflow file against the policies, and returning the
+// errors found, their messages translated by the translator if not nil
+func fileValidator(policies []policy.Policy, translator message.Translator) func(file string) []annotation.Annotation {
+	return func(file string) []annotation.Annotation {
+		workflow, err := parser.FromFile(file)
+		if err != nil {
+			return errorAnnotations(file, err, translator)
+		}
+		annotations := errorAnnotations(file, integrity.Validate(workflow), translator)
+		violations, err := policy.Evaluate(context.Background(), workflow, policies...)
+		if err != nil {
+			return append(annotations, annotation.Annotation{File: file, Message: err.Error()})
+		}
+		for _, violation := range violations {
+			annotations = append(annotations, annotation.Annotation{File: file, Path: violation.Path, Title: violation.Policy, Message: violation.Message})
+		}
+		return annotations
+	}
+}
+
+// messages returns a function returning the messages of the errors found by the file validator
+func messages(validateFile func(file string) []annotation.Annotation) func(file string) []string {
+	return func(file string) []string {
+		var messages []string
+		for _, a := range validateFile(file) {
+			messages = append(messages, a.String())
+		}
+		return messages
+	}
+}
+
+// loadMessages returns the message catalog of the file, nil if there's no file
+func loadMessages(path string) (message.Translator, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+	return message.LoadCatalog(path)
+}
+
+func errorAnnotations(file string, err error, translator message.Translator) []annotation.Annotation {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case integrity.Errors:
+		if len(e) == 0 {
+			return nil
+		}
+		annotations := make([]annotation.Annotation, len(e))
+		for i, violation := range e {
+			annotations[i] = annotation.Annotation{File: file, Path: violation.Path, Message: violation.Localize(translator)}
+		}
+		return annotations
+	}
+	var schemaErr *model.SchemaError
+	if errors.As(err, &schemaErr) {
+		var annotations []annotation.Annotation
+		for _, fieldErr := range schemaErr.Errors {
+			// the embedded base workflow is an implementation detail
+			field := strings.TrimPrefix(fieldErr.NameNamespace, "BaseWorkflow.")
+			m := message.Message{ID: message.Validation + "." + fieldErr.Tag, Args: map[string]interface{}{"field": field, "tag": fieldErr.Tag, "param": fieldErr.Param}}
+			rendered, _ := m.Render(translator)
+			annotations = append(annotations, annotation.Annotation{File: file, Path: field, Message: rendered})
+		}
+		sort.Slice(annotations, func(i, j int) bool { return annotations[i].String() < annotations[j].String() })
+		return annotations
+	}
+	return []annotation.Annotation{{File: file, Message: fileError(file, err).Error()}}
+}
+
+// fileError returns the error of the parsing of the file without the file, printed before it
+func fileError(file string, err error) error {
+	var parseErr *parser.Error
+	if !errors.As(err, &parseErr) || parseErr.Source != file {
+		return err
+	}
+	located := *parseErr
+	located.Source = ""
+	return &located
+}
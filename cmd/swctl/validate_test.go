@@ -0,0 +1,135 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/policy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunValidate(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	code := run([]string{"validate", "../../parser/testdata/workflows/greetings.sw.yaml", "../../parser/testdata/workflows/eventbasedswitch.sw.json"}, stdout, stderr)
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "2 file(s) validated, 0 invalid\n", stdout.String())
+
+	stdout.Reset()
+	code = run([]string{"validate", "-include", "*.authdupl.json", "../../parser/testdata/workflows"}, stdout, stderr)
+	assert.Equal(t, exitError, code)
+	assert.Equal(t, "../../parser/testdata/workflows/witherrors/applicationrequest.authdupl.json: Auth.name: failed on the 'reqnameunique' validation\n"+
+		"1 file(s) validated, 1 invalid\n", stdout.String())
+
+	dir, err := ioutil.TempDir("", "swctl")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	messages := filepath.Join(dir, "fr.yaml")
+	assert.NoError(t, ioutil.WriteFile(messages, []byte("validate: \"a échoué à la validation '{{.tag}}'\"\n"), 0600))
+	stdout.Reset()
+	code = run([]string{"validate", "-messages", messages, "-include", "*.authdupl.json", "../../parser/testdata/workflows"}, stdout, stderr)
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stdout.String(), "applicationrequest.authdupl.json: Auth.name: a échoué à la validation 'reqnameunique'\n")
+	assert.Equal(t, exitUsage, run([]string{"validate", "-messages", filepath.Join(dir, "missing.yaml"), "."}, stdout, stderr))
+
+	stdout.Reset()
+	code = run([]string{"validate", "-q", "../../parser/testdata/workflows/patientonboarding.sw.yaml"}, stdout, stderr)
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stdout.String(), "patientonboarding.sw.yaml: states[0].onEvents[0].eventRefs[0]: event NewPatientEvent is not defined\n")
+	assert.NotContains(t, stdout.String(), "validated")
+
+	assert.Equal(t, exitUsage, run([]string{"validate"}, stdout, stderr))
+	assert.Equal(t, exitError, run([]string{"validate", "missing.yaml"}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"unknown"}, stdout, stderr))
+	assert.Equal(t, exitUsage, run(nil, stdout, stderr))
+	assert.Contains(t, stderr.String(), "validate   validate workflow files or directories")
+}
+
+func TestRunValidateWorkers(t *testing.T) {
+	var outputs []string
+	for _, workers := range []string{"1", "8"} {
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+		code := run([]string{"validate", "-workers", workers, "-include", "*.sw.*", "../../parser/testdata/workflows"}, stdout, stderr)
+		assert.Equal(t, exitError, code)
+		outputs = append(outputs, stdout.String())
+	}
+	// the errors are reported in the order of the files whatever the number of workers
+	assert.Equal(t, outputs[0], outputs[1])
+	assert.Contains(t, outputs[0], "file(s) validated")
+}
+
+func TestRunValidateFormat(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	file := "../../parser/testdata/workflows/patientonboarding.sw.yaml"
+	assert.Equal(t, exitError, run([]string{"validate", "-format", "github", file}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "::error file="+file+",line=25,col=13::states[0].onEvents[0].eventRefs[0]: event NewPatientEvent is not defined\n")
+	assert.NotContains(t, stdout.String(), "validated")
+
+	stdout.Reset()
+	assert.Equal(t, exitError, run([]string{"validate", "-format", "gitlab", file}, stdout, stderr))
+	assert.Contains(t, stdout.String(), `"description": "states[0].onEvents[0].eventRefs[0]: event NewPatientEvent is not defined"`)
+	assert.Equal(t, exitUsage, run([]string{"validate", "-format", "xml", file}, stdout, stderr))
+}
+
+func TestRunValidatePolicy(t *testing.T) {
+	defer func(command string) { policy.OPACommand = command }(policy.OPACommand)
+	dir, err := ioutil.TempDir("", "swctl")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	policy.OPACommand = filepath.Join(dir, "opa")
+	script := "#!/bin/sh\ncat > /dev/null\necho '{\"result\": [{\"expressions\": [{\"value\": [\"functions must use an authRef\"]}]}]}'\n"
+	assert.NoError(t, ioutil.WriteFile(policy.OPACommand, []byte(script), 0700))
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	file := "../../parser/testdata/workflows/greetings.sw.yaml"
+	assert.Equal(t, exitError, run([]string{"validate", "-policy", "auth.rego", file}, stdout, stderr))
+	assert.Equal(t, file+": functions must use an authRef (data.workflow.deny)\n1 file(s) validated, 1 invalid\n", stdout.String())
+	assert.Equal(t, exitUsage, run([]string{"validate", "-policy", "auth.txt", file}, stdout, stderr))
+}
+
+func TestRunValidateWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swctl")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	invalid, err := ioutil.ReadFile("../../parser/testdata/workflows/eventbasedgreetingexclusive.sw.json")
+	assert.NoError(t, err)
+	valid, err := ioutil.ReadFile("../../parser/testdata/workflows/greetings.sw.json")
+	assert.NoError(t, err)
+	file := filepath.Join(dir, "greeting.sw.json")
+	assert.NoError(t, ioutil.WriteFile(file, invalid, 0600))
+
+	defer func(f func() (context.Context, context.CancelFunc)) { watchContext = f }(watchContext)
+	watchContext = func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(context.Background(), 500*time.Millisecond)
+	}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		// rename, so the file is never read half written
+		tmp := filepath.Join(dir, "greeting.tmp")
+		_ = ioutil.WriteFile(tmp, valid, 0600)
+		_ = os.Rename(tmp, file)
+	}()
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	assert.Equal(t, exitOK, run([]string{"validate", "-watch", "-interval", "10ms", dir}, stdout, stderr))
+	message := "states[0].onEvents[1].actions[0].functionRef.refName: function greetingFunction2 is not defined, did you mean greetingFunction?"
+	assert.Equal(t, file+": "+message+"\n"+file+": fixed: "+message+"\n", stdout.String())
+}
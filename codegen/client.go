@@ -0,0 +1,360 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/resolver"
+)
+
+// DefaultPackage package name of the generated code when none is given
+const DefaultPackage = "client"
+
+// ClientOptions options to generate the workflow functions client
+type ClientOptions struct {
+	// Package name of the generated file. Default is 'client'
+	Package string
+	// Loader used to load the OpenAPI documents and proto files referenced by the functions. See resolver.NewLoader
+	Loader resolver.Loader
+	// ProtoGoPackages Go import path of the proto packages whose files don't declare the go_package option,
+	// keyed by proto package name
+	ProtoGoPackages map[string]string
+}
+
+type clientData struct {
+	Package  string
+	Workflow string
+	Imports  []goImport
+	REST     []restFunction
+	RPC      []rpcFunction
+}
+
+type goImport struct {
+	Alias string
+	Path  string
+}
+
+type restFunction struct {
+	Name    string
+	GoName  string
+	Method  string
+	Path    string
+	Server  string
+	Params  []restParam
+	HasBody bool
+}
+
+type restParam struct {
+	Name     string
+	GoName   string
+	In       string
+	GoType   string
+	Required bool
+}
+
+type rpcFunction struct {
+	Name       string
+	GoName     string
+	FullMethod string
+	InputType  string
+	OutputType string
+}
+
+// GenerateClient generates the Go source of a client with a typed invoker for each rest and rpc function declared
+// in the workflow. Rest invokers are described by the referenced OpenAPI operations and rpc invokers call the
+// referenced gRPC methods using the message types generated by protoc-gen-go. Other function types are ignored.
+func GenerateClient(workflow *model.Workflow, opts ClientOptions) ([]byte, error) {
+	data := clientData{Package: opts.Package, Workflow: workflow.ID}
+	if len(data.Package) == 0 {
+		data.Package = DefaultPackage
+	}
+	imports := map[string]string{}
+	openAPI := resolver.NewOpenAPIResolver(opts.Loader)
+	rpc := resolver.NewRPCResolver(opts.Loader)
+
+	for _, function := range workflow.Functions {
+		switch function.Type {
+		case "", model.FunctionTypeREST:
+			operation, err := openAPI.Resolve(function)
+			if err != nil {
+				return nil, err
+			}
+			data.REST = append(data.REST, newRESTFunction(function, operation))
+		case model.FunctionTypeRPC:
+			method, err := rpc.Resolve(function)
+			if err != nil {
+				return nil, err
+			}
+			f, err := newRPCFunction(function, method, opts.ProtoGoPackages, imports)
+			if err != nil {
+				return nil, err
+			}
+			data.RPC = append(data.RPC, f)
+		}
+	}
+	if len(data.REST) > 0 || len(data.RPC) > 0 {
+		imports["context"] = ""
+	}
+	if len(data.REST) > 0 {
+		for _, pkg := range []string{"bytes", "encoding/json", "fmt", "io/ioutil", "net/http", "net/url", "strings"} {
+			imports[pkg] = ""
+		}
+	}
+	if len(data.RPC) > 0 {
+		imports["google.golang.org/grpc"] = ""
+	}
+	for importPath, alias := range imports {
+		data.Imports = append(data.Imports, goImport{Alias: alias, Path: importPath})
+	}
+	sort.Slice(data.Imports, func(i, j int) bool { return data.Imports[i].Path < data.Imports[j].Path })
+
+	buf := new(bytes.Buffer)
+	if err := clientTemplate.Execute(buf, data); err != nil {
+		return nil, err
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated client is not valid Go code: %w", err)
+	}
+	return source, nil
+}
+
+func newRESTFunction(function model.Function, operation *resolver.OpenAPIOperation) restFunction {
+	f := restFunction{
+		Name:    function.Name,
+		GoName:  GoName(function.Name),
+		Method:  operation.Method,
+		Path:    operation.Path,
+		HasBody: operation.RequestBody != nil,
+	}
+	if len(operation.Servers) > 0 {
+		f.Server = strings.TrimSuffix(operation.Servers[0], "/")
+	}
+	for _, parameter := range operation.Parameters {
+		f.Params = append(f.Params, restParam{
+			Name:     parameter.Name,
+			GoName:   GoName(parameter.Name),
+			In:       parameter.In,
+			GoType:   schemaGoType(parameter.Schema),
+			Required: parameter.Required,
+		})
+	}
+	return f
+}
+
+// schemaGoType maps the JSON schema of a parameter to a Go type
+func schemaGoType(schema json.RawMessage) string {
+	s := struct {
+		Type  string          `json:"type"`
+		Items json.RawMessage `json:"items"`
+	}{}
+	if len(schema) == 0 || json.Unmarshal(schema, &s) != nil {
+		return "string"
+	}
+	switch s.Type {
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + schemaGoType(s.Items)
+	case "object":
+		return "map[string]interface{}"
+	}
+	return "string"
+}
+
+func newRPCFunction(function model.Function, method *resolver.RPCMethod, goPackages map[string]string, imports map[string]string) (rpcFunction, error) {
+	if method.Method.IsClientStreaming() || method.Method.IsServerStreaming() {
+		return rpcFunction{}, fmt.Errorf("function %s: streaming method %s is not supported", function.Name, method.FullMethodName())
+	}
+	input, err := messageGoType(method.InputType(), goPackages, imports)
+	if err != nil {
+		return rpcFunction{}, fmt.Errorf("function %s: %w", function.Name, err)
+	}
+	output, err := messageGoType(method.OutputType(), goPackages, imports)
+	if err != nil {
+		return rpcFunction{}, fmt.Errorf("function %s: %w", function.Name, err)
+	}
+	return rpcFunction{
+		Name:       function.Name,
+		GoName:     GoName(function.Name),
+		FullMethod: method.FullMethodName(),
+		InputType:  input,
+		OutputType: output,
+	}, nil
+}
+
+// messageGoType returns the qualified Go type generated by protoc-gen-go for the given message and registers its import
+func messageGoType(message *desc.MessageDescriptor, goPackages map[string]string, imports map[string]string) (string, error) {
+	file := message.GetFile()
+	importPath, alias := file.GetFileOptions().GetGoPackage(), ""
+	if i := strings.Index(importPath, ";"); i >= 0 {
+		importPath, alias = importPath[:i], importPath[i+1:]
+	}
+	if len(importPath) == 0 {
+		importPath = goPackages[file.GetPackage()]
+	}
+	if len(importPath) == 0 {
+		return "", fmt.Errorf("go package of proto package %s unknown, set the go_package option or ProtoGoPackages", file.GetPackage())
+	}
+	if len(alias) == 0 {
+		alias = strings.NewReplacer("-", "", ".", "").Replace(path.Base(importPath))
+	}
+	if existing, ok := imports[importPath]; ok {
+		alias = existing
+	} else {
+		imports[importPath] = alias
+	}
+	// nested messages are generated as Parent_Child
+	name := strings.TrimPrefix(message.GetFullyQualifiedName(), file.GetPackage()+".")
+	return "*" + alias + "." + strings.ReplaceAll(name, ".", "_"), nil
+}
+
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by the Serverless Workflow Go SDK. DO NOT EDIT.
+
+// Package {{ .Package }} contains typed invokers for the functions of the workflow {{ .Workflow }}.
+package {{ .Package }}
+
+import (
+{{- range .Imports }}
+	{{ .Alias }} "{{ .Path }}"
+{{- end }}
+)
+
+// Client invokes the workflow functions
+type Client struct {
+{{- if .REST }}
+	// HTTPClient used to invoke the rest functions. Default is http.DefaultClient
+	HTTPClient *http.Client
+	// BaseURL if set, overrides the servers declared in the OpenAPI documents
+	BaseURL string
+{{- end }}
+{{- if .RPC }}
+	// Conn connection used to invoke the rpc functions
+	Conn grpc.ClientConnInterface
+{{- end }}
+}
+{{ range .REST }}
+// {{ .GoName }}Params parameters of the {{ .Name }} function
+type {{ .GoName }}Params struct {
+{{- range .Params }}
+	// {{ .GoName }} {{ .In }} parameter {{ .Name }}{{ if not .Required }}, optional{{ end }}
+	{{ .GoName }} {{ if not .Required }}*{{ end }}{{ .GoType }}
+{{- end }}
+{{- if .HasBody }}
+	// Body request body, encoded as JSON
+	Body interface{}
+{{- end }}
+}
+
+// {{ .GoName }} invokes the {{ .Name }} function: {{ .Method }} {{ .Path }}
+func (c *Client) {{ .GoName }}(ctx context.Context, params {{ .GoName }}Params) (json.RawMessage, error) {
+	path := "{{ .Path }}"
+	query := url.Values{}
+	header := http.Header{}
+{{- range .Params }}
+{{- if .Required }}
+	{{ template "param" . }}
+{{- else }}
+	if params.{{ .GoName }} != nil {
+		{{ template "param" . }}
+	}
+{{- end }}
+{{- end }}
+	var body interface{}
+{{- if .HasBody }}
+	body = params.Body
+{{- end }}
+	return c.invoke(ctx, "{{ .Method }}", "{{ .Server }}", path, query, header, body)
+}
+{{ end }}
+{{- if .REST }}
+func (c *Client) invoke(ctx context.Context, method, server, path string, query url.Values, header http.Header, body interface{}) (json.RawMessage, error) {
+	if len(c.BaseURL) > 0 {
+		server = strings.TrimSuffix(c.BaseURL, "/")
+	}
+	target := server + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	reader := bytes.NewReader(nil)
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+		header.Set("Content-Type", "application/json")
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("%s %s failed: %s", method, target, resp.Status)
+	}
+	return data, nil
+}
+{{ end }}
+{{- range .RPC }}
+// {{ .GoName }} invokes the {{ .Name }} function: {{ .FullMethod }}
+func (c *Client) {{ .GoName }}(ctx context.Context, in {{ .InputType }}, opts ...grpc.CallOption) ({{ .OutputType }}, error) {
+	out := new({{ slice .OutputType 1 }})
+	if err := c.Conn.Invoke(ctx, "{{ .FullMethod }}", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+{{ end }}
+{{- define "param" }}
+{{- $value := printf "params.%s" .GoName }}{{ if not .Required }}{{ $value = printf "*params.%s" .GoName }}{{ end }}
+{{- if eq .In "path" }}path = strings.ReplaceAll(path, "{{ printf "{%s}" .Name }}", url.PathEscape(fmt.Sprint({{ $value }})))
+{{- else if eq .In "query" }}query.Add("{{ .Name }}", fmt.Sprint({{ $value }}))
+{{- else if eq .In "header" }}header.Add("{{ .Name }}", fmt.Sprint({{ $value }}))
+{{- else if eq .In "cookie" }}header.Add("Cookie", "{{ .Name }}="+url.QueryEscape(fmt.Sprint({{ $value }})))
+{{- else }}query.Add("{{ .Name }}", fmt.Sprint({{ $value }}))
+{{- end }}
+{{- end }}
+`))
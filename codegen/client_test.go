@@ -0,0 +1,76 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoName(t *testing.T) {
+	assert.Equal(t, "ListPets", GoName("listPets"))
+	assert.Equal(t, "PetID", GoName("petId"))
+	assert.Equal(t, "XRequestID", GoName("X-Request-Id"))
+	assert.Equal(t, "GetUserURL", GoName("get_user_url"))
+	assert.Equal(t, "X1pets", GoName("1pets"))
+}
+
+func TestGenerateClient(t *testing.T) {
+	workflow := &model.Workflow{
+		BaseWorkflow: model.BaseWorkflow{ID: "petstore"},
+		Functions: []model.Function{
+			{Name: "listPets", Operation: "petstore.yaml#listPets"},
+			{Name: "showPet", Operation: "petstore.yaml#showPetById", Type: model.FunctionTypeREST},
+			{Name: "createPet", Operation: "petstore.yaml#createPet"},
+			{Name: "getUser", Operation: "userservice.proto#users.UserService#GetUser", Type: model.FunctionTypeRPC},
+			{Name: "count", Operation: ".pets | length", Type: model.FunctionTypeExpression},
+		},
+	}
+	source, err := GenerateClient(workflow, ClientOptions{
+		Package:         "petstore",
+		Loader:          resolver.NewLoader("../resolver/testdata", nil),
+		ProtoGoPackages: map[string]string{"users": "example.com/users"},
+	})
+	assert.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "client.go", source, parser.AllErrors)
+	assert.NoError(t, err)
+
+	code := string(source)
+	assert.Contains(t, code, "package petstore")
+	assert.Contains(t, code, "Limit *int64")
+	assert.Contains(t, code, "PetID string")
+	assert.Contains(t, code, "func (c *Client) ShowPet(ctx context.Context, params ShowPetParams) (json.RawMessage, error)")
+	assert.Contains(t, code, "Body interface{}")
+	assert.Contains(t, code, `users "example.com/users"`)
+	assert.Contains(t, code, "func (c *Client) GetUser(ctx context.Context, in *users.GetUserRequest, opts ...grpc.CallOption) (*users.User, error)")
+	assert.Contains(t, code, `"/users.UserService/GetUser"`)
+	assert.NotContains(t, code, "Count")
+
+	_, err = GenerateClient(workflow, ClientOptions{Loader: resolver.NewLoader("../resolver/testdata", nil)})
+	assert.Error(t, err)
+
+	workflow.Functions = workflow.Functions[4:]
+	source, err = GenerateClient(workflow, ClientOptions{})
+	require.NoError(t, err)
+	file, err := parser.ParseFile(token.NewFileSet(), "client.go", source, parser.AllErrors)
+	require.NoError(t, err)
+	assert.Empty(t, file.Imports)
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"strings"
+	"unicode"
+)
+
+// commonInitialisms identifiers written in upper case in Go names, as golint suggests
+var commonInitialisms = map[string]bool{
+	"API": true, "HTTP": true, "HTTPS": true, "ID": true, "JSON": true, "RPC": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// GoName converts the given name into an exported Go identifier, e.g. "send-email_function" into "SendEmailFunction"
+func GoName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, word := range words {
+		for _, part := range splitCamelCase(word) {
+			upper := strings.ToUpper(part)
+			if commonInitialisms[upper] {
+				b.WriteString(upper)
+				continue
+			}
+			runes := []rune(part)
+			b.WriteRune(unicode.ToUpper(runes[0]))
+			b.WriteString(string(runes[1:]))
+		}
+	}
+	id := b.String()
+	if len(id) == 0 || unicode.IsDigit([]rune(id)[0]) {
+		id = "X" + id
+	}
+	return id
+}
+
+// splitCamelCase splits "listUsersByID" into "list", "Users", "By", "ID"
+func splitCamelCase(word string) []string {
+	var parts []string
+	runes := []rune(word)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		lowerToUpper := unicode.IsLower(runes[i-1]) && unicode.IsUpper(runes[i])
+		acronymEnd := i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i+1])
+		if lowerToUpper || acronymEnd {
+			parts = append(parts, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(parts, string(runes[start:]))
+}
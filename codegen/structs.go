@@ -0,0 +1,360 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/serverlessworkflow/sdk-go/v2/jsonschema"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/resolver"
+)
+
+// EventDataSchemaKey metadata key of the event definitions referencing the JSON schema of the event data
+const EventDataSchemaKey = "dataSchema"
+
+// StructOptions options to generate the workflow data types
+type StructOptions struct {
+	// Package name of the generated file. Default is 'client'
+	Package string
+	// Loader used to load the schemas. See resolver.NewLoader
+	Loader resolver.Loader
+	// Validation generates a Validate method for each struct checking the required properties and enumerations
+	Validation bool
+}
+
+type structsData struct {
+	Package    string
+	Workflow   string
+	Validation bool
+	Structs    []*goStruct
+}
+
+type goStruct struct {
+	Name        string
+	Description string
+	// Alias aliased type, if the schema doesn't describe an object
+	Alias  string
+	Fields []goField
+}
+
+type goField struct {
+	Name        string
+	JSONName    string
+	Type        string
+	Description string
+	Required    bool
+	// Nillable the zero value of the field type is nil
+	Nillable bool
+	// Struct the field type is a generated struct, possibly behind a pointer
+	Struct bool
+	Enum   []string
+}
+
+// GenerateStructs generates the Go source of the types described by the dataInputSchema of the workflow and by the
+// schemas referenced by the 'dataSchema' metadata of its events. The input type is named after the workflow id with
+// an 'Input' suffix and the event types are named after the events with a 'Data' suffix.
+func GenerateStructs(workflow *model.Workflow, opts StructOptions) ([]byte, error) {
+	data := structsData{Package: opts.Package, Workflow: workflow.ID, Validation: opts.Validation}
+	if len(data.Package) == 0 {
+		data.Package = DefaultPackage
+	}
+	g := &structGenerator{names: map[string]bool{}}
+
+	if workflow.DataInputSchema != nil && len(workflow.DataInputSchema.Schema) > 0 {
+		name := workflow.ID
+		if len(name) == 0 {
+			name = workflow.Key
+		}
+		if err := g.generateDocument(opts.Loader, workflow.DataInputSchema.Schema, GoName(name)+"Input"); err != nil {
+			return nil, err
+		}
+	}
+	for _, event := range workflow.Events {
+		uri, ok := event.Metadata[EventDataSchemaKey].(string)
+		if !ok || len(uri) == 0 {
+			continue
+		}
+		if err := g.generateDocument(opts.Loader, uri, GoName(event.Name)+"Data"); err != nil {
+			return nil, fmt.Errorf("event %s: %w", event.Name, err)
+		}
+	}
+	data.Structs = g.structs
+
+	buf := new(bytes.Buffer)
+	if err := structsTemplate.Execute(buf, data); err != nil {
+		return nil, err
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated structs are not valid Go code: %w", err)
+	}
+	return source, nil
+}
+
+type structGenerator struct {
+	root    *jsonschema.Schema
+	structs []*goStruct
+	names   map[string]bool
+	// refs types generated for the referenced schemas of the current document
+	refs map[*jsonschema.Schema]string
+}
+
+func (g *structGenerator) generateDocument(loader resolver.Loader, uri string, name string) error {
+	raw, err := loader.Load(uri)
+	if err != nil {
+		return err
+	}
+	schema, err := jsonschema.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", uri, err)
+	}
+	g.root = schema
+	g.refs = map[*jsonschema.Schema]string{}
+	goType, err := g.goType(schema, name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", uri, err)
+	}
+	if goType != name {
+		// the root isn't an object, alias it so the type can still be referenced by name
+		g.structs = append(g.structs, &goStruct{Name: g.uniqueName(name), Alias: goType, Description: schema.Description})
+	}
+	return nil
+}
+
+// goType returns the Go type of the given schema, generating the structs needed to represent it
+func (g *structGenerator) goType(schema *jsonschema.Schema, nameHint string) (string, error) {
+	if len(schema.Ref) > 0 {
+		referenced, err := g.root.Resolve(schema.Ref)
+		if err != nil {
+			return "", err
+		}
+		if name, ok := g.refs[referenced]; ok {
+			return name, nil
+		}
+		segments := strings.Split(schema.Ref, "/")
+		if name := segments[len(segments)-1]; len(name) > 0 && name != "#" {
+			nameHint = GoName(name)
+		}
+		return g.goType(referenced, nameHint)
+	}
+	if len(schema.AllOf) > 0 {
+		merged, err := g.mergeAllOf(schema)
+		if err != nil {
+			return "", err
+		}
+		return g.goType(merged, nameHint)
+	}
+	switch schema.PrimaryType() {
+	case jsonschema.TypeObject:
+		if len(schema.Properties) == 0 {
+			return g.mapType(schema, nameHint)
+		}
+		return g.generateStruct(schema, nameHint)
+	case jsonschema.TypeArray:
+		if schema.Items == nil {
+			return "[]interface{}", nil
+		}
+		item, err := g.goType(schema.Items, nameHint+"Item")
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case jsonschema.TypeString:
+		return "string", nil
+	case jsonschema.TypeInteger:
+		return "int64", nil
+	case jsonschema.TypeNumber:
+		return "float64", nil
+	case jsonschema.TypeBoolean:
+		return "bool", nil
+	}
+	return "interface{}", nil
+}
+
+func (g *structGenerator) mapType(schema *jsonschema.Schema, nameHint string) (string, error) {
+	additional := &jsonschema.Schema{}
+	if len(schema.AdditionalProperties) == 0 || json.Unmarshal(schema.AdditionalProperties, additional) != nil {
+		return "map[string]interface{}", nil
+	}
+	value, err := g.goType(additional, nameHint+"Value")
+	if err != nil {
+		return "", err
+	}
+	return "map[string]" + value, nil
+}
+
+// mergeAllOf merges the properties of the allOf schemas into a single object schema
+func (g *structGenerator) mergeAllOf(schema *jsonschema.Schema) (*jsonschema.Schema, error) {
+	merged := *schema
+	merged.AllOf = nil
+	merged.Properties = map[string]*jsonschema.Schema{}
+	merged.Required = append([]string{}, schema.Required...)
+	for name, property := range schema.Properties {
+		merged.Properties[name] = property
+	}
+	for _, sub := range schema.AllOf {
+		for len(sub.Ref) > 0 {
+			referenced, err := g.root.Resolve(sub.Ref)
+			if err != nil {
+				return nil, err
+			}
+			sub = referenced
+		}
+		if len(sub.AllOf) > 0 {
+			var err error
+			if sub, err = g.mergeAllOf(sub); err != nil {
+				return nil, err
+			}
+		}
+		for name, property := range sub.Properties {
+			merged.Properties[name] = property
+		}
+		merged.Required = append(merged.Required, sub.Required...)
+		if len(merged.Type) == 0 {
+			merged.Type = sub.Type
+		}
+	}
+	return &merged, nil
+}
+
+func (g *structGenerator) generateStruct(schema *jsonschema.Schema, nameHint string) (string, error) {
+	name := g.uniqueName(nameHint)
+	g.refs[schema] = name
+	s := &goStruct{Name: name, Description: schema.Description}
+	// the struct is registered before its fields so nested types are declared after it
+	g.structs = append(g.structs, s)
+
+	properties := make([]string, 0, len(schema.Properties))
+	for property := range schema.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+	fieldNames := map[string]bool{}
+	for _, property := range properties {
+		propertySchema := schema.Properties[property]
+		fieldName := GoName(property)
+		for i := 2; fieldNames[fieldName]; i++ {
+			fieldName = GoName(property) + strconv.Itoa(i)
+		}
+		fieldNames[fieldName] = true
+
+		goType, err := g.goType(propertySchema, name+fieldName)
+		if err != nil {
+			return "", err
+		}
+		field := goField{
+			Name:        fieldName,
+			JSONName:    property,
+			Description: propertySchema.Description,
+			Required:    schema.IsRequired(property),
+			Struct:      g.names[goType],
+		}
+		switch {
+		case strings.HasPrefix(goType, "[]"), strings.HasPrefix(goType, "map["), goType == "interface{}":
+			field.Nillable = true
+		case field.Struct || !field.Required:
+			// optional values and nested structs are pointers to tell apart absent and zero values
+			goType = "*" + goType
+			field.Nillable = true
+		}
+		field.Type = goType
+		for _, value := range propertySchema.Enum {
+			if literal, ok := value.(string); ok && (goType == "string" || goType == "*string") {
+				field.Enum = append(field.Enum, strconv.Quote(literal))
+			}
+		}
+		s.Fields = append(s.Fields, field)
+	}
+	return name, nil
+}
+
+func (g *structGenerator) uniqueName(name string) string {
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	g.names[unique] = true
+	return unique
+}
+
+var structsTemplate = template.Must(template.New("structs").Parse(`// Code generated by the Serverless Workflow Go SDK. DO NOT EDIT.
+
+// Package {{ .Package }} contains the data types of the workflow {{ .Workflow }}.
+package {{ .Package }}
+{{ if .Validation }}
+import "fmt"
+{{ end }}
+{{- range .Structs }}
+{{- if not .Alias }}
+// {{ .Name }} {{ if .Description }}{{ .Description }}{{ else }}...{{ end }}
+type {{ .Name }} struct {
+{{- range .Fields }}
+{{- if .Description }}
+	// {{ .Name }} {{ .Description }}
+{{- end }}
+	{{ .Name }} {{ .Type }} ` + "`" + `json:"{{ .JSONName }}{{ if not .Required }},omitempty{{ end }}"` + "`" + `
+{{- end }}
+}
+{{ if $.Validation }}
+// Validate checks the required properties and enumerations of {{ .Name }}
+func (v *{{ .Name }}) Validate() error {
+{{- range .Fields }}
+{{- if and .Required .Nillable }}
+	if v.{{ .Name }} == nil {
+		return fmt.Errorf("{{ .JSONName }} is required")
+	}
+{{- end }}
+{{- if .Enum }}
+	{{- $value := printf "v.%s" .Name }}
+	{{- if .Nillable }}{{ $value = printf "*v.%s" .Name }}{{ end }}
+	{{ if .Nillable }}if v.{{ .Name }} != nil {
+	{{ end }}switch {{ $value }} {
+	case {{ range $i, $e := .Enum }}{{ if $i }}, {{ end }}{{ $e }}{{ end }}:
+	default:
+		return fmt.Errorf("{{ .JSONName }} %q is not one of the allowed values", {{ $value }})
+	}
+	{{- if .Nillable }}
+	}
+	{{- end }}
+{{- end }}
+{{- if and .Struct .Required }}
+	if err := v.{{ .Name }}.Validate(); err != nil {
+		return fmt.Errorf("{{ .JSONName }}: %w", err)
+	}
+{{- else if .Struct }}
+	if v.{{ .Name }} != nil {
+		if err := v.{{ .Name }}.Validate(); err != nil {
+			return fmt.Errorf("{{ .JSONName }}: %w", err)
+		}
+	}
+{{- end }}
+{{- end }}
+	return nil
+}
+{{ end }}
+{{- else }}
+// {{ .Name }} {{ if .Description }}{{ .Description }}{{ else }}...{{ end }}
+type {{ .Name }} = {{ .Alias }}
+{{ end }}
+{{- end }}
+`))
@@ -0,0 +1,63 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/resolver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateStructs(t *testing.T) {
+	workflow := &model.Workflow{
+		BaseWorkflow: model.BaseWorkflow{
+			ID:              "order-workflow",
+			DataInputSchema: &model.DataInputSchema{Schema: "order.schema.json"},
+		},
+		Events: []model.Event{
+			{Name: "orderShipped", Type: "order.shipped", Common: model.Common{Metadata: model.Metadata{EventDataSchemaKey: "shipped.schema.yaml"}}},
+			{Name: "orderCancelled", Type: "order.cancelled"},
+		},
+	}
+	source, err := GenerateStructs(workflow, StructOptions{Package: "orders", Loader: resolver.NewLoader("./testdata", nil), Validation: true})
+	assert.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "structs.go", source, parser.AllErrors)
+	assert.NoError(t, err)
+
+	code := string(source)
+	assert.Contains(t, code, "type OrderWorkflowInput struct")
+	assert.Contains(t, code, "// OrderWorkflowInput Order placed by a customer")
+	assert.Regexp(t, "ID\\s+string\\s+`json:\"id\"`", code)
+	assert.Contains(t, code, "Customer *Customer `json:\"customer\"`")
+	assert.Regexp(t, "Items\\s+\\[\\]OrderWorkflowInputItemsItem\\s+`json:\"items\"`", code)
+	assert.Contains(t, code, "Quantity *int64 `json:\"quantity,omitempty\"`")
+	assert.Regexp(t, "Tags\\s+map\\[string\\]string\\s+`json:\"tags,omitempty\"`", code)
+	assert.Contains(t, code, "type OrderShippedData struct")
+	assert.Contains(t, code, "func (v *Customer) Validate() error")
+	assert.Contains(t, code, `case "low", "normal", "high":`)
+	assert.NotContains(t, code, "OrderCancelled")
+
+	source, err = GenerateStructs(workflow, StructOptions{Loader: resolver.NewLoader("./testdata", nil)})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(source), "Validate")
+
+	workflow.DataInputSchema.Schema = "missing.json"
+	_, err = GenerateStructs(workflow, StructOptions{Loader: resolver.NewLoader("./testdata", nil)})
+	assert.Error(t, err)
+}
@@ -0,0 +1,248 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conformance runs the examples of the specification through the SDK: every example is parsed, validated and
+// round tripped, the examples of the previous versions being migrated first, and the results are gathered in a
+// compliance report, detecting the divergences between the SDK and the specification.
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/migration"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/resolver"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+	"github.com/serverlessworkflow/sdk-go/v2/validator"
+	"sigs.k8s.io/yaml"
+)
+
+// Suite examples of a specification version
+type Suite struct {
+	SpecVersion string
+	// URIs of the documents holding the examples: markdown documents, the examples being their JSON and YAML code
+	// blocks defining a workflow, or workflow files
+	URIs []string
+}
+
+// Suites suites of the examples of the specification repository by version
+var Suites = map[string]Suite{
+	"0.7": {SpecVersion: "0.7", URIs: []string{"https://raw.githubusercontent.com/serverlessworkflow/specification/0.7.x/examples/README.md"}},
+	"0.8": {SpecVersion: "0.8", URIs: []string{"https://raw.githubusercontent.com/serverlessworkflow/specification/0.8.x/examples/README.md"}},
+}
+
+// Example workflow of a suite
+type Example struct {
+	// Name of the example, the title of its section in a markdown document and its format, e.g. 'hello-world.json',
+	// the file name of a workflow file
+	Name   string
+	URI    string
+	Format serializer.Format
+	Source []byte
+}
+
+// Stage stage of the run of an example
+type Stage string
+
+const (
+	// StageMigrate migration of the example to the latest version, for the examples of the previous versions
+	StageMigrate Stage = "migrate"
+	// StageParse decoding of the example
+	StageParse Stage = "parse"
+	// StageValidate validation of the decoded example
+	StageValidate Stage = "validate"
+	// StageRoundTrip marshaling of the example parsed again into the same workflow and JSON
+	StageRoundTrip Stage = "roundTrip"
+)
+
+// Result result of the run of an example
+type Result struct {
+	Example string `json:"example"`
+	URI     string `json:"uri"`
+	// Failed stage the example failed at, empty if it passed
+	Failed Stage  `json:"failed,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Passed ...
+func (r Result) Passed() bool {
+	return r.Failed == ""
+}
+
+// Report compliance report of a suite
+type Report struct {
+	SpecVersion string   `json:"specVersion"`
+	Results     []Result `json:"results"`
+}
+
+// Passed number of examples passing
+func (r *Report) Passed() int {
+	passed := 0
+	for _, result := range r.Results {
+		if result.Passed() {
+			passed++
+		}
+	}
+	return passed
+}
+
+// Compliant whether every example passed
+func (r *Report) Compliant() bool {
+	return r.Passed() == len(r.Results)
+}
+
+// Text renders the report in a human readable form, one line per example and a summary
+func (r *Report) Text() string {
+	buf := new(bytes.Buffer)
+	for _, result := range r.Results {
+		if result.Passed() {
+			fmt.Fprintf(buf, "PASS %s\n", result.Example)
+		} else {
+			fmt.Fprintf(buf, "FAIL %s: %s: %s\n", result.Example, result.Failed, result.Error)
+		}
+	}
+	fmt.Fprintf(buf, "specification %s: %d/%d examples passed\n", r.SpecVersion, r.Passed(), len(r.Results))
+	return buf.String()
+}
+
+// Runner runs the suites
+type Runner struct {
+	// Loader of the documents of the suites, e.g. reading a vendored copy of the examples, default loads files relative
+	// to the working directory and http(s) URIs
+	Loader resolver.Loader
+	// Validator of the examples, default is validator.Default()
+	Validator *validator.Validator
+}
+
+// Run runs the suite with the default runner
+func Run(suite Suite) (*Report, error) {
+	return (&Runner{}).Run(suite)
+}
+
+// Run loads the examples of the suite and runs them, failing if a document can't be loaded or holds no example
+func (r *Runner) Run(suite Suite) (*Report, error) {
+	examples, err := r.Load(suite)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{SpecVersion: suite.SpecVersion, Results: make([]Result, 0, len(examples))}
+	for _, example := range examples {
+		report.Results = append(report.Results, r.Check(suite.SpecVersion, example))
+	}
+	return report, nil
+}
+
+// Load loads the examples of the suite
+func (r *Runner) Load(suite Suite) ([]Example, error) {
+	var examples []Example
+	for _, uri := range suite.URIs {
+		data, err := r.loader().Load(uri)
+		if err != nil {
+			return nil, err
+		}
+		var loaded []Example
+		if strings.HasSuffix(uri, ".md") {
+			loaded = Extract(uri, data)
+		} else {
+			loaded = []Example{{Name: path.Base(uri), URI: uri, Format: format(uri), Source: data}}
+		}
+		if len(loaded) == 0 {
+			return nil, fmt.Errorf("no example found in %s", uri)
+		}
+		examples = append(examples, loaded...)
+	}
+	return examples, nil
+}
+
+// Check runs the example of the given specification version: it's migrated to the latest version if older, parsed,
+// validated and round tripped
+func (r *Runner) Check(specVersion string, example Example) Result {
+	result := Result{Example: example.Name, URI: example.URI}
+	fail := func(stage Stage, err error) Result {
+		result.Failed, result.Error = stage, err.Error()
+		return result
+	}
+	source := example.Source
+	if specVersion != migration.LatestVersion {
+		migrated, err := migration.Migrate(source, example.Format, migration.LatestVersion)
+		if err != nil {
+			return fail(StageMigrate, err)
+		}
+		source = migrated.Document
+	}
+	data, err := yaml.YAMLToJSON(source)
+	if err != nil {
+		return fail(StageParse, err)
+	}
+	workflow := &model.Workflow{}
+	if err := json.Unmarshal(data, workflow); err != nil {
+		return fail(StageParse, err)
+	}
+	if err := r.validator().Struct(workflow); err != nil {
+		return fail(StageValidate, err)
+	}
+	if err := roundTrip(workflow); err != nil {
+		return fail(StageRoundTrip, err)
+	}
+	return result
+}
+
+func (r *Runner) loader() resolver.Loader {
+	if r.Loader == nil {
+		return resolver.NewLoader("", nil)
+	}
+	return r.Loader
+}
+
+func (r *Runner) validator() *validator.Validator {
+	if r.Validator == nil {
+		return validator.Default()
+	}
+	return r.Validator
+}
+
+// roundTrip checks that the workflow marshals to JSON decoding into the same workflow and JSON
+func roundTrip(workflow *model.Workflow) error {
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		return err
+	}
+	parsed := &model.Workflow{}
+	if err := json.Unmarshal(data, parsed); err != nil {
+		return fmt.Errorf("marshaled workflow doesn't parse: %w", err)
+	}
+	if !workflow.Equal(parsed) {
+		return fmt.Errorf("workflow changed by a round trip: %s", data)
+	}
+	dataAgain, err := json.Marshal(parsed)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(data, dataAgain) {
+		return fmt.Errorf("JSON changed by a round trip: %s", dataAgain)
+	}
+	return nil
+}
+
+// format format of the workflow file, from its extension
+func format(uri string) serializer.Format {
+	if strings.HasSuffix(uri, ".yaml") || strings.HasSuffix(uri, ".yml") {
+		return serializer.FormatYAML
+	}
+	return serializer.FormatJSON
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	markdown, err := ioutil.ReadFile(filepath.Join("testdata", "0.8", "README.md"))
+	require.NoError(t, err)
+	examples := Extract("README.md", markdown)
+	require.Len(t, examples, 4)
+	assert.Equal(t, "hello-world-example.json", examples[0].Name)
+	assert.Equal(t, serializer.FormatJSON, examples[0].Format)
+	assert.Equal(t, "hello-world-example.yaml", examples[1].Name)
+	assert.Equal(t, serializer.FormatYAML, examples[1].Format)
+	// the indentation of the fence is removed, the data input isn't a workflow
+	assert.Equal(t, "greeting-example.yaml", examples[2].Name)
+	assert.Contains(t, string(examples[2].Source), "id: greeting\nversion: '1.0'\n")
+	assert.Equal(t, "empty-example.json", examples[3].Name)
+
+	examples = Extract("README.md", []byte("## Twice\n```json\n{\"states\": [{}]}\n```\n```json\n{\"states\": [{}]}\n```\n"))
+	require.Len(t, examples, 2)
+	assert.Equal(t, "twice-2.json", examples[1].Name)
+	assert.Empty(t, Extract("README.md", []byte("```text\n{\"states\": [{}]}\n```\n")))
+}
+
+func TestRun(t *testing.T) {
+	suite := Suite{SpecVersion: "0.8", URIs: []string{filepath.Join("testdata", "0.8", "README.md")}}
+	report, err := Run(suite)
+	require.NoError(t, err)
+	require.Len(t, report.Results, 4)
+	assert.Equal(t, 3, report.Passed())
+	assert.False(t, report.Compliant())
+	assert.Equal(t, StageValidate, report.Results[3].Failed)
+	assert.Contains(t, report.Text(), "PASS hello-world-example.json\n")
+	assert.Contains(t, report.Text(), "FAIL empty-example.json: validate: ")
+	assert.Contains(t, report.Text(), "specification 0.8: 3/4 examples passed\n")
+
+	// the examples of the previous versions are migrated
+	report, err = Run(Suite{SpecVersion: "0.7", URIs: []string{filepath.Join("testdata", "0.7", "provisionorder.sw.yaml")}})
+	require.NoError(t, err)
+	assert.True(t, report.Compliant(), report.Text())
+	assert.Equal(t, "provisionorder.sw.yaml", report.Results[0].Example)
+
+	_, err = Run(Suite{SpecVersion: "0.8", URIs: []string{filepath.Join("testdata", "missing.md")}})
+	assert.Error(t, err)
+	_, err = Run(Suite{SpecVersion: "0.8", URIs: []string{filepath.Join("testdata", "0.8", "none.md")}})
+	assert.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	runner := &Runner{}
+	result := runner.Check("0.8", Example{Name: "broken.json", Format: serializer.FormatJSON, Source: []byte(`{"states": [{"type": "wait"}]}`)})
+	assert.Equal(t, StageParse, result.Failed)
+	result = runner.Check("0.6", Example{Name: "broken.json", Format: serializer.FormatJSON, Source: []byte(`{`)})
+	assert.Equal(t, StageMigrate, result.Failed)
+}
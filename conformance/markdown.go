@@ -0,0 +1,108 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+	"sigs.k8s.io/yaml"
+)
+
+// fence opening and closing the code blocks of markdown documents
+const fence = "```"
+
+// maxLevel deepest level of the headings of the sections naming the examples
+const maxLevel = 3
+
+// Extract extracts the examples of the markdown document: its JSON and YAML code blocks defining a workflow, i.e.
+// with states. The examples are named after the heading of their section, of level 2 or 3, the deeper headings being
+// those of its parts like in the documents of the specification, and their format, e.g. 'hello-world-example.yaml',
+// suffixed with their position in the section if it holds several ones of the same format.
+func Extract(uri string, markdown []byte) []Example {
+	var examples []Example
+	names := map[string]int{}
+	heading := "example"
+	var block *bytes.Buffer
+	var blockFormat serializer.Format
+	var indent string
+	scanner := bufio.NewScanner(bytes.NewReader(markdown))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(markdown)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case block != nil && strings.HasPrefix(trimmed, fence):
+			if blockFormat != "" && isWorkflow(block.Bytes()) {
+				name := slug(heading) + "." + string(blockFormat)
+				names[name]++
+				if n := names[name]; n > 1 {
+					name = slug(heading) + "-" + strconv.Itoa(n) + "." + string(blockFormat)
+				}
+				examples = append(examples, Example{Name: name, URI: uri, Format: blockFormat, Source: block.Bytes()})
+			}
+			block = nil
+		case block != nil:
+			// the blocks indented, e.g. in HTML tables, lose the indentation of their fence
+			block.WriteString(strings.TrimPrefix(line, indent))
+			block.WriteByte('\n')
+		case strings.HasPrefix(trimmed, fence):
+			switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(trimmed, fence))) {
+			case "json":
+				block, blockFormat = new(bytes.Buffer), serializer.FormatJSON
+			case "yaml", "yml":
+				block, blockFormat = new(bytes.Buffer), serializer.FormatYAML
+			default:
+				// other blocks are skipped to their closing fence
+				block, blockFormat = new(bytes.Buffer), ""
+			}
+			indent = line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		case strings.HasPrefix(trimmed, "#"):
+			if level := len(trimmed) - len(strings.TrimLeft(trimmed, "#")); level <= maxLevel {
+				heading = strings.TrimSpace(trimmed[level:])
+				names = map[string]int{}
+			}
+		}
+	}
+	return examples
+}
+
+// isWorkflow whether the JSON or YAML code block defines a workflow
+func isWorkflow(block []byte) bool {
+	data, err := yaml.YAMLToJSON(block)
+	if err != nil {
+		return false
+	}
+	var workflow struct {
+		States json.RawMessage `json:"states"`
+	}
+	return json.Unmarshal(data, &workflow) == nil && len(workflow.States) > 0
+}
+
+// slug lower case words of the heading separated by dashes
+func slug(heading string) string {
+	words := strings.FieldsFunc(strings.ToLower(heading), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "example"
+	}
+	return strings.Join(words, "-")
+}
@@ -0,0 +1,238 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coverage
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/diagram"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// StateCoverage ...
+type StateCoverage struct {
+	Name string
+	Hits int
+}
+
+// ActionCoverage coverage of a named action, unnamed actions can't be traced
+type ActionCoverage struct {
+	State string
+	Name  string
+	Hits  int
+}
+
+// BranchCoverage coverage of a way to leave a state: its transition or end, a switch condition or an error handler
+type BranchCoverage struct {
+	diagram.Edge
+	Hits int
+}
+
+// Report coverage of a workflow by a set of traces
+type Report struct {
+	States   []StateCoverage
+	Actions  []ActionCoverage
+	Branches []BranchCoverage
+	// Mismatches steps of the traces that don't match the workflow, e.g. a transition it doesn't define
+	Mismatches []string
+}
+
+// Analyze maps the traces onto the workflow. Traces of another workflow, per their WorkflowID, are ignored.
+func Analyze(workflow *model.Workflow, traces []Trace) *Report {
+	graph := diagram.New(workflow)
+	r := &Report{}
+	states := map[string]int{}
+	for _, state := range workflow.States {
+		states[state.GetName()] = len(r.States)
+		r.States = append(r.States, StateCoverage{Name: state.GetName()})
+		for _, action := range model.GetActions(state) {
+			if len(action.Name) > 0 {
+				r.Actions = append(r.Actions, ActionCoverage{State: state.GetName(), Name: action.Name})
+			}
+		}
+	}
+	for _, edge := range graph.Edges {
+		if edge.Kind != diagram.EdgeCompensation {
+			r.Branches = append(r.Branches, BranchCoverage{Edge: edge})
+		}
+	}
+
+	for i, trace := range traces {
+		if len(trace.WorkflowID) > 0 && trace.WorkflowID != workflow.ID {
+			continue
+		}
+		name := trace.Name
+		if len(name) == 0 {
+			name = fmt.Sprintf("trace %d", i)
+		}
+		// previous nil after an undefined state, the branch leaving it can't be covered
+		previous := &Step{State: diagram.StartNodeID}
+		for j := range trace.Steps {
+			step := &trace.Steps[j]
+			index, ok := states[step.State]
+			if !ok {
+				r.mismatch("%s: steps[%d]: state %s is not defined", name, j, step.State)
+				previous = nil
+				continue
+			}
+			r.States[index].Hits++
+			r.coverActions(name, j, step)
+			if previous != nil {
+				r.coverBranch(name, j, previous, step.State)
+			}
+			previous = step
+		}
+		if trace.Ended && previous != nil && len(trace.Steps) > 0 {
+			r.coverBranch(name, len(trace.Steps)-1, previous, diagram.EndNodeID)
+		}
+	}
+	return r
+}
+
+func (r *Report) mismatch(format string, args ...interface{}) {
+	r.Mismatches = append(r.Mismatches, fmt.Sprintf(format, args...))
+}
+
+func (r *Report) coverActions(name string, index int, step *Step) {
+	for _, action := range step.Actions {
+		found := false
+		for i := range r.Actions {
+			if r.Actions[i].State == step.State && r.Actions[i].Name == action {
+				r.Actions[i].Hits++
+				found = true
+			}
+		}
+		if !found {
+			r.mismatch("%s: steps[%d]: state %s has no action %s", name, index, step.State, action)
+		}
+	}
+}
+
+// coverBranch covers the branch the step left its state through to reach the next state
+func (r *Report) coverBranch(name string, index int, step *Step, to string) {
+	var candidates []int
+	for i, branch := range r.Branches {
+		if branch.From == step.State && branch.To == to {
+			candidates = append(candidates, i)
+		}
+	}
+	matches := func(i int) bool {
+		branch := r.Branches[i]
+		switch {
+		case len(step.Error) > 0:
+			return branch.Kind == diagram.EdgeError && strings.Contains(branch.Label, step.Error)
+		case len(step.Condition) > 0:
+			return branch.Kind == diagram.EdgeCondition && branch.Label == step.Condition
+		}
+		return branch.Kind != diagram.EdgeError
+	}
+	for _, i := range candidates {
+		if matches(i) {
+			r.Branches[i].Hits++
+			return
+		}
+	}
+	switch {
+	case step.State == diagram.StartNodeID:
+		r.mismatch("%s: steps[%d]: the workflow doesn't start at %s", name, index, to)
+	case to == diagram.EndNodeID:
+		r.mismatch("%s: steps[%d]: state %s can't end the workflow", name, index, step.State)
+	default:
+		r.mismatch("%s: steps[%d]: there is no transition from %s to %s", name, index, step.State, to)
+	}
+}
+
+// StateRatio fraction of the states visited
+func (r *Report) StateRatio() float64 {
+	covered := 0
+	for _, state := range r.States {
+		if state.Hits > 0 {
+			covered++
+		}
+	}
+	return ratio(covered, len(r.States))
+}
+
+// BranchRatio fraction of the branches taken
+func (r *Report) BranchRatio() float64 {
+	return ratio(len(r.Branches)-len(r.UncoveredBranches()), len(r.Branches))
+}
+
+// UncoveredBranches branches never taken, such as switch conditions and error handlers never exercised
+func (r *Report) UncoveredBranches() []BranchCoverage {
+	var uncovered []BranchCoverage
+	for _, branch := range r.Branches {
+		if branch.Hits == 0 {
+			uncovered = append(uncovered, branch)
+		}
+	}
+	return uncovered
+}
+
+func ratio(covered, total int) float64 {
+	if total == 0 {
+		return 1
+	}
+	return float64(covered) / float64(total)
+}
+
+// Text formats the report for humans, listing what is not covered
+func Text(r *Report) string {
+	buf := new(bytes.Buffer)
+	count := func(total int, covered func(i int) bool) int {
+		n := 0
+		for i := 0; i < total; i++ {
+			if covered(i) {
+				n++
+			}
+		}
+		return n
+	}
+	visited := count(len(r.States), func(i int) bool { return r.States[i].Hits > 0 })
+	fmt.Fprintf(buf, "states: %d/%d (%.1f%%)\n", visited, len(r.States), 100*r.StateRatio())
+	if len(r.Actions) > 0 {
+		run := count(len(r.Actions), func(i int) bool { return r.Actions[i].Hits > 0 })
+		fmt.Fprintf(buf, "actions: %d/%d (%.1f%%)\n", run, len(r.Actions), 100*ratio(run, len(r.Actions)))
+	}
+	uncovered := r.UncoveredBranches()
+	fmt.Fprintf(buf, "branches: %d/%d (%.1f%%)\n", len(r.Branches)-len(uncovered), len(r.Branches), 100*r.BranchRatio())
+
+	for _, state := range r.States {
+		if state.Hits == 0 {
+			fmt.Fprintf(buf, "state %s is never visited\n", state.Name)
+		}
+	}
+	for _, action := range r.Actions {
+		if action.Hits == 0 {
+			fmt.Fprintf(buf, "action %s of state %s is never run\n", action.Name, action.State)
+		}
+	}
+	for _, branch := range uncovered {
+		switch branch.Kind {
+		case diagram.EdgeCondition:
+			fmt.Fprintf(buf, "condition %s of state %s is never taken\n", branch.Label, branch.From)
+		case diagram.EdgeError:
+			fmt.Fprintf(buf, "error %s of state %s is never handled\n", branch.Label, branch.From)
+		default:
+			fmt.Fprintf(buf, "transition from %s to %s is never taken\n", branch.From, branch.To)
+		}
+	}
+	for _, mismatch := range r.Mismatches {
+		fmt.Fprintf(buf, "%s\n", mismatch)
+	}
+	return buf.String()
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coverage
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze(t *testing.T) {
+	workflow, err := parser.FromFile("../parser/testdata/workflows/applicationrequest.json")
+	require.NoError(t, err)
+
+	report := Analyze(workflow, []Trace{
+		{Name: "adult", Steps: []Step{{State: "CheckApplication"}, {State: "StartApplication"}}, Ended: true},
+		{Name: "adult again", WorkflowID: "applicantrequest", Steps: []Step{{State: "CheckApplication"}, {State: "StartApplication"}}},
+		{Name: "other workflow", WorkflowID: "other", Steps: []Step{{State: "RejectApplication"}}},
+	})
+	assert.Equal(t, []StateCoverage{{Name: "CheckApplication", Hits: 2}, {Name: "StartApplication", Hits: 2}, {Name: "RejectApplication"}}, report.States)
+	assert.InDelta(t, 2.0/3, report.StateRatio(), 0.001)
+	assert.InDelta(t, 3.0/6, report.BranchRatio(), 0.001)
+	assert.Empty(t, report.Mismatches)
+	assert.Equal(t, `states: 2/3 (66.7%)
+branches: 3/6 (50.0%)
+state RejectApplication is never visited
+condition {{ $.applicants[?(@.age < 18)] }} of state CheckApplication is never taken
+condition default of state CheckApplication is never taken
+transition from RejectApplication to end is never taken
+`, Text(report))
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	workflow, err := parser.FromFile("../parser/testdata/workflows/provisionorders.sw.json")
+	require.NoError(t, err)
+
+	report := Analyze(workflow, []Trace{
+		{Name: "missing id", Steps: []Step{{State: "ProvisionOrder", Error: "Missing order id"}, {State: "MissingId"}}, Ended: true},
+		{Name: "unknown", Steps: []Step{{State: "ProvisionOrder", Actions: []string{"provision"}}, {State: "Unknown"}, {State: "ApplyOrder"}}},
+		{Name: "wrong start", Steps: []Step{{State: "ApplyOrder"}, {State: "MissingId"}}, Ended: true},
+	})
+	assert.Equal(t, []string{
+		"unknown: steps[0]: state ProvisionOrder has no action provision",
+		"unknown: steps[1]: state Unknown is not defined",
+		"wrong start: steps[0]: the workflow doesn't start at ApplyOrder",
+		"wrong start: steps[1]: there is no transition from ApplyOrder to MissingId",
+	}, report.Mismatches)
+	text := Text(report)
+	assert.Contains(t, text, "error Missing order item of state ProvisionOrder is never handled\n")
+	assert.NotContains(t, text, "error Missing order id")
+	assert.Contains(t, text, "transition from ProvisionOrder to ApplyOrder is never taken\n")
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Trace states visited by a workflow execution, in order, e.g.
+//
+//	{"workflowId": "order", "steps": [{"state": "Check", "condition": "paid"}, {"state": "Ship"}], "ended": true}
+type Trace struct {
+	// Name identifies the trace in reports, e.g. the test that produced it
+	Name       string `json:"name,omitempty"`
+	WorkflowID string `json:"workflowId,omitempty"`
+	Steps      []Step `json:"steps"`
+	// Ended true if the execution ended after the last step, false if it was interrupted
+	Ended bool `json:"ended,omitempty"`
+}
+
+// Step state visited by an execution
+type Step struct {
+	State string `json:"state"`
+	// Actions names of the actions run by the state, optional
+	Actions []string `json:"actions,omitempty"`
+	// Condition name, or expression or event reference if unnamed, of the switch condition the state left through,
+	// 'default' for the default condition. Optional, needed only when several conditions lead to the same state.
+	Condition string `json:"condition,omitempty"`
+	// Error reference of the error the state left through, if it failed
+	Error string `json:"error,omitempty"`
+}
+
+// ReadTraces reads the traces of a stream of JSON traces or arrays of traces, e.g. JSON Lines
+func ReadTraces(r io.Reader) ([]Trace, error) {
+	var traces []Trace
+	decoder := json.NewDecoder(r)
+	for {
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err == io.EOF {
+			return traces, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid trace: %w", err)
+		}
+		if len(value) > 0 && value[0] == '[' {
+			var list []Trace
+			if err := json.Unmarshal(value, &list); err != nil {
+				return nil, fmt.Errorf("invalid trace: %w", err)
+			}
+			traces = append(traces, list...)
+			continue
+		}
+		var trace Trace
+		if err := json.Unmarshal(value, &trace); err != nil {
+			return nil, fmt.Errorf("invalid trace: %w", err)
+		}
+		traces = append(traces, trace)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coverage
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTraces(t *testing.T) {
+	traces, err := ReadTraces(bytes.NewBufferString(`{"name": "a", "steps": [{"state": "Check", "condition": "paid"}], "ended": true}
+[{"name": "b", "steps": [{"state": "Check", "error": "Timeout"}]}, {"name": "c", "steps": []}]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []Trace{
+		{Name: "a", Steps: []Step{{State: "Check", Condition: "paid"}}, Ended: true},
+		{Name: "b", Steps: []Step{{State: "Check", Error: "Timeout"}}},
+		{Name: "c", Steps: []Step{}},
+	}, traces)
+
+	_, err = ReadTraces(bytes.NewBufferString(`{"steps": 1}`))
+	assert.Error(t, err)
+}
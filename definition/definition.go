@@ -0,0 +1,97 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package definition is a read only view of the workflow definitions meant to be independent of the version of the
+// specification: the nodes of the control flow, states or tasks, the edges between them, the events consumed and
+// produced and the functions called. Only the model package, the 0.7 and 0.8 versions, implements it for now, with
+// FromModel: the SDK has no 1.0 DSL model yet, and the interface may change when one implements it.
+package definition
+
+// End target of the edges ending the workflow
+const End = ""
+
+// EdgeKind ...
+type EdgeKind string
+
+const (
+	// EdgeTransition transition to the next node, or end of the workflow
+	EdgeTransition EdgeKind = "transition"
+	// EdgeCondition transition taken when a condition holds
+	EdgeCondition EdgeKind = "condition"
+	// EdgeError transition taken when a node fails
+	EdgeError EdgeKind = "error"
+	// EdgeCompensation node compensating another one
+	EdgeCompensation EdgeKind = "compensation"
+)
+
+// Definition workflow definition of any version of the specification
+type Definition interface {
+	// ID identifier of the workflow, unique with its version
+	ID() string
+	Name() string
+	Version() string
+	// SpecVersion version of the specification the workflow follows
+	SpecVersion() string
+	// Start name of the node the workflow starts from, empty if unknown
+	Start() string
+	// Nodes states or tasks of the workflow, in the order they are defined
+	Nodes() []Node
+	// Edges ways from a node to another or to the End of the workflow
+	Edges() []Edge
+	// Consumed events the workflow consumes
+	Consumed() []Event
+	// Produced events the workflow produces
+	Produced() []Event
+	// Functions functions called by the workflow, in the order they are defined
+	Functions() []Function
+}
+
+// Node state or task of a workflow
+type Node struct {
+	Name string
+	// Type of the state or task, e.g. 'operation' or 'switch'
+	Type string
+	// Path of the node in the workflow document, e.g. 'states[2]'
+	Path string
+}
+
+// Edge way from a node to another
+type Edge struct {
+	From string
+	// To name of the target node, End if the edge ends the workflow
+	To   string
+	Kind EdgeKind
+	// Label name, condition or error of the conditional and error edges
+	Label string
+}
+
+// Event event consumed or produced by a workflow
+type Event struct {
+	Name string
+	// Type and Source CloudEvent attributes of the event
+	Type   string
+	Source string
+	// Path of the event definition in the workflow document, e.g. 'events[0]'
+	Path string
+}
+
+// Function function called by a workflow
+type Function struct {
+	Name string
+	// Type of the function, e.g. 'rest' or 'expression'
+	Type      string
+	Operation string
+	// Path of the function definition in the workflow document, e.g. 'functions[1]'
+	Path string
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definition
+
+import (
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v2/diagram"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// FromModel returns the definition of a workflow of the model package. The definition is computed once: the workflow
+// must not be modified afterwards.
+func FromModel(workflow *model.Workflow) Definition {
+	d := &modelDefinition{workflow: workflow}
+	if workflow.Start != nil {
+		d.start = workflow.Start.StateName
+	}
+	for i, state := range workflow.States {
+		d.nodes = append(d.nodes, Node{Name: state.GetName(), Type: string(state.GetType()), Path: fmt.Sprintf("states[%d]", i)})
+	}
+	for _, edge := range diagram.New(workflow).Edges {
+		if edge.From == diagram.StartNodeID {
+			continue
+		}
+		to := edge.To
+		if to == diagram.EndNodeID {
+			to = End
+		}
+		d.edges = append(d.edges, Edge{From: edge.From, To: to, Kind: EdgeKind(edge.Kind), Label: edge.Label})
+	}
+	for i, event := range workflow.Events {
+		e := Event{Name: event.Name, Type: event.Type, Source: event.Source, Path: fmt.Sprintf("events[%d]", i)}
+		if event.Kind == model.EventKindProduced {
+			d.produced = append(d.produced, e)
+		} else {
+			d.consumed = append(d.consumed, e)
+		}
+	}
+
+	called := map[string]bool{}
+	for _, state := range workflow.States {
+		for _, action := range model.GetActions(state) {
+			called[action.FunctionRef.RefName] = true
+		}
+	}
+	for i, function := range workflow.Functions {
+		if called[function.Name] {
+			functionType := function.Type
+			if len(functionType) == 0 {
+				functionType = model.FunctionTypeREST
+			}
+			d.functions = append(d.functions, Function{
+				Name:      function.Name,
+				Type:      string(functionType),
+				Operation: function.Operation,
+				Path:      fmt.Sprintf("functions[%d]", i),
+			})
+		}
+	}
+	return d
+}
+
+type modelDefinition struct {
+	workflow  *model.Workflow
+	start     string
+	nodes     []Node
+	edges     []Edge
+	consumed  []Event
+	produced  []Event
+	functions []Function
+}
+
+func (d *modelDefinition) ID() string            { return d.workflow.ID }
+func (d *modelDefinition) Name() string          { return d.workflow.Name }
+func (d *modelDefinition) Version() string       { return d.workflow.Version }
+func (d *modelDefinition) SpecVersion() string   { return d.workflow.SpecVersion }
+func (d *modelDefinition) Start() string         { return d.start }
+func (d *modelDefinition) Nodes() []Node         { return d.nodes }
+func (d *modelDefinition) Edges() []Edge         { return d.edges }
+func (d *modelDefinition) Consumed() []Event     { return d.consumed }
+func (d *modelDefinition) Produced() []Event     { return d.produced }
+func (d *modelDefinition) Functions() []Function { return d.functions }
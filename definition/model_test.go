@@ -0,0 +1,86 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definition
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromModel(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(`id: order
+name: Order
+version: '1.0'
+specVersion: '0.8'
+start: Wait
+events:
+  - name: OrderCreated
+    type: order.created
+    source: shop
+  - name: OrderShipped
+    type: order.shipped
+    source: warehouse
+    kind: produced
+functions:
+  - name: ship
+    operation: https://warehouse.example.com/api.json#ship
+  - name: unused
+    operation: https://warehouse.example.com/api.json#unused
+states:
+  - name: Wait
+    type: event
+    onEvents:
+      - eventRefs: [OrderCreated]
+    transition: Check
+  - name: Check
+    type: switch
+    dataConditions:
+      - name: Paid
+        condition: ${ .paid }
+        transition: Ship
+    defaultCondition:
+      end: true
+  - name: Ship
+    type: operation
+    actions:
+      - functionRef: ship
+    end:
+      produceEvents:
+        - eventRef: OrderShipped
+`))
+	require.NoError(t, err)
+	d := FromModel(workflow)
+	assert.Equal(t, "order", d.ID())
+	assert.Equal(t, "1.0", d.Version())
+	assert.Equal(t, "0.8", d.SpecVersion())
+	assert.Equal(t, "Wait", d.Start())
+	assert.Equal(t, []Node{
+		{Name: "Wait", Type: "event", Path: "states[0]"},
+		{Name: "Check", Type: "switch", Path: "states[1]"},
+		{Name: "Ship", Type: "operation", Path: "states[2]"},
+	}, d.Nodes())
+	assert.Equal(t, []Edge{
+		{From: "Wait", To: "Check", Kind: EdgeTransition},
+		{From: "Check", To: "Ship", Kind: EdgeCondition, Label: "Paid"},
+		{From: "Check", To: End, Kind: EdgeCondition, Label: "default"},
+		{From: "Ship", To: End, Kind: EdgeTransition},
+	}, d.Edges())
+	assert.Equal(t, []Event{{Name: "OrderCreated", Type: "order.created", Source: "shop", Path: "events[0]"}}, d.Consumed())
+	assert.Equal(t, []Event{{Name: "OrderShipped", Type: "order.shipped", Source: "warehouse", Path: "events[1]"}}, d.Produced())
+	assert.Equal(t, []Function{{Name: "ship", Type: "rest", Operation: "https://warehouse.example.com/api.json#ship", Path: "functions[0]"}}, d.Functions())
+}
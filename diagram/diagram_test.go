@@ -0,0 +1,143 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagram
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func testWorkflow() *model.Workflow {
+	return &model.Workflow{
+		BaseWorkflow: model.BaseWorkflow{ID: "order", Start: &model.Start{StateName: "Check"}},
+		States: []model.State{
+			&model.DataBasedSwitchState{
+				BaseSwitchState: model.BaseSwitchState{
+					BaseState:        model.BaseState{Name: "Check", Type: model.StateTypeSwitch},
+					DefaultCondition: model.DefaultCondition{Transition: model.Transition{NextState: "Reject"}},
+				},
+				DataConditions: []model.DataCondition{
+					&model.TransitionDataCondition{BaseDataCondition: model.BaseDataCondition{Name: "valid"}, Transition: model.Transition{NextState: "Store"}},
+					&model.EndDataCondition{BaseDataCondition: model.BaseDataCondition{Condition: "${ .total == 0 }"}},
+				},
+			},
+			&model.OperationState{
+				BaseState: model.BaseState{
+					Name:          "Store",
+					Type:          model.StateTypeOperation,
+					OnErrors:      []model.OnError{{ErrorRef: "Timeout", Transition: &model.Transition{NextState: "Missing"}}},
+					CompensatedBy: "Reject",
+					End:           &model.End{},
+				},
+			},
+			&model.OperationState{BaseState: model.BaseState{Name: "Reject \"order\"", Type: model.StateTypeOperation, End: &model.End{}}},
+		},
+	}
+}
+
+func TestGraph(t *testing.T) {
+	workflow := testWorkflow()
+	workflow.States[2].(*model.OperationState).Name = "Reject"
+	g := New(workflow)
+	assert.Len(t, g.Nodes, 5)
+	assert.Equal(t, []Edge{
+		{From: StartNodeID, To: "Check", Kind: EdgeTransition},
+		{From: "Check", To: "Store", Kind: EdgeCondition, Label: "valid"},
+		{From: "Check", To: EndNodeID, Kind: EdgeCondition, Label: "${ .total == 0 }"},
+		{From: "Check", To: "Reject", Kind: EdgeCondition, Label: "default"},
+		{From: "Store", To: EndNodeID, Kind: EdgeTransition},
+		{From: "Store", To: "Missing", Kind: EdgeError, Label: "Timeout"},
+		{From: "Store", To: "Reject", Kind: EdgeCompensation, Label: "compensated by"},
+		{From: "Reject", To: EndNodeID, Kind: EdgeTransition},
+	}, g.Edges)
+
+	path, err := g.Path([]string{StartNodeID, "Check", "Store", EndNodeID})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 4}, path)
+	_, err = g.Path([]string{"Store", "Check"})
+	assert.EqualError(t, err, "there is no transition from Store to Check")
+	_, err = g.Path([]string{"Unknown"})
+	assert.Error(t, err)
+
+	subset, err := g.Subset([]string{"Store", "Reject"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Store", "Reject", EndNodeID}, []string{subset.Nodes[0].ID, subset.Nodes[1].ID, subset.Nodes[2].ID})
+	assert.Len(t, subset.Edges, 3)
+	_, err = g.Subset([]string{EndNodeID})
+	assert.Error(t, err)
+}
+
+func TestMermaid(t *testing.T) {
+	out, err := Mermaid(testWorkflow(), Options{Highlight: []string{"Check", "Store"}})
+	assert.NoError(t, err)
+	assert.Equal(t, `flowchart TD
+    n0(("Start"))
+    n1{"Check"}
+    n2["Store"]
+    n3["Reject #quot;order#quot;"]
+    n4(("End"))
+    n0 --> n1
+    n1 -->|"valid"| n2
+    n1 -->|"${ .total == 0 }"| n4
+    n5["Reject (undefined)"]
+    n1 -->|"default"| n5
+    n2 --> n4
+    n6["Missing (undefined)"]
+    n2 -.->|"Timeout"| n6
+    n2 -.->|"compensated by"| n5
+    n3 --> n4
+    classDef highlight stroke:#d33,stroke-width:3px
+    class n1,n2 highlight
+    linkStyle 1 stroke:#d33,stroke-width:3px
+`, string(out))
+
+	_, err = Mermaid(testWorkflow(), Options{Highlight: []string{"Store", "Check"}})
+	assert.Error(t, err)
+}
+
+func TestDOT(t *testing.T) {
+	out, err := DOT(testWorkflow(), Options{States: []string{"Store"}, Highlight: []string{"Store", EndNodeID}})
+	assert.NoError(t, err)
+	assert.Equal(t, `digraph "order" {
+    node [shape=box, style=rounded];
+    n0 [label="Store", color="#d33", penwidth=3];
+    n1 [label="End", shape=doublecircle, color="#d33", penwidth=3];
+    n0 -> n1 [color="#d33", penwidth=3];
+}
+`, string(out))
+}
+
+func TestSVG(t *testing.T) {
+	defer func(command string) { DotCommand = command }(DotCommand)
+	dir, err := ioutil.TempDir("", "diagram")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	// the fake dot echoes the DOT source, enough to check the command plumbing without Graphviz
+	DotCommand = filepath.Join(dir, "dot")
+	assert.NoError(t, ioutil.WriteFile(DotCommand, []byte("#!/bin/sh\ncat\n"), 0700))
+	out, err := SVG(context.Background(), testWorkflow(), Options{})
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `digraph "order" {`)
+
+	DotCommand = "missing-graphviz-dot"
+	_, err = SVG(context.Background(), testWorkflow(), Options{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,220 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagram
+
+import (
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+const (
+	// StartNodeID identifier of the node the workflow starts from
+	StartNodeID = "start"
+	// EndNodeID identifier of the node reached when the workflow ends
+	EndNodeID = "end"
+)
+
+// NodeKind ...
+type NodeKind string
+
+const (
+	// NodeStart workflow start
+	NodeStart NodeKind = "start"
+	// NodeEnd workflow end
+	NodeEnd NodeKind = "end"
+	// NodeState workflow state
+	NodeState NodeKind = "state"
+)
+
+// EdgeKind ...
+type EdgeKind string
+
+const (
+	// EdgeTransition transition or end of a state
+	EdgeTransition EdgeKind = "transition"
+	// EdgeCondition transition or end of a switch state condition
+	EdgeCondition EdgeKind = "condition"
+	// EdgeError transition or end taken when a state fails
+	EdgeError EdgeKind = "error"
+	// EdgeCompensation state compensating another one
+	EdgeCompensation EdgeKind = "compensation"
+)
+
+// Node ...
+type Node struct {
+	ID    string
+	Kind  NodeKind
+	Label string
+	// State the node represents, nil for the start and end nodes
+	State model.State
+}
+
+// Edge ...
+type Edge struct {
+	From  string
+	To    string
+	Kind  EdgeKind
+	Label string
+}
+
+// Graph workflow control flow. States are identified by their name.
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// New builds the control flow graph of the workflow
+func New(workflow *model.Workflow) *Graph {
+	g := &Graph{Nodes: []Node{{ID: StartNodeID, Kind: NodeStart, Label: "Start"}}}
+	if workflow.Start != nil && len(workflow.Start.StateName) > 0 {
+		g.Edges = append(g.Edges, Edge{From: StartNodeID, To: workflow.Start.StateName, Kind: EdgeTransition})
+	}
+	for _, state := range workflow.States {
+		g.Nodes = append(g.Nodes, Node{ID: state.GetName(), Kind: NodeState, Label: state.GetName(), State: state})
+		g.addStateEdges(state)
+	}
+	g.Nodes = append(g.Nodes, Node{ID: EndNodeID, Kind: NodeEnd, Label: "End"})
+	return g
+}
+
+func (g *Graph) addStateEdges(state model.State) {
+	name := state.GetName()
+	if transition := state.GetTransition(); transition != nil && len(transition.NextState) > 0 {
+		g.addEdge(name, transition.NextState, EdgeTransition, "")
+	}
+	if state.GetEnd() != nil {
+		g.addEdge(name, EndNodeID, EdgeTransition, "")
+	}
+
+	switch s := state.(type) {
+	case *model.DataBasedSwitchState:
+		for _, condition := range s.DataConditions {
+			label := condition.GetName()
+			if len(label) == 0 {
+				label = condition.GetCondition()
+			}
+			switch c := condition.(type) {
+			case *model.TransitionDataCondition:
+				g.addEdge(name, c.Transition.NextState, EdgeCondition, label)
+			case *model.EndDataCondition:
+				g.addEdge(name, EndNodeID, EdgeCondition, label)
+			}
+		}
+		g.addDefaultCondition(name, s.DefaultCondition)
+	case *model.EventBasedSwitchState:
+		for _, condition := range s.EventConditions {
+			label := condition.GetName()
+			if len(label) == 0 {
+				label = condition.GetEventRef()
+			}
+			switch c := condition.(type) {
+			case *model.TransitionEventCondition:
+				g.addEdge(name, c.Transition.NextState, EdgeCondition, label)
+			case *model.EndEventCondition:
+				g.addEdge(name, EndNodeID, EdgeCondition, label)
+			}
+		}
+		g.addDefaultCondition(name, s.DefaultCondition)
+	}
+
+	for _, onError := range state.GetOnErrors() {
+		label := onError.ErrorRef
+		if len(onError.ErrorRefs) > 0 {
+			label = fmt.Sprint(onError.ErrorRefs)
+		}
+		if onError.Transition != nil && len(onError.Transition.NextState) > 0 {
+			g.addEdge(name, onError.Transition.NextState, EdgeError, label)
+		} else if onError.End != nil {
+			g.addEdge(name, EndNodeID, EdgeError, label)
+		}
+	}
+	if compensatedBy := state.GetCompensatedBy(); len(compensatedBy) > 0 {
+		g.addEdge(name, compensatedBy, EdgeCompensation, "compensated by")
+	}
+}
+
+func (g *Graph) addDefaultCondition(name string, condition model.DefaultCondition) {
+	if len(condition.Transition.NextState) > 0 {
+		g.addEdge(name, condition.Transition.NextState, EdgeCondition, "default")
+	} else {
+		g.addEdge(name, EndNodeID, EdgeCondition, "default")
+	}
+}
+
+func (g *Graph) addEdge(from, to string, kind EdgeKind, label string) {
+	g.Edges = append(g.Edges, Edge{From: from, To: to, Kind: kind, Label: label})
+}
+
+// Node returns the node with the given id, nil if not found
+func (g *Graph) Node(id string) *Node {
+	for i := range g.Nodes {
+		if g.Nodes[i].ID == id {
+			return &g.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// Subset returns the graph restricted to the given states. The start and end nodes are kept if they are connected
+// to any of the states.
+func (g *Graph) Subset(states []string) (*Graph, error) {
+	keep := map[string]bool{}
+	for _, state := range states {
+		if node := g.Node(state); node == nil || node.Kind != NodeState {
+			return nil, fmt.Errorf("state %s is not defined", state)
+		}
+		keep[state] = true
+	}
+	subset := &Graph{}
+	connected := map[string]bool{}
+	for _, edge := range g.Edges {
+		if (keep[edge.From] || edge.From == StartNodeID) && (keep[edge.To] || edge.To == EndNodeID) {
+			subset.Edges = append(subset.Edges, edge)
+			connected[edge.From] = true
+			connected[edge.To] = true
+		}
+	}
+	for _, node := range g.Nodes {
+		if keep[node.ID] || (node.Kind != NodeState && connected[node.ID]) {
+			subset.Nodes = append(subset.Nodes, node)
+		}
+	}
+	return subset, nil
+}
+
+// Path returns the indexes of the edges connecting the given states in order. Consecutive states must be connected
+// by an edge.
+func (g *Graph) Path(states []string) ([]int, error) {
+	for _, state := range states {
+		if g.Node(state) == nil {
+			return nil, fmt.Errorf("state %s is not defined", state)
+		}
+	}
+	var edges []int
+	for i := 1; i < len(states); i++ {
+		found := false
+		for j, edge := range g.Edges {
+			if edge.From == states[i-1] && edge.To == states[i] {
+				edges = append(edges, j)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("there is no transition from %s to %s", states[i-1], states[i])
+		}
+	}
+	return edges, nil
+}
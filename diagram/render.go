@@ -0,0 +1,234 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagram
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// DotCommand Graphviz command used to render SVG diagrams
+var DotCommand = "dot"
+
+const highlightColor = "#d33"
+
+// Options ...
+type Options struct {
+	// Highlight names of the states to highlight. The transitions between consecutive states are highlighted as well,
+	// so a path can be highlighted by listing its states in order. 'start' and 'end' stand for the workflow start and end.
+	Highlight []string
+	// States if not empty, only these states are rendered
+	States []string
+}
+
+type rendering struct {
+	graph *Graph
+	ids   map[string]string
+	nodes map[string]bool
+	edges map[Edge]bool
+}
+
+func newRendering(workflow *model.Workflow, opts Options) (*rendering, error) {
+	graph := New(workflow)
+	r := &rendering{graph: graph, ids: map[string]string{}, nodes: map[string]bool{}, edges: map[Edge]bool{}}
+	if len(opts.Highlight) > 0 {
+		path, err := graph.Path(opts.Highlight)
+		if err != nil {
+			return nil, err
+		}
+		for _, state := range opts.Highlight {
+			r.nodes[state] = true
+		}
+		for _, i := range path {
+			r.edges[graph.Edges[i]] = true
+		}
+	}
+	if len(opts.States) > 0 {
+		subset, err := graph.Subset(opts.States)
+		if err != nil {
+			return nil, err
+		}
+		r.graph = subset
+	}
+	for i, node := range r.graph.Nodes {
+		r.ids[node.ID] = fmt.Sprintf("n%d", i)
+	}
+	return r, nil
+}
+
+// Mermaid renders the workflow as a Mermaid flowchart
+func Mermaid(workflow *model.Workflow, opts Options) ([]byte, error) {
+	r, err := newRendering(workflow, opts)
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	buf.WriteString("flowchart TD\n")
+	var highlighted []string
+	for _, node := range r.graph.Nodes {
+		label := mermaidEscape(node.Label)
+		switch {
+		case node.Kind != NodeState:
+			fmt.Fprintf(buf, "    %s((\"%s\"))\n", r.ids[node.ID], label)
+		case isSwitch(node.State):
+			fmt.Fprintf(buf, "    %s{\"%s\"}\n", r.ids[node.ID], label)
+		default:
+			fmt.Fprintf(buf, "    %s[\"%s\"]\n", r.ids[node.ID], label)
+		}
+		if r.nodes[node.ID] {
+			highlighted = append(highlighted, r.ids[node.ID])
+		}
+	}
+	var highlightedEdges []string
+	for i, edge := range r.graph.Edges {
+		to, undefined := r.target(edge.To)
+		if undefined {
+			fmt.Fprintf(buf, "    %s[\"%s (undefined)\"]\n", to, mermaidEscape(edge.To))
+		}
+		arrow := "-->"
+		if edge.Kind == EdgeError || edge.Kind == EdgeCompensation {
+			arrow = "-.->"
+		}
+		if len(edge.Label) > 0 {
+			fmt.Fprintf(buf, "    %s %s|\"%s\"| %s\n", r.ids[edge.From], arrow, mermaidEscape(edge.Label), to)
+		} else {
+			fmt.Fprintf(buf, "    %s %s %s\n", r.ids[edge.From], arrow, to)
+		}
+		if r.edges[edge] {
+			highlightedEdges = append(highlightedEdges, fmt.Sprint(i))
+		}
+	}
+	if len(highlighted) > 0 {
+		fmt.Fprintf(buf, "    classDef highlight stroke:%s,stroke-width:3px\n", highlightColor)
+		fmt.Fprintf(buf, "    class %s highlight\n", strings.Join(highlighted, ","))
+	}
+	if len(highlightedEdges) > 0 {
+		fmt.Fprintf(buf, "    linkStyle %s stroke:%s,stroke-width:3px\n", strings.Join(highlightedEdges, ","), highlightColor)
+	}
+	return buf.Bytes(), nil
+}
+
+// target returns the id of the node the edge points to. Transitions to undefined states are rendered anyway, to show
+// the broken reference, so it also returns whether the node must be declared.
+func (r *rendering) target(name string) (id string, undefined bool) {
+	if id, ok := r.ids[name]; ok {
+		return id, false
+	}
+	id = fmt.Sprintf("n%d", len(r.ids))
+	r.ids[name] = id
+	return id, true
+}
+
+// DOT renders the workflow as a Graphviz digraph
+func DOT(workflow *model.Workflow, opts Options) ([]byte, error) {
+	r, err := newRendering(workflow, opts)
+	if err != nil {
+		return nil, err
+	}
+	name := workflow.ID
+	if len(name) == 0 {
+		name = workflow.Name
+	}
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "digraph %s {\n", dotQuote(name))
+	buf.WriteString("    node [shape=box, style=rounded];\n")
+	for _, node := range r.graph.Nodes {
+		attributes := []string{"label=" + dotQuote(node.Label)}
+		switch {
+		case node.Kind == NodeStart:
+			attributes = append(attributes, "shape=circle")
+		case node.Kind == NodeEnd:
+			attributes = append(attributes, "shape=doublecircle")
+		case isSwitch(node.State):
+			attributes = append(attributes, "shape=diamond", "style=\"\"")
+		}
+		if r.nodes[node.ID] {
+			attributes = append(attributes, dotQuoteAttribute("color", highlightColor), "penwidth=3")
+		}
+		fmt.Fprintf(buf, "    %s [%s];\n", r.ids[node.ID], strings.Join(attributes, ", "))
+	}
+	for _, edge := range r.graph.Edges {
+		to, undefined := r.target(edge.To)
+		if undefined {
+			fmt.Fprintf(buf, "    %s [label=%s, style=dashed];\n", to, dotQuote(edge.To+" (undefined)"))
+		}
+		var attributes []string
+		if len(edge.Label) > 0 {
+			attributes = append(attributes, "label="+dotQuote(edge.Label))
+		}
+		switch edge.Kind {
+		case EdgeError:
+			attributes = append(attributes, "style=dashed")
+		case EdgeCompensation:
+			attributes = append(attributes, "style=dotted")
+		}
+		if r.edges[edge] {
+			attributes = append(attributes, dotQuoteAttribute("color", highlightColor), "penwidth=3")
+		}
+		if len(attributes) > 0 {
+			fmt.Fprintf(buf, "    %s -> %s [%s];\n", r.ids[edge.From], to, strings.Join(attributes, ", "))
+		} else {
+			fmt.Fprintf(buf, "    %s -> %s;\n", r.ids[edge.From], to)
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes(), nil
+}
+
+// SVG renders the workflow as an SVG image. It requires the Graphviz dot command, see DotCommand.
+func SVG(ctx context.Context, workflow *model.Workflow, opts Options) ([]byte, error) {
+	dot, err := DOT(workflow, opts)
+	if err != nil {
+		return nil, err
+	}
+	path, err := exec.LookPath(DotCommand)
+	if err != nil {
+		return nil, fmt.Errorf("rendering SVG requires Graphviz: %w", err)
+	}
+	cmd := exec.CommandContext(ctx, path, "-Tsvg")
+	cmd.Stdin = bytes.NewReader(dot)
+	stderr := new(bytes.Buffer)
+	cmd.Stderr = stderr
+	svg, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w: %s", DotCommand, err, strings.TrimSpace(stderr.String()))
+	}
+	return svg, nil
+}
+
+func isSwitch(state model.State) bool {
+	switch state.(type) {
+	case *model.DataBasedSwitchState, *model.EventBasedSwitchState:
+		return true
+	}
+	return false
+}
+
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, "\"", "#quot;")
+}
+
+func dotQuote(s string) string {
+	return "\"" + strings.ReplaceAll(strings.ReplaceAll(s, "\\", "\\\\"), "\"", "\\\"") + "\""
+}
+
+func dotQuoteAttribute(name, value string) string {
+	return name + "=" + dotQuote(value)
+}
@@ -0,0 +1,281 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+// ChangeType ...
+type ChangeType string
+
+const (
+	// ChangeAdded the property or definition is only in the new workflow
+	ChangeAdded ChangeType = "added"
+	// ChangeRemoved the property or definition is only in the old workflow
+	ChangeRemoved ChangeType = "removed"
+	// ChangeModified the property value differs between both workflows
+	ChangeModified ChangeType = "modified"
+	// ChangeMoved the definition with a name is at another position in the new workflow, From and To being its
+	// positions. Only reported by the comparisons of the order.
+	ChangeMoved ChangeType = "moved"
+)
+
+const nameKey = "name"
+
+// Change difference between two workflows
+type Change struct {
+	Type ChangeType `json:"type"`
+	// Path of the changed property. Definitions with a name are identified by it instead of their position,
+	// e.g. 'states[Greet].transition'
+	Path string `json:"path"`
+	// From old value, nil if added
+	From interface{} `json:"from,omitempty"`
+	// To new value, nil if removed
+	To interface{} `json:"to,omitempty"`
+}
+
+// String ...
+func (c Change) String() string {
+	switch c.Type {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, format(c.To))
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, format(c.From))
+	case ChangeMoved:
+		return fmt.Sprintf("> %s: %s -> %s", c.Path, format(c.From), format(c.To))
+	}
+	return fmt.Sprintf("~ %s: %s -> %s", c.Path, format(c.From), format(c.To))
+}
+
+// Options of the comparisons, the zero value comparing the workflows like Workflows
+type Options struct {
+	// Order reports the definitions with a name at another position, matched by name whatever their position otherwise
+	Order bool
+	// Defaults tells the properties set to their default value from unset ones, considered equal otherwise
+	Defaults bool
+}
+
+// Workflows compares both workflows. Properties set to their default value are considered equal to unset ones,
+// and definitions with a name (states, functions, events, actions, ...) are matched by name, so reordering them isn't
+// reported as a change.
+func Workflows(from, to *model.Workflow) ([]Change, error) {
+	return Compare(from, to, Options{})
+}
+
+// Compare compares both workflows with the options. The changes are ordered by path, the properties of an object by
+// name and the items of an array by position, the definitions with a name of the old workflow first.
+func Compare(from, to *model.Workflow, opts Options) ([]Change, error) {
+	fromTree, err := tree(from, opts)
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := tree(to, opts)
+	if err != nil {
+		return nil, err
+	}
+	c := &comparison{opts: opts}
+	c.compare("", fromTree, toTree)
+	return c.changes, nil
+}
+
+// Equal tells whether both workflows are equal for the options
+func Equal(from, to *model.Workflow, opts Options) (bool, error) {
+	change, err := First(from, to, opts)
+	return change == nil, err
+}
+
+// First returns the first change between both workflows in the order of Compare, e.g. to report where the workflows
+// diverge, nil if they're equal
+func First(from, to *model.Workflow, opts Options) (*Change, error) {
+	changes, err := Compare(from, to, opts)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return &changes[0], nil
+}
+
+// Text renders the changes in a human readable form, one change per line
+func Text(changes []Change) string {
+	buf := new(bytes.Buffer)
+	for _, change := range changes {
+		buf.WriteString(change.String())
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+func tree(workflow *model.Workflow, opts Options) (interface{}, error) {
+	data, err := serializer.Marshal(workflow, serializer.Options{Format: serializer.FormatJSON, Normalize: !opts.Defaults})
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// comparison changes between two workflows, gathered as their trees are compared
+type comparison struct {
+	opts    Options
+	changes []Change
+}
+
+func (c *comparison) add(change Change) {
+	c.changes = append(c.changes, change)
+}
+
+func (c *comparison) compare(path string, from, to interface{}) {
+	switch {
+	case from == nil && to == nil:
+		return
+	case from == nil:
+		c.add(Change{Type: ChangeAdded, Path: path, To: to})
+		return
+	case to == nil:
+		c.add(Change{Type: ChangeRemoved, Path: path, From: from})
+		return
+	}
+
+	switch f := from.(type) {
+	case map[string]interface{}:
+		if t, ok := to.(map[string]interface{}); ok {
+			c.compareMaps(path, f, t)
+			return
+		}
+	case []interface{}:
+		if t, ok := to.([]interface{}); ok {
+			c.compareSlices(path, f, t)
+			return
+		}
+	}
+	if !reflect.DeepEqual(from, to) {
+		c.add(Change{Type: ChangeModified, Path: path, From: from, To: to})
+	}
+}
+
+func (c *comparison) compareMaps(path string, from, to map[string]interface{}) {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		c.compare(join(path, key), from[key], to[key])
+	}
+}
+
+func (c *comparison) compareSlices(path string, from, to []interface{}) {
+	fromNamed, fromOK := byName(from)
+	toNamed, toOK := byName(to)
+	if !fromOK || !toOK {
+		for i := 0; i < len(from) || i < len(to); i++ {
+			var f, t interface{}
+			if i < len(from) {
+				f = from[i]
+			}
+			if i < len(to) {
+				t = to[i]
+			}
+			c.compare(fmt.Sprintf("%s[%d]", path, i), f, t)
+		}
+		return
+	}
+	// keep the order of the definitions: removed ones in the old order, then the new ones in the new order
+	var positions map[string]int
+	if c.opts.Order {
+		positions = make(map[string]int, len(to))
+		for i, item := range to {
+			positions[item.(map[string]interface{})[nameKey].(string)] = i
+		}
+	}
+	for i, item := range from {
+		name := item.(map[string]interface{})[nameKey].(string)
+		if position, ok := positions[name]; ok && position != i {
+			c.add(Change{Type: ChangeMoved, Path: fmt.Sprintf("%s[%s]", path, name), From: i, To: position})
+		}
+		c.compare(fmt.Sprintf("%s[%s]", path, name), item, toNamed[name])
+	}
+	for _, item := range to {
+		name := item.(map[string]interface{})[nameKey].(string)
+		if _, ok := fromNamed[name]; !ok {
+			c.compare(fmt.Sprintf("%s[%s]", path, name), nil, item)
+		}
+	}
+}
+
+// byName indexes the items by name if all of them are objects with an unique name
+func byName(items []interface{}) (map[string]interface{}, bool) {
+	named := make(map[string]interface{}, len(items))
+	for _, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := object[nameKey].(string)
+		if !ok || len(name) == 0 {
+			return nil, false
+		}
+		if _, duplicated := named[name]; duplicated {
+			return nil, false
+		}
+		named[name] = item
+	}
+	return named, true
+}
+
+func join(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
+
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if name, ok := v[nameKey].(string); ok {
+			return fmt.Sprintf("{name: %s, ...}", name)
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return "{" + strings.Join(keys, ", ") + "}"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func orderWorkflow(timeout, next string) *model.Workflow {
+	workflow := &model.Workflow{
+		BaseWorkflow: model.BaseWorkflow{
+			ID:          "order",
+			Name:        "Order",
+			Version:     "1.0",
+			SpecVersion: "0.7",
+			Start:       &model.Start{StateName: "Store"},
+		},
+		Functions: []model.Function{{Name: "store", Operation: "http://orders#store"}},
+		States: []model.State{
+			&model.OperationState{
+				BaseState: model.BaseState{Name: "Store", Type: model.StateTypeOperation, Transition: &model.Transition{NextState: next}},
+				Actions:   []model.Action{{FunctionRef: model.FunctionRef{RefName: "store"}}},
+				Timeouts:  model.OperationStateTimeout{ActionExecTimeout: timeout},
+			},
+			&model.SleepState{
+				BaseState: model.BaseState{Name: "Wait", Type: model.StateTypeSleep, End: &model.End{Terminate: true}},
+				Duration:  "PT1M",
+			},
+		},
+	}
+	if next != "Wait" {
+		workflow.States = append(workflow.States, &model.SleepState{
+			BaseState: model.BaseState{Name: next, Type: model.StateTypeSleep, End: &model.End{Terminate: true}},
+			Duration:  "PT5M",
+		})
+	}
+	return workflow
+}
+
+func TestWorkflows(t *testing.T) {
+	changes, err := Workflows(orderWorkflow("PT1S", "Wait"), orderWorkflow("PT1S", "Wait"))
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	from := orderWorkflow("PT1S", "Wait")
+	to := orderWorkflow("PT10S", "Notify")
+	to.Version = "1.1"
+	// reordering named definitions isn't a change
+	to.States[1], to.States[2] = to.States[2], to.States[1]
+	changes, err = Workflows(from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Type: ChangeModified, Path: "states[Store].timeouts.actionExecTimeout", From: "PT1S", To: "PT10S"},
+		{Type: ChangeModified, Path: "states[Store].transition.nextState", From: "Wait", To: "Notify"},
+		{Type: ChangeAdded, Path: "states[Notify]", To: changes[2].To},
+		{Type: ChangeModified, Path: "version", From: "1.0", To: "1.1"},
+	}, changes)
+	assert.Equal(t, `~ states[Store].timeouts.actionExecTimeout: "PT1S" -> "PT10S"
+~ states[Store].transition.nextState: "Wait" -> "Notify"
++ states[Notify]: {name: Notify, ...}
+~ version: "1.0" -> "1.1"
+`, Text(changes))
+
+	to = orderWorkflow("PT1S", "Wait")
+	to.Functions = nil
+	to.States[1].(*model.SleepState).Timeouts.StateExecTimeout = model.StateExecTimeout{Total: "PT2M"}
+	changes, err = Workflows(from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, "- functions: [{\"name\":\"store\",\"operation\":\"http://orders#store\"}]\n"+
+		"+ states[Wait].timeouts: {stateExecTimeout}\n", Text(changes))
+}
+
+func TestCompare(t *testing.T) {
+	from := orderWorkflow("PT1S", "Wait")
+	to := orderWorkflow("PT1S", "Wait")
+	to.States[0], to.States[1] = to.States[1], to.States[0]
+	to.ExpressionLang = model.DefaultExpressionLang
+	equal, err := Equal(from, to, Options{})
+	assert.NoError(t, err)
+	assert.True(t, equal)
+
+	changes, err := Compare(from, to, Options{Order: true, Defaults: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `+ expressionLang: "jq"
+> states[Store]: 0 -> 1
+> states[Wait]: 1 -> 0
+`, Text(changes))
+	equal, err = Equal(from, to, Options{Order: true})
+	assert.NoError(t, err)
+	assert.False(t, equal)
+
+	change, err := First(from, to, Options{Defaults: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &Change{Type: ChangeAdded, Path: "expressionLang", To: "jq"}, change)
+	change, err = First(from, from, Options{Order: true, Defaults: true})
+	assert.NoError(t, err)
+	assert.Nil(t, change)
+}
@@ -0,0 +1,218 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Impact of a change on the users of a workflow, following semantic versioning
+type Impact string
+
+const (
+	// ImpactNone the change doesn't need a new version, e.g. the version itself
+	ImpactNone Impact = "none"
+	// ImpactPatch the change doesn't alter what the workflow accepts or does, e.g. a new description or timeout
+	ImpactPatch Impact = "patch"
+	// ImpactMinor the change adds a feature compatible with the previous version, e.g. a new state or event
+	ImpactMinor Impact = "minor"
+	// ImpactMajor the change breaks the users of the previous version, e.g. a removed state or a changed input schema
+	ImpactMajor Impact = "major"
+)
+
+var impactRanks = map[Impact]int{ImpactNone: 0, ImpactPatch: 1, ImpactMinor: 2, ImpactMajor: 3}
+
+// breakingPaths properties, and the properties in them, whose modification is a breaking change
+var breakingPaths = []string{"id", "start", "specVersion", "expressionLang", "dataInputSchema"}
+
+// breakingDefinitionProperties properties of the named top level definitions whose modification is a breaking change,
+// e.g. the type of a consumed or produced event
+var breakingDefinitionProperties = map[string][]string{
+	"events":    {"type", "source", "kind", "correlation"},
+	"functions": {"operation", "type"},
+}
+
+// ClassifiedChange change and its impact
+type ClassifiedChange struct {
+	Change
+	Impact Impact `json:"impact"`
+}
+
+// Classify returns the impact of the change. Removing states, events, functions or other top level definitions,
+// changing the data input schema, the start state or the type and source of events are breaking changes. Adding or
+// removing other definitions and properties are features, and the other changes are patches.
+func Classify(change Change) Impact {
+	if change.Path == "version" {
+		return ImpactNone
+	}
+	for _, path := range breakingPaths {
+		if under(change.Path, path) {
+			return ImpactMajor
+		}
+	}
+	collection, property := definitionProperty(change.Path)
+	switch {
+	case len(collection) > 0 && len(property) == 0 && change.Type == ChangeRemoved:
+		return ImpactMajor
+	case len(collection) > 0 && change.Type != ChangeAdded && contains(breakingDefinitionProperties[collection], property):
+		return ImpactMajor
+	case change.Type == ChangeAdded || change.Type == ChangeRemoved:
+		return ImpactMinor
+	}
+	return ImpactPatch
+}
+
+// ClassifyAll classifies the changes and returns the highest impact, ImpactNone if there are no changes
+func ClassifyAll(changes []Change) (Impact, []ClassifiedChange) {
+	impact := ImpactNone
+	classified := make([]ClassifiedChange, len(changes))
+	for i, change := range changes {
+		classified[i] = ClassifiedChange{Change: change, Impact: Classify(change)}
+		if impactRanks[classified[i].Impact] > impactRanks[impact] {
+			impact = classified[i].Impact
+		}
+	}
+	return impact, classified
+}
+
+// SuggestVersion returns the version following the given one for the impact, e.g. '2.0' for a major change of '1.3',
+// '1.3.1' for a patch. Versions are dot separated numbers, optionally prefixed with 'v', and pre-release or build
+// suffixes are dropped.
+func SuggestVersion(version string, impact Impact) (string, error) {
+	prefix, numbers, err := parseVersion(version)
+	if err != nil {
+		return "", err
+	}
+	var index int
+	switch impact {
+	case ImpactNone:
+		return version, nil
+	case ImpactMajor:
+		index = 0
+	case ImpactMinor:
+		index = 1
+	case ImpactPatch:
+		index = 2
+	default:
+		return "", fmt.Errorf("unknown impact %s", impact)
+	}
+	for len(numbers) <= index {
+		numbers = append(numbers, 0)
+	}
+	numbers[index]++
+	parts := make([]string, len(numbers))
+	for i := range numbers {
+		if i > index {
+			numbers[i] = 0
+		}
+		parts[i] = strconv.Itoa(numbers[i])
+	}
+	return prefix + strings.Join(parts, "."), nil
+}
+
+// CheckVersion verifies the new version is at least the one suggested for the impact from the old version
+func CheckVersion(from, to string, impact Impact) error {
+	suggested, err := SuggestVersion(from, impact)
+	if err != nil {
+		return err
+	}
+	_, minimum, _ := parseVersion(suggested)
+	_, actual, err := parseVersion(to)
+	if err != nil {
+		return err
+	}
+	if compareVersions(actual, minimum) < 0 {
+		return fmt.Errorf("version %s is lower than %s required by a %s change from %s", to, suggested, impact, from)
+	}
+	return nil
+}
+
+func parseVersion(version string) (string, []int, error) {
+	prefix := ""
+	trimmed := version
+	if strings.HasPrefix(trimmed, "v") {
+		prefix, trimmed = "v", trimmed[1:]
+	}
+	if i := strings.IndexAny(trimmed, "-+"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+	if len(trimmed) == 0 {
+		return "", nil, fmt.Errorf("invalid version %q", version)
+	}
+	parts := strings.Split(trimmed, ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return "", nil, fmt.Errorf("invalid version %q", version)
+		}
+		numbers[i] = number
+	}
+	return prefix, numbers, nil
+}
+
+// definitionProperty splits the path of a change in a named top level definition, e.g. 'events[Paid].type' into
+// 'events' and 'type', and 'states[Greet]' into 'states' and no property
+func definitionProperty(path string) (string, string) {
+	open := strings.Index(path, "[")
+	if open <= 0 || strings.Contains(path[:open], ".") {
+		return "", ""
+	}
+	closing := strings.Index(path, "]")
+	if closing < open {
+		return "", ""
+	}
+	rest := strings.TrimPrefix(path[closing+1:], ".")
+	if i := strings.IndexAny(rest, ".["); i >= 0 {
+		rest = rest[:i]
+	}
+	return path[:open], rest
+}
+
+// compareVersions compares the version numbers, missing trailing numbers are zeros
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// under tells whether the path is the property or a property in it
+func under(path, property string) bool {
+	return path == property || strings.HasPrefix(path, property+".") || strings.HasPrefix(path, property+"[")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
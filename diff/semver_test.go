@@ -0,0 +1,97 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		change Change
+		impact Impact
+	}{
+		{Change{Type: ChangeModified, Path: "version"}, ImpactNone},
+		{Change{Type: ChangeModified, Path: "description"}, ImpactPatch},
+		{Change{Type: ChangeModified, Path: "states[Store].timeouts.actionExecTimeout"}, ImpactPatch},
+		{Change{Type: ChangeAdded, Path: "states[Notify]"}, ImpactMinor},
+		{Change{Type: ChangeAdded, Path: "events[Paid].source"}, ImpactMinor},
+		{Change{Type: ChangeRemoved, Path: "states[Store].actions[notify]"}, ImpactMinor},
+		{Change{Type: ChangeRemoved, Path: "states[Notify]"}, ImpactMajor},
+		{Change{Type: ChangeRemoved, Path: "events[Paid]"}, ImpactMajor},
+		{Change{Type: ChangeModified, Path: "events[Paid].type"}, ImpactMajor},
+		{Change{Type: ChangeModified, Path: "functions[store].operation"}, ImpactMajor},
+		{Change{Type: ChangeAdded, Path: "dataInputSchema"}, ImpactMajor},
+		{Change{Type: ChangeModified, Path: "dataInputSchema.schema"}, ImpactMajor},
+		{Change{Type: ChangeModified, Path: "start.stateName"}, ImpactMajor},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.impact, Classify(test.change), test.change.Path)
+	}
+}
+
+func TestClassifyAll(t *testing.T) {
+	impact, classified := ClassifyAll(nil)
+	assert.Equal(t, ImpactNone, impact)
+	assert.Empty(t, classified)
+
+	from := orderWorkflow("PT1S", "Wait")
+	to := orderWorkflow("PT10S", "Notify")
+	changes, err := Workflows(from, to)
+	assert.NoError(t, err)
+	impact, classified = ClassifyAll(changes)
+	assert.Equal(t, ImpactMinor, impact)
+	assert.Equal(t, ImpactPatch, classified[0].Impact)
+	assert.Equal(t, ImpactMinor, classified[2].Impact)
+
+	changes, err = Workflows(to, from)
+	assert.NoError(t, err)
+	impact, _ = ClassifyAll(changes)
+	assert.Equal(t, ImpactMajor, impact)
+}
+
+func TestSuggestVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		impact   Impact
+		expected string
+	}{
+		{"1.3", ImpactMajor, "2.0"},
+		{"1.3", ImpactMinor, "1.4"},
+		{"1.3", ImpactPatch, "1.3.1"},
+		{"1", ImpactMinor, "1.1"},
+		{"v1.2.3", ImpactMinor, "v1.3.0"},
+		{"1.2.3-rc.1", ImpactPatch, "1.2.4"},
+		{"1.2.3", ImpactNone, "1.2.3"},
+	}
+	for _, test := range tests {
+		version, err := SuggestVersion(test.version, test.impact)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, version, test.version)
+	}
+	_, err := SuggestVersion("latest", ImpactMinor)
+	assert.EqualError(t, err, `invalid version "latest"`)
+}
+
+func TestCheckVersion(t *testing.T) {
+	assert.NoError(t, CheckVersion("1.0", "2.0", ImpactMajor))
+	assert.NoError(t, CheckVersion("1.0", "1.0.1", ImpactPatch))
+	assert.NoError(t, CheckVersion("1.0", "1.1.0", ImpactMinor))
+	assert.NoError(t, CheckVersion("1.0", "1.0", ImpactNone))
+	assert.EqualError(t, CheckVersion("1.0", "1.1", ImpactMajor), "version 1.1 is lower than 2.0 required by a major change from 1.0")
+	assert.Error(t, CheckVersion("1.0", "next", ImpactPatch))
+}
@@ -0,0 +1,222 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/google/uuid"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+const (
+	// ContentTypeJSON content type used for the data of the produced events
+	ContentTypeJSON = event.ApplicationJSON
+
+	attributeID              = "id"
+	attributeSource          = "source"
+	attributeType            = "type"
+	attributeSubject         = "subject"
+	attributeSpecVersion     = "specversion"
+	attributeDataSchema      = "dataschema"
+	attributeDataContentType = "datacontenttype"
+	attributeTime            = "time"
+)
+
+// FindEvent returns the event definition with the given name
+func FindEvent(workflow *model.Workflow, name string) (*model.Event, error) {
+	if event, ok := workflow.Event(name); ok {
+		return event, nil
+	}
+	return nil, fmt.Errorf("event %s not defined in workflow %s", name, workflow.ID)
+}
+
+// NewCloudEvent builds the CloudEvent described by the given produceEvents definition.
+// data is the already evaluated event payload. If nil, the produceEvent data is used when it's a custom object.
+func NewCloudEvent(workflow *model.Workflow, produce model.ProduceEvent, data interface{}) (event.Event, error) {
+	definition, err := FindEvent(workflow, produce.EventRef)
+	if err != nil {
+		return event.Event{}, err
+	}
+	if definition.Kind == model.EventKindConsumed {
+		return event.Event{}, fmt.Errorf("event %s is not a produced event", definition.Name)
+	}
+	if data == nil {
+		if _, isExpression := produce.Data.(string); !isExpression {
+			data = produce.Data
+		}
+	}
+	return NewCloudEventFromDefinition(*definition, produce.ContextAttributes, data)
+}
+
+// NewCloudEventFromDefinition builds a CloudEvent for the given event definition with the additional context
+// attributes, set with their setters if they're CloudEvent attributes, e.g. subject, as extensions otherwise.
+func NewCloudEventFromDefinition(definition model.Event, contextAttributes map[string]interface{}, data interface{}) (event.Event, error) {
+	ce := event.New()
+	ce.SetID(uuid.New().String())
+	ce.SetType(definition.Type)
+	ce.SetSource(definition.Source)
+	for _, correlation := range definition.Correlation {
+		if len(correlation.ContextAttributeValue) > 0 {
+			if err := setAttribute(&ce, correlation.ContextAttributeName, correlation.ContextAttributeValue); err != nil {
+				return event.Event{}, err
+			}
+		}
+	}
+	for name, value := range contextAttributes {
+		if err := setAttribute(&ce, name, value); err != nil {
+			return event.Event{}, err
+		}
+	}
+	if data != nil {
+		if err := ce.SetData(ContentTypeJSON, data); err != nil {
+			return event.Event{}, err
+		}
+	}
+	if err := ce.Validate(); err != nil {
+		return event.Event{}, err
+	}
+	return ce, nil
+}
+
+// Matches verifies if the incoming CloudEvent satisfies the given consumed event definition:
+// type and source must be equal and every correlation attribute must be present, with the same value when it's defined.
+func Matches(definition model.Event, ce event.Event) bool {
+	return MatchesCorrelation(definition, ce, nil)
+}
+
+// MatchesCorrelation same as Matches, but also requires that the correlation attributes without a static value
+// in the definition have the values of the given correlation context, usually the values taken from the first event
+// consumed by the workflow instance. See CorrelationContext.
+func MatchesCorrelation(definition model.Event, ce event.Event, correlationContext map[string]string) bool {
+	if ce.Type() != definition.Type {
+		return false
+	}
+	if len(definition.Source) > 0 && ce.Source() != definition.Source {
+		return false
+	}
+	for _, correlation := range definition.Correlation {
+		value, found := Attribute(ce, correlation.ContextAttributeName)
+		if !found {
+			return false
+		}
+		expected := correlation.ContextAttributeValue
+		if len(expected) == 0 {
+			expected = correlationContext[correlation.ContextAttributeName]
+		}
+		if len(expected) > 0 && value != expected {
+			return false
+		}
+	}
+	return true
+}
+
+// CorrelationContext returns the values of the correlation attributes of the given event definition found in the CloudEvent
+func CorrelationContext(definition model.Event, ce event.Event) map[string]string {
+	context := map[string]string{}
+	for _, correlation := range definition.Correlation {
+		if value, found := Attribute(ce, correlation.ContextAttributeName); found {
+			context[correlation.ContextAttributeName] = value
+		}
+	}
+	return context
+}
+
+// MatchEvent returns the first consumed event definition of the workflow satisfied by the given CloudEvent
+func MatchEvent(workflow *model.Workflow, ce event.Event) (*model.Event, bool) {
+	for i, definition := range workflow.Events {
+		if definition.Kind == model.EventKindProduced {
+			continue
+		}
+		if Matches(definition, ce) {
+			return &workflow.Events[i], true
+		}
+	}
+	return nil, false
+}
+
+// attributeSetters setters of the CloudEvent attributes holding strings
+var attributeSetters = map[string]func(ce *event.Event, value string){
+	attributeID:              (*event.Event).SetID,
+	attributeSource:          (*event.Event).SetSource,
+	attributeType:            (*event.Event).SetType,
+	attributeSubject:         (*event.Event).SetSubject,
+	attributeSpecVersion:     (*event.Event).SetSpecVersion,
+	attributeDataSchema:      (*event.Event).SetDataSchema,
+	attributeDataContentType: (*event.Event).SetDataContentType,
+}
+
+// setAttribute sets the given context attribute of the CloudEvent with its setter if it's a CloudEvent attribute, e.g.
+// subject or time, as an extension otherwise. It's the counterpart of Attribute.
+func setAttribute(ce *event.Event, name string, value interface{}) error {
+	if name == attributeTime {
+		t, err := types.ToTime(value)
+		if err != nil {
+			return fmt.Errorf("invalid context attribute %s: %w", name, err)
+		}
+		ce.SetTime(t)
+		return nil
+	}
+	setter, ok := attributeSetters[name]
+	if !ok {
+		ce.SetExtension(name, value)
+		return nil
+	}
+	formatted, err := types.Format(value)
+	if err != nil {
+		return fmt.Errorf("invalid context attribute %s: %w", name, err)
+	}
+	setter(ce, formatted)
+	return nil
+}
+
+// Attribute returns the canonical string value of the given context attribute or extension of the CloudEvent
+func Attribute(ce event.Event, name string) (string, bool) {
+	var value string
+	switch name {
+	case attributeID:
+		value = ce.ID()
+	case attributeSource:
+		value = ce.Source()
+	case attributeType:
+		value = ce.Type()
+	case attributeSubject:
+		value = ce.Subject()
+	case attributeSpecVersion:
+		value = ce.SpecVersion()
+	case attributeDataSchema:
+		value = ce.DataSchema()
+	case attributeDataContentType:
+		value = ce.DataContentType()
+	case attributeTime:
+		if ce.Time().IsZero() {
+			return "", false
+		}
+		value = types.FormatTime(ce.Time())
+	default:
+		extension, found := ce.Extensions()[name]
+		if !found {
+			return "", false
+		}
+		formatted, err := types.Format(extension)
+		if err != nil {
+			return "", false
+		}
+		return formatted, true
+	}
+	return value, len(value) > 0
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"testing"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var testWorkflow = &model.Workflow{
+	BaseWorkflow: model.BaseWorkflow{ID: "orders"},
+	Events: []model.Event{
+		{Name: "OrderCreated", Type: "order.created", Source: "/orders", Kind: model.EventKindConsumed,
+			Correlation: []model.Correlation{{ContextAttributeName: "tenant", ContextAttributeValue: "acme"}, {ContextAttributeName: "orderid"}}},
+		{Name: "OrderConfirmed", Type: "order.confirmed", Source: "/workflow/orders", Kind: model.EventKindProduced,
+			Correlation: []model.Correlation{{ContextAttributeName: "tenant", ContextAttributeValue: "acme"}}},
+	},
+}
+
+func TestNewCloudEvent(t *testing.T) {
+	produce := model.ProduceEvent{
+		EventRef:          "OrderConfirmed",
+		Data:              map[string]interface{}{"confirmed": true},
+		ContextAttributes: map[string]interface{}{"orderid": "1234"},
+	}
+	ce, err := NewCloudEvent(testWorkflow, produce, nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, ce.ID())
+	assert.Equal(t, "order.confirmed", ce.Type())
+	assert.Equal(t, "/workflow/orders", ce.Source())
+	assert.Equal(t, "acme", ce.Extensions()["tenant"])
+	assert.Equal(t, "1234", ce.Extensions()["orderid"])
+	assert.JSONEq(t, `{"confirmed": true}`, string(ce.Data()))
+
+	// the CloudEvent attributes are set with their setters, not as extensions
+	definition := model.Event{Name: "Shipped", Type: "order.shipped", Source: "/orders",
+		Correlation: []model.Correlation{{ContextAttributeName: "subject", ContextAttributeValue: "order-1234"}}}
+	ce, err = NewCloudEventFromDefinition(definition, map[string]interface{}{"time": "2022-06-01T12:00:00Z", "dataschema": "https://example.com/shipped.json"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "order-1234", ce.Subject())
+	assert.Equal(t, "2022-06-01T12:00:00Z", types.FormatTime(ce.Time()))
+	assert.Equal(t, "https://example.com/shipped.json", ce.DataSchema())
+	assert.Empty(t, ce.Extensions())
+	assert.True(t, Matches(definition, ce))
+	_, err = NewCloudEventFromDefinition(definition, map[string]interface{}{"time": "yesterday"}, nil)
+	assert.Error(t, err)
+
+	_, err = NewCloudEvent(testWorkflow, model.ProduceEvent{EventRef: "OrderCreated"}, nil)
+	assert.Error(t, err)
+	_, err = NewCloudEvent(testWorkflow, model.ProduceEvent{EventRef: "Unknown"}, nil)
+	assert.Error(t, err)
+}
+
+func TestMatches(t *testing.T) {
+	ce := event.New()
+	ce.SetID("1")
+	ce.SetType("order.created")
+	ce.SetSource("/orders")
+	ce.SetExtension("tenant", "acme")
+	ce.SetExtension("orderid", "1234")
+
+	definition := testWorkflow.Events[0]
+	assert.True(t, Matches(definition, ce))
+	assert.Equal(t, map[string]string{"tenant": "acme", "orderid": "1234"}, CorrelationContext(definition, ce))
+	assert.True(t, MatchesCorrelation(definition, ce, map[string]string{"orderid": "1234"}))
+	assert.False(t, MatchesCorrelation(definition, ce, map[string]string{"orderid": "5678"}))
+
+	matched, ok := MatchEvent(testWorkflow, ce)
+	assert.True(t, ok)
+	assert.Equal(t, "OrderCreated", matched.Name)
+
+	other := ce.Clone()
+	other.SetExtension("tenant", "other")
+	assert.False(t, Matches(definition, other))
+
+	missing := ce.Clone()
+	missing.SetExtension("orderid", nil)
+	assert.False(t, Matches(definition, missing))
+}
@@ -0,0 +1,313 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// Workflow describes the workflow in plain English, one paragraph for the workflow and one per state, e.g.
+// "Greet: On GreetingEvent, call greetingFunction, retrying up to 10 times with PT3S delay, then transition to Store."
+// The description is meant for reviews with non developers and change summaries, not as a specification.
+func Workflow(workflow *model.Workflow) string {
+	e := &explainer{workflow: workflow, retries: map[string]model.Retry{}}
+	for _, retry := range workflow.Retries {
+		e.retries[retry.Name] = retry
+	}
+	buf := new(bytes.Buffer)
+	buf.WriteString(e.header())
+	for _, state := range workflow.States {
+		buf.WriteString("\n")
+		buf.WriteString(e.state(state))
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+type explainer struct {
+	workflow *model.Workflow
+	retries  map[string]model.Retry
+}
+
+func (e *explainer) header() string {
+	w := e.workflow
+	name := w.Name
+	if len(name) == 0 {
+		name = w.ID
+	}
+	var details []string
+	if len(w.ID) > 0 && w.ID != name {
+		details = append(details, w.ID)
+	}
+	if len(w.Version) > 0 {
+		details = append(details, "version "+w.Version)
+	}
+	header := name
+	if len(details) > 0 {
+		header += " (" + strings.Join(details, ", ") + ")"
+	}
+	if len(w.Description) > 0 {
+		header += ": " + strings.TrimSuffix(w.Description, ".") + "."
+	}
+	header += "\n"
+	if w.Start != nil {
+		header += "\nStarts at " + w.Start.StateName
+		if w.Start.Schedule != nil {
+			switch {
+			case len(w.Start.Schedule.Interval) > 0:
+				header += ", every " + w.Start.Schedule.Interval
+			case len(w.Start.Schedule.Cron.Expression) > 0:
+				header += ", on the cron schedule " + w.Start.Schedule.Cron.Expression
+			}
+		}
+		header += ".\n"
+	}
+	return header
+}
+
+func (e *explainer) state(state model.State) string {
+	var sentences []string
+	var body string
+	switch s := state.(type) {
+	case *model.OperationState:
+		body = e.actions(s.Actions, s.ActionMode == model.ActionModeParallel)
+	case *model.EventState:
+		var parts []string
+		for _, onEvent := range s.OnEvents {
+			separator := " and "
+			if s.Exclusive {
+				separator = " or "
+			}
+			part := "on " + strings.Join(onEvent.EventRefs, separator)
+			if actions := e.actions(onEvent.Actions, onEvent.ActionMode == model.ActionModeParallel); len(actions) > 0 {
+				part += ", " + actions
+			}
+			parts = append(parts, part)
+		}
+		body = strings.Join(parts, "; ")
+	case *model.DataBasedSwitchState:
+		var parts []string
+		for _, condition := range s.DataConditions {
+			label := condition.GetCondition()
+			if len(condition.GetName()) > 0 {
+				label = condition.GetName()
+			}
+			switch c := condition.(type) {
+			case *model.TransitionDataCondition:
+				parts = append(parts, "if "+label+", transition to "+c.Transition.NextState)
+			case *model.EndDataCondition:
+				parts = append(parts, "if "+label+", "+e.end(&c.End))
+			}
+		}
+		parts = append(parts, "otherwise "+e.defaultCondition(s.DefaultCondition))
+		body = capitalize(strings.Join(parts, "; "))
+	case *model.EventBasedSwitchState:
+		var parts []string
+		for _, condition := range s.EventConditions {
+			switch c := condition.(type) {
+			case *model.TransitionEventCondition:
+				parts = append(parts, "on "+c.EventRef+", transition to "+c.Transition.NextState)
+			case *model.EndEventCondition:
+				parts = append(parts, "on "+c.EventRef+", "+e.end(&c.End))
+			}
+		}
+		otherwise := "if no event arrives"
+		if len(s.Timeouts.EventTimeout) > 0 {
+			otherwise += " within " + s.Timeouts.EventTimeout
+		}
+		parts = append(parts, otherwise+", "+e.defaultCondition(s.DefaultCondition))
+		body = "Wait for the first event: " + strings.Join(parts, "; ")
+	case *model.SleepState:
+		body = "Sleep for " + s.Duration
+	case *model.InjectState:
+		keys := make([]string, 0, len(s.Data))
+		for key := range s.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		body = "Inject " + strings.Join(keys, ", ") + " into the state data"
+	case *model.ParallelState:
+		names := make([]string, len(s.Branches))
+		for i, branch := range s.Branches {
+			names[i] = branch.Name
+		}
+		body = fmt.Sprintf("Run the branches %s in parallel", strings.Join(names, ", "))
+		if s.CompletionType == model.CompletionTypeAtLeast {
+			body += fmt.Sprintf(", waiting for at least %s of them", s.NumCompleted.String())
+		} else {
+			body += ", waiting for all of them"
+		}
+		for _, branch := range s.Branches {
+			if actions := e.actions(branch.Actions, false); len(actions) > 0 {
+				sentences = append(sentences, fmt.Sprintf("Branch %s: %s.", branch.Name, actions))
+			}
+		}
+	case *model.ForEachState:
+		body = fmt.Sprintf("For each %s in %s", s.IterationParam, s.InputCollection)
+		if actions := e.actions(s.Actions, false); len(actions) > 0 {
+			body += ", " + actions
+		}
+	case *model.CallbackState:
+		body = e.action(s.Action) + " and wait for " + s.EventRef
+		if len(s.Timeouts.EventTimeout) > 0 {
+			body += " up to " + s.Timeouts.EventTimeout
+		}
+	}
+
+	if next := e.next(state); len(next) > 0 {
+		if len(body) > 0 {
+			body += ", then " + next
+		} else {
+			body = capitalize(next)
+		}
+	}
+	if len(body) > 0 {
+		sentences = append([]string{capitalize(body) + "."}, sentences...)
+	}
+	for _, onError := range state.GetOnErrors() {
+		sentences = append(sentences, e.onError(onError))
+	}
+	if compensatedBy := state.GetCompensatedBy(); len(compensatedBy) > 0 {
+		sentences = append(sentences, "Compensated by "+compensatedBy+".")
+	}
+	if state.GetUsedForCompensation() {
+		sentences = append(sentences, "Only used for compensation.")
+	}
+	return fmt.Sprintf("%s (%s): %s", state.GetName(), state.GetType(), strings.Join(sentences, " "))
+}
+
+// actions describes the actions run in sequence or in parallel
+func (e *explainer) actions(actions []model.Action, parallel bool) string {
+	descriptions := make([]string, len(actions))
+	for i, action := range actions {
+		descriptions[i] = e.action(action)
+	}
+	if parallel && len(actions) > 1 {
+		return strings.Join(descriptions[:len(descriptions)-1], ", ") + " and " + descriptions[len(descriptions)-1] + " in parallel"
+	}
+	return strings.Join(descriptions, ", then ")
+}
+
+func (e *explainer) action(action model.Action) string {
+	var description string
+	switch {
+	case len(action.FunctionRef.RefName) > 0:
+		description = "call " + action.FunctionRef.RefName
+	case len(action.EventRef.GetTriggerEventRef()) > 0:
+		description = "produce " + action.EventRef.TriggerEventRef + " and wait for " + action.EventRef.GetResultEventRef()
+	case len(action.SubFlowRef.WorkflowID) > 0:
+		description = "run the workflow " + action.SubFlowRef.WorkflowID
+		if len(action.SubFlowRef.Version) > 0 {
+			description += " version " + action.SubFlowRef.Version
+		}
+	default:
+		description = "run " + action.Name
+	}
+	if retry, ok := e.retries[action.RetryRef]; ok && len(action.RetryRef) > 0 {
+		description += ", " + describeRetry(retry)
+	}
+	return description
+}
+
+func describeRetry(retry model.Retry) string {
+	description := "retrying up to " + retry.MaxAttempts.String() + " times"
+	if len(retry.Delay) > 0 {
+		description += " with " + retry.Delay + " delay"
+	}
+	if retry.Multiplier != nil {
+		description += fmt.Sprintf(" multiplied by %s", retry.Multiplier.String())
+	}
+	if len(retry.MaxDelay) > 0 {
+		description += " up to " + retry.MaxDelay
+	}
+	return description
+}
+
+// next describes what happens after the state completes
+func (e *explainer) next(state model.State) string {
+	if transition := state.GetTransition(); transition != nil && len(transition.NextState) > 0 {
+		return "transition to " + transition.NextState
+	}
+	if end := state.GetEnd(); end != nil {
+		return e.end(end)
+	}
+	return ""
+}
+
+func (e *explainer) end(end *model.End) string {
+	description := "end the workflow"
+	if end.Terminate {
+		description = "terminate the workflow"
+	}
+	var also []string
+	if len(end.ProduceEvents) > 0 {
+		events := make([]string, len(end.ProduceEvents))
+		for i, produce := range end.ProduceEvents {
+			events[i] = produce.EventRef
+		}
+		also = append(also, "producing "+strings.Join(events, ", "))
+	}
+	if end.Compensate {
+		also = append(also, "triggering the compensation")
+	}
+	if end.ContinueAs != nil && len(end.ContinueAs.WorkflowID) > 0 {
+		also = append(also, "continuing as "+end.ContinueAs.WorkflowID)
+	}
+	if len(also) > 0 {
+		description += ", " + strings.Join(also, " and ")
+	}
+	return description
+}
+
+func (e *explainer) defaultCondition(condition model.DefaultCondition) string {
+	if len(condition.Transition.NextState) > 0 {
+		return "transition to " + condition.Transition.NextState
+	}
+	return e.end(&condition.End)
+}
+
+func (e *explainer) onError(onError model.OnError) string {
+	errors := onError.ErrorRef
+	if len(onError.ErrorRefs) > 0 {
+		errors = strings.Join(onError.ErrorRefs, " or ")
+	}
+	switch errors {
+	case "":
+		errors = "an error"
+	case "*":
+		errors = "any error"
+	default:
+		errors = "error " + errors
+	}
+	action := "ignore it"
+	if onError.Transition != nil && len(onError.Transition.NextState) > 0 {
+		action = "transition to " + onError.Transition.NextState
+	} else if onError.End != nil {
+		action = e.end(onError.End)
+	}
+	return "On " + errors + ", " + action + "."
+}
+
+func capitalize(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
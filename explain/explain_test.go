@@ -0,0 +1,124 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderWorkflow = `
+id: order
+name: Order
+version: '1.0'
+specVersion: '0.7'
+description: Handle new orders
+start: Receive
+retries:
+  - name: threeTimes
+    delay: PT3S
+    maxAttempts: 3
+events:
+  - name: OrderCreated
+    type: order.created
+    source: orders
+  - name: OrderPaid
+    type: order.paid
+    source: orders
+  - name: OrderShipped
+    type: order.shipped
+    source: orders
+functions:
+  - name: storeOrder
+    operation: http://orders.json#store
+  - name: notify
+    operation: http://notify.json#send
+  - name: ship
+    operation: http://shipping.json#ship
+errors:
+  - name: StoreFailed
+    code: '500'
+states:
+  - name: Receive
+    type: event
+    onEvents:
+      - eventRefs: [OrderCreated]
+        actions:
+          - functionRef: storeOrder
+            retryRef: threeTimes
+    onErrors:
+      - errorRef: StoreFailed
+        end: true
+    transition: Check
+  - name: Check
+    type: switch
+    dataConditions:
+      - name: paid
+        condition: ${ .paid }
+        transition: Prepare
+    defaultCondition:
+      transition: Wait
+  - name: Wait
+    type: sleep
+    duration: PT1H
+    transition: Prepare
+  - name: Prepare
+    type: parallel
+    completionType: atLeast
+    numCompleted: 1
+    branches:
+      - name: Notify
+        actions:
+          - functionRef: notify
+      - name: Ship
+        actions:
+          - functionRef: ship
+    transition: Done
+  - name: Done
+    type: inject
+    data:
+      status: done
+    end:
+      produceEvents:
+        - eventRef: OrderShipped
+`
+
+func TestWorkflow(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+	assert.Equal(t, `Order (order, version 1.0): Handle new orders.
+
+Starts at Receive.
+
+Receive (event): On OrderCreated, call storeOrder, retrying up to 3 times with PT3S delay, then transition to Check. On error StoreFailed, end the workflow.
+
+Check (switch): If paid, transition to Prepare; otherwise transition to Wait.
+
+Wait (sleep): Sleep for PT1H, then transition to Prepare.
+
+Prepare (parallel): Run the branches Notify, Ship in parallel, waiting for at least 1 of them, then transition to Done. Branch Notify: call notify. Branch Ship: call ship.
+
+Done (inject): Inject status into the state data, then end the workflow, producing OrderShipped.
+`, Workflow(workflow))
+}
+
+func TestWorkflowFromTestdata(t *testing.T) {
+	workflow, err := parser.FromFile("../parser/testdata/workflows/eventbasedgreetingexclusive.sw.json")
+	require.NoError(t, err)
+	assert.Contains(t, Workflow(workflow), "Greet (event): On GreetingEvent, call greetingFunction; on GreetingEvent2, call greetingFunction2, then terminate the workflow.")
+}
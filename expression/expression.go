@@ -0,0 +1,188 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package expression evaluates the workflow expressions, such as the arguments of the actions, the data filters and
+// the conditions of the switch states, against JSON data.
+package expression
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+const (
+	// VariableConstants variable holding the workflow constants
+	VariableConstants = "$CONST"
+	// VariableSecrets variable holding the workflow secrets
+	VariableSecrets = "$SECRETS"
+)
+
+// Variables values of the variables the expressions can reference, by name including the '$', e.g. '$CONST'
+type Variables map[string]interface{}
+
+// Evaluator evaluates the expressions of a language, e.g. JQ for the default jq expression language
+type Evaluator interface {
+	// Evaluate evaluates the expression, with or without the '${ }' delimiters, against the input JSON data, e.g.
+	// a map[string]interface{} or a value marshaled into JSON, returning the JSON result
+	Evaluate(ctx context.Context, expression string, input interface{}, vars Variables) (interface{}, error)
+}
+
+// IsExpression checks whether the value is a workflow expression, e.g. '${ .order.total }'
+func IsExpression(value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}")
+}
+
+// Strip removes the '${ }' delimiters of the expression
+func Strip(expression string) string {
+	expression = strings.TrimSpace(expression)
+	if IsExpression(expression) {
+		return strings.TrimSpace(expression[2 : len(expression)-1])
+	}
+	return expression
+}
+
+// WorkflowVariables returns the variables of the workflow: its constants and, if not nil, the given secrets
+func WorkflowVariables(workflow *model.Workflow, secrets map[string]string) (Variables, error) {
+	constants := map[string]interface{}{}
+	if workflow.Constants != nil {
+		for name, value := range workflow.Constants.Data {
+			var v interface{}
+			if err := json.Unmarshal(value, &v); err != nil {
+				return nil, fmt.Errorf("constant %s: %w", name, err)
+			}
+			constants[name] = v
+		}
+	}
+	vars := Variables{VariableConstants: constants}
+	if secrets != nil {
+		values := make(map[string]interface{}, len(secrets))
+		for name, value := range secrets {
+			values[name] = value
+		}
+		vars[VariableSecrets] = values
+	}
+	return vars, nil
+}
+
+// EvaluateCondition evaluates the condition, e.g. of a switch state, the condition holding unless the result is
+// false or null
+func EvaluateCondition(ctx context.Context, evaluator Evaluator, condition string, input interface{}, vars Variables) (bool, error) {
+	result, err := evaluator.Evaluate(ctx, condition, input, vars)
+	if err != nil {
+		return false, err
+	}
+	return result != nil && result != false, nil
+}
+
+// EvaluateFilter evaluates the data filter, e.g. the input or output filter of a state, the filter selecting the
+// whole input if empty
+func EvaluateFilter(ctx context.Context, evaluator Evaluator, filter string, input interface{}, vars Variables) (interface{}, error) {
+	if len(strings.TrimSpace(filter)) == 0 {
+		return normalize(input)
+	}
+	return evaluator.Evaluate(ctx, filter, input, vars)
+}
+
+// EvaluateArguments evaluates the expressions of the arguments of an action, returning the arguments passed to the
+// function: the values of the expressions, possibly nested in objects and arrays, and the other values as is
+func EvaluateArguments(ctx context.Context, evaluator Evaluator, arguments map[string]interface{}, input interface{}, vars Variables) (map[string]interface{}, error) {
+	evaluated, err := evaluateValue(ctx, evaluator, arguments, input, vars, "")
+	if err != nil {
+		return nil, err
+	}
+	return evaluated.(map[string]interface{}), nil
+}
+
+func evaluateValue(ctx context.Context, evaluator Evaluator, value, input interface{}, vars Variables, path string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		evaluated := make(map[string]interface{}, len(v))
+		for key, property := range v {
+			propertyPath := key
+			if len(path) > 0 {
+				propertyPath = path + "." + key
+			}
+			e, err := evaluateValue(ctx, evaluator, property, input, vars, propertyPath)
+			if err != nil {
+				return nil, err
+			}
+			evaluated[key] = e
+		}
+		return evaluated, nil
+	case []interface{}:
+		evaluated := make([]interface{}, len(v))
+		for i, element := range v {
+			e, err := evaluateValue(ctx, evaluator, element, input, vars, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			evaluated[i] = e
+		}
+		return evaluated, nil
+	case string:
+		if !IsExpression(v) {
+			return v, nil
+		}
+		e, err := evaluator.Evaluate(ctx, v, input, vars)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return e, nil
+	}
+	return value, nil
+}
+
+// normalize converts the value into the generic JSON values: maps, slices, strings, float64, booleans and nil
+func normalize(value interface{}) (interface{}, error) {
+	if generic(value) {
+		return value, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// generic whether the value only holds generic JSON values
+func generic(value interface{}) bool {
+	switch v := value.(type) {
+	case nil, bool, string, float64:
+		return true
+	case map[string]interface{}:
+		for _, property := range v {
+			if !generic(property) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		for _, element := range v {
+			if !generic(element) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
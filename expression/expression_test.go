@@ -0,0 +1,150 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"context"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Evaluator = &JQ{}
+
+const orderWorkflow = `id: order
+name: Order
+version: '1.0'
+specVersion: '0.8'
+start: Check
+constants:
+  limits:
+    max: 100
+functions:
+  - name: storeOrder
+    operation: https://orders.example.com/openapi.json#storeOrder
+states:
+  - name: Check
+    type: switch
+    dataConditions:
+      - condition: ${ .order.total > $CONST.limits.max }
+        transition: Store
+    defaultCondition:
+      end: true
+  - name: Store
+    type: operation
+    stateDataFilter:
+      output: ${ .order }
+    actions:
+      - functionRef:
+          refName: storeOrder
+          arguments:
+            id: ${ .order.id }
+            customer:
+              name: ${ .order.customer | ascii_upcase }
+            items: [ '${ .order.items | length }', fixed ]
+            token: ${ "Bearer " + $SECRETS.token }
+            priority: 1
+    end: true
+`
+
+func TestEvaluateWorkflow(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+	vars, err := WorkflowVariables(workflow, map[string]string{"token": "t1"})
+	require.NoError(t, err)
+	data := map[string]interface{}{
+		"order": map[string]interface{}{"id": "o1", "total": 150, "customer": "ada", "items": []string{"a", "b"}},
+	}
+	jq := NewJQ()
+	ctx := context.Background()
+
+	condition := workflow.States[0].(*model.DataBasedSwitchState).DataConditions[0].GetCondition()
+	holds, err := EvaluateCondition(ctx, jq, condition, data, vars)
+	require.NoError(t, err)
+	assert.True(t, holds)
+	vars[VariableConstants] = map[string]interface{}{"limits": map[string]interface{}{"max": 200}}
+	holds, err = EvaluateCondition(ctx, jq, condition, data, vars)
+	require.NoError(t, err)
+	assert.False(t, holds)
+
+	store := workflow.States[1].(*model.OperationState)
+	arguments, err := EvaluateArguments(ctx, jq, store.Actions[0].FunctionRef.Arguments, data, vars)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id":       "o1",
+		"customer": map[string]interface{}{"name": "ADA"},
+		"items":    []interface{}{float64(2), "fixed"},
+		"token":    "Bearer t1",
+		"priority": float64(1),
+	}, arguments)
+
+	output, err := EvaluateFilter(ctx, jq, store.StateDataFilter.Output, data, vars)
+	require.NoError(t, err)
+	assert.Equal(t, "o1", output.(map[string]interface{})["id"])
+	output, err = EvaluateFilter(ctx, jq, "", data, vars)
+	require.NoError(t, err)
+	assert.Equal(t, "ada", output.(map[string]interface{})["order"].(map[string]interface{})["customer"])
+}
+
+func TestJQ(t *testing.T) {
+	jq := NewJQ()
+	ctx := context.Background()
+
+	result, err := jq.Evaluate(ctx, "${ .a + .b }", map[string]interface{}{"a": 1, "b": 2}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, float64(3), result)
+	result, err = jq.Evaluate(ctx, ".[] | select(. > 1)", []int{1, 2, 3}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, float64(2), result)
+	result, err = jq.Evaluate(ctx, "empty", nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+
+	_, err = jq.Evaluate(ctx, "${ .a + }", nil, nil)
+	assert.Error(t, err)
+	_, err = jq.Evaluate(ctx, "$CONST.a", nil, nil)
+	assert.Error(t, err)
+	_, err = jq.Evaluate(ctx, `error("failed")`, nil, nil)
+	assert.EqualError(t, err, `expression error("failed") failed: error: failed`)
+
+	_, err = EvaluateArguments(ctx, jq, map[string]interface{}{"a": map[string]interface{}{"b": "${ .x | error }"}}, map[string]interface{}{"x": "boom"}, nil)
+	assert.EqualError(t, err, "a.b: expression ${ .x | error } failed: error: boom")
+}
+
+func TestJQSharedPrograms(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewJQ().Evaluate(ctx, "${ .total * $CONST.rate }", map[string]interface{}{"total": 2}, Variables{"$CONST": map[string]interface{}{"rate": 3}})
+	require.NoError(t, err)
+	code, err := (&JQ{}).compile(".total * $CONST.rate", []string{"$CONST"})
+	require.NoError(t, err)
+	cached, err := NewJQ().compile(".total * $CONST.rate", []string{"$CONST"})
+	require.NoError(t, err)
+	assert.Same(t, code, cached)
+
+	// the same expression with other variables is compiled again
+	other, err := NewJQ().compile(".total * $CONST.rate", []string{"$CONST", "$SECRET"})
+	require.NoError(t, err)
+	assert.NotSame(t, code, other)
+}
+
+func TestStrip(t *testing.T) {
+	assert.Equal(t, ".order.total", Strip(" ${ .order.total } "))
+	assert.Equal(t, ".order.total", Strip(".order.total"))
+	assert.True(t, IsExpression("${ .a }"))
+	assert.False(t, IsExpression(".a"))
+}
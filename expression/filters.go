@@ -0,0 +1,193 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// FilterStateInput applies the input filter of the state data filter to the state data input, returning the state
+// data the state works on. The input is returned as is without a filter.
+func FilterStateInput(ctx context.Context, evaluator Evaluator, filter *model.StateDataFilter, input interface{}, vars Variables) (interface{}, error) {
+	if filter == nil {
+		return normalize(input)
+	}
+	filtered, err := EvaluateFilter(ctx, evaluator, filter.Input, input, vars)
+	if err != nil {
+		return nil, fmt.Errorf("stateDataFilter.input: %w", err)
+	}
+	return filtered, nil
+}
+
+// FilterStateOutput applies the output filter of the state data filter to the state data, returning the state data
+// output passed to the next state
+func FilterStateOutput(ctx context.Context, evaluator Evaluator, filter *model.StateDataFilter, data interface{}, vars Variables) (interface{}, error) {
+	if filter == nil {
+		return normalize(data)
+	}
+	filtered, err := EvaluateFilter(ctx, evaluator, filter.Output, data, vars)
+	if err != nil {
+		return nil, fmt.Errorf("stateDataFilter.output: %w", err)
+	}
+	return filtered, nil
+}
+
+// ActionInput applies the fromStateData filter of the action data filter to the state data, returning the data the
+// arguments of the action are evaluated against
+func ActionInput(ctx context.Context, evaluator Evaluator, filter *model.ActionDataFilter, data interface{}, vars Variables) (interface{}, error) {
+	if filter == nil {
+		return normalize(data)
+	}
+	filtered, err := EvaluateFilter(ctx, evaluator, filter.FromStateData, data, vars)
+	if err != nil {
+		return nil, fmt.Errorf("actionDataFilter.fromStateData: %w", err)
+	}
+	return filtered, nil
+}
+
+// MergeActionResults applies the results filter of the action data filter to the results of the action and merges
+// them into the state data, at the element selected by the toStateData filter or at the top level, see MergeData.
+// The state data is returned.
+func MergeActionResults(ctx context.Context, evaluator Evaluator, filter *model.ActionDataFilter, data, results interface{}, vars Variables) (interface{}, error) {
+	if filter == nil {
+		filter = &model.ActionDataFilter{}
+	}
+	filtered, err := EvaluateFilter(ctx, evaluator, filter.Results, results, vars)
+	if err != nil {
+		return nil, fmt.Errorf("actionDataFilter.results: %w", err)
+	}
+	merged, err := mergeAt(ctx, evaluator, filter.ToStateData, data, filtered, vars)
+	if err != nil {
+		return nil, fmt.Errorf("actionDataFilter.toStateData: %w", err)
+	}
+	return merged, nil
+}
+
+// MergeEventData applies the data filter of the event data filter to the payload of the event and merges it into the
+// state data, at the element selected by the toStateData filter or at the top level, see MergeData. The state data is
+// returned.
+func MergeEventData(ctx context.Context, evaluator Evaluator, filter *model.EventDataFilter, data, payload interface{}, vars Variables) (interface{}, error) {
+	if filter == nil {
+		filter = &model.EventDataFilter{}
+	}
+	filtered, err := EvaluateFilter(ctx, evaluator, filter.Data, payload, vars)
+	if err != nil {
+		return nil, fmt.Errorf("eventDataFilter.data: %w", err)
+	}
+	merged, err := mergeAt(ctx, evaluator, filter.ToStateData, data, filtered, vars)
+	if err != nil {
+		return nil, fmt.Errorf("eventDataFilter.toStateData: %w", err)
+	}
+	return merged, nil
+}
+
+// MergeData merges the source, e.g. the results of an action or the payload of an event, into the state data
+// following the data merging rules of the specification: objects are merged recursively, the source properties
+// overriding the state data ones, arrays are concatenated and other values replace the state data. The state data is
+// left unchanged.
+func MergeData(data, source interface{}) interface{} {
+	switch s := source.(type) {
+	case map[string]interface{}:
+		d, ok := data.(map[string]interface{})
+		if !ok {
+			return s
+		}
+		merged := make(map[string]interface{}, len(d)+len(s))
+		for key, value := range d {
+			merged[key] = value
+		}
+		for key, value := range s {
+			if current, ok := merged[key]; ok {
+				merged[key] = MergeData(current, value)
+			} else {
+				merged[key] = value
+			}
+		}
+		return merged
+	case []interface{}:
+		d, ok := data.([]interface{})
+		if !ok {
+			return s
+		}
+		merged := make([]interface{}, 0, len(d)+len(s))
+		return append(append(merged, d...), s...)
+	}
+	return source
+}
+
+// mergeAt merges the source into the element of the state data selected by the toStateData expression, e.g.
+// '${ .customer }', the element being created if missing. The expression must be a jq path expression.
+func mergeAt(ctx context.Context, evaluator Evaluator, toStateData string, data, source interface{}, vars Variables) (interface{}, error) {
+	data, err := normalize(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(toStateData)) == 0 {
+		return MergeData(data, source), nil
+	}
+	path, err := evaluator.Evaluate(ctx, "path("+Strip(toStateData)+")", data, vars)
+	if err != nil {
+		return nil, err
+	}
+	keys, ok := path.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s doesn't select an element of the state data", toStateData)
+	}
+	return mergePath(data, keys, source)
+}
+
+func mergePath(data interface{}, keys []interface{}, source interface{}) (interface{}, error) {
+	if len(keys) == 0 {
+		return MergeData(data, source), nil
+	}
+	switch key := keys[0].(type) {
+	case string:
+		object, ok := data.(map[string]interface{})
+		if !ok {
+			if data != nil {
+				return nil, fmt.Errorf("cannot merge into the property %s of a non object", key)
+			}
+			object = map[string]interface{}{}
+		}
+		value, err := mergePath(object[key], keys[1:], source)
+		if err != nil {
+			return nil, err
+		}
+		merged := make(map[string]interface{}, len(object)+1)
+		for k, v := range object {
+			merged[k] = v
+		}
+		merged[key] = value
+		return merged, nil
+	case float64:
+		array, ok := data.([]interface{})
+		index := int(key)
+		if !ok || index < 0 || index >= len(array) {
+			return nil, fmt.Errorf("cannot merge into the element %d of a %d elements array", index, len(array))
+		}
+		value, err := mergePath(array[index], keys[1:], source)
+		if err != nil {
+			return nil, err
+		}
+		merged := append([]interface{}{}, array...)
+		merged[index] = value
+		return merged, nil
+	}
+	return nil, fmt.Errorf("unsupported path element %v", keys[0])
+}
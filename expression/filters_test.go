@@ -0,0 +1,127 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseJSON(t *testing.T, source string) interface{} {
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(source), &value))
+	return value
+}
+
+// the state data filter example of the specification
+func TestStateDataFilter(t *testing.T) {
+	jq := NewJQ()
+	ctx := context.Background()
+	input := parseJSON(t, `{
+  "fruits": [ "apple", "orange", "pear" ],
+  "vegetables": [
+    { "veggieName": "potato", "veggieLike": true },
+    { "veggieName": "broccoli", "veggieLike": false }
+  ]
+}`)
+	filter := &model.StateDataFilter{
+		Input:  "${ {vegetables: .vegetables} }",
+		Output: "${ {vegetables: [.vegetables[] | select(.veggieLike == true)]} }",
+	}
+
+	data, err := FilterStateInput(ctx, jq, filter, input, nil)
+	require.NoError(t, err)
+	assert.Equal(t, parseJSON(t, `{"vegetables": [
+    { "veggieName": "potato", "veggieLike": true },
+    { "veggieName": "broccoli", "veggieLike": false }
+  ]}`), data)
+	output, err := FilterStateOutput(ctx, jq, filter, data, nil)
+	require.NoError(t, err)
+	assert.Equal(t, parseJSON(t, `{"vegetables": [{ "veggieName": "potato", "veggieLike": true }]}`), output)
+
+	output, err = FilterStateOutput(ctx, jq, nil, data, nil)
+	require.NoError(t, err)
+	assert.Equal(t, data, output)
+	_, err = FilterStateInput(ctx, jq, &model.StateDataFilter{Input: "${ .a | error }"}, map[string]interface{}{"a": "x"}, nil)
+	assert.EqualError(t, err, "stateDataFilter.input: expression ${ .a | error } failed: error: x")
+}
+
+// the action data filter examples of the specification
+func TestActionDataFilter(t *testing.T) {
+	jq := NewJQ()
+	ctx := context.Background()
+	data := parseJSON(t, `{"fruits": ["apple", "orange"], "vegetables": [{"veggieName": "potato"}]}`)
+	results := parseJSON(t, `{"greeting": "Hello", "vegetables": [{"veggieName": "carrot"}]}`)
+
+	input, err := ActionInput(ctx, jq, &model.ActionDataFilter{FromStateData: "${ .fruits }"}, data, nil)
+	require.NoError(t, err)
+	assert.Equal(t, parseJSON(t, `["apple", "orange"]`), input)
+
+	// merged at the top level
+	merged, err := MergeActionResults(ctx, jq, nil, data, results, nil)
+	require.NoError(t, err)
+	assert.Equal(t, parseJSON(t, `{
+  "fruits": ["apple", "orange"],
+  "vegetables": [{"veggieName": "potato"}, {"veggieName": "carrot"}],
+  "greeting": "Hello"
+}`), merged)
+
+	// filtered and merged into an element
+	merged, err = MergeActionResults(ctx, jq, &model.ActionDataFilter{Results: "${ .vegetables }", ToStateData: "${ .vegetables }"}, data, results, nil)
+	require.NoError(t, err)
+	assert.Equal(t, parseJSON(t, `{"fruits": ["apple", "orange"], "vegetables": [{"veggieName": "potato"}, {"veggieName": "carrot"}]}`), merged)
+	merged, err = MergeActionResults(ctx, jq, &model.ActionDataFilter{Results: "${ .greeting }", ToStateData: "${ .messages.greeting }"}, data, results, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", merged.(map[string]interface{})["messages"].(map[string]interface{})["greeting"])
+	merged, err = MergeActionResults(ctx, jq, &model.ActionDataFilter{Results: "${ .greeting }", ToStateData: "${ .vegetables[0].name }"}, data, results, nil)
+	require.NoError(t, err)
+	assert.Equal(t, parseJSON(t, `[{"veggieName": "potato", "name": "Hello"}]`), merged.(map[string]interface{})["vegetables"])
+
+	// the state data is left unchanged
+	assert.Equal(t, parseJSON(t, `{"fruits": ["apple", "orange"], "vegetables": [{"veggieName": "potato"}]}`), data)
+
+	_, err = MergeActionResults(ctx, jq, &model.ActionDataFilter{ToStateData: "${ .fruits[5] }"}, data, results, nil)
+	assert.EqualError(t, err, "actionDataFilter.toStateData: cannot merge into the element 5 of a 2 elements array")
+	_, err = MergeActionResults(ctx, jq, &model.ActionDataFilter{ToStateData: "${ .fruits[0].name }"}, data, results, nil)
+	assert.EqualError(t, err, `actionDataFilter.toStateData: expression path(.fruits[0].name) failed: expected an object but got: string ("apple")`)
+}
+
+// the event data filter example of the specification
+func TestEventDataFilter(t *testing.T) {
+	jq := NewJQ()
+	ctx := context.Background()
+	data := parseJSON(t, `{"patientId": "p1"}`)
+	payload := parseJSON(t, `{"vitals": {"temperature": 39}, "source": "monitor"}`)
+
+	merged, err := MergeEventData(ctx, jq, &model.EventDataFilter{Data: "${ .vitals }", ToStateData: "${ .vitals }"}, data, payload, nil)
+	require.NoError(t, err)
+	assert.Equal(t, parseJSON(t, `{"patientId": "p1", "vitals": {"temperature": 39}}`), merged)
+
+	merged, err = MergeEventData(ctx, jq, nil, data, payload, nil)
+	require.NoError(t, err)
+	assert.Equal(t, parseJSON(t, `{"patientId": "p1", "vitals": {"temperature": 39}, "source": "monitor"}`), merged)
+}
+
+func TestMergeData(t *testing.T) {
+	assert.Equal(t, parseJSON(t, `{"a": {"b": 1, "c": 2}, "d": [1, 2], "e": "new"}`),
+		MergeData(parseJSON(t, `{"a": {"b": 1}, "d": [1], "e": "old"}`), parseJSON(t, `{"a": {"c": 2}, "d": [2], "e": "new"}`)))
+	assert.Equal(t, "value", MergeData(parseJSON(t, `{"a": 1}`), "value"))
+	assert.Equal(t, parseJSON(t, `{"a": 1}`), MergeData("value", parseJSON(t, `{"a": 1}`)))
+}
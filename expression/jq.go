@@ -0,0 +1,91 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/itchyny/gojq"
+	"github.com/serverlessworkflow/sdk-go/v2/internal/cache"
+)
+
+// programs compiled jq expressions, shared by the evaluators and keyed by the hash of the expression and the names of
+// its variables
+var programs = cache.New(4096)
+
+// JQ evaluator of the jq expressions, the default expression language, built on gojq. The compiled expressions are
+// cached, shared by all the evaluators, a JQ being safe for concurrent use.
+type JQ struct{}
+
+// NewJQ returns a jq expression evaluator
+func NewJQ() *JQ {
+	return &JQ{}
+}
+
+// Evaluate evaluates the expression, returning its first result, nil if it has none. The input and the variables
+// holding other values than the generic JSON ones, e.g. structs or integers, are marshaled into JSON first, and the
+// result holds generic JSON values, the numbers being float64.
+func (j *JQ) Evaluate(ctx context.Context, expression string, input interface{}, vars Variables) (interface{}, error) {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	code, err := j.compile(Strip(expression), names)
+	if err != nil {
+		return nil, err
+	}
+	if input, err = normalize(input); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(names))
+	for i, name := range names {
+		if values[i], err = normalize(vars[name]); err != nil {
+			return nil, fmt.Errorf("variable %s: %w", name, err)
+		}
+	}
+
+	result, ok := code.RunWithContext(ctx, input, values...).Next()
+	if !ok {
+		return nil, nil
+	}
+	if err, ok := result.(error); ok {
+		return nil, fmt.Errorf("expression %s failed: %w", expression, err)
+	}
+	return normalize(result)
+}
+
+// compile returns the cached code of the expression compiled with the variables
+func (j *JQ) compile(expression string, names []string) (*gojq.Code, error) {
+	key := cache.Key([]byte(strings.Join(names, ",")), []byte(expression))
+	code, err := programs.GetOrCompile(key, func() (interface{}, error) {
+		query, err := gojq.Parse(expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression %s: %w", expression, err)
+		}
+		code, err := gojq.Compile(query, gojq.WithVariables(names))
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression %s: %w", expression, err)
+		}
+		return code, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return code.(*gojq.Code), nil
+}
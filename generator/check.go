@@ -0,0 +1,53 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// Check checks the property on n workflows generated from the seeds 1 to n. The first workflow failing it fails the
+// test with its seed, to reproduce it with New, and the shrunk workflow still failing, in JSON. The property
+// panicking fails too. The property receives copies of the workflows, it can modify them.
+func Check(t testing.TB, n int, config Config, property func(w *model.Workflow) error) {
+	t.Helper()
+	for seed := int64(1); seed <= int64(n); seed++ {
+		workflow := New(seed, config)
+		err := check(property, workflow)
+		if err == nil {
+			continue
+		}
+		shrunk := Shrink(workflow, func(w *model.Workflow) bool { return check(property, w) != nil })
+		data, _ := json.MarshalIndent(shrunk, "", "  ")
+		t.Fatalf("workflow of seed %d fails: %v\nshrunk workflow failing with %v:\n%s", seed, err,
+			check(property, shrunk), data)
+		// Fatalf may not stop the test, e.g. with a testing.TB of another framework
+		return
+	}
+}
+
+// check returns the error of the property on a copy of the workflow, panics included
+func check(property func(w *model.Workflow) error, workflow *model.Workflow) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return property(workflow.DeepCopy())
+}
@@ -0,0 +1,581 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package generator generates random workflows valid against the specification, for the property-based tests of the
+// parser, serializer, converters and runtimes. A workflow is determined by the seed of its random source and the
+// configuration, a failing workflow being reproduced from its seed, and Shrink reduces it to a smaller one still
+// failing, for debugging.
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Feature optional part of the generated workflows
+type Feature uint
+
+const (
+	// Retries retry definitions referenced by the actions
+	Retries Feature = 1 << iota
+	// Errors error definitions handled by the states
+	Errors
+	// Timeouts workflow and state timeouts
+	Timeouts
+	// DataFilters state, action and event data filters
+	DataFilters
+	// Metadata metadata of the workflow and its definitions
+	Metadata
+	// Schedule start schedule of the workflow
+	Schedule
+	// Auth auth definitions of the functions
+	Auth
+	// SubFlows actions invoking sub-workflows
+	SubFlows
+	// EventActions actions producing and consuming events
+	EventActions
+)
+
+// StateTypes types of the states generated by default, those of the specification but the delay states, replaced
+// by the sleep states. The switch states are data or event based.
+var StateTypes = []model.StateType{model.StateTypeOperation, model.StateTypeEvent, model.StateTypeSwitch,
+	model.StateTypeParallel, model.StateTypeForEach, model.StateTypeInject, model.StateTypeCallback,
+	model.StateTypeSleep}
+
+// Config of the generated workflows, the zero value generating workflows of every state type and feature
+type Config struct {
+	// MinStates minimum number of states, default is 1
+	MinStates int
+	// MaxStates maximum number of states, default is 10
+	MaxStates int
+	// MaxActions maximum number of actions of the states, branches and events with actions, default is 3
+	MaxActions int
+	// StateTypes types of the states, picked with the same probability: repeating a type makes it more likely.
+	// Default is StateTypes.
+	StateTypes []model.StateType
+	// Exclude features the workflows don't have, none by default
+	Exclude Feature
+}
+
+func (c Config) withDefaults() Config {
+	if c.MinStates <= 0 {
+		c.MinStates = 1
+	}
+	if c.MaxStates <= 0 {
+		c.MaxStates = 10
+	}
+	if c.MaxStates < c.MinStates {
+		c.MaxStates = c.MinStates
+	}
+	if c.MaxActions <= 0 {
+		c.MaxActions = 3
+	}
+	if len(c.StateTypes) == 0 {
+		c.StateTypes = StateTypes
+	}
+	return c
+}
+
+// New returns the workflow generated from the seed
+func New(seed int64, config Config) *model.Workflow {
+	return Workflow(rand.New(rand.NewSource(seed)), config) // #nosec
+}
+
+// Workflow returns a workflow generated from the random source. The states are chained from the first, the start
+// state, to the last, ending the workflow: every state transitions to the next one, the conditions, errors and
+// events to a following one, so that every state is reachable and the workflow ends. The functions, events, retries,
+// errors and auth definitions are those the states reference.
+func Workflow(r *rand.Rand, config Config) *model.Workflow {
+	config = config.withDefaults()
+	g := &generator{r: r, config: config, names: map[string]bool{}}
+	w := &model.Workflow{}
+	noun, verb := pick(r, nouns), pick(r, verbs)
+	w.ID = strings.ToLower(noun + "-" + verb)
+	w.Name = noun + " " + verb
+	w.Version = fmt.Sprintf("%d.%d", r.Intn(3)+1, r.Intn(10))
+	w.SpecVersion = "0.8"
+	w.ExpressionLang = model.DefaultExpressionLang
+	w.KeepActive = r.Intn(4) == 0
+	w.AutoRetries = r.Intn(4) == 0
+	if r.Intn(2) == 0 {
+		w.Description = "Generated " + w.Name
+	}
+
+	count := config.MinStates + r.Intn(config.MaxStates-config.MinStates+1)
+	g.states = make([]string, count)
+	for i := range g.states {
+		g.states[i] = g.uniqueName(pick(r, verbs) + pick(r, nouns))
+	}
+	w.Start = &model.Start{StateName: g.states[0]}
+	if g.has(Schedule) && r.Intn(3) == 0 {
+		w.Start.Schedule = &model.Schedule{Cron: &model.Cron{Expression: "0 0/15 * * * ?"}, Timezone: "UTC"}
+	}
+	for i := range g.states {
+		w.States = append(w.States, g.state(i))
+	}
+
+	if g.has(Timeouts) && r.Intn(3) == 0 {
+		w.Timeouts = &model.Timeouts{
+			WorkflowExecTimeout: &model.WorkflowExecTimeout{Duration: g.duration()},
+			ActionExecTimeout:   g.duration(),
+		}
+	}
+	if g.has(Metadata) && r.Intn(2) == 0 {
+		w.Metadata = g.metadata()
+	}
+	w.Functions = g.functions
+	w.Events = g.events
+	w.Retries = g.retries
+	w.Errors = g.errors
+	if len(g.auths) > 0 {
+		w.Auth = model.AuthDefinitions{Defs: g.auths}
+		w.Secrets = model.Secrets{"password", "token", "username"}
+	}
+	return w
+}
+
+// generator state of the generation of a workflow
+type generator struct {
+	r      *rand.Rand
+	config Config
+	// names used, the names of the workflow being unique across its definitions
+	names map[string]bool
+	// states names of the states, in order
+	states []string
+	// definitions referenced by the states
+	functions []model.Function
+	events    []model.Event
+	retries   []model.Retry
+	errors    []model.Error
+	auths     []model.Auth
+}
+
+var (
+	verbs = []string{"Check", "Validate", "Store", "Notify", "Approve", "Reject", "Charge", "Ship", "Archive",
+		"Compute", "Fetch", "Publish", "Route", "Reserve"}
+	nouns = []string{"Order", "Payment", "Customer", "Invoice", "Shipment", "Stock", "Report", "Account", "Review",
+		"Ticket"}
+)
+
+func pick(r *rand.Rand, values []string) string {
+	return values[r.Intn(len(values))]
+}
+
+func (g *generator) has(feature Feature) bool {
+	return g.config.Exclude&feature == 0
+}
+
+// uniqueName returns the name, or the name suffixed by a number if it's already used
+func (g *generator) uniqueName(name string) string {
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	g.names[unique] = true
+	return unique
+}
+
+func (g *generator) id() string {
+	return strings.ToLower(pick(g.r, nouns)) + "-" + strings.ToLower(pick(g.r, verbs))
+}
+
+func (g *generator) duration() string {
+	units := []string{"S", "M", "H"}
+	return fmt.Sprintf("PT%d%s", g.r.Intn(59)+1, pick(g.r, units))
+}
+
+func (g *generator) expression() string {
+	field := strings.ToLower(pick(g.r, nouns))
+	switch g.r.Intn(3) {
+	case 0:
+		return "${ ." + field + " }"
+	case 1:
+		return fmt.Sprintf("${ .%s.total > %d }", field, g.r.Intn(1000))
+	}
+	return "${ ." + field + " != null }"
+}
+
+// value returns a free-form JSON value, its numbers being float64 like the decoded ones
+func (g *generator) value(depth int) interface{} {
+	switch g.r.Intn(6) {
+	case 0:
+		return float64(g.r.Intn(1000))
+	case 1:
+		return g.r.Intn(2) == 0
+	case 2:
+		return g.expression()
+	case 3:
+		if depth < 2 {
+			return []interface{}{g.value(depth + 1), g.value(depth + 1)}
+		}
+	case 4:
+		if depth < 2 {
+			return g.object(depth + 1)
+		}
+	}
+	return strings.ToLower(pick(g.r, nouns))
+}
+
+func (g *generator) object(depth int) map[string]interface{} {
+	object := map[string]interface{}{}
+	for i := g.r.Intn(3) + 1; i > 0; i-- {
+		object[strings.ToLower(pick(g.r, nouns))] = g.value(depth)
+	}
+	return object
+}
+
+func (g *generator) metadata() model.Metadata {
+	return model.Metadata{"owner": strings.ToLower(pick(g.r, nouns)) + "-team", "tier": float64(g.r.Intn(3) + 1)}
+}
+
+// target returns the name of a state following the state i, or "" to end
+func (g *generator) target(i int) string {
+	if i+1 >= len(g.states) || g.r.Intn(4) == 0 {
+		return ""
+	}
+	return g.states[i+1+g.r.Intn(len(g.states)-i-1)]
+}
+
+// next sets the transition of the state i to the next state, or its end if it's the last state
+func (g *generator) next(i int, state *model.BaseState) {
+	if i+1 < len(g.states) {
+		state.Transition = &model.Transition{NextState: g.states[i+1]}
+		return
+	}
+	state.End = g.end()
+}
+
+func (g *generator) end() *model.End {
+	end := &model.End{}
+	if g.r.Intn(4) == 0 {
+		end.Terminate = true
+	}
+	if g.has(EventActions) && g.r.Intn(5) == 0 {
+		end.ProduceEvents = []model.ProduceEvent{{EventRef: g.event(model.EventKindProduced), Data: g.expression()}}
+	}
+	return end
+}
+
+func (g *generator) baseState(i int, stateType model.StateType) model.BaseState {
+	state := model.BaseState{Name: g.states[i], Type: stateType}
+	g.next(i, &state)
+	if g.has(DataFilters) && g.r.Intn(4) == 0 {
+		state.StateDataFilter = &model.StateDataFilter{Output: g.expression()}
+	}
+	if g.has(Errors) && g.r.Intn(4) == 0 {
+		onError := model.OnError{ErrorRef: g.error()}
+		if target := g.target(i); target != "" {
+			onError.Transition = &model.Transition{NextState: target}
+		} else {
+			onError.End = &model.End{}
+		}
+		state.OnErrors = []model.OnError{onError}
+	}
+	if g.has(Metadata) && g.r.Intn(4) == 0 {
+		metadata := g.metadata()
+		state.Metadata = &metadata
+	}
+	return state
+}
+
+func (g *generator) stateExecTimeout() model.StateExecTimeout {
+	if !g.has(Timeouts) || g.r.Intn(3) != 0 {
+		return model.StateExecTimeout{}
+	}
+	return model.StateExecTimeout{Total: g.duration()}
+}
+
+// state returns the state i, of a random type
+func (g *generator) state(i int) model.State {
+	stateType := g.config.StateTypes[g.r.Intn(len(g.config.StateTypes))]
+	switch stateType {
+	case model.StateTypeOperation:
+		state := &model.OperationState{BaseState: g.baseState(i, stateType), Actions: g.actions(1)}
+		state.ActionMode = g.actionMode()
+		state.Timeouts.StateExecTimeout = g.stateExecTimeout()
+		return state
+	case model.StateTypeEvent:
+		state := &model.EventState{BaseState: g.baseState(i, stateType), Exclusive: g.r.Intn(3) != 0}
+		for n := g.r.Intn(2) + 1; n > 0; n-- {
+			onEvents := model.OnEvents{EventRefs: []string{g.event(model.EventKindConsumed)}, Actions: g.actions(0)}
+			onEvents.ActionMode = g.actionMode()
+			if g.has(DataFilters) && g.r.Intn(3) == 0 {
+				onEvents.EventDataFilter = model.EventDataFilter{Data: g.expression()}
+			}
+			state.OnEvents = append(state.OnEvents, onEvents)
+		}
+		if g.has(Timeouts) && g.r.Intn(2) == 0 {
+			state.Timeout.EventTimeout = g.duration()
+		}
+		return state
+	case model.StateTypeSwitch:
+		if g.has(EventActions) && g.r.Intn(3) == 0 {
+			return g.eventSwitch(i)
+		}
+		return g.dataSwitch(i)
+	case model.StateTypeParallel:
+		state := &model.ParallelState{BaseState: g.baseState(i, stateType), CompletionType: model.CompletionTypeAllOf}
+		for n := g.r.Intn(3) + 1; n > 0; n-- {
+			state.Branches = append(state.Branches, model.Branch{Name: g.uniqueName("Branch"), Actions: g.actions(1)})
+		}
+		if len(state.Branches) > 1 && g.r.Intn(2) == 0 {
+			state.CompletionType = model.CompletionTypeAtLeast
+			state.NumCompleted = intstr.FromInt(g.r.Intn(len(state.Branches)) + 1)
+		}
+		state.Timeouts.StateExecTimeout = g.stateExecTimeout()
+		return state
+	case model.StateTypeForEach:
+		state := &model.ForEachState{BaseState: g.baseState(i, stateType), Actions: g.actions(1)}
+		noun := strings.ToLower(pick(g.r, nouns))
+		state.InputCollection = "${ ." + noun + "s }"
+		state.IterationParam = noun
+		state.Mode = model.ForEachModeTypeParallel
+		if g.r.Intn(2) == 0 {
+			state.Mode = model.ForEachModeTypeSequential
+		} else if g.r.Intn(2) == 0 {
+			state.BatchSize = intstr.FromInt(g.r.Intn(10) + 1)
+		}
+		state.Timeouts.StateExecTimeout = g.stateExecTimeout()
+		return state
+	case model.StateTypeInject:
+		state := &model.InjectState{BaseState: g.baseState(i, stateType), Data: g.object(0)}
+		state.Timeouts.StateExecTimeout = g.stateExecTimeout()
+		return state
+	case model.StateTypeCallback:
+		state := &model.CallbackState{BaseState: g.baseState(i, stateType), Action: g.functionAction(),
+			EventRef: g.event(model.EventKindConsumed)}
+		if g.has(Timeouts) && g.r.Intn(2) == 0 {
+			state.Timeouts.EventTimeout = g.duration()
+		}
+		return state
+	case model.StateTypeSleep:
+		state := &model.SleepState{BaseState: g.baseState(i, stateType), Duration: g.duration()}
+		state.Timeouts.StateExecTimeout = g.stateExecTimeout()
+		return state
+	}
+	panic(fmt.Sprintf("state type %s not supported", stateType))
+}
+
+func (g *generator) dataSwitch(i int) model.State {
+	base := g.baseState(i, model.StateTypeSwitch)
+	state := &model.DataBasedSwitchState{}
+	state.BaseState = base
+	// the default condition of the switch states replaces their transition
+	state.DefaultCondition = defaultCondition(&state.BaseState)
+	for n := g.r.Intn(3) + 1; n > 0; n-- {
+		condition := model.BaseDataCondition{Condition: g.expression()}
+		if target := g.target(i); target != "" {
+			state.DataConditions = append(state.DataConditions, &model.TransitionDataCondition{
+				BaseDataCondition: condition, Transition: model.Transition{NextState: target}})
+		} else {
+			state.DataConditions = append(state.DataConditions, &model.EndDataCondition{BaseDataCondition: condition})
+		}
+	}
+	return state
+}
+
+func (g *generator) eventSwitch(i int) model.State {
+	base := g.baseState(i, model.StateTypeSwitch)
+	state := &model.EventBasedSwitchState{}
+	state.BaseState = base
+	state.DefaultCondition = defaultCondition(&state.BaseState)
+	for n := g.r.Intn(2) + 1; n > 0; n-- {
+		condition := model.BaseEventCondition{EventRef: g.event(model.EventKindConsumed)}
+		if target := g.target(i); target != "" {
+			state.EventConditions = append(state.EventConditions, &model.TransitionEventCondition{
+				BaseEventCondition: condition, Transition: model.Transition{NextState: target}})
+		} else {
+			state.EventConditions = append(state.EventConditions, &model.EndEventCondition{BaseEventCondition: condition})
+		}
+	}
+	state.Timeouts.EventTimeout = g.duration()
+	return state
+}
+
+// defaultCondition moves the transition or end of the state to its default condition
+func defaultCondition(state *model.BaseState) model.DefaultCondition {
+	var condition model.DefaultCondition
+	if state.Transition != nil {
+		condition.Transition = *state.Transition
+	} else {
+		condition.End = *state.End
+	}
+	state.Transition, state.End = nil, nil
+	return condition
+}
+
+func (g *generator) actionMode() model.ActionMode {
+	if g.r.Intn(2) == 0 {
+		return model.ActionModeSequential
+	}
+	return model.ActionModeParallel
+}
+
+// actions returns at least min actions
+func (g *generator) actions(min int) []model.Action {
+	n := min + g.r.Intn(g.config.MaxActions-min+1)
+	if n == 0 {
+		// the actions omitted decode to nil
+		return nil
+	}
+	actions := make([]model.Action, 0, n)
+	for i := 0; i < n; i++ {
+		switch r := g.r.Intn(10); {
+		case r == 0 && g.has(SubFlows):
+			actions = append(actions, model.Action{Name: g.uniqueName("Run" + pick(g.r, nouns)),
+				SubFlowRef: model.WorkflowRef{WorkflowID: g.id(), Version: "1.0"}})
+		case r == 1 && g.has(EventActions):
+			actions = append(actions, model.Action{Name: g.uniqueName("Await" + pick(g.r, nouns)),
+				EventRef: &model.EventRef{TriggerEventRef: g.event(model.EventKindProduced),
+					ResultEventRef: g.event(model.EventKindConsumed), Data: g.expression()}})
+		default:
+			actions = append(actions, g.functionAction())
+		}
+	}
+	return actions
+}
+
+func (g *generator) functionAction() model.Action {
+	action := model.Action{FunctionRef: model.FunctionRef{RefName: g.function()}}
+	if g.r.Intn(2) == 0 {
+		action.Name = g.uniqueName("Call" + pick(g.r, nouns))
+	}
+	if g.r.Intn(2) == 0 {
+		action.FunctionRef.Arguments = g.object(0)
+	}
+	if g.has(Retries) && g.r.Intn(3) == 0 {
+		action.RetryRef = g.retry()
+	}
+	if g.has(DataFilters) && g.r.Intn(3) == 0 {
+		action.ActionDataFilter = model.ActionDataFilter{Results: g.expression()}
+	}
+	if g.has(Timeouts) && g.r.Intn(8) == 0 {
+		action.Sleep = model.Sleep{Before: g.duration()}
+	}
+	return action
+}
+
+// reuse whether to reference an existing definition rather than a new one
+func (g *generator) reuse(count int) bool {
+	return count > 0 && g.r.Intn(3) != 0
+}
+
+// function returns the name of a function, new or already defined
+func (g *generator) function() string {
+	if g.reuse(len(g.functions)) {
+		return g.functions[g.r.Intn(len(g.functions))].Name
+	}
+	noun := pick(g.r, nouns)
+	function := model.Function{Name: g.uniqueName(strings.ToLower(pick(g.r, verbs)) + noun)}
+	path := strings.ToLower(noun)
+	switch g.r.Intn(5) {
+	case 0:
+		function.Type = model.FunctionTypeRPC
+		function.Operation = "file://" + path + ".proto#" + noun + "Service#" + function.Name
+	case 1:
+		function.Type = model.FunctionTypeExpression
+		function.Operation = g.expression()
+	case 2:
+		function.Type = model.FunctionTypeGraphQL
+		function.Operation = "https://" + path + ".example.com/graphql#query#" + function.Name
+	default:
+		function.Type = model.FunctionTypeREST
+		function.Operation = "https://" + path + ".example.com/openapi.json#" + function.Name
+	}
+	if function.Type != model.FunctionTypeExpression && g.has(Auth) && g.r.Intn(4) == 0 {
+		function.AuthRef = g.auth()
+	}
+	if g.has(Metadata) && g.r.Intn(4) == 0 {
+		function.Metadata = g.metadata()
+	}
+	g.functions = append(g.functions, function)
+	return function.Name
+}
+
+// event returns the name of an event of the given kind, new or already defined
+func (g *generator) event(kind model.EventKind) string {
+	var defined []string
+	for _, event := range g.events {
+		if event.Kind == kind {
+			defined = append(defined, event.Name)
+		}
+	}
+	if g.reuse(len(defined)) {
+		return defined[g.r.Intn(len(defined))]
+	}
+	noun := pick(g.r, nouns)
+	event := model.Event{Name: g.uniqueName(noun + pick(g.r, []string{"Created", "Updated", "Approved", "Failed"})),
+		Source: "/" + strings.ToLower(noun), Kind: kind}
+	event.Type = "com.example." + strings.ToLower(event.Name)
+	if kind == model.EventKindConsumed && g.r.Intn(3) == 0 {
+		event.Correlation = []model.Correlation{{ContextAttributeName: strings.ToLower(noun) + "id"}}
+	}
+	g.events = append(g.events, event)
+	return event.Name
+}
+
+func (g *generator) retry() string {
+	if g.reuse(len(g.retries)) {
+		return g.retries[g.r.Intn(len(g.retries))].Name
+	}
+	retry := model.Retry{Name: g.uniqueName(pick(g.r, []string{"Default", "Fast", "Slow", "Patient"}) + "Retry"),
+		Delay: g.duration(), MaxAttempts: intstr.FromInt(g.r.Intn(10) + 1)}
+	g.retries = append(g.retries, retry)
+	return retry.Name
+}
+
+func (g *generator) error() string {
+	if g.reuse(len(g.errors)) {
+		return g.errors[g.r.Intn(len(g.errors))].Name
+	}
+	e := model.Error{Name: g.uniqueName(pick(g.r, nouns) + pick(g.r, []string{"NotFound", "Invalid", "Timeout"}))}
+	if g.r.Intn(2) == 0 {
+		e.Code = fmt.Sprintf("%d", 400+g.r.Intn(100))
+	}
+	g.errors = append(g.errors, e)
+	return e.Name
+}
+
+func (g *generator) auth() string {
+	if g.reuse(len(g.auths)) {
+		return g.auths[g.r.Intn(len(g.auths))].Name
+	}
+	auth := model.Auth{Name: g.uniqueName(strings.ToLower(pick(g.r, nouns)) + "Auth")}
+	if g.r.Intn(2) == 0 {
+		auth.Scheme = model.AuthTypeBearer
+		auth.Properties = &model.BearerAuthProperties{Token: "${ $SECRETS.token }"}
+	} else {
+		auth.Scheme = model.AuthTypeBasic
+		auth.Properties = &model.BasicAuthProperties{Username: "${ $SECRETS.username }",
+			Password: "${ $SECRETS.password }"}
+	}
+	g.auths = append(g.auths, auth)
+	return auth.Name
+}
+
+// Random random workflow generated by testing/quick, e.g. quick.Check(func(w generator.Random) bool { ... }, nil).
+// The size of quick bounds the number of states.
+type Random struct {
+	*model.Workflow
+}
+
+// Generate implements quick.Generator
+func (Random) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(Random{Workflow(r, Config{MaxStates: size})})
+}
@@ -0,0 +1,142 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"testing/quick"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	assert.Equal(t, New(7, Config{}), New(7, Config{}))
+	assert.NotEqual(t, New(7, Config{}), New(8, Config{}))
+
+	Check(t, 200, Config{MaxStates: 20}, func(w *model.Workflow) error {
+		if err := validator.Struct(w); err != nil {
+			return err
+		}
+		data, err := json.Marshal(w)
+		if err != nil {
+			return err
+		}
+		parsed, err := parser.FromJSONSource(data)
+		if err != nil {
+			return err
+		}
+		if !w.Equal(parsed) {
+			return errors.New("workflow changed by a round trip")
+		}
+		return nil
+	})
+}
+
+func TestConfig(t *testing.T) {
+	config := Config{MinStates: 3, MaxStates: 3, MaxActions: 1, StateTypes: []model.StateType{model.StateTypeOperation},
+		Exclude: Retries | Errors | Timeouts | DataFilters | Metadata | Schedule | Auth | SubFlows | EventActions}
+	for seed := int64(1); seed <= 50; seed++ {
+		w := New(seed, config)
+		require.Len(t, w.States, 3)
+		for _, state := range w.States {
+			operation := state.(*model.OperationState)
+			require.Len(t, operation.Actions, 1)
+			assert.NotEmpty(t, operation.Actions[0].FunctionRef.RefName)
+			assert.Empty(t, operation.OnErrors)
+			assert.Nil(t, operation.StateDataFilter)
+		}
+		assert.Empty(t, w.Retries)
+		assert.Empty(t, w.Errors)
+		assert.Empty(t, w.Events)
+		assert.Empty(t, w.Auth.Defs)
+		assert.Nil(t, w.Timeouts)
+		assert.Nil(t, w.Start.Schedule)
+	}
+}
+
+func TestShrink(t *testing.T) {
+	w := New(3, Config{MinStates: 8, MaxStates: 8})
+	hasCallback := func(w *model.Workflow) bool {
+		for _, state := range w.States {
+			if state.GetType() == model.StateTypeCallback {
+				return true
+			}
+		}
+		return false
+	}
+	for seed := int64(4); !hasCallback(w); seed++ {
+		w = New(seed, Config{MinStates: 8, MaxStates: 8})
+	}
+	before := New(3, Config{}).DeepCopy()
+
+	shrunk := Shrink(w, func(w *model.Workflow) bool {
+		require.NoError(t, validator.Struct(w))
+		return hasCallback(w) && len(w.States) >= 2
+	})
+	require.Len(t, shrunk.States, 2)
+	assert.True(t, hasCallback(shrunk))
+	assert.Len(t, w.States, 8)
+	assert.Equal(t, before, New(3, Config{}))
+
+	// the definitions left are those of the remaining states
+	for _, function := range shrunk.Functions {
+		used := false
+		for _, state := range shrunk.States {
+			for _, action := range model.GetActions(state) {
+				used = used || action.FunctionRef.RefName == function.Name
+			}
+		}
+		assert.True(t, used, function.Name)
+	}
+	assert.Nil(t, shrunk.Timeouts)
+	assert.Nil(t, shrunk.Metadata)
+}
+
+// failingT records the failure of Check
+type failingT struct {
+	testing.TB
+	message string
+}
+
+func (t *failingT) Helper() {}
+
+func (t *failingT) Fatalf(format string, args ...interface{}) {
+	t.message = fmt.Sprintf(format, args...)
+}
+
+func TestCheck(t *testing.T) {
+	ft := &failingT{TB: t}
+	Check(ft, 10, Config{MinStates: 5}, func(w *model.Workflow) error {
+		if len(w.States) > 1 {
+			panic("too many states")
+		}
+		return nil
+	})
+	assert.Contains(t, ft.message, "workflow of seed 1 fails: panic: too many states")
+	assert.Contains(t, ft.message, "shrunk workflow failing with panic: too many states")
+}
+
+func TestRandom(t *testing.T) {
+	require.NoError(t, quick.Check(func(w Random) bool {
+		return len(w.States) > 0 && validator.Struct(w.Workflow) == nil
+	}, nil))
+}
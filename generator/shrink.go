@@ -0,0 +1,368 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// Shrink returns a smaller workflow still failing, given a failing workflow, e.g. a generated workflow failing a
+// property: it removes the states, actions, branches, events handled, conditions and optional parts of the workflow
+// one at a time, keeping the removals after which fails still returns true, until none can be removed. The states
+// removed are replaced by the state they transition to in the references to them, the definitions no longer
+// referenced are removed too. The workflow isn't modified, fails receives copies of it.
+func Shrink(workflow *model.Workflow, fails func(*model.Workflow) bool) *model.Workflow {
+	current := workflow.DeepCopy()
+	for shrunk := true; shrunk; {
+		shrunk = false
+		for _, reduce := range reductions(current) {
+			candidate := current.DeepCopy()
+			if !reduce(candidate) {
+				continue
+			}
+			prune(candidate)
+			if fails(candidate.DeepCopy()) {
+				current = candidate
+				shrunk = true
+				break
+			}
+		}
+	}
+	return current
+}
+
+// reduction removes a part of the workflow, returns false if it can't
+type reduction func(w *model.Workflow) bool
+
+// reductions returns the reductions of the workflow, the largest first
+func reductions(w *model.Workflow) []reduction {
+	var reductions []reduction
+	for i := range w.States {
+		i := i
+		reductions = append(reductions, func(w *model.Workflow) bool { return removeState(w, i) })
+	}
+	for i, state := range w.States {
+		i := i
+		for l, list := range actionLists(state) {
+			l := l
+			for a := range *list {
+				a := a
+				reductions = append(reductions, func(w *model.Workflow) bool {
+					list := actionLists(w.States[i])[l]
+					if len(*list) <= 1 && requiresActions(w.States[i]) {
+						return false
+					}
+					*list = append((*list)[:a], (*list)[a+1:]...)
+					return true
+				})
+			}
+		}
+		reductions = append(reductions, func(w *model.Workflow) bool { return removeCase(w.States[i]) })
+		reductions = append(reductions, func(w *model.Workflow) bool { return removeOptional(w.States[i]) })
+	}
+	reductions = append(reductions, func(w *model.Workflow) bool {
+		changed := w.Timeouts != nil || w.Metadata != nil || w.Start.Schedule != nil || w.Description != "" ||
+			w.KeepActive || w.AutoRetries
+		w.Timeouts, w.Metadata, w.Start.Schedule, w.Description, w.KeepActive, w.AutoRetries = nil, nil, nil, "", false, false
+		return changed
+	})
+	return reductions
+}
+
+// baseState returns the properties common to every state type
+func baseState(state model.State) *model.BaseState {
+	switch s := state.(type) {
+	case *model.DelayState:
+		return &s.BaseState
+	case *model.EventState:
+		return &s.BaseState
+	case *model.OperationState:
+		return &s.BaseState
+	case *model.ParallelState:
+		return &s.BaseState
+	case *model.InjectState:
+		return &s.BaseState
+	case *model.ForEachState:
+		return &s.BaseState
+	case *model.CallbackState:
+		return &s.BaseState
+	case *model.SleepState:
+		return &s.BaseState
+	case *model.EventBasedSwitchState:
+		return &s.BaseState
+	case *model.DataBasedSwitchState:
+		return &s.BaseState
+	}
+	return nil
+}
+
+// actionLists returns the lists of actions of the state
+func actionLists(state model.State) []*[]model.Action {
+	switch s := state.(type) {
+	case *model.OperationState:
+		return []*[]model.Action{&s.Actions}
+	case *model.ForEachState:
+		return []*[]model.Action{&s.Actions}
+	case *model.EventState:
+		lists := make([]*[]model.Action, len(s.OnEvents))
+		for i := range s.OnEvents {
+			lists[i] = &s.OnEvents[i].Actions
+		}
+		return lists
+	case *model.ParallelState:
+		lists := make([]*[]model.Action, len(s.Branches))
+		for i := range s.Branches {
+			lists[i] = &s.Branches[i].Actions
+		}
+		return lists
+	}
+	return nil
+}
+
+// requiresActions whether the lists of actions of the state can't be empty
+func requiresActions(state model.State) bool {
+	_, events := state.(*model.EventState)
+	return !events
+}
+
+// removeCase removes the last branch, events handled or condition of the state, keeping one
+func removeCase(state model.State) bool {
+	switch s := state.(type) {
+	case *model.ParallelState:
+		if len(s.Branches) > 1 {
+			s.Branches = s.Branches[:len(s.Branches)-1]
+			if s.NumCompleted.IntValue() > len(s.Branches) {
+				s.NumCompleted.IntVal = int32(len(s.Branches))
+			}
+			return true
+		}
+	case *model.EventState:
+		if len(s.OnEvents) > 1 {
+			s.OnEvents = s.OnEvents[:len(s.OnEvents)-1]
+			return true
+		}
+	case *model.DataBasedSwitchState:
+		if len(s.DataConditions) > 1 {
+			s.DataConditions = s.DataConditions[:len(s.DataConditions)-1]
+			return true
+		}
+	case *model.EventBasedSwitchState:
+		if len(s.EventConditions) > 1 {
+			s.EventConditions = s.EventConditions[:len(s.EventConditions)-1]
+			return true
+		}
+	}
+	return false
+}
+
+// removeOptional removes the optional parts of the state and its actions
+func removeOptional(state model.State) bool {
+	base := baseState(state)
+	changed := base.OnErrors != nil || base.StateDataFilter != nil || base.Metadata != nil
+	base.OnErrors, base.StateDataFilter, base.Metadata = nil, nil, nil
+	for _, list := range actionLists(state) {
+		for i := range *list {
+			action := &(*list)[i]
+			stripped := model.Action{Name: action.Name, FunctionRef: model.FunctionRef{RefName: action.FunctionRef.RefName},
+				EventRef: action.EventRef, SubFlowRef: action.SubFlowRef}
+			if action.FunctionRef.Arguments != nil || action.RetryRef != "" || action.ActionDataFilter != stripped.ActionDataFilter ||
+				action.Sleep != stripped.Sleep {
+				changed = true
+			}
+			*action = stripped
+		}
+	}
+	return changed
+}
+
+// successor returns the state the state transitions to, "" if it ends
+func successor(state model.State) string {
+	switch s := state.(type) {
+	case *model.DataBasedSwitchState:
+		return s.DefaultCondition.Transition.NextState
+	case *model.EventBasedSwitchState:
+		return s.DefaultCondition.Transition.NextState
+	}
+	if transition := state.GetTransition(); transition != nil {
+		return transition.NextState
+	}
+	return ""
+}
+
+// removeState removes the state i, replaced by its successor in the references to it, or the end of the workflow
+func removeState(w *model.Workflow, i int) bool {
+	if len(w.States) <= 1 {
+		return false
+	}
+	name, next := w.States[i].GetName(), successor(w.States[i])
+	if w.Start.StateName == name {
+		if next == "" {
+			return false
+		}
+		w.Start.StateName = next
+	}
+	w.States = append(w.States[:i], w.States[i+1:]...)
+	for _, state := range w.States {
+		retarget(state, name, next)
+	}
+	return true
+}
+
+// retarget replaces the transitions of the state to the state from by transitions to the state to, or ends if to is
+// empty
+func retarget(state model.State, from, to string) {
+	base := baseState(state)
+	if base.Transition != nil && base.Transition.NextState == from {
+		if to == "" {
+			base.Transition, base.End = nil, &model.End{}
+		} else {
+			base.Transition.NextState = to
+		}
+	}
+	for i := range base.OnErrors {
+		onError := &base.OnErrors[i]
+		if onError.Transition != nil && onError.Transition.NextState == from {
+			if to == "" {
+				onError.Transition, onError.End = nil, &model.End{}
+			} else {
+				onError.Transition.NextState = to
+			}
+		}
+	}
+	var defaultCondition *model.DefaultCondition
+	switch s := state.(type) {
+	case *model.DataBasedSwitchState:
+		defaultCondition = &s.DefaultCondition
+		for i, condition := range s.DataConditions {
+			if c, ok := condition.(*model.TransitionDataCondition); ok && c.Transition.NextState == from {
+				if to == "" {
+					s.DataConditions[i] = &model.EndDataCondition{BaseDataCondition: c.BaseDataCondition}
+				} else {
+					c.Transition.NextState = to
+				}
+			}
+		}
+	case *model.EventBasedSwitchState:
+		defaultCondition = &s.DefaultCondition
+		for i, condition := range s.EventConditions {
+			if c, ok := condition.(*model.TransitionEventCondition); ok && c.Transition.NextState == from {
+				if to == "" {
+					s.EventConditions[i] = &model.EndEventCondition{BaseEventCondition: c.BaseEventCondition}
+				} else {
+					c.Transition.NextState = to
+				}
+			}
+		}
+	}
+	if defaultCondition != nil && defaultCondition.Transition.NextState == from {
+		if to == "" {
+			*defaultCondition = model.DefaultCondition{}
+		} else {
+			defaultCondition.Transition.NextState = to
+		}
+	}
+}
+
+// prune removes the definitions no longer referenced by the states
+func prune(w *model.Workflow) {
+	functions, events, retries, errors, auths := map[string]bool{}, map[string]bool{}, map[string]bool{}, map[string]bool{}, map[string]bool{}
+	for _, state := range w.States {
+		for _, action := range model.GetActions(state) {
+			functions[action.FunctionRef.RefName] = true
+			retries[action.RetryRef] = true
+			if action.EventRef != nil {
+				events[action.EventRef.TriggerEventRef] = true
+				events[action.EventRef.ResultEventRef] = true
+			}
+		}
+		for _, onError := range state.GetOnErrors() {
+			errors[onError.ErrorRef] = true
+			for _, ref := range onError.ErrorRefs {
+				errors[ref] = true
+			}
+		}
+		if end := state.GetEnd(); end != nil {
+			for _, produced := range end.ProduceEvents {
+				events[produced.EventRef] = true
+			}
+		}
+		switch s := state.(type) {
+		case *model.EventState:
+			for _, onEvents := range s.OnEvents {
+				for _, ref := range onEvents.EventRefs {
+					events[ref] = true
+				}
+			}
+		case *model.CallbackState:
+			events[s.EventRef] = true
+		case *model.EventBasedSwitchState:
+			for _, condition := range s.EventConditions {
+				events[condition.GetEventRef()] = true
+			}
+		}
+	}
+	kept := w.Functions[:0]
+	for _, function := range w.Functions {
+		if functions[function.Name] {
+			kept = append(kept, function)
+			auths[function.AuthRef] = true
+		}
+	}
+	w.Functions = kept
+	keptEvents := w.Events[:0]
+	for _, event := range w.Events {
+		if events[event.Name] {
+			keptEvents = append(keptEvents, event)
+		}
+	}
+	w.Events = keptEvents
+	keptRetries := w.Retries[:0]
+	for _, retry := range w.Retries {
+		if retries[retry.Name] {
+			keptRetries = append(keptRetries, retry)
+		}
+	}
+	w.Retries = keptRetries
+	keptErrors := w.Errors[:0]
+	for _, e := range w.Errors {
+		if errors[e.Name] {
+			keptErrors = append(keptErrors, e)
+		}
+	}
+	w.Errors = keptErrors
+	keptAuths := w.Auth.Defs[:0]
+	for _, auth := range w.Auth.Defs {
+		if auths[auth.Name] {
+			keptAuths = append(keptAuths, auth)
+		}
+	}
+	w.Auth.Defs = keptAuths
+	if len(w.Auth.Defs) == 0 {
+		w.Auth.Defs, w.Secrets = nil, nil
+	}
+	if len(w.Functions) == 0 {
+		w.Functions = nil
+	}
+	if len(w.Events) == 0 {
+		w.Events = nil
+	}
+	if len(w.Retries) == 0 {
+		w.Retries = nil
+	}
+	if len(w.Errors) == 0 {
+		w.Errors = nil
+	}
+	w.Reindex()
+}
@@ -1,11 +1,19 @@
 module github.com/serverlessworkflow/sdk-go/v2
 
-go 1.15
+go 1.16
 
 require (
+	github.com/cloudevents/sdk-go/v2 v2.8.0
+	github.com/google/uuid v1.1.2
+	github.com/itchyny/gojq v0.12.7
+	github.com/jhump/protoreflect v1.10.3
 	github.com/stretchr/testify v1.6.1
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.27.1
 	gopkg.in/go-playground/assert.v1 v1.2.1 // indirect
 	gopkg.in/go-playground/validator.v8 v8.18.2
+	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
 	k8s.io/apimachinery v0.21.0
 	sigs.k8s.io/yaml v1.2.0
 )
@@ -0,0 +1,445 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command deepcopy generates the DeepCopyInto and DeepCopy functions of the model and kubernetes types, plus the
+// DeepCopyObject functions of the Kubernetes resources. Unlike deepcopy-gen it supports the interface fields of the
+// model, e.g. the workflow states, by copying every implementation of the package.
+//
+// It is run by 'go generate' from the package directory and writes zz_generated.deepcopy.go:
+//
+//	go run ../hack/deepcopy model
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/kubernetes"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const output = "zz_generated.deepcopy.go"
+
+const header = `// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by hack/deepcopy. DO NOT EDIT.
+
+`
+
+// valueCopyFunc hand written function copying the free-form JSON values, see model/deepcopy.go
+const valueCopyFunc = "deepCopyValue"
+
+// seeds types the functions are generated for, along with the types they reference. Implementations of the
+// interfaces must be listed since they can't be reached from the fields.
+var seeds = map[string][]interface{}{
+	"model": {
+		model.Workflow{},
+		model.BaseState{},
+		model.DelayState{},
+		model.EventState{},
+		model.OperationState{},
+		model.ParallelState{},
+		model.InjectState{},
+		model.ForEachState{},
+		model.CallbackState{},
+		model.SleepState{},
+		model.EventBasedSwitchState{},
+		model.DataBasedSwitchState{},
+		model.BaseEventCondition{},
+		model.TransitionEventCondition{},
+		model.EndEventCondition{},
+		model.BaseDataCondition{},
+		model.TransitionDataCondition{},
+		model.EndDataCondition{},
+		model.BaseAuthProperties{},
+		model.BasicAuthProperties{},
+		model.BearerAuthProperties{},
+		model.OAuth2AuthProperties{},
+	},
+	"kubernetes": {
+		kubernetes.SonataFlow{},
+		kubernetes.ConfigMap{},
+	},
+}
+
+var (
+	typeMetaType   = reflect.TypeOf(metav1.TypeMeta{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("deepcopy: ")
+	if len(os.Args) != 2 || seeds[os.Args[1]] == nil {
+		log.Fatalf("usage: deepcopy model|kubernetes")
+	}
+	g := newGenerator(os.Args[1])
+	for _, seed := range seeds[os.Args[1]] {
+		g.collect(reflect.TypeOf(seed))
+	}
+	src, err := g.generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type generator struct {
+	pkgPath    string
+	pkgName    string
+	types      map[string]reflect.Type
+	interfaces map[string]reflect.Type
+	imports    map[string]bool
+	deep       map[reflect.Type]bool
+	buf        bytes.Buffer
+}
+
+func newGenerator(pkgName string) *generator {
+	pkgPath := reflect.TypeOf(seeds[pkgName][0]).PkgPath()
+	return &generator{
+		pkgPath:    pkgPath,
+		pkgName:    pkgName,
+		types:      map[string]reflect.Type{},
+		interfaces: map[string]reflect.Type{},
+		imports:    map[string]bool{},
+		deep:       map[reflect.Type]bool{},
+	}
+}
+
+// local whether the named type is declared in the generated package
+func (g *generator) local(t reflect.Type) bool {
+	return t.Name() != "" && t.PkgPath() == g.pkgPath
+}
+
+// collect adds the types of the package reachable from t
+func (g *generator) collect(t reflect.Type) {
+	if g.local(t) {
+		if t.Kind() == reflect.Interface {
+			g.interfaces[t.Name()] = t
+			return
+		}
+		if _, ok := g.types[t.Name()]; ok {
+			return
+		}
+		g.types[t.Name()] = t
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		g.collect(t.Elem())
+	case reflect.Map:
+		g.collect(t.Key())
+		g.collect(t.Elem())
+	case reflect.Struct:
+		if !g.local(t) {
+			return
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if !unexported(t.Field(i)) {
+				g.collect(t.Field(i).Type)
+			}
+		}
+	}
+}
+
+// unexported whether the field is unexported, such fields holding state derived from the exported ones, e.g. indexes,
+// which the copies rebuild from a zero value of their own
+func unexported(f reflect.StructField) bool {
+	return len(f.PkgPath) > 0 && !f.Anonymous
+}
+
+// needsDeepCopy whether the values of the type hold references
+func (g *generator) needsDeepCopy(t reflect.Type) bool {
+	if deep, ok := g.deep[t]; ok {
+		return deep
+	}
+	deep := false
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		deep = true
+	case reflect.Array:
+		deep = g.needsDeepCopy(t.Elem())
+	case reflect.Struct:
+		g.deep[t] = false
+		for i := 0; i < t.NumField() && !deep; i++ {
+			deep = g.needsDeepCopy(t.Field(i).Type)
+		}
+	}
+	g.deep[t] = deep
+	return deep
+}
+
+// hasDeepCopyInto whether the struct type has, or will have, a DeepCopyInto function
+func (g *generator) hasDeepCopyInto(t reflect.Type) bool {
+	if g.local(t) {
+		return true
+	}
+	_, ok := reflect.PtrTo(t).MethodByName("DeepCopyInto")
+	return ok
+}
+
+// typeName Go expression of the type, registering the imports it needs
+func (g *generator) typeName(t reflect.Type) string {
+	if t == rawMessageType {
+		// json.RawMessage is an alias in recent Go versions, reflect reports the aliased type
+		g.imports["encoding/json"] = true
+		return "json.RawMessage"
+	}
+	if t.Name() != "" {
+		if t.PkgPath() == "" || t.PkgPath() == g.pkgPath {
+			return t.Name()
+		}
+		g.imports[t.PkgPath()] = true
+		return t.String()
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + g.typeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + g.typeName(t.Elem())
+	case reflect.Map:
+		return "map[" + g.typeName(t.Key()) + "]" + g.typeName(t.Elem())
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "interface{}"
+		}
+	}
+	panic(fmt.Sprintf("unsupported type %s", t))
+}
+
+// interfaceCopyFunc name of the function copying the values of the interface type
+func (g *generator) interfaceCopyFunc(t reflect.Type) string {
+	if t.Name() == "" {
+		return valueCopyFunc
+	}
+	return "deepCopy" + t.Name()
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) generate() ([]byte, error) {
+	var names []string
+	for name := range g.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		g.generateType(g.types[name])
+	}
+	names = names[:0]
+	for name := range g.interfaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		g.generateInterface(g.interfaces[name])
+	}
+
+	var out bytes.Buffer
+	out.WriteString(header)
+	fmt.Fprintf(&out, "package %s\n\n", g.pkgName)
+	if len(g.imports) > 0 {
+		var imports []string
+		for path := range g.imports {
+			imports = append(imports, path)
+		}
+		sort.Strings(imports)
+		out.WriteString("import (\n")
+		for i, path := range imports {
+			// standard library first, separated from the other imports
+			if i > 0 && !strings.Contains(imports[i-1], ".") && strings.Contains(path, ".") {
+				out.WriteString("\n")
+			}
+			fmt.Fprintf(&out, "%q\n", path)
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(g.buf.Bytes())
+	return format.Source(out.Bytes())
+}
+
+func (g *generator) generateType(t reflect.Type) {
+	name := t.Name()
+	g.printf("// DeepCopyInto copies the receiver into out, in must be non-nil.\n")
+	switch t.Kind() {
+	case reflect.Struct:
+		g.printf("func (in *%s) DeepCopyInto(out *%s) {\n", name, name)
+		g.printf("*out = *in\n")
+		for i := 0; i < t.NumField(); i++ {
+			g.generateField(t.Field(i))
+		}
+		g.printf("}\n\n")
+		g.printf("// DeepCopy copies the receiver, creating a new %s.\n", name)
+		g.printf("func (in *%s) DeepCopy() *%s {\n", name, name)
+		g.printf("if in == nil {\nreturn nil\n}\n")
+		g.printf("out := new(%s)\nin.DeepCopyInto(out)\nreturn out\n}\n\n", name)
+		if f, ok := t.FieldByName(typeMetaType.Name()); ok && f.Anonymous && f.Type == typeMetaType {
+			g.imports["k8s.io/apimachinery/pkg/runtime"] = true
+			g.printf("// DeepCopyObject copies the receiver, creating a new runtime.Object.\n")
+			g.printf("func (in *%s) DeepCopyObject() runtime.Object {\n", name)
+			g.printf("if c := in.DeepCopy(); c != nil {\nreturn c\n}\nreturn nil\n}\n\n")
+		}
+	case reflect.Map, reflect.Slice:
+		g.printf("func (in %s) DeepCopyInto(out *%s) {\n{\nin := &in\n", name, name)
+		g.generatePointers(t)
+		g.printf("}\n}\n\n")
+		g.printf("// DeepCopy copies the receiver, creating a new %s.\n", name)
+		g.printf("func (in %s) DeepCopy() %s {\n", name, name)
+		g.printf("if in == nil {\nreturn nil\n}\n")
+		g.printf("out := new(%s)\nin.DeepCopyInto(out)\nreturn *out\n}\n\n", name)
+	default:
+		g.printf("func (in *%s) DeepCopyInto(out *%s) {\n*out = *in\n}\n\n", name, name)
+		g.printf("// DeepCopy copies the receiver, creating a new %s.\n", name)
+		g.printf("func (in *%s) DeepCopy() *%s {\n", name, name)
+		g.printf("if in == nil {\nreturn nil\n}\n")
+		g.printf("out := new(%s)\nin.DeepCopyInto(out)\nreturn out\n}\n\n", name)
+	}
+}
+
+func (g *generator) generateField(f reflect.StructField) {
+	t := f.Type
+	if unexported(f) {
+		switch t.Kind() {
+		case reflect.Ptr:
+			g.printf("if in.%s != nil {\nout.%s = new(%s)\n}\n", f.Name, f.Name, g.typeName(t.Elem()))
+		case reflect.Slice, reflect.Map, reflect.Interface:
+			g.printf("out.%s = nil\n", f.Name)
+		default:
+			panic(fmt.Sprintf("unsupported unexported field %s of type %s", f.Name, t))
+		}
+		return
+	}
+	if !g.needsDeepCopy(t) {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		g.requireDeepCopyInto(t)
+		g.printf("in.%s.DeepCopyInto(&out.%s)\n", f.Name, f.Name)
+	case reflect.Interface:
+		g.printf("if in.%s != nil {\nout.%s = %s(in.%s)\n}\n", f.Name, f.Name, g.interfaceCopyFunc(t), f.Name)
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		g.printf("if in.%s != nil {\nin, out := &in.%s, &out.%s\n", f.Name, f.Name, f.Name)
+		g.generatePointers(t)
+		g.printf("}\n")
+	default:
+		panic(fmt.Sprintf("unsupported field %s of type %s", f.Name, t))
+	}
+}
+
+// generatePointers copies *in into *out, in and out being non-nil pointers of the type and *in non-nil
+func (g *generator) generatePointers(t reflect.Type) {
+	elem := t.Elem()
+	switch t.Kind() {
+	case reflect.Ptr:
+		g.printf("*out = new(%s)\n", g.typeName(elem))
+		if g.needsDeepCopy(elem) && elem.Kind() == reflect.Struct {
+			g.requireDeepCopyInto(elem)
+			g.printf("(*in).DeepCopyInto(*out)\n")
+		} else if !g.needsDeepCopy(elem) {
+			g.printf("**out = **in\n")
+		} else if elem.Kind() == reflect.Slice || elem.Kind() == reflect.Map || elem.Kind() == reflect.Ptr {
+			g.printf("if **in != nil {\nin, out := *in, *out\n")
+			g.generatePointers(elem)
+			g.printf("}\n")
+		} else {
+			panic(fmt.Sprintf("unsupported type %s", t))
+		}
+	case reflect.Slice:
+		g.printf("*out = make(%s, len(*in))\n", g.typeName(t))
+		switch {
+		case !g.needsDeepCopy(elem):
+			g.printf("copy(*out, *in)\n")
+		case elem.Kind() == reflect.Struct:
+			g.requireDeepCopyInto(elem)
+			g.printf("for i := range *in {\n(*in)[i].DeepCopyInto(&(*out)[i])\n}\n")
+		case elem.Kind() == reflect.Interface:
+			g.printf("for i := range *in {\nif (*in)[i] != nil {\n(*out)[i] = %s((*in)[i])\n}\n}\n",
+				g.interfaceCopyFunc(elem))
+		default:
+			panic(fmt.Sprintf("unsupported type %s", t))
+		}
+	case reflect.Map:
+		g.printf("*out = make(%s, len(*in))\n", g.typeName(t))
+		g.printf("for key, val := range *in {\n")
+		switch {
+		case !g.needsDeepCopy(elem):
+			g.printf("(*out)[key] = val\n")
+		case elem.Kind() == reflect.Struct:
+			g.requireDeepCopyInto(elem)
+			g.printf("(*out)[key] = *val.DeepCopy()\n")
+		case elem.Kind() == reflect.Interface:
+			g.printf("if val == nil {\n(*out)[key] = nil\n} else {\n(*out)[key] = %s(val)\n}\n",
+				g.interfaceCopyFunc(elem))
+		case elem.Kind() == reflect.Slice || elem.Kind() == reflect.Map || elem.Kind() == reflect.Ptr:
+			g.printf("var outVal %s\n", g.typeName(elem))
+			g.printf("if val == nil {\n(*out)[key] = nil\n} else {\nin, out := &val, &outVal\n")
+			g.generatePointers(elem)
+			g.printf("}\n(*out)[key] = outVal\n")
+		default:
+			panic(fmt.Sprintf("unsupported type %s", t))
+		}
+		g.printf("}\n")
+	}
+}
+
+func (g *generator) requireDeepCopyInto(t reflect.Type) {
+	if !g.hasDeepCopyInto(t) {
+		panic(fmt.Sprintf("type %s holds references but has no DeepCopyInto function", t))
+	}
+}
+
+// generateInterface generates the function copying the values of the interface, implementations not declared in the
+// package are shared since there is no way to copy them
+func (g *generator) generateInterface(t reflect.Type) {
+	var impls []string
+	for name, impl := range g.types {
+		if impl.Kind() == reflect.Struct && reflect.PtrTo(impl).Implements(t) {
+			impls = append(impls, name)
+		}
+	}
+	sort.Strings(impls)
+	name := g.interfaceCopyFunc(t)
+	g.printf("// %s copies the %s implementations of the package, other implementations are shared.\n", name, t.Name())
+	g.printf("func %s(in %s) %s {\n", name, t.Name(), t.Name())
+	g.printf("switch v := in.(type) {\n")
+	for _, impl := range impls {
+		g.printf("case *%s:\nreturn v.DeepCopy()\n", impl)
+	}
+	g.printf("}\nreturn in\n}\n\n")
+}
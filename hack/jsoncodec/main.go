@@ -0,0 +1,590 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command jsoncodec generates the MarshalJSON and UnmarshalJSON methods of the model types, encoding and decoding
+// their properties without the reflection of encoding/json. The generated methods behave like encoding/json: the
+// values they don't handle directly, e.g. the maps and free-form values, are left to encoding/json, and so are the
+// documents their decoder fails on, e.g. invalid ones, so that the errors are those of encoding/json.
+//
+// The types with hand written methods keep them, and so do the types embedding them since the methods are promoted.
+// The types embedded in other types get no exported methods, which would be promoted to the embedding types, but
+// unexported ones encoding and decoding them as fields of other types.
+//
+// It is run by 'go generate' from the package directory and writes zz_generated.json.go:
+//
+//	go run ../hack/jsoncodec
+package main
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+const output = "zz_generated.json.go"
+
+const header = `// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by hack/jsoncodec. DO NOT EDIT.
+
+`
+
+const jsoncodecPkg = "github.com/serverlessworkflow/sdk-go/v2/internal/jsoncodec"
+
+// seeds types the methods are generated for, along with the types they reference. Implementations of the interfaces
+// must be listed since they can't be reached from the fields.
+var seeds = []interface{}{
+	model.Workflow{},
+	model.BaseState{},
+	model.DelayState{},
+	model.EventState{},
+	model.OperationState{},
+	model.ParallelState{},
+	model.InjectState{},
+	model.ForEachState{},
+	model.CallbackState{},
+	model.SleepState{},
+	model.EventBasedSwitchState{},
+	model.DataBasedSwitchState{},
+	model.BaseEventCondition{},
+	model.TransitionEventCondition{},
+	model.EndEventCondition{},
+	model.BaseDataCondition{},
+	model.TransitionDataCondition{},
+	model.EndDataCondition{},
+	model.BaseAuthProperties{},
+	model.BasicAuthProperties{},
+	model.BearerAuthProperties{},
+	model.OAuth2AuthProperties{},
+}
+
+var (
+	marshalerType       = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	unmarshalerType     = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("jsoncodec: ")
+	if len(os.Args) != 1 {
+		log.Fatalf("usage: jsoncodec")
+	}
+	g := &generator{
+		pkgPath:       reflect.TypeOf(seeds[0]).PkgPath(),
+		types:         map[string]reflect.Type{},
+		embedded:      map[string]bool{},
+		handMarshal:   map[string]bool{},
+		handUnmarshal: map[string]bool{},
+		imports:       map[string]bool{},
+	}
+	if err := g.parseMethods("."); err != nil {
+		log.Fatal(err)
+	}
+	for _, seed := range seeds {
+		g.collect(reflect.TypeOf(seed))
+	}
+	src, err := g.generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type generator struct {
+	pkgPath string
+	types   map[string]reflect.Type
+	// embedded types embedded in other types
+	embedded map[string]bool
+	// handMarshal and handUnmarshal types with hand written MarshalJSON and UnmarshalJSON methods
+	handMarshal   map[string]bool
+	handUnmarshal map[string]bool
+	imports       map[string]bool
+	// dynamic whether appendJSONValue is used
+	dynamic bool
+	buf     bytes.Buffer
+}
+
+// parseMethods finds the hand written MarshalJSON and UnmarshalJSON methods of the package in dir. Reflection can't
+// tell them from the generated ones.
+func (g *generator) parseMethods(dir string) error {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, func(info os.FileInfo) bool {
+		return !strings.HasPrefix(info.Name(), "zz_generated") && !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return err
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+					continue
+				}
+				recv := fn.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				ident, ok := recv.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				switch fn.Name.Name {
+				case "MarshalJSON", "MarshalText":
+					g.handMarshal[ident.Name] = true
+				case "UnmarshalJSON", "UnmarshalText":
+					g.handUnmarshal[ident.Name] = true
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// local whether the named type is declared in the generated package
+func (g *generator) local(t reflect.Type) bool {
+	return t.Name() != "" && t.PkgPath() == g.pkgPath
+}
+
+// collect adds the exported struct types of the package reachable from t
+func (g *generator) collect(t reflect.Type) {
+	if g.local(t) && t.Kind() == reflect.Struct && ast.IsExported(t.Name()) {
+		if _, ok := g.types[t.Name()]; ok {
+			return
+		}
+		g.types[t.Name()] = t
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		g.collect(t.Elem())
+	case reflect.Map:
+		g.collect(t.Elem())
+	case reflect.Struct:
+		if !g.local(t) {
+			return
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Anonymous {
+				if f.Type.Kind() != reflect.Struct {
+					panic(fmt.Sprintf("unsupported embedded field %s of %s", f.Name, t))
+				}
+				g.embedded[f.Type.Name()] = true
+			}
+			if len(f.PkgPath) == 0 || f.Anonymous {
+				g.collect(f.Type)
+			}
+		}
+	}
+}
+
+// promoted whether the struct type has one of the methods of the set through its embedded types
+func promoted(t reflect.Type, methods map[string]bool) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.Anonymous && (methods[f.Type.Name()] || promoted(f.Type, methods)) {
+			return true
+		}
+	}
+	return false
+}
+
+// encoded whether the appendJSON method of the type is generated
+func (g *generator) encoded(t reflect.Type) bool {
+	_, ok := g.types[t.Name()]
+	return ok && g.local(t) && !g.handMarshal[t.Name()] && !promoted(t, g.handMarshal)
+}
+
+// decoded whether the decodeJSON method of the type is generated
+func (g *generator) decoded(t reflect.Type) bool {
+	_, ok := g.types[t.Name()]
+	return ok && g.local(t) && !g.handUnmarshal[t.Name()] && !promoted(t, g.handUnmarshal)
+}
+
+// field property of a struct type, as encoding/json sees it
+type field struct {
+	name string
+	// expr Go selector of the field from the receiver, e.g. BaseState.Name
+	expr      string
+	typ       reflect.Type
+	omitEmpty bool
+	depth     int
+	tagged    bool
+}
+
+// fields returns the properties of the struct type in the order of encoding/json, the fields of the embedded structs
+// included unless hidden by shallower fields of the same name
+func fields(t reflect.Type) []field {
+	var all []field
+	var walk func(t reflect.Type, expr string, depth int)
+	walk = func(t reflect.Type, expr string, depth int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if len(f.PkgPath) > 0 && !f.Anonymous {
+				continue
+			}
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			opts := strings.Split(tag, ",")
+			name := opts[0]
+			if f.Anonymous && len(name) == 0 {
+				walk(f.Type, expr+f.Name+".", depth+1)
+				continue
+			}
+			omitEmpty := false
+			for _, opt := range opts[1:] {
+				switch opt {
+				case "omitempty":
+					omitEmpty = true
+				default:
+					panic(fmt.Sprintf("unsupported option %s of the field %s of %s", opt, f.Name, t))
+				}
+			}
+			tagged := len(name) > 0
+			if !tagged {
+				name = f.Name
+			}
+			if quoted, _ := json.Marshal(name); string(quoted) != `"`+name+`"` {
+				panic(fmt.Sprintf("unsupported property name %s of %s", name, t))
+			}
+			all = append(all, field{name: name, expr: expr + f.Name, typ: f.Type, omitEmpty: omitEmpty, depth: depth, tagged: tagged})
+		}
+	}
+	walk(t, "", 0)
+
+	// the dominant field of each name: the shallowest, tagged if several are, none if still ambiguous
+	var result []field
+	for _, f := range all {
+		dominant := true
+		for _, other := range all {
+			if other.name != f.name || other.expr == f.expr {
+				continue
+			}
+			if other.depth < f.depth || other.depth == f.depth && (other.tagged || !f.tagged) {
+				dominant = false
+			}
+		}
+		if dominant {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) generate() ([]byte, error) {
+	var names []string
+	for name := range g.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t := g.types[name]
+		if g.encoded(t) {
+			g.generateMarshal(t)
+		}
+		if g.decoded(t) {
+			g.generateUnmarshal(t)
+		}
+	}
+	if g.dynamic {
+		g.generateDynamic(names)
+	}
+
+	var out bytes.Buffer
+	out.WriteString(header)
+	fmt.Fprintf(&out, "package %s\n\n", g.pkgPath[strings.LastIndex(g.pkgPath, "/")+1:])
+	var imports []string
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	out.WriteString("import (\n")
+	for i, path := range imports {
+		// standard library first, separated from the other imports
+		if i > 0 && !strings.Contains(imports[i-1], ".") && strings.Contains(path, ".") {
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(&out, "%q\n", path)
+	}
+	out.WriteString(")\n\n")
+	out.Write(g.buf.Bytes())
+	return format.Source(out.Bytes())
+}
+
+func (g *generator) generateMarshal(t reflect.Type) {
+	name := t.Name()
+	g.imports[jsoncodecPkg] = true
+	if !g.embedded[name] {
+		g.printf("// MarshalJSON implements json.Marshaler\n")
+		g.printf("func (t %s) MarshalJSON() ([]byte, error) {\nreturn t.appendJSON(make([]byte, 0, 128))\n}\n\n", name)
+	}
+	var body bytes.Buffer
+	usesErr := false
+	for _, f := range fields(t) {
+		value := "t." + f.expr
+		if cond := emptyCheck(f, value); f.omitEmpty && len(cond) > 0 {
+			fmt.Fprintf(&body, "if %s {\n", cond)
+		}
+		fmt.Fprintf(&body, "b = append(b, `\"%s\":`...)\n", f.name)
+		usesErr = g.appendValue(&body, f.typ, value) || usesErr
+		body.WriteString("b = append(b, ',')\n")
+		if f.omitEmpty && len(emptyCheck(f, value)) > 0 {
+			body.WriteString("}\n")
+		}
+	}
+	g.printf("// appendJSON appends the JSON of the %s like encoding/json\n", name)
+	g.printf("func (t *%s) appendJSON(b []byte) ([]byte, error) {\n", name)
+	g.printf("if t == nil {\nreturn append(b, \"null\"...), nil\n}\n")
+	if usesErr {
+		g.printf("var err error\n")
+	}
+	g.printf("b = append(b, '{')\n")
+	g.buf.Write(body.Bytes())
+	g.printf("// the comma after the last property, if any, closes the object\n")
+	g.printf("if b[len(b)-1] == ',' {\nb[len(b)-1] = '}'\nreturn b, nil\n}\nreturn append(b, '}'), nil\n}\n\n")
+}
+
+// generateDynamic generates appendJSONValue, appending the JSON of the values of the interfaces, e.g. the states,
+// with the generated methods of their types rather than with encoding/json, which would compact their JSON again
+func (g *generator) generateDynamic(names []string) {
+	g.printf("// appendJSONValue appends the JSON of the value of an interface, encoded by its generated method if it has one\n")
+	g.printf("func appendJSONValue(b []byte, v interface{}) ([]byte, error) {\nswitch v := v.(type) {\n")
+	for _, name := range names {
+		if t := g.types[name]; g.encoded(t) && !g.embedded[name] {
+			g.printf("case *%s:\nreturn v.appendJSON(b)\ncase %s:\nreturn v.appendJSON(b)\n", name, name)
+		}
+	}
+	g.printf("}\nreturn jsoncodec.AppendValue(b, v)\n}\n")
+}
+
+// emptyCheck returns the condition of the non-empty values of the field, empty if the values are never empty
+func emptyCheck(f field, value string) string {
+	switch f.typ.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return fmt.Sprintf("len(%s) != 0", value)
+	case reflect.Bool:
+		return value
+	case reflect.Ptr, reflect.Interface:
+		return value + " != nil"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
+		reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return value + " != 0"
+	}
+	return ""
+}
+
+// marshaler whether encoding/json marshals the values of the type with their own methods
+func marshaler(t reflect.Type) bool {
+	return t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType) ||
+		t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType)
+}
+
+// direct whether appendValue encodes the values of the type without encoding/json
+func (g *generator) direct(t reflect.Type) bool {
+	switch {
+	case g.encoded(t), t.Kind() == reflect.Ptr && g.encoded(t.Elem()), t.Kind() == reflect.Interface && g.local(t):
+		return true
+	case marshaler(t):
+		return false
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// appendValue writes the code appending the JSON of the value of the type, returns whether the code sets err
+func (g *generator) appendValue(w *bytes.Buffer, t reflect.Type, value string) bool {
+	switch {
+	case g.encoded(t) || t.Kind() == reflect.Ptr && g.encoded(t.Elem()):
+		fmt.Fprintf(w, "if b, err = %s.appendJSON(b); err != nil {\nreturn nil, err\n}\n", value)
+		return true
+	case marshaler(t):
+	case t.Kind() == reflect.String:
+		if t.Name() != "string" {
+			value = "string(" + value + ")"
+		}
+		fmt.Fprintf(w, "b = jsoncodec.AppendString(b, %s)\n", value)
+		return false
+	case t.Kind() == reflect.Bool:
+		fmt.Fprintf(w, "b = jsoncodec.AppendBool(b, bool(%s))\n", value)
+		return false
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		g.imports["strconv"] = true
+		fmt.Fprintf(w, "b = strconv.AppendInt(b, int64(%s), 10)\n", value)
+		return false
+	case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64:
+		g.imports["strconv"] = true
+		fmt.Fprintf(w, "b = strconv.AppendUint(b, uint64(%s), 10)\n", value)
+		return false
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		fmt.Fprintf(w, "if b, err = jsoncodec.AppendFloat(b, float64(%s), %d); err != nil {\nreturn nil, err\n}\n", value, t.Bits())
+		return true
+	case t.Kind() == reflect.Interface && g.local(t):
+		g.dynamic = true
+		fmt.Fprintf(w, "if b, err = appendJSONValue(b, %s); err != nil {\nreturn nil, err\n}\n", value)
+		return true
+	case t.Kind() == reflect.Slice && g.direct(t.Elem()):
+		fmt.Fprintf(w, "if %s == nil {\nb = append(b, \"null\"...)\n} else {\nb = append(b, '[')\n", value)
+		fmt.Fprintf(w, "for i := range %s {\nif i > 0 {\nb = append(b, ',')\n}\n", value)
+		usesErr := g.appendValue(w, t.Elem(), value+"[i]")
+		w.WriteString("}\nb = append(b, ']')\n}\n")
+		return usesErr
+	}
+	fmt.Fprintf(w, "if b, err = jsoncodec.AppendValue(b, %s); err != nil {\nreturn nil, err\n}\n", value)
+	return true
+}
+
+func (g *generator) generateUnmarshal(t reflect.Type) {
+	name := t.Name()
+	g.imports[jsoncodecPkg] = true
+	if !g.embedded[name] {
+		g.imports["encoding/json"] = true
+		g.printf("// UnmarshalJSON implements json.Unmarshaler\n")
+		g.printf("func (t *%s) UnmarshalJSON(data []byte) error {\n", name)
+		g.printf("saved := *t\nd := jsoncodec.NewDecoder(data)\nt.decodeJSON(&d)\nif d.End() {\nreturn nil\n}\n")
+		g.printf("// the errors, and the keys matching the properties regardless of their case, are left to encoding/json\n")
+		g.printf("*t = saved\ntype plain %s\nreturn json.Unmarshal(data, (*plain)(t))\n}\n\n", name)
+	}
+	var keys []string
+	g.printf("// decodeJSON decodes the %s like encoding/json, the decoder failing on what it doesn't handle\n", name)
+	g.printf("func (t *%s) decodeJSON(d *jsoncodec.Decoder) {\n", name)
+	g.printf("if d.Null() {\nreturn\n}\n")
+	g.printf("for more := d.Object(); more; more = d.Next() {\nswitch string(d.Key()) {\n")
+	for _, f := range fields(t) {
+		keys = append(keys, fmt.Sprintf("%q", f.name))
+		g.printf("case %q:\n", f.name)
+		g.decodeValue(f.typ, "t."+f.expr)
+	}
+	g.printf("default:\nd.Unknown(jsonKeys%s)\n}\n}\n}\n\n", name)
+	g.printf("// jsonKeys%s keys of the properties of the %s\n", name, name)
+	g.printf("var jsonKeys%s = []string{%s}\n\n", name, strings.Join(keys, ", "))
+}
+
+// unmarshaler whether encoding/json decodes the values of the type with their own methods
+func unmarshaler(t reflect.Type) bool {
+	return reflect.PtrTo(t).Implements(unmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType)
+}
+
+// decodeValue writes the code decoding the next value into the addressable value of the type
+func (g *generator) decodeValue(t reflect.Type, value string) {
+	switch {
+	case g.decoded(t):
+		g.printf("%s.decodeJSON(d)\n", value)
+	case t.Kind() == reflect.Ptr && (g.decoded(t.Elem()) || reflect.PtrTo(t.Elem()).Implements(unmarshalerType)):
+		g.printf("if d.Null() {\n%s = nil\n} else {\nif %s == nil {\n%s = new(%s)\n}\n", value, value, value, g.typeName(t.Elem()))
+		if g.decoded(t.Elem()) {
+			g.printf("%s.decodeJSON(d)\n}\n", value)
+		} else {
+			g.printf("d.Unmarshaler(%s)\n}\n", value)
+		}
+	case reflect.PtrTo(t).Implements(unmarshalerType):
+		g.printf("d.Unmarshaler(&%s)\n", value)
+	case unmarshaler(t):
+		g.printf("d.Value(&%s)\n", value)
+	case t.Kind() == reflect.String:
+		if t.Name() != "string" {
+			g.printf("d.String((*string)(&%s))\n", value)
+		} else {
+			g.printf("d.String(&%s)\n", value)
+		}
+	case t.Kind() == reflect.Bool:
+		if t.Name() != "bool" {
+			g.printf("d.Bool((*bool)(&%s))\n", value)
+		} else {
+			g.printf("d.Bool(&%s)\n", value)
+		}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		g.printf("if v, ok := d.Int(%s); ok {\n%s = %s(v)\n}\n", g.bits(t), value, g.typeName(t))
+	case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64:
+		g.printf("if v, ok := d.Uint(%s); ok {\n%s = %s(v)\n}\n", g.bits(t), value, g.typeName(t))
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		g.printf("if v, ok := d.Float(%d); ok {\n%s = %s(v)\n}\n", t.Bits(), value, g.typeName(t))
+	case t.Kind() == reflect.Slice && t.Name() == "" &&
+		(g.decoded(t.Elem()) || t.Elem().Kind() == reflect.String && t.Elem().Name() == "string"):
+		// like encoding/json, the items already in the slice are decoded into
+		g.printf("if d.Null() {\n%s = nil\n} else {\ns := %s\ni := 0\n", value, value)
+		zero := g.typeName(t.Elem()) + "{}"
+		if t.Elem().Kind() == reflect.String {
+			zero = `""`
+		}
+		g.printf("for more := d.Array(); more; more = d.Item() {\nif i == len(s) {\ns = append(s, %s)\n}\n", zero)
+		g.decodeValue(t.Elem(), "s[i]")
+		g.printf("i++\n}\nif s == nil {\ns = %s{}\n}\n%s = s[:i]\n}\n", g.typeName(t), value)
+	default:
+		g.printf("d.Value(&%s)\n", value)
+	}
+}
+
+// bits Go expression of the bit size of the integer type
+func (g *generator) bits(t reflect.Type) string {
+	if t.Kind() == reflect.Int || t.Kind() == reflect.Uint {
+		g.imports["strconv"] = true
+		return "strconv.IntSize"
+	}
+	return fmt.Sprint(t.Bits())
+}
+
+// typeName Go expression of the type
+func (g *generator) typeName(t reflect.Type) string {
+	if t.Name() != "" {
+		if t.PkgPath() == "" || t.PkgPath() == g.pkgPath {
+			return t.Name()
+		}
+		g.imports[t.PkgPath()] = true
+		return t.String()
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + g.typeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + g.typeName(t.Elem())
+	}
+	panic(fmt.Sprintf("unsupported type %s", t))
+}
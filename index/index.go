@@ -0,0 +1,264 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/internal/pool"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+)
+
+// Entry indexed workflow file
+type Entry struct {
+	File string `json:"file"`
+	// ModTime and Size of the file when it was indexed, a file is parsed again only if they change
+	ModTime     time.Time `json:"modTime"`
+	Size        int64     `json:"size"`
+	ID          string    `json:"id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Version     string    `json:"version,omitempty"`
+	Description string    `json:"description,omitempty"`
+	// EventTypes CloudEvent types of the events the workflow consumes or produces
+	EventTypes []string `json:"eventTypes,omitempty"`
+	// Operations operations of the workflow functions, e.g. 'http://orders.json#store'
+	Operations []string `json:"operations,omitempty"`
+	// Labels workflow metadata, values formatted as strings
+	Labels map[string]string `json:"labels,omitempty"`
+	// Error why the file couldn't be parsed, the entry is only found by file
+	Error string `json:"error,omitempty"`
+}
+
+// Index searchable index of workflow files. Update it with the files to index, only the new and modified ones are
+// parsed. Index is safe for concurrent use.
+type Index struct {
+	mu      sync.RWMutex
+	entries map[string]*Entry
+}
+
+// New creates an empty index
+func New() *Index {
+	return &Index{entries: map[string]*Entry{}}
+}
+
+// Load reads an index written by Save
+func Load(r io.Reader) (*Index, error) {
+	var entries []*Entry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("invalid index: %w", err)
+	}
+	idx := New()
+	for _, entry := range entries {
+		idx.entries[entry.File] = entry
+	}
+	return idx, nil
+}
+
+// Save writes the index as JSON, sorted by file
+func (idx *Index) Save(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(idx.Entries())
+}
+
+// Entries returns all the entries, sorted by file
+func (idx *Index) Entries() []*Entry {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	entries := make([]*Entry, 0, len(idx.entries))
+	for _, entry := range idx.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].File < entries[j].File })
+	return entries
+}
+
+// Stats what an update changed
+type Stats struct {
+	// Parsed number of new or modified files parsed
+	Parsed int
+	// Unchanged number of files kept from the index
+	Unchanged int
+	// Removed number of files no longer in the index
+	Removed int
+}
+
+// Update indexes the files, parsing the new and modified ones in parallel, and drops the entries of the files not
+// listed anymore. Files that can't be parsed are indexed with their error.
+func (idx *Index) Update(files []string) (Stats, error) {
+	type job struct {
+		file string
+		info os.FileInfo
+	}
+	var stats Stats
+	var jobs []job
+	listed := make(map[string]bool, len(files))
+	idx.mu.RLock()
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			idx.mu.RUnlock()
+			return stats, err
+		}
+		listed[file] = true
+		if entry, ok := idx.entries[file]; ok && entry.ModTime.Equal(info.ModTime()) && entry.Size == info.Size() {
+			stats.Unchanged++
+			continue
+		}
+		jobs = append(jobs, job{file: file, info: info})
+	}
+	idx.mu.RUnlock()
+
+	entries := make([]*Entry, len(jobs))
+	pool.Run(len(jobs), 0, func(i int) {
+		entries[i] = newEntry(jobs[i].file, jobs[i].info)
+	})
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, entry := range entries {
+		idx.entries[entry.File] = entry
+	}
+	for file := range idx.entries {
+		if !listed[file] {
+			delete(idx.entries, file)
+			stats.Removed++
+		}
+	}
+	stats.Parsed = len(entries)
+	return stats, nil
+}
+
+func newEntry(file string, info os.FileInfo) *Entry {
+	entry := &Entry{File: file, ModTime: info.ModTime().UTC(), Size: info.Size()}
+	workflow, err := parser.FromFile(file)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+	entry.ID = workflow.ID
+	entry.Name = workflow.Name
+	entry.Version = workflow.Version
+	entry.Description = workflow.Description
+	entry.EventTypes = eventTypes(workflow)
+	entry.Operations = operations(workflow)
+	if len(workflow.Metadata) > 0 {
+		entry.Labels = make(map[string]string, len(workflow.Metadata))
+		for key, value := range workflow.Metadata {
+			entry.Labels[key] = fmt.Sprint(value)
+		}
+	}
+	return entry
+}
+
+func eventTypes(workflow *model.Workflow) []string {
+	var types []string
+	for _, event := range workflow.Events {
+		types = appendUnique(types, event.Type)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func operations(workflow *model.Workflow) []string {
+	var operations []string
+	for _, function := range workflow.Functions {
+		operations = appendUnique(operations, function.Operation)
+	}
+	sort.Strings(operations)
+	return operations
+}
+
+func appendUnique(values []string, value string) []string {
+	if len(value) == 0 {
+		return values
+	}
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// Query search criteria, the entries must match all the criteria set
+type Query struct {
+	ID        string
+	EventType string
+	// Operation matches the operations containing it, e.g. 'orders.json' matches 'http://orders.json#store'
+	Operation string
+	// Labels metadata the workflows must have, with the given values
+	Labels map[string]string
+	// Text matches the entries whose id, name or description contain it, ignoring the case
+	Text string
+}
+
+// Search returns the entries matching the query, sorted by file. Entries of files that couldn't be parsed never match.
+func (idx *Index) Search(query Query) []*Entry {
+	var found []*Entry
+	for _, entry := range idx.Entries() {
+		if len(entry.Error) == 0 && query.matches(entry) {
+			found = append(found, entry)
+		}
+	}
+	return found
+}
+
+func (q Query) matches(entry *Entry) bool {
+	if len(q.ID) > 0 && entry.ID != q.ID {
+		return false
+	}
+	if len(q.EventType) > 0 && !contains(entry.EventTypes, q.EventType) {
+		return false
+	}
+	if len(q.Operation) > 0 {
+		found := false
+		for _, operation := range entry.Operations {
+			found = found || strings.Contains(operation, q.Operation)
+		}
+		if !found {
+			return false
+		}
+	}
+	for key, value := range q.Labels {
+		if label, ok := entry.Labels[key]; !ok || label != value {
+			return false
+		}
+	}
+	if len(q.Text) > 0 {
+		text := strings.ToLower(q.Text)
+		if !strings.Contains(strings.ToLower(entry.ID+"\n"+entry.Name+"\n"+entry.Description), text) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
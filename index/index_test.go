@@ -0,0 +1,126 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderWorkflow = `id: order
+name: Order
+description: Handle new orders
+version: '1.0'
+specVersion: '0.7'
+metadata:
+  team: checkout
+  tier: 1
+start: Store
+events:
+  - name: OrderCreated
+    type: order.created
+functions:
+  - name: storeOrder
+    operation: http://orders.json#store
+states:
+  - name: Store
+    type: operation
+    actions:
+      - functionRef: storeOrder
+    end: true
+`
+
+const paymentWorkflow = `id: payment
+name: Payment
+version: '1.0'
+specVersion: '0.7'
+metadata:
+  team: payments
+start: Charge
+functions:
+  - name: charge
+    operation: http://payment.json#charge
+states:
+  - name: Charge
+    type: operation
+    actions:
+      - functionRef: charge
+    end: true
+`
+
+func ids(entries []*Entry) []string {
+	var ids []string
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	return ids
+}
+
+func TestIndex(t *testing.T) {
+	dir, err := ioutil.TempDir("", "index")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	order, payment, broken := filepath.Join(dir, "order.sw.yaml"), filepath.Join(dir, "payment.sw.yaml"), filepath.Join(dir, "broken.sw.yaml")
+	require.NoError(t, ioutil.WriteFile(order, []byte(orderWorkflow), 0600))
+	require.NoError(t, ioutil.WriteFile(payment, []byte(paymentWorkflow), 0600))
+	require.NoError(t, ioutil.WriteFile(broken, []byte("id: broken"), 0600))
+
+	idx := New()
+	stats, err := idx.Update([]string{order, payment, broken})
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Parsed: 3}, stats)
+
+	entries := idx.Entries()
+	require.Len(t, entries, 3)
+	assert.NotEmpty(t, entries[0].Error)
+	assert.Equal(t, []string{"order.created"}, entries[1].EventTypes)
+	assert.Equal(t, []string{"http://orders.json#store"}, entries[1].Operations)
+	assert.Equal(t, map[string]string{"team": "checkout", "tier": "1"}, entries[1].Labels)
+
+	assert.Equal(t, []string{"order", "payment"}, ids(idx.Search(Query{})))
+	assert.Equal(t, []string{"payment"}, ids(idx.Search(Query{ID: "payment"})))
+	assert.Equal(t, []string{"order"}, ids(idx.Search(Query{EventType: "order.created"})))
+	assert.Equal(t, []string{"payment"}, ids(idx.Search(Query{Operation: "payment.json"})))
+	assert.Equal(t, []string{"order"}, ids(idx.Search(Query{Labels: map[string]string{"team": "checkout"}})))
+	assert.Equal(t, []string{"order"}, ids(idx.Search(Query{Text: "NEW ORDERS"})))
+	assert.Empty(t, idx.Search(Query{ID: "order", Labels: map[string]string{"team": "payments"}}))
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, idx.Save(buf))
+	loaded, err := Load(buf)
+	require.NoError(t, err)
+	assert.Equal(t, idx.Entries(), loaded.Entries())
+
+	// only the modified files are parsed again, the removed ones are dropped
+	require.NoError(t, ioutil.WriteFile(payment, []byte(paymentWorkflow+"description: Charge customers\n"), 0600))
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(payment, future, future))
+	stats, err = loaded.Update([]string{order, payment})
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Parsed: 1, Unchanged: 1, Removed: 1}, stats)
+	assert.Equal(t, []string{"payment"}, ids(loaded.Search(Query{Text: "customers"})))
+
+	_, err = loaded.Update([]string{filepath.Join(dir, "missing.sw.yaml")})
+	assert.Error(t, err)
+	_, err = Load(bytes.NewBufferString("{"))
+	assert.Error(t, err)
+}
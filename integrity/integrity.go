@@ -0,0 +1,311 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/message"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// anyError error reference matching all the errors
+const anyError = "*"
+
+// Error integrity violation of a workflow definition, such as a reference to an undefined state
+type Error struct {
+	// Path JSON path of the violating property, e.g. 'states[0].transition.nextState'
+	Path string
+	// Message describes the violation, in English
+	Message string
+	// ID stable identifier of the violation, the message ID of the message package, e.g. message.UndefinedReference
+	ID string
+	// Args arguments of the message, see Localize
+	Args map[string]interface{}
+	// Suggestions defined names likely meant by a reference to an undefined name, the closest first
+	Suggestions []string
+	// Err cause of the violation if typed: a *model.ReferenceError for the references to undefined names,
+	// model.ErrDuplicateName for the duplicated names
+	Err error
+}
+
+// Error ...
+func (e *Error) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// Unwrap ...
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Localize returns the message rendered with the translator, e.g. a message.Catalog of the language of the workflow
+// authors, in English if it's not translated
+func (e *Error) Localize(t message.Translator) string {
+	if rendered, ok := (message.Message{ID: e.ID, Args: e.Args}).Render(t); ok {
+		return rendered
+	}
+	return e.Message
+}
+
+// Errors integrity violations of a workflow definition
+type Errors []*Error
+
+// Error ...
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Is tells whether one of the violations matches the target, see errors.Is
+func (e Errors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first violation matching the target, see errors.As
+func (e Errors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the references between the workflow definitions: transitions and the start state must reference
+// existing states, actions existing functions, events, retries and errors, and names must be unique.
+// The parser only validates each definition in isolation, run Validate to catch broken references.
+func Validate(workflow *model.Workflow) Errors {
+	v := &validation{
+		workflow:  workflow,
+		states:    map[string]bool{},
+		functions: map[string]bool{},
+		events:    map[string]model.EventKind{},
+		retries:   map[string]bool{},
+		errors:    map[string]bool{anyError: true},
+	}
+	v.index()
+	if workflow.Start != nil {
+		v.checkState("start.stateName", workflow.Start.StateName)
+	}
+	for i, state := range workflow.States {
+		v.validateState(fmt.Sprintf("states[%d]", i), state)
+	}
+	return v.errs
+}
+
+type validation struct {
+	workflow  *model.Workflow
+	states    map[string]bool
+	functions map[string]bool
+	events    map[string]model.EventKind
+	retries   map[string]bool
+	errors    map[string]bool
+	errs      Errors
+}
+
+// report reports the violation of the message ID at the path, its message rendered in English with the arguments
+func (v *validation) report(path, id string, args map[string]interface{}, err error) *Error {
+	violation := &Error{Path: path, Message: message.Message{ID: id, Args: args}.String(), ID: id, Args: args, Err: err}
+	v.errs = append(v.errs, violation)
+	return violation
+}
+
+func (v *validation) reportDuplicated(path, kind, name string) {
+	v.report(path, message.DuplicateName, map[string]interface{}{"kind": kind, "name": name}, model.ErrDuplicateName)
+}
+
+// index collects the defined names, reporting the duplicated ones
+func (v *validation) index() {
+	unique := func(names map[string]bool, path, kind, name string) {
+		if names[name] {
+			v.reportDuplicated(path, kind, name)
+		}
+		names[name] = true
+	}
+	for i, state := range v.workflow.States {
+		unique(v.states, fmt.Sprintf("states[%d].name", i), "state", state.GetName())
+	}
+	for i, function := range v.workflow.Functions {
+		unique(v.functions, fmt.Sprintf("functions[%d].name", i), "function", function.Name)
+	}
+	events := map[string]bool{}
+	for i, event := range v.workflow.Events {
+		unique(events, fmt.Sprintf("events[%d].name", i), "event", event.Name)
+		kind := event.Kind
+		if len(kind) == 0 {
+			kind = model.EventKindConsumed
+		}
+		v.events[event.Name] = kind
+	}
+	for i, retry := range v.workflow.Retries {
+		unique(v.retries, fmt.Sprintf("retries[%d].name", i), "retry", retry.Name)
+	}
+	for i, err := range v.workflow.Errors {
+		unique(v.errors, fmt.Sprintf("errors[%d].name", i), "error", err.Name)
+	}
+}
+
+func (v *validation) validateState(path string, state model.State) {
+	if transition := state.GetTransition(); transition != nil {
+		v.checkState(path+".transition.nextState", transition.NextState)
+	}
+	if compensatedBy := state.GetCompensatedBy(); len(compensatedBy) > 0 {
+		v.checkState(path+".compensatedBy", compensatedBy)
+	}
+	for i, onError := range state.GetOnErrors() {
+		errorPath := fmt.Sprintf("%s.onErrors[%d]", path, i)
+		if len(onError.ErrorRef) > 0 {
+			v.checkName(v.errors, errorPath+".errorRef", "error", onError.ErrorRef)
+		}
+		for j, ref := range onError.ErrorRefs {
+			v.checkName(v.errors, fmt.Sprintf("%s.errorRefs[%d]", errorPath, j), "error", ref)
+		}
+		if onError.Transition != nil {
+			v.checkState(errorPath+".transition.nextState", onError.Transition.NextState)
+		}
+	}
+	if end := state.GetEnd(); end != nil {
+		v.validateEnd(path+".end", end)
+	}
+
+	switch s := state.(type) {
+	case *model.OperationState:
+		v.validateActions(path+".actions", s.Actions)
+	case *model.ForEachState:
+		v.validateActions(path+".actions", s.Actions)
+	case *model.ParallelState:
+		for i, branch := range s.Branches {
+			v.validateActions(fmt.Sprintf("%s.branches[%d].actions", path, i), branch.Actions)
+		}
+	case *model.EventState:
+		for i, onEvent := range s.OnEvents {
+			onEventPath := fmt.Sprintf("%s.onEvents[%d]", path, i)
+			for j, ref := range onEvent.EventRefs {
+				v.checkEvent(fmt.Sprintf("%s.eventRefs[%d]", onEventPath, j), ref, model.EventKindConsumed)
+			}
+			v.validateActions(onEventPath+".actions", onEvent.Actions)
+		}
+	case *model.CallbackState:
+		v.validateAction(path+".action", &s.Action)
+		v.checkEvent(path+".eventRef", s.EventRef, model.EventKindConsumed)
+	case *model.EventBasedSwitchState:
+		v.validateDefaultCondition(path, s.DefaultCondition)
+		for i, condition := range s.EventConditions {
+			conditionPath := fmt.Sprintf("%s.eventConditions[%d]", path, i)
+			v.checkEvent(conditionPath+".eventRef", condition.GetEventRef(), model.EventKindConsumed)
+			switch c := condition.(type) {
+			case *model.TransitionEventCondition:
+				v.checkState(conditionPath+".transition.nextState", c.Transition.NextState)
+			case *model.EndEventCondition:
+				v.validateEnd(conditionPath+".end", &c.End)
+			}
+		}
+	case *model.DataBasedSwitchState:
+		v.validateDefaultCondition(path, s.DefaultCondition)
+		for i, condition := range s.DataConditions {
+			conditionPath := fmt.Sprintf("%s.dataConditions[%d]", path, i)
+			switch c := condition.(type) {
+			case *model.TransitionDataCondition:
+				v.checkState(conditionPath+".transition.nextState", c.Transition.NextState)
+			case *model.EndDataCondition:
+				v.validateEnd(conditionPath+".end", &c.End)
+			}
+		}
+	}
+}
+
+func (v *validation) validateDefaultCondition(path string, condition model.DefaultCondition) {
+	if len(condition.Transition.NextState) > 0 {
+		v.checkState(path+".defaultCondition.transition.nextState", condition.Transition.NextState)
+	}
+}
+
+func (v *validation) validateEnd(path string, end *model.End) {
+	for i, produce := range end.ProduceEvents {
+		v.checkEvent(fmt.Sprintf("%s.produceEvents[%d].eventRef", path, i), produce.EventRef, model.EventKindProduced)
+	}
+}
+
+func (v *validation) validateActions(path string, actions []model.Action) {
+	for i := range actions {
+		v.validateAction(fmt.Sprintf("%s[%d]", path, i), &actions[i])
+	}
+}
+
+func (v *validation) validateAction(path string, action *model.Action) {
+	if len(action.FunctionRef.RefName) > 0 {
+		v.checkName(v.functions, path+".functionRef.refName", "function", action.FunctionRef.RefName)
+	}
+	if len(action.EventRef.GetTriggerEventRef()) > 0 {
+		v.checkEvent(path+".eventRef.triggerEventRef", action.EventRef.TriggerEventRef, model.EventKindProduced)
+	}
+	if len(action.EventRef.GetResultEventRef()) > 0 {
+		v.checkEvent(path+".eventRef.resultEventRef", action.EventRef.ResultEventRef, model.EventKindConsumed)
+	}
+	if len(action.RetryRef) > 0 {
+		v.checkName(v.retries, path+".retryRef", "retry", action.RetryRef)
+	}
+	for i, ref := range action.RetryableErrors {
+		v.checkName(v.errors, fmt.Sprintf("%s.retryableErrors[%d]", path, i), "error", ref)
+	}
+	for i, ref := range action.NonRetryableErrors {
+		v.checkName(v.errors, fmt.Sprintf("%s.nonRetryableErrors[%d]", path, i), "error", ref)
+	}
+}
+
+func (v *validation) checkState(path, name string) {
+	v.checkName(v.states, path, "state", name)
+}
+
+func (v *validation) checkName(names map[string]bool, path, kind, name string) {
+	if !names[name] {
+		defined := make([]string, 0, len(names))
+		for n := range names {
+			defined = append(defined, n)
+		}
+		v.reportUndefined(path, kind, name, defined)
+	}
+}
+
+// reportUndefined reports the reference to an undefined name, suggesting the defined names likely meant
+func (v *validation) reportUndefined(path, kind, name string, defined []string) {
+	suggestions := suggest(name, defined)
+	args := map[string]interface{}{"kind": kind, "name": name, "suggestions": suggestions}
+	v.report(path, message.UndefinedReference, args, &model.ReferenceError{Kind: kind, Name: name, Path: path}).Suggestions = suggestions
+}
+
+func (v *validation) checkEvent(path, name string, kind model.EventKind) {
+	defined, ok := v.events[name]
+	if !ok {
+		defined := make([]string, 0, len(v.events))
+		for n := range v.events {
+			defined = append(defined, n)
+		}
+		v.reportUndefined(path, "event", name, defined)
+	} else if defined != kind {
+		v.report(path, message.EventKind, map[string]interface{}{"name": name, "expected": kind, "actual": defined}, nil)
+	}
+}
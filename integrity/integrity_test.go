@@ -0,0 +1,129 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/message"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	workflow := &model.Workflow{
+		BaseWorkflow: model.BaseWorkflow{
+			Start:  &model.Start{StateName: "Missing"},
+			Errors: []model.Error{{Name: "Timeout"}},
+		},
+		Functions: []model.Function{{Name: "store"}, {Name: "store"}},
+		Events: []model.Event{
+			{Name: "created", Type: "created"},
+			{Name: "stored", Type: "stored", Kind: model.EventKindProduced},
+		},
+		States: []model.State{
+			&model.EventState{
+				BaseState: model.BaseState{Name: "Wait", Transition: &model.Transition{NextState: "Store"}},
+				OnEvents:  []model.OnEvents{{EventRefs: []string{"created", "stored"}, Actions: []model.Action{{FunctionRef: model.FunctionRef{RefName: "unknown"}}}}},
+			},
+			&model.OperationState{
+				BaseState: model.BaseState{
+					Name:     "Store",
+					OnErrors: []model.OnError{{ErrorRef: "Timeout", Transition: &model.Transition{NextState: "Wait"}}, {ErrorRef: "Crash"}, {ErrorRef: "*"}},
+					End:      &model.End{ProduceEvents: []model.ProduceEvent{{EventRef: "stored"}}},
+				},
+				Actions: []model.Action{{FunctionRef: model.FunctionRef{RefName: "store"}, RetryRef: "fast"}},
+			},
+		},
+	}
+	errs := Validate(workflow)
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	assert.Equal(t, []string{
+		"functions[1].name: duplicated function name store",
+		"start.stateName: state Missing is not defined",
+		"states[0].onEvents[0].eventRefs[1]: event stored must be consumed but is produced",
+		"states[0].onEvents[0].actions[0].functionRef.refName: function unknown is not defined",
+		"states[1].onErrors[1].errorRef: error Crash is not defined",
+		"states[1].actions[0].retryRef: retry fast is not defined",
+	}, messages)
+	assert.Contains(t, errs.Error(), "\nstart.stateName")
+
+	workflow.Start.StateName = "Wait"
+	workflow.Functions = workflow.Functions[:1]
+	workflow.States[0].(*model.EventState).OnEvents[0] = model.OnEvents{EventRefs: []string{"created"}}
+	workflow.States[1].(*model.OperationState).OnErrors = nil
+	workflow.States[1].(*model.OperationState).Actions[0].RetryRef = ""
+	assert.Empty(t, Validate(workflow))
+}
+
+func TestValidateSuggestions(t *testing.T) {
+	workflow := &model.Workflow{
+		BaseWorkflow: model.BaseWorkflow{Start: &model.Start{StateName: "greet"}},
+		Functions:    []model.Function{{Name: "greetingFunction"}, {Name: "greetingFunctions"}, {Name: "store"}},
+		Events:       []model.Event{{Name: "GreetingEvent", Type: "greeting"}},
+		States: []model.State{
+			&model.EventState{
+				BaseState: model.BaseState{Name: "Greet", End: &model.End{}},
+				OnEvents: []model.OnEvents{{
+					EventRefs: []string{"GretingEvent"},
+					Actions:   []model.Action{{FunctionRef: model.FunctionRef{RefName: "greetingFunction2"}}},
+				}},
+			},
+		},
+	}
+	errs := Validate(workflow)
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	assert.Equal(t, []string{
+		"start.stateName: state greet is not defined, did you mean Greet?",
+		"states[0].onEvents[0].eventRefs[0]: event GretingEvent is not defined, did you mean GreetingEvent?",
+		"states[0].onEvents[0].actions[0].functionRef.refName: function greetingFunction2 is not defined, did you mean greetingFunction or greetingFunctions?",
+	}, messages)
+	assert.Equal(t, []string{"greetingFunction", "greetingFunctions"}, errs[2].Suggestions)
+
+	var refErr *model.ReferenceError
+	require.True(t, errors.As(errs, &refErr))
+	assert.Equal(t, model.ReferenceError{Kind: "state", Name: "greet", Path: "start.stateName"}, *refErr)
+	assert.False(t, errors.Is(errs, model.ErrDuplicateName))
+	workflow.Functions = append(workflow.Functions, model.Function{Name: "store"})
+	assert.True(t, errors.Is(Validate(workflow), model.ErrDuplicateName))
+}
+
+func TestSuggest(t *testing.T) {
+	assert.Equal(t, []string{"Store"}, suggest("Stor", []string{"Store", "Wait", "*"}))
+	assert.Empty(t, suggest("unknown", []string{"store"}))
+	assert.Equal(t, []string{"a", "b", "c"}, suggest("x", []string{"d", "c", "b", "a"}))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	assert.Equal(t, 0, editDistance("", ""))
+}
+
+func TestLocalize(t *testing.T) {
+	workflow := &model.Workflow{States: []model.State{
+		&model.InjectState{BaseState: model.BaseState{Name: "Store", Type: model.StateTypeInject, Transition: &model.Transition{NextState: "Stor"}}},
+	}}
+	errs := Validate(workflow)
+	require.Len(t, errs, 1)
+	assert.Equal(t, message.UndefinedReference, errs[0].ID)
+	assert.Equal(t, "state Stor is not defined, did you mean Store?", errs[0].Message)
+	assert.Equal(t, errs[0].Message, errs[0].Localize(nil))
+	assert.Equal(t, "état Stor non défini", errs[0].Localize(message.Catalog{message.UndefinedReference: "état {{.name}} non défini"}))
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrity
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestions maximum number of names suggested for a broken reference
+const maxSuggestions = 3
+
+// suggest returns the defined names close enough to the given one to be a likely typo, the closest first.
+// Names are compared ignoring case.
+func suggest(name string, defined []string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+	threshold := len([]rune(name)) / 3
+	if threshold < 1 {
+		threshold = 1
+	}
+	var candidates []candidate
+	for _, d := range defined {
+		if d == anyError || d == name {
+			continue
+		}
+		if distance := editDistance(strings.ToLower(name), strings.ToLower(d)); distance <= threshold {
+			candidates = append(candidates, candidate{name: d, distance: distance})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	var names []string
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		names = append(names, candidates[i].name)
+	}
+	return names
+}
+
+// editDistance Levenshtein distance between the strings
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	previous := make([]int, len(t)+1)
+	current := make([]int, len(t)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		current[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(t)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache caches compiled artifacts, e.g. schemas and expressions, keyed by the hash of their content so that
+// long-running services validating the same definitions over and over compile them once.
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// Key returns the content hash of the parts, hex encoded. The parts are length prefixed, different splits of the same
+// bytes have different keys.
+func Key(parts ...[]byte) string {
+	hash := sha256.New()
+	var size [8]byte
+	for _, part := range parts {
+		n := uint64(len(part))
+		for i := range size {
+			size[i] = byte(n >> (8 * i))
+		}
+		hash.Write(size[:])
+		hash.Write(part)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// LRU cache of at most Size values, evicting the least recently used ones. It's safe for concurrent use, the values
+// being shared between the goroutines they're returned to must be treated as read only.
+type LRU struct {
+	size    int
+	mutex   sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type entry struct {
+	key   string
+	value interface{}
+}
+
+// New returns a cache of at most size values
+func New(size int) *LRU {
+	return &LRU{size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// Get returns the value cached with the key
+func (c *LRU) Get(key string) (interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*entry).value, true
+}
+
+// Add caches the value with the key, evicting the least recently used value if the cache is full
+func (c *LRU) Add(key string, value interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*entry).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).key)
+	}
+}
+
+// GetOrCompile returns the value cached with the key, compiling and caching it if there is none. Errors aren't
+// cached. Concurrent calls with a key not cached yet may compile the value more than once.
+func (c *LRU) GetOrCompile(key string, compile func() (interface{}, error)) (interface{}, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+	value, err := compile()
+	if err != nil {
+		return nil, err
+	}
+	c.Add(key, value)
+	return value, nil
+}
+
+// Len returns the number of cached values
+func (c *LRU) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, Key([]byte("ab")), Key([]byte("ab")))
+	assert.NotEqual(t, Key([]byte("ab")), Key([]byte("a"), []byte("b")))
+	assert.NotEqual(t, Key([]byte("ab")), Key([]byte("ba")))
+	assert.Len(t, Key(), 64)
+}
+
+func TestLRU(t *testing.T) {
+	cache := New(2)
+	cache.Add("a", 1)
+	cache.Add("b", 2)
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	// b is the least recently used
+	cache.Add("c", 3)
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	value, _ = cache.Get("c")
+	assert.Equal(t, 3, value)
+
+	cache.Add("a", 4)
+	value, _ = cache.Get("a")
+	assert.Equal(t, 4, value)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestGetOrCompile(t *testing.T) {
+	cache := New(4)
+	compiled := 0
+	compile := func() (interface{}, error) {
+		compiled++
+		return compiled, nil
+	}
+	for i := 0; i < 3; i++ {
+		value, err := cache.GetOrCompile("key", compile)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, value)
+	}
+	assert.Equal(t, 1, compiled)
+
+	_, err := cache.GetOrCompile("invalid", func() (interface{}, error) { return nil, errors.New("invalid") })
+	assert.EqualError(t, err, "invalid")
+	_, ok := cache.Get("invalid")
+	assert.False(t, ok)
+}
@@ -0,0 +1,337 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package document reads and writes JSON and YAML documents preserving the order of the Object members
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Object JSON Object preserving the order of its members
+type Object []Member
+
+// Member key and value of an Object member
+type Member struct {
+	Key   string
+	Value interface{}
+}
+
+// Get returns the value of the member with the given key, false if there is none
+func (o Object) Get(key string) (interface{}, bool) {
+	for _, m := range o {
+		if m.Key == key {
+			return m.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Without returns a copy of the Object without the member with the given key
+func (o Object) Without(key string) Object {
+	result := make(Object, 0, len(o))
+	for _, m := range o {
+		if m.Key != key {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// Set replaces the value of the member with the given key, or appends a new member if there is none
+func (o Object) Set(key string, value interface{}) Object {
+	for i, m := range o {
+		if m.Key == key {
+			o[i].Value = value
+			return o
+		}
+	}
+	return append(o, Member{Key: key, Value: value})
+}
+
+// Rename renames the member keeping its position. If there is already a member with the new key, it's replaced.
+func (o Object) Rename(from, to string) Object {
+	value, ok := o.Get(from)
+	if !ok {
+		return o
+	}
+	o = o.Without(to)
+	for i, m := range o {
+		if m.Key == from {
+			o[i] = Member{Key: to, Value: value}
+		}
+	}
+	return o
+}
+
+// Decode decodes the JSON document into a tree of Object, []interface{}, string, bool, json.Number and nil values
+func Decode(data []byte) (interface{}, error) {
+	if !json.Valid(data) {
+		// the decoder reports where the syntax error is
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("invalid JSON document")
+	}
+	d := &decoder{data: data}
+	return d.value()
+}
+
+// decoder decodes a valid JSON document, much faster than the tokens of a json.Decoder
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *decoder) skipSpaces() {
+	for d.pos < len(d.data) {
+		switch d.data[d.pos] {
+		case ' ', '\t', '\r', '\n':
+			d.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (d *decoder) value() (interface{}, error) {
+	d.skipSpaces()
+	switch d.data[d.pos] {
+	case '{':
+		d.pos++
+		// most definitions have a few members, starting with room for them saves growing the slice
+		obj := make(Object, 0, 8)
+		d.skipSpaces()
+		if d.data[d.pos] == '}' {
+			d.pos++
+			return obj, nil
+		}
+		for {
+			d.skipSpaces()
+			key, err := d.string()
+			if err != nil {
+				return nil, err
+			}
+			d.skipSpaces()
+			// colon
+			d.pos++
+			value, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, Member{Key: key, Value: value})
+			d.skipSpaces()
+			d.pos++
+			if d.data[d.pos-1] == '}' {
+				return obj, nil
+			}
+		}
+	case '[':
+		d.pos++
+		array := []interface{}{}
+		d.skipSpaces()
+		if d.data[d.pos] == ']' {
+			d.pos++
+			return array, nil
+		}
+		for {
+			value, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+			d.skipSpaces()
+			d.pos++
+			if d.data[d.pos-1] == ']' {
+				return array, nil
+			}
+		}
+	case '"':
+		return d.string()
+	case 't':
+		d.pos += len("true")
+		return true, nil
+	case 'f':
+		d.pos += len("false")
+		return false, nil
+	case 'n':
+		d.pos += len("null")
+		return nil, nil
+	}
+	start := d.pos
+	for d.pos < len(d.data) && strings.IndexByte("+-0123456789.eE", d.data[d.pos]) >= 0 {
+		d.pos++
+	}
+	return json.Number(d.data[start:d.pos]), nil
+}
+
+// string decodes the string starting at the current position
+func (d *decoder) string() (string, error) {
+	start := d.pos
+	d.pos++
+	simple := true
+	for d.data[d.pos] != '"' {
+		if d.data[d.pos] == '\\' {
+			simple = false
+			d.pos++
+		} else if d.data[d.pos] >= utf8.RuneSelf {
+			simple = false
+		}
+		d.pos++
+	}
+	d.pos++
+	if simple {
+		return string(d.data[start+1 : d.pos-1]), nil
+	}
+	// escapes and non ASCII characters, invalid UTF-8 included, are left to the standard decoder
+	var s string
+	err := json.Unmarshal(d.data[start:d.pos], &s)
+	return s, err
+}
+
+// WriteJSON writes the tree as indented JSON
+func WriteJSON(w *bytes.Buffer, value interface{}, indent string, depth int) error {
+	newline := func(depth int) {
+		w.WriteByte('\n')
+		for i := 0; i < depth; i++ {
+			w.WriteString(indent)
+		}
+	}
+	switch v := value.(type) {
+	case Object:
+		if len(v) == 0 {
+			w.WriteString("{}")
+			return nil
+		}
+		w.WriteByte('{')
+		for i, m := range v {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			newline(depth + 1)
+			// json.Marshal escapes the HTML characters of the keys, unlike the values
+			if !writeSimpleString(w, m.Key, true) {
+				key, _ := json.Marshal(m.Key)
+				w.Write(key)
+			}
+			w.WriteString(": ")
+			if err := WriteJSON(w, m.Value, indent, depth+1); err != nil {
+				return err
+			}
+		}
+		newline(depth)
+		w.WriteByte('}')
+	case []interface{}:
+		if len(v) == 0 {
+			w.WriteString("[]")
+			return nil
+		}
+		w.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			newline(depth + 1)
+			if err := WriteJSON(w, item, indent, depth+1); err != nil {
+				return err
+			}
+		}
+		newline(depth)
+		w.WriteByte(']')
+	case string:
+		if !writeSimpleString(w, v, false) {
+			return writeValue(w, v)
+		}
+	case bool:
+		w.WriteString(strconv.FormatBool(v))
+	case nil:
+		w.WriteString("null")
+	default:
+		return writeValue(w, v)
+	}
+	return nil
+}
+
+// writeValue writes a scalar value with the JSON encoder
+func writeValue(w *bytes.Buffer, value interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	// Encode appends a new line
+	w.Truncate(w.Len() - 1)
+	return nil
+}
+
+// writeSimpleString writes the string quoted if it doesn't need to be escaped, i.e. if it's printable ASCII without
+// quotes, backslashes and, if escapeHTML, HTML characters. Returns false, without writing anything, otherwise.
+func writeSimpleString(w *bytes.Buffer, s string, escapeHTML bool) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 0x20 || c > 0x7e || c == '"' || c == '\\' || (escapeHTML && (c == '<' || c == '>' || c == '&')) {
+			return false
+		}
+	}
+	w.WriteByte('"')
+	w.WriteString(s)
+	w.WriteByte('"')
+	return true
+}
+
+// ToYAML converts the tree into values that yaml.v2 encodes in the same order
+func ToYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case Object:
+		slice := make(yaml.MapSlice, len(v))
+		for i, m := range v {
+			slice[i] = yaml.MapItem{Key: m.Key, Value: ToYAML(m.Value)}
+		}
+		return slice
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, item := range v {
+			array[i] = ToYAML(item)
+		}
+		return array
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return i
+		}
+		if f, err := strconv.ParseFloat(string(v), 64); err == nil {
+			return f
+		}
+		return string(v)
+	}
+	return value
+}
+
+// WriteYAML writes the tree as YAML, in the order of the Object members
+func WriteYAML(w io.Writer, value interface{}) error {
+	data, err := yaml.Marshal(ToYAML(value))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	tree, err := Decode([]byte(` {"name": "a\"bé\n", "ok": true, "ko": false, "none": null,
+  "numbers": [1, -2.5e3, 0], "empty": {}, "list": [], "nested": {"é": [{"x": "y"}]}, "bad": "` + "\xff" + `"} `))
+	require.NoError(t, err)
+	assert.Equal(t, Object{
+		{Key: "name", Value: "a\"bé\n"},
+		{Key: "ok", Value: true},
+		{Key: "ko", Value: false},
+		{Key: "none", Value: nil},
+		{Key: "numbers", Value: []interface{}{json.Number("1"), json.Number("-2.5e3"), json.Number("0")}},
+		{Key: "empty", Value: Object{}},
+		{Key: "list", Value: []interface{}{}},
+		{Key: "nested", Value: Object{{Key: "é", Value: []interface{}{Object{{Key: "x", Value: "y"}}}}}},
+		{Key: "bad", Value: "�"},
+	}, tree)
+
+	_, err = Decode([]byte(`{"name": }`))
+	assert.EqualError(t, err, "invalid character '}' looking for beginning of value")
+	_, err = Decode([]byte(`{"name": "a"} {}`))
+	assert.Error(t, err)
+}
+
+func TestWriteJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, WriteJSON(buf, Object{
+		{Key: "<key>", Value: "<value> & \"quotes\""},
+		{Key: "list", Value: []interface{}{json.Number("1.5"), true, nil, "é"}},
+	}, "  ", 0))
+	// the keys are escaped as json.Marshal does, not the values
+	assert.Equal(t, `{
+  "\u003ckey\u003e": "<value> & \"quotes\"",
+  "list": [
+    1.5,
+    true,
+    null,
+    "é"
+  ]
+}`, buf.String())
+}
@@ -0,0 +1,385 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	yamlMergeTag = "!!merge"
+	// maxAliasExpansion nodes visited per byte of the document, beyond which the aliases are considered an attack
+	// expanding a small document into a huge one
+	maxAliasExpansion = 100
+)
+
+// yaml11Bools plain scalars resolved to booleans by YAML 1.1, and by yaml.v2 decoding the workflows until now, but
+// to strings by YAML 1.2
+var yaml11Bools = map[string]bool{
+	"y": true, "Y": true, "yes": true, "Yes": true, "YES": true, "on": true, "On": true, "ON": true,
+	"n": false, "N": false, "no": false, "No": false, "NO": false, "off": false, "Off": false, "OFF": false,
+}
+
+// yaml11Bool returns the boolean of the scalar node if YAML 1.1 resolves it to a boolean but YAML 1.2 to a string:
+// unquoted and untagged, e.g. yes or off
+func yaml11Bool(node *yaml.Node) (value bool, ok bool) {
+	if node.Kind != yaml.ScalarNode || node.Style != 0 || node.ShortTag() != "!!str" {
+		return false, false
+	}
+	value, ok = yaml11Bools[node.Value]
+	return value, ok
+}
+
+// yamlPair member of a mapping, once its merge keys are resolved
+type yamlPair struct {
+	key   string
+	value *yaml.Node
+}
+
+// yamlWalker walks the yaml.v3 node tree of a document, resolving the aliases and merge keys. The keys keep their
+// order and the errors report the line of the invalid node.
+type yamlWalker struct {
+	visits    int
+	maxVisits int
+}
+
+func newYAMLWalker(data []byte) *yamlWalker {
+	return &yamlWalker{maxVisits: maxAliasExpansion*len(data) + 1<<16}
+}
+
+// root returns the root node of the document, nil if the document is empty
+func (y *yamlWalker) root(data []byte) (*yaml.Node, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return nil, nil
+	}
+	return document.Content[0], nil
+}
+
+// resolve returns the node an alias points to, the node itself otherwise
+func (y *yamlWalker) resolve(node *yaml.Node) (*yaml.Node, error) {
+	y.visits++
+	if y.visits > y.maxVisits {
+		return nil, fmt.Errorf("yaml: line %d: document expanded too much by its aliases", node.Line)
+	}
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node, nil
+}
+
+// pairs returns the members of the mapping, the explicit ones overriding those merged with the merge keys, and the
+// merged mappings overriding the ones following them
+func (y *yamlWalker) pairs(node *yaml.Node) ([]yamlPair, error) {
+	pairs := make([]yamlPair, 0, len(node.Content)/2)
+	explicit := make(map[string]int, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, err := y.resolve(node.Content[i])
+		if err != nil {
+			return nil, err
+		}
+		if keyNode.ShortTag() == yamlMergeTag {
+			continue
+		}
+		key, err := yamlKey(keyNode)
+		if err != nil {
+			return nil, err
+		}
+		if line, ok := explicit[key]; ok {
+			return nil, fmt.Errorf("yaml: line %d: mapping key %q already defined at line %d", keyNode.Line, key, line)
+		}
+		explicit[key] = keyNode.Line
+	}
+	added := make(map[string]bool, len(explicit))
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, _ := y.resolve(node.Content[i])
+		if keyNode.ShortTag() != yamlMergeTag {
+			key, _ := yamlKey(keyNode)
+			pairs = append(pairs, yamlPair{key: key, value: node.Content[i+1]})
+			added[key] = true
+			continue
+		}
+		merged, err := y.merged(node.Content[i+1])
+		if err != nil {
+			return nil, err
+		}
+		for _, pair := range merged {
+			if _, ok := explicit[pair.key]; !ok && !added[pair.key] {
+				pairs = append(pairs, pair)
+				added[pair.key] = true
+			}
+		}
+	}
+	return pairs, nil
+}
+
+// merged returns the members of the mappings merged by a merge key: a mapping or a sequence of mappings
+func (y *yamlWalker) merged(node *yaml.Node) ([]yamlPair, error) {
+	node, err := y.resolve(node)
+	if err != nil {
+		return nil, err
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		return y.pairs(node)
+	case yaml.SequenceNode:
+		var pairs []yamlPair
+		seen := map[string]bool{}
+		for _, item := range node.Content {
+			mapping, err := y.resolve(item)
+			if err != nil {
+				return nil, err
+			}
+			if mapping.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("yaml: line %d: map merge requires map or sequence of maps as the value", item.Line)
+			}
+			itemPairs, err := y.pairs(mapping)
+			if err != nil {
+				return nil, err
+			}
+			for _, pair := range itemPairs {
+				if !seen[pair.key] {
+					pairs = append(pairs, pair)
+					seen[pair.key] = true
+				}
+			}
+		}
+		return pairs, nil
+	}
+	return nil, fmt.Errorf("yaml: line %d: map merge requires map or sequence of maps as the value", node.Line)
+}
+
+// yamlKey converts the key of a mapping to a string, formatting the numbers as the YAML encoder does
+func yamlKey(node *yaml.Node) (string, error) {
+	if node.Kind == yaml.ScalarNode {
+		switch node.ShortTag() {
+		case "!!str":
+			if value, ok := yaml11Bool(node); ok {
+				return strconv.FormatBool(value), nil
+			}
+			return node.Value, nil
+		case "!!int", "!!float", "!!bool":
+			var value interface{}
+			if err := node.Decode(&value); err != nil {
+				return "", err
+			}
+			switch v := value.(type) {
+			case float64:
+				s := strconv.FormatFloat(v, 'g', -1, 32)
+				switch s {
+				case "+Inf":
+					s = ".inf"
+				case "-Inf":
+					s = "-.inf"
+				case "NaN":
+					s = ".nan"
+				}
+				return s, nil
+			default:
+				return fmt.Sprint(v), nil
+			}
+		case "!!null":
+		default:
+			return node.Value, nil
+		}
+	}
+	return "", fmt.Errorf("yaml: line %d: unsupported map key %s", node.Line, describeYAML(node))
+}
+
+// scalar returns the value of the scalar node: nil, a bool, a json.Number or a string. The timestamps, binaries and
+// custom tags are kept as strings. The booleans of YAML 1.1, e.g. yes and off, are still booleans.
+func (y *yamlWalker) scalar(node *yaml.Node) (interface{}, error) {
+	if value, ok := yaml11Bool(node); ok {
+		return value, nil
+	}
+	switch node.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool", "!!int", "!!float":
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return nil, err
+		}
+		switch v := value.(type) {
+		case int:
+			return json.Number(strconv.Itoa(v)), nil
+		case int64:
+			return json.Number(strconv.FormatInt(v, 10)), nil
+		case uint64:
+			return json.Number(strconv.FormatUint(v, 10)), nil
+		case float64:
+			if math.IsInf(v, 0) || math.IsNaN(v) {
+				return nil, fmt.Errorf("yaml: line %d: unsupported value %s", node.Line, node.Value)
+			}
+			return json.Number(strconv.FormatFloat(v, 'g', -1, 64)), nil
+		}
+		return value, nil
+	}
+	return node.Value, nil
+}
+
+// describeYAML describes the node in the errors
+func describeYAML(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "mapping"
+	case yaml.SequenceNode:
+		return "sequence"
+	}
+	if node.ShortTag() == "!!null" {
+		return "null"
+	}
+	return strconv.Quote(node.Value)
+}
+
+// DecodeYAML decodes the YAML document, which must be a mapping, into the same tree as Decode. The anchors, aliases
+// and merge keys are resolved, and the errors report the line of the invalid node.
+func DecodeYAML(data []byte) (interface{}, error) {
+	y := newYAMLWalker(data)
+	root, err := y.root(data)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return Object{}, nil
+	}
+	if root, err = y.resolve(root); err != nil {
+		return nil, err
+	}
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("yaml: line %d: the document must be a mapping, got a %s", root.Line, describeYAML(root))
+	}
+	return y.decode(root)
+}
+
+func (y *yamlWalker) decode(node *yaml.Node) (interface{}, error) {
+	node, err := y.resolve(node)
+	if err != nil {
+		return nil, err
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		pairs, err := y.pairs(node)
+		if err != nil {
+			return nil, err
+		}
+		obj := make(Object, len(pairs))
+		for i, pair := range pairs {
+			value, err := y.decode(pair.value)
+			if err != nil {
+				return nil, err
+			}
+			obj[i] = Member{Key: pair.key, Value: value}
+		}
+		return obj, nil
+	case yaml.SequenceNode:
+		array := make([]interface{}, len(node.Content))
+		for i, item := range node.Content {
+			if array[i], err = y.decode(item); err != nil {
+				return nil, err
+			}
+		}
+		return array, nil
+	}
+	return y.scalar(node)
+}
+
+// YAMLToJSON converts the YAML document to compact JSON into the buffer, the members of the mappings in the order of
+// the document. The anchors, aliases and merge keys are resolved, and the errors report the line of the invalid node.
+// An empty document is converted to null.
+func YAMLToJSON(w *bytes.Buffer, data []byte) error {
+	y := newYAMLWalker(data)
+	root, err := y.root(data)
+	if err != nil {
+		return err
+	}
+	if root == nil {
+		w.WriteString("null")
+		return nil
+	}
+	return y.writeJSON(w, root)
+}
+
+func (y *yamlWalker) writeJSON(w *bytes.Buffer, node *yaml.Node) error {
+	node, err := y.resolve(node)
+	if err != nil {
+		return err
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		pairs, err := y.pairs(node)
+		if err != nil {
+			return err
+		}
+		w.WriteByte('{')
+		for i, pair := range pairs {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := writeJSONString(w, pair.key); err != nil {
+				return err
+			}
+			w.WriteByte(':')
+			if err := y.writeJSON(w, pair.value); err != nil {
+				return err
+			}
+		}
+		w.WriteByte('}')
+		return nil
+	case yaml.SequenceNode:
+		w.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := y.writeJSON(w, item); err != nil {
+				return err
+			}
+		}
+		w.WriteByte(']')
+		return nil
+	}
+	value, err := y.scalar(node)
+	if err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case nil:
+		w.WriteString("null")
+	case bool:
+		w.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		w.WriteString(string(v))
+	case string:
+		return writeJSONString(w, v)
+	}
+	return nil
+}
+
+func writeJSONString(w *bytes.Buffer, s string) error {
+	if writeSimpleString(w, s, false) {
+		return nil
+	}
+	return writeValue(w, s)
+}
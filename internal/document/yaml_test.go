@@ -0,0 +1,106 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAMLToJSON(t *testing.T) {
+	source := `
+base: &base
+  type: rest
+  retries: 3
+defaults: &defaults
+  retries: 5
+  timeout: PT1S
+functions:
+  - name: store
+    <<: [*base, *defaults]
+    retries: 1
+  - <<: *base
+    name: load
+flags: [yes, no, on, Off, "yes", !!str no, true, ~, 0x10, 1.5e3, 2023-01-02]
+1: one
+2.5: two
+false: three
+on: four
+`
+	buf := new(bytes.Buffer)
+	require.NoError(t, YAMLToJSON(buf, []byte(source)))
+	// the members keep the order of the document, the explicit keys override the merged ones, and the first merged
+	// mappings the following ones
+	assert.Equal(t, `{"base":{"type":"rest","retries":3},"defaults":{"retries":5,"timeout":"PT1S"},`+
+		`"functions":[{"name":"store","type":"rest","timeout":"PT1S","retries":1},{"type":"rest","retries":3,"name":"load"}],`+
+		`"flags":[true,false,true,false,"yes","no",true,null,16,1500,"2023-01-02"],"1":"one","2.5":"two","false":"three",`+
+		`"true":"four"}`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, YAMLToJSON(buf, []byte("")))
+	assert.Equal(t, "null", buf.String())
+	buf.Reset()
+	require.NoError(t, YAMLToJSON(buf, []byte(`text: "tab\t\"quoted\" <b>é</b>"`)))
+	assert.Equal(t, `{"text":"tab\t\"quoted\" <b>é</b>"}`, buf.String())
+}
+
+func TestYAMLErrors(t *testing.T) {
+	for source, expected := range map[string]string{
+		"name: a\n~: b\n":           "yaml: line 2: unsupported map key null",
+		"name: a\n[a, b]: c\n":      "yaml: line 2: unsupported map key sequence",
+		"name: a\nid: b\nname: c\n": "yaml: line 3: mapping key \"name\" already defined at line 1",
+		"a: 1\nb:\n  <<: [1]\n":     "yaml: line 3: map merge requires map or sequence of maps as the value",
+		"a: .inf\n":                 "yaml: line 1: unsupported value .inf",
+		"a: [1\n":                   "yaml: line 1: did not find expected ',' or ']'",
+		"a: *missing\n":             "yaml: unknown anchor 'missing' referenced",
+	} {
+		err := YAMLToJSON(new(bytes.Buffer), []byte(source))
+		if assert.Error(t, err, source) {
+			assert.Contains(t, err.Error(), expected, source)
+		}
+	}
+
+	// each level doubles the size of the document
+	bomb := strings.Builder{}
+	bomb.WriteString("a0: &a0 [x, x]\n")
+	for i := 1; i < 40; i++ {
+		fmt.Fprintf(&bomb, "a%d: &a%d [*a%d, *a%d]\n", i, i, i-1, i-1)
+	}
+	err := YAMLToJSON(new(bytes.Buffer), []byte(bomb.String()))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "document expanded too much by its aliases")
+	}
+}
+
+func TestDecodeYAML(t *testing.T) {
+	tree, err := DecodeYAML([]byte("id: order\n<<: {version: '1.0'}\nstates: [{name: a}]\n"))
+	require.NoError(t, err)
+	assert.Equal(t, Object{
+		{Key: "id", Value: "order"},
+		{Key: "version", Value: "1.0"},
+		{Key: "states", Value: []interface{}{Object{{Key: "name", Value: "a"}}}},
+	}, tree)
+
+	tree, err = DecodeYAML(nil)
+	require.NoError(t, err)
+	assert.Equal(t, Object{}, tree)
+	_, err = DecodeYAML([]byte("- a\n"))
+	assert.EqualError(t, err, "yaml: line 1: the document must be a mapping, got a sequence")
+}
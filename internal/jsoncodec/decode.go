@@ -0,0 +1,404 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsoncodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Decoder reads the values of a JSON document in order for the generated UnmarshalJSON methods. It doesn't report
+// errors: it fails on anything out of its fast path, e.g. invalid JSON, a value of an unexpected type or an escaped
+// key, the generated methods then decoding the document again with encoding/json, which reports the errors. Once
+// failed, the reads are no-ops and the loops end.
+type Decoder struct {
+	data   []byte
+	pos    int
+	key    []byte
+	failed bool
+}
+
+// NewDecoder returns a decoder of the JSON document
+func NewDecoder(data []byte) Decoder {
+	return Decoder{data: data}
+}
+
+// Failed whether the decoder failed
+func (d *Decoder) Failed() bool {
+	return d.failed
+}
+
+// End whether the document was decoded without failure, up to its end
+func (d *Decoder) End() bool {
+	d.skipSpace()
+	return !d.failed && d.pos == len(d.data)
+}
+
+func (d *Decoder) fail() {
+	d.failed = true
+}
+
+func (d *Decoder) skipSpace() {
+	for d.pos < len(d.data) {
+		switch d.data[d.pos] {
+		case ' ', '\t', '\n', '\r':
+			d.pos++
+		default:
+			return
+		}
+	}
+}
+
+// peek returns the first byte of the next value, 0 at the end of the document or once failed
+func (d *Decoder) peek() byte {
+	if d.failed {
+		return 0
+	}
+	d.skipSpace()
+	if d.pos == len(d.data) {
+		return 0
+	}
+	return d.data[d.pos]
+}
+
+// Null reads the next value if it's null, returns whether it was
+func (d *Decoder) Null() bool {
+	if d.peek() == 'n' && bytes.HasPrefix(d.data[d.pos:], []byte("null")) {
+		d.pos += 4
+		return true
+	}
+	return false
+}
+
+// Object starts reading an object, returns whether it has a member, the key of which is then returned by Key
+func (d *Decoder) Object() bool {
+	if d.peek() != '{' {
+		d.fail()
+		return false
+	}
+	d.pos++
+	if d.peek() == '}' {
+		d.pos++
+		return false
+	}
+	return d.readKey(true)
+}
+
+// Next returns whether the object has another member, once the value of the previous one was read
+func (d *Decoder) Next() bool {
+	switch d.peek() {
+	case ',':
+		d.pos++
+		return d.readKey(true)
+	case '}':
+		d.pos++
+		return false
+	}
+	d.fail()
+	return false
+}
+
+// Key returns the key of the current object member
+func (d *Decoder) Key() []byte {
+	return d.key
+}
+
+// readKey reads the key of a member, failing on the escaped keys if strict: once unescaped they could match a
+// property, they're left to encoding/json
+func (d *Decoder) readKey(strict bool) bool {
+	raw, escaped := d.readString()
+	if escaped && strict {
+		d.fail()
+	}
+	if d.peek() != ':' {
+		d.fail()
+		return false
+	}
+	d.pos++
+	d.key = raw[1 : len(raw)-1]
+	return true
+}
+
+// Unknown skips the value of a member matching none of the keys, and fails if the member matches one of them
+// regardless of the case, such members being matched by encoding/json
+func (d *Decoder) Unknown(keys []string) {
+	for _, key := range keys {
+		if bytes.EqualFold(d.key, []byte(key)) {
+			d.fail()
+			return
+		}
+	}
+	d.Skip()
+}
+
+// Array starts reading an array, returns whether it has an item
+func (d *Decoder) Array() bool {
+	if d.peek() != '[' {
+		d.fail()
+		return false
+	}
+	d.pos++
+	if d.peek() == ']' {
+		d.pos++
+		return false
+	}
+	return !d.failed
+}
+
+// Item returns whether the array has another item, once the previous one was read
+func (d *Decoder) Item() bool {
+	switch d.peek() {
+	case ',':
+		d.pos++
+		return true
+	case ']':
+		d.pos++
+		return false
+	}
+	d.fail()
+	return false
+}
+
+// String reads a string into p, left unchanged if the value is null
+func (d *Decoder) String(p *string) {
+	if d.Null() {
+		return
+	}
+	if d.peek() != '"' {
+		d.fail()
+		return
+	}
+	raw, escaped := d.readString()
+	if d.failed {
+		return
+	}
+	s := raw[1 : len(raw)-1]
+	if escaped || !ascii(s) && !utf8.Valid(s) {
+		// the escapes and invalid characters are decoded by encoding/json
+		d.unmarshal(raw, p)
+		return
+	}
+	*p = string(s)
+}
+
+func ascii(s []byte) bool {
+	for _, c := range s {
+		if c >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// readString reads a string, returns its raw JSON and whether it has escapes
+func (d *Decoder) readString() ([]byte, bool) {
+	if d.peek() != '"' {
+		d.fail()
+		return []byte(`""`), false
+	}
+	start := d.pos
+	escaped := false
+	for i := start + 1; i < len(d.data); i++ {
+		switch c := d.data[i]; {
+		case c == '"':
+			d.pos = i + 1
+			return d.data[start:d.pos], escaped
+		case c == '\\':
+			escaped = true
+			i++
+		case c < 0x20:
+			d.fail()
+			return []byte(`""`), false
+		}
+	}
+	d.fail()
+	return []byte(`""`), false
+}
+
+// Bool reads a boolean into p, left unchanged if the value is null
+func (d *Decoder) Bool(p *bool) {
+	switch {
+	case d.Null():
+	case d.peek() == 't' && bytes.HasPrefix(d.data[d.pos:], []byte("true")):
+		d.pos += 4
+		*p = true
+	case d.peek() == 'f' && bytes.HasPrefix(d.data[d.pos:], []byte("false")):
+		d.pos += 5
+		*p = false
+	default:
+		d.fail()
+	}
+}
+
+// Int reads an integer of the given bit size, returns false if the value is null
+func (d *Decoder) Int(bits int) (int64, bool) {
+	number, ok := d.number()
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(string(number), 10, bits)
+	if err != nil {
+		d.fail()
+		return 0, false
+	}
+	return v, true
+}
+
+// Uint reads an unsigned integer of the given bit size, returns false if the value is null
+func (d *Decoder) Uint(bits int) (uint64, bool) {
+	number, ok := d.number()
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(string(number), 10, bits)
+	if err != nil {
+		d.fail()
+		return 0, false
+	}
+	return v, true
+}
+
+// Float reads a number of the given bit size, returns false if the value is null
+func (d *Decoder) Float(bits int) (float64, bool) {
+	number, ok := d.number()
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(string(number), bits)
+	if err != nil {
+		d.fail()
+		return 0, false
+	}
+	return v, true
+}
+
+// number reads a number, returns false if the value is null
+func (d *Decoder) number() ([]byte, bool) {
+	if d.Null() {
+		return nil, false
+	}
+	start := d.pos
+	if d.peek() == '-' {
+		d.pos++
+	}
+	switch {
+	case d.pos < len(d.data) && d.data[d.pos] == '0':
+		d.pos++
+	case d.digits() == 0:
+		d.fail()
+		return nil, false
+	}
+	if d.pos < len(d.data) && d.data[d.pos] == '.' {
+		d.pos++
+		if d.digits() == 0 {
+			d.fail()
+			return nil, false
+		}
+	}
+	if d.pos < len(d.data) && (d.data[d.pos] == 'e' || d.data[d.pos] == 'E') {
+		d.pos++
+		if d.pos < len(d.data) && (d.data[d.pos] == '+' || d.data[d.pos] == '-') {
+			d.pos++
+		}
+		if d.digits() == 0 {
+			d.fail()
+			return nil, false
+		}
+	}
+	return d.data[start:d.pos], true
+}
+
+// digits reads the digits of a number, returns their count
+func (d *Decoder) digits() int {
+	start := d.pos
+	for d.pos < len(d.data) && d.data[d.pos] >= '0' && d.data[d.pos] <= '9' {
+		d.pos++
+	}
+	return d.pos - start
+}
+
+// Unmarshaler reads the next value with its UnmarshalJSON method, called with null too like encoding/json does
+func (d *Decoder) Unmarshaler(u json.Unmarshaler) {
+	raw := d.Skip()
+	if d.failed {
+		return
+	}
+	if err := u.UnmarshalJSON(raw); err != nil {
+		d.fail()
+	}
+}
+
+// Value reads the next value into v with encoding/json, e.g. a map or a free-form value
+func (d *Decoder) Value(v interface{}) {
+	raw := d.Skip()
+	if !d.failed {
+		d.unmarshal(raw, v)
+	}
+}
+
+func (d *Decoder) unmarshal(raw []byte, v interface{}) {
+	if err := json.Unmarshal(raw, v); err != nil {
+		d.fail()
+	}
+}
+
+// Skip reads the next value, returns its raw JSON
+func (d *Decoder) Skip() []byte {
+	c := d.peek()
+	start := d.pos
+	switch {
+	case c == '"':
+		d.readString()
+	case c == '{':
+		d.pos++
+		if d.peek() == '}' {
+			d.pos++
+			break
+		}
+		for more := d.readKey(false); more; {
+			d.Skip()
+			switch d.peek() {
+			case ',':
+				d.pos++
+				more = d.readKey(false)
+			case '}':
+				d.pos++
+				more = false
+			default:
+				d.fail()
+				more = false
+			}
+		}
+	case c == '[':
+		for more := d.Array(); more; more = d.Item() {
+			d.Skip()
+		}
+	case c == 't' || c == 'f':
+		var b bool
+		d.Bool(&b)
+	case c == 'n':
+		if !d.Null() {
+			d.fail()
+		}
+	default:
+		d.number()
+	}
+	if d.failed {
+		return nil
+	}
+	return d.data[start:d.pos]
+}
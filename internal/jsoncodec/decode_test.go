@@ -0,0 +1,104 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsoncodec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	name    string
+	enabled bool
+	count   int64
+	ratio   float64
+	tags    []string
+	raw     []byte
+}
+
+// decodeItem decodes an item the way the generated methods do
+func decodeItem(data string) (item, bool) {
+	var it item
+	d := NewDecoder([]byte(data))
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "name":
+			d.String(&it.name)
+		case "enabled":
+			d.Bool(&it.enabled)
+		case "count":
+			if v, ok := d.Int(32); ok {
+				it.count = v
+			}
+		case "ratio":
+			if v, ok := d.Float(64); ok {
+				it.ratio = v
+			}
+		case "tags":
+			for more := d.Array(); more; more = d.Item() {
+				var tag string
+				d.String(&tag)
+				it.tags = append(it.tags, tag)
+			}
+		default:
+			d.Unknown([]string{"name", "enabled", "count", "ratio", "tags"})
+		}
+	}
+	return it, d.End()
+}
+
+func TestDecoder(t *testing.T) {
+	it, ok := decodeItem(` { "name" : "a\"bé", "enabled": true, "count": -12, "ratio": 1.5e-3,
+		"tags": ["x", "y"], "other": {"a": [1, {"b": null}], "c\"": "d"}, "ratio2": null } `)
+	assert.True(t, ok)
+	assert.Equal(t, item{name: "a\"bé", enabled: true, count: -12, ratio: 1.5e-3, tags: []string{"x", "y"}}, it)
+
+	it, ok = decodeItem(`{"name": null, "tags": []}`)
+	assert.True(t, ok)
+	assert.Equal(t, item{}, it)
+}
+
+func TestDecoderFails(t *testing.T) {
+	for _, data := range []string{
+		``,
+		`[]`,
+		`{"name": 1}`,
+		`{"enabled": "true"}`,
+		`{"count": 1.5}`,
+		`{"count": 4294967296}`,
+		`{"ratio": 01}`,
+		`{"ratio": 1.}`,
+		`{"ratio": -}`,
+		`{"tags": ["x",]}`,
+		`{"name": "a"`,
+		`{"name": "a"} {}`,
+		`{"name": "a	b"}`,
+		`{"NAME": "a"}`,
+		`{"n\u0061me": "a"}`,
+		`{"other": [1 2]}`,
+		`{"other": nul}`,
+	} {
+		_, ok := decodeItem(data)
+		assert.False(t, ok, data)
+	}
+}
+
+func TestSkip(t *testing.T) {
+	d := NewDecoder([]byte(` {"a": [1, "b", true, null, {}]} , `))
+	assert.Equal(t, `{"a": [1, "b", true, null, {}]}`, string(d.Skip()))
+	assert.False(t, d.Failed())
+	assert.False(t, d.End())
+}
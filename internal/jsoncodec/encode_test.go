@@ -0,0 +1,57 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsoncodec
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendString(t *testing.T) {
+	for _, s := range []string{"", "name", "a \"quoted\" \\ value", "<b>&</b>", "tab\tnew\nline\x01", "été ✓ 🙂",
+		"invalid \xff utf-8", "line\u2028separator", "${ .a }"} {
+		expected, err := json.Marshal(s)
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(AppendString([]byte("x"), s))[1:], s)
+	}
+}
+
+func TestAppendFloat(t *testing.T) {
+	for _, f := range []float64{0, 1, -1.5, 0.1, 1e-6, 1e-7, 123456789, 1e20, 1e21, -1e-9, math.MaxFloat64,
+		math.SmallestNonzeroFloat64} {
+		expected, err := json.Marshal(f)
+		assert.NoError(t, err)
+		b, err := AppendFloat(nil, f, 64)
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(b), f)
+
+		if math.Abs(f) > math.MaxFloat32 {
+			continue
+		}
+		expected, err = json.Marshal(float32(f))
+		assert.NoError(t, err)
+		b, err = AppendFloat(nil, float64(float32(f)), 32)
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(b), f)
+	}
+
+	_, err := AppendFloat(nil, math.NaN(), 64)
+	assert.Error(t, err)
+	_, err = AppendFloat(nil, math.Inf(1), 64)
+	assert.Error(t, err)
+}
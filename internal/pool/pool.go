@@ -0,0 +1,54 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pool runs indexed jobs on a bounded pool of workers
+package pool
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Workers returns the number of workers running n jobs: workers, as many as CPUs if it isn't positive, at most n
+func Workers(n, workers int) int {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > n {
+		workers = n
+	}
+	return workers
+}
+
+// Run calls fn with the index of every job, from 0 to n excluded, on a pool of workers, see Workers. It returns once
+// every job is done. fn stores its results at the index of the job, keeping them in order whatever the number of
+// workers.
+func Run(n, workers int, fn func(i int)) {
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for w := 0; w < Workers(n, workers); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
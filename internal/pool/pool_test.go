@@ -0,0 +1,56 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pool
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkers(t *testing.T) {
+	assert.Equal(t, 2, Workers(10, 2))
+	assert.Equal(t, 3, Workers(3, 8))
+	assert.Equal(t, 0, Workers(0, 8))
+	if runtime.NumCPU() < 100 {
+		assert.Equal(t, runtime.NumCPU(), Workers(100, 0))
+	}
+}
+
+func TestRun(t *testing.T) {
+	results := make([]int, 100)
+	var running, max int32
+	Run(len(results), 4, func(i int) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			seen := atomic.LoadInt32(&max)
+			if current <= seen || atomic.CompareAndSwapInt32(&max, seen, current) {
+				break
+			}
+		}
+		results[i] = i * i
+		atomic.AddInt32(&running, -1)
+	})
+	for i, result := range results {
+		assert.Equal(t, i*i, result)
+	}
+	assert.LessOrEqual(t, max, int32(4))
+
+	Run(0, 4, func(i int) {
+		t.Fatal("no jobs")
+	})
+}
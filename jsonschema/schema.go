@@ -0,0 +1,208 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/internal/cache"
+	"sigs.k8s.io/yaml"
+)
+
+// schemas compiled schemas keyed by the hash of their documents
+var schemas = cache.New(256)
+
+const (
+	// TypeObject ...
+	TypeObject = "object"
+	// TypeArray ...
+	TypeArray = "array"
+	// TypeString ...
+	TypeString = "string"
+	// TypeInteger ...
+	TypeInteger = "integer"
+	// TypeNumber ...
+	TypeNumber = "number"
+	// TypeBoolean ...
+	TypeBoolean = "boolean"
+	// TypeNull ...
+	TypeNull = "null"
+)
+
+// Schema subset of a JSON Schema (draft 7 and 2019-09) document describing the structure of JSON data
+type Schema struct {
+	ID          string             `json:"$id,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        Types              `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Enum        []interface{}      `json:"enum,omitempty"`
+	Const       interface{}        `json:"const,omitempty"`
+	Default     interface{}        `json:"default,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	// AdditionalProperties either a boolean or a schema
+	AdditionalProperties json.RawMessage    `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Definitions          map[string]*Schema `json:"definitions,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// Types the type keyword, either a single type or an array of types
+type Types []string
+
+// UnmarshalJSON ...
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings: %w", err)
+	}
+	*t = many
+	return nil
+}
+
+// MarshalJSON ...
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Parse parses a JSON or YAML schema document
+func Parse(data []byte) (*Schema, error) {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	schema := &Schema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	return schema, nil
+}
+
+// Compile parses a JSON or YAML schema document like Parse, caching the schemas by the hash of their documents so that
+// services validating against the same schemas parse each of them once. The schemas returned are shared, they must be
+// treated as read only.
+func Compile(data []byte) (*Schema, error) {
+	schema, err := schemas.GetOrCompile(cache.Key(data), func() (interface{}, error) {
+		return Parse(data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schema.(*Schema), nil
+}
+
+// Is checks whether the schema allows the given type
+func (s *Schema) Is(t string) bool {
+	for _, st := range s.Type {
+		if st == t {
+			return true
+		}
+	}
+	return false
+}
+
+// PrimaryType the first type of the schema that is not null, or an empty string if the type isn't declared.
+// Schemas declaring properties but no type are considered objects.
+func (s *Schema) PrimaryType() string {
+	for _, t := range s.Type {
+		if t != TypeNull {
+			return t
+		}
+	}
+	if len(s.Properties) > 0 {
+		return TypeObject
+	}
+	return ""
+}
+
+// IsRequired checks whether the given property is required
+func (s *Schema) IsRequired(property string) bool {
+	for _, r := range s.Required {
+		if r == property {
+			return true
+		}
+	}
+	return false
+}
+
+// Definition returns the schema defined under definitions or $defs with the given name
+func (s *Schema) Definition(name string) (*Schema, bool) {
+	if def, ok := s.Defs[name]; ok {
+		return def, true
+	}
+	def, ok := s.Definitions[name]
+	return def, ok
+}
+
+// Resolve resolves a local reference such as "#/definitions/address" or "#/$defs/address" against the root schema
+func (s *Schema) Resolve(ref string) (*Schema, error) {
+	if ref == "#" {
+		return s, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("reference %s not supported, only local references are", ref)
+	}
+	current := s
+	parts := strings.Split(strings.TrimPrefix(ref, "#/"), "/")
+	for i := 0; i < len(parts); i++ {
+		var next *Schema
+		switch parts[i] {
+		case "definitions", "$defs":
+			if i+1 < len(parts) {
+				i++
+				next, _ = current.Definition(unescape(parts[i]))
+			}
+		case "properties":
+			if i+1 < len(parts) {
+				i++
+				next = current.Properties[unescape(parts[i])]
+			}
+		case "items":
+			next = current.Items
+		}
+		if next == nil {
+			return nil, fmt.Errorf("reference %s not found", ref)
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// unescape decodes a JSON pointer token
+func unescape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	schema, err := Parse([]byte(`
+type: [object, "null"]
+required: [name]
+properties:
+  name:
+    type: string
+  address:
+    $ref: '#/$defs/address'
+$defs:
+  address:
+    type: object
+    properties:
+      city:
+        type: string
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, Types{TypeObject, TypeNull}, schema.Type)
+	assert.Equal(t, TypeObject, schema.PrimaryType())
+	assert.True(t, schema.Is(TypeNull))
+	assert.True(t, schema.IsRequired("name"))
+	assert.False(t, schema.IsRequired("address"))
+
+	address, err := schema.Resolve(schema.Properties["address"].Ref)
+	assert.NoError(t, err)
+	assert.Equal(t, TypeString, address.Properties["city"].PrimaryType())
+	city, err := schema.Resolve("#/$defs/address/properties/city")
+	assert.NoError(t, err)
+	assert.Equal(t, Types{TypeString}, city.Type)
+
+	_, err = schema.Resolve("#/definitions/phone")
+	assert.Error(t, err)
+	_, err = schema.Resolve("other.json#/definitions/address")
+	assert.Error(t, err)
+
+	_, err = Parse([]byte(`{"type": 1}`))
+	assert.Error(t, err)
+}
+
+func TestCompile(t *testing.T) {
+	document := []byte(`{"type": "string", "pattern": "^[a-z]+$"}`)
+	schema, err := Compile(document)
+	assert.NoError(t, err)
+	cached, err := Compile([]byte(`{"type": "string", "pattern": "^[a-z]+$"}`))
+	assert.NoError(t, err)
+	assert.Same(t, schema, cached)
+	assert.Empty(t, cached.Validate("order"))
+	assert.Len(t, cached.Validate("Order"), 1)
+
+	other, err := Compile([]byte(`{"type": "number"}`))
+	assert.NoError(t, err)
+	assert.NotSame(t, schema, other)
+
+	_, err = Compile([]byte(`{"type": 1}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,324 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/validator"
+)
+
+// ValidationError violation of a schema by a JSON value
+type ValidationError struct {
+	// Path of the invalid value, e.g. 'customer.items[0].sku', empty for the root value
+	Path    string
+	Message string
+}
+
+// Error ...
+func (e ValidationError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Validate checks a decoded JSON value, as returned by json.Unmarshal into an interface{}, against the schema.
+// Local references are resolved against the schema, the format keyword isn't checked.
+func (s *Schema) Validate(value interface{}) []ValidationError {
+	v := &validation{root: s}
+	v.validate(s, normalize(value), "")
+	return v.errors
+}
+
+type validation struct {
+	root   *Schema
+	errors []ValidationError
+	// refs references being expanded, to stop on recursive schemas referencing themselves without consuming the value
+	refs []string
+}
+
+func (v *validation) fail(path string, format string, args ...interface{}) {
+	v.errors = append(v.errors, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validation) validate(schema *Schema, value interface{}, path string) {
+	if schema == nil {
+		return
+	}
+	if len(schema.Ref) > 0 {
+		for _, ref := range v.refs {
+			if ref == schema.Ref {
+				return
+			}
+		}
+		target, err := v.root.Resolve(schema.Ref)
+		if err != nil {
+			v.fail(path, "%v", err)
+			return
+		}
+		v.refs = append(v.refs, schema.Ref)
+		v.validate(target, value, path)
+		v.refs = v.refs[:len(v.refs)-1]
+	}
+	if len(schema.Type) > 0 && !schema.allows(value) {
+		v.fail(path, "%s is not of type %s", describe(value), strings.Join(schema.Type, " or "))
+		return
+	}
+	if len(schema.Enum) > 0 && !contains(schema.Enum, value) {
+		v.fail(path, "%s is not one of %s", describe(value), marshal(schema.Enum))
+	}
+	if schema.Const != nil && !reflect.DeepEqual(normalize(schema.Const), value) {
+		v.fail(path, "%s is not %s", describe(value), marshal(schema.Const))
+	}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(schema, value, path)
+	case []interface{}:
+		v.validateArray(schema, value, path)
+	case string:
+		v.validateString(schema, value, path)
+	case float64:
+		if schema.Minimum != nil && value < *schema.Minimum {
+			v.fail(path, "%v is less than the minimum %v", value, *schema.Minimum)
+		}
+		if schema.Maximum != nil && value > *schema.Maximum {
+			v.fail(path, "%v is greater than the maximum %v", value, *schema.Maximum)
+		}
+	}
+	for _, sub := range schema.AllOf {
+		v.validate(sub, value, path)
+	}
+	if len(schema.AnyOf) > 0 && v.matches(schema.AnyOf, value, path) == 0 {
+		v.fail(path, "%s doesn't match any of the anyOf schemas", describe(value))
+	}
+	if len(schema.OneOf) > 0 {
+		if matched := v.matches(schema.OneOf, value, path); matched != 1 {
+			v.fail(path, "%s matches %d of the oneOf schemas, expected exactly one", describe(value), matched)
+		}
+	}
+}
+
+// nested clears the references being expanded while validating the nested values, returning the function
+// restoring them
+func (v *validation) nested() func() {
+	refs := v.refs
+	v.refs = nil
+	return func() {
+		v.refs = refs
+	}
+}
+
+// matches counts the schemas the value is valid against
+func (v *validation) matches(schemas []*Schema, value interface{}, path string) int {
+	matched := 0
+	for _, sub := range schemas {
+		nested := &validation{root: v.root, refs: v.refs}
+		nested.validate(sub, value, path)
+		if len(nested.errors) == 0 {
+			matched++
+		}
+	}
+	return matched
+}
+
+func (v *validation) validateObject(schema *Schema, value map[string]interface{}, path string) {
+	defer v.nested()()
+	for _, required := range schema.Required {
+		if _, ok := value[required]; !ok {
+			v.fail(path, "missing required property %s", required)
+		}
+	}
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var additional *Schema
+	allowed := true
+	if len(schema.AdditionalProperties) > 0 {
+		if err := json.Unmarshal(schema.AdditionalProperties, &allowed); err != nil {
+			allowed = true
+			additional = &Schema{}
+			if err := json.Unmarshal(schema.AdditionalProperties, additional); err != nil {
+				v.fail(path, "invalid additionalProperties: %v", err)
+				return
+			}
+		}
+	}
+	for _, name := range names {
+		property, declared := schema.Properties[name]
+		switch {
+		case declared:
+			v.validate(property, value[name], join(path, name))
+		case !allowed:
+			v.fail(path, "property %s is not allowed", name)
+		case additional != nil:
+			v.validate(additional, value[name], join(path, name))
+		}
+	}
+}
+
+func (v *validation) validateArray(schema *Schema, value []interface{}, path string) {
+	defer v.nested()()
+	if schema.MinItems != nil && len(value) < *schema.MinItems {
+		v.fail(path, "%d items, expected at least %d", len(value), *schema.MinItems)
+	}
+	if schema.MaxItems != nil && len(value) > *schema.MaxItems {
+		v.fail(path, "%d items, expected at most %d", len(value), *schema.MaxItems)
+	}
+	for i, item := range value {
+		v.validate(schema.Items, item, path+"["+strconv.Itoa(i)+"]")
+	}
+}
+
+func (v *validation) validateString(schema *Schema, value string, path string) {
+	length := len([]rune(value))
+	if schema.MinLength != nil && length < *schema.MinLength {
+		v.fail(path, "%q is shorter than %d characters", value, *schema.MinLength)
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		v.fail(path, "%q is longer than %d characters", value, *schema.MaxLength)
+	}
+	if len(schema.Pattern) > 0 {
+		pattern, err := validator.Pattern(schema.Pattern)
+		if err != nil {
+			v.fail(path, "invalid pattern %s: %v", schema.Pattern, err)
+		} else if !pattern.MatchString(value) {
+			v.fail(path, "%q doesn't match the pattern %s", value, schema.Pattern)
+		}
+	}
+}
+
+// allows checks whether the type of the value is one of the types of the schema
+func (s *Schema) allows(value interface{}) bool {
+	for _, t := range s.Type {
+		switch value := value.(type) {
+		case nil:
+			if t == TypeNull {
+				return true
+			}
+		case bool:
+			if t == TypeBoolean {
+				return true
+			}
+		case string:
+			if t == TypeString {
+				return true
+			}
+		case float64:
+			if t == TypeNumber || (t == TypeInteger && value == math.Trunc(value)) {
+				return true
+			}
+		case []interface{}:
+			if t == TypeArray {
+				return true
+			}
+		case map[string]interface{}:
+			if t == TypeObject {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalize converts the value to the types produced by json.Unmarshal, e.g. the integers to float64, so values
+// decoded from YAML or built in Go are compared the same way
+func normalize(value interface{}) interface{} {
+	if decoded(value) {
+		return value
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var result interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return value
+	}
+	return result
+}
+
+// decoded checks whether the value only holds the types produced by json.Unmarshal
+func decoded(value interface{}) bool {
+	switch value := value.(type) {
+	case nil, bool, string, float64:
+		return true
+	case map[string]interface{}:
+		for _, item := range value {
+			if !decoded(item) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		for _, item := range value {
+			if !decoded(item) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func contains(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(normalize(v), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe the value in the messages, e.g. 'the string "a"'
+func describe(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "the boolean " + marshal(value)
+	case string:
+		return "the string " + marshal(value)
+	case float64:
+		return "the number " + marshal(value)
+	case []interface{}:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+func marshal(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func join(path, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return path + "." + name
+}
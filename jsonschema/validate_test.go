@@ -0,0 +1,121 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	schema, err := Parse([]byte(`
+type: object
+required: [id, items]
+additionalProperties: false
+properties:
+  id:
+    type: string
+    pattern: '^[A-Z]+-[0-9]+$'
+  status:
+    enum: [created, shipped]
+  priority:
+    type: integer
+    minimum: 1
+    maximum: 5
+  items:
+    type: array
+    minItems: 1
+    items:
+      $ref: '#/$defs/item'
+  customer:
+    oneOf:
+    - type: string
+      maxLength: 3
+    - $ref: '#/$defs/customer'
+$defs:
+  item:
+    type: object
+    required: [sku]
+    properties:
+      sku:
+        type: string
+        minLength: 2
+      quantity:
+        type: number
+  customer:
+    type: object
+    additionalProperties:
+      type: string
+`))
+	require.NoError(t, err)
+
+	assert.Empty(t, schema.Validate(map[string]interface{}{
+		"id":       "ORD-1",
+		"status":   "created",
+		"priority": 2,
+		"items":    []interface{}{map[string]interface{}{"sku": "ab", "quantity": 1.5}},
+		"customer": map[string]interface{}{"name": "Jane"},
+	}))
+
+	errs := schema.Validate(map[string]interface{}{
+		"id":       "ord-1",
+		"status":   "lost",
+		"priority": 2.5,
+		"items":    []interface{}{map[string]interface{}{"sku": "a"}, map[string]interface{}{"quantity": "1"}},
+		"customer": map[string]interface{}{"name": 1},
+		"extra":    true,
+	})
+	var messages []string
+	for _, e := range errs {
+		messages = append(messages, e.Error())
+	}
+	assert.Equal(t, []string{
+		"customer: an object matches 0 of the oneOf schemas, expected exactly one",
+		"property extra is not allowed",
+		`id: "ord-1" doesn't match the pattern ^[A-Z]+-[0-9]+$`,
+		`items[0].sku: "a" is shorter than 2 characters`,
+		"items[1]: missing required property sku",
+		`items[1].quantity: the string "1" is not of type number`,
+		"priority: the number 2.5 is not of type integer",
+		`status: the string "lost" is not one of ["created","shipped"]`,
+	}, messages)
+
+	errs = schema.Validate([]interface{}{})
+	require.Len(t, errs, 1)
+	assert.Equal(t, ValidationError{Message: "an array is not of type object"}, errs[0])
+
+	errs = schema.Validate(map[string]interface{}{"id": "A-1", "items": []interface{}{}})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "items: 0 items, expected at least 1", errs[0].Error())
+}
+
+func TestValidateRecursive(t *testing.T) {
+	schema, err := Parse([]byte(`{
+  "$ref": "#/definitions/node",
+  "definitions": {
+    "node": {
+      "type": "object",
+      "properties": {"children": {"type": "array", "items": {"$ref": "#/definitions/node"}}, "name": {"type": "string"}}
+    }
+  }
+}`))
+	require.NoError(t, err)
+	assert.Empty(t, schema.Validate(map[string]interface{}{"children": []interface{}{map[string]interface{}{"name": "leaf"}}}))
+	errs := schema.Validate(map[string]interface{}{"children": []interface{}{map[string]interface{}{"name": 1}}})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "children[0].name: the number 1 is not of type string", errs[0].Error())
+}
@@ -0,0 +1,278 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/integrity"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/policy"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultAdmissionFieldPath path of the workflow in the admitted objects, e.g. the flow of the SonataFlow resources
+	DefaultAdmissionFieldPath = "spec.flow"
+
+	admissionAPIVersion = "admission.k8s.io/v1"
+	admissionKind       = "AdmissionReview"
+	// maxAdmissionReviewSize the API server limits the requests to 3MiB
+	maxAdmissionReviewSize = 3 << 20
+)
+
+// fileProperties workflow properties the parser reads from a file, or downloads, when they are a string
+var fileProperties = []string{"events", "functions", "retries", "errors", "timeouts", "secrets", "constants"}
+
+// AdmissionReview admission.k8s.io/v1 AdmissionReview, holding the request sent by the API server to the webhook
+// and the response of the webhook
+type AdmissionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *AdmissionRequest  `json:"request,omitempty"`
+	Response        *AdmissionResponse `json:"response,omitempty"`
+}
+
+// AdmissionRequest ...
+type AdmissionRequest struct {
+	UID       types.UID               `json:"uid"`
+	Kind      metav1.GroupVersionKind `json:"kind"`
+	Name      string                  `json:"name,omitempty"`
+	Namespace string                  `json:"namespace,omitempty"`
+	// Operation CREATE, UPDATE, DELETE or CONNECT
+	Operation string `json:"operation"`
+	// Object admitted object, empty when deleting
+	Object json.RawMessage `json:"object,omitempty"`
+	DryRun *bool           `json:"dryRun,omitempty"`
+}
+
+// AdmissionResponse ...
+type AdmissionResponse struct {
+	UID     types.UID `json:"uid"`
+	Allowed bool      `json:"allowed"`
+	// Result why the object isn't allowed, with a cause per invalid field of the workflow
+	Result   *metav1.Status `json:"status,omitempty"`
+	Warnings []string       `json:"warnings,omitempty"`
+}
+
+// AdmissionOptions options of the admission webhook validating the workflows embedded in the admitted objects
+type AdmissionOptions struct {
+	// FieldPath dot separated path of the workflow in the admitted objects, DefaultAdmissionFieldPath if empty. The
+	// workflow is either an object or a string holding the JSON or YAML definition.
+	FieldPath string
+	// Policies the workflows must follow, besides the SDK validation
+	Policies []policy.Policy
+}
+
+// NewAdmissionHandler returns the http.Handler of a ValidatingAdmissionWebhook validating the workflows embedded in the
+// admitted objects. Workflows are parsed, checked for broken references with integrity.Validate and evaluated
+// against the policies, and the objects whose workflow is invalid are denied with a cause per error, the field of
+// the causes being the path of the error in the object, e.g. 'spec.flow.states[0].transition.nextState'.
+//
+// As in FromSonataFlow, the id and name of the workflows default to the object name. Definitions referencing a file,
+// e.g. 'functions: functions.json', are denied since the webhook can't read the files.
+func NewAdmissionHandler(opts AdmissionOptions) http.Handler {
+	if len(opts.FieldPath) == 0 {
+		opts.FieldPath = DefaultAdmissionFieldPath
+	}
+	return &admissionHandler{opts: opts}
+}
+
+type admissionHandler struct {
+	opts AdmissionOptions
+}
+
+func (h *admissionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "admission reviews must be posted", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxAdmissionReviewSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	review := &AdmissionReview{}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		http.Error(w, "invalid admission review", http.StatusBadRequest)
+		return
+	}
+
+	response := h.admit(r.Context(), review.Request)
+	response.UID = review.Request.UID
+	typeMeta := review.TypeMeta
+	if len(typeMeta.APIVersion) == 0 {
+		typeMeta = metav1.TypeMeta{APIVersion: admissionAPIVersion, Kind: admissionKind}
+	}
+	data, err := json.Marshal(&AdmissionReview{TypeMeta: typeMeta, Response: response})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// admit validates the workflow of the admitted object
+func (h *admissionHandler) admit(ctx context.Context, request *AdmissionRequest) *AdmissionResponse {
+	if request.Operation == "DELETE" || len(request.Object) == 0 {
+		return &AdmissionResponse{Allowed: true}
+	}
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(request.Object, &object); err != nil {
+		return denied(request, fmt.Sprintf("invalid object: %v", err), nil)
+	}
+	causes := h.validate(ctx, object)
+	if len(causes) == 0 {
+		return &AdmissionResponse{Allowed: true}
+	}
+	messages := make([]string, len(causes))
+	for i, cause := range causes {
+		messages[i] = cause.Field + ": " + cause.Message
+	}
+	return denied(request, "invalid workflow: "+strings.Join(messages, ", "), causes)
+}
+
+// validate returns a cause per error found in the workflow of the object
+func (h *admissionHandler) validate(ctx context.Context, object map[string]interface{}) []metav1.StatusCause {
+	fieldPath := h.opts.FieldPath
+	value, ok := fieldValue(object, fieldPath)
+	if !ok {
+		return []metav1.StatusCause{{Type: metav1.CauseTypeFieldValueRequired, Field: fieldPath, Message: "workflow is required"}}
+	}
+	cause := func(path, message string) metav1.StatusCause {
+		field := fieldPath
+		if len(path) > 0 {
+			field += "." + path
+		}
+		return metav1.StatusCause{Type: metav1.CauseTypeFieldValueInvalid, Field: field, Message: message}
+	}
+
+	if source, ok := value.(string); ok {
+		data, err := yaml.YAMLToJSON([]byte(source))
+		if err != nil {
+			return []metav1.StatusCause{cause("", err.Error())}
+		}
+		value = nil
+		if err := json.Unmarshal(data, &value); err != nil {
+			return []metav1.StatusCause{cause("", err.Error())}
+		}
+	}
+	flow, ok := value.(map[string]interface{})
+	if !ok {
+		return []metav1.StatusCause{cause("", "workflow must be an object")}
+	}
+	if causes := fileReferences(flow, cause); len(causes) > 0 {
+		return causes
+	}
+	meta := metav1.ObjectMeta{}
+	if data, err := json.Marshal(object["metadata"]); err == nil {
+		_ = json.Unmarshal(data, &meta)
+	}
+	setFlowDefaults(flow, meta)
+	data, err := json.Marshal(flow)
+	if err != nil {
+		return []metav1.StatusCause{cause("", err.Error())}
+	}
+
+	workflow, err := parser.FromJSONSource(data)
+	if err != nil {
+		var schemaErr *model.SchemaError
+		if errors.As(err, &schemaErr) {
+			var causes []metav1.StatusCause
+			for _, fieldErr := range schemaErr.Errors {
+				// the embedded base workflow is an implementation detail
+				path := strings.TrimPrefix(fieldErr.NameNamespace, "BaseWorkflow.")
+				causes = append(causes, cause(path, fmt.Sprintf("failed on the '%s' validation", fieldErr.Tag)))
+			}
+			sort.Slice(causes, func(i, j int) bool { return causes[i].Field < causes[j].Field })
+			return causes
+		}
+		return []metav1.StatusCause{cause("", err.Error())}
+	}
+	var causes []metav1.StatusCause
+	for _, violation := range integrity.Validate(workflow) {
+		causes = append(causes, cause(violation.Path, violation.Message))
+	}
+	violations, err := policy.Evaluate(ctx, workflow, h.opts.Policies...)
+	if err != nil {
+		return append(causes, cause("", err.Error()))
+	}
+	for _, violation := range violations {
+		causes = append(causes, cause(violation.Path, violation.Message+" ("+violation.Policy+")"))
+	}
+	return causes
+}
+
+// fileReferences returns a cause per definition of the flow referencing a file
+func fileReferences(flow map[string]interface{}, cause func(path, message string) metav1.StatusCause) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	for _, property := range fileProperties {
+		if file, ok := flow[property].(string); ok {
+			causes = append(causes, cause(property, fmt.Sprintf("file reference %s not supported, the definitions must be inlined", file)))
+		}
+	}
+	if auths, ok := flow["auth"].([]interface{}); ok {
+		for i, auth := range auths {
+			if file, ok := auth.(string); ok {
+				causes = append(causes, cause(fmt.Sprintf("auth[%d]", i), fmt.Sprintf("file reference %s not supported, the definitions must be inlined", file)))
+			}
+		}
+	}
+	return causes
+}
+
+// fieldValue returns the value at the dot separated path of the object
+func fieldValue(object map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = object
+	for _, key := range strings.Split(path, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[key]; !ok || value == nil {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func denied(request *AdmissionRequest, message string, causes []metav1.StatusCause) *AdmissionResponse {
+	return &AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: message,
+			Reason:  metav1.StatusReasonInvalid,
+			Code:    http.StatusUnprocessableEntity,
+			Details: &metav1.StatusDetails{
+				Name:   request.Name,
+				Group:  request.Kind.Group,
+				Kind:   request.Kind.Kind,
+				Causes: causes,
+			},
+		},
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const admissionFlow = `{
+  "specVersion": "0.8",
+  "start": "Greet",
+  "functions": [{"name": "greetingFunction", "operation": "file://myapis/greetingapis.json#greeting"}],
+  "states": [{
+    "name": "Greet",
+    "type": "operation",
+    "actions": [{"functionRef": {"refName": "greetingFunction"}}],
+    "transition": "%s"
+  }, {
+    "name": "End",
+    "type": "inject",
+    "data": {"done": true},
+    "end": true
+  }]
+}`
+
+func admissionReview(t *testing.T, handler http.Handler, operation string, object string) (int, *AdmissionResponse) {
+	review := AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &AdmissionRequest{
+			UID:       "705ab4f5-6393-11e8-b7cc-42010a800002",
+			Kind:      metav1.GroupVersionKind{Group: "sonataflow.org", Version: "v1alpha08", Kind: "SonataFlow"},
+			Name:      "greeting",
+			Operation: operation,
+			Object:    json.RawMessage(object),
+		},
+	}
+	body, err := json.Marshal(review)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(body)))
+	if recorder.Code != http.StatusOK {
+		return recorder.Code, nil
+	}
+	response := &AdmissionReview{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), response))
+	assert.Equal(t, review.TypeMeta, response.TypeMeta)
+	require.NotNil(t, response.Response)
+	assert.Equal(t, review.Request.UID, response.Response.UID)
+	return recorder.Code, response.Response
+}
+
+func sonataFlowObject(flow string) string {
+	return `{"apiVersion": "sonataflow.org/v1alpha08", "kind": "SonataFlow", "metadata": {"name": "greeting"}, "spec": {"flow": ` + flow + `}}`
+}
+
+func TestAdmissionHandler(t *testing.T) {
+	handler := NewAdmissionHandler(AdmissionOptions{})
+
+	code, response := admissionReview(t, handler, "CREATE", sonataFlowObject(fmtFlow("End")))
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, response.Allowed)
+
+	code, response = admissionReview(t, handler, "UPDATE", sonataFlowObject(fmtFlow("Missing")))
+	assert.Equal(t, http.StatusOK, code)
+	assert.False(t, response.Allowed)
+	require.NotNil(t, response.Result)
+	assert.Equal(t, metav1.StatusReasonInvalid, response.Result.Reason)
+	assert.Equal(t, int32(http.StatusUnprocessableEntity), response.Result.Code)
+	assert.Equal(t, "SonataFlow", response.Result.Details.Kind)
+	require.Len(t, response.Result.Details.Causes, 1)
+	assert.Equal(t, "spec.flow.states[0].transition.nextState", response.Result.Details.Causes[0].Field)
+	assert.Equal(t, metav1.CauseTypeFieldValueInvalid, response.Result.Details.Causes[0].Type)
+
+	// the workflow can be a YAML string
+	yamlFlow, err := json.Marshal("specVersion: '0.8'\nstart: Wait\nstates:\n- name: Wait\n  type: sleep\n  duration: PT1S\n  end: true\n")
+	require.NoError(t, err)
+	_, response = admissionReview(t, handler, "CREATE", sonataFlowObject(string(yamlFlow)))
+	assert.True(t, response.Allowed)
+
+	_, response = admissionReview(t, handler, "CREATE", sonataFlowObject(`{"specVersion": "0.8", "functions": "/etc/functions.json", "states": []}`))
+	assert.False(t, response.Allowed)
+	require.Len(t, response.Result.Details.Causes, 1)
+	assert.Equal(t, "spec.flow.functions", response.Result.Details.Causes[0].Field)
+
+	_, response = admissionReview(t, handler, "CREATE", `{"metadata": {"name": "greeting"}, "spec": {}}`)
+	assert.False(t, response.Allowed)
+	assert.Equal(t, metav1.CauseTypeFieldValueRequired, response.Result.Details.Causes[0].Type)
+
+	_, response = admissionReview(t, handler, "DELETE", "")
+	assert.True(t, response.Allowed)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader([]byte("{}"))))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/validate", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestAdmissionHandlerOptions(t *testing.T) {
+	noInject := policy.Func(func(ctx context.Context, workflow *model.Workflow) ([]policy.Violation, error) {
+		var violations []policy.Violation
+		for _, state := range workflow.States {
+			if state.GetType() == "inject" {
+				violations = append(violations, policy.Violation{Policy: "no-inject", Path: "states", Message: "inject states are not allowed"})
+			}
+		}
+		return violations, nil
+	})
+	handler := NewAdmissionHandler(AdmissionOptions{FieldPath: "spec.workflow", Policies: []policy.Policy{noInject}})
+
+	_, response := admissionReview(t, handler, "CREATE", `{"metadata": {"name": "greeting"}, "spec": {"workflow": `+fmtFlow("End")+`}}`)
+	assert.False(t, response.Allowed)
+	require.Len(t, response.Result.Details.Causes, 1)
+	assert.Equal(t, "spec.workflow.states", response.Result.Details.Causes[0].Field)
+	assert.Equal(t, "inject states are not allowed (no-inject)", response.Result.Details.Causes[0].Message)
+}
+
+func fmtFlow(nextState string) string {
+	return fmt.Sprintf(admissionFlow, nextState)
+}
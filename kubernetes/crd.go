@@ -0,0 +1,418 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/util/floatstr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	crdAPIVersion = "apiextensions.k8s.io/v1"
+	crdKind       = "CustomResourceDefinition"
+)
+
+// JSONSchemaProps subset of the OpenAPI v3 schema of the CustomResourceDefinition versions
+type JSONSchemaProps struct {
+	Type                 string                     `json:"type,omitempty"`
+	Format               string                     `json:"format,omitempty"`
+	Description          string                     `json:"description,omitempty"`
+	Enum                 []string                   `json:"enum,omitempty"`
+	Properties           map[string]JSONSchemaProps `json:"properties,omitempty"`
+	Required             []string                   `json:"required,omitempty"`
+	AdditionalProperties *JSONSchemaProps           `json:"additionalProperties,omitempty"`
+	Items                *JSONSchemaProps           `json:"items,omitempty"`
+	OneOf                []JSONSchemaProps          `json:"oneOf,omitempty"`
+	MinLength            *int64                     `json:"minLength,omitempty"`
+	MinItems             *int64                     `json:"minItems,omitempty"`
+	// XPreserveUnknownFields keeps the fields not declared by the schema, any value is accepted if the type is empty
+	XPreserveUnknownFields bool `json:"x-kubernetes-preserve-unknown-fields,omitempty"`
+	// XIntOrString accepts either an integer or a string
+	XIntOrString bool `json:"x-kubernetes-int-or-string,omitempty"`
+}
+
+// CustomResourceDefinition ...
+type CustomResourceDefinition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              CustomResourceDefinitionSpec `json:"spec"`
+}
+
+// CustomResourceDefinitionSpec ...
+type CustomResourceDefinitionSpec struct {
+	Group    string                            `json:"group"`
+	Names    CustomResourceDefinitionNames     `json:"names"`
+	Scope    string                            `json:"scope"`
+	Versions []CustomResourceDefinitionVersion `json:"versions"`
+}
+
+// CustomResourceDefinitionNames ...
+type CustomResourceDefinitionNames struct {
+	Plural   string `json:"plural"`
+	Singular string `json:"singular,omitempty"`
+	Kind     string `json:"kind"`
+	ListKind string `json:"listKind,omitempty"`
+}
+
+// CustomResourceDefinitionVersion ...
+type CustomResourceDefinitionVersion struct {
+	Name    string                    `json:"name"`
+	Served  bool                      `json:"served"`
+	Storage bool                      `json:"storage"`
+	Schema  *CustomResourceValidation `json:"schema,omitempty"`
+}
+
+// CustomResourceValidation ...
+type CustomResourceValidation struct {
+	OpenAPIV3Schema *JSONSchemaProps `json:"openAPIV3Schema,omitempty"`
+}
+
+// CRDOptions options to generate the CustomResourceDefinition of a resource embedding a workflow
+type CRDOptions struct {
+	// Group API group of the resource, e.g. 'flows.example.com'
+	Group string
+	// Version API version of the resource, defaults to v1alpha1
+	Version string
+	// Kind of the resource, e.g. 'Flow'
+	Kind string
+	// Plural name of the resource, defaults to the lower case kind followed by an 's'
+	Plural string
+	// Field property of the resource spec holding the workflow, the workflow is the whole spec if empty
+	Field string
+	// Namespaced whether the resources are namespaced, otherwise cluster scoped
+	Namespaced bool
+}
+
+// WorkflowCRD generates the CustomResourceDefinition of a resource embedding a workflow in its spec, so the API server
+// validates the workflows of the resources. See WorkflowSchema.
+func WorkflowCRD(opts CRDOptions) (*CustomResourceDefinition, error) {
+	if len(opts.Group) == 0 || len(opts.Kind) == 0 {
+		return nil, fmt.Errorf("group and kind are required to generate the CustomResourceDefinition")
+	}
+	if len(opts.Version) == 0 {
+		opts.Version = "v1alpha1"
+	}
+	singular := strings.ToLower(opts.Kind)
+	if len(opts.Plural) == 0 {
+		opts.Plural = singular + "s"
+	}
+	scope := "Cluster"
+	if opts.Namespaced {
+		scope = "Namespaced"
+	}
+
+	spec := WorkflowSchema()
+	if len(opts.Field) > 0 {
+		spec = JSONSchemaProps{
+			Type:       "object",
+			Properties: map[string]JSONSchemaProps{opts.Field: spec},
+			Required:   []string{opts.Field},
+		}
+	}
+	return &CustomResourceDefinition{
+		TypeMeta:   metav1.TypeMeta{APIVersion: crdAPIVersion, Kind: crdKind},
+		ObjectMeta: metav1.ObjectMeta{Name: opts.Plural + "." + opts.Group},
+		Spec: CustomResourceDefinitionSpec{
+			Group: opts.Group,
+			Names: CustomResourceDefinitionNames{
+				Plural:   opts.Plural,
+				Singular: singular,
+				Kind:     opts.Kind,
+				ListKind: opts.Kind + "List",
+			},
+			Scope: scope,
+			Versions: []CustomResourceDefinitionVersion{{
+				Name:    opts.Version,
+				Served:  true,
+				Storage: true,
+				Schema: &CustomResourceValidation{OpenAPIV3Schema: &JSONSchemaProps{
+					Type:       "object",
+					Properties: map[string]JSONSchemaProps{"spec": spec},
+					Required:   []string{"spec"},
+				}},
+			}},
+		},
+	}, nil
+}
+
+// union implementations of an interface of the model
+type union struct {
+	// discriminator property telling the implementations apart, along with their required properties
+	discriminator string
+	variants      []variant
+	// oneOf whether the object must match exactly one of the variants, otherwise the variants are only merged
+	oneOf bool
+}
+
+type variant struct {
+	value interface{}
+	// discriminator value of the variant
+	discriminator string
+}
+
+var (
+	stateType          = reflect.TypeOf((*model.State)(nil)).Elem()
+	dataConditionType  = reflect.TypeOf((*model.DataCondition)(nil)).Elem()
+	eventConditionType = reflect.TypeOf((*model.EventCondition)(nil)).Elem()
+	authPropertiesType = reflect.TypeOf((*model.AuthProperties)(nil)).Elem()
+
+	authDefinitionsType = reflect.TypeOf(model.AuthDefinitions{})
+)
+
+var unions = map[reflect.Type]union{
+	stateType: {
+		discriminator: "type",
+		oneOf:         true,
+		variants: []variant{
+			{model.DelayState{}, model.StateTypeDelay},
+			{model.EventState{}, model.StateTypeEvent},
+			{model.OperationState{}, model.StateTypeOperation},
+			{model.ParallelState{}, model.StateTypeParallel},
+			{model.DataBasedSwitchState{}, model.StateTypeSwitch},
+			{model.EventBasedSwitchState{}, model.StateTypeSwitch},
+			{model.ForEachState{}, model.StateTypeForEach},
+			{model.InjectState{}, model.StateTypeInject},
+			{model.CallbackState{}, model.StateTypeCallback},
+			{model.SleepState{}, model.StateTypeSleep},
+		},
+	},
+	dataConditionType: {
+		oneOf:    true,
+		variants: []variant{{value: model.TransitionDataCondition{}}, {value: model.EndDataCondition{}}},
+	},
+	eventConditionType: {
+		oneOf:    true,
+		variants: []variant{{value: model.TransitionEventCondition{}}, {value: model.EndEventCondition{}}},
+	},
+	// the scheme of the auth definition tells the properties apart, a secret alone is valid for every scheme
+	authPropertiesType: {
+		variants: []variant{
+			{value: model.BasicAuthProperties{}},
+			{value: model.BearerAuthProperties{}},
+			{value: model.OAuth2AuthProperties{}},
+		},
+	},
+}
+
+// enums values of the string types of the model
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(model.StateType("")):       {model.StateTypeDelay, model.StateTypeEvent, model.StateTypeOperation, model.StateTypeParallel, model.StateTypeSwitch, model.StateTypeForEach, model.StateTypeInject, model.StateTypeCallback, model.StateTypeSleep},
+	reflect.TypeOf(model.AuthType("")):        {string(model.AuthTypeBasic), string(model.AuthTypeBearer), string(model.AuthTypeOAuth2)},
+	reflect.TypeOf(model.GrantType("")):       {string(model.GrantTypePassword), string(model.GrantTypeClientCredentials), string(model.GrantTypeTokenExchange)},
+	reflect.TypeOf(model.EventKind("")):       {string(model.EventKindConsumed), string(model.EventKindProduced)},
+	reflect.TypeOf(model.FunctionType("")):    {string(model.FunctionTypeREST), string(model.FunctionTypeRPC), string(model.FunctionTypeExpression), string(model.FunctionTypeGraphQL), string(model.FunctionTypeAsyncAPI), string(model.FunctionTypeOData)},
+	reflect.TypeOf(model.ActionMode("")):      {string(model.ActionModeSequential), string(model.ActionModeParallel)},
+	reflect.TypeOf(model.CompletionType("")):  {string(model.CompletionTypeAllOf), string(model.CompletionTypeAtLeast)},
+	reflect.TypeOf(model.ForEachModeType("")): {string(model.ForEachModeTypeSequential), string(model.ForEachModeTypeParallel)},
+}
+
+// anySchema schema of the free-form values, e.g. the data of the inject states
+var anySchema = JSONSchemaProps{XPreserveUnknownFields: true}
+
+// freeFormObject schema of the free-form objects, e.g. the metadata
+var freeFormObject = JSONSchemaProps{Type: "object", XPreserveUnknownFields: true}
+
+// overrides schemas of the types with a custom JSON form
+var overrides = map[reflect.Type]JSONSchemaProps{
+	reflect.TypeOf(intstr.IntOrString{}):       {XIntOrString: true},
+	reflect.TypeOf(floatstr.Float32OrString{}): anySchema,
+	reflect.TypeOf(model.Metadata{}):           freeFormObject,
+	reflect.TypeOf(model.Constants{}):          freeFormObject,
+}
+
+// WorkflowSchema generates the OpenAPI v3 structural schema of the workflows in their long form, as json.Marshal or the
+// serializer without the Shorthand option write them: the properties accepting either a string or an object, such as
+// the transitions, are objects and the definitions are never file references. The states are told apart by a oneOf on
+// their type and, for the switch states, on their conditions.
+func WorkflowSchema() JSONSchemaProps {
+	return schemaOf(reflect.TypeOf(model.Workflow{}))
+}
+
+func schemaPtr(t reflect.Type) *JSONSchemaProps {
+	schema := schemaOf(t)
+	return &schema
+}
+
+func schemaOf(t reflect.Type) JSONSchemaProps {
+	if schema, ok := overrides[t]; ok {
+		return schema
+	}
+	if t == authDefinitionsType {
+		// written as an array, see AuthDefinitions.MarshalJSON
+		return JSONSchemaProps{Type: "array", Items: schemaPtr(reflect.TypeOf(model.Auth{}))}
+	}
+	if u, ok := unions[t]; ok {
+		return unionSchema(u)
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOf(t.Elem())
+	case reflect.Interface:
+		return anySchema
+	case reflect.Struct:
+		schema := JSONSchemaProps{Type: "object", Properties: map[string]JSONSchemaProps{}}
+		structProperties(t, &schema)
+		return schema
+	case reflect.Slice, reflect.Array:
+		return JSONSchemaProps{Type: "array", Items: schemaPtr(t.Elem())}
+	case reflect.Map:
+		return JSONSchemaProps{Type: "object", AdditionalProperties: schemaPtr(t.Elem())}
+	case reflect.String:
+		return JSONSchemaProps{Type: "string", Enum: enums[t]}
+	case reflect.Bool:
+		return JSONSchemaProps{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return JSONSchemaProps{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return JSONSchemaProps{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return JSONSchemaProps{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return JSONSchemaProps{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return JSONSchemaProps{Type: "number", Format: "double"}
+	}
+	panic(fmt.Sprintf("no schema for type %s", t))
+}
+
+// structProperties adds the properties of the struct fields to the schema, the embedded structs are flattened
+func structProperties(t reflect.Type, schema *JSONSchemaProps) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if len(field.PkgPath) > 0 && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && len(name) == 0 && field.Type.Kind() == reflect.Struct {
+			structProperties(field.Type, schema)
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+		property := schemaOf(field.Type)
+		if field.Type.Kind() == reflect.Struct && strings.Contains(tag, ",omitempty") {
+			// the zero value of the struct is written anyway, e.g. the transition of a default condition ending
+			// the workflow, so its properties can't be required
+			property = relax(property)
+		}
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			switch rule {
+			case "required":
+				schema.Required = append(schema.Required, name)
+			case "min=1":
+				one := int64(1)
+				switch property.Type {
+				case "string":
+					property.MinLength = &one
+				case "array":
+					property.MinItems = &one
+				}
+			}
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// unionSchema merges the variants into a single structural schema, the variants then only add the discriminator
+// value and their required properties in a oneOf
+func unionSchema(u union) JSONSchemaProps {
+	var merged *JSONSchemaProps
+	var oneOf []JSONSchemaProps
+	for _, v := range u.variants {
+		schema := schemaOf(reflect.TypeOf(v.value))
+		if merged == nil {
+			merged = &JSONSchemaProps{}
+			*merged = schema
+		} else {
+			*merged = mergeSchemas(*merged, schema)
+		}
+		branch := JSONSchemaProps{Required: schema.Required}
+		if len(v.discriminator) > 0 {
+			branch.Properties = map[string]JSONSchemaProps{u.discriminator: {Enum: []string{v.discriminator}}}
+		}
+		oneOf = append(oneOf, branch)
+	}
+	if u.oneOf {
+		merged.OneOf = oneOf
+	}
+	return *merged
+}
+
+// mergeSchemas merges the properties of the object schemas, only the properties required by both stay required
+func mergeSchemas(a, b JSONSchemaProps) JSONSchemaProps {
+	if a.Type != b.Type {
+		panic(fmt.Sprintf("can't merge schemas of types %s and %s", a.Type, b.Type))
+	}
+	switch a.Type {
+	case "array":
+		items := mergeSchemas(*a.Items, *b.Items)
+		a.Items = &items
+	case "object":
+		if a.Properties == nil {
+			return a
+		}
+		properties := make(map[string]JSONSchemaProps, len(a.Properties))
+		for name, property := range a.Properties {
+			if other, ok := b.Properties[name]; ok {
+				property = mergeSchemas(property, other)
+			}
+			properties[name] = property
+		}
+		for name, property := range b.Properties {
+			if _, ok := properties[name]; !ok {
+				properties[name] = property
+			}
+		}
+		a.Properties = properties
+		var required []string
+		for _, name := range a.Required {
+			for _, other := range b.Required {
+				if name == other {
+					required = append(required, name)
+				}
+			}
+		}
+		sort.Strings(required)
+		a.Required = required
+		a.OneOf = nil
+	}
+	return a
+}
+
+// relax drops the required properties and minimum lengths of the schema and of its properties
+func relax(schema JSONSchemaProps) JSONSchemaProps {
+	schema.Required = nil
+	schema.MinLength = nil
+	if schema.Properties != nil {
+		properties := make(map[string]JSONSchemaProps, len(schema.Properties))
+		for name, property := range schema.Properties {
+			properties[name] = relax(property)
+		}
+		schema.Properties = properties
+	}
+	return schema
+}
@@ -0,0 +1,195 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowSchema(t *testing.T) {
+	schema := WorkflowSchema()
+	assertStructural(t, "workflow", schema, false)
+	assert.Contains(t, schema.Required, "states")
+	states := schema.Properties["states"].Items
+	require.NotNil(t, states)
+	assert.Len(t, states.OneOf, 10)
+	assert.Contains(t, states.Properties, "dataConditions")
+	assert.Contains(t, states.Properties, "eventConditions")
+	assert.Equal(t, []string{"name", "type"}, states.Required)
+
+	files, err := filepath.Glob("../parser/testdata/workflows/*.*")
+	require.NoError(t, err)
+	validated := 0
+	for _, file := range files {
+		workflow, err := parser.FromFile(file)
+		if err != nil {
+			continue
+		}
+		data, err := json.Marshal(workflow)
+		require.NoError(t, err)
+		var value interface{}
+		require.NoError(t, json.Unmarshal(data, &value))
+		assert.Empty(t, validateSchema(schema, "", value), file)
+		validated++
+	}
+	assert.NotZero(t, validated)
+
+	invalid := func(flow string) []string {
+		var value interface{}
+		require.NoError(t, json.Unmarshal([]byte(flow), &value))
+		return validateSchema(schema, "", value)
+	}
+	assert.Empty(t, invalid(`{"id": "a", "name": "a", "specVersion": "0.8", "start": {"stateName": "s"}, "states": [{"name": "s", "type": "inject", "data": {"a": 1}, "end": {"terminate": true}}]}`))
+	assert.NotEmpty(t, invalid(`{"id": "a", "name": "a", "specVersion": "0.8", "start": {"stateName": "s"}, "states": [{"name": "s", "type": "unknown"}]}`))
+	assert.NotEmpty(t, invalid(`{"id": "a", "name": "a", "specVersion": "0.8", "start": {"stateName": "s"}, "states": [{"name": "s", "type": "switch", "defaultCondition": {"end": {}}}]}`))
+	assert.NotEmpty(t, invalid(`{"id": "a", "name": "a", "specVersion": "0.8", "start": {"stateName": "s"}, "states": [{"type": "sleep", "duration": "PT1S"}]}`))
+	assert.NotEmpty(t, invalid(`{"id": "a", "name": "a", "specVersion": "0.8", "start": {"stateName": "s"}, "states": [{"name": "s", "type": "sleep", "duration": "PT1S", "transition": "next"}]}`))
+}
+
+func TestWorkflowCRD(t *testing.T) {
+	crd, err := WorkflowCRD(CRDOptions{Group: "flows.example.com", Kind: "Flow", Field: "flow", Namespaced: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "flows.flows.example.com", crd.Name)
+	assert.Equal(t, CustomResourceDefinitionNames{Plural: "flows", Singular: "flow", Kind: "Flow", ListKind: "FlowList"}, crd.Spec.Names)
+	assert.Equal(t, "Namespaced", crd.Spec.Scope)
+	require.Len(t, crd.Spec.Versions, 1)
+	assert.Equal(t, "v1alpha1", crd.Spec.Versions[0].Name)
+	spec := crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"]
+	assert.Equal(t, []string{"flow"}, spec.Required)
+	assert.Contains(t, spec.Properties["flow"].Properties, "states")
+
+	_, err = WorkflowCRD(CRDOptions{Kind: "Flow"})
+	assert.Error(t, err)
+}
+
+// assertStructural checks the rules of the structural schemas: every node declares a type, unless it preserves the
+// unknown fields or is an int or string, and the oneOf branches only hold value validations
+func assertStructural(t *testing.T, path string, schema JSONSchemaProps, junctor bool) {
+	if junctor {
+		assert.Empty(t, schema.Type, "%s: type in oneOf", path)
+		assert.Nil(t, schema.Items, "%s: items in oneOf", path)
+	} else if !schema.XPreserveUnknownFields && !schema.XIntOrString {
+		assert.NotEmpty(t, schema.Type, "%s: no type", path)
+	}
+	for name, property := range schema.Properties {
+		assertStructural(t, path+"."+name, property, junctor)
+	}
+	if schema.Items != nil {
+		assertStructural(t, path+"[]", *schema.Items, junctor)
+	}
+	if schema.AdditionalProperties != nil {
+		assertStructural(t, path+".*", *schema.AdditionalProperties, junctor)
+	}
+	for i, branch := range schema.OneOf {
+		assertStructural(t, fmt.Sprintf("%s.oneOf[%d]", path, i), branch, true)
+		for name := range branch.Properties {
+			assert.Contains(t, schema.Properties, name, "%s: oneOf property not in the structural schema", path)
+		}
+	}
+}
+
+// validateSchema checks the value against the schema the way the API server does, the unknown fields are reported
+// instead of being pruned
+func validateSchema(schema JSONSchemaProps, path string, value interface{}) []string {
+	if value == nil || schema.XIntOrString || (len(schema.Type) == 0 && schema.XPreserveUnknownFields) {
+		return nil
+	}
+	var errs []string
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, path+": "+fmt.Sprintf(format, args...))
+	}
+	switch schema.Type {
+	case "object":
+		if _, ok := value.(map[string]interface{}); !ok {
+			fail("not an object")
+			return errs
+		}
+	case "array":
+		if _, ok := value.([]interface{}); !ok {
+			fail("not an array")
+			return errs
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			fail("not a string")
+			return errs
+		}
+	case "integer", "number":
+		if _, ok := value.(float64); !ok {
+			fail("not a number")
+			return errs
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("not a boolean")
+			return errs
+		}
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				fail("%s is required", name)
+			}
+		}
+		for name, val := range v {
+			if property, ok := schema.Properties[name]; ok {
+				errs = append(errs, validateSchema(property, path+"."+name, val)...)
+			} else if schema.AdditionalProperties != nil {
+				errs = append(errs, validateSchema(*schema.AdditionalProperties, path+"."+name, val)...)
+			} else if schema.Type == "object" && !schema.XPreserveUnknownFields {
+				fail("unknown field %s", name)
+			}
+		}
+	case []interface{}:
+		for i, val := range v {
+			if schema.Items != nil {
+				errs = append(errs, validateSchema(*schema.Items, fmt.Sprintf("%s[%d]", path, i), val)...)
+			}
+		}
+	case string:
+		if len(schema.Enum) > 0 {
+			found := false
+			for _, e := range schema.Enum {
+				found = found || e == v
+			}
+			if !found {
+				fail("%s not in %v", v, schema.Enum)
+			}
+		}
+		if schema.MinLength != nil && int64(len(v)) < *schema.MinLength {
+			fail("too short")
+		}
+	}
+	if len(schema.OneOf) > 0 {
+		matches := 0
+		for _, branch := range schema.OneOf {
+			if len(validateSchema(branch, path, value)) == 0 {
+				matches++
+			}
+		}
+		if matches != 1 {
+			fail("matches %d oneOf branches", matches)
+		}
+	}
+	return errs
+}
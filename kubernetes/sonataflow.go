@@ -0,0 +1,224 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/serverlessworkflow/sdk-go/v2/manifest"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/registry"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//go:generate go run ../hack/deepcopy kubernetes
+
+const (
+	// SonataFlowAPIVersion API version of the SonataFlow resources
+	SonataFlowAPIVersion = "sonataflow.org/v1alpha08"
+	// SonataFlowKind ...
+	SonataFlowKind = "SonataFlow"
+	// AnnotationDescription SonataFlow annotation holding the workflow description
+	AnnotationDescription = "sonataflow.org/description"
+	// AnnotationVersion SonataFlow annotation holding the workflow version
+	AnnotationVersion = "sonataflow.org/version"
+	// AnnotationProfile SonataFlow annotation holding the deployment profile, e.g. 'dev' or 'preview'
+	AnnotationProfile = "sonataflow.org/profile"
+
+	kindConfigMap = "ConfigMap"
+)
+
+// flowExcludedKeys workflow properties that SonataFlow keeps in the resource metadata instead of the flow
+var flowExcludedKeys = []string{"id", "name", "description", "version"}
+
+// SonataFlow SonataFlow custom resource
+type SonataFlow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              SonataFlowSpec `json:"spec"`
+}
+
+// SonataFlowSpec ...
+type SonataFlowSpec struct {
+	// Flow workflow definition without the properties stored in the resource metadata
+	Flow json.RawMessage `json:"flow"`
+	// Resources files referenced by the workflow, mounted from config maps
+	Resources *SonataFlowResources `json:"resources,omitempty"`
+}
+
+// SonataFlowResources ...
+type SonataFlowResources struct {
+	ConfigMaps []ConfigMapWorkflowResource `json:"configMaps,omitempty"`
+}
+
+// ConfigMapWorkflowResource config map holding files referenced by the workflow
+type ConfigMapWorkflowResource struct {
+	ConfigMap LocalObjectReference `json:"configMap"`
+	// WorkflowPath directory the files are mounted in, relative to the workflow file
+	WorkflowPath string `json:"workflowPath,omitempty"`
+}
+
+// LocalObjectReference reference to an object in the same namespace
+type LocalObjectReference struct {
+	Name string `json:"name"`
+}
+
+// ConfigMap Kubernetes config map
+type ConfigMap struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Data              map[string]string `json:"data,omitempty"`
+}
+
+// SonataFlowOptions options to convert a workflow into a SonataFlow resource
+type SonataFlowOptions struct {
+	// Namespace of the resource
+	Namespace string
+	// Profile deployment profile, see AnnotationProfile
+	Profile string
+	// Resources config maps holding the files referenced by the workflow. See ResourceConfigMaps
+	Resources []ConfigMapWorkflowResource
+}
+
+// ToSonataFlow converts the workflow into a SonataFlow resource. The resource is named after the workflow id, the
+// description and version are stored as annotations and the rest of the definition is embedded in the flow.
+func ToSonataFlow(workflow *model.Workflow, opts SonataFlowOptions) (*SonataFlow, error) {
+	id := workflow.ID
+	if len(id) == 0 {
+		id = workflow.Key
+	}
+	name := manifest.ResourceName(id)
+	if len(name) == 0 {
+		return nil, fmt.Errorf("workflow id is required to name the SonataFlow resource")
+	}
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		return nil, err
+	}
+	flow := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &flow); err != nil {
+		return nil, err
+	}
+	for _, key := range flowExcludedKeys {
+		delete(flow, key)
+	}
+	if data, err = json.Marshal(flow); err != nil {
+		return nil, err
+	}
+
+	annotations := map[string]string{}
+	if len(workflow.Description) > 0 {
+		annotations[AnnotationDescription] = workflow.Description
+	}
+	if len(workflow.Version) > 0 {
+		annotations[AnnotationVersion] = workflow.Version
+	}
+	if len(opts.Profile) > 0 {
+		annotations[AnnotationProfile] = opts.Profile
+	}
+	sonataFlow := &SonataFlow{
+		TypeMeta: metav1.TypeMeta{APIVersion: SonataFlowAPIVersion, Kind: SonataFlowKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   opts.Namespace,
+			Annotations: annotations,
+			Labels:      map[string]string{manifest.LabelWorkflowID: name},
+		},
+		Spec: SonataFlowSpec{Flow: data},
+	}
+	if len(opts.Resources) > 0 {
+		sonataFlow.Spec.Resources = &SonataFlowResources{ConfigMaps: opts.Resources}
+	}
+	return sonataFlow, nil
+}
+
+// FromSonataFlow converts the SonataFlow resource into a workflow. The resource name is used both as the workflow id
+// and as the workflow name, unless the flow declares them.
+func FromSonataFlow(sonataFlow *SonataFlow) (*model.Workflow, error) {
+	flow := map[string]interface{}{}
+	if err := json.Unmarshal(sonataFlow.Spec.Flow, &flow); err != nil {
+		return nil, fmt.Errorf("invalid flow in SonataFlow %s: %w", sonataFlow.Name, err)
+	}
+	setFlowDefaults(flow, sonataFlow.ObjectMeta)
+	data, err := json.Marshal(flow)
+	if err != nil {
+		return nil, err
+	}
+	workflow, err := parser.FromJSONSource(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid flow in SonataFlow %s: %w", sonataFlow.Name, err)
+	}
+	return workflow, nil
+}
+
+// setFlowDefaults sets the id, name, description and version of the flow from the resource metadata, unless the flow
+// declares them
+func setFlowDefaults(flow map[string]interface{}, meta metav1.ObjectMeta) {
+	setDefault := func(key, value string) {
+		if _, ok := flow[key]; !ok && len(value) > 0 {
+			flow[key] = value
+		}
+	}
+	setDefault("id", meta.Name)
+	setDefault("name", meta.Name)
+	setDefault("description", meta.Annotations[AnnotationDescription])
+	setDefault("version", meta.Annotations[AnnotationVersion])
+}
+
+// ResourceConfigMaps generates a config map per directory holding the local files referenced by the workflow, read
+// from baseDir, along with the references to add to SonataFlowOptions.Resources
+func ResourceConfigMaps(workflow *model.Workflow, baseDir, namespace string) ([]*ConfigMap, []ConfigMapWorkflowResource, error) {
+	byDir := map[string]map[string]string{}
+	for _, name := range registry.LocalReferences(workflow) {
+		data, err := ioutil.ReadFile(filepath.Join(baseDir, filepath.FromSlash(name)))
+		if err != nil {
+			return nil, nil, err
+		}
+		dir, file := path.Split(name)
+		dir = path.Clean(dir)
+		if byDir[dir] == nil {
+			byDir[dir] = map[string]string{}
+		}
+		byDir[dir][file] = string(data)
+	}
+	dirs := make([]string, 0, len(byDir))
+	for dir := range byDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var configMaps []*ConfigMap
+	var resources []ConfigMapWorkflowResource
+	for i, dir := range dirs {
+		name := manifest.ResourceName(workflow.ID, "resources", fmt.Sprint(i+1))
+		configMaps = append(configMaps, &ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: kindConfigMap},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{manifest.LabelWorkflowID: manifest.ResourceName(workflow.ID)}},
+			Data:       byDir[dir],
+		})
+		resource := ConfigMapWorkflowResource{ConfigMap: LocalObjectReference{Name: name}}
+		if dir != "." {
+			resource.WorkflowPath = dir
+		}
+		resources = append(resources, resource)
+	}
+	return configMaps, resources, nil
+}
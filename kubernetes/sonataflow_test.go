@@ -0,0 +1,85 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ runtime.Object = &SonataFlow{}
+var _ runtime.Object = &ConfigMap{}
+
+func TestSonataFlow(t *testing.T) {
+	workflow, err := parser.FromFile("../registry/testdata/greetings.sw.yaml")
+	assert.NoError(t, err)
+
+	configMaps, resources, err := ResourceConfigMaps(workflow, "../registry/testdata", "flows")
+	assert.NoError(t, err)
+	assert.Len(t, configMaps, 1)
+	assert.Equal(t, "greeting-resources-1", configMaps[0].Name)
+	assert.Contains(t, configMaps[0].Data, "greetingapis.json")
+	assert.Equal(t, []ConfigMapWorkflowResource{{ConfigMap: LocalObjectReference{Name: "greeting-resources-1"}, WorkflowPath: "myapis"}}, resources)
+
+	sonataFlow, err := ToSonataFlow(workflow, SonataFlowOptions{Namespace: "flows", Profile: "preview", Resources: resources})
+	assert.NoError(t, err)
+	assert.Equal(t, "greeting", sonataFlow.Name)
+	assert.Equal(t, "flows", sonataFlow.Namespace)
+	assert.Equal(t, map[string]string{AnnotationDescription: "Greet Someone", AnnotationVersion: "1.0", AnnotationProfile: "preview"}, sonataFlow.Annotations)
+	flow := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(sonataFlow.Spec.Flow, &flow))
+	assert.NotContains(t, flow, "id")
+	assert.NotContains(t, flow, "version")
+	assert.Contains(t, flow, "states")
+	assert.Len(t, sonataFlow.Spec.Resources.ConfigMaps, 1)
+
+	converted, err := FromSonataFlow(sonataFlow)
+	assert.NoError(t, err)
+	assert.Equal(t, "greeting", converted.ID)
+	assert.Equal(t, "greeting", converted.Name)
+	assert.Equal(t, "Greet Someone", converted.Description)
+	assert.Equal(t, "1.0", converted.Version)
+	assert.Equal(t, workflow.States, converted.States)
+	assert.Equal(t, workflow.Functions, converted.Functions)
+
+	sonataFlow.Spec.Flow = json.RawMessage(`{"start": "Greet"}`)
+	_, err = FromSonataFlow(sonataFlow)
+	assert.Error(t, err)
+
+	workflow.ID = ""
+	_, err = ToSonataFlow(workflow, SonataFlowOptions{})
+	assert.Error(t, err)
+}
+
+func TestSonataFlowDeepCopy(t *testing.T) {
+	workflow, err := parser.FromFile("../registry/testdata/greetings.sw.yaml")
+	assert.NoError(t, err)
+	sonataFlow, err := ToSonataFlow(workflow, SonataFlowOptions{Namespace: "flows"})
+	assert.NoError(t, err)
+
+	copied := sonataFlow.DeepCopyObject().(*SonataFlow)
+	assert.Equal(t, sonataFlow, copied)
+	copied.Annotations[AnnotationVersion] = "2.0"
+	copied.Spec.Flow[0] = ' '
+	assert.Equal(t, "1.0", sonataFlow.Annotations[AnnotationVersion])
+	assert.Equal(t, byte('{'), sonataFlow.Spec.Flow[0])
+
+	var nilFlow *SonataFlow
+	assert.Nil(t, nilFlow.DeepCopyObject())
+}
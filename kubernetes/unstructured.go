@@ -0,0 +1,81 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+)
+
+// ToUnstructured converts the workflow into the unstructured content of a Kubernetes object, as written by its JSON
+// marshalers: the auth definitions are an array, the constants an object, etc. Numbers are int64 or float64, following
+// the conventions of the unstructured objects, so the content can be deep copied and set in unstructured.Unstructured.
+func ToUnstructured(workflow *model.Workflow) (map[string]interface{}, error) {
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		return nil, err
+	}
+	content := map[string]interface{}{}
+	if err := utiljson.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// FromUnstructured converts the unstructured content into a workflow with its JSON unmarshalers, accepting the same
+// forms as the parser, e.g. a transition as the next state name. Unlike the parser, the workflow isn't validated.
+func FromUnstructured(content map[string]interface{}) (*model.Workflow, error) {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	workflow := &model.Workflow{}
+	if err := json.Unmarshal(data, workflow); err != nil {
+		return nil, err
+	}
+	return workflow, nil
+}
+
+// NestedWorkflow returns the workflow at the fields of the unstructured object, e.g. "spec", "flow", and whether it
+// was found
+func NestedWorkflow(obj map[string]interface{}, fields ...string) (*model.Workflow, bool, error) {
+	value, found, err := unstructured.NestedFieldNoCopy(obj, fields...)
+	if !found || err != nil {
+		return nil, found, err
+	}
+	content, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, true, fmt.Errorf("%s accessor error: %v is of the type %T, expected map[string]interface{}", strings.Join(fields, "."), value, value)
+	}
+	workflow, err := FromUnstructured(content)
+	if err != nil {
+		return nil, true, fmt.Errorf("%s: %w", strings.Join(fields, "."), err)
+	}
+	return workflow, true, nil
+}
+
+// SetNestedWorkflow sets the workflow at the fields of the unstructured object, creating the missing parents
+func SetNestedWorkflow(obj map[string]interface{}, workflow *model.Workflow, fields ...string) error {
+	content, err := ToUnstructured(workflow)
+	if err != nil {
+		return err
+	}
+	return unstructured.SetNestedField(obj, content, fields...)
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestUnstructured(t *testing.T) {
+	files, err := filepath.Glob("../parser/testdata/workflows/*.*")
+	require.NoError(t, err)
+	converted := 0
+	for _, file := range files {
+		workflow, err := parser.FromFile(file)
+		if err != nil {
+			continue
+		}
+		content, err := ToUnstructured(workflow)
+		require.NoError(t, err, file)
+		// panics on values unstructured objects don't support
+		assert.Equal(t, content, runtime.DeepCopyJSON(content), file)
+		back, err := FromUnstructured(content)
+		require.NoError(t, err, file)
+		assert.Equal(t, workflow, back, file)
+		converted++
+	}
+	assert.NotZero(t, converted)
+
+	workflow, err := parser.FromFile("../parser/testdata/workflows/applicationrequest.json")
+	require.NoError(t, err)
+	workflow.Retries[0].MaxAttempts = intstr.FromInt(5)
+	content, err := ToUnstructured(workflow)
+	require.NoError(t, err)
+	auth, _, err := unstructured.NestedSlice(content, "auth")
+	assert.NoError(t, err)
+	assert.Len(t, auth, 1)
+	maxAttempts, _, err := unstructured.NestedFieldNoCopy(content, "retries")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), maxAttempts.([]interface{})[0].(map[string]interface{})["maxAttempts"])
+
+	// the short forms are accepted
+	workflow, err = FromUnstructured(map[string]interface{}{
+		"id":          "greeting",
+		"specVersion": "0.8",
+		"start":       "Wait",
+		"states":      []interface{}{map[string]interface{}{"name": "Wait", "type": "sleep", "duration": "PT1S", "transition": "Wait"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Wait", workflow.Start.StateName)
+	assert.Equal(t, "Wait", workflow.States[0].GetTransition().NextState)
+
+	_, err = FromUnstructured(map[string]interface{}{"states": "none"})
+	assert.Error(t, err)
+}
+
+func TestNestedWorkflow(t *testing.T) {
+	workflow, err := parser.FromFile("../parser/testdata/workflows/greetings.sw.json")
+	require.NoError(t, err)
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": SonataFlowAPIVersion, "kind": SonataFlowKind}}
+	require.NoError(t, SetNestedWorkflow(obj.Object, workflow, "spec", "flow"))
+
+	nested, found, err := NestedWorkflow(obj.DeepCopy().Object, "spec", "flow")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, workflow, nested)
+
+	_, found, err = NestedWorkflow(obj.Object, "spec", "missing")
+	assert.NoError(t, err)
+	assert.False(t, found)
+	_, found, err = NestedWorkflow(obj.Object, "kind")
+	assert.Error(t, err)
+	assert.True(t, found)
+}
@@ -0,0 +1,121 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/serverlessworkflow/sdk-go/v2/validator"
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigFileName name of the lint configuration file
+const ConfigFileName = ".swlint.yaml"
+
+// Config lint configuration, usually loaded from a .swlint.yaml file
+type Config struct {
+	// Disabled names of the rules that are not run
+	Disabled []string `json:"disabled,omitempty"`
+	// Severity overrides the default severity of the rules, by rule name
+	Severity map[string]Severity `json:"severity,omitempty"`
+	// Naming regular expressions the names must match
+	Naming NamingConfig `json:"naming,omitempty"`
+	// MaxStates maximum number of states per workflow
+	MaxStates int `json:"maxStates,omitempty"`
+	// RequiredMetadata keys the workflow metadata must define
+	RequiredMetadata []string `json:"requiredMetadata,omitempty"`
+}
+
+// NamingConfig regular expressions the names of the definitions must match. Empty expressions aren't checked.
+type NamingConfig struct {
+	States    string `json:"states,omitempty"`
+	Functions string `json:"functions,omitempty"`
+	Events    string `json:"events,omitempty"`
+}
+
+const (
+	defaultNamePattern = "^[A-Za-z][A-Za-z0-9_.-]*$"
+	defaultMaxStates   = 50
+)
+
+// DefaultConfig configuration used when there is no configuration file
+func DefaultConfig() *Config {
+	return &Config{
+		Naming:    NamingConfig{States: defaultNamePattern, Functions: defaultNamePattern, Events: defaultNamePattern},
+		MaxStates: defaultMaxStates,
+	}
+}
+
+// LoadConfig loads the configuration file in the given path. Unset properties take their default value.
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	config := DefaultConfig()
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("invalid lint configuration %s: %w", path, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid lint configuration %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// FindConfig looks for the configuration file in the given directory and its parents. Returns an empty path if
+// there is none.
+func FindConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, ConfigFileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+func (c *Config) validate() error {
+	for _, pattern := range []string{c.Naming.States, c.Naming.Functions, c.Naming.Events} {
+		if _, err := validator.Pattern(pattern); err != nil {
+			return err
+		}
+	}
+	for rule, severity := range c.Severity {
+		if severity != SeverityError && severity != SeverityWarning {
+			return fmt.Errorf("severity %s of rule %s must be %s or %s", severity, rule, SeverityError, SeverityWarning)
+		}
+	}
+	return nil
+}
+
+func (c *Config) disabled(rule string) bool {
+	for _, disabled := range c.Disabled {
+		if disabled == rule {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/serverlessworkflow/sdk-go/v2/message"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// Severity ...
+type Severity string
+
+const (
+	// SeverityError issues that must be fixed
+	SeverityError Severity = "error"
+	// SeverityWarning issues that should be fixed
+	SeverityWarning Severity = "warning"
+)
+
+// Issue rule violation found in a workflow
+type Issue struct {
+	Rule     string
+	Severity Severity
+	// Path JSON path of the violating property, e.g. 'states[0].name'
+	Path string
+	// Message in English
+	Message string
+	// Args arguments of the message, rendered with the template of the message ID of the rule, see Localize
+	Args map[string]interface{}
+}
+
+// String ...
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", i.Path, i.Severity, i.Message, i.Rule)
+}
+
+// Localize returns the message rendered with the translator, e.g. a message.Catalog of the language of the workflow
+// authors, from the template of the ID lint.<rule>. The messages without template, e.g. of the custom rules, are
+// returned in English.
+func (i Issue) Localize(t message.Translator) string {
+	if rendered, ok := (message.Message{ID: message.Lint + "." + i.Rule, Args: i.Args}).Render(t); ok {
+		return rendered
+	}
+	return i.Message
+}
+
+// newIssue returns the issue of the rule at the path, its message rendered in English with the arguments
+func newIssue(rule, path string, args map[string]interface{}) Issue {
+	m := message.Message{ID: message.Lint + "." + rule, Args: args}
+	return Issue{Path: path, Message: m.String(), Args: args}
+}
+
+// Rule lint rule
+type Rule struct {
+	Name        string
+	Description string
+	// Severity default severity of the issues reported by the rule
+	Severity Severity
+	// Check reports the violations of the rule. The issue severity and rule name are set by Lint.
+	Check func(workflow *model.Workflow, config *Config) []Issue
+}
+
+var rules []*Rule
+
+// Register adds a rule to the ones run by Lint
+func Register(rule *Rule) {
+	rules = append(rules, rule)
+}
+
+// Rules returns the registered rules
+func Rules() []*Rule {
+	return append([]*Rule{}, rules...)
+}
+
+// Lint runs the enabled rules against the workflow. If config is nil, DefaultConfig is used.
+// Issues are sorted by path.
+func Lint(workflow *model.Workflow, config *Config) []Issue {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	var issues []Issue
+	for _, rule := range rules {
+		if config.disabled(rule.Name) {
+			continue
+		}
+		severity := rule.Severity
+		if override, ok := config.Severity[rule.Name]; ok {
+			severity = override
+		}
+		for _, issue := range rule.Check(workflow, config) {
+			issue.Rule = rule.Name
+			issue.Severity = severity
+			issues = append(issues, issue)
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Path < issues[j].Path })
+	return issues
+}
+
+// HasErrors checks whether any of the issues has the error severity
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func testWorkflow() *model.Workflow {
+	return &model.Workflow{
+		BaseWorkflow: model.BaseWorkflow{
+			Metadata: model.Metadata{"owner": "team"},
+			Auth: model.AuthDefinitions{Defs: []model.Auth{
+				{Name: "basic", Scheme: model.AuthTypeBasic, Properties: &model.BasicAuthProperties{Username: "user", Password: "plain"}},
+				{Name: "bearer", Scheme: model.AuthTypeBearer, Properties: &model.BearerAuthProperties{Token: "${ $SECRETS.token }"}},
+			}},
+		},
+		Functions: []model.Function{{Name: "store order", Operation: "http://orders#store"}, {Name: "total", Type: model.FunctionTypeExpression}},
+		Events:    []model.Event{{Name: "created", Type: "created"}},
+		States: []model.State{
+			&model.OperationState{
+				BaseState: model.BaseState{Name: "Store"},
+				Actions: []model.Action{
+					{FunctionRef: model.FunctionRef{RefName: "store order", Arguments: map[string]interface{}{
+						"order":   "${ .order }",
+						"headers": map[string]interface{}{"apiKey": "1234"},
+					}}},
+					{FunctionRef: model.FunctionRef{RefName: "total"}},
+					{FunctionRef: model.FunctionRef{RefName: "store order"}, RetryRef: "default"},
+				},
+			},
+		},
+	}
+}
+
+func TestLint(t *testing.T) {
+	issues := Lint(testWorkflow(), nil)
+	var messages []string
+	for _, issue := range issues {
+		messages = append(messages, issue.String())
+	}
+	assert.Equal(t, []string{
+		"auth[0].properties.password: error: inline credential, use a workflow secret instead (no-inline-secrets)",
+		`functions[0].name: warning: function name "store order" does not match ^[A-Za-z][A-Za-z0-9_.-]*$ (naming)`,
+		"states[0].actions[0].functionRef.arguments.headers.apiKey: error: inline credential, use a workflow secret instead (no-inline-secrets)",
+		"states[0].actions[0].retryRef: warning: action invoking REST function store order has no retry policy (rest-retries)",
+	}, messages)
+	assert.True(t, HasErrors(issues))
+
+	workflow := testWorkflow()
+	workflow.AutoRetries = true
+	for _, issue := range Lint(workflow, nil) {
+		assert.NotEqual(t, RuleRESTRetries, issue.Rule)
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lint")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	nested := filepath.Join(dir, "workflows")
+	assert.NoError(t, os.Mkdir(nested, 0755))
+
+	path, err := FindConfig(nested)
+	assert.NoError(t, err)
+	assert.Empty(t, path)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, ConfigFileName), []byte(`
+disabled: [no-inline-secrets, naming]
+severity:
+  rest-retries: error
+maxStates: 0
+requiredMetadata: [owner, domain]
+`), 0600))
+	path, err = FindConfig(nested)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, ConfigFileName), path)
+
+	config, err := LoadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultNamePattern, config.Naming.States)
+
+	issues := Lint(testWorkflow(), config)
+	assert.Equal(t, []Issue{
+		{Rule: RuleRequiredMetadata, Severity: SeverityError, Path: "metadata", Message: "metadata key domain is required", Args: map[string]interface{}{"key": "domain"}},
+		{Rule: RuleRESTRetries, Severity: SeverityError, Path: "states[0].actions[0].retryRef", Message: "action invoking REST function store order has no retry policy",
+			Args: map[string]interface{}{"function": "store order"}},
+	}, issues)
+
+	config = DefaultConfig()
+	config.MaxStates = 0
+	assert.Empty(t, checkMaxStates(testWorkflow(), config))
+	config.MaxStates = 1
+	workflow := testWorkflow()
+	workflow.States = append(workflow.States, &model.OperationState{BaseState: model.BaseState{Name: "Other"}})
+	assert.Equal(t, []Issue{{Path: "states", Message: "workflow defines 2 states, the maximum is 1", Args: map[string]interface{}{"count": 2, "max": 1}}},
+		checkMaxStates(workflow, config))
+
+	for _, invalid := range []string{"naming: {states: '['}", "severity: {naming: fatal}", "unknown: true"} {
+		assert.NoError(t, ioutil.WriteFile(path, []byte(invalid), 0600))
+		_, err = LoadConfig(path)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/validator"
+)
+
+const (
+	// RuleNaming names of the states, functions and events must match the configured expressions
+	RuleNaming = "naming"
+	// RuleMaxStates workflows can't define more states than the configured maximum
+	RuleMaxStates = "max-states"
+	// RuleRequiredMetadata workflow metadata must define the configured keys
+	RuleRequiredMetadata = "required-metadata"
+	// RuleNoInlineSecrets credentials must reference workflow secrets instead of being written in the definition
+	RuleNoInlineSecrets = "no-inline-secrets"
+	// RuleRESTRetries actions invoking REST functions must define a retry policy
+	RuleRESTRetries = "rest-retries"
+)
+
+var secretKey = regexp.MustCompile(`(?i)(password|passwd|secret|token|api[-_]?key|credential)`)
+
+func init() {
+	Register(&Rule{
+		Name:        RuleNaming,
+		Description: "names of the states, functions and events must match the configured expressions",
+		Severity:    SeverityWarning,
+		Check:       checkNaming,
+	})
+	Register(&Rule{
+		Name:        RuleMaxStates,
+		Description: "workflows can't define more states than the configured maximum",
+		Severity:    SeverityWarning,
+		Check:       checkMaxStates,
+	})
+	Register(&Rule{
+		Name:        RuleRequiredMetadata,
+		Description: "workflow metadata must define the configured keys",
+		Severity:    SeverityError,
+		Check:       checkRequiredMetadata,
+	})
+	Register(&Rule{
+		Name:        RuleNoInlineSecrets,
+		Description: "credentials must reference workflow secrets instead of being written in the definition",
+		Severity:    SeverityError,
+		Check:       checkNoInlineSecrets,
+	})
+	Register(&Rule{
+		Name:        RuleRESTRetries,
+		Description: "actions invoking REST functions must define a retry policy",
+		Severity:    SeverityWarning,
+		Check:       checkRESTRetries,
+	})
+}
+
+func checkNaming(workflow *model.Workflow, config *Config) []Issue {
+	var issues []Issue
+	check := func(pattern, path, kind, name string) {
+		if len(pattern) == 0 {
+			return
+		}
+		// the configuration is validated when loaded, the expressions are compiled once then
+		if compiled, err := validator.Pattern(pattern); err == nil && !compiled.MatchString(name) {
+			issues = append(issues, newIssue(RuleNaming, path, map[string]interface{}{"kind": kind, "name": name, "pattern": pattern}))
+		}
+	}
+	for i, state := range workflow.States {
+		check(config.Naming.States, fmt.Sprintf("states[%d].name", i), "state", state.GetName())
+	}
+	for i, function := range workflow.Functions {
+		check(config.Naming.Functions, fmt.Sprintf("functions[%d].name", i), "function", function.Name)
+	}
+	for i, event := range workflow.Events {
+		check(config.Naming.Events, fmt.Sprintf("events[%d].name", i), "event", event.Name)
+	}
+	return issues
+}
+
+func checkMaxStates(workflow *model.Workflow, config *Config) []Issue {
+	if config.MaxStates <= 0 || len(workflow.States) <= config.MaxStates {
+		return nil
+	}
+	return []Issue{newIssue(RuleMaxStates, "states", map[string]interface{}{"count": len(workflow.States), "max": config.MaxStates})}
+}
+
+func checkRequiredMetadata(workflow *model.Workflow, config *Config) []Issue {
+	var issues []Issue
+	for _, key := range config.RequiredMetadata {
+		if _, ok := workflow.Metadata[key]; !ok {
+			issues = append(issues, newIssue(RuleRequiredMetadata, "metadata", map[string]interface{}{"key": key}))
+		}
+	}
+	return issues
+}
+
+func checkNoInlineSecrets(workflow *model.Workflow, _ *Config) []Issue {
+	var issues []Issue
+	check := func(path, value string) {
+		if len(value) > 0 && !isExpression(value) {
+			issues = append(issues, newIssue(RuleNoInlineSecrets, path, nil))
+		}
+	}
+	for i, auth := range workflow.Auth.Defs {
+		path := fmt.Sprintf("auth[%d].properties", i)
+		switch properties := auth.Properties.(type) {
+		case *model.BasicAuthProperties:
+			check(path+".password", properties.Password)
+		case *model.BearerAuthProperties:
+			check(path+".token", properties.Token)
+		case *model.OAuth2AuthProperties:
+			check(path+".clientSecret", properties.ClientSecret)
+			check(path+".password", properties.Password)
+			check(path+".subjectToken", properties.SubjectToken)
+		}
+	}
+	forEachAction(workflow, func(path string, action *model.Action) {
+		checkArguments(path+".functionRef.arguments", action.FunctionRef.Arguments, check)
+	})
+	return issues
+}
+
+// checkArguments looks for string values stored under keys that usually hold credentials
+func checkArguments(path string, arguments map[string]interface{}, check func(path, value string)) {
+	keys := make([]string, 0, len(arguments))
+	for key := range arguments {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch value := arguments[key].(type) {
+		case string:
+			if secretKey.MatchString(key) {
+				check(path+"."+key, value)
+			}
+		case map[string]interface{}:
+			checkArguments(path+"."+key, value, check)
+		}
+	}
+}
+
+// isExpression checks whether the value is a workflow expression, e.g. '${ $SECRETS.password }', or references a secret
+func isExpression(value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.HasPrefix(value, "${") || strings.HasPrefix(value, "$SECRETS")
+}
+
+func checkRESTRetries(workflow *model.Workflow, _ *Config) []Issue {
+	if workflow.AutoRetries {
+		return nil
+	}
+	rest := map[string]bool{}
+	for _, function := range workflow.Functions {
+		if function.Type == model.FunctionTypeREST || len(function.Type) == 0 {
+			rest[function.Name] = true
+		}
+	}
+	var issues []Issue
+	forEachAction(workflow, func(path string, action *model.Action) {
+		if rest[action.FunctionRef.RefName] && len(action.RetryRef) == 0 {
+			issues = append(issues, newIssue(RuleRESTRetries, path+".retryRef", map[string]interface{}{"function": action.FunctionRef.RefName}))
+		}
+	})
+	return issues
+}
+
+// forEachAction calls fn with every action defined in the workflow states and its path
+func forEachAction(workflow *model.Workflow, fn func(path string, action *model.Action)) {
+	each := func(path string, actions []model.Action) {
+		for i := range actions {
+			fn(fmt.Sprintf("%s[%d]", path, i), &actions[i])
+		}
+	}
+	for i, state := range workflow.States {
+		path := fmt.Sprintf("states[%d]", i)
+		switch s := state.(type) {
+		case *model.OperationState:
+			each(path+".actions", s.Actions)
+		case *model.ForEachState:
+			each(path+".actions", s.Actions)
+		case *model.ParallelState:
+			for j, branch := range s.Branches {
+				each(fmt.Sprintf("%s.branches[%d].actions", path, j), branch.Actions)
+			}
+		case *model.EventState:
+			for j, onEvent := range s.OnEvents {
+				each(fmt.Sprintf("%s.onEvents[%d].actions", path, j), onEvent.Actions)
+			}
+		case *model.CallbackState:
+			fn(path+".action", &s.Action)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging lets the services embedding the SDK see what the parser and the validator do, e.g. the references
+// they resolve, the defaults they apply and the validations they skip. The events are logged at the debug level to a
+// Logger, e.g. a log/slog logger through the Slog adapter, and discarded by default.
+package logging
+
+// Names of the attributes of the events
+const (
+	// Kind kind of the definitions resolved, e.g. functions
+	Kind = "kind"
+	// Ref reference resolved, e.g. the path of the file holding the definitions
+	Ref = "ref"
+	// Pointer JSON pointer of the property of the workflow, see RFC 6901
+	Pointer = "pointer"
+	// Value value of the property
+	Value = "value"
+	// Type Go type of the value validated
+	Type = "type"
+	// Fingerprint fingerprint of the value validated, see validator.Fingerprint
+	Fingerprint = "fingerprint"
+)
+
+// Logger receives the debug events. The implementations must be safe for concurrent use, and cheap: the events are
+// logged while parsing and validating.
+type Logger interface {
+	// Debug logs the event of the given message with its attributes, alternating their names and values like the
+	// log/slog loggers
+	Debug(msg string, args ...interface{})
+}
+
+// Func Logger calling the function with the events
+type Func func(msg string, args ...interface{})
+
+// Debug ...
+func (f Func) Debug(msg string, args ...interface{}) {
+	f(msg, args...)
+}
+
+type nop struct{}
+
+func (nop) Debug(string, ...interface{}) {}
+
+// Nop Logger discarding the events, like the nil loggers of the parser and the validator
+var Nop Logger = nop{}
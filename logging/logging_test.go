@@ -0,0 +1,32 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunc(t *testing.T) {
+	var logged []interface{}
+	var logger Logger = Func(func(msg string, args ...interface{}) {
+		logged = append(append(logged, msg), args...)
+	})
+	logger.Debug("default applied", Pointer, "/expressionLang", Value, "jq")
+	assert.Equal(t, []interface{}{"default applied", Pointer, "/expressionLang", Value, "jq"}, logged)
+
+	Nop.Debug("default applied", Pointer, "/expressionLang")
+}
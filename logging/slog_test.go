@@ -0,0 +1,38 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+// +build go1.21
+
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := Slog(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	logger.Debug("default applied", Pointer, "/expressionLang", Value, "jq")
+	assert.Contains(t, buf.String(), `level=DEBUG msg="default applied" pointer=/expressionLang value=jq`)
+
+	buf.Reset()
+	Slog(slog.New(slog.NewTextHandler(&buf, nil))).Debug("default applied")
+	assert.Empty(t, buf.String())
+	assert.NotNil(t, Slog(nil))
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+// propertyDocs documentation shown when hovering the workflow properties, from the specification
+var propertyDocs = map[string]string{
+	"id":                  "Workflow unique identifier.",
+	"key":                 "Domain-specific workflow identifier.",
+	"name":                "Unique name of the definition.",
+	"description":         "Description of the definition.",
+	"version":             "Workflow version.",
+	"specVersion":         "Serverless Workflow schema version.",
+	"annotations":         "List of helpful terms describing the workflow intended purpose, subject areas, or other important qualities.",
+	"dataInputSchema":     "URI of the JSON Schema used to validate the workflow data input.",
+	"expressionLang":      "Expression language used for the workflow expressions. Default is `jq`.",
+	"keepActive":          "If `true`, workflow instances are not terminated when there are no active execution paths.",
+	"autoRetries":         "If `true`, actions are automatically retried on unchecked errors. Default is `false`.",
+	"metadata":            "Custom information shared with the runtime.",
+	"secrets":             "Names of the secrets accessible in the workflow expressions, e.g. `$SECRETS.name`.",
+	"constants":           "Static, immutable data available to the workflow expressions, e.g. `$CONSTANTS.name`.",
+	"timeouts":            "Timeouts of the workflow, states, actions, branches and event consumption, as ISO 8601 durations.",
+	"errors":              "Declarations of the errors that can be handled by the states `onErrors`.",
+	"auth":                "Authentication definitions used to access the resources defined in the function operations.",
+	"start":               "Name of the workflow starting state, or an object with the state name and schedule.",
+	"stateName":           "Name of the state the workflow starts from.",
+	"schedule":            "Recurring time intervals or cron expressions at which workflow instances are created.",
+	"states":              "Workflow states.",
+	"functions":           "Function definitions, the services invoked by the state actions.",
+	"events":              "Event definitions, the CloudEvents consumed or produced by the workflow.",
+	"retries":             "Retry strategies that can be referenced by the actions `retryRef`.",
+	"type":                "Type of the definition.",
+	"transition":          "Next state to transition to, by name or as an object with `nextState`.",
+	"nextState":           "Name of the state to transition to.",
+	"end":                 "If `true`, or an end definition, the workflow ends after the state.",
+	"terminate":           "If `true`, completes all execution flows of the workflow instance.",
+	"compensate":          "If `true`, triggers the workflow compensation.",
+	"produceEvents":       "Events produced when the workflow ends.",
+	"continueAs":          "Starts a new instance of the given workflow when this one ends.",
+	"compensatedBy":       "Name of the state responsible for the compensation of this state.",
+	"usedForCompensation": "If `true`, the state is used to compensate another state. Default is `false`.",
+	"onErrors":            "Error handling definitions of the state.",
+	"errorRef":            "Name of the workflow error definition handled.",
+	"errorRefs":           "Names of the workflow error definitions handled.",
+	"stateDataFilter":     "Expressions filtering the state data input and output.",
+	"input":               "Expression selecting the state data input.",
+	"output":              "Expression selecting the state data output.",
+	"actions":             "Actions invoked by the state.",
+	"actionMode":          "Whether the actions run `sequential`ly or in `parallel`. Default is `sequential`.",
+	"functionRef":         "Function invoked by the action, by name or as an object with the function name and arguments.",
+	"refName":             "Name of the referenced function.",
+	"arguments":           "Arguments passed to the function.",
+	"eventRef":            "Event referenced by name.",
+	"triggerEventRef":     "Name of the produced event triggering the action.",
+	"resultEventRef":      "Name of the consumed event carrying the action results.",
+	"subFlowRef":          "Workflow invoked by the action, by id or as an object with the workflow id and version.",
+	"retryRef":            "Name of the retry definition of the action. If not defined the default retry policy is assumed.",
+	"retryableErrors":     "Names of the errors the action is retried on.",
+	"nonRetryableErrors":  "Names of the errors the action is not retried on.",
+	"actionDataFilter":    "Expressions filtering the action data input and results.",
+	"sleep":               "Durations to sleep before and after the action, as ISO 8601 durations.",
+	"operation":           "Function operation, depending on the function type, e.g. an OpenAPI document URI and operation id.",
+	"source":              "CloudEvent source.",
+	"kind":                "Whether the event is `consumed` or `produced` by the workflow. Default is `consumed`.",
+	"dataOnly":            "If `true`, only the event payload is accessible to the consuming states.",
+	"correlation":         "CloudEvent context attributes used to correlate the event with the workflow instance.",
+	"onEvents":            "Events the state waits for and the actions invoked when they are consumed.",
+	"eventRefs":           "Names of the consumed events.",
+	"exclusive":           "If `true`, consuming any of the events starts the actions, otherwise all of them must be consumed. Default is `true`.",
+	"eventDataFilter":     "Expressions filtering the event data.",
+	"dataConditions":      "Conditions evaluated against the state data, the first one true is taken.",
+	"eventConditions":     "Conditions waiting for events, the first event consumed is taken.",
+	"defaultCondition":    "Transition or end taken if no condition is met.",
+	"condition":           "Expression evaluated against the state data.",
+	"duration":            "Time to sleep, as an ISO 8601 duration.",
+	"data":                "State data to inject, or expression selecting the event data.",
+	"branches":            "Branches executed in parallel.",
+	"completionType":      "Whether `allOf` the branches must complete or `atLeast` `numCompleted`. Default is `allOf`.",
+	"numCompleted":        "Number of branches that must complete when the completion type is `atLeast`.",
+	"inputCollection":     "Expression selecting the collection to iterate over.",
+	"outputCollection":    "Expression selecting where the results of the iterations are stored.",
+	"iterationParam":      "Name of the iteration parameter referencing the current element of the collection.",
+	"batchSize":           "Number of iterations executed in parallel.",
+	"action":              "Action invoked by the callback state.",
+	"maxAttempts":         "Maximum number of retry attempts.",
+	"delay":               "Time delay between retry attempts, as an ISO 8601 duration.",
+	"multiplier":          "Multiplier of the delay between retry attempts.",
+	"maxDelay":            "Maximum time delay between retry attempts, as an ISO 8601 duration.",
+	"increment":           "Time added to the delay between retry attempts, as an ISO 8601 duration.",
+	"jitter":              "Random amount of time added to or subtracted from the delay between retry attempts.",
+}
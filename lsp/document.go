@@ -0,0 +1,228 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/serverlessworkflow/sdk-go/v2/integrity"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"gopkg.in/yaml.v3"
+)
+
+const diagnosticSource = "serverlessworkflow"
+
+var errorLine = regexp.MustCompile(`line (\d+)`)
+
+// entry object member or array item of the document
+type entry struct {
+	path string
+	// key nil for array items
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// document open text document. JSON documents are parsed as YAML, which keeps the positions of the nodes.
+type document struct {
+	uri     string
+	version int
+	text    string
+	json    bool
+	// entries of the last text that could be parsed, kept to complete references while the text is being edited
+	entries []entry
+	byPath  map[string]*entry
+	// parseErr error parsing the current text as YAML
+	parseErr error
+	// incremental parser of the workflow, decoding only the parts changed by the edits
+	incremental *parser.Incremental
+	workflow    *model.Workflow
+	err         error
+}
+
+func newDocument(uri string, version int, text string) *document {
+	d := &document{uri: uri, json: strings.HasSuffix(strings.ToLower(uri), ".json")}
+	d.incremental = parser.NewIncremental(!d.json)
+	d.update(version, text)
+	return d
+}
+
+func (d *document) update(version int, text string) {
+	d.version = version
+	d.workflow, d.err = d.incremental.Update(parser.DiffEdit([]byte(d.text), []byte(text)))
+	d.text = text
+	var root yaml.Node
+	if d.parseErr = yaml.Unmarshal([]byte(text), &root); d.parseErr != nil {
+		return
+	}
+	d.entries = nil
+	d.byPath = map[string]*entry{}
+	if len(root.Content) > 0 {
+		d.index("", root.Content[0])
+	}
+	for i := range d.entries {
+		d.byPath[d.entries[i].path] = &d.entries[i]
+	}
+}
+
+func (d *document) index(path string, node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPath := key.Value
+			if len(path) > 0 {
+				childPath = path + "." + key.Value
+			}
+			d.entries = append(d.entries, entry{path: childPath, key: key, value: value})
+			d.index(childPath, value)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			childPath := fmt.Sprintf("%s[%d]", path, i)
+			d.entries = append(d.entries, entry{path: childPath, value: item})
+			d.index(childPath, item)
+		}
+	}
+}
+
+// diagnostics parses and validates the document
+func (d *document) diagnostics() []Diagnostic {
+	if d.parseErr != nil {
+		return []Diagnostic{d.diagnostic(d.errorRange(d.parseErr), d.parseErr.Error())}
+	}
+	var diagnostics []Diagnostic
+	var schemaErr *model.SchemaError
+	switch {
+	case d.err == nil:
+		for _, violation := range integrity.Validate(d.workflow) {
+			diagnostics = append(diagnostics, d.diagnostic(d.pathRange(violation.Path), violation.Message))
+		}
+	case errors.As(d.err, &schemaErr):
+		for _, fieldErr := range schemaErr.Errors {
+			path := namespacePath(fieldErr.NameNamespace)
+			diagnostics = append(diagnostics, d.diagnostic(d.pathRange(path), fmt.Sprintf("%s failed on the '%s' validation", path, fieldErr.Tag)))
+		}
+		sort.Slice(diagnostics, func(i, j int) bool { return diagnostics[i].Message < diagnostics[j].Message })
+	default:
+		diagnostics = append(diagnostics, d.diagnostic(d.errorRange(d.err), d.err.Error()))
+	}
+	return diagnostics
+}
+
+func (d *document) diagnostic(r Range, message string) Diagnostic {
+	return Diagnostic{Range: r, Severity: SeverityError, Source: diagnosticSource, Message: message}
+}
+
+// pathRange returns the range of the value with the given path or, if it's not in the document, of its closest parent
+func (d *document) pathRange(path string) Range {
+	for len(path) > 0 {
+		if e, ok := d.byPath[path]; ok {
+			switch {
+			case e.key == nil:
+				return nodeRange(e.value)
+			case e.value.Kind == yaml.ScalarNode:
+				return Range{Start: nodeRange(e.key).Start, End: nodeRange(e.value).End}
+			}
+			// objects and arrays span several lines, their key is enough
+			return nodeRange(e.key)
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return Range{}
+}
+
+// errorRange returns the range of the line reported by the error, the whole first line if none
+func (d *document) errorRange(err error) Range {
+	line := 0
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line = strings.Count(d.text[:min(int(syntaxErr.Offset), len(d.text))], "\n")
+	} else if match := errorLine.FindStringSubmatch(err.Error()); match != nil {
+		line, _ = strconv.Atoi(match[1])
+		line--
+	}
+	lines := strings.Split(d.text, "\n")
+	if line < 0 || line >= len(lines) {
+		line = 0
+	}
+	return Range{Start: Position{Line: line}, End: Position{Line: line, Character: utf8.RuneCountInString(lines[line])}}
+}
+
+// at returns the entry whose key or scalar value contains the position, and whether the position is on the key
+func (d *document) at(pos Position) (*entry, bool) {
+	for i := range d.entries {
+		e := &d.entries[i]
+		if e.key != nil && contains(nodeRange(e.key), pos) {
+			return e, true
+		}
+		if e.value.Kind == yaml.ScalarNode && contains(nodeRange(e.value), pos) {
+			return e, false
+		}
+	}
+	return nil, false
+}
+
+// namespacePath converts the namespace of a validation error into the path of the property, e.g.
+// 'Workflow.BaseWorkflow.Start' into 'start'
+func namespacePath(namespace string) string {
+	var segments []string
+	for i, segment := range strings.Split(namespace, ".") {
+		if i == 0 || strings.HasPrefix(segment, "Base") || segment == "Common" {
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(segment)
+		segments = append(segments, string(unicode.ToLower(r))+segment[size:])
+	}
+	return strings.Join(segments, ".")
+}
+
+// nodeRange approximates the range of the node with its first line
+func nodeRange(node *yaml.Node) Range {
+	start := Position{Line: node.Line - 1, Character: node.Column - 1}
+	length := utf8.RuneCountInString(node.Value)
+	if node.Kind != yaml.ScalarNode {
+		length = 1
+	} else if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		length += 2
+	}
+	if i := strings.IndexByte(node.Value, '\n'); i >= 0 {
+		length = i
+	}
+	return Range{Start: start, End: Position{Line: start.Line, Character: start.Character + length}}
+}
+
+func contains(r Range, pos Position) bool {
+	return pos.Line == r.Start.Line && pos.Character >= r.Start.Character && pos.Character <= r.End.Character
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,172 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const greetingYAML = `id: greeting
+name: Greeting
+version: '1.0'
+specVersion: '0.7'
+start: Greet
+functions:
+- name: greetingFunction
+  operation: file://myapis/greetingapis.json#greeting
+events:
+- name: GreetingEvent
+  type: greetingEventType
+  source: greetingEventSource
+states:
+- name: Greet
+  type: event
+  onEvents:
+  - eventRefs:
+    - GreetingEvent
+    actions:
+    - functionRef:
+        refName: greetingFunction
+  transition: Store
+`
+
+func TestDocument(t *testing.T) {
+	doc := newDocument("file:///greeting.sw.yaml", 1, greetingYAML)
+	assert.Equal(t, []Diagnostic{{
+		Range:    Range{Start: Position{Line: 21, Character: 2}, End: Position{Line: 21, Character: 19}},
+		Severity: SeverityError,
+		Source:   diagnosticSource,
+		Message:  "state Store is not defined",
+	}}, doc.diagnostics())
+
+	// refName: greetingFunction
+	name, kind := doc.definition(Position{Line: 20, Character: 20})
+	assert.Equal(t, kindFunction, kind)
+	assert.Equal(t, Range{Start: Position{Line: 6, Character: 8}, End: Position{Line: 6, Character: 24}}, nodeRange(name.value))
+	// eventRefs item
+	name, kind = doc.definition(Position{Line: 17, Character: 8})
+	assert.Equal(t, kindEvent, kind)
+	assert.Equal(t, "events[0].name", name.path)
+	// start
+	name, _ = doc.definition(Position{Line: 4, Character: 8})
+	assert.Equal(t, "states[0].name", name.path)
+	// not a reference
+	name, _ = doc.definition(Position{Line: 1, Character: 8})
+	assert.Nil(t, name)
+
+	hover := doc.hover(Position{Line: 20, Character: 20})
+	assert.Equal(t, "function **greetingFunction**\n\noperation: `file://myapis/greetingapis.json#greeting`", hover.Contents.Value)
+	hover = doc.hover(Position{Line: 21, Character: 4})
+	assert.Equal(t, "**transition**\n\nNext state to transition to, by name or as an object with `nextState`.", hover.Contents.Value)
+	assert.Nil(t, doc.hover(Position{Line: 100}))
+
+	// edit the document to a state it can't be parsed, the names of the last parsed text are completed
+	doc.update(2, strings.Replace(greetingYAML, "  transition: Store", "  transition: ", 1)+"- name: [")
+	diagnostics := doc.diagnostics()
+	assert.Len(t, diagnostics, 1)
+	assert.Equal(t, 22, diagnostics[0].Range.Start.Line)
+	assert.Equal(t, []CompletionItem{{Label: "Greet", Kind: CompletionItemKindReference, Detail: "state"}}, doc.completion(Position{Line: 21, Character: 14}))
+	assert.Nil(t, doc.completion(Position{Line: 1, Character: 6}))
+}
+
+func TestDocumentJSON(t *testing.T) {
+	text := `{
+  "id": "greeting",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "start": "Greet",
+  "events": [{"name": "GreetingEvent", "type": "greeting"}],
+  "states": [
+    {
+      "name": "Greet",
+      "type": "event",
+      "onEvents": [{"eventRefs": ["GreetingEvent", "Other"]}],
+      "end": true
+    }
+  ]
+}`
+	doc := newDocument("file:///greeting.sw.json", 1, text)
+	var messages []string
+	for _, diagnostic := range doc.diagnostics() {
+		messages = append(messages, fmt.Sprintf("%d:%d %s", diagnostic.Range.Start.Line, diagnostic.Range.Start.Character, diagnostic.Message))
+	}
+	assert.Equal(t, []string{"0:0 name failed on the 'required' validation"}, messages)
+
+	name, _ := doc.definition(Position{Line: 10, Character: 35})
+	assert.Equal(t, "events[0].name", name.path)
+	assert.Equal(t, []CompletionItem{{Label: "GreetingEvent", Kind: CompletionItemKindReference, Detail: "event"}},
+		doc.completion(Position{Line: 10, Character: 55}))
+
+	doc.update(2, "{\"id\": ")
+	assert.Len(t, doc.diagnostics(), 1)
+}
+
+func TestNamespacePath(t *testing.T) {
+	assert.Equal(t, "start", namespacePath("Workflow.BaseWorkflow.Start"))
+	assert.Equal(t, "states[0].name", namespacePath("Workflow.States[0].BaseState.Name"))
+	assert.Equal(t, "auth.name", namespacePath("Workflow.BaseWorkflow.Auth.name"))
+}
+
+func message(method string, id int, params interface{}) string {
+	data, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": id, "method": method, "params": params})
+	if id == 0 {
+		data, _ = json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params})
+	}
+	return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(data), data)
+}
+
+func TestServe(t *testing.T) {
+	uri := "file:///greeting.sw.yaml"
+	in := strings.Join([]string{
+		message("initialize", 1, map[string]interface{}{}),
+		message("initialized", 0, map[string]interface{}{}),
+		message("textDocument/didOpen", 0, DidOpenTextDocumentParams{TextDocument: TextDocumentItem{URI: uri, Version: 1, Text: greetingYAML}}),
+		message("textDocument/definition", 2, TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{URI: uri}, Position: Position{Line: 20, Character: 20}}),
+		message("textDocument/didChange", 0, DidChangeTextDocumentParams{
+			TextDocument:   VersionedTextDocumentIdentifier{URI: uri, Version: 2},
+			ContentChanges: []TextDocumentContentChangeEvent{{Text: strings.Replace(greetingYAML, "transition: Store", "end: true", 1)}},
+		}),
+		message("textDocument/formatting", 3, map[string]interface{}{}),
+		message("textDocument/didClose", 0, DidCloseTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: uri}}),
+		message("shutdown", 4, nil),
+		message("exit", 0, nil),
+	}, "")
+	out := new(bytes.Buffer)
+	assert.NoError(t, Serve(strings.NewReader(in), out))
+
+	c := newConn(out, nil)
+	var replies []string
+	for {
+		content, err := c.read()
+		if err != nil {
+			break
+		}
+		replies = append(replies, string(content))
+	}
+	assert.Len(t, replies, 7)
+	assert.Contains(t, replies[0], `"hoverProvider":true`)
+	assert.Contains(t, replies[1], `"message":"state Store is not defined"`)
+	assert.Equal(t, `{"jsonrpc":"2.0","id":2,"result":{"uri":"file:///greeting.sw.yaml","range":{"start":{"line":6,"character":8},"end":{"line":6,"character":24}}}}`, replies[2])
+	assert.Equal(t, `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{"uri":"file:///greeting.sw.yaml","version":2,"diagnostics":[]}}`, replies[3])
+	assert.Contains(t, replies[4], `"error":{"code":-32601`)
+	assert.Contains(t, replies[5], `"diagnostics":[]`)
+	assert.Equal(t, `{"jsonrpc":"2.0","id":4,"result":null}`, replies[6])
+}
@@ -0,0 +1,209 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// definitionKind kind of the definitions that can be referenced by name
+type definitionKind string
+
+const (
+	kindState    definitionKind = "state"
+	kindFunction definitionKind = "function"
+	kindEvent    definitionKind = "event"
+	kindError    definitionKind = "error"
+	kindRetry    definitionKind = "retry"
+)
+
+// definitionArrays workflow properties declaring the definitions of each kind
+var definitionArrays = map[definitionKind]string{
+	kindState:    "states",
+	kindFunction: "functions",
+	kindEvent:    "events",
+	kindError:    "errors",
+	kindRetry:    "retries",
+}
+
+// referenceKeys properties whose string value references a definition by name
+var referenceKeys = map[string]definitionKind{
+	"start":           kindState,
+	"stateName":       kindState,
+	"transition":      kindState,
+	"nextState":       kindState,
+	"compensatedBy":   kindState,
+	"functionRef":     kindFunction,
+	"refName":         kindFunction,
+	"eventRef":        kindEvent,
+	"triggerEventRef": kindEvent,
+	"resultEventRef":  kindEvent,
+	"errorRef":        kindError,
+	"retryRef":        kindRetry,
+}
+
+// referenceArrayKeys properties whose array items reference definitions by name
+var referenceArrayKeys = map[string]definitionKind{
+	"eventRefs":          kindEvent,
+	"errorRefs":          kindError,
+	"retryableErrors":    kindError,
+	"nonRetryableErrors": kindError,
+}
+
+var (
+	arrayIndex = regexp.MustCompile(`\[\d+\]$`)
+	// keyBefore matches the key of the value being written at the end of the line
+	keyBefore = regexp.MustCompile(`"?([A-Za-z]+)"?\s*:\s*"?[^"\s,\]]*$`)
+	// arrayBefore matches the key of the array whose item is being written at the end of the line
+	arrayBefore = regexp.MustCompile(`"?([A-Za-z]+)"?\s*:\s*\[[^\]\[{]*$`)
+	// arrayStart matches a line opening an array or a YAML block sequence
+	arrayStart = regexp.MustCompile(`^-?\s*"?([A-Za-z]+)"?\s*:\s*\[?$`)
+	// itemBefore matches an array item being written
+	itemBefore = regexp.MustCompile(`^\s*(-\s*)?"?[^"\s,:]*$`)
+)
+
+// referenceKind returns the kind of the definition referenced by the value with the given path, if any
+func referenceKind(path string) (definitionKind, bool) {
+	if arrayIndex.MatchString(path) {
+		path = arrayIndex.ReplaceAllString(path, "")
+		kind, ok := referenceArrayKeys[lastKey(path)]
+		return kind, ok
+	}
+	key := lastKey(path)
+	if key == "start" && path != "start" {
+		return "", false
+	}
+	kind, ok := referenceKeys[key]
+	return kind, ok
+}
+
+func lastKey(path string) string {
+	return path[strings.LastIndex(path, ".")+1:]
+}
+
+// definitions returns the name entries of the definitions of the given kind
+func (d *document) definitions(kind definitionKind) []*entry {
+	prefix := definitionArrays[kind] + "["
+	var names []*entry
+	for i := range d.entries {
+		e := &d.entries[i]
+		if strings.HasPrefix(e.path, prefix) && strings.HasSuffix(e.path, "].name") && strings.Count(e.path, ".") == 1 &&
+			e.value.Kind == yaml.ScalarNode {
+			names = append(names, e)
+		}
+	}
+	return names
+}
+
+// definition returns the name entry of the definition referenced by the value at the position
+func (d *document) definition(pos Position) (*entry, definitionKind) {
+	e, onKey := d.at(pos)
+	if e == nil || onKey {
+		return nil, ""
+	}
+	kind, ok := referenceKind(e.path)
+	if !ok {
+		return nil, ""
+	}
+	for _, name := range d.definitions(kind) {
+		if name.value.Value == e.value.Value {
+			return name, kind
+		}
+	}
+	return nil, kind
+}
+
+// hover returns the documentation of the property or the summary of the definition referenced at the position
+func (d *document) hover(pos Position) *Hover {
+	e, onKey := d.at(pos)
+	if e == nil {
+		return nil
+	}
+	if onKey {
+		doc, ok := propertyDocs[e.key.Value]
+		if !ok {
+			return nil
+		}
+		r := nodeRange(e.key)
+		return &Hover{Contents: MarkupContent{Kind: "markdown", Value: fmt.Sprintf("**%s**\n\n%s", e.key.Value, doc)}, Range: &r}
+	}
+	name, kind := d.definition(pos)
+	if name == nil {
+		return nil
+	}
+	summary := fmt.Sprintf("%s **%s**", kind, name.value.Value)
+	definition := strings.TrimSuffix(name.path, ".name")
+	for _, detail := range []string{"type", "operation", "source", "code"} {
+		if value, ok := d.byPath[definition+"."+detail]; ok && value.value.Kind == yaml.ScalarNode {
+			summary += fmt.Sprintf("\n\n%s: `%s`", detail, value.value.Value)
+		}
+	}
+	r := nodeRange(e.value)
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: summary}, Range: &r}
+}
+
+// completion returns the names of the definitions that can be referenced at the position. The text before the
+// position is used to find the property being written, as the document usually can't be parsed while editing.
+func (d *document) completion(pos Position) []CompletionItem {
+	kind, ok := d.completionKind(pos)
+	if !ok {
+		return nil
+	}
+	items := []CompletionItem{}
+	for _, name := range d.definitions(kind) {
+		items = append(items, CompletionItem{Label: name.value.Value, Kind: CompletionItemKindReference, Detail: string(kind)})
+	}
+	return items
+}
+
+func (d *document) completionKind(pos Position) (definitionKind, bool) {
+	lines := strings.Split(d.text, "\n")
+	if pos.Line >= len(lines) {
+		return "", false
+	}
+	line := []rune(lines[pos.Line])
+	if pos.Character < len(line) {
+		line = line[:pos.Character]
+	}
+	before := string(line)
+
+	if match := arrayBefore.FindStringSubmatch(before); match != nil {
+		kind, ok := referenceArrayKeys[match[1]]
+		return kind, ok
+	}
+	if match := keyBefore.FindStringSubmatch(before); match != nil {
+		kind, ok := referenceKeys[match[1]]
+		return kind, ok
+	}
+	if !itemBefore.MatchString(before) {
+		return "", false
+	}
+	// item of a multiline array, look for the line opening it
+	for i := pos.Line - 1; i >= 0; i-- {
+		previous := strings.TrimSpace(lines[i])
+		if match := arrayStart.FindStringSubmatch(previous); match != nil {
+			kind, ok := referenceArrayKeys[match[1]]
+			return kind, ok
+		}
+		if !strings.HasPrefix(previous, "-") && !strings.HasPrefix(previous, "\"") {
+			break
+		}
+	}
+	return "", false
+}
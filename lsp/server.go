@@ -0,0 +1,153 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// server language server of the workflow definitions. Requests are handled one at a time, in order.
+type server struct {
+	conn      *conn
+	documents map[string]*document
+}
+
+// Serve runs the language server over the given streams, usually the standard input and output, until the client
+// sends the exit notification or closes the input
+func Serve(in io.Reader, out io.Writer) error {
+	s := &server{conn: newConn(in, out), documents: map[string]*document{}}
+	for {
+		content, err := s.conn.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var req request
+		if err := json.Unmarshal(content, &req); err != nil {
+			if err := s.conn.reply(nil, nil, &responseError{Code: codeParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+		if len(req.Method) == 0 {
+			// response to a request of the server, none is sent
+			continue
+		}
+		result, err := s.handle(&req)
+		if req.ID != nil {
+			if err := s.conn.reply(req.ID, result, err); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *server) handle(req *request) (interface{}, error) {
+	switch req.Method {
+	case "initialize":
+		return &InitializeResult{
+			Capabilities: ServerCapabilities{
+				TextDocumentSync:   1,
+				HoverProvider:      true,
+				DefinitionProvider: true,
+				CompletionProvider: &CompletionOptions{TriggerCharacters: []string{"\"", " "}},
+			},
+			ServerInfo: ServerInfo{Name: "swctl"},
+		}, nil
+	case "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, err
+		}
+		doc := newDocument(params.TextDocument.URI, params.TextDocument.Version, params.TextDocument.Text)
+		s.documents[doc.uri] = doc
+		return nil, s.publish(doc)
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, err
+		}
+		if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		text := params.ContentChanges[len(params.ContentChanges)-1].Text
+		doc, ok := s.documents[params.TextDocument.URI]
+		if ok {
+			doc.update(params.TextDocument.Version, text)
+		} else {
+			doc = newDocument(params.TextDocument.URI, params.TextDocument.Version, text)
+			s.documents[doc.uri] = doc
+		}
+		return nil, s.publish(doc)
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, err
+		}
+		delete(s.documents, params.TextDocument.URI)
+		return nil, s.conn.notify("textDocument/publishDiagnostics", &PublishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []Diagnostic{}})
+	case "textDocument/hover":
+		doc, pos, err := s.position(req)
+		if doc == nil || err != nil {
+			return nil, err
+		}
+		return doc.hover(pos), nil
+	case "textDocument/definition":
+		doc, pos, err := s.position(req)
+		if doc == nil || err != nil {
+			return nil, err
+		}
+		if name, _ := doc.definition(pos); name != nil {
+			return &Location{URI: doc.uri, Range: nodeRange(name.value)}, nil
+		}
+		return nil, nil
+	case "textDocument/completion":
+		doc, pos, err := s.position(req)
+		if doc == nil || err != nil {
+			return nil, err
+		}
+		return doc.completion(pos), nil
+	}
+	if req.ID != nil {
+		return nil, &responseError{Code: codeMethodNotFound, Message: "method not supported: " + req.Method}
+	}
+	// notifications not supported are ignored
+	return nil, nil
+}
+
+// position returns the open document and the position of the request, nil if the document isn't open
+func (s *server) position(req *request) (*document, Position, error) {
+	var params TextDocumentPositionParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return nil, Position{}, err
+	}
+	return s.documents[params.TextDocument.URI], params.Position, nil
+}
+
+func (s *server) publish(doc *document) error {
+	diagnostics := doc.diagnostics()
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+	return s.conn.notify("textDocument/publishDiagnostics", &PublishDiagnosticsParams{URI: doc.uri, Version: doc.version, Diagnostics: diagnostics})
+}
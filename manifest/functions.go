@@ -0,0 +1,256 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// LabelFunction label added to the resources scaffolded for a function with the function name
+	LabelFunction = "serverlessworkflow.io/function"
+	// AnnotationOperation annotation of the resources scaffolded for a function holding the operation the service
+	// must implement
+	AnnotationOperation = "serverlessworkflow.io/operation"
+
+	// ScaffoldKnativeService scaffolds a Knative Service per function
+	ScaffoldKnativeService ScaffoldKind = "knative"
+	// ScaffoldDeployment scaffolds a Deployment and a Service per function
+	ScaffoldDeployment ScaffoldKind = "deployment"
+
+	// PortNameHTTP name of the container port of the REST, GraphQL and OData functions
+	PortNameHTTP = "http"
+	// PortNameGRPC name of the container port of the RPC functions
+	PortNameGRPC = "grpc"
+
+	defaultScaffoldImage = "{{ .Name }}:latest"
+	defaultScaffoldPort  = 8080
+)
+
+// KnativeServiceTemplate default template of the ScaffoldKnativeService kind. The RPC functions use the h2c port so
+// Knative routes HTTP/2 to the container.
+const KnativeServiceTemplate = `apiVersion: ` + KnativeServingAPIVersion + `
+kind: Service
+metadata:
+  name: {{ .Name }}
+{{- if .Namespace }}
+  namespace: {{ .Namespace }}
+{{- end }}
+  labels:
+{{- range $name, $value := .Labels }}
+    {{ $name }}: {{ quote $value }}
+{{- end }}
+  annotations:
+    ` + AnnotationOperation + `: {{ quote .Function.Operation }}
+spec:
+  template:
+    spec:
+      containers:
+      - image: {{ quote .Image }}
+        ports:
+        - name: {{ if eq .PortName "grpc" }}h2c{{ else }}http1{{ end }}
+          containerPort: {{ .Port }}
+`
+
+// DeploymentTemplate default template of the ScaffoldDeployment kind
+const DeploymentTemplate = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Name }}
+{{- if .Namespace }}
+  namespace: {{ .Namespace }}
+{{- end }}
+  labels:
+{{- range $name, $value := .Labels }}
+    {{ $name }}: {{ quote $value }}
+{{- end }}
+  annotations:
+    ` + AnnotationOperation + `: {{ quote .Function.Operation }}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      ` + LabelFunction + `: {{ quote .Name }}
+  template:
+    metadata:
+      labels:
+{{- range $name, $value := .Labels }}
+        {{ $name }}: {{ quote $value }}
+{{- end }}
+    spec:
+      containers:
+      - name: {{ .Name }}
+        image: {{ quote .Image }}
+        ports:
+        - name: {{ .PortName }}
+          containerPort: {{ .Port }}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ .Name }}
+{{- if .Namespace }}
+  namespace: {{ .Namespace }}
+{{- end }}
+  labels:
+{{- range $name, $value := .Labels }}
+    {{ $name }}: {{ quote $value }}
+{{- end }}
+spec:
+  selector:
+    ` + LabelFunction + `: {{ quote .Name }}
+  ports:
+  - name: {{ .PortName }}
+    port: 80
+    targetPort: {{ .PortName }}
+{{- if eq .PortName "grpc" }}
+    appProtocol: grpc
+{{- end }}
+`
+
+// scaffoldFuncs functions available to the scaffold templates
+var scaffoldFuncs = template.FuncMap{
+	// quote quotes a string as a YAML scalar
+	"quote": func(value string) string {
+		data, _ := json.Marshal(value)
+		return string(data)
+	},
+}
+
+// ScaffoldKind kind of the resources scaffolded for the functions
+type ScaffoldKind string
+
+// ScaffoldOptions options to scaffold the resources running the functions of a workflow
+type ScaffoldOptions struct {
+	// Kind of the resources. Default is ScaffoldKnativeService
+	Kind ScaffoldKind
+	// Template text/template of the resources of a function, executed with a ScaffoldData, overriding the template of
+	// the Kind. See KnativeServiceTemplate and DeploymentTemplate
+	Template string
+	// Namespace where the resources are created
+	Namespace string
+	// Image template of the container image, executed with a ScaffoldData. Default is '{{ .Name }}:latest'
+	Image string
+	// Port the containers listen on. Default is 8080
+	Port int32
+}
+
+// ScaffoldData data the templates are executed with
+type ScaffoldData struct {
+	// Name resource name of the function
+	Name      string
+	Namespace string
+	// Image of the container, empty when executing the image template
+	Image string
+	Port  int32
+	// PortName PortNameGRPC for the RPC functions, PortNameHTTP otherwise
+	PortName string
+	Function model.Function
+	// Labels of the resources: the workflow id and the function name
+	Labels map[string]string
+}
+
+// FunctionScaffold resources scaffolded for a function
+type FunctionScaffold struct {
+	Function model.Function
+	// Name resource name of the function
+	Name string
+	// Manifest YAML stream of the resources
+	Manifest []byte
+}
+
+// ScaffoldFunctions scaffolds the resources running the functions of the workflow backed by a service, all but the
+// expression and AsyncAPI functions, one set of resources per function. The scaffolds are starting points to
+// complete, e.g. with the image actually implementing the operation.
+func ScaffoldFunctions(workflow *model.Workflow, opts ScaffoldOptions) ([]FunctionScaffold, error) {
+	source := opts.Template
+	if len(source) == 0 {
+		switch opts.Kind {
+		case ScaffoldKnativeService, "":
+			source = KnativeServiceTemplate
+		case ScaffoldDeployment:
+			source = DeploymentTemplate
+		default:
+			return nil, fmt.Errorf("unknown scaffold kind %s, expected %s or %s", opts.Kind, ScaffoldKnativeService, ScaffoldDeployment)
+		}
+	}
+	resources, err := template.New("scaffold").Funcs(scaffoldFuncs).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scaffold template: %w", err)
+	}
+	imageSource := opts.Image
+	if len(imageSource) == 0 {
+		imageSource = defaultScaffoldImage
+	}
+	image, err := template.New("image").Funcs(scaffoldFuncs).Parse(imageSource)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image template: %w", err)
+	}
+	port := opts.Port
+	if port == 0 {
+		port = defaultScaffoldPort
+	}
+
+	var scaffolds []FunctionScaffold
+	for _, function := range workflow.Functions {
+		if function.Type == model.FunctionTypeExpression || function.Type == model.FunctionTypeAsyncAPI {
+			continue
+		}
+		name := ResourceName(function.Name)
+		labels := workflowLabels(workflow, "")
+		labels[LabelFunction] = name
+		data := ScaffoldData{Name: name, Namespace: opts.Namespace, Port: port, PortName: PortNameHTTP, Function: function, Labels: labels}
+		if function.Type == model.FunctionTypeRPC {
+			data.PortName = PortNameGRPC
+		}
+		buf := new(bytes.Buffer)
+		if err := image.Execute(buf, data); err != nil {
+			return nil, fmt.Errorf("function %s: %w", function.Name, err)
+		}
+		data.Image = buf.String()
+		buf = new(bytes.Buffer)
+		if err := resources.Execute(buf, data); err != nil {
+			return nil, fmt.Errorf("function %s: %w", function.Name, err)
+		}
+		if err := checkYAMLStream(buf.Bytes()); err != nil {
+			return nil, fmt.Errorf("function %s: scaffold isn't valid YAML: %w", function.Name, err)
+		}
+		scaffolds = append(scaffolds, FunctionScaffold{Function: function, Name: name, Manifest: buf.Bytes()})
+	}
+	return scaffolds, nil
+}
+
+// checkYAMLStream checks that every document of the stream is valid YAML
+func checkYAMLStream(data []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc interface{}
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
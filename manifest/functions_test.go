@@ -0,0 +1,84 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func scaffoldWorkflow() *model.Workflow {
+	workflow := testWorkflow()
+	workflow.Functions = []model.Function{
+		{Name: "storeOrder", Operation: "https://orders.example.com/openapi.json#storeOrder"},
+		{Name: "price", Operation: "pricing.proto#Pricing#Price", Type: model.FunctionTypeRPC},
+		{Name: "total", Operation: ".items | length", Type: model.FunctionTypeExpression},
+		{Name: "publish", Operation: "asyncapi.yaml#publishOrder", Type: model.FunctionTypeAsyncAPI},
+	}
+	return workflow
+}
+
+func TestScaffoldKnativeServices(t *testing.T) {
+	scaffolds, err := ScaffoldFunctions(scaffoldWorkflow(), ScaffoldOptions{Namespace: "orders", Image: "ghcr.io/acme/{{ .Name }}:1.0"})
+	require.NoError(t, err)
+	require.Len(t, scaffolds, 2)
+	assert.Equal(t, "storeorder", scaffolds[0].Name)
+	assert.Equal(t, "price", scaffolds[1].Name)
+
+	service := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(scaffolds[0].Manifest, &service))
+	assert.Equal(t, KnativeServingAPIVersion, service["apiVersion"])
+	metadata := service["metadata"].(map[string]interface{})
+	assert.Equal(t, "orders", metadata["namespace"])
+	assert.Equal(t, map[string]interface{}{LabelWorkflowID: "order-workflow", LabelFunction: "storeorder"}, metadata["labels"])
+	assert.Equal(t, "https://orders.example.com/openapi.json#storeOrder", metadata["annotations"].(map[string]interface{})[AnnotationOperation])
+	assert.Contains(t, string(scaffolds[0].Manifest), `image: "ghcr.io/acme/storeorder:1.0"`)
+	assert.Contains(t, string(scaffolds[0].Manifest), "name: http1")
+	assert.Contains(t, string(scaffolds[1].Manifest), "name: h2c")
+}
+
+func TestScaffoldDeployments(t *testing.T) {
+	scaffolds, err := ScaffoldFunctions(scaffoldWorkflow(), ScaffoldOptions{Kind: ScaffoldDeployment, Port: 9090})
+	require.NoError(t, err)
+	require.Len(t, scaffolds, 2)
+	docs := strings.Split(string(scaffolds[1].Manifest), "---\n")
+	require.Len(t, docs, 2)
+	assert.Contains(t, docs[0], "kind: Deployment")
+	assert.Contains(t, docs[0], `image: "price:latest"`)
+	assert.Contains(t, docs[0], "containerPort: 9090")
+	assert.Contains(t, docs[1], "kind: Service")
+	assert.Contains(t, docs[1], "targetPort: grpc")
+	assert.Contains(t, docs[1], "appProtocol: grpc")
+	assert.NotContains(t, string(scaffolds[0].Manifest), "appProtocol")
+}
+
+func TestScaffoldTemplate(t *testing.T) {
+	scaffolds, err := ScaffoldFunctions(scaffoldWorkflow(), ScaffoldOptions{Template: "name: {{ .Name }}\noperation: {{ quote .Function.Operation }}\n"})
+	require.NoError(t, err)
+	assert.Equal(t, "name: storeorder\noperation: \"https://orders.example.com/openapi.json#storeOrder\"\n", string(scaffolds[0].Manifest))
+
+	_, err = ScaffoldFunctions(scaffoldWorkflow(), ScaffoldOptions{Template: "name: {{ .Name }\n"})
+	assert.Error(t, err)
+	_, err = ScaffoldFunctions(scaffoldWorkflow(), ScaffoldOptions{Template: "name: [{{ .Name }}\n"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "function storeOrder: scaffold isn't valid YAML")
+	_, err = ScaffoldFunctions(scaffoldWorkflow(), ScaffoldOptions{Kind: "lambda"})
+	assert.EqualError(t, err, "unknown scaffold kind lambda, expected knative or deployment")
+}
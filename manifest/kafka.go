@@ -0,0 +1,173 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"sort"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// StrimziAPIVersion API version of the Strimzi Kafka resources
+	StrimziAPIVersion = "kafka.strimzi.io/v1beta2"
+	// LabelStrimziCluster label binding a Strimzi resource to its Kafka cluster
+	LabelStrimziCluster = "strimzi.io/cluster"
+	// KafkaHeaderPrefix prefix of the Kafka headers carrying the CloudEvent context attributes in binary content mode
+	KafkaHeaderPrefix = "ce_"
+
+	kindKafkaTopic           = "KafkaTopic"
+	defaultKafkaPartitions   = 1
+	defaultKafkaReplicas     = 1
+	defaultKafkaGroupPostfix = "consumer"
+)
+
+// TopicStrategy maps a consumed event definition to the Kafka topic the event is read from
+type TopicStrategy func(event model.Event) string
+
+// TopicPerEventType strategy reading each event from a topic named after its CloudEvent type
+func TopicPerEventType(event model.Event) string {
+	return event.Type
+}
+
+// TopicPerEventName strategy reading each event from a topic named after its definition name
+func TopicPerEventName(event model.Event) string {
+	return event.Name
+}
+
+// SingleTopic strategy reading all the events from the given topic
+func SingleTopic(topic string) TopicStrategy {
+	return func(model.Event) string {
+		return topic
+	}
+}
+
+// KafkaOptions options to generate the Kafka configuration
+type KafkaOptions struct {
+	// Namespace where the topic resources are created
+	Namespace string
+	// Cluster name of the Strimzi Kafka cluster the topics belong to
+	Cluster string
+	// Strategy maps the events to topics. Default is TopicPerEventType
+	Strategy TopicStrategy
+	// ConsumerGroup group id of the workflow consumers. Default is '<workflow id>-consumer'
+	ConsumerGroup string
+	// Partitions of the generated topics. Default is 1
+	Partitions int32
+	// Replicas of the generated topics. Default is 1
+	Replicas int32
+}
+
+// KafkaConfig configuration needed to feed the events consumed by a workflow from Kafka
+type KafkaConfig struct {
+	// ConsumerGroup group id shared by the workflow consumers
+	ConsumerGroup string `json:"consumerGroup"`
+	// Topics read by the workflow, sorted by name
+	Topics []KafkaTopicConfig `json:"topics"`
+}
+
+// KafkaTopicConfig topic read by the workflow and the filters selecting the records of each consumed event
+type KafkaTopicConfig struct {
+	Name    string        `json:"name"`
+	Filters []KafkaFilter `json:"filters"`
+}
+
+// KafkaFilter exact match of the record headers selecting the records of a consumed event
+type KafkaFilter struct {
+	// Event name of the event definition
+	Event string `json:"event"`
+	// Headers CloudEvent context attributes as binary content mode headers, e.g. 'ce_type'
+	Headers map[string]string `json:"headers"`
+}
+
+// KafkaTopic Strimzi topic resource
+type KafkaTopic struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              KafkaTopicSpec `json:"spec"`
+}
+
+// KafkaTopicSpec ...
+type KafkaTopicSpec struct {
+	TopicName  string            `json:"topicName,omitempty"`
+	Partitions int32             `json:"partitions"`
+	Replicas   int32             `json:"replicas"`
+	Config     map[string]string `json:"config,omitempty"`
+}
+
+// Kafka generates the consumer group, topics and filters needed to feed the events consumed by the given workflow from Kafka
+func Kafka(workflow *model.Workflow, opts KafkaOptions) *KafkaConfig {
+	strategy := opts.Strategy
+	if strategy == nil {
+		strategy = TopicPerEventType
+	}
+	config := &KafkaConfig{ConsumerGroup: opts.ConsumerGroup}
+	if len(config.ConsumerGroup) == 0 {
+		config.ConsumerGroup = ResourceName(workflowID(workflow), defaultKafkaGroupPostfix)
+	}
+
+	topics := map[string]*KafkaTopicConfig{}
+	var names []string
+	for _, event := range ConsumedEvents(workflow) {
+		name := strategy(event)
+		topic, ok := topics[name]
+		if !ok {
+			topic = &KafkaTopicConfig{Name: name}
+			topics[name] = topic
+			names = append(names, name)
+		}
+		headers := map[string]string{}
+		for attribute, value := range EventAttributes(event) {
+			headers[KafkaHeaderPrefix+attribute] = value
+		}
+		topic.Filters = append(topic.Filters, KafkaFilter{Event: event.Name, Headers: headers})
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		config.Topics = append(config.Topics, *topics[name])
+	}
+	return config
+}
+
+// KafkaTopics generates the Strimzi topic resources of the topics the given workflow reads from
+func KafkaTopics(workflow *model.Workflow, opts KafkaOptions) []interface{} {
+	partitions, replicas := opts.Partitions, opts.Replicas
+	if partitions <= 0 {
+		partitions = defaultKafkaPartitions
+	}
+	if replicas <= 0 {
+		replicas = defaultKafkaReplicas
+	}
+	var resources []interface{}
+	for _, topic := range Kafka(workflow, opts).Topics {
+		labels := workflowLabels(workflow, "")
+		if len(opts.Cluster) > 0 {
+			labels[LabelStrimziCluster] = opts.Cluster
+		}
+		spec := KafkaTopicSpec{Partitions: partitions, Replicas: replicas}
+		name := ResourceName(topic.Name)
+		if name != topic.Name {
+			// topic names allow characters that resource names don't
+			spec.TopicName = topic.Name
+		}
+		resources = append(resources, &KafkaTopic{
+			TypeMeta:   metav1.TypeMeta{APIVersion: StrimziAPIVersion, Kind: kindKafkaTopic},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: opts.Namespace, Labels: labels},
+			Spec:       spec,
+		})
+	}
+	return resources
+}
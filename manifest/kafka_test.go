@@ -0,0 +1,54 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafka(t *testing.T) {
+	config := Kafka(testWorkflow(), KafkaOptions{})
+	assert.Equal(t, "order-workflow-consumer", config.ConsumerGroup)
+	assert.Len(t, config.Topics, 2)
+	assert.Equal(t, "order.created", config.Topics[0].Name)
+	assert.Equal(t, []KafkaFilter{{
+		Event:   "OrderCreated",
+		Headers: map[string]string{"ce_type": "order.created", "ce_source": "/orders", "ce_tenant": "acme"},
+	}}, config.Topics[0].Filters)
+	assert.Equal(t, "order.shipped", config.Topics[1].Name)
+
+	config = Kafka(testWorkflow(), KafkaOptions{Strategy: SingleTopic("orders"), ConsumerGroup: "workflows"})
+	assert.Equal(t, "workflows", config.ConsumerGroup)
+	assert.Len(t, config.Topics, 1)
+	assert.Len(t, config.Topics[0].Filters, 2)
+	assert.Equal(t, "OrderShipped", config.Topics[0].Filters[1].Event)
+}
+
+func TestKafkaTopics(t *testing.T) {
+	resources := KafkaTopics(testWorkflow(), KafkaOptions{Namespace: "kafka", Cluster: "my-cluster", Strategy: TopicPerEventName, Partitions: 3})
+	assert.Len(t, resources, 2)
+	topic := resources[0].(*KafkaTopic)
+	assert.Equal(t, "ordercreated", topic.Name)
+	assert.Equal(t, "OrderCreated", topic.Spec.TopicName)
+	assert.Equal(t, int32(3), topic.Spec.Partitions)
+	assert.Equal(t, int32(1), topic.Spec.Replicas)
+	assert.Equal(t, "my-cluster", topic.Labels[LabelStrimziCluster])
+
+	out, err := ToYAML(resources...)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "apiVersion: kafka.strimzi.io/v1beta2")
+}
@@ -0,0 +1,173 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// KnativeEventingAPIVersion API version of the Knative Eventing resources
+	KnativeEventingAPIVersion = "eventing.knative.dev/v1"
+	// KnativeMessagingAPIVersion API version of the Knative Messaging resources
+	KnativeMessagingAPIVersion = "messaging.knative.dev/v1"
+	// KnativeServingAPIVersion API version of the Knative Serving resources
+	KnativeServingAPIVersion = "serving.knative.dev/v1"
+	// DefaultBrokerName name of the broker used when none is given
+	DefaultBrokerName = "default"
+
+	kindBroker       = "Broker"
+	kindTrigger      = "Trigger"
+	kindSubscription = "Subscription"
+	kindService      = "Service"
+)
+
+// KReference Knative reference to another Kubernetes object
+type KReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+}
+
+// Destination Knative addressable destination. Either a reference, an URI or both (URI relative to the reference)
+type Destination struct {
+	Ref *KReference `json:"ref,omitempty"`
+	URI string      `json:"uri,omitempty"`
+}
+
+// Broker Knative Eventing broker
+type Broker struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              BrokerSpec `json:"spec,omitempty"`
+}
+
+// BrokerSpec ...
+type BrokerSpec struct {
+	// Config reference to the configuration that specifies the broker implementation
+	Config *KReference `json:"config,omitempty"`
+}
+
+// Trigger Knative Eventing trigger
+type Trigger struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              TriggerSpec `json:"spec"`
+}
+
+// TriggerSpec ...
+type TriggerSpec struct {
+	// Broker name this trigger receives events from
+	Broker string `json:"broker"`
+	// Filter exact match of the events context attributes
+	Filter *TriggerFilter `json:"filter,omitempty"`
+	// Subscriber addressable that receives the filtered events
+	Subscriber Destination `json:"subscriber"`
+}
+
+// TriggerFilter ...
+type TriggerFilter struct {
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Subscription Knative Messaging subscription to a channel
+type Subscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              SubscriptionSpec `json:"spec"`
+}
+
+// SubscriptionSpec ...
+type SubscriptionSpec struct {
+	// Channel reference to the channel the subscriber is subscribed to
+	Channel KReference `json:"channel"`
+	// Subscriber addressable that receives the channel events
+	Subscriber Destination `json:"subscriber"`
+}
+
+// KnativeOptions options to generate Knative Eventing manifests
+type KnativeOptions struct {
+	// Namespace where the resources are created
+	Namespace string
+	// Broker name the triggers are attached to. Default is 'default'
+	Broker string
+	// CreateBroker if true, the broker resource is generated as well
+	CreateBroker bool
+	// BrokerConfig optional reference to the broker configuration
+	BrokerConfig *KReference
+	// Channel if set, subscriptions to this channel are generated instead of a broker and triggers.
+	// Channels can't filter events, the sink is responsible for discarding the events it does not consume.
+	Channel *KReference
+	// Sink destination of the consumed events, usually the service running the workflow
+	Sink Destination
+}
+
+// Knative generates the Knative Eventing resources required to deliver the events consumed by the given workflow to the sink
+func Knative(workflow *model.Workflow, opts KnativeOptions) []interface{} {
+	if opts.Channel != nil {
+		return knativeSubscriptions(workflow, opts)
+	}
+	broker := opts.Broker
+	if len(broker) == 0 {
+		broker = DefaultBrokerName
+	}
+
+	var resources []interface{}
+	if opts.CreateBroker {
+		resources = append(resources, &Broker{
+			TypeMeta:   metav1.TypeMeta{APIVersion: KnativeEventingAPIVersion, Kind: kindBroker},
+			ObjectMeta: metav1.ObjectMeta{Name: broker, Namespace: opts.Namespace, Labels: workflowLabels(workflow, "")},
+			Spec:       BrokerSpec{Config: opts.BrokerConfig},
+		})
+	}
+	for _, event := range ConsumedEvents(workflow) {
+		resources = append(resources, &Trigger{
+			TypeMeta: metav1.TypeMeta{APIVersion: KnativeEventingAPIVersion, Kind: kindTrigger},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ResourceName(workflowID(workflow), event.Name),
+				Namespace: opts.Namespace,
+				Labels:    workflowLabels(workflow, event.Name),
+			},
+			Spec: TriggerSpec{
+				Broker:     broker,
+				Filter:     &TriggerFilter{Attributes: EventAttributes(event)},
+				Subscriber: opts.Sink,
+			},
+		})
+	}
+	return resources
+}
+
+func knativeSubscriptions(workflow *model.Workflow, opts KnativeOptions) []interface{} {
+	if len(ConsumedEvents(workflow)) == 0 {
+		return nil
+	}
+	return []interface{}{&Subscription{
+		TypeMeta: metav1.TypeMeta{APIVersion: KnativeMessagingAPIVersion, Kind: kindSubscription},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ResourceName(workflowID(workflow), opts.Channel.Name),
+			Namespace: opts.Namespace,
+			Labels:    workflowLabels(workflow, ""),
+		},
+		Spec: SubscriptionSpec{Channel: *opts.Channel, Subscriber: opts.Sink},
+	}}
+}
+
+// KnativeServiceSink destination pointing to a Knative Service with the given name
+func KnativeServiceSink(name, namespace string) Destination {
+	return Destination{Ref: &KReference{APIVersion: KnativeServingAPIVersion, Kind: kindService, Name: name, Namespace: namespace}}
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func testWorkflow() *model.Workflow {
+	return &model.Workflow{
+		BaseWorkflow: model.BaseWorkflow{ID: "Order_Workflow"},
+		Events: []model.Event{
+			{Name: "OrderCreated", Type: "order.created", Source: "/orders",
+				Correlation: []model.Correlation{{ContextAttributeName: "tenant", ContextAttributeValue: "acme"}, {ContextAttributeName: "orderid"}}},
+			{Name: "OrderShipped", Type: "order.shipped", Kind: model.EventKindConsumed},
+			{Name: "OrderConfirmed", Type: "order.confirmed", Kind: model.EventKindProduced},
+		},
+	}
+}
+
+func TestKnativeTriggers(t *testing.T) {
+	resources := Knative(testWorkflow(), KnativeOptions{Namespace: "orders", CreateBroker: true, Sink: KnativeServiceSink("order-workflow", "")})
+	assert.Len(t, resources, 3)
+	assert.IsType(t, &Broker{}, resources[0])
+	assert.Equal(t, DefaultBrokerName, resources[0].(*Broker).Name)
+
+	trigger := resources[1].(*Trigger)
+	assert.Equal(t, "order-workflow-ordercreated", trigger.Name)
+	assert.Equal(t, "orders", trigger.Namespace)
+	assert.Equal(t, map[string]string{"type": "order.created", "source": "/orders", "tenant": "acme"}, trigger.Spec.Filter.Attributes)
+	assert.Equal(t, "order-workflow", trigger.Spec.Subscriber.Ref.Name)
+	assert.Equal(t, map[string]string{"type": "order.shipped"}, resources[2].(*Trigger).Spec.Filter.Attributes)
+
+	out, err := ToYAML(resources...)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "kind: Trigger")
+	assert.Contains(t, string(out), "---\n")
+}
+
+func TestKnativeSubscriptions(t *testing.T) {
+	channel := &KReference{APIVersion: KnativeMessagingAPIVersion, Kind: "InMemoryChannel", Name: "orders"}
+	resources := Knative(testWorkflow(), KnativeOptions{Channel: channel, Sink: Destination{URI: "http://order-workflow"}})
+	assert.Len(t, resources, 1)
+	subscription := resources[0].(*Subscription)
+	assert.Equal(t, "orders", subscription.Spec.Channel.Name)
+	assert.Equal(t, "http://order-workflow", subscription.Spec.Subscriber.URI)
+}
+
+func TestResourceName(t *testing.T) {
+	assert.Equal(t, "my-workflow-event-1", ResourceName("My_Workflow", "", "event.1"))
+	assert.Len(t, ResourceName(string(make([]byte, 100)), "abc"), 3)
+}
@@ -0,0 +1,114 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// LabelWorkflowID label added to every generated resource with the workflow id
+	LabelWorkflowID = "serverlessworkflow.io/workflow-id"
+	// LabelWorkflowEvent label added to the generated resources bound to a single workflow event
+	LabelWorkflowEvent = "serverlessworkflow.io/event"
+
+	// AttributeType CloudEvent type context attribute
+	AttributeType = "type"
+	// AttributeSource CloudEvent source context attribute
+	AttributeSource = "source"
+
+	maxNameLength = 63
+)
+
+var invalidNameChars = regexp.MustCompile("[^a-z0-9-]+")
+
+// ConsumedEvents returns the events consumed by the given workflow. Events without kind are consumed by default.
+func ConsumedEvents(workflow *model.Workflow) []model.Event {
+	var events []model.Event
+	for _, event := range workflow.Events {
+		if event.Kind == model.EventKindConsumed || len(event.Kind) == 0 {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// EventAttributes returns the CloudEvent context attributes that an incoming event must match to be consumed by
+// the given event definition. Correlation rules without a static value can only be evaluated at runtime and are skipped.
+func EventAttributes(event model.Event) map[string]string {
+	attributes := map[string]string{AttributeType: event.Type}
+	if len(event.Source) > 0 {
+		attributes[AttributeSource] = event.Source
+	}
+	for _, correlation := range event.Correlation {
+		if len(correlation.ContextAttributeValue) > 0 {
+			attributes[correlation.ContextAttributeName] = correlation.ContextAttributeValue
+		}
+	}
+	return attributes
+}
+
+// ToYAML encodes the given resources as a multi-document YAML stream.
+func ToYAML(resources ...interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	for i, resource := range resources {
+		data, err := yaml.Marshal(resource)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// ResourceName builds a valid Kubernetes resource name (RFC 1123 label) from the given parts.
+func ResourceName(parts ...string) string {
+	var sanitized []string
+	for _, part := range parts {
+		part = invalidNameChars.ReplaceAllString(strings.ToLower(part), "-")
+		part = strings.Trim(part, "-")
+		if len(part) > 0 {
+			sanitized = append(sanitized, part)
+		}
+	}
+	name := strings.Join(sanitized, "-")
+	if len(name) > maxNameLength {
+		name = strings.TrimRight(name[:maxNameLength], "-")
+	}
+	return name
+}
+
+func workflowLabels(workflow *model.Workflow, event string) map[string]string {
+	labels := map[string]string{LabelWorkflowID: ResourceName(workflowID(workflow))}
+	if len(event) > 0 {
+		labels[LabelWorkflowEvent] = ResourceName(event)
+	}
+	return labels
+}
+
+func workflowID(workflow *model.Workflow) string {
+	if len(workflow.ID) > 0 {
+		return workflow.ID
+	}
+	return workflow.Key
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+const (
+	// ProtocolHTTP CloudEvents Subscription API HTTP protocol
+	ProtocolHTTP = "HTTP"
+)
+
+// CloudEventsSubscription subscription object of the CNCF CloudEvents Subscription API
+type CloudEventsSubscription struct {
+	ID string `json:"id"`
+	// Source matches the source context attribute of the events
+	Source string `json:"source,omitempty"`
+	// Types matches the type context attribute of the events
+	Types   []string          `json:"types,omitempty"`
+	Config  map[string]string `json:"config,omitempty"`
+	Filters []Filter          `json:"filters,omitempty"`
+	// Sink URI the events are delivered to
+	Sink             string                 `json:"sink"`
+	Protocol         string                 `json:"protocol"`
+	ProtocolSettings map[string]interface{} `json:"protocolsettings,omitempty"`
+}
+
+// Filter filter expression of the CloudEvents Subscription API, one dialect per filter
+type Filter struct {
+	// Exact context attributes that must match exactly
+	Exact map[string]string `json:"exact,omitempty"`
+	// Prefix context attributes that must start with the given value. An empty prefix requires the attribute to be present
+	Prefix map[string]string `json:"prefix,omitempty"`
+	// Suffix context attributes that must end with the given value
+	Suffix map[string]string `json:"suffix,omitempty"`
+	All    []Filter          `json:"all,omitempty"`
+	Any    []Filter          `json:"any,omitempty"`
+	Not    *Filter           `json:"not,omitempty"`
+}
+
+// CloudEventsSubscriptionOptions options to generate CloudEvents Subscription API objects
+type CloudEventsSubscriptionOptions struct {
+	// Sink URI of the workflow endpoint receiving the events
+	Sink string
+	// Protocol the events are delivered with. Default is HTTP
+	Protocol string
+	// ProtocolSettings protocol specific settings, e.g. the HTTP method
+	ProtocolSettings map[string]interface{}
+}
+
+// CloudEventsSubscriptions generates a CloudEvents Subscription API object per event consumed by the given workflow.
+// Correlation attributes with a static value are matched exactly, the others are required to be present.
+func CloudEventsSubscriptions(workflow *model.Workflow, opts CloudEventsSubscriptionOptions) []*CloudEventsSubscription {
+	protocol := opts.Protocol
+	if len(protocol) == 0 {
+		protocol = ProtocolHTTP
+	}
+	var subscriptions []*CloudEventsSubscription
+	for _, event := range ConsumedEvents(workflow) {
+		subscription := &CloudEventsSubscription{
+			ID:               ResourceName(workflowID(workflow), event.Name),
+			Source:           event.Source,
+			Types:            []string{event.Type},
+			Sink:             opts.Sink,
+			Protocol:         protocol,
+			ProtocolSettings: opts.ProtocolSettings,
+		}
+		exact, present := map[string]string{}, map[string]string{}
+		for _, correlation := range event.Correlation {
+			if len(correlation.ContextAttributeValue) > 0 {
+				exact[correlation.ContextAttributeName] = correlation.ContextAttributeValue
+			} else {
+				present[correlation.ContextAttributeName] = ""
+			}
+		}
+		if len(exact) > 0 {
+			subscription.Filters = append(subscription.Filters, Filter{Exact: exact})
+		}
+		if len(present) > 0 {
+			subscription.Filters = append(subscription.Filters, Filter{Prefix: present})
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions
+}
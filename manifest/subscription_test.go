@@ -0,0 +1,46 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudEventsSubscriptions(t *testing.T) {
+	subscriptions := CloudEventsSubscriptions(testWorkflow(), CloudEventsSubscriptionOptions{Sink: "https://orders.example.com/events"})
+	assert.Len(t, subscriptions, 2)
+
+	created := subscriptions[0]
+	assert.Equal(t, "order-workflow-ordercreated", created.ID)
+	assert.Equal(t, "/orders", created.Source)
+	assert.Equal(t, []string{"order.created"}, created.Types)
+	assert.Equal(t, ProtocolHTTP, created.Protocol)
+	assert.Equal(t, []Filter{{Exact: map[string]string{"tenant": "acme"}}, {Prefix: map[string]string{"orderid": ""}}}, created.Filters)
+	assert.Empty(t, subscriptions[1].Filters)
+
+	data, err := json.Marshal(created)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": "order-workflow-ordercreated",
+		"source": "/orders",
+		"types": ["order.created"],
+		"filters": [{"exact": {"tenant": "acme"}}, {"prefix": {"orderid": ""}}],
+		"sink": "https://orders.example.com/events",
+		"protocol": "HTTP"
+	}`, string(data))
+}
@@ -0,0 +1,150 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package materialize
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// constantReference reference to a constant, e.g. '$CONST.limits.max' or '$CONST["limits"]', the whole
+	// constants for '$CONST'
+	constantReference = regexp.MustCompile(`\$CONST((?:\.[A-Za-z_]\w*|\[\s*"[^"]*"\s*\]|\[\s*'[^']*'\s*\])*)`)
+	// constantSegment key of a constant reference, e.g. '.limits' or '["limits"]'
+	constantSegment = regexp.MustCompile(`\.([A-Za-z_]\w*)|\[\s*"([^"]*)"\s*\]|\[\s*'([^']*)'\s*\]`)
+	// constantExpression expression only referencing a constant, e.g. '${ $CONST.limits.max }'
+	constantExpression = regexp.MustCompile(`^\$\{\s*` + constantReference.String() + `\s*\}$`)
+)
+
+// LoadConstants loads and merges the constants files in the given paths, JSON or YAML objects, the constants of the
+// last files overriding the others
+func LoadConstants(paths ...string) (map[string]interface{}, error) {
+	constants := map[string]interface{}{}
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, err
+		}
+		loaded := map[string]interface{}{}
+		if err := yaml.Unmarshal(data, &loaded); err != nil {
+			return nil, fmt.Errorf("invalid constants file %s: %w", path, err)
+		}
+		for name, value := range loaded {
+			constants[name] = value
+		}
+	}
+	return constants, nil
+}
+
+// ResolveConstants returns a copy of the workflow where the constants given by the caller are merged into the
+// workflow constants, inlined or read from a file by the parser, overriding the constants with the same name, and the
+// references to constants in the expressions are replaced with the constant values. An expression only referencing a
+// string constant, e.g. '${ $CONST.region }', takes the value of the constant, while the other references are
+// replaced with the value as a JSON literal, e.g. '${ .total > $CONST.limits.max }' becomes '${ .total > 100 }'.
+//
+// The merged constants stay in the workflow, for the expressions of the runtime to reference them.
+func ResolveConstants(workflow *model.Workflow, constants map[string]interface{}) (*model.Workflow, error) {
+	merged := map[string]interface{}{}
+	if workflow.Constants != nil {
+		for name, value := range workflow.Constants.Data {
+			var v interface{}
+			if err := json.Unmarshal(value, &v); err != nil {
+				return nil, fmt.Errorf("constant %s: %w", name, err)
+			}
+			merged[name] = v
+		}
+	}
+	for name, value := range constants {
+		merged[name] = value
+	}
+
+	resolved, err := rewriteStrings(workflow, func(s, path string) (string, error) {
+		return resolveConstantReferences(s, path, merged)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(merged) == 0 {
+		return resolved, nil
+	}
+	resolved.Constants = &model.Constants{Data: map[string]json.RawMessage{}}
+	for name, value := range merged {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("constant %s: %w", name, err)
+		}
+		resolved.Constants.Data[name] = data
+	}
+	return resolved, nil
+}
+
+func resolveConstantReferences(s, path string, constants map[string]interface{}) (string, error) {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "${") {
+		return s, nil
+	}
+	if match := constantExpression.FindStringSubmatch(trimmed); match != nil {
+		value, err := constantValue(match[1], path, constants)
+		if err != nil {
+			return "", err
+		}
+		if str, ok := value.(string); ok {
+			return str, nil
+		}
+	}
+	var err error
+	resolved := constantReference.ReplaceAllStringFunc(s, func(reference string) string {
+		if err != nil {
+			return reference
+		}
+		var value interface{}
+		if value, err = constantValue(strings.TrimPrefix(reference, "$CONST"), path, constants); err != nil {
+			return reference
+		}
+		literal, marshalErr := json.Marshal(value)
+		if marshalErr != nil {
+			err = fmt.Errorf("%s: %w", path, marshalErr)
+			return reference
+		}
+		return string(literal)
+	})
+	return resolved, err
+}
+
+// constantValue returns the value of the constant referenced by the keys, e.g. '.limits.max'
+func constantValue(keys, path string, constants map[string]interface{}) (interface{}, error) {
+	var value interface{} = constants
+	name := "$CONST"
+	for _, segment := range constantSegment.FindAllStringSubmatch(keys, -1) {
+		key := segment[1] + segment[2] + segment[3]
+		name += "." + key
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: constant %s not defined", path, name)
+		}
+		if value, ok = object[key]; !ok {
+			return nil, fmt.Errorf("%s: constant %s not defined", path, name)
+		}
+	}
+	return value, nil
+}
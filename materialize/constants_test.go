@@ -0,0 +1,109 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package materialize
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const constantsWorkflow = `id: order
+name: Order
+version: '1.0'
+specVersion: '0.8'
+start: Check
+constants:
+  region: us-east-1
+  limits:
+    max: 10
+functions:
+  - name: storeOrder
+    operation: https://orders.example.com/openapi.json#storeOrder
+states:
+  - name: Check
+    type: switch
+    dataConditions:
+      - condition: ${ .total > $CONST.limits.max }
+        transition: Store
+    defaultCondition:
+      end: true
+  - name: Store
+    type: operation
+    actions:
+      - functionRef:
+          refName: storeOrder
+          arguments:
+            region: ${ $CONST.region }
+            limits: ${ $CONST["limits"] }
+            currency: ${ $CONST.limits.currency }
+    end: true
+`
+
+func TestResolveConstants(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(constantsWorkflow))
+	require.NoError(t, err)
+	workflow.States[1].(*model.OperationState).Actions[0].FunctionRef.Arguments["currency"] = "${ .currency }"
+
+	resolved, err := ResolveConstants(workflow, nil)
+	require.NoError(t, err)
+	check := resolved.States[0].(*model.DataBasedSwitchState)
+	assert.Equal(t, "${ .total > 10 }", check.DataConditions[0].GetCondition())
+	arguments := resolved.States[1].(*model.OperationState).Actions[0].FunctionRef.Arguments
+	assert.Equal(t, "us-east-1", arguments["region"])
+	assert.Equal(t, `${ {"max":10} }`, arguments["limits"])
+	assert.Equal(t, json.RawMessage(`"us-east-1"`), resolved.Constants.Data["region"])
+
+	// the injected constants override the workflow ones
+	constants, err := LoadConstants("testdata/constants.yaml", "testdata/constants-prod.json")
+	require.NoError(t, err)
+	workflow.States[1].(*model.OperationState).Actions[0].FunctionRef.Arguments["currency"] = "${ $CONST.limits.currency }"
+	resolved, err = ResolveConstants(workflow, constants)
+	require.NoError(t, err)
+	check = resolved.States[0].(*model.DataBasedSwitchState)
+	assert.Equal(t, "${ .total > 1000 }", check.DataConditions[0].GetCondition())
+	arguments = resolved.States[1].(*model.OperationState).Actions[0].FunctionRef.Arguments
+	assert.Equal(t, "eu-west-1", arguments["region"])
+	assert.Equal(t, "EUR", arguments["currency"])
+	assert.JSONEq(t, `{"max": 1000, "currency": "EUR"}`, string(resolved.Constants.Data["limits"]))
+
+	_, err = ResolveConstants(workflow, nil)
+	assert.EqualError(t, err, "states[1].actions[0].functionRef.arguments.currency: constant $CONST.limits.currency not defined")
+
+	_, err = LoadConstants("testdata/missing.yaml")
+	assert.Error(t, err)
+}
+
+func TestMaterialize(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(securedWorkflow))
+	require.NoError(t, err)
+	materialized, err := Materialize(context.Background(), workflow, Options{
+		Constants: map[string]interface{}{"region": "eu-west-1"},
+		Secrets:   StaticSecrets{"username": "admin", "password": "p@ss", "token": "t1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "admin", materialized.Auth.Defs[0].Properties.(*model.BasicAuthProperties).Username)
+	assert.Equal(t, json.RawMessage(`"eu-west-1"`), materialized.Constants.Data["region"])
+
+	materialized, err = Materialize(context.Background(), workflow, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "${ $SECRETS.username }", materialized.Auth.Defs[0].Properties.(*model.BasicAuthProperties).Username)
+	assert.Nil(t, materialized.Constants)
+}
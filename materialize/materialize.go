@@ -0,0 +1,102 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package materialize
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// Options what to resolve when materializing a workflow
+type Options struct {
+	// Constants injected into the workflow constants, see ResolveConstants
+	Constants map[string]interface{}
+	// Secrets provider of the referenced secrets, the secrets are left unresolved if nil
+	Secrets SecretsProvider
+}
+
+// Materialize returns a copy of the workflow where the references to constants and, if a provider is given, secrets
+// are resolved
+func Materialize(ctx context.Context, workflow *model.Workflow, opts Options) (*model.Workflow, error) {
+	materialized, err := ResolveConstants(workflow, opts.Constants)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Secrets == nil {
+		return materialized, nil
+	}
+	return ResolveSecrets(ctx, materialized, opts.Secrets)
+}
+
+// rewriteStrings returns a copy of the workflow where the strings of the workflow document are rewritten, the
+// function being given the path of each string, e.g. 'auth[0].properties.password'
+func rewriteStrings(workflow *model.Workflow, rewrite func(s, path string) (string, error)) (*model.Workflow, error) {
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if document, err = rewriteValue(document, "", rewrite); err != nil {
+		return nil, err
+	}
+	if data, err = json.Marshal(document); err != nil {
+		return nil, err
+	}
+	rewritten := &model.Workflow{}
+	if err := json.Unmarshal(data, rewritten); err != nil {
+		return nil, err
+	}
+	return rewritten, nil
+}
+
+func rewriteValue(value interface{}, path string, rewrite func(s, path string) (string, error)) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			property := path + "." + key
+			if len(path) == 0 {
+				property = key
+			}
+			rewritten, err := rewriteValue(v[key], property, rewrite)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = rewritten
+		}
+	case []interface{}:
+		for i, element := range v {
+			rewritten, err := rewriteValue(element, fmt.Sprintf("%s[%d]", path, i), rewrite)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rewritten
+		}
+	case string:
+		return rewrite(v, path)
+	}
+	return value, nil
+}
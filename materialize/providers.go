@@ -0,0 +1,216 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package materialize
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+	vaultAddressEnv = "VAULT_ADDR"
+	vaultTokenEnv   = "VAULT_TOKEN"
+)
+
+// EnvSecrets provider reading the secrets from the environment variables
+type EnvSecrets struct {
+	// Prefix of the environment variables, e.g. 'SECRET_' to read the token secret from SECRET_token
+	Prefix string
+}
+
+// Secret ...
+func (e EnvSecrets) Secret(_ context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(e.Prefix + name)
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+// FileSecrets provider reading the secrets from the files of a directory named after the secrets, such as the
+// Kubernetes secrets mounted as a volume or the Docker secrets in /run/secrets. The trailing new line of the files is
+// ignored.
+type FileSecrets struct {
+	Dir string
+}
+
+// Secret ...
+func (f FileSecrets) Secret(_ context.Context, name string) (string, error) {
+	if len(name) == 0 || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid secret name %s", name)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(f.Dir, name))
+	if os.IsNotExist(err) {
+		return "", ErrSecretNotFound
+	} else if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r"), nil
+}
+
+// KubernetesSecrets provider reading the secrets from the keys of a Kubernetes Secret through the API server
+type KubernetesSecrets struct {
+	// Host URL of the API server, e.g. 'https://kubernetes.default.svc'
+	Host string
+	// Token bearer token authenticating the requests
+	Token string
+	// Namespace and Name of the Secret
+	Namespace string
+	Name      string
+	// Client sending the requests, http.DefaultClient if nil
+	Client *http.Client
+}
+
+// InClusterSecrets returns the provider reading the Secret with the given name in the namespace of the pod, with the
+// credentials of its service account
+func InClusterSecrets(name string) (*KubernetesSecrets, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if len(host) == 0 || len(port) == 0 {
+		return nil, fmt.Errorf("not running in a Kubernetes cluster")
+	}
+	token, err := ioutil.ReadFile(filepath.Join(serviceAccountDir, "token"))
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := ioutil.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+	if err != nil {
+		return nil, err
+	}
+	ca, err := ioutil.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("invalid service account certificate")
+	}
+	return &KubernetesSecrets{
+		Host:      "https://" + net.JoinHostPort(host, port),
+		Token:     strings.TrimSpace(string(token)),
+		Namespace: strings.TrimSpace(string(namespace)),
+		Name:      name,
+		Client:    &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}}},
+	}, nil
+}
+
+// Secret returns the value of the key of the Secret with the same name
+func (k *KubernetesSecrets) Secret(ctx context.Context, name string) (string, error) {
+	secret := struct {
+		// the API server encodes the values in base64, decoded by json.Unmarshal for byte slices
+		Data map[string][]byte `json:"data"`
+	}{}
+	endpoint := fmt.Sprintf("%s/api/v1/namespaces/%s/secrets/%s", strings.TrimSuffix(k.Host, "/"), url.PathEscape(k.Namespace), url.PathEscape(k.Name))
+	if err := getJSON(ctx, k.Client, endpoint, map[string]string{"Authorization": "Bearer " + k.Token}, &secret); err != nil {
+		return "", fmt.Errorf("secret %s/%s: %w", k.Namespace, k.Name, err)
+	}
+	value, ok := secret.Data[name]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return string(value), nil
+}
+
+// VaultSecrets provider reading the secrets from the keys of a HashiCorp Vault secret, stored by either version of
+// the key/value secrets engine
+type VaultSecrets struct {
+	// Address of the Vault server, e.g. 'https://vault.example.com:8200'
+	Address string
+	// Token Vault token authenticating the requests
+	Token string
+	// Path of the secret, including the mount path, e.g. 'secret/data/workflows/order' with the version 2 of the
+	// engine mounted in secret
+	Path string
+	// Client sending the requests, http.DefaultClient if nil
+	Client *http.Client
+}
+
+// NewVaultSecrets returns the provider reading the secret in the given path from the Vault server of the VAULT_ADDR
+// environment variable, authenticated by the VAULT_TOKEN one
+func NewVaultSecrets(path string) (*VaultSecrets, error) {
+	address, token := os.Getenv(vaultAddressEnv), os.Getenv(vaultTokenEnv)
+	if len(address) == 0 || len(token) == 0 {
+		return nil, fmt.Errorf("%s and %s are required", vaultAddressEnv, vaultTokenEnv)
+	}
+	return &VaultSecrets{Address: address, Token: token, Path: path}, nil
+}
+
+// Secret returns the value of the key of the Vault secret with the same name
+func (v *VaultSecrets) Secret(ctx context.Context, name string) (string, error) {
+	secret := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	endpoint := strings.TrimSuffix(v.Address, "/") + path.Join("/v1", v.Path)
+	if err := getJSON(ctx, v.Client, endpoint, map[string]string{"X-Vault-Token": v.Token}, &secret); err != nil {
+		return "", fmt.Errorf("vault secret %s: %w", v.Path, err)
+	}
+	data := secret.Data
+	// the version 2 of the engine nests the keys with the metadata of the version
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	value, ok := data[name]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, endpoint string, headers map[string]string, v interface{}) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return ErrSecretNotFound
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", response.Status)
+	}
+	return json.NewDecoder(response.Body).Decode(v)
+}
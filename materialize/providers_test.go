@@ -0,0 +1,122 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package materialize
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvSecrets(t *testing.T) {
+	require.NoError(t, os.Setenv("SDK_GO_SECRET_token", "t1"))
+	defer os.Unsetenv("SDK_GO_SECRET_token")
+	provider := EnvSecrets{Prefix: "SDK_GO_SECRET_"}
+	value, err := provider.Secret(context.Background(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, "t1", value)
+	_, err = provider.Secret(context.Background(), "password")
+	assert.Equal(t, ErrSecretNotFound, err)
+}
+
+func TestFileSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "token"), []byte("t1\n"), 0600))
+
+	provider := FileSecrets{Dir: dir}
+	value, err := provider.Secret(context.Background(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, "t1", value)
+	_, err = provider.Secret(context.Background(), "password")
+	assert.Equal(t, ErrSecretNotFound, err)
+	_, err = provider.Secret(context.Background(), "../token")
+	assert.EqualError(t, err, "invalid secret name ../token")
+}
+
+func TestKubernetesSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sa-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/api/v1/namespaces/orders/secrets/order-secrets" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"kind": "Secret", "data": {"token": "dDE="}}`))
+	}))
+	defer server.Close()
+
+	provider := &KubernetesSecrets{Host: server.URL, Token: "sa-token", Namespace: "orders", Name: "order-secrets"}
+	value, err := provider.Secret(context.Background(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, "t1", value)
+	_, err = provider.Secret(context.Background(), "password")
+	assert.Equal(t, ErrSecretNotFound, err)
+
+	provider.Name = "missing"
+	_, err = provider.Secret(context.Background(), "token")
+	assert.True(t, errors.Is(err, ErrSecretNotFound))
+	provider.Token = "invalid"
+	_, err = provider.Secret(context.Background(), "token")
+	assert.EqualError(t, err, "secret orders/missing: unexpected status 401 Unauthorized")
+}
+
+func TestVaultSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/order":
+			_, _ = w.Write([]byte(`{"data": {"data": {"token": "t2", "port": 8080}, "metadata": {"version": 1}}}`))
+		case "/v1/kv/order":
+			_, _ = w.Write([]byte(`{"data": {"token": "t1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := &VaultSecrets{Address: server.URL, Token: "vault-token", Path: "kv/order"}
+	value, err := provider.Secret(context.Background(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, "t1", value)
+
+	provider.Path = "secret/data/order"
+	value, err = provider.Secret(context.Background(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, "t2", value)
+	value, err = provider.Secret(context.Background(), "port")
+	require.NoError(t, err)
+	assert.Equal(t, "8080", value)
+	_, err = provider.Secret(context.Background(), "password")
+	assert.Equal(t, ErrSecretNotFound, err)
+
+	provider.Token = "invalid"
+	_, err = provider.Secret(context.Background(), "token")
+	assert.EqualError(t, err, "vault secret secret/data/order: unexpected status 403 Forbidden")
+}
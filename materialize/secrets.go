@@ -0,0 +1,132 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package materialize produces the concrete workflow run by a runtime from a workflow definition, resolving what the
+// definition only references, such as the constants and the secrets, so the definitions can be shared and stored
+// without the secrets.
+package materialize
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// ErrSecretNotFound returned by the providers not holding the requested secret
+var ErrSecretNotFound = errors.New("secret not found")
+
+var (
+	// secretReference reference to a secret, e.g. '$SECRETS.token' or '$SECRETS["token"]'
+	secretReference = regexp.MustCompile(`\$SECRETS(?:\.([\w-]+)|\[\s*["']([^"']+)["']\s*\])`)
+	// secretExpression expression only referencing a secret, e.g. '${ $SECRETS.token }'
+	secretExpression = regexp.MustCompile(`^\$\{\s*` + secretReference.String() + `\s*\}$`)
+)
+
+// SecretsProvider provides the values of the secrets referenced by the workflows, e.g. EnvSecrets,
+// KubernetesSecrets or VaultSecrets
+type SecretsProvider interface {
+	// Secret returns the value of the secret, ErrSecretNotFound if the provider doesn't hold it
+	Secret(ctx context.Context, name string) (string, error)
+}
+
+// SecretsProviders provider looking for the secrets in each provider in turn
+type SecretsProviders []SecretsProvider
+
+// Secret returns the value of the first provider holding the secret
+func (p SecretsProviders) Secret(ctx context.Context, name string) (string, error) {
+	for _, provider := range p {
+		value, err := provider.Secret(ctx, name)
+		if !errors.Is(err, ErrSecretNotFound) {
+			return value, err
+		}
+	}
+	return "", ErrSecretNotFound
+}
+
+// StaticSecrets provider holding the secrets in memory, e.g. for tests
+type StaticSecrets map[string]string
+
+// Secret ...
+func (s StaticSecrets) Secret(_ context.Context, name string) (string, error) {
+	value, ok := s[name]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+// ResolveSecrets returns a copy of the workflow where the references to secrets are replaced with the values of the
+// provider, e.g. in the auth properties, the headers of the metadata or the arguments of the functions. A property
+// only referencing a secret, '${ $SECRETS.token }' or '$SECRETS.token', takes the value of the secret, while the
+// references within an expression, e.g. '${ "Bearer " + $SECRETS.token }', are replaced with the value as a string
+// literal. The secrets are resolved when materializing the workflow run by a runtime, the definition itself staying
+// secret-free.
+func ResolveSecrets(ctx context.Context, workflow *model.Workflow, provider SecretsProvider) (*model.Workflow, error) {
+	r := &secretsResolver{ctx: ctx, provider: provider, values: map[string]string{}}
+	return rewriteStrings(workflow, r.resolveString)
+}
+
+type secretsResolver struct {
+	ctx      context.Context
+	provider SecretsProvider
+	// values of the secrets already resolved, each secret being requested once
+	values map[string]string
+}
+
+func (r *secretsResolver) resolveString(s, path string) (string, error) {
+	trimmed := strings.TrimSpace(s)
+	match := secretExpression.FindStringSubmatch(trimmed)
+	if match == nil {
+		if match = secretReference.FindStringSubmatch(trimmed); match != nil && match[0] != trimmed {
+			match = nil
+		}
+	}
+	if match != nil {
+		return r.secret(match[1]+match[2], path)
+	}
+	if !strings.HasPrefix(trimmed, "${") {
+		return s, nil
+	}
+	var err error
+	resolved := secretReference.ReplaceAllStringFunc(s, func(reference string) string {
+		if err != nil {
+			return reference
+		}
+		m := secretReference.FindStringSubmatch(reference)
+		var value string
+		if value, err = r.secret(m[1]+m[2], path); err != nil {
+			return reference
+		}
+		literal, _ := json.Marshal(value)
+		return string(literal)
+	})
+	return resolved, err
+}
+
+func (r *secretsResolver) secret(name, path string) (string, error) {
+	if value, ok := r.values[name]; ok {
+		return value, nil
+	}
+	value, err := r.provider.Secret(r.ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("%s: secret %s: %w", path, name, err)
+	}
+	r.values[name] = value
+	return value, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package materialize
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const securedWorkflow = `id: order
+name: Order
+version: '1.0'
+specVersion: '0.8'
+start: Store
+secrets:
+  - username
+  - password
+  - token
+auth:
+  - name: basic
+    scheme: basic
+    properties:
+      username: ${ $SECRETS.username }
+      password: $SECRETS.password
+functions:
+  - name: storeOrder
+    operation: https://orders.example.com/openapi.json#storeOrder
+    authRef: basic
+    metadata:
+      Authorization: ${ "Bearer " + $SECRETS["token"] }
+states:
+  - name: Store
+    type: operation
+    actions:
+      - functionRef:
+          refName: storeOrder
+          arguments:
+            order: ${ .order }
+            apiKey: ${ $SECRETS.token }
+            headers:
+              X-Token: ${ $SECRETS.token }
+    end: true
+`
+
+type countingSecrets struct {
+	StaticSecrets
+	calls int
+}
+
+func (c *countingSecrets) Secret(ctx context.Context, name string) (string, error) {
+	c.calls++
+	return c.StaticSecrets.Secret(ctx, name)
+}
+
+func TestResolveSecrets(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(securedWorkflow))
+	require.NoError(t, err)
+	provider := &countingSecrets{StaticSecrets: StaticSecrets{"username": "admin", "password": "p@ss", "token": `t"1`}}
+
+	resolved, err := ResolveSecrets(context.Background(), workflow, provider)
+	require.NoError(t, err)
+	assert.Equal(t, 3, provider.calls)
+	properties := resolved.Auth.Defs[0].Properties.(*model.BasicAuthProperties)
+	assert.Equal(t, "admin", properties.Username)
+	assert.Equal(t, "p@ss", properties.Password)
+	assert.Equal(t, `${ "Bearer " + "t\"1" }`, resolved.Functions[0].Metadata["Authorization"])
+	arguments := resolved.States[0].(*model.OperationState).Actions[0].FunctionRef.Arguments
+	assert.Equal(t, "${ .order }", arguments["order"])
+	assert.Equal(t, `t"1`, arguments["apiKey"])
+	assert.Equal(t, map[string]interface{}{"X-Token": `t"1`}, arguments["headers"])
+
+	// the definition is left secret-free
+	properties = workflow.Auth.Defs[0].Properties.(*model.BasicAuthProperties)
+	assert.Equal(t, "${ $SECRETS.username }", properties.Username)
+
+	_, err = ResolveSecrets(context.Background(), workflow, StaticSecrets{"username": "admin", "password": "p@ss"})
+	assert.EqualError(t, err, "functions[0].metadata.Authorization: secret token: secret not found")
+	assert.True(t, errors.Is(err, ErrSecretNotFound))
+}
+
+func TestSecretsProviders(t *testing.T) {
+	providers := SecretsProviders{StaticSecrets{"token": "first"}, StaticSecrets{"token": "second", "password": "p@ss"}}
+	value, err := providers.Secret(context.Background(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, "first", value)
+	value, err = providers.Secret(context.Background(), "password")
+	require.NoError(t, err)
+	assert.Equal(t, "p@ss", value)
+	_, err = providers.Secret(context.Background(), "username")
+	assert.Equal(t, ErrSecretNotFound, err)
+}
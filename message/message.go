@@ -0,0 +1,198 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package message renders the messages of the validations and the lint rules from templates keyed by stable IDs, so
+// that they can be translated for the workflow authors while the tools keep matching them by their ID. The templates
+// are in the text/template syntax, the arguments of the messages being their data.
+package message
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"sigs.k8s.io/yaml"
+)
+
+// IDs of the messages. The IDs are stable, the messages aren't.
+const (
+	// Validation field failing a validate tag, with the field, tag and param arguments. The messages of the tags are
+	// identified by the tag after it, e.g. validate.required, and default to it.
+	Validation = "validate"
+	// DuplicateName definition named like another one of the same kind, with the kind and name arguments
+	DuplicateName = "integrity.duplicate-name"
+	// UndefinedReference reference to an undefined definition, with the kind, name and suggestions arguments
+	UndefinedReference = "integrity.undefined-reference"
+	// EventKind reference to an event of the wrong kind, with the name, expected and actual arguments
+	EventKind = "integrity.event-kind"
+	// Lint issue of a lint rule, identified by the name of the rule after it, e.g. lint.naming
+	Lint = "lint"
+)
+
+// Message message identified by its ID, rendered with its arguments from the template of the ID
+type Message struct {
+	ID string
+	// Args values of the template fields by name
+	Args map[string]interface{}
+}
+
+// Translator hook translating the messages, e.g. a Catalog of a language or a function looking up the templates of
+// the language of the user
+type Translator interface {
+	// Template returns the template of the message ID, false if it's not translated
+	Template(id string) (string, bool)
+}
+
+// TranslatorFunc function used as a Translator
+type TranslatorFunc func(id string) (string, bool)
+
+// Template ...
+func (f TranslatorFunc) Template(id string) (string, bool) {
+	return f(id)
+}
+
+// Catalog templates of the messages by ID, in one language
+type Catalog map[string]string
+
+// Template ...
+func (c Catalog) Template(id string) (string, bool) {
+	text, ok := c[id]
+	return text, ok
+}
+
+// English templates of the messages of the SDK, rendering the messages when not translated
+var English = Catalog{
+	Validation:                  "failed on the '{{.tag}}' validation",
+	DuplicateName:               "duplicated {{.kind}} name {{.name}}",
+	UndefinedReference:          `{{.kind}} {{.name}} is not defined{{if .suggestions}}, did you mean {{list .suggestions ", " " or "}}?{{end}}`,
+	EventKind:                   "event {{.name}} must be {{.expected}} but is {{.actual}}",
+	Lint + ".naming":            "{{.kind}} name {{quote .name}} does not match {{.pattern}}",
+	Lint + ".max-states":        "workflow defines {{.count}} states, the maximum is {{.max}}",
+	Lint + ".required-metadata": "metadata key {{.key}} is required",
+	Lint + ".no-inline-secrets": "inline credential, use a workflow secret instead",
+	Lint + ".rest-retries":      "action invoking REST function {{.function}} has no retry policy",
+}
+
+// functions of the templates
+var functions = template.FuncMap{
+	// quote quotes the value like %q
+	"quote": func(value interface{}) string { return fmt.Sprintf("%q", value) },
+	// join joins the values with the separator
+	"join": func(values []string, sep string) string { return strings.Join(values, sep) },
+	// list joins the values with the separator, the last two with the last separator, e.g. 'a, b or c'
+	"list": func(values []string, sep, last string) string {
+		if len(values) < 2 {
+			return strings.Join(values, sep)
+		}
+		return strings.Join(values[:len(values)-1], sep) + last + values[len(values)-1]
+	},
+}
+
+// templates parsed templates by text
+var templates sync.Map
+
+func parse(text string) (*template.Template, error) {
+	if t, ok := templates.Load(text); ok {
+		return t.(*template.Template), nil
+	}
+	t, err := template.New("message").Funcs(functions).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	templates.Store(text, t)
+	return t, nil
+}
+
+// Render renders the message with the template of the translator, the English one if the translator is nil or its
+// template fails to render. The messages without template have the template of their parent ID, e.g. validate for
+// validate.required. Render returns false if there's no template at all, e.g. for the issues of custom lint rules.
+func (m Message) Render(t Translator) (string, bool) {
+	for _, translator := range []Translator{t, English} {
+		if translator == nil {
+			continue
+		}
+		for id := m.ID; len(id) > 0; id = parent(id) {
+			text, ok := translator.Template(id)
+			if !ok {
+				continue
+			}
+			if rendered, err := render(text, m.Args); err == nil {
+				return rendered, true
+			}
+			break
+		}
+	}
+	return "", false
+}
+
+// String renders the message in English, or as its ID and arguments if it has no template
+func (m Message) String() string {
+	if rendered, ok := m.Render(nil); ok {
+		return rendered
+	}
+	keys := make([]string, 0, len(m.Args))
+	for key := range m.Args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(m.ID)
+	for _, key := range keys {
+		fmt.Fprintf(&b, " %s=%v", key, m.Args[key])
+	}
+	return b.String()
+}
+
+func render(text string, args map[string]interface{}) (string, error) {
+	t, err := parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, args); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// parent returns the parent of the message ID, empty if it has none
+func parent(id string) string {
+	if i := strings.LastIndexByte(id, '.'); i >= 0 {
+		return id[:i]
+	}
+	return ""
+}
+
+// LoadCatalog reads the catalog of the YAML or JSON file mapping the message IDs to their template, e.g. a
+// translation of the English catalog. The templates must parse.
+func LoadCatalog(path string) (Catalog, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var catalog Catalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("message catalog %s: %w", path, err)
+	}
+	for id, text := range catalog {
+		if _, err := parse(text); err != nil {
+			return nil, fmt.Errorf("message catalog %s: template of %s: %w", path, id, err)
+		}
+	}
+	return catalog, nil
+}
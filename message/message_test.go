@@ -0,0 +1,78 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	undefined := Message{ID: UndefinedReference, Args: map[string]interface{}{"kind": "state", "name": "x", "suggestions": []string{"a", "b", "c"}}}
+	assert.Equal(t, "state x is not defined, did you mean a, b or c?", undefined.String())
+	undefined.Args["suggestions"] = []string(nil)
+	assert.Equal(t, "state x is not defined", undefined.String())
+
+	french := Catalog{
+		UndefinedReference: `{{.name}} n'est pas défini{{if .suggestions}}, vouliez-vous dire {{list .suggestions ", " " ou "}} ?{{end}}`,
+		Validation:         "a échoué à la validation '{{.tag}}'",
+		Lint + ".naming":   "{{.name",
+	}
+	undefined.Args["suggestions"] = []string{"a", "b"}
+	rendered, ok := undefined.Render(french)
+	assert.True(t, ok)
+	assert.Equal(t, "x n'est pas défini, vouliez-vous dire a ou b ?", rendered)
+
+	// the parent template
+	rendered, _ = Message{ID: Validation + ".required", Args: map[string]interface{}{"tag": "required"}}.Render(french)
+	assert.Equal(t, "a échoué à la validation 'required'", rendered)
+	rendered, _ = Message{ID: Validation + ".required", Args: map[string]interface{}{"tag": "required"}}.Render(nil)
+	assert.Equal(t, "failed on the 'required' validation", rendered)
+
+	// English when not translated or failing
+	naming := Message{ID: Lint + ".naming", Args: map[string]interface{}{"kind": "state", "name": "a b", "pattern": "^[a-z]+$"}}
+	rendered, _ = naming.Render(french)
+	assert.Equal(t, `state name "a b" does not match ^[a-z]+$`, rendered)
+	rendered, _ = Message{ID: EventKind, Args: map[string]interface{}{"name": "e", "expected": "produced", "actual": "consumed"}}.Render(french)
+	assert.Equal(t, "event e must be produced but is consumed", rendered)
+
+	custom := Message{ID: Lint + ".custom", Args: map[string]interface{}{"b": 2, "a": 1}}
+	_, ok = custom.Render(TranslatorFunc(func(string) (string, bool) { return "", false }))
+	assert.False(t, ok)
+	assert.Equal(t, "lint.custom a=1 b=2", custom.String())
+}
+
+func TestLoadCatalog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "message")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fr.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte("integrity.duplicate-name: \"nom {{.name}} en double\"\n"), 0600))
+	catalog, err := LoadCatalog(path)
+	require.NoError(t, err)
+	rendered, _ := Message{ID: DuplicateName, Args: map[string]interface{}{"kind": "state", "name": "a"}}.Render(catalog)
+	assert.Equal(t, "nom a en double", rendered)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("integrity.duplicate-name: \"{{.name\"\n"), 0600))
+	_, err = LoadCatalog(path)
+	assert.Error(t, err)
+	_, err = LoadCatalog(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"expvar"
+	"math"
+	"strconv"
+)
+
+// Expvar returns the expvar variable of the registry, to be published, e.g. expvar.Publish("workflows", r.Expvar()).
+// It's an object of the counters and histograms by name, each histogram holding its count, sum, cumulative buckets by
+// upper bound and its estimated median and 99th percentile.
+func (r *Registry) Expvar() expvar.Var {
+	return expvar.Func(func() interface{} {
+		counters, histograms, names := r.snapshot()
+		values := make(map[string]interface{}, len(names))
+		for _, name := range names {
+			if value, ok := counters[name]; ok {
+				values[name] = value
+				continue
+			}
+			h := histograms[name]
+			buckets := make(map[string]uint64, len(h.Counts))
+			var cumulative uint64
+			for i, count := range h.Counts {
+				cumulative += count
+				buckets[bound(h.Bounds, i)] = cumulative
+			}
+			values[name] = map[string]interface{}{
+				"count":   h.Count,
+				"sum":     h.Sum,
+				"buckets": buckets,
+				"p50":     finite(h.Quantile(0.5)),
+				"p99":     finite(h.Quantile(0.99)),
+			}
+		}
+		return values
+	})
+}
+
+// bound returns the upper bound of the bucket i as a Prometheus label, +Inf for the last bucket
+func bound(bounds []float64, i int) string {
+	if i == len(bounds) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(bounds[i], 'g', -1, 64)
+}
+
+// finite returns nil for NaN, which JSON can't encode
+func finite(f float64) interface{} {
+	if math.IsNaN(f) {
+		return nil
+	}
+	return f
+}
@@ -0,0 +1,174 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics measures the parsing and validation of the workflows, for the services embedding the SDK to monitor
+// them. The parser reports its measures to a Recorder, e.g. a Registry exported through expvar or to Prometheus.
+package metrics
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Names of the measures of the parser. The counters end with _total, the histograms with their unit.
+const (
+	// Parses counter of the workflows parsed, successfully or not
+	Parses = "workflow_parses_total"
+	// ParseFailures counter of the workflows that failed to parse, invalid ones included
+	ParseFailures = "workflow_parse_failures_total"
+	// ParseDuration histogram of the durations of the parses, validation included
+	ParseDuration = "workflow_parse_duration_seconds"
+	// ParseSize histogram of the sizes of the sources parsed, the streamed and incremental ones excluded
+	ParseSize = "workflow_parse_size_bytes"
+	// ValidationFailures counter of the workflows decoded but invalid
+	ValidationFailures = "workflow_validation_failures_total"
+	// ValidationDuration histogram of the durations of the validations of the decoded workflows
+	ValidationDuration = "workflow_validation_duration_seconds"
+	// CacheHits counter of the workflows found in the cache of a parser.Cache
+	CacheHits = "workflow_cache_hits_total"
+	// CacheMisses counter of the workflows parsed by a parser.Cache
+	CacheMisses = "workflow_cache_misses_total"
+)
+
+// Recorder receives the measures. The implementations must be safe for concurrent use, and cheap: the measures are
+// recorded while parsing.
+type Recorder interface {
+	// Add adds the delta to the counter of the given name
+	Add(name string, delta float64)
+	// Observe records the value in the histogram of the given name
+	Observe(name string, value float64)
+}
+
+var (
+	// DurationBuckets upper bounds in seconds of the buckets of the histograms of durations
+	DurationBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+	// SizeBuckets upper bounds in bytes of the buckets of the histograms of sizes
+	SizeBuckets = []float64{1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20, 64 << 20}
+)
+
+// Registry Recorder keeping the counters and histograms in memory, to be exported with its expvar or Prometheus
+// adapters. The histograms whose name ends with _bytes have the SizeBuckets, the others the DurationBuckets.
+type Registry struct {
+	mu         sync.Mutex
+	counters   map[string]float64
+	histograms map[string]*Histogram
+}
+
+// Histogram values observed, counted in buckets
+type Histogram struct {
+	// Bounds upper bounds of the buckets, increasing, the last bucket having no upper bound
+	Bounds []float64
+	// Counts number of values of each bucket, not cumulative, with the values greater than the last bound
+	Counts []uint64
+	Count  uint64
+	Sum    float64
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{counters: map[string]float64{}, histograms: map[string]*Histogram{}}
+}
+
+// Add implements Recorder
+func (r *Registry) Add(name string, delta float64) {
+	r.mu.Lock()
+	r.counters[name] += delta
+	r.mu.Unlock()
+}
+
+// Observe implements Recorder
+func (r *Registry) Observe(name string, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.histograms[name]
+	if !ok {
+		bounds := DurationBuckets
+		if strings.HasSuffix(name, "_bytes") {
+			bounds = SizeBuckets
+		}
+		h = &Histogram{Bounds: bounds, Counts: make([]uint64, len(bounds)+1)}
+		r.histograms[name] = h
+	}
+	h.Counts[sort.SearchFloat64s(h.Bounds, value)]++
+	h.Count++
+	h.Sum += value
+}
+
+// Counter returns the value of the counter of the given name, 0 if never added to
+func (r *Registry) Counter(name string) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.counters[name]
+}
+
+// Histogram returns a copy of the histogram of the given name, false if nothing was observed
+func (r *Registry) Histogram(name string) (Histogram, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.histograms[name]
+	if !ok {
+		return Histogram{}, false
+	}
+	return h.copy(), true
+}
+
+// snapshot copies the counters and histograms, along with their names sorted
+func (r *Registry) snapshot() (map[string]float64, map[string]Histogram, []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counters := make(map[string]float64, len(r.counters))
+	histograms := make(map[string]Histogram, len(r.histograms))
+	names := make([]string, 0, len(r.counters)+len(r.histograms))
+	for name, value := range r.counters {
+		counters[name] = value
+		names = append(names, name)
+	}
+	for name, h := range r.histograms {
+		histograms[name] = h.copy()
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return counters, histograms, names
+}
+
+func (h *Histogram) copy() Histogram {
+	return Histogram{Bounds: h.Bounds, Counts: append([]uint64(nil), h.Counts...), Count: h.Count, Sum: h.Sum}
+}
+
+// Quantile estimates the value below which the given fraction of the values observed are, interpolating within its
+// bucket like Prometheus does. Returns NaN if nothing was observed, the last bound if the quantile is beyond it.
+func (h Histogram) Quantile(q float64) float64 {
+	if h.Count == 0 {
+		return math.NaN()
+	}
+	rank := q * float64(h.Count)
+	var cumulative uint64
+	for i, count := range h.Counts {
+		if float64(cumulative+count) < rank || count == 0 {
+			cumulative += count
+			continue
+		}
+		if i == len(h.Bounds) {
+			break
+		}
+		lower := 0.0
+		if i > 0 {
+			lower = h.Bounds[i-1]
+		}
+		return lower + (h.Bounds[i]-lower)*(rank-float64(cumulative))/float64(count)
+	}
+	return h.Bounds[len(h.Bounds)-1]
+}
@@ -0,0 +1,110 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"encoding/json"
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	r.Add(Parses, 1)
+	r.Add(Parses, 2)
+	r.Observe(ParseDuration, 0.003)
+	r.Observe(ParseDuration, 20)
+	r.Observe(ParseSize, 2000)
+
+	assert.Equal(t, float64(3), r.Counter(Parses))
+	assert.Zero(t, r.Counter(ParseFailures))
+
+	duration, ok := r.Histogram(ParseDuration)
+	require.True(t, ok)
+	assert.Equal(t, DurationBuckets, duration.Bounds)
+	assert.Equal(t, uint64(2), duration.Count)
+	assert.Equal(t, 20.003, duration.Sum)
+	assert.Equal(t, uint64(1), duration.Counts[3])
+	assert.Equal(t, uint64(1), duration.Counts[len(DurationBuckets)])
+
+	size, ok := r.Histogram(ParseSize)
+	require.True(t, ok)
+	assert.Equal(t, SizeBuckets, size.Bounds)
+	assert.Equal(t, uint64(1), size.Counts[1])
+
+	_, ok = r.Histogram(ValidationDuration)
+	assert.False(t, ok)
+}
+
+func TestQuantile(t *testing.T) {
+	h := Histogram{Bounds: []float64{1, 2, 4}, Counts: []uint64{2, 0, 2, 0}, Count: 4}
+	assert.Equal(t, 0.5, h.Quantile(0.25))
+	assert.Equal(t, float64(1), h.Quantile(0.5))
+	assert.Equal(t, float64(4), h.Quantile(1))
+
+	h.Counts[3] = 4
+	h.Count = 8
+	assert.Equal(t, float64(4), h.Quantile(0.99))
+
+	assert.True(t, math.IsNaN(Histogram{Bounds: []float64{1}, Counts: []uint64{0, 0}}.Quantile(0.5)))
+}
+
+func TestWritePrometheus(t *testing.T) {
+	r := NewRegistry()
+	r.Add(CacheHits, 2)
+	r.Observe(ValidationDuration, 0.002)
+
+	recorder := httptest.NewRecorder()
+	r.PrometheusHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, prometheusContentType, recorder.Header().Get("Content-Type"))
+	body := recorder.Body.String()
+	assert.True(t, strings.HasPrefix(body, "# TYPE workflow_cache_hits_total counter\nworkflow_cache_hits_total 2\n"), body)
+	assert.Contains(t, body, "# TYPE workflow_validation_duration_seconds histogram\n")
+	assert.Contains(t, body, `workflow_validation_duration_seconds_bucket{le="0.001"} 0`+"\n")
+	assert.Contains(t, body, `workflow_validation_duration_seconds_bucket{le="0.0025"} 1`+"\n")
+	assert.Contains(t, body, `workflow_validation_duration_seconds_bucket{le="+Inf"} 1`+"\n")
+	assert.Contains(t, body, "workflow_validation_duration_seconds_sum 0.002\n")
+	assert.Contains(t, body, "workflow_validation_duration_seconds_count 1\n")
+}
+
+func TestExpvar(t *testing.T) {
+	r := NewRegistry()
+	v := r.Expvar()
+	assert.Equal(t, "{}", v.String())
+
+	r.Add(Parses, 1)
+	r.Observe(ParseSize, 100)
+	var values struct {
+		Parses float64 `json:"workflow_parses_total"`
+		Size   struct {
+			Count   uint64            `json:"count"`
+			Sum     float64           `json:"sum"`
+			Buckets map[string]uint64 `json:"buckets"`
+			P50     float64           `json:"p50"`
+		} `json:"workflow_parse_size_bytes"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(v.String()), &values))
+	assert.Equal(t, float64(1), values.Parses)
+	assert.Equal(t, uint64(1), values.Size.Count)
+	assert.Equal(t, float64(100), values.Size.Sum)
+	assert.Equal(t, uint64(1), values.Size.Buckets["1024"])
+	assert.Equal(t, uint64(1), values.Size.Buckets["+Inf"])
+	assert.Equal(t, float64(512), values.Size.P50)
+}
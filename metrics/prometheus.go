@@ -0,0 +1,60 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// prometheusContentType content type of the Prometheus text format
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// WritePrometheus writes the counters and histograms in the Prometheus text format, sorted by name
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	counters, histograms, names := r.snapshot()
+	out := bufio.NewWriter(w)
+	for _, name := range names {
+		if value, ok := counters[name]; ok {
+			out.WriteString("# TYPE " + name + " counter\n")
+			out.WriteString(name + " " + formatValue(value) + "\n")
+			continue
+		}
+		h := histograms[name]
+		out.WriteString("# TYPE " + name + " histogram\n")
+		var cumulative uint64
+		for i, count := range h.Counts {
+			cumulative += count
+			out.WriteString(name + `_bucket{le="` + bound(h.Bounds, i) + `"} ` + strconv.FormatUint(cumulative, 10) + "\n")
+		}
+		out.WriteString(name + "_sum " + formatValue(h.Sum) + "\n")
+		out.WriteString(name + "_count " + strconv.FormatUint(h.Count, 10) + "\n")
+	}
+	return out.Flush()
+}
+
+// PrometheusHandler returns the handler serving the metrics of the registry to Prometheus, e.g. on /metrics
+func (r *Registry) PrometheusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", prometheusContentType)
+		_ = r.WritePrometheus(w)
+	})
+}
+
+func formatValue(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
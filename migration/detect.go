@@ -0,0 +1,210 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v2/internal/document"
+)
+
+// Detection specification version of a workflow document
+type Detection struct {
+	// Version specification version of the document, e.g. '0.8', empty if it's neither declared nor inferred
+	Version string
+	// Declared tells whether the version is the specVersion of the document, inferred from the properties otherwise
+	Declared bool
+	// Evidence paths of the properties the version was inferred from, e.g. 'states[0].actions[0].eventRef.produceEventRef'
+	Evidence []string
+}
+
+// marker property telling the specification versions of the documents defining it
+type marker struct {
+	// since version the property was introduced in
+	since string
+	// until last version defining the property, removed in the next one
+	until string
+}
+
+// markers properties introduced or removed by a specification version, keyed by their name prefixed by the name of
+// the property holding them, e.g. 'functionRef.arguments', and suffixed by their value when it tells the version,
+// e.g. 'states.type=sleep'. The members of the workflow are prefixed by a dot.
+var markers = map[string]marker{
+	".execTimeout":                     {until: "0.6"},
+	"states.type=delay":                {until: "0.6"},
+	"states.type=subflow":              {until: "0.6"},
+	"states.default":                   {until: "0.6"},
+	"states.completionType=and":        {until: "0.6"},
+	"states.completionType=xor":        {until: "0.6"},
+	"states.completionType=n_of_m":     {until: "0.6"},
+	"stateDataFilter.dataInputPath":    {until: "0.6"},
+	"stateDataFilter.dataOutputPath":   {until: "0.6"},
+	"eventDataFilter.dataOutputPath":   {until: "0.6"},
+	"actionDataFilter.dataResultsPath": {until: "0.6"},
+	"functionRef.parameters":           {until: "0.6"},
+	"onErrors.error":                   {until: "0.6"},
+	"end.kind":                         {until: "0.6"},
+	"eventRef.triggerEventRef":         {until: "0.7"},
+	"eventRef.resultEventRef":          {until: "0.7"},
+	".timeouts":                        {since: "0.7"},
+	".errors":                          {since: "0.7"},
+	"states.type=sleep":                {since: "0.7"},
+	"states.defaultCondition":          {since: "0.7"},
+	"stateDataFilter.input":            {since: "0.7"},
+	"stateDataFilter.output":           {since: "0.7"},
+	"actionDataFilter.results":         {since: "0.7"},
+	"functionRef.arguments":            {since: "0.7"},
+	"onErrors.errorRef":                {since: "0.7"},
+	"onErrors.errorRefs":               {since: "0.7"},
+	".key":                             {since: "0.8"},
+	".extensions":                      {since: "0.8"},
+	"states.mode":                      {since: "0.8"},
+	"actions.sleep":                    {since: "0.8"},
+	"eventRef.produceEventRef":         {since: "0.8"},
+	"eventRef.consumeEventRef":         {since: "0.8"},
+	"eventRef.invoke":                  {since: "0.8"},
+	"functionRef.invoke":               {since: "0.8"},
+	"subFlowRef.invoke":                {since: "0.8"},
+	"end.continueAs":                   {since: "0.8"},
+}
+
+// DetectSpecVersion returns the specification version of the JSON or YAML workflow document without parsing it into
+// a workflow: the declared specVersion, or the version inferred from the properties introduced or removed by the
+// versions if there is none, the oldest version defining all the properties introduced. The version of the JSON
+// documents declaring it is read without decoding the rest of the document.
+func DetectSpecVersion(raw []byte) (Detection, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		if version, ok := declaredJSONVersion(trimmed); ok {
+			return Detection{Version: version, Declared: true}, nil
+		}
+	}
+
+	var tree interface{}
+	var err error
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		tree, err = document.Decode(trimmed)
+	} else {
+		// the JSON documents are YAML too
+		tree, err = document.DecodeYAML(raw)
+	}
+	if err != nil {
+		return Detection{}, err
+	}
+	root, ok := tree.(document.Object)
+	if !ok {
+		return Detection{}, fmt.Errorf("workflow document must be an object")
+	}
+	if version, ok := root.Get("specVersion"); ok {
+		if version, ok := version.(string); ok && len(version) > 0 {
+			return Detection{Version: version, Declared: true}, nil
+		}
+	}
+	return infer(root), nil
+}
+
+// declaredJSONVersion reads the specVersion of the JSON document, skipping the other members of the workflow. It
+// returns false if the document doesn't declare it or isn't valid.
+func declaredJSONVersion(data []byte) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return "", false
+	}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return "", false
+		}
+		if key == "specVersion" {
+			var version string
+			if err := decoder.Decode(&version); err != nil || len(version) == 0 {
+				return "", false
+			}
+			return version, true
+		}
+		var skipped json.RawMessage
+		if err := decoder.Decode(&skipped); err != nil {
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// infer infers the version of the document from its markers: the newest version a property was introduced in, or the
+// oldest version a property was removed after if none was introduced
+func infer(root document.Object) Detection {
+	var since, until Detection
+	walk("", "", root, func(path, key string) {
+		m, ok := markers[key]
+		if !ok {
+			return
+		}
+		if len(m.since) > 0 {
+			since = evidence(since, m.since, path, versionIndex(m.since) > versionIndex(since.Version))
+		} else {
+			until = evidence(until, m.until, path, len(until.Version) == 0 || versionIndex(m.until) < versionIndex(until.Version))
+		}
+	})
+	if len(since.Version) > 0 {
+		return since
+	}
+	return until
+}
+
+// evidence adds the path of the marker of the version to the detection, replacing its version and evidence if newer
+func evidence(d Detection, version, path string, replace bool) Detection {
+	if replace {
+		return Detection{Version: version, Evidence: []string{path}}
+	}
+	if version == d.Version {
+		d.Evidence = append(d.Evidence, path)
+	}
+	return d
+}
+
+// walk calls fn with the path of every member of the value and its marker key, the members of the array items being
+// prefixed by the name of the array
+func walk(path, parent string, value interface{}, fn func(path, key string)) {
+	switch v := value.(type) {
+	case document.Object:
+		for _, m := range v {
+			memberPath := member(path, m.Key)
+			fn(memberPath, parent+"."+m.Key)
+			if s, ok := m.Value.(string); ok {
+				fn(memberPath, parent+"."+m.Key+"="+s)
+			}
+			walk(memberPath, m.Key, m.Value, fn)
+		}
+	case []interface{}:
+		for i, item := range v {
+			walk(fmt.Sprintf("%s[%d]", path, i), parent, item, fn)
+		}
+	}
+}
+
+// versionIndex returns the position of the version in the migration steps, -1 if it's unknown
+func versionIndex(version string) int {
+	for i, s := range steps {
+		if s.from == version {
+			return i
+		}
+	}
+	if version == LatestVersion {
+		return len(steps)
+	}
+	return -1
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectSpecVersion(t *testing.T) {
+	detection, err := DetectSpecVersion([]byte(workflow0_6))
+	assert.NoError(t, err)
+	assert.Equal(t, Detection{Version: "0.6", Declared: true}, detection)
+
+	detection, err = DetectSpecVersion([]byte(`{"id": "order", "states": [{"name": "Wait"}], "specVersion": "0.8", "start": "Wait"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, Detection{Version: "0.8", Declared: true}, detection)
+
+	detection, err = DetectSpecVersion([]byte(`id: order
+execTimeout:
+  duration: PT1H
+states:
+- name: Wait
+  type: delay
+  timeDelay: PT5S
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, Detection{Version: "0.6", Evidence: []string{"execTimeout", "states[0].type"}}, detection)
+
+	detection, err = DetectSpecVersion([]byte(`{
+  "id": "order",
+  "states": [
+    {
+      "name": "Store",
+      "type": "operation",
+      "actions": [
+        {"functionRef": {"refName": "store", "arguments": {"order": "${ .order }"}}},
+        {"eventRef": {"produceEventRef": "OrderStored", "consumeEventRef": "OrderCreated"}}
+      ],
+      "onErrors": [{"errorRef": "Timeout", "end": true}],
+      "end": true
+    }
+  ]
+}`))
+	assert.NoError(t, err)
+	assert.Equal(t, Detection{Version: "0.8", Evidence: []string{
+		"states[0].actions[1].eventRef.produceEventRef",
+		"states[0].actions[1].eventRef.consumeEventRef",
+	}}, detection)
+
+	detection, err = DetectSpecVersion([]byte(`{"id": "order", "specVersion": ""}`))
+	assert.NoError(t, err)
+	assert.Equal(t, Detection{}, detection)
+
+	_, err = DetectSpecVersion([]byte(`{"id": `))
+	assert.Error(t, err)
+	_, err = DetectSpecVersion([]byte(`- id: order`))
+	assert.Error(t, err)
+}
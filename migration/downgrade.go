@@ -0,0 +1,251 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"github.com/serverlessworkflow/sdk-go/v2/internal/document"
+)
+
+// completionTypes0_7 parallel state completion types renamed back by a downgrade to 0.6
+var completionTypes0_7 = map[string]string{"allOf": "and", "atLeast": "n_of_m"}
+
+// downgrade08To07 renames back the action event references, keeps the 0.8 default of the events dataOnly property,
+// which is false in 0.7, and removes the workflow key and extensions, the continuations of the ends, the asynchronous
+// invocations, the action sleeps and conditions and the sequential foreach states.
+func downgrade08To07(root document.Object, m *migration) document.Object {
+	root = m.lose("", root, "key", "key", "workflow keys are not supported by 0.7")
+	root = m.lose("", root, "extensions", "extensions", "extensions are not supported by 0.7")
+	for i, member := range root {
+		switch member.Key {
+		case "events":
+			root[i].Value = each("events", member.Value, func(path string, event document.Object) document.Object {
+				if _, ok := event.Get("dataOnly"); !ok {
+					event = event.Set("dataOnly", true)
+					m.change(path+".dataOnly", "dataOnly set to true, the default of 0.8")
+				}
+				return event
+			})
+		case "states":
+			root[i].Value = each("states", member.Value, func(path string, state document.Object) document.Object {
+				return downgradeState08To07(path, state, m)
+			})
+		}
+	}
+	return root
+}
+
+func downgradeState08To07(path string, state document.Object, m *migration) document.Object {
+	if stringValue(state, "type") == "foreach" {
+		if mode := stringValue(state, "mode"); mode == "sequential" {
+			state = m.lose(path, state, "mode", "mode: sequential", "sequential foreach states are not supported by 0.7, the iterations run in parallel")
+		} else if len(mode) > 0 {
+			state = state.Without("mode")
+			m.change(path, "mode %s removed, the only mode of 0.7", mode)
+		}
+	}
+	state = eachEnd(path, state, func(path string, end document.Object) interface{} {
+		end = m.lose(path, end, "continueAs", "continueAs", "continuing as a new execution is not supported by 0.7, the workflow ends")
+		if len(end) == 0 {
+			return true
+		}
+		return end
+	})
+	return forEachAction(path, state, func(path string, action document.Object) document.Object {
+		action = m.lose(path, action, "sleep", "sleep", "action sleeps are not supported by 0.7")
+		action = m.lose(path, action, "condition", "condition", "action conditions are not supported by 0.7, the action always runs")
+		action = update(action, "functionRef", func(ref document.Object) document.Object {
+			return downgradeInvoke08To07(path+".functionRef", ref, "function", m)
+		})
+		action = update(action, "eventRef", func(ref document.Object) document.Object {
+			ref = m.rename(path+".eventRef", ref, "produceEventRef", "triggerEventRef")
+			ref = m.rename(path+".eventRef", ref, "consumeEventRef", "resultEventRef")
+			return downgradeInvoke08To07(path+".eventRef", ref, "event", m)
+		})
+		return update(action, "subFlowRef", func(ref document.Object) document.Object {
+			ref = m.lose(path+".subFlowRef", ref, "onParentComplete", "onParentComplete", "subflows are always terminated with their parent in 0.7")
+			return downgradeInvoke08To07(path+".subFlowRef", ref, "subflow", m)
+		})
+	})
+}
+
+// downgradeInvoke08To07 removes the invoke property of the reference, losing the asynchronous invocations
+func downgradeInvoke08To07(path string, ref document.Object, kind string, m *migration) document.Object {
+	switch invoke := stringValue(ref, "invoke"); invoke {
+	case "":
+		return ref
+	case "sync":
+		m.change(path, "invoke sync removed, the only invocation of 0.7")
+		return ref.Without("invoke")
+	default:
+		return m.lose(path, ref, "invoke", "invoke: "+invoke, "asynchronous invocations are not supported by 0.7, the %s is invoked synchronously", kind)
+	}
+}
+
+// downgrade07To06 moves the workflow execution timeout back to execTimeout, replaces the sleep states and the
+// operation states invoking a single subflow by delay and subflow states, error references by error names and the end
+// properties by end kinds, renames back the data filters, function arguments and switch default condition properties,
+// and removes the other timeouts, the error declarations and the retry references of the actions.
+func downgrade07To06(root document.Object, m *migration) document.Object {
+	if value, ok := root.Get("timeouts"); ok {
+		timeouts, _ := value.(document.Object)
+		for _, member := range timeouts.Without("workflowExecTimeout") {
+			m.lose("timeouts", timeouts, member.Key, "timeouts."+member.Key, "%s is not supported by 0.6", member.Key)
+		}
+		if execTimeout, ok := timeouts.Get("workflowExecTimeout"); ok {
+			root = root.Rename("timeouts", "execTimeout").Set("execTimeout", execTimeout)
+			m.change("execTimeout", "timeouts.workflowExecTimeout moved to execTimeout")
+		} else {
+			root = root.Without("timeouts")
+		}
+	}
+	root = m.lose("", root, "errors", "errors", "error declarations are not supported by 0.6, the errors are referenced by name")
+	for i, member := range root {
+		if member.Key == "states" {
+			root[i].Value = each("states", member.Value, func(path string, state document.Object) document.Object {
+				return downgradeState07To06(path, state, m)
+			})
+		}
+	}
+	return root
+}
+
+func downgradeState07To06(path string, state document.Object, m *migration) document.Object {
+	// the ends are replaced before the switch default condition is renamed
+	state = eachEnd(path, state, func(path string, end document.Object) interface{} {
+		return downgradeEnd07To06(path, end, m)
+	})
+	switch stringValue(state, "type") {
+	case "sleep":
+		state = state.Set("type", "delay").Rename("duration", "timeDelay")
+		m.change(path, "sleep state replaced by a delay state, its duration by the timeDelay")
+	case "operation":
+		if workflowID, ok := singleSubflow(state); ok {
+			state = state.Set("type", "subflow").Rename("actions", "workflowId").Set("workflowId", workflowID).Without("actionMode")
+			m.change(path, "operation state invoking a subflow replaced by a subflow state")
+		}
+	case "switch":
+		state = m.rename(path, state, "defaultCondition", "default")
+		for i, member := range state {
+			if member.Key == "eventConditions" {
+				state[i].Value = each(path+".eventConditions", member.Value, func(path string, o document.Object) document.Object {
+					return downgradeEventDataFilter07To06(path, o, m)
+				})
+			}
+		}
+	case "parallel":
+		if completionType := stringValue(state, "completionType"); len(completionType) > 0 {
+			if renamed, ok := completionTypes0_7[completionType]; ok {
+				state = state.Set("completionType", renamed)
+				m.change(path+".completionType", "completion type %s renamed to %s", completionType, renamed)
+			}
+		}
+		state = m.rename(path, state, "numCompleted", "n")
+	case "foreach":
+		state = m.rename(path, state, "batchSize", "max")
+	case "callback":
+		state = downgradeEventDataFilter07To06(path, state, m)
+	}
+	state = m.lose(path, state, "timeouts", "timeouts", "state timeouts are not supported by 0.6")
+
+	state = update(state, "stateDataFilter", func(filter document.Object) document.Object {
+		filter = m.rename(path+".stateDataFilter", filter, "input", "dataInputPath")
+		return m.rename(path+".stateDataFilter", filter, "output", "dataOutputPath")
+	})
+	for i, member := range state {
+		switch member.Key {
+		case "onErrors":
+			state[i].Value = each(path+".onErrors", member.Value, func(path string, onError document.Object) document.Object {
+				onError = m.lose(path, onError, "errorRefs", "errorRefs", "handling several errors at once is not supported by 0.6, define an onErrors per error")
+				return m.rename(path, onError, "errorRef", "error")
+			})
+		case "onEvents":
+			state[i].Value = each(path+".onEvents", member.Value, func(path string, o document.Object) document.Object {
+				return downgradeEventDataFilter07To06(path, o, m)
+			})
+		}
+	}
+	return forEachAction(path, state, func(path string, action document.Object) document.Object {
+		action = m.lose(path, action, "retryRef", "retryRef", "retries are defined on the onErrors in 0.6, set retryRef on the state onErrors")
+		action = m.lose(path, action, "retryableErrors", "retryableErrors", "retryable errors are not supported by 0.6")
+		action = m.lose(path, action, "nonRetryableErrors", "nonRetryableErrors", "non retryable errors are not supported by 0.6")
+		action = m.lose(path, action, "subFlowRef", "subFlowRef", "subflow actions are not supported by 0.6, invoke the subflow from a subflow state")
+		action = update(action, "functionRef", func(ref document.Object) document.Object {
+			return m.rename(path+".functionRef", ref, "arguments", "parameters")
+		})
+		return update(action, "actionDataFilter", func(filter document.Object) document.Object {
+			return m.rename(path+".actionDataFilter", filter, "results", "dataResultsPath")
+		})
+	})
+}
+
+// downgradeEnd07To06 replaces the end properties by the end kind, terminate or event producing the first event, losing
+// the compensations and the other events produced
+func downgradeEnd07To06(path string, end document.Object, m *migration) interface{} {
+	end = m.lose(path, end, "compensate", "compensate", "compensations on end are not supported by 0.6")
+	terminate, _ := end.Get("terminate")
+	value, _ := end.Get("produceEvents")
+	produceEvents, _ := value.([]interface{})
+	switch {
+	case terminate == true:
+		end = m.lose(path, end, "produceEvents", "produceEvents", "terminating ends producing events are not supported by 0.6, the events aren't produced")
+		end = end.Rename("terminate", "kind").Set("kind", "terminate")
+	case len(produceEvents) > 0:
+		if len(produceEvents) > 1 {
+			m.loss(path+".produceEvents", "produceEvents", "ends producing several events are not supported by 0.6, only the first one is produced")
+		}
+		end = end.Without("terminate").Rename("produceEvents", "produceEvent").Set("produceEvent", produceEvents[0])
+		end = append(document.Object{{Key: "kind", Value: "event"}}, end...)
+	default:
+		end = end.Without("terminate").Without("produceEvents")
+		if len(end) == 0 {
+			return true
+		}
+		return end
+	}
+	m.change(path, "end properties replaced by the end kind %s", stringValue(end, "kind"))
+	return end
+}
+
+// singleSubflow returns the workflow ID of the subflow invoked by the operation state, if it's its only action
+func singleSubflow(state document.Object) (string, bool) {
+	value, _ := state.Get("actions")
+	actions, ok := value.([]interface{})
+	if !ok || len(actions) != 1 {
+		return "", false
+	}
+	action, ok := actions[0].(document.Object)
+	if !ok || len(action) != 1 {
+		return "", false
+	}
+	ref, ok := action.Get("subFlowRef")
+	if !ok {
+		return "", false
+	}
+	switch ref := ref.(type) {
+	case string:
+		return ref, len(ref) > 0
+	case document.Object:
+		workflowID := stringValue(ref, "workflowId")
+		return workflowID, len(workflowID) > 0 && len(ref.Without("version")) == 1
+	}
+	return "", false
+}
+
+// downgradeEventDataFilter07To06 renames back the property of the event data filter of the object at the path
+func downgradeEventDataFilter07To06(path string, o document.Object, m *migration) document.Object {
+	return update(o, "eventDataFilter", func(filter document.Object) document.Object {
+		return m.rename(path+".eventDataFilter", filter, "data", "dataOutputPath")
+	})
+}
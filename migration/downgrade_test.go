@@ -0,0 +1,246 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const workflow0_8 = `id: order
+key: order-key
+specVersion: '0.8'
+start: Wait
+timeouts:
+  workflowExecTimeout:
+    duration: PT1H
+  stateExecTimeout:
+    total: PT5M
+events:
+- name: OrderCreated
+  type: order.created
+errors:
+- name: Timeout
+states:
+- name: Wait
+  type: sleep
+  duration: PT5S
+  transition: Store
+- name: Store
+  type: operation
+  actions:
+  - functionRef:
+      refName: store
+      arguments:
+        order: "${ .order }"
+      invoke: async
+    retryRef: fast
+  - eventRef:
+      produceEventRef: OrderStored
+      consumeEventRef: OrderCreated
+  onErrors:
+  - errorRef: Timeout
+    end: true
+  transition: Child
+- name: Child
+  type: operation
+  actions:
+  - subFlowRef:
+      workflowId: child
+      invoke: sync
+  end:
+    continueAs: order
+`
+
+func TestDowngrade(t *testing.T) {
+	result, err := Downgrade([]byte(workflow0_8), serializer.FormatYAML, "0.6")
+	require.NoError(t, err)
+	assert.Equal(t, "0.8", result.From)
+	assert.Equal(t, "0.6", result.To)
+	assert.Equal(t, `id: order
+specVersion: "0.6"
+start: Wait
+execTimeout:
+  duration: PT1H
+events:
+- name: OrderCreated
+  type: order.created
+  dataOnly: true
+states:
+- name: Wait
+  type: delay
+  timeDelay: PT5S
+  transition: Store
+- name: Store
+  type: operation
+  actions:
+  - functionRef:
+      refName: store
+      parameters:
+        order: ${ .order }
+  - eventRef:
+      triggerEventRef: OrderStored
+      resultEventRef: OrderCreated
+  onErrors:
+  - error: Timeout
+    end: true
+  transition: Child
+- name: Child
+  type: subflow
+  workflowId: child
+  end: true
+`, string(result.Document))
+
+	var losses []string
+	for _, loss := range result.Losses {
+		losses = append(losses, fmt.Sprintf("%s %s [%s]", loss.To, loss, loss.Feature))
+	}
+	assert.Equal(t, []string{
+		"0.7 key: workflow keys are not supported by 0.7 [key]",
+		"0.7 states[1].actions[0].functionRef.invoke: asynchronous invocations are not supported by 0.7, the function is invoked synchronously [invoke: async]",
+		"0.7 states[2].end.continueAs: continuing as a new execution is not supported by 0.7, the workflow ends [continueAs]",
+		"0.6 timeouts.stateExecTimeout: stateExecTimeout is not supported by 0.6 [timeouts.stateExecTimeout]",
+		"0.6 errors: error declarations are not supported by 0.6, the errors are referenced by name [errors]",
+		"0.6 states[1].actions[0].retryRef: retries are defined on the onErrors in 0.6, set retryRef on the state onErrors [retryRef]",
+	}, losses)
+
+	var changes []string
+	for _, change := range result.Changes {
+		changes = append(changes, change.To+" "+change.String())
+	}
+	assert.Equal(t, []string{
+		"0.7 events[0].dataOnly: dataOnly set to true, the default of 0.8",
+		"0.7 states[1].actions[1].eventRef.triggerEventRef: produceEventRef renamed to triggerEventRef",
+		"0.7 states[1].actions[1].eventRef.resultEventRef: consumeEventRef renamed to resultEventRef",
+		"0.7 states[2].actions[0].subFlowRef: invoke sync removed, the only invocation of 0.7",
+		"0.7 specVersion: specVersion 0.8 replaced by 0.7",
+		"0.6 execTimeout: timeouts.workflowExecTimeout moved to execTimeout",
+		"0.6 states[0]: sleep state replaced by a delay state, its duration by the timeDelay",
+		"0.6 states[1].onErrors[0].error: errorRef renamed to error",
+		"0.6 states[1].actions[0].functionRef.parameters: arguments renamed to parameters",
+		"0.6 states[2]: operation state invoking a subflow replaced by a subflow state",
+		"0.6 specVersion: specVersion 0.7 replaced by 0.6",
+	}, changes)
+
+	result, err = Downgrade([]byte(`{"specVersion": "0.8", "states": [{"name": "Each", "type": "foreach", "mode": "parallel"}]}`), serializer.FormatJSON, "0.7")
+	require.NoError(t, err)
+	assert.Empty(t, result.Losses)
+	assert.NotContains(t, string(result.Document), "mode")
+
+	_, err = Downgrade([]byte(`{"specVersion": "0.7"}`), serializer.FormatJSON, "0.8")
+	assert.EqualError(t, err, "downgrade from spec version 0.7 to 0.8 is not supported")
+}
+
+func TestDowngradeMigrated(t *testing.T) {
+	migrated, err := Migrate([]byte(workflow0_6), serializer.FormatYAML, "0.7")
+	require.NoError(t, err)
+	downgraded, err := Downgrade(migrated.Document, serializer.FormatYAML, "0.6")
+	require.NoError(t, err)
+	assert.Empty(t, downgraded.Losses)
+	for _, property := range []string{"execTimeout:", "type: delay", "timeDelay: PT5S", "dataInputPath:", "default:", "parameters:", "error: Timeout", "type: subflow", "workflowId: child"} {
+		assert.Contains(t, string(downgraded.Document), property)
+	}
+}
+
+func TestDowngradeEnds(t *testing.T) {
+	result, err := Downgrade([]byte(`id: order
+specVersion: '0.7'
+states:
+- name: Check
+  type: switch
+  dataConditions:
+  - condition: "${ .cancelled }"
+    end:
+      terminate: true
+      produceEvents:
+      - eventRef: OrderCancelled
+  - condition: "${ .valid }"
+    end:
+      produceEvents:
+      - eventRef: OrderAccepted
+      - eventRef: OrderStored
+      compensate: true
+  defaultCondition:
+    end:
+      produceEvents:
+      - eventRef: OrderRejected
+        data: "${ .order }"
+- name: Done
+  type: inject
+  end:
+    terminate: true
+`), serializer.FormatYAML, "0.6")
+	require.NoError(t, err)
+	assert.Equal(t, `id: order
+specVersion: "0.6"
+states:
+- name: Check
+  type: switch
+  dataConditions:
+  - condition: ${ .cancelled }
+    end:
+      kind: terminate
+  - condition: ${ .valid }
+    end:
+      kind: event
+      produceEvent:
+        eventRef: OrderAccepted
+  default:
+    end:
+      kind: event
+      produceEvent:
+        eventRef: OrderRejected
+        data: ${ .order }
+- name: Done
+  type: inject
+  end:
+    kind: terminate
+`, string(result.Document))
+
+	var losses []string
+	for _, loss := range result.Losses {
+		losses = append(losses, fmt.Sprintf("%s [%s]", loss, loss.Feature))
+	}
+	assert.Equal(t, []string{
+		"states[0].dataConditions[0].end.produceEvents: terminating ends producing events are not supported by 0.6, the events aren't produced [produceEvents]",
+		"states[0].dataConditions[1].end.compensate: compensations on end are not supported by 0.6 [compensate]",
+		"states[0].dataConditions[1].end.produceEvents: ends producing several events are not supported by 0.6, only the first one is produced [produceEvents]",
+	}, losses)
+
+	// the ends migrated from 0.6 are restored
+	migrated, err := Migrate([]byte(`id: order
+specVersion: '0.6'
+states:
+- name: Done
+  type: inject
+  end:
+    kind: event
+    produceEvent:
+      eventRef: OrderDone
+`), serializer.FormatYAML, "0.7")
+	require.NoError(t, err)
+	downgraded, err := Downgrade(migrated.Document, serializer.FormatYAML, "0.6")
+	require.NoError(t, err)
+	assert.Empty(t, downgraded.Losses)
+	assert.Contains(t, string(downgraded.Document), `  end:
+    kind: event
+    produceEvent:
+      eventRef: OrderDone
+`)
+}
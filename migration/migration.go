@@ -0,0 +1,302 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/internal/document"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+// LatestVersion most recent specification version documents can be migrated to
+const LatestVersion = "0.8"
+
+// Note change the migration could not do and needs manual attention
+type Note struct {
+	// Path of the property in the migrated document, e.g. 'states[0].onErrors[1]'
+	Path    string
+	Message string
+}
+
+// String ...
+func (n Note) String() string {
+	return n.Path + ": " + n.Message
+}
+
+// Change rewrite of the document by the migration to a specification version
+type Change struct {
+	// To specification version the document was migrated to by the change
+	To string
+	// Path of the property in the migrated document, e.g. 'states[0].actions[0].functionRef.arguments'
+	Path    string
+	Message string
+}
+
+// String ...
+func (c Change) String() string {
+	return c.Path + ": " + c.Message
+}
+
+// Result ...
+type Result struct {
+	// Document migrated document, in the same format as the input
+	Document []byte
+	// From specification version of the input document
+	From string
+	// To specification version of the migrated document
+	To    string
+	Notes []Note
+	// Changes rewrites of the document, in the order of the migrations to the intermediate versions
+	Changes []Change
+	// Losses features removed from the document by a downgrade, not supported by the version it's downgraded to
+	Losses []Loss
+}
+
+// Loss feature of a document that can't be represented in the specification version it's downgraded to, removed
+// from the document
+type Loss struct {
+	// To specification version not supporting the feature
+	To string
+	// Path of the property removed, e.g. 'states[0].end.continueAs'
+	Path string
+	// Feature property or value removed, e.g. 'continueAs' or 'invoke: async'
+	Feature string
+	Message string
+}
+
+// String ...
+func (l Loss) String() string {
+	return l.Path + ": " + l.Message
+}
+
+// step migrates the document from a specification version to the next one
+type step struct {
+	from    string
+	to      string
+	migrate func(root document.Object, m *migration) document.Object
+}
+
+var steps = []step{
+	{from: "0.6", to: "0.7", migrate: migrate06To07},
+	{from: "0.7", to: "0.8", migrate: migrate07To08},
+}
+
+// downgrades steps downgrading the document to the previous version
+var downgrades = []step{
+	{from: "0.8", to: "0.7", migrate: downgrade08To07},
+	{from: "0.7", to: "0.6", migrate: downgrade07To06},
+}
+
+type migration struct {
+	// to specification version of the current step
+	to      string
+	notes   []Note
+	changes []Change
+	losses  []Loss
+}
+
+func (m *migration) note(path, format string, args ...interface{}) {
+	m.notes = append(m.notes, Note{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (m *migration) change(path, format string, args ...interface{}) {
+	m.changes = append(m.changes, Change{To: m.to, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// lose removes the member of the object at the path, recording the loss of the feature
+func (m *migration) lose(path string, o document.Object, key, feature, format string, args ...interface{}) document.Object {
+	if _, ok := o.Get(key); !ok {
+		return o
+	}
+	m.loss(member(path, key), feature, format, args...)
+	return o.Without(key)
+}
+
+// loss records the loss of the feature at the path
+func (m *migration) loss(path, feature, format string, args ...interface{}) {
+	m.losses = append(m.losses, Loss{To: m.to, Path: path, Feature: feature, Message: fmt.Sprintf(format, args...)})
+}
+
+// rename renames the member of the object at the path keeping its position, recording the change
+func (m *migration) rename(path string, o document.Object, from, to string) document.Object {
+	if _, ok := o.Get(from); !ok {
+		return o
+	}
+	m.change(member(path, to), "%s renamed to %s", from, to)
+	return o.Rename(from, to)
+}
+
+// Migrate rewrites the workflow document to the given specification version. The document is migrated through every
+// intermediate version; downgrades are not supported, see Downgrade.
+func Migrate(data []byte, format serializer.Format, to string) (*Result, error) {
+	return run(data, format, to, steps, "migration")
+}
+
+// Downgrade rewrites the workflow document to the given older specification version, through every intermediate
+// version. The features the older versions can't represent, e.g. the continueAs of the ends since 0.8, are removed
+// and reported as the Losses of the result.
+func Downgrade(data []byte, format serializer.Format, to string) (*Result, error) {
+	return run(data, format, to, downgrades, "downgrade")
+}
+
+// run runs the steps from the version of the document to the given version, named after the kind of steps in errors
+func run(data []byte, format serializer.Format, to string, steps []step, kind string) (*Result, error) {
+	var tree interface{}
+	var err error
+	switch format {
+	case serializer.FormatJSON:
+		tree, err = document.Decode(data)
+	case serializer.FormatYAML:
+		tree, err = document.DecodeYAML(data)
+	default:
+		err = fmt.Errorf("format %s not supported", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	root, ok := tree.(document.Object)
+	if !ok {
+		return nil, fmt.Errorf("workflow document must be an object")
+	}
+	version, _ := root.Get("specVersion")
+	from, ok := version.(string)
+	if !ok || len(from) == 0 {
+		return nil, fmt.Errorf("workflow document has no specVersion")
+	}
+
+	m := &migration{}
+	current := from
+	for current != to {
+		next := -1
+		for i, s := range steps {
+			if s.from == current {
+				next = i
+			}
+		}
+		if next < 0 || !reachable(steps[next:], to) {
+			return nil, fmt.Errorf("%s from spec version %s to %s is not supported", kind, from, to)
+		}
+		m.to = steps[next].to
+		root = steps[next].migrate(root, m)
+		root = root.Set("specVersion", m.to)
+		m.change("specVersion", "specVersion %s replaced by %s", current, m.to)
+		current = m.to
+	}
+
+	buf := new(bytes.Buffer)
+	if format == serializer.FormatYAML {
+		err = document.WriteYAML(buf, root)
+	} else {
+		err = document.WriteJSON(buf, root, "  ", 0)
+		buf.WriteString("\n")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Document: buf.Bytes(), From: from, To: to, Notes: m.notes, Changes: m.changes, Losses: m.losses}, nil
+}
+
+func reachable(steps []step, version string) bool {
+	for _, s := range steps {
+		if s.to == version {
+			return true
+		}
+	}
+	return false
+}
+
+// each calls fn with every object in the array value and its path, replacing it with the result
+func each(path string, value interface{}, fn func(path string, o document.Object) document.Object) interface{} {
+	array, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+	for i, item := range array {
+		if o, ok := item.(document.Object); ok {
+			array[i] = fn(fmt.Sprintf("%s[%d]", path, i), o)
+		}
+	}
+	return array
+}
+
+// update replaces the value of the object member with the given key by the result of fn, if the member is an object
+func update(o document.Object, key string, fn func(o document.Object) document.Object) document.Object {
+	if value, ok := o.Get(key); ok {
+		if child, ok := value.(document.Object); ok {
+			return o.Set(key, fn(child))
+		}
+	}
+	return o
+}
+
+// forEachAction calls fn with every action in the state and its path
+func forEachAction(path string, state document.Object, fn func(path string, action document.Object) document.Object) document.Object {
+	for i, m := range state {
+		switch m.Key {
+		case "actions":
+			state[i].Value = each(path+".actions", m.Value, fn)
+		case "action":
+			if action, ok := m.Value.(document.Object); ok {
+				state[i].Value = fn(path+".action", action)
+			}
+		case "branches", "onEvents":
+			state[i].Value = each(path+"."+m.Key, m.Value, func(p string, o document.Object) document.Object {
+				return forEachAction(p, o, fn)
+			})
+		}
+	}
+	return state
+}
+
+// eachEnd replaces the end objects of the state, its onErrors and conditions by the result of fn
+func eachEnd(path string, state document.Object, fn func(path string, end document.Object) interface{}) document.Object {
+	replace := func(path string, o document.Object) document.Object {
+		if value, ok := o.Get("end"); ok {
+			if end, ok := value.(document.Object); ok {
+				return o.Set("end", fn(path+".end", end))
+			}
+		}
+		return o
+	}
+	state = replace(path, state)
+	for i, member := range state {
+		switch member.Key {
+		case "onErrors", "dataConditions", "eventConditions":
+			state[i].Value = each(path+"."+member.Key, member.Value, replace)
+		case "defaultCondition":
+			if o, ok := member.Value.(document.Object); ok {
+				state[i].Value = replace(path+".defaultCondition", o)
+			}
+		}
+	}
+	return state
+}
+
+// member returns the path of the member of the object at the path, the members of the workflow having no prefix
+func member(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
+
+func stringValue(o document.Object, key string) string {
+	value, _ := o.Get(key)
+	s, _ := value.(string)
+	return strings.TrimSpace(s)
+}
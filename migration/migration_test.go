@@ -0,0 +1,243 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+	"github.com/stretchr/testify/assert"
+)
+
+const workflow0_6 = `id: order
+name: Order
+version: '1.0'
+specVersion: '0.6'
+start: Wait
+execTimeout:
+  duration: PT1H
+events:
+- name: OrderCreated
+  type: order.created
+states:
+- name: Wait
+  type: delay
+  timeDelay: PT5S
+  transition: Check
+- name: Check
+  type: switch
+  stateDataFilter:
+    dataInputPath: "${ .order }"
+  dataConditions:
+  - condition: "${ .valid }"
+    transition: Store
+  default:
+    transition: Child
+- name: Store
+  type: operation
+  actions:
+  - functionRef:
+      refName: store
+      parameters:
+        order: "${ .order }"
+    eventRef:
+      triggerEventRef: OrderStored
+      resultEventRef: OrderCreated
+  onErrors:
+  - error: Timeout
+    code: '408'
+    retryRef: fast
+    end: true
+  end: true
+- name: Child
+  type: subflow
+  workflowId: child
+  waitForCompletion: false
+  end: true
+`
+
+func TestMigrate(t *testing.T) {
+	result, err := Migrate([]byte(workflow0_6), serializer.FormatYAML, LatestVersion)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.6", result.From)
+	assert.Equal(t, "0.8", result.To)
+	assert.Equal(t, `id: order
+name: Order
+version: "1.0"
+specVersion: "0.8"
+start: Wait
+timeouts:
+  workflowExecTimeout:
+    duration: PT1H
+events:
+- name: OrderCreated
+  type: order.created
+  dataOnly: false
+states:
+- name: Wait
+  type: sleep
+  duration: PT5S
+  transition: Check
+- name: Check
+  type: switch
+  stateDataFilter:
+    input: ${ .order }
+  dataConditions:
+  - condition: ${ .valid }
+    transition: Store
+  defaultCondition:
+    transition: Child
+- name: Store
+  type: operation
+  actions:
+  - functionRef:
+      refName: store
+      arguments:
+        order: ${ .order }
+    eventRef:
+      produceEventRef: OrderStored
+      consumeEventRef: OrderCreated
+  onErrors:
+  - errorRef: Timeout
+    end: true
+  end: true
+- name: Child
+  type: operation
+  actions:
+  - subFlowRef:
+      workflowId: child
+  end: true
+`, string(result.Document))
+
+	var notes []string
+	for _, note := range result.Notes {
+		notes = append(notes, note.String())
+	}
+	assert.Equal(t, []string{
+		"states[2].onErrors[0].errorRef: error Timeout must be declared in the workflow errors since 0.7",
+		"states[2].onErrors[0]: error code 408 was removed, set it in the declaration of the error in the workflow errors",
+		"states[2].onErrors[0]: retries are defined on the actions since 0.7, retryRef fast was removed and must be set on the state actions",
+		"states[3].actions[0].subFlowRef: subflows are always invoked synchronously since 0.7, waitForCompletion false was removed",
+	}, notes)
+
+	var changes []string
+	for _, change := range result.Changes {
+		changes = append(changes, change.To+" "+change.String())
+	}
+	assert.Equal(t, []string{
+		"0.7 timeouts.workflowExecTimeout: execTimeout moved to timeouts.workflowExecTimeout",
+		"0.7 states[0]: delay state replaced by a sleep state, its timeDelay by the duration",
+		"0.7 states[1].defaultCondition: default renamed to defaultCondition",
+		"0.7 states[1].stateDataFilter.input: dataInputPath renamed to input",
+		"0.7 states[2].onErrors[0].errorRef: error renamed to errorRef",
+		"0.7 states[2].actions[0].functionRef.arguments: parameters renamed to arguments",
+		"0.7 states[3]: subflow state replaced by an operation state invoking the subflow",
+		"0.7 specVersion: specVersion 0.6 replaced by 0.7",
+		"0.8 events[0].dataOnly: dataOnly set to false, the default of 0.7",
+		"0.8 states[2].actions[0].eventRef.produceEventRef: triggerEventRef renamed to produceEventRef",
+		"0.8 states[2].actions[0].eventRef.consumeEventRef: resultEventRef renamed to consumeEventRef",
+		"0.8 specVersion: specVersion 0.7 replaced by 0.8",
+	}, changes)
+}
+
+func TestMigrateSwitchesAndEnds(t *testing.T) {
+	result, err := Migrate([]byte(`id: order
+specVersion: '0.6'
+states:
+- name: Check
+  type: switch
+  eventConditions:
+  - eventRef: OrderCancelled
+    end:
+      kind: terminate
+  dataConditions:
+  - condition: "${ .valid }"
+    end:
+      kind: default
+  default:
+    end:
+      kind: event
+      produceEvent:
+        eventRef: OrderRejected
+`), serializer.FormatYAML, "0.7")
+	assert.NoError(t, err)
+	assert.Equal(t, `id: order
+specVersion: "0.7"
+states:
+- name: Check
+  type: switch
+  eventConditions:
+  - eventRef: OrderCancelled
+    end:
+      terminate: true
+  defaultCondition:
+    transition:
+      nextState: CheckData
+- name: CheckData
+  type: switch
+  dataConditions:
+  - condition: ${ .valid }
+    end: true
+  defaultCondition:
+    end:
+      produceEvents:
+      - eventRef: OrderRejected
+`, string(result.Document))
+
+	var changes []string
+	for _, change := range result.Changes {
+		changes = append(changes, change.String())
+	}
+	assert.Equal(t, []string{
+		"states[1]: data conditions of the switch state Check moved to the new switch state CheckData, its default condition",
+		"states[0].defaultCondition: default renamed to defaultCondition",
+		"states[0].eventConditions[0].end: end kind terminate replaced by the end properties",
+		"states[1].defaultCondition: default renamed to defaultCondition",
+		"states[1].dataConditions[0].end: end kind default replaced by the end properties",
+		"states[1].defaultCondition.end: end kind event replaced by the end properties",
+		"specVersion: specVersion 0.6 replaced by 0.7",
+	}, changes)
+	assert.Equal(t, []Note{{Path: "states[1]", Message: "switch states define either data or event conditions since 0.7, the data conditions of Check are evaluated once no event condition matches"}}, result.Notes)
+}
+
+func TestMigrateJSON(t *testing.T) {
+	result, err := Migrate([]byte(`{"id": "order", "specVersion": "0.7", "events": [{"name": "created", "dataOnly": true}]}`), serializer.FormatJSON, "0.8")
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "id": "order",
+  "specVersion": "0.8",
+  "events": [
+    {
+      "name": "created",
+      "dataOnly": true
+    }
+  ]
+}
+`, string(result.Document))
+	assert.Empty(t, result.Notes)
+
+	result, err = Migrate([]byte(`{"specVersion": "0.7"}`), serializer.FormatJSON, "0.7")
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"specVersion\": \"0.7\"\n}\n", string(result.Document))
+
+	_, err = Migrate([]byte(`{"specVersion": "0.8"}`), serializer.FormatJSON, "0.7")
+	assert.EqualError(t, err, "migration from spec version 0.8 to 0.7 is not supported")
+	_, err = Migrate([]byte(`{"specVersion": "0.7"}`), serializer.FormatJSON, "1.0")
+	assert.Error(t, err)
+	_, err = Migrate([]byte(`{"id": "order"}`), serializer.FormatJSON, "0.8")
+	assert.EqualError(t, err, "workflow document has no specVersion")
+	_, err = Migrate([]byte(`[]`), serializer.FormatJSON, "0.8")
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+// Upgraded workflow document migrated and parsed
+type Upgraded struct {
+	Result
+	// Workflow migrated document parsed
+	Workflow *model.Workflow
+}
+
+// Upgrade migrates the workflow document to the given specification version like Migrate, and parses the migrated
+// document with the parser, nil for the one of the package functions. The model decodes the event references renamed
+// by 0.8, produceEventRef and consumeEventRef, into their 0.7 fields.
+func Upgrade(p *parser.Parser, data []byte, format serializer.Format, to string) (*Upgraded, error) {
+	result, err := Migrate(data, format, to)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		p = parser.New(nil)
+	}
+	var workflow *model.Workflow
+	if format == serializer.FormatYAML {
+		workflow, err = p.FromYAMLSource(result.Document)
+	} else {
+		workflow, err = p.FromJSONSource(result.Document)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("workflow migrated to %s doesn't parse: %w", to, err)
+	}
+	return &Upgraded{Result: *result, Workflow: workflow}, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const upgradable0_6 = `{
+  "id": "order",
+  "name": "Order",
+  "version": "1.0",
+  "specVersion": "0.6",
+  "start": "Wait",
+  "states": [
+    {"name": "Wait", "type": "delay", "timeDelay": "PT5S", "transition": "Store"},
+    {
+      "name": "Store",
+      "type": "operation",
+      "actions": [
+        {"functionRef": {"refName": "store", "parameters": {"order": "${ .order }"}}},
+        {"eventRef": {"triggerEventRef": "Order", "resultEventRef": "Stored"}}
+      ],
+      "end": {"kind": "terminate"}
+    }
+  ],
+  "functions": [{"name": "store", "operation": "https://example.com/orders.json#store"}],
+  "events": [
+    {"name": "Order", "source": "orders", "type": "order.created", "kind": "produced"},
+    {"name": "Stored", "source": "orders", "type": "order.stored"}
+  ]
+}`
+
+func TestUpgrade(t *testing.T) {
+	upgraded, err := Upgrade(nil, []byte(upgradable0_6), serializer.FormatJSON, LatestVersion)
+	require.NoError(t, err)
+	assert.Equal(t, "0.8", upgraded.Workflow.SpecVersion)
+	sleep, ok := upgraded.Workflow.States[0].(*model.SleepState)
+	require.True(t, ok)
+	assert.Equal(t, "PT5S", sleep.Duration)
+	operation := upgraded.Workflow.States[1].(*model.OperationState)
+	assert.Equal(t, map[string]interface{}{"order": "${ .order }"}, operation.Actions[0].FunctionRef.Arguments)
+	// the event references renamed by 0.8 are decoded
+	assert.Equal(t, &model.EventRef{TriggerEventRef: "Order", ResultEventRef: "Stored"}, operation.Actions[1].EventRef)
+	assert.True(t, operation.End.Terminate)
+	assert.Len(t, upgraded.Changes, 9)
+	assert.Equal(t, "0.6", upgraded.From)
+
+	_, err = Upgrade(parser.New(nil), []byte(`id: order
+specVersion: '0.7'`), serializer.FormatYAML, LatestVersion)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "workflow migrated to 0.8 doesn't parse")
+	_, err = Upgrade(nil, []byte(`{"id": "order"}`), serializer.FormatJSON, LatestVersion)
+	assert.EqualError(t, err, "workflow document has no specVersion")
+}
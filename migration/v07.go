@@ -0,0 +1,232 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v2/internal/document"
+)
+
+// completionTypes0_6 parallel state completion types renamed in 0.7
+var completionTypes0_6 = map[string]string{"and": "allOf", "xor": "atLeast", "n_of_m": "atLeast"}
+
+// migrate06To07 moves the workflow execTimeout to timeouts, replaces the delay and subflow states by sleep and
+// operation states, splits the switch states with both data and event conditions, replaces the end kinds by the end
+// properties, onErrors error names by error references, and renames the data filters, function arguments and switch
+// default condition properties.
+func migrate06To07(root document.Object, m *migration) document.Object {
+	if execTimeout, ok := root.Get("execTimeout"); ok {
+		if timeouts, ok := root.Get("timeouts"); ok {
+			if timeouts, ok := timeouts.(document.Object); ok {
+				root = root.Set("timeouts", timeouts.Set("workflowExecTimeout", execTimeout)).Without("execTimeout")
+				m.change("timeouts.workflowExecTimeout", "execTimeout moved to timeouts.workflowExecTimeout")
+			}
+		} else {
+			root = root.Rename("execTimeout", "timeouts").Set("timeouts", document.Object{{Key: "workflowExecTimeout", Value: execTimeout}})
+			m.change("timeouts.workflowExecTimeout", "execTimeout moved to timeouts.workflowExecTimeout")
+		}
+	}
+
+	errors := map[string]bool{}
+	if value, ok := root.Get("errors"); ok {
+		each("errors", value, func(_ string, o document.Object) document.Object {
+			errors[stringValue(o, "name")] = true
+			return o
+		})
+	}
+	for i, member := range root {
+		if member.Key == "states" {
+			root[i].Value = splitSwitches06To07(member.Value, m)
+			root[i].Value = each("states", root[i].Value, func(path string, state document.Object) document.Object {
+				return migrateState06To07(path, state, errors, m)
+			})
+		}
+	}
+	return root
+}
+
+// splitSwitches06To07 splits the switch states with both data and event conditions, defining either since 0.7: the
+// event conditions are kept and the data conditions moved to a new switch state, the default condition of the state
+func splitSwitches06To07(states interface{}, m *migration) interface{} {
+	array, ok := states.([]interface{})
+	if !ok {
+		return states
+	}
+	names := map[string]bool{}
+	for _, item := range array {
+		if state, ok := item.(document.Object); ok {
+			names[stringValue(state, "name")] = true
+		}
+	}
+	split := make([]interface{}, 0, len(array))
+	for _, item := range array {
+		split = append(split, item)
+		state, ok := item.(document.Object)
+		if !ok || stringValue(state, "type") != "switch" {
+			continue
+		}
+		dataConditions, hasData := state.Get("dataConditions")
+		if _, hasEvents := state.Get("eventConditions"); !hasData || !hasEvents {
+			continue
+		}
+		name := stringValue(state, "name") + "Data"
+		for names[name] {
+			name += "Data"
+		}
+		names[name] = true
+		dataSwitch := document.Object{
+			{Key: "name", Value: name},
+			{Key: "type", Value: "switch"},
+			{Key: "dataConditions", Value: dataConditions},
+		}
+		if defaultCondition, ok := state.Get("default"); ok {
+			dataSwitch = dataSwitch.Set("default", defaultCondition)
+		}
+		state = state.Without("dataConditions").Set("default", document.Object{{Key: "transition", Value: document.Object{{Key: "nextState", Value: name}}}})
+		split[len(split)-1] = state
+		split = append(split, dataSwitch)
+		path := fmt.Sprintf("states[%d]", len(split)-1)
+		m.change(path, "data conditions of the switch state %s moved to the new switch state %s, its default condition", stringValue(state, "name"), name)
+		m.note(path, "switch states define either data or event conditions since 0.7, the data conditions of %s are evaluated once no event condition matches", stringValue(state, "name"))
+	}
+	return split
+}
+
+func migrateState06To07(path string, state document.Object, errors map[string]bool, m *migration) document.Object {
+	switch stringValue(state, "type") {
+	case "delay":
+		state = state.Set("type", "sleep").Rename("timeDelay", "duration")
+		m.change(path, "delay state replaced by a sleep state, its timeDelay by the duration")
+	case "subflow":
+		subFlowRef := document.Object{{Key: "workflowId", Value: stringValue(state, "workflowId")}}
+		state = state.Set("type", "operation").
+			Rename("workflowId", "actions").
+			Set("actions", []interface{}{document.Object{{Key: "subFlowRef", Value: subFlowRef}}})
+		m.change(path, "subflow state replaced by an operation state invoking the subflow")
+		if waitForCompletion, ok := state.Get("waitForCompletion"); ok {
+			if waitForCompletion == false {
+				m.note(path+".actions[0].subFlowRef", "subflows are always invoked synchronously since 0.7, waitForCompletion false was removed")
+			}
+			state = state.Without("waitForCompletion")
+		}
+		if _, ok := state.Get("repeat"); ok {
+			m.note(path, "subflow repeat is not supported since 0.7 and was removed, use a transition loop instead")
+			state = state.Without("repeat")
+		}
+	case "switch":
+		state = m.rename(path, state, "default", "defaultCondition")
+		for i, member := range state {
+			if member.Key == "eventConditions" {
+				state[i].Value = each(path+".eventConditions", member.Value, func(path string, o document.Object) document.Object {
+					return migrateEventDataFilter06To07(path, o, m)
+				})
+			}
+		}
+	case "parallel":
+		if completionType := stringValue(state, "completionType"); len(completionType) > 0 {
+			if renamed, ok := completionTypes0_6[completionType]; ok {
+				state = state.Set("completionType", renamed)
+				m.change(path+".completionType", "completion type %s renamed to %s", completionType, renamed)
+			}
+			if completionType == "xor" {
+				state = state.Set("numCompleted", 1)
+			}
+		}
+		state = m.rename(path, state, "n", "numCompleted")
+	case "foreach":
+		state = m.rename(path, state, "max", "batchSize")
+	case "callback":
+		state = migrateEventDataFilter06To07(path, state, m)
+	}
+
+	state = update(state, "stateDataFilter", func(filter document.Object) document.Object {
+		filter = m.rename(path+".stateDataFilter", filter, "dataInputPath", "input")
+		return m.rename(path+".stateDataFilter", filter, "dataOutputPath", "output")
+	})
+	for i, member := range state {
+		switch member.Key {
+		case "onErrors":
+			state[i].Value = each(path+".onErrors", member.Value, func(path string, onError document.Object) document.Object {
+				return migrateOnError06To07(path, onError, errors, m)
+			})
+		case "onEvents":
+			state[i].Value = each(path+".onEvents", member.Value, func(path string, o document.Object) document.Object {
+				return migrateEventDataFilter06To07(path, o, m)
+			})
+		}
+	}
+	// the ends of the switch default condition once renamed
+	state = eachEnd(path, state, func(path string, end document.Object) interface{} {
+		return migrateEnd06To07(path, end, m)
+	})
+	return forEachAction(path, state, func(path string, action document.Object) document.Object {
+		action = update(action, "functionRef", func(ref document.Object) document.Object {
+			return m.rename(path+".functionRef", ref, "parameters", "arguments")
+		})
+		return update(action, "actionDataFilter", func(filter document.Object) document.Object {
+			return m.rename(path+".actionDataFilter", filter, "dataResultsPath", "results")
+		})
+	})
+}
+
+// migrateEnd06To07 replaces the kind of the end by the end properties: the default kind by true, the terminate kind by
+// terminate and the event kind by the produceEvents
+func migrateEnd06To07(path string, end document.Object, m *migration) interface{} {
+	kind := stringValue(end, "kind")
+	var migrated interface{}
+	switch kind {
+	case "":
+		return end
+	case "default":
+		migrated = true
+	case "terminate":
+		migrated = end.Rename("kind", "terminate").Set("terminate", true)
+	case "event":
+		end = end.Without("kind")
+		if produceEvent, ok := end.Get("produceEvent"); ok {
+			end = end.Rename("produceEvent", "produceEvents").Set("produceEvents", []interface{}{produceEvent})
+		}
+		migrated = end
+	default:
+		m.note(path, "end kind %s is not supported since 0.7 and was removed", kind)
+		return end.Without("kind")
+	}
+	m.change(path, "end kind %s replaced by the end properties", kind)
+	return migrated
+}
+
+func migrateOnError06To07(path string, onError document.Object, errors map[string]bool, m *migration) document.Object {
+	onError = m.rename(path, onError, "error", "errorRef")
+	if name := stringValue(onError, "errorRef"); len(name) > 0 && name != "*" && !errors[name] {
+		m.note(path+".errorRef", "error %s must be declared in the workflow errors since 0.7", name)
+	}
+	if code, ok := onError.Get("code"); ok {
+		m.note(path, "error code %v was removed, set it in the declaration of the error in the workflow errors", code)
+		onError = onError.Without("code")
+	}
+	if retryRef := stringValue(onError, "retryRef"); len(retryRef) > 0 {
+		m.note(path, "retries are defined on the actions since 0.7, retryRef %s was removed and must be set on the state actions", retryRef)
+		onError = onError.Without("retryRef")
+	}
+	return onError
+}
+
+// migrateEventDataFilter06To07 renames the property of the event data filter of the object at the path
+func migrateEventDataFilter06To07(path string, o document.Object, m *migration) document.Object {
+	return update(o, "eventDataFilter", func(filter document.Object) document.Object {
+		return m.rename(path+".eventDataFilter", filter, "dataOutputPath", "data")
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"github.com/serverlessworkflow/sdk-go/v2/internal/document"
+)
+
+// migrate07To08 renames the action event references and keeps the 0.7 default of the events dataOnly property,
+// which is true since 0.8.
+func migrate07To08(root document.Object, m *migration) document.Object {
+	for i, member := range root {
+		switch member.Key {
+		case "events":
+			root[i].Value = each("events", member.Value, func(path string, event document.Object) document.Object {
+				if _, ok := event.Get("dataOnly"); !ok {
+					event = event.Set("dataOnly", false)
+					m.change(path+".dataOnly", "dataOnly set to false, the default of 0.7")
+				}
+				return event
+			})
+		case "states":
+			root[i].Value = each("states", member.Value, func(path string, state document.Object) document.Object {
+				return forEachAction(path, state, func(path string, action document.Object) document.Object {
+					return update(action, "eventRef", func(ref document.Object) document.Object {
+						ref = m.rename(path+".eventRef", ref, "triggerEventRef", "produceEventRef")
+						return m.rename(path+".eventRef", ref, "resultEventRef", "consumeEventRef")
+					})
+				})
+			})
+		}
+	}
+	return root
+}
@@ -23,7 +23,7 @@ import (
 )
 
 func init() {
-	val.GetValidator().RegisterStructValidation(AuthDefinitionsStructLevelValidation, AuthDefinitions{})
+	val.RegisterDefaultStructValidation(AuthDefinitionsStructLevelValidation, AuthDefinitions{})
 }
 
 // AuthDefinitionsStructLevelValidation custom validator for unique name of the auth methods
@@ -71,10 +71,10 @@ const (
 )
 
 // authTypesMapping map to support JSON unmarshalling when guessing the auth scheme
-var authTypesMapping = map[AuthType]AuthProperties{
-	AuthTypeBasic:  &BasicAuthProperties{},
-	AuthTypeBearer: &BearerAuthProperties{},
-	AuthTypeOAuth2: &OAuth2AuthProperties{},
+var authTypesMapping = map[AuthType]func() AuthProperties{
+	AuthTypeBasic:  func() AuthProperties { return &BasicAuthProperties{} },
+	AuthTypeBearer: func() AuthProperties { return &BearerAuthProperties{} },
+	AuthTypeOAuth2: func() AuthProperties { return &OAuth2AuthProperties{} },
 }
 
 // Auth ...
@@ -102,6 +102,14 @@ func (a *AuthDefinitions) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON implements json.Marshaler, auth definitions are always written as an array
+func (a AuthDefinitions) MarshalJSON() ([]byte, error) {
+	if a.Defs == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(a.Defs)
+}
+
 func (a *AuthDefinitions) unmarshalSingle(data []byte) error {
 	var auth Auth
 	err := json.Unmarshal(data, &auth)
@@ -123,38 +131,44 @@ func (a *AuthDefinitions) unmarshalMany(data []byte) error {
 	return nil
 }
 
+// authUnmarshal Auth with the properties kept raw until the scheme is known
+type authUnmarshal struct {
+	Name       string          `json:"name"`
+	Scheme     AuthType        `json:"scheme"`
+	Properties json.RawMessage `json:"properties"`
+}
+
 // UnmarshalJSON Auth definition
 func (a *Auth) UnmarshalJSON(data []byte) error {
-	auth := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &auth); err != nil {
+	if isJSONString(data) {
 		// it's a file
 		file, err := unmarshalFile(data)
 		if err != nil {
 			return err
 		}
 		// call us recursively
-		if err := json.Unmarshal(file, &a); err != nil {
-			return err
-		}
-		return nil
-	}
-	if err := unmarshalKey("scheme", auth, &a.Scheme); err != nil {
-		return err
+		return json.Unmarshal(file, a)
 	}
-	if err := unmarshalKey("name", auth, &a.Name); err != nil {
+	raw := authUnmarshal{Name: a.Name, Scheme: a.Scheme}
+	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	a.Name = raw.Name
+	a.Scheme = raw.Scheme
 
 	if len(a.Scheme) == 0 {
 		a.Scheme = AuthTypeBasic
 	}
-	if _, ok := authTypesMapping[a.Scheme]; !ok {
-		return fmt.Errorf("authentication scheme %s not supported", authTypesMapping["type"])
+	newProperties, ok := authTypesMapping[a.Scheme]
+	if !ok {
+		return errorf(ErrUnknownAuthScheme, "authentication scheme %s not supported", a.Scheme)
 	}
 	// we take the type we want to unmarshal based on the scheme
-	authProperties := authTypesMapping[a.Scheme]
-	if err := unmarshalKey("properties", auth, authProperties); err != nil {
-		return err
+	authProperties := newProperties()
+	if raw.Properties != nil {
+		if err := json.Unmarshal(raw.Properties, authProperties); err != nil {
+			return err
+		}
 	}
 
 	a.Properties = authProperties
@@ -178,21 +192,14 @@ type BaseAuthProperties struct {
 
 // UnmarshalJSON ...
 func (b *BaseAuthProperties) UnmarshalJSON(data []byte) error {
-	properties := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &properties); err != nil {
+	if isJSONString(data) {
+		var err error
 		b.Secret, err = unmarshalString(data)
-		if err != nil {
-			return err
-		}
-		return nil
-	}
-	if err := unmarshalKey("metadata", properties, &b.Metadata); err != nil {
 		return err
 	}
-	if err := unmarshalKey("secret", properties, &b.Secret); err != nil {
-		return err
-	}
-	return nil
+	// the conversion drops the UnmarshalJSON method, the object form is decoded directly
+	type baseAuthProperties BaseAuthProperties
+	return json.Unmarshal(data, (*baseAuthProperties)(b))
 }
 
 // GetMetadata ...
@@ -214,25 +221,28 @@ type BasicAuthProperties struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// basicAuthPropertiesUnmarshal properties of the object form of BasicAuthProperties. BasicAuthProperties can't be
+// decoded through a conversion, which would keep the UnmarshalJSON method promoted from BaseAuthProperties.
+type basicAuthPropertiesUnmarshal struct {
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	Metadata Metadata `json:"metadata"`
+	Secret   string   `json:"secret"`
+}
+
 // UnmarshalJSON ...
 func (b *BasicAuthProperties) UnmarshalJSON(data []byte) error {
-	properties := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &properties); err != nil {
-		err = json.Unmarshal(data, &b.BaseAuthProperties)
-		if err != nil {
-			return err
-		}
-		return nil
+	if !isJSONObject(data) {
+		return json.Unmarshal(data, &b.BaseAuthProperties)
 	}
-	if err := unmarshalKey("username", properties, &b.Username); err != nil {
-		return err
-	}
-	if err := unmarshalKey("password", properties, &b.Password); err != nil {
-		return err
-	}
-	if err := unmarshalKey("metadata", properties, &b.Metadata); err != nil {
+	raw := basicAuthPropertiesUnmarshal{Username: b.Username, Password: b.Password, Metadata: b.Metadata, Secret: b.Secret}
+	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	b.Username = raw.Username
+	b.Password = raw.Password
+	b.Metadata = raw.Metadata
+	b.Secret = raw.Secret
 	return nil
 }
 
@@ -243,22 +253,26 @@ type BearerAuthProperties struct {
 	Token string `json:"token" validate:"required"`
 }
 
+// bearerAuthPropertiesUnmarshal properties of the object form of BearerAuthProperties, see
+// basicAuthPropertiesUnmarshal
+type bearerAuthPropertiesUnmarshal struct {
+	Token    string   `json:"token"`
+	Metadata Metadata `json:"metadata"`
+	Secret   string   `json:"secret"`
+}
+
 // UnmarshalJSON ...
 func (b *BearerAuthProperties) UnmarshalJSON(data []byte) error {
-	properties := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &properties); err != nil {
-		err = json.Unmarshal(data, &b.BaseAuthProperties)
-		if err != nil {
-			return err
-		}
-		return nil
-	}
-	if err := unmarshalKey("token", properties, &b.Token); err != nil {
-		return err
+	if !isJSONObject(data) {
+		return json.Unmarshal(data, &b.BaseAuthProperties)
 	}
-	if err := unmarshalKey("metadata", properties, &b.Metadata); err != nil {
+	raw := bearerAuthPropertiesUnmarshal{Token: b.Token, Metadata: b.Metadata, Secret: b.Secret}
+	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	b.Token = raw.Token
+	b.Metadata = raw.Metadata
+	b.Secret = raw.Secret
 	return nil
 }
 
@@ -289,53 +303,59 @@ type OAuth2AuthProperties struct {
 	RequestedIssuer string `json:"requestedIssuer,omitempty" validate:"omitempty,min=1"`
 }
 
-// TODO: use reflection to unmarshal the keys and think on a generic approach to handle them
+// oauth2AuthPropertiesUnmarshal properties of the object form of OAuth2AuthProperties, see
+// basicAuthPropertiesUnmarshal
+type oauth2AuthPropertiesUnmarshal struct {
+	Authority        string    `json:"authority"`
+	GrantType        GrantType `json:"grantType"`
+	ClientID         string    `json:"clientId"`
+	ClientSecret     string    `json:"clientSecret"`
+	Scopes           []string  `json:"scopes"`
+	Username         string    `json:"username"`
+	Password         string    `json:"password"`
+	Audiences        []string  `json:"audiences"`
+	SubjectToken     string    `json:"subjectToken"`
+	RequestedSubject string    `json:"requestedSubject"`
+	RequestedIssuer  string    `json:"requestedIssuer"`
+	Metadata         Metadata  `json:"metadata"`
+	Secret           string    `json:"secret"`
+}
 
 // UnmarshalJSON ...
 func (b *OAuth2AuthProperties) UnmarshalJSON(data []byte) error {
-	properties := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &properties); err != nil {
-		err = json.Unmarshal(data, &b.BaseAuthProperties)
-		if err != nil {
-			return err
-		}
-		return nil
-	}
-	if err := unmarshalKey("authority", properties, &b.Authority); err != nil {
-		return err
-	}
-	if err := unmarshalKey("grantType", properties, &b.GrantType); err != nil {
-		return err
-	}
-	if err := unmarshalKey("clientId", properties, &b.ClientID); err != nil {
-		return err
-	}
-	if err := unmarshalKey("clientSecret", properties, &b.ClientSecret); err != nil {
-		return err
-	}
-	if err := unmarshalKey("scopes", properties, &b.Scopes); err != nil {
-		return err
-	}
-	if err := unmarshalKey("username", properties, &b.Username); err != nil {
-		return err
-	}
-	if err := unmarshalKey("password", properties, &b.Password); err != nil {
-		return err
-	}
-	if err := unmarshalKey("audiences", properties, &b.Audiences); err != nil {
-		return err
-	}
-	if err := unmarshalKey("subjectToken", properties, &b.SubjectToken); err != nil {
-		return err
-	}
-	if err := unmarshalKey("requestedSubject", properties, &b.RequestedSubject); err != nil {
-		return err
-	}
-	if err := unmarshalKey("requestedIssuer", properties, &b.RequestedIssuer); err != nil {
-		return err
-	}
-	if err := unmarshalKey("metadata", properties, &b.Metadata); err != nil {
+	if !isJSONObject(data) {
+		return json.Unmarshal(data, &b.BaseAuthProperties)
+	}
+	raw := oauth2AuthPropertiesUnmarshal{
+		Authority:        b.Authority,
+		GrantType:        b.GrantType,
+		ClientID:         b.ClientID,
+		ClientSecret:     b.ClientSecret,
+		Scopes:           b.Scopes,
+		Username:         b.Username,
+		Password:         b.Password,
+		Audiences:        b.Audiences,
+		SubjectToken:     b.SubjectToken,
+		RequestedSubject: b.RequestedSubject,
+		RequestedIssuer:  b.RequestedIssuer,
+		Metadata:         b.Metadata,
+		Secret:           b.Secret,
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	b.Authority = raw.Authority
+	b.GrantType = raw.GrantType
+	b.ClientID = raw.ClientID
+	b.ClientSecret = raw.ClientSecret
+	b.Scopes = raw.Scopes
+	b.Username = raw.Username
+	b.Password = raw.Password
+	b.Audiences = raw.Audiences
+	b.SubjectToken = raw.SubjectToken
+	b.RequestedSubject = raw.RequestedSubject
+	b.RequestedIssuer = raw.RequestedIssuer
+	b.Metadata = raw.Metadata
+	b.Secret = raw.Secret
 	return nil
 }
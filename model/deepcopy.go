@@ -0,0 +1,41 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "encoding/json"
+
+//go:generate go run ../hack/deepcopy model
+
+// deepCopyValue copies the free-form JSON values, e.g. the metadata and the function arguments. Values of other types
+// are shared.
+func deepCopyValue(in interface{}) interface{} {
+	switch v := in.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			out[key] = deepCopyValue(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = deepCopyValue(val)
+		}
+		return out
+	case json.RawMessage:
+		return append(json.RawMessage(nil), v...)
+	}
+	return in
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowDeepCopy(t *testing.T) {
+	files, err := filepath.Glob("../parser/testdata/workflows/*.*")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			workflow, err := parser.FromFile(file)
+			if err != nil {
+				t.Skip(err)
+			}
+			copied := workflow.DeepCopy()
+			assert.True(t, workflow.Equal(copied))
+			assertNotShared(t, "workflow", reflect.ValueOf(workflow), reflect.ValueOf(copied))
+		})
+	}
+}
+
+// assertNotShared fails if the values share a pointer, map or slice. The unexported fields, e.g. the index of the
+// workflows, are derived from the exported ones and shared by the copies.
+func assertNotShared(t *testing.T, path string, a, b reflect.Value) {
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if a.IsNil() || b.IsNil() {
+			return
+		}
+		if a.Kind() != reflect.Slice || a.Len() > 0 {
+			assert.NotEqual(t, a.Pointer(), b.Pointer(), "%s is shared", path)
+		}
+	}
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !a.IsNil() && !b.IsNil() {
+			assertNotShared(t, path, a.Elem(), b.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < a.Len() && i < b.Len(); i++ {
+			assertNotShared(t, path+"[]", a.Index(i), b.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range a.MapKeys() {
+			if value := b.MapIndex(key); value.IsValid() {
+				assertNotShared(t, path+"."+key.String(), a.MapIndex(key), value)
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if f := a.Type().Field(i); len(f.PkgPath) > 0 && !f.Anonymous {
+				continue
+			}
+			assertNotShared(t, path+"."+a.Type().Field(i).Name, a.Field(i), b.Field(i))
+		}
+	}
+}
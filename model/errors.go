@@ -0,0 +1,136 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/util/iso8601"
+	"gopkg.in/go-playground/validator.v8"
+)
+
+// iso8601DurationTag validate tag of the ISO 8601 durations, see the validator package
+const iso8601DurationTag = "iso8601duration"
+
+var (
+	// ErrUnknownStateType the states without type or of a type not supported fail to decode with it
+	ErrUnknownStateType = errors.New("unknown state type")
+	// ErrUnknownAuthScheme the authentication definitions of a scheme not supported fail to decode with it
+	ErrUnknownAuthScheme = errors.New("unknown authentication scheme")
+	// ErrMissingStates the workflows without states fail to decode with it
+	ErrMissingStates = errors.New("workflow states are required")
+	// ErrDuplicateName the definitions added with the name of a definition of the same kind fail with it
+	ErrDuplicateName = errors.New("duplicated definition name")
+	// ErrInvalidDuration the durations not valid ISO 8601 durations fail with it, when parsed or validated: a
+	// SchemaError matches it if a property failed the iso8601duration tag
+	ErrInvalidDuration = iso8601.ErrInvalidDuration
+)
+
+// ReferenceError reference to a definition not defined in the workflow
+type ReferenceError struct {
+	// Kind of the definition referenced: state, function, event, retry or error
+	Kind string
+	// Name of the definition referenced
+	Name string
+	// Path of the reference, e.g. 'states[0].transition.nextState'
+	Path string
+}
+
+// Error ...
+func (e *ReferenceError) Error() string {
+	return fmt.Sprintf("%s: %s %s is not defined", e.Path, e.Kind, e.Name)
+}
+
+// SchemaError workflow violating the validate tags of the model, or the struct level validations
+type SchemaError struct {
+	// Errors errors of the fields, errors.As extracting them from the SchemaError too
+	Errors validator.ValidationErrors
+}
+
+// Error ...
+func (e *SchemaError) Error() string {
+	return e.Errors.Error()
+}
+
+// Unwrap ...
+func (e *SchemaError) Unwrap() error {
+	return e.Errors
+}
+
+// Is matches ErrInvalidDuration if a property failed the iso8601duration tag
+func (e *SchemaError) Is(target error) bool {
+	if target != ErrInvalidDuration {
+		return false
+	}
+	for _, fieldErr := range e.Errors {
+		if fieldErr.Tag == iso8601DurationTag {
+			return true
+		}
+	}
+	return false
+}
+
+// messageError error with its own message matching a sentinel error, keeping the messages of the errors which
+// became typed
+type messageError struct {
+	message string
+	err     error
+}
+
+// Error ...
+func (e *messageError) Error() string {
+	return e.message
+}
+
+// Unwrap ...
+func (e *messageError) Unwrap() error {
+	return e.err
+}
+
+// errorf returns an error of the formatted message matching err
+func errorf(err error, format string, args ...interface{}) error {
+	return &messageError{message: fmt.Sprintf(format, args...), err: err}
+}
+
+// DecodeError error decoding the value at the JSON pointer of the workflow, e.g. '/states/2' for its third state. Its
+// message is the one of the error, the pointer being added by the parser with the other context of the error.
+type DecodeError struct {
+	// Pointer JSON pointer of the value, see RFC 6901
+	Pointer string
+	Err     error
+}
+
+// Error ...
+func (e *DecodeError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap ...
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeError returns the error decoding the value at the pointer, the pointer of the type errors of encoding/json
+// being relative to the value
+func decodeError(pointer string, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && len(typeErr.Field) > 0 {
+		pointer += "/" + strings.Replace(typeErr.Field, ".", "/", -1)
+	}
+	return &DecodeError{Pointer: pointer, Err: err}
+}
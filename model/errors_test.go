@@ -0,0 +1,58 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/validator.v8"
+)
+
+func TestErrors(t *testing.T) {
+	err := json.Unmarshal([]byte(`{"states": [{"name": "Wait", "type": "wait"}]}`), &Workflow{})
+	assert.True(t, errors.Is(err, ErrUnknownStateType))
+	assert.EqualError(t, err, "state wait not supported")
+	err = json.Unmarshal([]byte(`{"states": [{"name": "Wait"}]}`), &Workflow{})
+	assert.True(t, errors.Is(err, ErrUnknownStateType))
+	assert.True(t, errors.Is(json.Unmarshal([]byte(`{"id": "order"}`), &Workflow{}), ErrMissingStates))
+	assert.True(t, errors.Is(json.Unmarshal([]byte(`{"name": "digest", "scheme": "digest"}`), &Auth{}), ErrUnknownAuthScheme))
+
+	workflow := &Workflow{States: []State{&SleepState{BaseState: BaseState{Name: "Wait"}}}}
+	err = workflow.AddState(&SleepState{BaseState: BaseState{Name: "Wait"}})
+	assert.True(t, errors.Is(err, ErrDuplicateName))
+	assert.EqualError(t, err, "state Wait already defined")
+
+	assert.EqualError(t, &ReferenceError{Kind: "state", Name: "Store", Path: "states[0].transition.nextState"},
+		"states[0].transition.nextState: state Store is not defined")
+}
+
+func TestSchemaError(t *testing.T) {
+	fieldErrs := validator.ValidationErrors{
+		"Workflow.Timeouts.ActionExecTimeout": {NameNamespace: "Timeouts.ActionExecTimeout", Tag: iso8601DurationTag},
+	}
+	var err error = &SchemaError{Errors: fieldErrs}
+	assert.True(t, errors.Is(err, ErrInvalidDuration))
+	var unwrapped validator.ValidationErrors
+	require.True(t, errors.As(err, &unwrapped))
+	assert.Equal(t, fieldErrs, unwrapped)
+	assert.Equal(t, fieldErrs.Error(), err.Error())
+
+	err = &SchemaError{Errors: validator.ValidationErrors{"Workflow.ID": {NameNamespace: "ID", Tag: "required"}}}
+	assert.False(t, errors.Is(err, ErrInvalidDuration))
+}
@@ -15,6 +15,8 @@
 package model
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
 
 	val "github.com/serverlessworkflow/sdk-go/v2/validator"
@@ -29,7 +31,7 @@ const (
 )
 
 func init() {
-	val.GetValidator().RegisterStructValidation(EventStructLevelValidation, Event{})
+	val.RegisterDefaultStructValidation(EventStructLevelValidation, Event{})
 }
 
 // EventStructLevelValidation custom validator for event kind consumed
@@ -81,3 +83,48 @@ type EventRef struct {
 	// Add additional extension context attributes to the produced event
 	ContextAttributes map[string]interface{} `json:"contextAttributes,omitempty"`
 }
+
+// GetTriggerEventRef returns the name of the event triggered, empty if the reference is nil
+func (e *EventRef) GetTriggerEventRef() string {
+	if e == nil {
+		return ""
+	}
+	return e.TriggerEventRef
+}
+
+// GetResultEventRef returns the name of the event waited for, empty if the reference is nil
+func (e *EventRef) GetResultEventRef() string {
+	if e == nil {
+		return ""
+	}
+	return e.ResultEventRef
+}
+
+// UnmarshalJSON ...
+func (e *EventRef) UnmarshalJSON(data []byte) error {
+	// the conversion drops the UnmarshalJSON method, the references are decoded with their 0.8 names too, e.g. for
+	// the workflows upgraded by the migration package
+	type eventRef EventRef
+	ref := &struct {
+		*eventRef
+		ProduceEventRef string `json:"produceEventRef"`
+		ConsumeEventRef string `json:"consumeEventRef"`
+	}{eventRef: (*eventRef)(e)}
+	if err := json.Unmarshal(data, ref); err != nil {
+		return err
+	}
+	// a reference with both names is ambiguous, the workflows using either version of the specification
+	if len(ref.ProduceEventRef) > 0 {
+		if len(e.TriggerEventRef) > 0 {
+			return fmt.Errorf("eventRef has both triggerEventRef and produceEventRef, its 0.8 name")
+		}
+		e.TriggerEventRef = ref.ProduceEventRef
+	}
+	if len(ref.ConsumeEventRef) > 0 {
+		if len(e.ResultEventRef) > 0 {
+			return fmt.Errorf("eventRef has both resultEventRef and consumeEventRef, its 0.8 name")
+		}
+		e.ResultEventRef = ref.ConsumeEventRef
+	}
+	return nil
+}
@@ -59,20 +59,12 @@ type FunctionRef struct {
 
 // UnmarshalJSON ...
 func (f *FunctionRef) UnmarshalJSON(data []byte) error {
-	funcRef := make(map[string]interface{})
-	if err := json.Unmarshal(data, &funcRef); err != nil {
+	if isJSONString(data) {
+		var err error
 		f.RefName, err = unmarshalString(data)
-		if err != nil {
-			return err
-		}
-		return nil
+		return err
 	}
-
-	f.RefName = requiresNotNilOrEmpty(funcRef["refName"])
-	if _, found := funcRef["arguments"]; found {
-		f.Arguments = funcRef["arguments"].(map[string]interface{})
-	}
-	f.SelectionSet = requiresNotNilOrEmpty(funcRef["selectionSet"])
-
-	return nil
+	// the conversion drops the UnmarshalJSON method, the object form is decoded directly
+	type functionRef FunctionRef
+	return json.Unmarshal(data, (*functionRef)(f))
 }
@@ -0,0 +1,274 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"sync"
+)
+
+// kinds of the definitions indexed by name
+const (
+	indexStates = iota
+	indexFunctions
+	indexEvents
+	indexRetries
+	indexKinds
+)
+
+// workflowIndex positions of the definitions of a workflow by name, set when the workflow is decoded or modified by
+// its mutation methods, each kind being indexed by its first lookup. The hits are checked against the definitions, a
+// stale hit rebuilding the index of its kind, but the misses aren't: the workflows whose slices were modified directly
+// must be reindexed, see Reindex.
+type workflowIndex struct {
+	mu    sync.RWMutex
+	names [indexKinds]map[string]int
+}
+
+// definitions names of the definitions of the kind, by position
+type definitions interface {
+	Len() int
+	Name(i int) string
+}
+
+type stateNames []State
+
+func (s stateNames) Len() int          { return len(s) }
+func (s stateNames) Name(i int) string { return s[i].GetName() }
+
+type functionNames []Function
+
+func (f functionNames) Len() int          { return len(f) }
+func (f functionNames) Name(i int) string { return f[i].Name }
+
+type eventNames []Event
+
+func (e eventNames) Len() int          { return len(e) }
+func (e eventNames) Name(i int) string { return e[i].Name }
+
+type retryNames []Retry
+
+func (r retryNames) Len() int          { return len(r) }
+func (r retryNames) Name(i int) string { return r[i].Name }
+
+func (w *Workflow) definitions() [indexKinds]definitions {
+	return [indexKinds]definitions{
+		indexStates:    stateNames(w.States),
+		indexFunctions: functionNames(w.Functions),
+		indexEvents:    eventNames(w.Events),
+		indexRetries:   retryNames(w.Retries),
+	}
+}
+
+// names positions of the definitions by name, the first one winning for duplicated names like a scan
+func names(defs definitions) map[string]int {
+	names := make(map[string]int, defs.Len())
+	for i := defs.Len() - 1; i >= 0; i-- {
+		names[defs.Name(i)] = i
+	}
+	return names
+}
+
+// Reindex indexes the definitions of the workflow again, once its slices were modified directly. Like the other
+// modifications of the workflow, it must happen before the workflow is shared between goroutines.
+func (w *Workflow) Reindex() {
+	w.index = &workflowIndex{}
+}
+
+// ensureIndex indexes the workflow unless it is, e.g. built in code. Like the other modifications of the workflow, it
+// must happen before the workflow is shared between goroutines.
+func (w *Workflow) ensureIndex() {
+	if w.index == nil {
+		w.index = &workflowIndex{}
+	}
+}
+
+// Equal checks whether the workflows have the same definitions, regardless of their indexes, which
+// reflect.DeepEqual compares
+func (w *Workflow) Equal(other *Workflow) bool {
+	if w == nil || other == nil {
+		return w == other
+	}
+	a, b := *w, *other
+	a.index, b.index = nil, nil
+	return reflect.DeepEqual(&a, &b)
+}
+
+// lookup returns the position of the definition of the kind with the given name. The workflows without index, e.g.
+// built in code, are scanned.
+func (w *Workflow) lookup(kind int, name string) (int, bool) {
+	defs, index := w.definitions()[kind], w.index
+	if index == nil {
+		for i := 0; i < defs.Len(); i++ {
+			if defs.Name(i) == name {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+	index.mu.RLock()
+	i, ok := index.names[kind][name]
+	indexed := index.names[kind] != nil
+	index.mu.RUnlock()
+	if indexed && (!ok || i < defs.Len() && defs.Name(i) == name) {
+		return i, ok
+	}
+	// the kind isn't indexed yet, or the hit is stale
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	index.names[kind] = names(defs)
+	i, ok = index.names[kind][name]
+	return i, ok
+}
+
+// mutableLookup returns the position of the definition of the kind with the given name, indexing the workflow being
+// modified
+func (w *Workflow) mutableLookup(kind int, name string) (int, bool) {
+	w.ensureIndex()
+	return w.lookup(kind, name)
+}
+
+// added indexes the definition of the kind appended to the workflow
+func (w *Workflow) added(kind int, name string) {
+	if index := w.index; index != nil {
+		index.mu.Lock()
+		defer index.mu.Unlock()
+		if index.names[kind] != nil {
+			index.names[kind][name] = w.definitions()[kind].Len() - 1
+		}
+	}
+}
+
+// removed drops the index of the kind once a definition was removed, their positions having changed
+func (w *Workflow) removed(kind int) {
+	if index := w.index; index != nil {
+		index.mu.Lock()
+		defer index.mu.Unlock()
+		index.names[kind] = nil
+	}
+}
+
+// State returns the state with the given name
+func (w *Workflow) State(name string) (State, bool) {
+	if i, ok := w.lookup(indexStates, name); ok {
+		return w.States[i], true
+	}
+	return nil, false
+}
+
+// Function returns the function definition with the given name
+func (w *Workflow) Function(name string) (*Function, bool) {
+	if i, ok := w.lookup(indexFunctions, name); ok {
+		return &w.Functions[i], true
+	}
+	return nil, false
+}
+
+// Event returns the event definition with the given name
+func (w *Workflow) Event(name string) (*Event, bool) {
+	if i, ok := w.lookup(indexEvents, name); ok {
+		return &w.Events[i], true
+	}
+	return nil, false
+}
+
+// Retry returns the retry definition with the given name
+func (w *Workflow) Retry(name string) (*Retry, bool) {
+	if i, ok := w.lookup(indexRetries, name); ok {
+		return &w.Retries[i], true
+	}
+	return nil, false
+}
+
+// AddState appends the state to the workflow, failing if the workflow has a state with the same name
+func (w *Workflow) AddState(state State) error {
+	if _, ok := w.mutableLookup(indexStates, state.GetName()); ok {
+		return errorf(ErrDuplicateName, "state %s already defined", state.GetName())
+	}
+	w.States = append(w.States, state)
+	w.added(indexStates, state.GetName())
+	return nil
+}
+
+// RemoveState removes the state with the given name, returns whether the workflow had one
+func (w *Workflow) RemoveState(name string) bool {
+	i, ok := w.mutableLookup(indexStates, name)
+	if ok {
+		w.States = append(w.States[:i], w.States[i+1:]...)
+		w.removed(indexStates)
+	}
+	return ok
+}
+
+// AddFunction appends the function definition to the workflow, failing if the workflow has a function with the same
+// name
+func (w *Workflow) AddFunction(function Function) error {
+	if _, ok := w.mutableLookup(indexFunctions, function.Name); ok {
+		return errorf(ErrDuplicateName, "function %s already defined", function.Name)
+	}
+	w.Functions = append(w.Functions, function)
+	w.added(indexFunctions, function.Name)
+	return nil
+}
+
+// RemoveFunction removes the function definition with the given name, returns whether the workflow had one
+func (w *Workflow) RemoveFunction(name string) bool {
+	i, ok := w.mutableLookup(indexFunctions, name)
+	if ok {
+		w.Functions = append(w.Functions[:i], w.Functions[i+1:]...)
+		w.removed(indexFunctions)
+	}
+	return ok
+}
+
+// AddEvent appends the event definition to the workflow, failing if the workflow has an event with the same name
+func (w *Workflow) AddEvent(event Event) error {
+	if _, ok := w.mutableLookup(indexEvents, event.Name); ok {
+		return errorf(ErrDuplicateName, "event %s already defined", event.Name)
+	}
+	w.Events = append(w.Events, event)
+	w.added(indexEvents, event.Name)
+	return nil
+}
+
+// RemoveEvent removes the event definition with the given name, returns whether the workflow had one
+func (w *Workflow) RemoveEvent(name string) bool {
+	i, ok := w.mutableLookup(indexEvents, name)
+	if ok {
+		w.Events = append(w.Events[:i], w.Events[i+1:]...)
+		w.removed(indexEvents)
+	}
+	return ok
+}
+
+// AddRetry appends the retry definition to the workflow, failing if the workflow has a retry with the same name
+func (w *Workflow) AddRetry(retry Retry) error {
+	if _, ok := w.mutableLookup(indexRetries, retry.Name); ok {
+		return errorf(ErrDuplicateName, "retry %s already defined", retry.Name)
+	}
+	w.Retries = append(w.Retries, retry)
+	w.added(indexRetries, retry.Name)
+	return nil
+}
+
+// RemoveRetry removes the retry definition with the given name, returns whether the workflow had one
+func (w *Workflow) RemoveRetry(name string) bool {
+	i, ok := w.mutableLookup(indexRetries, name)
+	if ok {
+		w.Retries = append(w.Retries[:i], w.Retries[i+1:]...)
+		w.removed(indexRetries)
+	}
+	return ok
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const indexedWorkflow = `{
+  "id": "order",
+  "name": "Order",
+  "specVersion": "0.8",
+  "start": "Store",
+  "functions": [{"name": "store", "operation": "http://api.example.com/openapi.json#store"},
+    {"name": "notify", "operation": "http://api.example.com/openapi.json#notify"}],
+  "events": [{"name": "Stored", "type": "order.stored", "kind": "produced"}],
+  "retries": [{"name": "Default", "maxAttempts": 3}],
+  "states": [
+    {"name": "Store", "type": "operation", "actions": [{"functionRef": "store"}], "transition": "Notify"},
+    {"name": "Notify", "type": "operation", "actions": [{"functionRef": "notify"}], "end": true}
+  ]
+}`
+
+func TestWorkflowLookups(t *testing.T) {
+	workflow := &Workflow{}
+	require.NoError(t, json.Unmarshal([]byte(indexedWorkflow), workflow))
+	require.NotNil(t, workflow.index)
+
+	state, ok := workflow.State("Notify")
+	require.True(t, ok)
+	assert.Same(t, workflow.States[1], state)
+	function, ok := workflow.Function("notify")
+	require.True(t, ok)
+	assert.Same(t, &workflow.Functions[1], function)
+	event, ok := workflow.Event("Stored")
+	require.True(t, ok)
+	assert.Equal(t, "order.stored", event.Type)
+	retry, ok := workflow.Retry("Default")
+	require.True(t, ok)
+	assert.Equal(t, "Default", retry.Name)
+	_, ok = workflow.State("Missing")
+	assert.False(t, ok)
+
+	// the stale hits of the slices modified directly rebuild the index, the misses need a Reindex
+	workflow.Functions = workflow.Functions[1:]
+	function, ok = workflow.Function("notify")
+	require.True(t, ok)
+	assert.Same(t, &workflow.Functions[0], function)
+	_, ok = workflow.Function("store")
+	assert.False(t, ok)
+	workflow.States[0].(*OperationState).Name = "Save"
+	_, ok = workflow.State("Store")
+	assert.False(t, ok)
+	state, ok = workflow.State("Save")
+	require.True(t, ok)
+	assert.Same(t, workflow.States[0], state)
+	workflow.Functions = append(workflow.Functions, Function{Name: "audit"})
+	_, ok = workflow.Function("audit")
+	assert.False(t, ok)
+	workflow.Reindex()
+	function, ok = workflow.Function("audit")
+	require.True(t, ok)
+	assert.Same(t, &workflow.Functions[1], function)
+}
+
+func TestWorkflowMutations(t *testing.T) {
+	// workflows built in code are indexed on their first lookup
+	workflow := &Workflow{}
+	require.NoError(t, workflow.AddState(&InjectState{BaseState: BaseState{Name: "Init", Type: StateTypeInject}}))
+	require.NoError(t, workflow.AddState(&InjectState{BaseState: BaseState{Name: "Done", Type: StateTypeInject}}))
+	assert.EqualError(t, workflow.AddState(&InjectState{BaseState: BaseState{Name: "Init"}}), "state Init already defined")
+	require.NoError(t, workflow.AddFunction(Function{Name: "store"}))
+	assert.EqualError(t, workflow.AddFunction(Function{Name: "store"}), "function store already defined")
+	require.NoError(t, workflow.AddEvent(Event{Name: "Stored"}))
+	assert.EqualError(t, workflow.AddEvent(Event{Name: "Stored"}), "event Stored already defined")
+	require.NoError(t, workflow.AddRetry(Retry{Name: "Default"}))
+	assert.EqualError(t, workflow.AddRetry(Retry{Name: "Default"}), "retry Default already defined")
+
+	state, ok := workflow.State("Done")
+	require.True(t, ok)
+	assert.Same(t, workflow.States[1], state)
+	assert.True(t, workflow.RemoveState("Init"))
+	assert.False(t, workflow.RemoveState("Init"))
+	state, ok = workflow.State("Done")
+	require.True(t, ok)
+	assert.Same(t, workflow.States[0], state)
+	assert.Len(t, workflow.States, 1)
+
+	assert.True(t, workflow.RemoveFunction("store"))
+	assert.True(t, workflow.RemoveEvent("Stored"))
+	assert.True(t, workflow.RemoveRetry("Default"))
+	assert.Empty(t, workflow.Functions)
+	assert.Empty(t, workflow.Events)
+	assert.Empty(t, workflow.Retries)
+	_, ok = workflow.Retry("Default")
+	assert.False(t, ok)
+}
+
+func TestWorkflowLookupsConcurrency(t *testing.T) {
+	workflow := &Workflow{}
+	require.NoError(t, json.Unmarshal([]byte(indexedWorkflow), workflow))
+	copied := workflow.DeepCopy()
+	copied.Functions = copied.Functions[:1]
+	// workflows built in code are scanned
+	built := &Workflow{Functions: []Function{{Name: "store"}, {Name: "notify"}}}
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			_, ok := workflow.Function("notify")
+			assert.True(t, ok)
+		}()
+		// the copies are indexed on their own
+		go func() {
+			defer wg.Done()
+			_, ok := copied.Function("notify")
+			assert.False(t, ok)
+		}()
+		go func() {
+			defer wg.Done()
+			_, ok := built.Function("notify")
+			assert.True(t, ok)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWorkflowIndexEquality(t *testing.T) {
+	workflow := &Workflow{}
+	require.NoError(t, json.Unmarshal([]byte(indexedWorkflow), workflow))
+	parsed := &Workflow{}
+	require.NoError(t, json.Unmarshal([]byte(indexedWorkflow), parsed))
+	built := &Workflow{BaseWorkflow: workflow.BaseWorkflow, States: workflow.States, Events: workflow.Events,
+		Functions: workflow.Functions, Retries: workflow.Retries}
+
+	// the indexes don't make the workflows with the same definitions differ
+	_, ok := parsed.Function("notify")
+	require.True(t, ok)
+	assert.True(t, workflow.Equal(parsed))
+	assert.True(t, workflow.Equal(built))
+	assert.False(t, workflow.Equal(&Workflow{}))
+	assert.True(t, (*Workflow)(nil).Equal(nil))
+
+	// the copies are indexed on their own
+	copied := workflow.DeepCopy()
+	assert.True(t, workflow.Equal(copied))
+	require.NotNil(t, copied.index)
+	assert.NotSame(t, workflow.index, copied.index)
+	require.NoError(t, copied.AddFunction(Function{Name: "audit"}))
+	_, ok = workflow.Function("audit")
+	assert.False(t, ok)
+}
@@ -0,0 +1,141 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "sync"
+
+const (
+	internShards = 64
+	// internShardSize strings of a shard, the shard is emptied once full, bounding the memory of the table
+	internShardSize = 4096
+	// maxInternedLength length above which the strings aren't interned, the long strings are rarely repeated
+	maxInternedLength = 128
+)
+
+// interned table of the names and references of the decoded workflows: the names repeated across the states of a
+// workflow, and across the workflows of a workspace, e.g. the function, event and state names and the types, share a
+// single copy instead of one per occurrence
+var interned [internShards]internShard
+
+type internShard struct {
+	mu      sync.Mutex
+	strings map[string]string
+}
+
+// intern returns the interned copy of the string
+func intern(s string) string {
+	if len(s) == 0 || len(s) > maxInternedLength {
+		return s
+	}
+	// FNV-1a
+	hash := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		hash ^= uint32(s[i])
+		hash *= 16777619
+	}
+	shard := &interned[hash%internShards]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if value, ok := shard.strings[s]; ok {
+		return value
+	}
+	if shard.strings == nil || len(shard.strings) >= internShardSize {
+		shard.strings = make(map[string]string, 64)
+	}
+	shard.strings[s] = s
+	return s
+}
+
+func internAll(strings []string) {
+	for i := range strings {
+		strings[i] = intern(strings[i])
+	}
+}
+
+// baseState returns the base state of the states embedding it
+func (s *BaseState) baseState() *BaseState {
+	return s
+}
+
+// internDefinitions interns the names of the definitions of the workflow
+func (w *Workflow) internDefinitions() {
+	w.Name = intern(w.Name)
+	w.Version = intern(w.Version)
+	w.SpecVersion = intern(w.SpecVersion)
+	w.ExpressionLang = intern(w.ExpressionLang)
+	for i := range w.Functions {
+		f := &w.Functions[i]
+		f.Name = intern(f.Name)
+		f.Operation = intern(f.Operation)
+		f.Type = FunctionType(intern(string(f.Type)))
+		f.AuthRef = intern(f.AuthRef)
+	}
+	for i := range w.Events {
+		e := &w.Events[i]
+		e.Name = intern(e.Name)
+		e.Source = intern(e.Source)
+		e.Type = intern(e.Type)
+		e.Kind = EventKind(intern(string(e.Kind)))
+	}
+	for i := range w.Retries {
+		w.Retries[i].Name = intern(w.Retries[i].Name)
+	}
+	for i := range w.Errors {
+		w.Errors[i].Name = intern(w.Errors[i].Name)
+		w.Errors[i].Code = intern(w.Errors[i].Code)
+	}
+}
+
+// internState interns the names and references of the state and its actions
+func internState(state State) {
+	if base, ok := state.(interface{ baseState() *BaseState }); ok {
+		s := base.baseState()
+		s.Name = intern(s.Name)
+		s.Type = StateType(intern(string(s.Type)))
+		s.CompensatedBy = intern(s.CompensatedBy)
+		internTransition(s.Transition)
+		for i := range s.OnErrors {
+			s.OnErrors[i].ErrorRef = intern(s.OnErrors[i].ErrorRef)
+			internAll(s.OnErrors[i].ErrorRefs)
+			internTransition(s.OnErrors[i].Transition)
+		}
+	}
+	switch s := state.(type) {
+	case *EventState:
+		for i := range s.OnEvents {
+			internAll(s.OnEvents[i].EventRefs)
+		}
+	case *CallbackState:
+		s.EventRef = intern(s.EventRef)
+	}
+	for _, action := range GetActions(state) {
+		action.Name = intern(action.Name)
+		action.FunctionRef.RefName = intern(action.FunctionRef.RefName)
+		action.RetryRef = intern(action.RetryRef)
+		action.SubFlowRef.WorkflowID = intern(action.SubFlowRef.WorkflowID)
+		if action.EventRef != nil {
+			action.EventRef.TriggerEventRef = intern(action.EventRef.TriggerEventRef)
+			action.EventRef.ResultEventRef = intern(action.EventRef.ResultEventRef)
+		}
+		internAll(action.NonRetryableErrors)
+		internAll(action.RetryableErrors)
+	}
+}
+
+func internTransition(t *Transition) {
+	if t != nil {
+		t.NextState = intern(t.NextState)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// data returns the address of the bytes of the string
+func data(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+func TestIntern(t *testing.T) {
+	first := intern(string([]byte("store")))
+	second := intern(string([]byte("store")))
+	assert.Equal(t, data(first), data(second))
+
+	long := strings.Repeat("x", maxInternedLength+1)
+	assert.NotEqual(t, data(intern(string([]byte(long)))), data(intern(string([]byte(long)))))
+	assert.Equal(t, "", intern(""))
+}
+
+func TestInternWorkflows(t *testing.T) {
+	source := []byte(`{"id": "order", "name": "Order", "specVersion": "0.8", "start": "Store",
+  "functions": [{"name": "store", "operation": "http://api.example.com/openapi.json#store"}],
+  "events": [{"name": "Stored", "type": "order.stored", "kind": "produced"}],
+  "states": [
+    {"name": "Store", "type": "operation", "transition": "Notify",
+     "actions": [{"functionRef": "store", "eventRef": {"triggerEventRef": "Stored", "resultEventRef": "Stored"}}]},
+    {"name": "Notify", "type": "operation", "actions": [{"functionRef": "store"}], "end": true}
+  ]}`)
+	first, second := &Workflow{}, &Workflow{}
+	require.NoError(t, json.Unmarshal(source, first))
+	require.NoError(t, json.Unmarshal(source, second))
+
+	assert.Equal(t, data(first.Functions[0].Name), data(second.Functions[0].Name))
+	assert.Equal(t, data(first.Events[0].Type), data(second.Events[0].Type))
+	store := first.States[0].(*OperationState)
+	notify := second.States[1].(*OperationState)
+	assert.Equal(t, data(store.Actions[0].FunctionRef.RefName), data(notify.Actions[0].FunctionRef.RefName))
+	assert.Equal(t, data(store.Actions[0].EventRef.TriggerEventRef), data(store.Actions[0].EventRef.ResultEventRef))
+	assert.Equal(t, data(store.Transition.NextState), data(notify.Name))
+	assert.Equal(t, data(string(store.Type)), data(string(notify.Type)))
+	assert.Nil(t, notify.Actions[0].EventRef)
+}
@@ -0,0 +1,140 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// LazyWorkflow workflow whose states are kept raw when it's decoded, each state being decoded on its first access and
+// cached. Tools needing the metadata of a workflow or a few of its states don't pay the decoding of all the states.
+// It's safe for concurrent use.
+type LazyWorkflow struct {
+	BaseWorkflow
+	Events    []Event
+	Functions []Function
+	Retries   []Retry
+
+	mu     sync.Mutex
+	raw    []json.RawMessage
+	states []State
+	names  map[string]int
+}
+
+// UnmarshalJSON decodes the workflow keeping its states raw
+func (w *LazyWorkflow) UnmarshalJSON(data []byte) error {
+	workflow := Workflow{BaseWorkflow: w.BaseWorkflow}
+	raw, err := workflow.unmarshal(data)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.BaseWorkflow = workflow.BaseWorkflow
+	w.Events = workflow.Events
+	w.Functions = workflow.Functions
+	w.Retries = workflow.Retries
+	w.raw = raw
+	w.states = make([]State, len(raw))
+	w.names = nil
+	return nil
+}
+
+// validateStruct checks the workflow has states like the validate tags of Workflow.States
+func (w *LazyWorkflow) validateStruct(v *validation, ns string) {
+	if len(w.raw) == 0 {
+		v.fieldError(ns+"States", "min", "1", reflect.ValueOf(w.raw))
+	}
+}
+
+// StateCount number of states of the workflow
+func (w *LazyWorkflow) StateCount() int {
+	return len(w.raw)
+}
+
+// StateAt returns the state at the given index, decoding it on the first call
+func (w *LazyWorkflow) StateAt(index int) (State, error) {
+	if index < 0 || index >= len(w.raw) {
+		return nil, fmt.Errorf("state index %d out of range, the workflow has %d states", index, len(w.raw))
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.stateAt(index)
+}
+
+func (w *LazyWorkflow) stateAt(index int) (State, error) {
+	if w.states[index] == nil {
+		state, err := UnmarshalState(w.raw[index])
+		if err != nil {
+			return nil, decodeError("/states/"+strconv.Itoa(index), err)
+		}
+		w.states[index] = state
+	}
+	return w.states[index], nil
+}
+
+// StateIndex returns the index of the state with the given name. Only the names of the states are decoded.
+func (w *LazyWorkflow) StateIndex(name string) (int, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.names == nil {
+		w.names = make(map[string]int, len(w.raw))
+		for i, raw := range w.raw {
+			probe := stateProbe{}
+			// the states failing to decode are found by index, decoding them reports the error
+			if err := json.Unmarshal(raw, &probe); err == nil {
+				if _, ok := w.names[probe.Name]; !ok {
+					w.names[probe.Name] = i
+				}
+			}
+		}
+	}
+	index, ok := w.names[name]
+	return index, ok
+}
+
+// State returns the state with the given name, decoding it on the first call. Returns nil if there is no such state.
+func (w *LazyWorkflow) State(name string) (State, error) {
+	index, ok := w.StateIndex(name)
+	if !ok {
+		return nil, nil
+	}
+	return w.StateAt(index)
+}
+
+// Workflow decodes the states not decoded yet and returns the complete workflow, validated like the workflows decoded
+// whole, e.g. by the struct level validations of Workflow. The states are shared with the lazy workflow.
+func (w *LazyWorkflow) Workflow() (*Workflow, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	states := make([]State, len(w.raw))
+	for i := range w.raw {
+		state, err := w.stateAt(i)
+		if err != nil {
+			return nil, err
+		}
+		states[i] = state
+	}
+	workflow := &Workflow{BaseWorkflow: w.BaseWorkflow, States: states, Events: w.Events, Functions: w.Functions, Retries: w.Retries}
+	workflow.Reindex()
+	if err := workflow.Validate(); err != nil {
+		return nil, err
+	}
+	return workflow, nil
+}
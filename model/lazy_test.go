@@ -0,0 +1,140 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/validator.v8"
+)
+
+const lazyWorkflow = `{
+  "id": "order",
+  "name": "Order",
+  "specVersion": "0.8",
+  "start": "Store",
+  "functions": [{"name": "store", "operation": "http://api.example.com/openapi.json#store"}],
+  "states": [
+    {"name": "Store", "type": "operation", "actions": [{"functionRef": "store"}], "transition": "Notify"},
+    {"name": "Broken", "type": "unknown"},
+    {"name": "Notify", "type": "inject", "data": {"ok": true}, "end": true}
+  ]
+}`
+
+func TestLazyWorkflow(t *testing.T) {
+	workflow := &LazyWorkflow{}
+	require.NoError(t, json.Unmarshal([]byte(lazyWorkflow), workflow))
+	assert.Equal(t, "order", workflow.ID)
+	assert.Equal(t, DefaultExpressionLang, workflow.ExpressionLang)
+	assert.Equal(t, "store", workflow.Functions[0].Name)
+	assert.Equal(t, 3, workflow.StateCount())
+	for _, state := range workflow.states {
+		assert.Nil(t, state)
+	}
+
+	index, ok := workflow.StateIndex("Notify")
+	assert.True(t, ok)
+	assert.Equal(t, 2, index)
+	_, ok = workflow.StateIndex("Missing")
+	assert.False(t, ok)
+	assert.Nil(t, workflow.states[2])
+
+	state, err := workflow.State("Notify")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"ok": true}, state.(*InjectState).Data)
+	again, err := workflow.StateAt(2)
+	require.NoError(t, err)
+	assert.Same(t, state, again)
+	assert.Nil(t, workflow.states[0])
+
+	state, err = workflow.State("Missing")
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+	_, err = workflow.StateAt(1)
+	assert.EqualError(t, err, "state unknown not supported")
+	_, err = workflow.StateAt(3)
+	assert.EqualError(t, err, "state index 3 out of range, the workflow has 3 states")
+	_, err = workflow.Workflow()
+	assert.EqualError(t, err, "state unknown not supported")
+}
+
+func TestLazyWorkflowConcurrentAccess(t *testing.T) {
+	workflow := &LazyWorkflow{}
+	require.NoError(t, json.Unmarshal([]byte(lazyWorkflow), workflow))
+	states := make([]State, 8)
+	var wg sync.WaitGroup
+	for i := range states {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			states[i], _ = workflow.State("Store")
+		}(i)
+	}
+	wg.Wait()
+	for _, state := range states {
+		assert.Same(t, states[0], state)
+	}
+}
+
+func TestLazyWorkflowMatchesWorkflow(t *testing.T) {
+	source := []byte(`{"id": "greeting", "name": "Greeting", "specVersion": "0.8", "start": "Greet",
+  "states": [{"name": "Greet", "type": "inject", "data": {"greeting": "hello"}, "end": true}]}`)
+	expected := &Workflow{}
+	require.NoError(t, json.Unmarshal(source, expected))
+	lazy := &LazyWorkflow{}
+	require.NoError(t, json.Unmarshal(source, lazy))
+	workflow, err := lazy.Workflow()
+	require.NoError(t, err)
+	assert.Equal(t, expected, workflow)
+	assert.NoError(t, lazy.Validate())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "Empty", "specVersion": "0.8", "start": "Greet", "states": []}`), lazy))
+	errs, ok := lazy.Validate().(validator.ValidationErrors)
+	require.True(t, ok)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "min", errs["LazyWorkflow.States"].Tag)
+}
+
+func TestLazyWorkflowValidatesLikeWorkflow(t *testing.T) {
+	sources := map[string]string{
+		"no actions": `{"id": "order", "name": "Order", "specVersion": "0.8", "start": "Store",
+  "states": [{"name": "Store", "type": "operation", "end": true}]}`,
+		"no name": `{"specVersion": "0.8", "start": "Store", "states": [{"type": "inject", "data": {}, "end": true}]}`,
+		"no type": `{"id": "order", "name": "Order", "specVersion": "0.8", "start": "Store", "states": [{"name": "Store"}]}`,
+	}
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			expected := &Workflow{}
+			err := json.Unmarshal([]byte(source), expected)
+			if err == nil {
+				err = expected.Validate()
+			}
+			lazy := &LazyWorkflow{}
+			require.NoError(t, json.Unmarshal([]byte(source), lazy))
+			workflow, lazyErr := lazy.Workflow()
+			if err != nil {
+				assert.Nil(t, workflow)
+				assert.EqualError(t, lazyErr, err.Error())
+				return
+			}
+			require.NoError(t, lazyErr)
+			assert.True(t, expected.Equal(workflow))
+		})
+	}
+}
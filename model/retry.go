@@ -15,6 +15,8 @@
 package model
 
 import (
+	"encoding/json"
+
 	"github.com/serverlessworkflow/sdk-go/v2/util/floatstr"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -36,3 +38,13 @@ type Retry struct {
 	// If float type, maximum amount of random time added or subtracted from the delay between each retry relative to total delay (between 0 and 1). If string type, absolute maximum amount of random time added or subtracted from the delay between each retry (ISO 8601 duration format)
 	Jitter floatstr.Float32OrString `json:"jitter,omitempty" validate:"omitempty,min=0,max=1"`
 }
+
+// UnmarshalJSON ...
+func (r *Retry) UnmarshalJSON(data []byte) error {
+	// the conversion drops the UnmarshalJSON method, the definition is decoded directly but maxAttempts
+	type retry Retry
+	return json.Unmarshal(data, &struct {
+		*retry
+		MaxAttempts *intOrString `json:"maxAttempts"`
+	}{(*retry)(r), (*intOrString)(&r.MaxAttempts)})
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// SharedWorkflow workflow read by many goroutines and modified through copy-on-write snapshots. The readers get the
+// current snapshot without locking nor copying it, and must treat it as read only. Update modifies a deep copy of the
+// snapshot, published once the modification succeeds: the readers holding the previous snapshot keep seeing it
+// unchanged. The zero value holds no workflow.
+type SharedWorkflow struct {
+	// mu serializes the updates, the reads don't take it
+	mu       sync.Mutex
+	snapshot atomic.Value
+}
+
+// workflowSnapshot published snapshot of a SharedWorkflow
+type workflowSnapshot struct {
+	workflow *Workflow
+	version  uint64
+}
+
+// NewSharedWorkflow returns a shared workflow whose first snapshot is the given workflow, which the caller must not
+// modify afterwards
+func NewSharedWorkflow(workflow *Workflow) *SharedWorkflow {
+	s := &SharedWorkflow{}
+	if workflow != nil {
+		workflow.ensureIndex()
+	}
+	s.snapshot.Store(&workflowSnapshot{workflow: workflow, version: 1})
+	return s
+}
+
+// Load returns the current snapshot, nil if no workflow was stored. The snapshot must not be modified.
+func (s *SharedWorkflow) Load() *Workflow {
+	workflow, _ := s.Snapshot()
+	return workflow
+}
+
+// Snapshot returns the current snapshot and its version, incremented by every update, 0 if no workflow was stored.
+// The snapshot must not be modified.
+func (s *SharedWorkflow) Snapshot() (*Workflow, uint64) {
+	if snapshot, ok := s.snapshot.Load().(*workflowSnapshot); ok {
+		return snapshot.workflow, snapshot.version
+	}
+	return nil, 0
+}
+
+// Store publishes the workflow as the new snapshot, the caller must not modify it afterwards
+func (s *SharedWorkflow) Store(workflow *Workflow) {
+	if workflow != nil {
+		workflow.ensureIndex()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, version := s.Snapshot()
+	s.snapshot.Store(&workflowSnapshot{workflow: workflow, version: version + 1})
+}
+
+// Update calls the function with a deep copy of the current snapshot, an empty workflow if none was stored, and
+// publishes the copy as the new snapshot unless the function fails. The updates are serialized, each one starting from
+// the snapshot published by the previous one. Returns the published snapshot.
+func (s *SharedWorkflow) Update(fn func(workflow *Workflow) error) (*Workflow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, version := s.Snapshot()
+	workflow := &Workflow{}
+	if current != nil {
+		workflow = current.DeepCopy()
+	}
+	if err := fn(workflow); err != nil {
+		return nil, err
+	}
+	workflow.ensureIndex()
+	s.snapshot.Store(&workflowSnapshot{workflow: workflow, version: version + 1})
+	return workflow, nil
+}
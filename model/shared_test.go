@@ -0,0 +1,106 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedWorkflow(t *testing.T) {
+	original := &Workflow{
+		BaseWorkflow: BaseWorkflow{ID: "order", Name: "Order"},
+		States:       []State{&InjectState{BaseState: BaseState{Name: "Init", Type: StateTypeInject}}},
+	}
+	shared := NewSharedWorkflow(original)
+	workflow, version := shared.Snapshot()
+	assert.Same(t, original, workflow)
+	assert.Equal(t, uint64(1), version)
+
+	updated, err := shared.Update(func(workflow *Workflow) error {
+		workflow.Name = "Orders"
+		workflow.States[0].(*InjectState).Name = "Start"
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Same(t, updated, shared.Load())
+	assert.Equal(t, "Orders", updated.Name)
+	assert.Equal(t, "Start", updated.States[0].GetName())
+	// the previous snapshot is unchanged
+	assert.Equal(t, "Order", original.Name)
+	assert.Equal(t, "Init", original.States[0].GetName())
+
+	_, err = shared.Update(func(workflow *Workflow) error {
+		workflow.Name = "Invalid"
+		return errors.New("invalid")
+	})
+	assert.EqualError(t, err, "invalid")
+	workflow, version = shared.Snapshot()
+	assert.Same(t, updated, workflow)
+	assert.Equal(t, uint64(2), version)
+
+	shared.Store(original)
+	workflow, version = shared.Snapshot()
+	assert.Same(t, original, workflow)
+	assert.Equal(t, uint64(3), version)
+}
+
+func TestSharedWorkflowZeroValue(t *testing.T) {
+	shared := &SharedWorkflow{}
+	workflow, version := shared.Snapshot()
+	assert.Nil(t, workflow)
+	assert.Zero(t, version)
+	workflow, err := shared.Update(func(workflow *Workflow) error {
+		workflow.ID = "order"
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "order", shared.Load().ID)
+	assert.Same(t, workflow, shared.Load())
+}
+
+func TestSharedWorkflowConcurrency(t *testing.T) {
+	shared := NewSharedWorkflow(&Workflow{BaseWorkflow: BaseWorkflow{ID: "order"}})
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_, err := shared.Update(func(workflow *Workflow) error {
+				workflow.Functions = append(workflow.Functions, Function{Name: fmt.Sprintf("f%d", i)})
+				return nil
+			})
+			assert.NoError(t, err)
+		}(i)
+		go func() {
+			defer wg.Done()
+			workflow := shared.Load()
+			names := make([]string, 0, len(workflow.Functions))
+			for _, function := range workflow.Functions {
+				names = append(names, function.Name)
+			}
+			assert.Len(t, names, len(workflow.Functions))
+		}()
+	}
+	wg.Wait()
+	workflow, version := shared.Snapshot()
+	assert.Len(t, workflow.Functions, 8)
+	assert.Equal(t, uint64(9), version)
+}
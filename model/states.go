@@ -138,44 +138,35 @@ type DelayState struct {
 type EventState struct {
 	BaseState
 	// If true consuming one of the defined events causes its associated actions to be performed. If false all of the defined events must be consumed in order for actions to be performed
-	Exclusive bool `json:"exclusive,omitempty"`
+	Exclusive bool `json:"exclusive"`
 	// Define the events to be consumed and optional actions to be performed
 	OnEvents []OnEvents `json:"onEvents" validate:"required,min=1,dive"`
 	// State specific timeouts
 	Timeout EventStateTimeout `json:"timeouts,omitempty"`
 }
 
+// eventStateUnmarshal EventState without its UnmarshalJSON method, exclusive being kept raw to default to true
+type eventStateUnmarshal struct {
+	BaseState
+	Exclusive json.RawMessage   `json:"exclusive"`
+	OnEvents  []OnEvents        `json:"onEvents"`
+	Timeout   EventStateTimeout `json:"timeouts,omitempty"`
+}
+
 // UnmarshalJSON ...
 func (e *EventState) UnmarshalJSON(data []byte) error {
-	if err := json.Unmarshal(data, &e.BaseState); err != nil {
+	raw := eventStateUnmarshal{BaseState: e.BaseState, Timeout: e.Timeout}
+	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
-
-	eventStateMap := make(map[string]interface{})
-	if err := json.Unmarshal(data, &eventStateMap); err != nil {
-		return err
-	}
-
+	e.BaseState = raw.BaseState
+	e.OnEvents = raw.OnEvents
+	e.Timeout = raw.Timeout
 	e.Exclusive = true
-
-	if eventStateMap["exclusive"] != nil {
-		exclusiveVal, ok := eventStateMap["exclusive"].(bool)
-		if ok {
-			e.Exclusive = exclusiveVal
-		}
-	}
-
-	eventStateRaw := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &eventStateRaw); err != nil {
-		return err
-	}
-	if err := json.Unmarshal(eventStateRaw["onEvents"], &e.OnEvents); err != nil {
-		return err
-	}
-	if err := unmarshalKey("timeouts", eventStateRaw, &e.Timeout); err != nil {
-		return err
+	var exclusive bool
+	if err := json.Unmarshal(raw.Exclusive, &exclusive); err == nil {
+		e.Exclusive = exclusive
 	}
-
 	return nil
 }
 
@@ -216,6 +207,16 @@ type ParallelState struct {
 	Timeouts ParallelStateTimeout `json:"timeouts,omitempty"`
 }
 
+// UnmarshalJSON ...
+func (p *ParallelState) UnmarshalJSON(data []byte) error {
+	// the conversion drops the UnmarshalJSON method, the state is decoded directly but numCompleted
+	type parallelState ParallelState
+	return json.Unmarshal(data, &struct {
+		*parallelState
+		NumCompleted *intOrString `json:"numCompleted"`
+	}{(*parallelState)(p), (*intOrString)(&p.NumCompleted)})
+}
+
 // ParallelStateTimeout ...
 type ParallelStateTimeout struct {
 	StateExecTimeout  StateExecTimeout `json:"stateExecTimeout,omitempty"`
@@ -255,6 +256,16 @@ type ForEachState struct {
 	Mode ForEachModeType `json:"mode,omitempty"`
 }
 
+// UnmarshalJSON ...
+func (f *ForEachState) UnmarshalJSON(data []byte) error {
+	// the conversion drops the UnmarshalJSON method, the state is decoded directly but batchSize
+	type forEachState ForEachState
+	return json.Unmarshal(data, &struct {
+		*forEachState
+		BatchSize *intOrString `json:"batchSize"`
+	}{(*forEachState)(f), (*intOrString)(&f.BatchSize)})
+}
+
 // ForEachStateTimeout ...
 type ForEachStateTimeout struct {
 	StateExecTimeout  StateExecTimeout `json:"stateExecTimeout,omitempty"`
@@ -311,31 +322,30 @@ type EventBasedSwitchState struct {
 	Timeouts EventBasedSwitchStateTimeout `json:"timeouts,omitempty"`
 }
 
+// eventBasedSwitchStateUnmarshal EventBasedSwitchState with the conditions kept raw until their type is known
+type eventBasedSwitchStateUnmarshal struct {
+	BaseSwitchState
+	EventConditions []json.RawMessage            `json:"eventConditions"`
+	Timeouts        EventBasedSwitchStateTimeout `json:"timeouts,omitempty"`
+}
+
 // UnmarshalJSON implementation for json Unmarshal function for the Eventbasedswitch type
 func (j *EventBasedSwitchState) UnmarshalJSON(data []byte) error {
-	if err := json.Unmarshal(data, &j.BaseSwitchState); err != nil {
-		return err
-	}
-	eventBasedSwitch := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &eventBasedSwitch); err != nil {
-		return err
-	}
-	var rawConditions []json.RawMessage
-	if err := unmarshalKey("timeouts", eventBasedSwitch, &j.Timeouts); err != nil {
-		return err
-	}
-	if err := json.Unmarshal(eventBasedSwitch["eventConditions"], &rawConditions); err != nil {
+	raw := eventBasedSwitchStateUnmarshal{BaseSwitchState: j.BaseSwitchState, Timeouts: j.Timeouts}
+	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	j.BaseSwitchState = raw.BaseSwitchState
+	j.Timeouts = raw.Timeouts
 
-	j.EventConditions = make([]EventCondition, len(rawConditions))
-	var mapConditions map[string]interface{}
-	for i, rawCondition := range rawConditions {
-		if err := json.Unmarshal(rawCondition, &mapConditions); err != nil {
+	j.EventConditions = make([]EventCondition, len(raw.EventConditions))
+	for i, rawCondition := range raw.EventConditions {
+		probe := conditionProbe{}
+		if err := json.Unmarshal(rawCondition, &probe); err != nil {
 			return err
 		}
 		var condition EventCondition
-		if _, ok := mapConditions["end"]; ok {
+		if probe.End != nil {
 			condition = &EndEventCondition{}
 		} else {
 			condition = &TransitionEventCondition{}
@@ -348,6 +358,11 @@ func (j *EventBasedSwitchState) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// conditionProbe tells apart the switch conditions ending the workflow from those with a transition
+type conditionProbe struct {
+	End json.RawMessage `json:"end"`
+}
+
 // EventBasedSwitchStateTimeout ...
 type EventBasedSwitchStateTimeout struct {
 	StateExecTimeout StateExecTimeout `json:"stateExecTimeout,omitempty"`
@@ -406,30 +421,30 @@ type DataBasedSwitchState struct {
 	Timeouts       DataBasedSwitchStateTimeout `json:"timeouts,omitempty"`
 }
 
+// dataBasedSwitchStateUnmarshal DataBasedSwitchState with the conditions kept raw until their type is known
+type dataBasedSwitchStateUnmarshal struct {
+	BaseSwitchState
+	DataConditions []json.RawMessage           `json:"dataConditions"`
+	Timeouts       DataBasedSwitchStateTimeout `json:"timeouts,omitempty"`
+}
+
 // UnmarshalJSON implementation for json Unmarshal function for the Databasedswitch type
 func (j *DataBasedSwitchState) UnmarshalJSON(data []byte) error {
-	if err := json.Unmarshal(data, &j.BaseSwitchState); err != nil {
-		return err
-	}
-	dataBasedSwitch := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &dataBasedSwitch); err != nil {
-		return err
-	}
-	var rawConditions []json.RawMessage
-	if err := unmarshalKey("timeouts", dataBasedSwitch, &j.Timeouts); err != nil {
+	raw := dataBasedSwitchStateUnmarshal{BaseSwitchState: j.BaseSwitchState, Timeouts: j.Timeouts}
+	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
-	if err := json.Unmarshal(dataBasedSwitch["dataConditions"], &rawConditions); err != nil {
-		return err
-	}
-	j.DataConditions = make([]DataCondition, len(rawConditions))
-	var mapConditions map[string]interface{}
-	for i, rawCondition := range rawConditions {
-		if err := json.Unmarshal(rawCondition, &mapConditions); err != nil {
+	j.BaseSwitchState = raw.BaseSwitchState
+	j.Timeouts = raw.Timeouts
+
+	j.DataConditions = make([]DataCondition, len(raw.DataConditions))
+	for i, rawCondition := range raw.DataConditions {
+		probe := conditionProbe{}
+		if err := json.Unmarshal(rawCondition, &probe); err != nil {
 			return err
 		}
 		var condition DataCondition
-		if _, ok := mapConditions["end"]; ok {
+		if probe.End != nil {
 			condition = &EndDataCondition{}
 		} else {
 			condition = &TransitionDataCondition{}
@@ -485,3 +500,31 @@ type EndDataCondition struct {
 	// Workflow end definition
 	End End `json:"end" validate:"required"`
 }
+
+// GetActions returns the actions performed by the given state, including the ones defined in event handlers and
+// parallel branches. The returned pointers reference the actions stored in the state.
+func GetActions(state State) []*Action {
+	var actions []*Action
+	appendAll := func(list []Action) {
+		for i := range list {
+			actions = append(actions, &list[i])
+		}
+	}
+	switch s := state.(type) {
+	case *OperationState:
+		appendAll(s.Actions)
+	case *EventState:
+		for i := range s.OnEvents {
+			appendAll(s.OnEvents[i].Actions)
+		}
+	case *ParallelState:
+		for i := range s.Branches {
+			appendAll(s.Branches[i].Actions)
+		}
+	case *ForEachState:
+		appendAll(s.Actions)
+	case *CallbackState:
+		actions = append(actions, &s.Action)
+	}
+	return actions
+}
@@ -0,0 +1,52 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalSwitchStates(t *testing.T) {
+	workflow := &Workflow{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "id": "order",
+  "name": "Order",
+  "specVersion": "0.7",
+  "start": "Route",
+  "states": [
+    {"name": "Route", "type": "switch", "dataConditions": [{"condition": ".ok", "transition": {"nextState": "Confirm"}}, {"condition": ".ko", "end": true}],
+      "defaultCondition": {"transition": {"nextState": "Confirm"}}},
+    {"name": "Confirm", "type": "switch", "eventConditions": [{"eventRef": "Confirmed", "end": true}, {"eventRef": "Rejected", "transition": {"nextState": "Route"}}],
+      "defaultCondition": {"end": true}}
+  ]
+}`), workflow))
+
+	require.Len(t, workflow.States, 2)
+	dataSwitch, ok := workflow.States[0].(*DataBasedSwitchState)
+	require.True(t, ok)
+	assert.IsType(t, &TransitionDataCondition{}, dataSwitch.DataConditions[0])
+	assert.IsType(t, &EndDataCondition{}, dataSwitch.DataConditions[1])
+
+	eventSwitch, ok := workflow.States[1].(*EventBasedSwitchState)
+	require.True(t, ok, "event based switch decoded as %T", workflow.States[1])
+	require.Len(t, eventSwitch.EventConditions, 2)
+	assert.IsType(t, &EndEventCondition{}, eventSwitch.EventConditions[0])
+	assert.IsType(t, &TransitionEventCondition{}, eventSwitch.EventConditions[1])
+	assert.Equal(t, "Rejected", eventSwitch.EventConditions[1].GetEventRef())
+}
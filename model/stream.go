@@ -0,0 +1,145 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// StreamedWorkflow workflow decoded by DecodeStates: its definitions, the states having been handed to the callback
+// one at a time instead of being kept
+type StreamedWorkflow struct {
+	BaseWorkflow
+	Events    []Event
+	Functions []Function
+	Retries   []Retry
+	// StateCount number of states decoded
+	StateCount int
+}
+
+// validateStruct checks the workflow had states like the validate tags of Workflow.States
+func (w *StreamedWorkflow) validateStruct(v *validation, ns string) {
+	if w.StateCount == 0 {
+		v.fieldError(ns+"States", "min", "1", reflect.ValueOf(w.StateCount))
+	}
+}
+
+// StateFunc callback of DecodeStates, called with every state in order. Returning an error stops the decoding.
+type StateFunc func(index int, state State) error
+
+// DecodeStates decodes a JSON workflow from the token stream of the decoder, calling fn with every state as soon as
+// it's decoded, so that only one state is in memory at a time whatever the size of the workflow. The other properties
+// are decoded once the whole workflow is read, they may follow the states in the document.
+func DecodeStates(dec *json.Decoder, fn StateFunc) (*StreamedWorkflow, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	// the workflow without its states, decoded at the end
+	rest := bytes.NewBufferString("{")
+	count := -1
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid workflow: unexpected %v", token)
+		}
+		if key == "states" && count < 0 {
+			if count, err = decodeStates(dec, fn); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		if rest.Len() > 1 {
+			rest.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		rest.Write(name)
+		rest.WriteByte(':')
+		rest.Write(value)
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	if count < 0 {
+		return nil, ErrMissingStates
+	}
+	if rest.Len() > 1 {
+		rest.WriteByte(',')
+	}
+	rest.WriteString(`"states":[]}`)
+
+	workflow := Workflow{}
+	if _, err := workflow.unmarshal(rest.Bytes()); err != nil {
+		return nil, err
+	}
+	return &StreamedWorkflow{
+		BaseWorkflow: workflow.BaseWorkflow,
+		Events:       workflow.Events,
+		Functions:    workflow.Functions,
+		Retries:      workflow.Retries,
+		StateCount:   count,
+	}, nil
+}
+
+// decodeStates decodes the array of states one state at a time, returning the number of states
+func decodeStates(dec *json.Decoder, fn StateFunc) (int, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return 0, err
+	}
+	if token == nil {
+		return 0, ErrMissingStates
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return 0, fmt.Errorf("invalid workflow states: expected an array, got %v", token)
+	}
+	count := 0
+	for ; dec.More(); count++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return 0, err
+		}
+		state, err := UnmarshalState(raw)
+		if err != nil {
+			return 0, decodeError("/states/"+strconv.Itoa(count), err)
+		}
+		if err := fn(count, state); err != nil {
+			return 0, err
+		}
+	}
+	return count, expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("invalid workflow: expected %v, got %v", delim, token)
+	}
+	return nil
+}
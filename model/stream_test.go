@@ -0,0 +1,85 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/validator.v8"
+)
+
+func TestDecodeStates(t *testing.T) {
+	// the definitions follow the states
+	source := `{"id": "order", "name": "Order", "specVersion": "0.8", "start": "Store",
+  "states": [
+    {"name": "Store", "type": "operation", "actions": [{"functionRef": "store"}], "transition": "Notify"},
+    {"name": "Notify", "type": "inject", "data": {"ok": true}, "end": true}
+  ],
+  "functions": [{"name": "store", "operation": "http://api.example.com/openapi.json#store"}]}`
+	var states []State
+	workflow, err := DecodeStates(json.NewDecoder(strings.NewReader(source)), func(index int, state State) error {
+		assert.Equal(t, len(states), index)
+		states = append(states, state)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "order", workflow.ID)
+	assert.Equal(t, DefaultExpressionLang, workflow.ExpressionLang)
+	assert.Equal(t, "store", workflow.Functions[0].Name)
+	assert.Equal(t, 2, workflow.StateCount)
+	assert.NoError(t, workflow.Validate())
+
+	expected := &Workflow{}
+	require.NoError(t, json.Unmarshal([]byte(source), expected))
+	assert.Equal(t, expected.States, states)
+	assert.Equal(t, expected.BaseWorkflow, workflow.BaseWorkflow)
+}
+
+func TestDecodeStatesErrors(t *testing.T) {
+	decode := func(source string, fn StateFunc) error {
+		if fn == nil {
+			fn = func(int, State) error { return nil }
+		}
+		_, err := DecodeStates(json.NewDecoder(strings.NewReader(source)), fn)
+		return err
+	}
+	assert.EqualError(t, decode(`{"id": "order"}`, nil), "workflow states are required")
+	assert.EqualError(t, decode(`{"id": "order", "states": null}`, nil), "workflow states are required")
+	assert.EqualError(t, decode(`[]`, nil), "invalid workflow: expected {, got [")
+	assert.EqualError(t, decode(`{"states": {}}`, nil), "invalid workflow states: expected an array, got {")
+	assert.EqualError(t, decode(`{"states": [{"name": "Broken", "type": "unknown"}]}`, nil), "state unknown not supported")
+	assert.Error(t, decode(`{"states": [{"name": "Store"`, nil))
+
+	stop := errors.New("stop")
+	calls := 0
+	err := decode(`{"states": [{"name": "A", "type": "inject", "end": true}, {"name": "B", "type": "inject", "end": true}]}`, func(int, State) error {
+		calls++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, calls)
+
+	workflow, err := DecodeStates(json.NewDecoder(strings.NewReader(`{"name": "Empty", "specVersion": "0.8", "start": "A", "states": []}`)), func(int, State) error { return nil })
+	require.NoError(t, err)
+	errs, ok := workflow.Validate().(validator.ValidationErrors)
+	require.True(t, ok)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "min", errs["StreamedWorkflow.States"].Tag)
+}
@@ -20,7 +20,11 @@ import (
 	"io/ioutil"
 	"net/http"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const prefix = "file:/"
@@ -52,14 +56,11 @@ func getBytesFromFile(s string) (b []byte, err error) {
 	return b, nil
 }
 
-func requiresNotNilOrEmpty(value interface{}) string {
-	if value == nil {
-		return ""
-	}
-	return value.(string)
-}
-
+// unmarshalString decodes the JSON string, directly if it has ASCII characters only and no escape sequence
 func unmarshalString(data []byte) (string, error) {
+	if value, ok := unquote(data); ok {
+		return value, nil
+	}
 	var value string
 	if err := json.Unmarshal(data, &value); err != nil {
 		return "", err
@@ -67,6 +68,54 @@ func unmarshalString(data []byte) (string, error) {
 	return value, nil
 }
 
+// unquote returns the content of the JSON string if it has ASCII characters only, and no escape sequence
+func unquote(data []byte) (string, bool) {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return "", false
+	}
+	data = data[1 : len(data)-1]
+	for _, c := range data {
+		if c < ' ' || c == '"' || c == '\\' || c >= utf8.RuneSelf {
+			return "", false
+		}
+	}
+	return string(data), true
+}
+
+// intOrString intstr.IntOrString decoded without going through encoding/json, the definitions holding one decode it
+// through a conversion, e.g. (*intOrString)(&retry.MaxAttempts)
+type intOrString intstr.IntOrString
+
+// UnmarshalJSON ...
+func (i *intOrString) UnmarshalJSON(data []byte) error {
+	if isJSONString(data) {
+		i.Type = intstr.String
+		var err error
+		i.StrVal, err = unmarshalString(data)
+		return err
+	}
+	i.Type = intstr.Int
+	if n, err := strconv.ParseInt(string(data), 10, 32); err == nil {
+		i.IntVal = int32(n)
+		return nil
+	}
+	// null, or an invalid value for which encoding/json reports the error
+	return json.Unmarshal(data, &i.IntVal)
+}
+
+// isJSONString checks whether the JSON value is a string. The definitions with a short form check it before decoding
+// the object form, failing on strings is much more expensive than decoding them.
+func isJSONString(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return len(data) > 0 && data[0] == '"'
+}
+
+// isJSONObject checks whether the JSON value is an object
+func isJSONObject(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return len(data) > 0 && data[0] == '{'
+}
+
 func unmarshalKey(key string, data map[string]json.RawMessage, output interface{}) error {
 	if _, found := data[key]; found {
 		if err := json.Unmarshal(data[key], output); err != nil {
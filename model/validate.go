@@ -0,0 +1,142 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	val "github.com/serverlessworkflow/sdk-go/v2/validator"
+	"gopkg.in/go-playground/validator.v8"
+)
+
+//go:generate go run ../hack/validate
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// validation errors of a generated validation function. The namespaces given to its functions are the name
+// namespaces of the fields: their path from the validated struct, e.g. BaseWorkflow.Name, the field namespaces are
+// prefixed by the name of the validated struct like the tag-based validator does, e.g. Workflow.BaseWorkflow.Name
+type validation struct {
+	top  string
+	errs validator.ValidationErrors
+}
+
+// result the errors of the validation, nil if there are none
+func (v *validation) result() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+func (v *validation) report(fieldErr *validator.FieldError) {
+	if v.errs == nil {
+		v.errs = validator.ValidationErrors{}
+	}
+	v.errs[fieldErr.FieldNamespace] = fieldErr
+}
+
+// fieldError reports the field with the given namespace failing the validation tag
+func (v *validation) fieldError(ns, tag, param string, value reflect.Value) {
+	field := ns[strings.LastIndexByte(ns, '.')+1:]
+	fieldErr := &validator.FieldError{
+		FieldNamespace: v.top + ns,
+		NameNamespace:  ns,
+		Field:          field,
+		Name:           field,
+		Tag:            tag,
+		ActualTag:      tag,
+		Kind:           value.Kind(),
+		Param:          param,
+	}
+	if value.IsValid() {
+		fieldErr.Type = value.Type()
+		fieldErr.Value = value.Interface()
+	}
+	v.report(fieldErr)
+}
+
+// structError reports a failed struct level validation of the struct with the given namespace prefix, like
+// validator.StructLevel.ReportError does
+func (v *validation) structError(ns, field, name, tag string, value interface{}) {
+	current := reflect.ValueOf(value)
+	v.report(&validator.FieldError{
+		FieldNamespace: v.top + ns + field,
+		NameNamespace:  ns + name,
+		Field:          field,
+		Name:           name,
+		Tag:            tag,
+		ActualTag:      tag,
+		Kind:           current.Kind(),
+		Type:           current.Type(),
+		Value:          value,
+	})
+}
+
+// value validates the struct held by an interface field like the tag-based validator does: with its generated
+// validation function if it has one, with its validate tags through the default validator otherwise. Other values
+// aren't validated.
+func (v *validation) value(ns string, value interface{}) {
+	switch current := value.(type) {
+	case nil, string, float64, bool, map[string]interface{}, []interface{}:
+		// free-form JSON values, the most common
+		return
+	case interface {
+		validate(*validation, string)
+	}:
+		current.validate(v, ns)
+		return
+	}
+	current := reflect.ValueOf(value)
+	for current.Kind() == reflect.Ptr || current.Kind() == reflect.Interface {
+		if current.IsNil() {
+			return
+		}
+		current = current.Elem()
+	}
+	if current.Kind() != reflect.Struct || current.Type() == timeType {
+		return
+	}
+	v.merge(ns, current.Type().Name()+".", val.Struct(current.Interface()))
+}
+
+// structLevel runs the struct level validations registered on the default validator for the type of the struct, see
+// validator.RegisterDefaultStructValidation
+func (v *validation) structLevel(ns string, current interface{}) {
+	v.merge(ns, indirect(reflect.TypeOf(current)).Name()+".", val.StructLevel(current))
+}
+
+// merge reports the errors of the validation of a struct with the given namespace, replacing the prefix of their
+// namespaces, the name of the struct
+func (v *validation) merge(ns, prefix string, err error) {
+	fieldErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return
+	}
+	for _, fieldErr := range fieldErrs {
+		fieldErr.NameNamespace = ns + strings.TrimPrefix(fieldErr.NameNamespace, prefix)
+		fieldErr.FieldNamespace = v.top + ns + strings.TrimPrefix(fieldErr.FieldNamespace, prefix)
+		v.report(fieldErr)
+	}
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
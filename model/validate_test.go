@@ -0,0 +1,121 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	val "github.com/serverlessworkflow/sdk-go/v2/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/validator.v8"
+)
+
+// invalidWorkflows valid workflows made invalid, with the tags of the fields failing the validation
+func invalidWorkflows() map[string]func(w *model.Workflow) map[string]string {
+	return map[string]func(w *model.Workflow) map[string]string{
+		"no name and start": func(w *model.Workflow) map[string]string {
+			w.Name = ""
+			w.Start = nil
+			return map[string]string{"Workflow.BaseWorkflow.Name": "required", "Workflow.BaseWorkflow.Start": "required"}
+		},
+		"empty states": func(w *model.Workflow) map[string]string {
+			w.States = []model.State{}
+			w.Key = "k"
+			return map[string]string{"Workflow.States": "min"}
+		},
+		"no start state name": func(w *model.Workflow) map[string]string {
+			w.Start.StateName = ""
+			return map[string]string{"Workflow.BaseWorkflow.Start.StateName": "required"}
+		},
+		"input schema": func(w *model.Workflow) map[string]string {
+			fail := false
+			w.DataInputSchema = &model.DataInputSchema{FailOnValidationErrors: &fail}
+			return map[string]string{
+				"Workflow.BaseWorkflow.DataInputSchema.Schema":                 "required",
+				"Workflow.BaseWorkflow.DataInputSchema.FailOnValidationErrors": "required",
+			}
+		},
+		"duplicated auth": func(w *model.Workflow) map[string]string {
+			w.Auth.Defs = []model.Auth{
+				{Name: "basic", Scheme: model.AuthTypeBasic, Properties: &model.BasicAuthProperties{Username: "u"}},
+				{Name: "basic", Scheme: model.AuthTypeBearer, Properties: &model.BearerAuthProperties{Token: "t"}},
+			}
+			return map[string]string{"Workflow.BaseWorkflow.Auth.Name": "reqnameunique"}
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for name, invalidate := range invalidWorkflows() {
+		t.Run(name, func(t *testing.T) {
+			workflow, err := parser.FromFile("../parser/testdata/workflows/greetings.sw.json")
+			require.NoError(t, err)
+			require.NoError(t, workflow.Validate())
+			expected := invalidate(workflow)
+
+			errs, ok := workflow.Validate().(validator.ValidationErrors)
+			require.True(t, ok)
+			tags := map[string]string{}
+			for key, fieldErr := range errs {
+				assert.Equal(t, key, fieldErr.FieldNamespace)
+				tags[key] = fieldErr.Tag
+			}
+			assert.Equal(t, expected, tags)
+			assert.Equal(t, errs, val.Struct(workflow))
+		})
+	}
+}
+
+func TestValidateEvent(t *testing.T) {
+	event := &model.Event{Name: "approved", Kind: model.EventKindConsumed}
+	errs, ok := event.Validate().(validator.ValidationErrors)
+	require.True(t, ok)
+	require.Len(t, errs, 1)
+	fieldErr := errs["Event.Type"]
+	require.NotNil(t, fieldErr)
+	assert.Equal(t, "reqtypeconsumed", fieldErr.Tag)
+	assert.Equal(t, "type", fieldErr.NameNamespace)
+
+	event.Type = "order.approved"
+	assert.NoError(t, event.Validate())
+}
+
+// TestValidateMatchesTags checks the generated validation functions against the tag-based validator
+func TestValidateMatchesTags(t *testing.T) {
+	files, err := filepath.Glob("../parser/testdata/workflows/*.*")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			workflow, err := parser.FromFile(file)
+			if err != nil {
+				t.Skip(err)
+			}
+			assert.Equal(t, val.GetValidator().Struct(workflow), workflow.Validate())
+		})
+	}
+	for name, invalidate := range invalidWorkflows() {
+		t.Run(name, func(t *testing.T) {
+			workflow, err := parser.FromFile("../parser/testdata/workflows/greetings.sw.json")
+			require.NoError(t, err)
+			invalidate(workflow)
+			assert.Equal(t, val.GetValidator().Struct(workflow), workflow.Validate())
+		})
+	}
+}
@@ -16,9 +16,12 @@ package model
 
 import (
 	"encoding/json"
-	"fmt"
+	"strconv"
 )
 
+// the MarshalJSON and UnmarshalJSON methods of the types without hand written ones are generated, see hack/jsoncodec
+//go:generate go run ../hack/jsoncodec
+
 const (
 	// DefaultExpressionLang ...
 	DefaultExpressionLang = "jq"
@@ -30,21 +33,29 @@ const (
 	UnlimitedTimeout = "unlimited"
 )
 
-var actionsModelMapping = map[string]func(state map[string]interface{}) State{
-	StateTypeDelay:     func(map[string]interface{}) State { return &DelayState{} },
-	StateTypeEvent:     func(map[string]interface{}) State { return &EventState{} },
-	StateTypeOperation: func(map[string]interface{}) State { return &OperationState{} },
-	StateTypeParallel:  func(map[string]interface{}) State { return &ParallelState{} },
-	StateTypeSwitch: func(s map[string]interface{}) State {
-		if _, ok := s["dataConditions"]; ok {
+var actionsModelMapping = map[string]func(state *stateProbe) State{
+	StateTypeDelay:     func(*stateProbe) State { return &DelayState{} },
+	StateTypeEvent:     func(*stateProbe) State { return &EventState{} },
+	StateTypeOperation: func(*stateProbe) State { return &OperationState{} },
+	StateTypeParallel:  func(*stateProbe) State { return &ParallelState{} },
+	StateTypeSwitch: func(s *stateProbe) State {
+		if s.DataConditions != nil {
 			return &DataBasedSwitchState{}
 		}
 		return &EventBasedSwitchState{}
 	},
-	StateTypeInject:   func(map[string]interface{}) State { return &InjectState{} },
-	StateTypeForEach:  func(map[string]interface{}) State { return &ForEachState{} },
-	StateTypeCallback: func(map[string]interface{}) State { return &CallbackState{} },
-	StateTypeSleep:    func(map[string]interface{}) State { return &SleepState{} },
+	StateTypeInject:   func(*stateProbe) State { return &InjectState{} },
+	StateTypeForEach:  func(*stateProbe) State { return &ForEachState{} },
+	StateTypeCallback: func(*stateProbe) State { return &CallbackState{} },
+	StateTypeSleep:    func(*stateProbe) State { return &SleepState{} },
+}
+
+// stateProbe properties telling apart the concrete type of a state. Decoding it skips the other properties without
+// allocating, so every state is only decoded once into its concrete type.
+type stateProbe struct {
+	Name           string          `json:"name"`
+	Type           string          `json:"type"`
+	DataConditions json.RawMessage `json:"dataConditions"`
 }
 
 // ActionMode ...
@@ -99,103 +110,183 @@ type Workflow struct {
 	Events    []Event    `json:"events,omitempty"`
 	Functions []Function `json:"functions,omitempty"`
 	Retries   []Retry    `json:"retries,omitempty"`
+
+	// index positions of the definitions by name, see State, Function, Event and Retry. reflect.DeepEqual compares it,
+	// Equal doesn't.
+	index *workflowIndex
+}
+
+// workflowUnmarshal Workflow decoded in a single pass, the properties that can't be decoded directly being kept raw
+type workflowUnmarshal struct {
+	BaseWorkflow
+	States    []json.RawMessage `json:"states"`
+	Events    json.RawMessage   `json:"events"`
+	Functions json.RawMessage   `json:"functions"`
+	Retries   json.RawMessage   `json:"retries"`
+	// Errors shadows the errors of the BaseWorkflow, which can be the path of a file
+	Errors json.RawMessage `json:"errors"`
 }
 
 // UnmarshalJSON implementation for json Unmarshal function for the Workflow type
 func (w *Workflow) UnmarshalJSON(data []byte) error {
-	if err := json.Unmarshal(data, &w.BaseWorkflow); err != nil {
+	states, err := w.unmarshal(data)
+	if err != nil {
 		return err
 	}
+	w.States = make([]State, len(states))
+	for i, rawState := range states {
+		if w.States[i], err = UnmarshalState(rawState); err != nil {
+			return decodeError("/states/"+strconv.Itoa(i), err)
+		}
+	}
+	w.Reindex()
+	return nil
+}
 
-	workflowMap := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &workflowMap); err != nil {
-		return err
+// UnmarshalState decodes the JSON state into the State implementation of its type
+func UnmarshalState(data []byte) (State, error) {
+	probe := stateProbe{}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
 	}
-	var rawStates []json.RawMessage
-	if err := json.Unmarshal(workflowMap["states"], &rawStates); err != nil {
-		return err
+	if len(probe.Type) == 0 {
+		return nil, errorf(ErrUnknownStateType, "state %s has no type", probe.Name)
+	}
+	newState, ok := actionsModelMapping[probe.Type]
+	if !ok {
+		return nil, errorf(ErrUnknownStateType, "state %s not supported", probe.Type)
+	}
+	state := newState(&probe)
+	// the states decode themselves, sparing encoding/json a validation of the whole state before the call
+	var err error
+	if u, ok := state.(json.Unmarshaler); ok {
+		err = u.UnmarshalJSON(data)
+	} else {
+		err = json.Unmarshal(data, state)
+	}
+	if err != nil {
+		return nil, err
 	}
+	internState(state)
+	return state, nil
+}
 
-	w.States = make([]State, len(rawStates))
-	var mapState map[string]interface{}
-	for i, rawState := range rawStates {
-		if err := json.Unmarshal(rawState, &mapState); err != nil {
+// unmarshal decodes the workflow but its states, which are returned raw
+func (w *Workflow) unmarshal(data []byte) ([]json.RawMessage, error) {
+	raw := workflowUnmarshal{BaseWorkflow: w.BaseWorkflow}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	w.BaseWorkflow = raw.BaseWorkflow
+	if raw.States == nil {
+		return nil, ErrMissingStates
+	}
+	if err := w.unmarshalDefinitions(&raw); err != nil {
+		return nil, err
+	}
+	w.setDefaults()
+	w.internDefinitions()
+	return raw.States, nil
+}
+
+// unmarshalDefinitions decodes the events, functions, retries and errors definitions, given inline or as files
+func (w *Workflow) unmarshalDefinitions(raw *workflowUnmarshal) error {
+	if err := w.unmarshalEvents(raw.Events); err != nil {
+		return decodeError("/events", err)
+	}
+	if err := w.unmarshalFunctions(raw.Functions); err != nil {
+		return decodeError("/functions", err)
+	}
+	if err := w.unmarshalRetries(raw.Retries); err != nil {
+		return decodeError("/retries", err)
+	}
+	if err := w.unmarshalErrors(raw.Errors); err != nil {
+		return decodeError("/errors", err)
+	}
+	return nil
+}
+
+func (w *Workflow) unmarshalEvents(data json.RawMessage) error {
+	if data == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, &w.Events); err != nil {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
 			return err
 		}
-		if _, ok := actionsModelMapping[mapState["type"].(string)]; !ok {
-			return fmt.Errorf("state %s not supported", mapState["type"])
-		}
-		state := actionsModelMapping[mapState["type"].(string)](mapState)
-		if err := json.Unmarshal(rawState, &state); err != nil {
+		var nestedData []byte
+		if nestedData, err = getBytesFromFile(s); err != nil {
 			return err
 		}
-		w.States[i] = state
-	}
-	if _, ok := workflowMap["events"]; ok {
-		if err := json.Unmarshal(workflowMap["events"], &w.Events); err != nil {
-			var s string
-			if err := json.Unmarshal(workflowMap["events"], &s); err != nil {
-				return err
-			}
-			var nestedData []byte
-			if nestedData, err = getBytesFromFile(s); err != nil {
-				return err
-			}
-			m := make(map[string][]Event)
-			if err := json.Unmarshal(nestedData, &m); err != nil {
-				return err
-			}
-			w.Events = m["events"]
+		m := make(map[string][]Event)
+		if err := json.Unmarshal(nestedData, &m); err != nil {
+			return err
 		}
+		w.Events = m["events"]
 	}
-	if _, ok := workflowMap["functions"]; ok {
-		if err := json.Unmarshal(workflowMap["functions"], &w.Functions); err != nil {
-			var s string
-			if err := json.Unmarshal(workflowMap["functions"], &s); err != nil {
-				return err
-			}
-			var nestedData []byte
-			if nestedData, err = getBytesFromFile(s); err != nil {
-				return err
-			}
-			m := make(map[string][]Function)
-			if err := json.Unmarshal(nestedData, &m); err != nil {
-				return err
-			}
-			w.Functions = m["functions"]
+	return nil
+}
+
+func (w *Workflow) unmarshalFunctions(data json.RawMessage) error {
+	if data == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, &w.Functions); err != nil {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		var nestedData []byte
+		if nestedData, err = getBytesFromFile(s); err != nil {
+			return err
 		}
+		m := make(map[string][]Function)
+		if err := json.Unmarshal(nestedData, &m); err != nil {
+			return err
+		}
+		w.Functions = m["functions"]
+	}
+	return nil
+}
+
+func (w *Workflow) unmarshalRetries(data json.RawMessage) error {
+	if data == nil {
+		return nil
 	}
-	if _, ok := workflowMap["retries"]; ok {
-		if err := json.Unmarshal(workflowMap["retries"], &w.Retries); err != nil {
-			var s string
-			if err := json.Unmarshal(workflowMap["retries"], &s); err != nil {
-				return err
-			}
-			var nestedData []byte
-			if nestedData, err = getBytesFromFile(s); err != nil {
-				return err
-			}
-			m := make(map[string][]Retry)
-			if err := json.Unmarshal(nestedData, &m); err != nil {
-				return err
-			}
-			w.Retries = m["retries"]
+	if err := json.Unmarshal(data, &w.Retries); err != nil {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
 		}
+		var nestedData []byte
+		if nestedData, err = getBytesFromFile(s); err != nil {
+			return err
+		}
+		m := make(map[string][]Retry)
+		if err := json.Unmarshal(nestedData, &m); err != nil {
+			return err
+		}
+		w.Retries = m["retries"]
+	}
+	return nil
+}
+
+func (w *Workflow) unmarshalErrors(data json.RawMessage) error {
+	if data == nil {
+		return nil
 	}
-	if _, ok := workflowMap["errors"]; ok {
-		if err := json.Unmarshal(workflowMap["errors"], &w.Errors); err != nil {
-			nestedData, err := unmarshalFile(workflowMap["errors"])
-			if err != nil {
-				return err
-			}
-			m := make(map[string][]Error)
-			if err := json.Unmarshal(nestedData, &m); err != nil {
-				return err
-			}
-			w.Errors = m["errors"]
+	if err := json.Unmarshal(data, &w.Errors); err != nil {
+		nestedData, err := unmarshalFile(data)
+		if err != nil {
+			return err
+		}
+		m := make(map[string][]Error)
+		if err := json.Unmarshal(nestedData, &m); err != nil {
+			return err
 		}
+		w.Errors = m["errors"]
 	}
-	w.setDefaults()
 	return nil
 }
 
@@ -215,6 +306,11 @@ type WorkflowRef struct {
 
 // UnmarshalJSON ...
 func (s *WorkflowRef) UnmarshalJSON(data []byte) error {
+	if isJSONString(data) {
+		var err error
+		s.WorkflowID, err = unmarshalString(data)
+		return err
+	}
 	subflowRef := make(map[string]json.RawMessage)
 	if err := json.Unmarshal(data, &subflowRef); err != nil {
 		s.WorkflowID, err = unmarshalString(data)
@@ -249,35 +345,17 @@ type Timeouts struct {
 
 // UnmarshalJSON ...
 func (t *Timeouts) UnmarshalJSON(data []byte) error {
-	timeout := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &timeout); err != nil {
-		// assumes it's a reference to a file
+	if isJSONString(data) {
+		// a reference to a file
 		file, err := unmarshalFile(data)
 		if err != nil {
 			return err
 		}
-		if err := json.Unmarshal(file, &t); err != nil {
-			return err
-		}
-		return nil
-	}
-	if err := unmarshalKey("workflowExecTimeout", timeout, &t.WorkflowExecTimeout); err != nil {
-		return err
-	}
-	if err := unmarshalKey("stateExecTimeout", timeout, &t.StateExecTimeout); err != nil {
-		return err
+		return json.Unmarshal(file, t)
 	}
-	if err := unmarshalKey("actionExecTimeout", timeout, &t.ActionExecTimeout); err != nil {
-		return err
-	}
-	if err := unmarshalKey("branchExecTimeout", timeout, &t.ActionExecTimeout); err != nil {
-		return err
-	}
-	if err := unmarshalKey("eventTimeout", timeout, &t.ActionExecTimeout); err != nil {
-		return err
-	}
-
-	return nil
+	// the conversion drops the UnmarshalJSON method, the object form is decoded directly
+	type timeouts Timeouts
+	return json.Unmarshal(data, (*timeouts)(t))
 }
 
 // WorkflowExecTimeout ...
@@ -292,20 +370,16 @@ type WorkflowExecTimeout struct {
 
 // UnmarshalJSON ...
 func (w *WorkflowExecTimeout) UnmarshalJSON(data []byte) error {
-	execTimeout := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &execTimeout); err != nil {
-		w.Duration, err = unmarshalString(data)
+	if isJSONString(data) {
+		duration, err := unmarshalString(data)
 		if err != nil {
 			return err
 		}
+		w.Duration = duration
 	} else {
-		if err := unmarshalKey("duration", execTimeout, &w.Duration); err != nil {
-			return err
-		}
-		if err := unmarshalKey("interrupt", execTimeout, &w.Interrupt); err != nil {
-			return err
-		}
-		if err := unmarshalKey("runBefore", execTimeout, &w.RunBefore); err != nil {
+		// the conversion drops the UnmarshalJSON method, the object form is decoded directly
+		type workflowExecTimeout WorkflowExecTimeout
+		if err := json.Unmarshal(data, (*workflowExecTimeout)(w)); err != nil {
 			return err
 		}
 	}
@@ -325,21 +399,14 @@ type StateExecTimeout struct {
 
 // UnmarshalJSON ...
 func (s *StateExecTimeout) UnmarshalJSON(data []byte) error {
-	stateTimeout := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &stateTimeout); err != nil {
+	if isJSONString(data) {
+		var err error
 		s.Total, err = unmarshalString(data)
-		if err != nil {
-			return err
-		}
-		return nil
-	}
-	if err := unmarshalKey("total", stateTimeout, &s.Total); err != nil {
-		return err
-	}
-	if err := unmarshalKey("single", stateTimeout, &s.Single); err != nil {
 		return err
 	}
-	return nil
+	// the conversion drops the UnmarshalJSON method, the object form is decoded directly
+	type stateExecTimeout StateExecTimeout
+	return json.Unmarshal(data, (*stateExecTimeout)(s))
 }
 
 // Error declaration for workflow definitions
@@ -360,6 +427,11 @@ type Start struct {
 
 // UnmarshalJSON ...
 func (s *Start) UnmarshalJSON(data []byte) error {
+	if isJSONString(data) {
+		var err error
+		s.StateName, err = unmarshalString(data)
+		return err
+	}
 	startMap := make(map[string]json.RawMessage)
 	if err := json.Unmarshal(data, &startMap); err != nil {
 		s.StateName, err = unmarshalString(data)
@@ -395,6 +467,11 @@ type Schedule struct {
 
 // UnmarshalJSON ...
 func (s *Schedule) UnmarshalJSON(data []byte) error {
+	if isJSONString(data) {
+		var err error
+		s.Interval, err = unmarshalString(data)
+		return err
+	}
 	scheduleMap := make(map[string]json.RawMessage)
 	if err := json.Unmarshal(data, &scheduleMap); err != nil {
 		s.Interval, err = unmarshalString(data)
@@ -427,18 +504,19 @@ type Cron struct {
 
 // UnmarshalJSON custom unmarshal function for Cron
 func (c *Cron) UnmarshalJSON(data []byte) error {
-	cron := make(map[string]interface{})
-	if err := json.Unmarshal(data, &cron); err != nil {
+	if isJSONString(data) {
+		var err error
 		c.Expression, err = unmarshalString(data)
-		if err != nil {
-			return err
-		}
-		return nil
+		return err
 	}
-
-	c.Expression = requiresNotNilOrEmpty(cron["expression"])
-	c.ValidUntil = requiresNotNilOrEmpty(cron["validUntil"])
-
+	// the conversion drops the UnmarshalJSON method, the object form is decoded directly. The values of the wrong type
+	// are reported rather than asserted.
+	type cronUnmarshal Cron
+	var cron cronUnmarshal
+	if err := json.Unmarshal(data, &cron); err != nil {
+		return err
+	}
+	*c = Cron(cron)
 	return nil
 }
 
@@ -454,26 +532,14 @@ type Transition struct {
 
 // UnmarshalJSON ...
 func (t *Transition) UnmarshalJSON(data []byte) error {
-	transitionMap := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &transitionMap); err != nil {
+	if isJSONString(data) {
+		var err error
 		t.NextState, err = unmarshalString(data)
-		if err != nil {
-			return err
-		}
-		return nil
-	}
-
-	if err := unmarshalKey("compensate", transitionMap, &t.Compensate); err != nil {
-		return err
-	}
-	if err := unmarshalKey("produceEvents", transitionMap, &t.ProduceEvents); err != nil {
 		return err
 	}
-	if err := unmarshalKey("nextState", transitionMap, &t.NextState); err != nil {
-		return err
-	}
-
-	return nil
+	// the conversion drops the UnmarshalJSON method, the object form is decoded directly
+	type transition Transition
+	return json.Unmarshal(data, (*transition)(t))
 }
 
 // OnError ...
@@ -506,7 +572,7 @@ type Action struct {
 	Name        string      `json:"name,omitempty"`
 	FunctionRef FunctionRef `json:"functionRef,omitempty"`
 	// References a 'trigger' and 'result' reusable event definitions
-	EventRef EventRef `json:"eventRef,omitempty"`
+	EventRef *EventRef `json:"eventRef,omitempty"`
 	// References a sub-workflow to be executed
 	SubFlowRef WorkflowRef `json:"subFlowRef,omitempty"`
 	// Sleep Defines time period workflow execution should sleep before / after function execution
@@ -528,18 +594,22 @@ type End struct {
 	// Defines events that should be produced
 	ProduceEvents []ProduceEvent `json:"produceEvents,omitempty"`
 	// If set to true, triggers workflow compensation. Default is false
-	Compensate bool       `json:"compensate,omitempty"`
-	ContinueAs ContinueAs `json:"continueAs,omitempty"`
+	Compensate bool        `json:"compensate,omitempty"`
+	ContinueAs *ContinueAs `json:"continueAs,omitempty"`
 }
 
 // UnmarshalJSON ...
 func (e *End) UnmarshalJSON(data []byte) error {
-	endMap := make(map[string]json.RawMessage)
-	if err := json.Unmarshal(data, &endMap); err != nil {
+	if !isJSONObject(data) {
+		// 'end: true' ends the execution path without further definition
 		e.Terminate = false
 		e.Compensate = false
 		return nil
 	}
+	endMap := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &endMap); err != nil {
+		return err
+	}
 
 	if err := unmarshalKey("compensate", endMap, &e.Compensate); err != nil {
 		return err
@@ -691,6 +761,11 @@ func (c *Constants) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON implements json.Marshaler
+func (c Constants) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Data)
+}
+
 // Sleep ...
 type Sleep struct {
 	// Before Amount of time (ISO 8601 duration format) to sleep before function/subflow invocation. Does not apply if 'eventRef' is defined.
@@ -0,0 +1,182 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/util/floatstr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestWorkflowUnmarshalStates(t *testing.T) {
+	workflow := &Workflow{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "id": "order",
+  "name": "Order",
+  "specVersion": "0.8",
+  "start": "Wait",
+  "errors": [{"name": "NotFound", "code": "404"}],
+  "states": [
+    {"name": "Wait", "type": "event", "onEvents": [{"eventRefs": ["OrderCreated"]}], "exclusive": "no", "transition": "Route"},
+    {"name": "Route", "type": "switch", "dataConditions": [{"condition": ".ok", "transition": "Notify"}, {"condition": ".ko", "end": true}]},
+    {"name": "Confirm", "type": "switch", "eventConditions": [{"eventRef": "Confirmed", "end": null}, {"eventRef": "Rejected", "transition": "Notify"}],
+      "timeouts": {"eventTimeout": "PT1H"}},
+    {"name": "Notify", "type": "inject", "data": {}, "end": true}
+  ]
+}`), workflow))
+
+	require.Len(t, workflow.States, 4)
+	event := workflow.States[0].(*EventState)
+	assert.True(t, event.Exclusive)
+	assert.Equal(t, "Route", event.Transition.NextState)
+	assert.Equal(t, []string{"OrderCreated"}, event.OnEvents[0].EventRefs)
+
+	dataSwitch := workflow.States[1].(*DataBasedSwitchState)
+	assert.IsType(t, &TransitionDataCondition{}, dataSwitch.DataConditions[0])
+	assert.IsType(t, &EndDataCondition{}, dataSwitch.DataConditions[1])
+
+	eventSwitch := workflow.States[2].(*EventBasedSwitchState)
+	assert.IsType(t, &EndEventCondition{}, eventSwitch.EventConditions[0])
+	assert.IsType(t, &TransitionEventCondition{}, eventSwitch.EventConditions[1])
+	assert.Equal(t, "PT1H", eventSwitch.Timeouts.EventTimeout)
+
+	assert.IsType(t, &InjectState{}, workflow.States[3])
+	assert.Equal(t, "NotFound", workflow.Errors[0].Name)
+	assert.Equal(t, DefaultExpressionLang, workflow.ExpressionLang)
+
+	event = &EventState{}
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "Wait", "type": "event", "exclusive": false}`), event))
+	assert.False(t, event.Exclusive)
+
+	err := json.Unmarshal([]byte(`{"states": [{"name": "Wait", "type": "wait"}]}`), &Workflow{})
+	assert.EqualError(t, err, "state wait not supported")
+	err = json.Unmarshal([]byte(`{"states": [{"name": "Wait"}]}`), &Workflow{})
+	assert.EqualError(t, err, "state Wait has no type")
+	err = json.Unmarshal([]byte(`{"id": "order"}`), &Workflow{})
+	assert.EqualError(t, err, "workflow states are required")
+}
+
+func TestUnmarshalUnions(t *testing.T) {
+	retry := Retry{}
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "Default", "maxAttempts": 3, "jitter": 0.5}`), &retry))
+	assert.Equal(t, intstr.FromInt(3), retry.MaxAttempts)
+	assert.Equal(t, floatstr.FromFloat(0.5), retry.Jitter)
+	retry = Retry{}
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "Default", "maxAttempts": "${ .attempts }", "jitter": "PT1S"}`), &retry))
+	assert.Equal(t, "Default", retry.Name)
+	assert.Equal(t, intstr.FromString("${ .attempts }"), retry.MaxAttempts)
+	assert.Equal(t, floatstr.FromString("PT1S"), retry.Jitter)
+	assert.Error(t, json.Unmarshal([]byte(`{"name": "Default", "maxAttempts": 1.5}`), &retry))
+
+	parallel := ParallelState{}
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "Branches", "type": "parallel", "numCompleted": "2",
+  "timeouts": {"stateExecTimeout": "PT1M", "branchExecTimeout": "PT10S"}}`), &parallel))
+	assert.Equal(t, "Branches", parallel.Name)
+	assert.Equal(t, intstr.FromString("2"), parallel.NumCompleted)
+	assert.Equal(t, StateExecTimeout{Total: "PT1M"}, parallel.Timeouts.StateExecTimeout)
+
+	forEach := ForEachState{}
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "Items", "type": "foreach", "batchSize": 10,
+  "timeouts": {"stateExecTimeout": {"single": "PT1S", "total": "PT1M"}}}`), &forEach))
+	assert.Equal(t, intstr.FromInt(10), forEach.BatchSize)
+	assert.Equal(t, StateExecTimeout{Single: "PT1S", Total: "PT1M"}, forEach.Timeouts.StateExecTimeout)
+
+	timeouts := Timeouts{}
+	require.NoError(t, json.Unmarshal([]byte(`{"workflowExecTimeout": {"duration": "PT1H", "runBefore": "Cleanup"},
+  "actionExecTimeout": "PT1M"}`), &timeouts))
+	assert.Equal(t, WorkflowExecTimeout{Duration: "PT1H", RunBefore: "Cleanup"}, *timeouts.WorkflowExecTimeout)
+	assert.Equal(t, "PT1M", timeouts.ActionExecTimeout)
+	execTimeout := WorkflowExecTimeout{}
+	require.NoError(t, json.Unmarshal([]byte(`{}`), &execTimeout))
+	assert.Equal(t, UnlimitedTimeout, execTimeout.Duration)
+	require.NoError(t, json.Unmarshal([]byte(`"délai"`), &execTimeout))
+	assert.Equal(t, "délai", execTimeout.Duration)
+
+	cron := Cron{}
+	require.NoError(t, json.Unmarshal([]byte(`{"expression": "0 0 * * *", "validUntil": "2023-01-01T00:00:00Z"}`), &cron))
+	assert.Equal(t, Cron{Expression: "0 0 * * *", ValidUntil: "2023-01-01T00:00:00Z"}, cron)
+	assert.Error(t, json.Unmarshal([]byte(`{"expression": true}`), &cron))
+
+	// the event references are decoded with their 0.8 names too
+	ref := EventRef{}
+	require.NoError(t, json.Unmarshal([]byte(`{"produceEventRef": "Order", "consumeEventRef": "Stored", "data": "${ .order }"}`), &ref))
+	assert.Equal(t, EventRef{TriggerEventRef: "Order", ResultEventRef: "Stored", Data: "${ .order }"}, ref)
+	action := Action{}
+	require.NoError(t, json.Unmarshal([]byte(`{"eventRef": {"triggerEventRef": "Order", "resultEventRef": "Stored"}}`), &action))
+	assert.Equal(t, &EventRef{TriggerEventRef: "Order", ResultEventRef: "Stored"}, action.EventRef)
+	// but not with both names
+	assert.EqualError(t, json.Unmarshal([]byte(`{"triggerEventRef": "Order", "produceEventRef": "Order"}`), &EventRef{}),
+		"eventRef has both triggerEventRef and produceEventRef, its 0.8 name")
+	assert.EqualError(t, json.Unmarshal([]byte(`{"eventRef": {"triggerEventRef": "Order", "resultEventRef": "Stored",
+  "consumeEventRef": "Paid"}}`), &Action{}), "eventRef has both resultEventRef and consumeEventRef, its 0.8 name")
+}
+
+func TestUnmarshalAuth(t *testing.T) {
+	auth := Auth{}
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "basic", "properties": {"username": "user", "password": "pass",
+  "metadata": {"realm": "orders"}}}`), &auth))
+	assert.Equal(t, Auth{Name: "basic", Scheme: AuthTypeBasic, Properties: &BasicAuthProperties{
+		BaseAuthProperties: BaseAuthProperties{Common: Common{Metadata: Metadata{"realm": "orders"}}},
+		Username:           "user",
+		Password:           "pass",
+	}}, auth)
+
+	auth = Auth{}
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "bearer", "scheme": "bearer", "properties": "${ SECRETS.token }"}`), &auth))
+	assert.Equal(t, Auth{Name: "bearer", Scheme: AuthTypeBearer, Properties: &BearerAuthProperties{
+		BaseAuthProperties: BaseAuthProperties{Secret: "${ SECRETS.token }"},
+	}}, auth)
+
+	auth = Auth{}
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "oauth", "scheme": "oauth2", "properties": {"grantType": "clientCredentials",
+  "clientId": "orders", "scopes": ["read", "write"]}}`), &auth))
+	assert.Equal(t, Auth{Name: "oauth", Scheme: AuthTypeOAuth2, Properties: &OAuth2AuthProperties{
+		GrantType: GrantTypeClientCredentials,
+		ClientID:  "orders",
+		Scopes:    []string{"read", "write"},
+	}}, auth)
+
+	// the secret of the object form round trips
+	auth = Auth{}
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "bearer", "scheme": "bearer", "properties": {"secret": "token", "token": "t"}}`), &auth))
+	assert.Equal(t, "token", auth.Properties.GetSecret())
+
+	assert.EqualError(t, json.Unmarshal([]byte(`{"name": "digest", "scheme": "digest"}`), &Auth{}),
+		"authentication scheme digest not supported")
+	assert.Error(t, json.Unmarshal([]byte(`{"name": "basic", "properties": {"username": 1}}`), &Auth{}))
+}
+
+func TestUnmarshalUnionsAllocations(t *testing.T) {
+	maxAttempts, duration := []byte(`12`), []byte(`"PT1M"`)
+	var value intOrString
+	var timeout StateExecTimeout
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := value.UnmarshalJSON(maxAttempts); err != nil {
+			t.Fatal(err)
+		}
+	})
+	assert.Zero(t, allocs)
+	allocs = testing.AllocsPerRun(100, func() {
+		if err := timeout.UnmarshalJSON(duration); err != nil {
+			t.Fatal(err)
+		}
+	})
+	// the string of the duration only
+	assert.Equal(t, float64(1), allocs)
+}
@@ -0,0 +1,1667 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by hack/deepcopy. DO NOT EDIT.
+
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/serverlessworkflow/sdk-go/v2/util/floatstr"
+)
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Action) DeepCopyInto(out *Action) {
+	*out = *in
+	in.FunctionRef.DeepCopyInto(&out.FunctionRef)
+	if in.EventRef != nil {
+		in, out := &in.EventRef, &out.EventRef
+		*out = new(EventRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NonRetryableErrors != nil {
+		in, out := &in.NonRetryableErrors, &out.NonRetryableErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetryableErrors != nil {
+		in, out := &in.RetryableErrors, &out.RetryableErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Action.
+func (in *Action) DeepCopy() *Action {
+	if in == nil {
+		return nil
+	}
+	out := new(Action)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *ActionDataFilter) DeepCopyInto(out *ActionDataFilter) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new ActionDataFilter.
+func (in *ActionDataFilter) DeepCopy() *ActionDataFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionDataFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *ActionMode) DeepCopyInto(out *ActionMode) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new ActionMode.
+func (in *ActionMode) DeepCopy() *ActionMode {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionMode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Auth) DeepCopyInto(out *Auth) {
+	*out = *in
+	if in.Properties != nil {
+		out.Properties = deepCopyAuthProperties(in.Properties)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Auth.
+func (in *Auth) DeepCopy() *Auth {
+	if in == nil {
+		return nil
+	}
+	out := new(Auth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *AuthDefinitions) DeepCopyInto(out *AuthDefinitions) {
+	*out = *in
+	if in.Defs != nil {
+		in, out := &in.Defs, &out.Defs
+		*out = make([]Auth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new AuthDefinitions.
+func (in *AuthDefinitions) DeepCopy() *AuthDefinitions {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthDefinitions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *AuthType) DeepCopyInto(out *AuthType) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new AuthType.
+func (in *AuthType) DeepCopy() *AuthType {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *BaseAuthProperties) DeepCopyInto(out *BaseAuthProperties) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+}
+
+// DeepCopy copies the receiver, creating a new BaseAuthProperties.
+func (in *BaseAuthProperties) DeepCopy() *BaseAuthProperties {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseAuthProperties)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *BaseDataCondition) DeepCopyInto(out *BaseDataCondition) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(Metadata, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = deepCopyValue(val)
+			}
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new BaseDataCondition.
+func (in *BaseDataCondition) DeepCopy() *BaseDataCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseDataCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *BaseEventCondition) DeepCopyInto(out *BaseEventCondition) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(Metadata, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = deepCopyValue(val)
+			}
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new BaseEventCondition.
+func (in *BaseEventCondition) DeepCopy() *BaseEventCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseEventCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *BaseState) DeepCopyInto(out *BaseState) {
+	*out = *in
+	if in.OnErrors != nil {
+		in, out := &in.OnErrors, &out.OnErrors
+		*out = make([]OnError, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Transition != nil {
+		in, out := &in.Transition, &out.Transition
+		*out = new(Transition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StateDataFilter != nil {
+		in, out := &in.StateDataFilter, &out.StateDataFilter
+		*out = new(StateDataFilter)
+		**out = **in
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = new(End)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(Metadata)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make(Metadata, len(*in))
+			for key, val := range *in {
+				if val == nil {
+					(*out)[key] = nil
+				} else {
+					(*out)[key] = deepCopyValue(val)
+				}
+			}
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new BaseState.
+func (in *BaseState) DeepCopy() *BaseState {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *BaseSwitchState) DeepCopyInto(out *BaseSwitchState) {
+	*out = *in
+	in.BaseState.DeepCopyInto(&out.BaseState)
+	in.DefaultCondition.DeepCopyInto(&out.DefaultCondition)
+}
+
+// DeepCopy copies the receiver, creating a new BaseSwitchState.
+func (in *BaseSwitchState) DeepCopy() *BaseSwitchState {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseSwitchState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *BaseWorkflow) DeepCopyInto(out *BaseWorkflow) {
+	*out = *in
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = new(Start)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DataInputSchema != nil {
+		in, out := &in.DataInputSchema, &out.DataInputSchema
+		*out = new(DataInputSchema)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make(Secrets, len(*in))
+		copy(*out, *in)
+	}
+	if in.Constants != nil {
+		in, out := &in.Constants, &out.Constants
+		*out = new(Constants)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(Timeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]Error, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(Metadata, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = deepCopyValue(val)
+			}
+		}
+	}
+	in.Auth.DeepCopyInto(&out.Auth)
+}
+
+// DeepCopy copies the receiver, creating a new BaseWorkflow.
+func (in *BaseWorkflow) DeepCopy() *BaseWorkflow {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseWorkflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *BasicAuthProperties) DeepCopyInto(out *BasicAuthProperties) {
+	*out = *in
+	in.BaseAuthProperties.DeepCopyInto(&out.BaseAuthProperties)
+}
+
+// DeepCopy copies the receiver, creating a new BasicAuthProperties.
+func (in *BasicAuthProperties) DeepCopy() *BasicAuthProperties {
+	if in == nil {
+		return nil
+	}
+	out := new(BasicAuthProperties)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *BearerAuthProperties) DeepCopyInto(out *BearerAuthProperties) {
+	*out = *in
+	in.BaseAuthProperties.DeepCopyInto(&out.BaseAuthProperties)
+}
+
+// DeepCopy copies the receiver, creating a new BearerAuthProperties.
+func (in *BearerAuthProperties) DeepCopy() *BearerAuthProperties {
+	if in == nil {
+		return nil
+	}
+	out := new(BearerAuthProperties)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Branch) DeepCopyInto(out *Branch) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]Action, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Branch.
+func (in *Branch) DeepCopy() *Branch {
+	if in == nil {
+		return nil
+	}
+	out := new(Branch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *BranchTimeouts) DeepCopyInto(out *BranchTimeouts) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new BranchTimeouts.
+func (in *BranchTimeouts) DeepCopy() *BranchTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(BranchTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *CallbackState) DeepCopyInto(out *CallbackState) {
+	*out = *in
+	in.BaseState.DeepCopyInto(&out.BaseState)
+	in.Action.DeepCopyInto(&out.Action)
+}
+
+// DeepCopy copies the receiver, creating a new CallbackState.
+func (in *CallbackState) DeepCopy() *CallbackState {
+	if in == nil {
+		return nil
+	}
+	out := new(CallbackState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *CallbackStateTimeout) DeepCopyInto(out *CallbackStateTimeout) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new CallbackStateTimeout.
+func (in *CallbackStateTimeout) DeepCopy() *CallbackStateTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(CallbackStateTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Common) DeepCopyInto(out *Common) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(Metadata, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = deepCopyValue(val)
+			}
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Common.
+func (in *Common) DeepCopy() *Common {
+	if in == nil {
+		return nil
+	}
+	out := new(Common)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *CompletionType) DeepCopyInto(out *CompletionType) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new CompletionType.
+func (in *CompletionType) DeepCopy() *CompletionType {
+	if in == nil {
+		return nil
+	}
+	out := new(CompletionType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Constants) DeepCopyInto(out *Constants) {
+	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]json.RawMessage, len(*in))
+		for key, val := range *in {
+			var outVal json.RawMessage
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(json.RawMessage, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Constants.
+func (in *Constants) DeepCopy() *Constants {
+	if in == nil {
+		return nil
+	}
+	out := new(Constants)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *ContinueAs) DeepCopyInto(out *ContinueAs) {
+	*out = *in
+	if in.Data != nil {
+		out.Data = deepCopyValue(in.Data)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new ContinueAs.
+func (in *ContinueAs) DeepCopy() *ContinueAs {
+	if in == nil {
+		return nil
+	}
+	out := new(ContinueAs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Correlation) DeepCopyInto(out *Correlation) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new Correlation.
+func (in *Correlation) DeepCopy() *Correlation {
+	if in == nil {
+		return nil
+	}
+	out := new(Correlation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Cron) DeepCopyInto(out *Cron) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new Cron.
+func (in *Cron) DeepCopy() *Cron {
+	if in == nil {
+		return nil
+	}
+	out := new(Cron)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *DataBasedSwitchState) DeepCopyInto(out *DataBasedSwitchState) {
+	*out = *in
+	in.BaseSwitchState.DeepCopyInto(&out.BaseSwitchState)
+	if in.DataConditions != nil {
+		in, out := &in.DataConditions, &out.DataConditions
+		*out = make([]DataCondition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				(*out)[i] = deepCopyDataCondition((*in)[i])
+			}
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new DataBasedSwitchState.
+func (in *DataBasedSwitchState) DeepCopy() *DataBasedSwitchState {
+	if in == nil {
+		return nil
+	}
+	out := new(DataBasedSwitchState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *DataBasedSwitchStateTimeout) DeepCopyInto(out *DataBasedSwitchStateTimeout) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new DataBasedSwitchStateTimeout.
+func (in *DataBasedSwitchStateTimeout) DeepCopy() *DataBasedSwitchStateTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(DataBasedSwitchStateTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *DataInputSchema) DeepCopyInto(out *DataInputSchema) {
+	*out = *in
+	if in.FailOnValidationErrors != nil {
+		in, out := &in.FailOnValidationErrors, &out.FailOnValidationErrors
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy copies the receiver, creating a new DataInputSchema.
+func (in *DataInputSchema) DeepCopy() *DataInputSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(DataInputSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *DefaultCondition) DeepCopyInto(out *DefaultCondition) {
+	*out = *in
+	in.Transition.DeepCopyInto(&out.Transition)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy copies the receiver, creating a new DefaultCondition.
+func (in *DefaultCondition) DeepCopy() *DefaultCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *DelayState) DeepCopyInto(out *DelayState) {
+	*out = *in
+	in.BaseState.DeepCopyInto(&out.BaseState)
+}
+
+// DeepCopy copies the receiver, creating a new DelayState.
+func (in *DelayState) DeepCopy() *DelayState {
+	if in == nil {
+		return nil
+	}
+	out := new(DelayState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *End) DeepCopyInto(out *End) {
+	*out = *in
+	if in.ProduceEvents != nil {
+		in, out := &in.ProduceEvents, &out.ProduceEvents
+		*out = make([]ProduceEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ContinueAs != nil {
+		in, out := &in.ContinueAs, &out.ContinueAs
+		*out = new(ContinueAs)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new End.
+func (in *End) DeepCopy() *End {
+	if in == nil {
+		return nil
+	}
+	out := new(End)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *EndDataCondition) DeepCopyInto(out *EndDataCondition) {
+	*out = *in
+	in.BaseDataCondition.DeepCopyInto(&out.BaseDataCondition)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy copies the receiver, creating a new EndDataCondition.
+func (in *EndDataCondition) DeepCopy() *EndDataCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(EndDataCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *EndEventCondition) DeepCopyInto(out *EndEventCondition) {
+	*out = *in
+	in.BaseEventCondition.DeepCopyInto(&out.BaseEventCondition)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy copies the receiver, creating a new EndEventCondition.
+func (in *EndEventCondition) DeepCopy() *EndEventCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(EndEventCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Error) DeepCopyInto(out *Error) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new Error.
+func (in *Error) DeepCopy() *Error {
+	if in == nil {
+		return nil
+	}
+	out := new(Error)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Event) DeepCopyInto(out *Event) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+	if in.Correlation != nil {
+		in, out := &in.Correlation, &out.Correlation
+		*out = make([]Correlation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Event.
+func (in *Event) DeepCopy() *Event {
+	if in == nil {
+		return nil
+	}
+	out := new(Event)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *EventBasedSwitchState) DeepCopyInto(out *EventBasedSwitchState) {
+	*out = *in
+	in.BaseSwitchState.DeepCopyInto(&out.BaseSwitchState)
+	if in.EventConditions != nil {
+		in, out := &in.EventConditions, &out.EventConditions
+		*out = make([]EventCondition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				(*out)[i] = deepCopyEventCondition((*in)[i])
+			}
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new EventBasedSwitchState.
+func (in *EventBasedSwitchState) DeepCopy() *EventBasedSwitchState {
+	if in == nil {
+		return nil
+	}
+	out := new(EventBasedSwitchState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *EventBasedSwitchStateTimeout) DeepCopyInto(out *EventBasedSwitchStateTimeout) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new EventBasedSwitchStateTimeout.
+func (in *EventBasedSwitchStateTimeout) DeepCopy() *EventBasedSwitchStateTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(EventBasedSwitchStateTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *EventDataFilter) DeepCopyInto(out *EventDataFilter) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new EventDataFilter.
+func (in *EventDataFilter) DeepCopy() *EventDataFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(EventDataFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *EventKind) DeepCopyInto(out *EventKind) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new EventKind.
+func (in *EventKind) DeepCopy() *EventKind {
+	if in == nil {
+		return nil
+	}
+	out := new(EventKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *EventRef) DeepCopyInto(out *EventRef) {
+	*out = *in
+	if in.Data != nil {
+		out.Data = deepCopyValue(in.Data)
+	}
+	if in.ContextAttributes != nil {
+		in, out := &in.ContextAttributes, &out.ContextAttributes
+		*out = make(map[string]interface{}, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = deepCopyValue(val)
+			}
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new EventRef.
+func (in *EventRef) DeepCopy() *EventRef {
+	if in == nil {
+		return nil
+	}
+	out := new(EventRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *EventState) DeepCopyInto(out *EventState) {
+	*out = *in
+	in.BaseState.DeepCopyInto(&out.BaseState)
+	if in.OnEvents != nil {
+		in, out := &in.OnEvents, &out.OnEvents
+		*out = make([]OnEvents, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new EventState.
+func (in *EventState) DeepCopy() *EventState {
+	if in == nil {
+		return nil
+	}
+	out := new(EventState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *EventStateTimeout) DeepCopyInto(out *EventStateTimeout) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new EventStateTimeout.
+func (in *EventStateTimeout) DeepCopy() *EventStateTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(EventStateTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *ForEachModeType) DeepCopyInto(out *ForEachModeType) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new ForEachModeType.
+func (in *ForEachModeType) DeepCopy() *ForEachModeType {
+	if in == nil {
+		return nil
+	}
+	out := new(ForEachModeType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *ForEachState) DeepCopyInto(out *ForEachState) {
+	*out = *in
+	in.BaseState.DeepCopyInto(&out.BaseState)
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]Action, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new ForEachState.
+func (in *ForEachState) DeepCopy() *ForEachState {
+	if in == nil {
+		return nil
+	}
+	out := new(ForEachState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *ForEachStateTimeout) DeepCopyInto(out *ForEachStateTimeout) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new ForEachStateTimeout.
+func (in *ForEachStateTimeout) DeepCopy() *ForEachStateTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(ForEachStateTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Function) DeepCopyInto(out *Function) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+}
+
+// DeepCopy copies the receiver, creating a new Function.
+func (in *Function) DeepCopy() *Function {
+	if in == nil {
+		return nil
+	}
+	out := new(Function)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *FunctionRef) DeepCopyInto(out *FunctionRef) {
+	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make(map[string]interface{}, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = deepCopyValue(val)
+			}
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new FunctionRef.
+func (in *FunctionRef) DeepCopy() *FunctionRef {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *FunctionType) DeepCopyInto(out *FunctionType) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new FunctionType.
+func (in *FunctionType) DeepCopy() *FunctionType {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *GrantType) DeepCopyInto(out *GrantType) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new GrantType.
+func (in *GrantType) DeepCopy() *GrantType {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *InjectState) DeepCopyInto(out *InjectState) {
+	*out = *in
+	in.BaseState.DeepCopyInto(&out.BaseState)
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]interface{}, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = deepCopyValue(val)
+			}
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new InjectState.
+func (in *InjectState) DeepCopy() *InjectState {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *InjectStateTimeout) DeepCopyInto(out *InjectStateTimeout) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new InjectStateTimeout.
+func (in *InjectStateTimeout) DeepCopy() *InjectStateTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectStateTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in Metadata) DeepCopyInto(out *Metadata) {
+	{
+		in := &in
+		*out = make(Metadata, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = deepCopyValue(val)
+			}
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Metadata.
+func (in Metadata) DeepCopy() Metadata {
+	if in == nil {
+		return nil
+	}
+	out := new(Metadata)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *OAuth2AuthProperties) DeepCopyInto(out *OAuth2AuthProperties) {
+	*out = *in
+	in.BaseAuthProperties.DeepCopyInto(&out.BaseAuthProperties)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new OAuth2AuthProperties.
+func (in *OAuth2AuthProperties) DeepCopy() *OAuth2AuthProperties {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2AuthProperties)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *OnError) DeepCopyInto(out *OnError) {
+	*out = *in
+	if in.ErrorRefs != nil {
+		in, out := &in.ErrorRefs, &out.ErrorRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Transition != nil {
+		in, out := &in.Transition, &out.Transition
+		*out = new(Transition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = new(End)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new OnError.
+func (in *OnError) DeepCopy() *OnError {
+	if in == nil {
+		return nil
+	}
+	out := new(OnError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *OnEvents) DeepCopyInto(out *OnEvents) {
+	*out = *in
+	if in.EventRefs != nil {
+		in, out := &in.EventRefs, &out.EventRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]Action, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new OnEvents.
+func (in *OnEvents) DeepCopy() *OnEvents {
+	if in == nil {
+		return nil
+	}
+	out := new(OnEvents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *OperationState) DeepCopyInto(out *OperationState) {
+	*out = *in
+	in.BaseState.DeepCopyInto(&out.BaseState)
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]Action, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new OperationState.
+func (in *OperationState) DeepCopy() *OperationState {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *OperationStateTimeout) DeepCopyInto(out *OperationStateTimeout) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new OperationStateTimeout.
+func (in *OperationStateTimeout) DeepCopy() *OperationStateTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationStateTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *ParallelState) DeepCopyInto(out *ParallelState) {
+	*out = *in
+	in.BaseState.DeepCopyInto(&out.BaseState)
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]Branch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new ParallelState.
+func (in *ParallelState) DeepCopy() *ParallelState {
+	if in == nil {
+		return nil
+	}
+	out := new(ParallelState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *ParallelStateTimeout) DeepCopyInto(out *ParallelStateTimeout) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new ParallelStateTimeout.
+func (in *ParallelStateTimeout) DeepCopy() *ParallelStateTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(ParallelStateTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *ProduceEvent) DeepCopyInto(out *ProduceEvent) {
+	*out = *in
+	if in.Data != nil {
+		out.Data = deepCopyValue(in.Data)
+	}
+	if in.ContextAttributes != nil {
+		in, out := &in.ContextAttributes, &out.ContextAttributes
+		*out = make(map[string]interface{}, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = deepCopyValue(val)
+			}
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new ProduceEvent.
+func (in *ProduceEvent) DeepCopy() *ProduceEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(ProduceEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Retry) DeepCopyInto(out *Retry) {
+	*out = *in
+	if in.Multiplier != nil {
+		in, out := &in.Multiplier, &out.Multiplier
+		*out = new(floatstr.Float32OrString)
+		**out = **in
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Retry.
+func (in *Retry) DeepCopy() *Retry {
+	if in == nil {
+		return nil
+	}
+	out := new(Retry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Schedule) DeepCopyInto(out *Schedule) {
+	*out = *in
+	if in.Cron != nil {
+		in, out := &in.Cron, &out.Cron
+		*out = new(Cron)
+		**out = **in
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Schedule.
+func (in *Schedule) DeepCopy() *Schedule {
+	if in == nil {
+		return nil
+	}
+	out := new(Schedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in Secrets) DeepCopyInto(out *Secrets) {
+	{
+		in := &in
+		*out = make(Secrets, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Secrets.
+func (in Secrets) DeepCopy() Secrets {
+	if in == nil {
+		return nil
+	}
+	out := new(Secrets)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Sleep) DeepCopyInto(out *Sleep) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new Sleep.
+func (in *Sleep) DeepCopy() *Sleep {
+	if in == nil {
+		return nil
+	}
+	out := new(Sleep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *SleepState) DeepCopyInto(out *SleepState) {
+	*out = *in
+	in.BaseState.DeepCopyInto(&out.BaseState)
+}
+
+// DeepCopy copies the receiver, creating a new SleepState.
+func (in *SleepState) DeepCopy() *SleepState {
+	if in == nil {
+		return nil
+	}
+	out := new(SleepState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *SleepStateTimeout) DeepCopyInto(out *SleepStateTimeout) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new SleepStateTimeout.
+func (in *SleepStateTimeout) DeepCopy() *SleepStateTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(SleepStateTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Start) DeepCopyInto(out *Start) {
+	*out = *in
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(Schedule)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Start.
+func (in *Start) DeepCopy() *Start {
+	if in == nil {
+		return nil
+	}
+	out := new(Start)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *StateDataFilter) DeepCopyInto(out *StateDataFilter) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new StateDataFilter.
+func (in *StateDataFilter) DeepCopy() *StateDataFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(StateDataFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *StateExecTimeout) DeepCopyInto(out *StateExecTimeout) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new StateExecTimeout.
+func (in *StateExecTimeout) DeepCopy() *StateExecTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(StateExecTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *StateType) DeepCopyInto(out *StateType) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new StateType.
+func (in *StateType) DeepCopy() *StateType {
+	if in == nil {
+		return nil
+	}
+	out := new(StateType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Timeouts) DeepCopyInto(out *Timeouts) {
+	*out = *in
+	if in.WorkflowExecTimeout != nil {
+		in, out := &in.WorkflowExecTimeout, &out.WorkflowExecTimeout
+		*out = new(WorkflowExecTimeout)
+		**out = **in
+	}
+	if in.StateExecTimeout != nil {
+		in, out := &in.StateExecTimeout, &out.StateExecTimeout
+		*out = new(StateExecTimeout)
+		**out = **in
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Timeouts.
+func (in *Timeouts) DeepCopy() *Timeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(Timeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Transition) DeepCopyInto(out *Transition) {
+	*out = *in
+	if in.ProduceEvents != nil {
+		in, out := &in.ProduceEvents, &out.ProduceEvents
+		*out = make([]ProduceEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Transition.
+func (in *Transition) DeepCopy() *Transition {
+	if in == nil {
+		return nil
+	}
+	out := new(Transition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *TransitionDataCondition) DeepCopyInto(out *TransitionDataCondition) {
+	*out = *in
+	in.BaseDataCondition.DeepCopyInto(&out.BaseDataCondition)
+	in.Transition.DeepCopyInto(&out.Transition)
+}
+
+// DeepCopy copies the receiver, creating a new TransitionDataCondition.
+func (in *TransitionDataCondition) DeepCopy() *TransitionDataCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitionDataCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *TransitionEventCondition) DeepCopyInto(out *TransitionEventCondition) {
+	*out = *in
+	in.BaseEventCondition.DeepCopyInto(&out.BaseEventCondition)
+	in.Transition.DeepCopyInto(&out.Transition)
+}
+
+// DeepCopy copies the receiver, creating a new TransitionEventCondition.
+func (in *TransitionEventCondition) DeepCopy() *TransitionEventCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitionEventCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *Workflow) DeepCopyInto(out *Workflow) {
+	*out = *in
+	in.BaseWorkflow.DeepCopyInto(&out.BaseWorkflow)
+	if in.States != nil {
+		in, out := &in.States, &out.States
+		*out = make([]State, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				(*out)[i] = deepCopyState((*in)[i])
+			}
+		}
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]Event, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Functions != nil {
+		in, out := &in.Functions, &out.Functions
+		*out = make([]Function, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = make([]Retry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.index != nil {
+		out.index = new(workflowIndex)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new Workflow.
+func (in *Workflow) DeepCopy() *Workflow {
+	if in == nil {
+		return nil
+	}
+	out := new(Workflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *WorkflowExecTimeout) DeepCopyInto(out *WorkflowExecTimeout) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new WorkflowExecTimeout.
+func (in *WorkflowExecTimeout) DeepCopy() *WorkflowExecTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowExecTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out, in must be non-nil.
+func (in *WorkflowRef) DeepCopyInto(out *WorkflowRef) {
+	*out = *in
+}
+
+// DeepCopy copies the receiver, creating a new WorkflowRef.
+func (in *WorkflowRef) DeepCopy() *WorkflowRef {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// deepCopyAuthProperties copies the AuthProperties implementations of the package, other implementations are shared.
+func deepCopyAuthProperties(in AuthProperties) AuthProperties {
+	switch v := in.(type) {
+	case *BaseAuthProperties:
+		return v.DeepCopy()
+	case *BasicAuthProperties:
+		return v.DeepCopy()
+	case *BearerAuthProperties:
+		return v.DeepCopy()
+	case *OAuth2AuthProperties:
+		return v.DeepCopy()
+	}
+	return in
+}
+
+// deepCopyDataCondition copies the DataCondition implementations of the package, other implementations are shared.
+func deepCopyDataCondition(in DataCondition) DataCondition {
+	switch v := in.(type) {
+	case *BaseDataCondition:
+		return v.DeepCopy()
+	case *EndDataCondition:
+		return v.DeepCopy()
+	case *TransitionDataCondition:
+		return v.DeepCopy()
+	}
+	return in
+}
+
+// deepCopyEventCondition copies the EventCondition implementations of the package, other implementations are shared.
+func deepCopyEventCondition(in EventCondition) EventCondition {
+	switch v := in.(type) {
+	case *BaseEventCondition:
+		return v.DeepCopy()
+	case *EndEventCondition:
+		return v.DeepCopy()
+	case *TransitionEventCondition:
+		return v.DeepCopy()
+	}
+	return in
+}
+
+// deepCopyState copies the State implementations of the package, other implementations are shared.
+func deepCopyState(in State) State {
+	switch v := in.(type) {
+	case *BaseState:
+		return v.DeepCopy()
+	case *BaseSwitchState:
+		return v.DeepCopy()
+	case *CallbackState:
+		return v.DeepCopy()
+	case *DataBasedSwitchState:
+		return v.DeepCopy()
+	case *DelayState:
+		return v.DeepCopy()
+	case *EventBasedSwitchState:
+		return v.DeepCopy()
+	case *EventState:
+		return v.DeepCopy()
+	case *ForEachState:
+		return v.DeepCopy()
+	case *InjectState:
+		return v.DeepCopy()
+	case *OperationState:
+		return v.DeepCopy()
+	case *ParallelState:
+		return v.DeepCopy()
+	case *SleepState:
+		return v.DeepCopy()
+	}
+	return in
+}
@@ -0,0 +1,5736 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by hack/jsoncodec. DO NOT EDIT.
+
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/serverlessworkflow/sdk-go/v2/internal/jsoncodec"
+)
+
+// MarshalJSON implements json.Marshaler
+func (t Action) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Action like encoding/json
+func (t *Action) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.Name) != 0 {
+		b = append(b, `"name":`...)
+		b = jsoncodec.AppendString(b, t.Name)
+		b = append(b, ',')
+	}
+	b = append(b, `"functionRef":`...)
+	if b, err = t.FunctionRef.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if t.EventRef != nil {
+		b = append(b, `"eventRef":`...)
+		if b, err = t.EventRef.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"subFlowRef":`...)
+	if b, err = t.SubFlowRef.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, `"sleep":`...)
+	if b, err = t.Sleep.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.RetryRef) != 0 {
+		b = append(b, `"retryRef":`...)
+		b = jsoncodec.AppendString(b, t.RetryRef)
+		b = append(b, ',')
+	}
+	if len(t.NonRetryableErrors) != 0 {
+		b = append(b, `"nonRetryableErrors":`...)
+		if t.NonRetryableErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.NonRetryableErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = jsoncodec.AppendString(b, t.NonRetryableErrors[i])
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if len(t.RetryableErrors) != 0 {
+		b = append(b, `"retryableErrors":`...)
+		if t.RetryableErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.RetryableErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = jsoncodec.AppendString(b, t.RetryableErrors[i])
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"actionDataFilter":`...)
+	if b, err = t.ActionDataFilter.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Action) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain Action
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the Action like encoding/json, the decoder failing on what it doesn't handle
+func (t *Action) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "name":
+			d.String(&t.Name)
+		case "functionRef":
+			d.Unmarshaler(&t.FunctionRef)
+		case "eventRef":
+			if d.Null() {
+				t.EventRef = nil
+			} else {
+				if t.EventRef == nil {
+					t.EventRef = new(EventRef)
+				}
+				d.Unmarshaler(t.EventRef)
+			}
+		case "subFlowRef":
+			d.Unmarshaler(&t.SubFlowRef)
+		case "sleep":
+			t.Sleep.decodeJSON(d)
+		case "retryRef":
+			d.String(&t.RetryRef)
+		case "nonRetryableErrors":
+			if d.Null() {
+				t.NonRetryableErrors = nil
+			} else {
+				s := t.NonRetryableErrors
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, "")
+					}
+					d.String(&s[i])
+					i++
+				}
+				if s == nil {
+					s = []string{}
+				}
+				t.NonRetryableErrors = s[:i]
+			}
+		case "retryableErrors":
+			if d.Null() {
+				t.RetryableErrors = nil
+			} else {
+				s := t.RetryableErrors
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, "")
+					}
+					d.String(&s[i])
+					i++
+				}
+				if s == nil {
+					s = []string{}
+				}
+				t.RetryableErrors = s[:i]
+			}
+		case "actionDataFilter":
+			t.ActionDataFilter.decodeJSON(d)
+		default:
+			d.Unknown(jsonKeysAction)
+		}
+	}
+}
+
+// jsonKeysAction keys of the properties of the Action
+var jsonKeysAction = []string{"name", "functionRef", "eventRef", "subFlowRef", "sleep", "retryRef", "nonRetryableErrors", "retryableErrors", "actionDataFilter"}
+
+// MarshalJSON implements json.Marshaler
+func (t ActionDataFilter) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the ActionDataFilter like encoding/json
+func (t *ActionDataFilter) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '{')
+	if len(t.FromStateData) != 0 {
+		b = append(b, `"fromStateData":`...)
+		b = jsoncodec.AppendString(b, t.FromStateData)
+		b = append(b, ',')
+	}
+	if len(t.Results) != 0 {
+		b = append(b, `"results":`...)
+		b = jsoncodec.AppendString(b, t.Results)
+		b = append(b, ',')
+	}
+	if len(t.ToStateData) != 0 {
+		b = append(b, `"toStateData":`...)
+		b = jsoncodec.AppendString(b, t.ToStateData)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *ActionDataFilter) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain ActionDataFilter
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the ActionDataFilter like encoding/json, the decoder failing on what it doesn't handle
+func (t *ActionDataFilter) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "fromStateData":
+			d.String(&t.FromStateData)
+		case "results":
+			d.String(&t.Results)
+		case "toStateData":
+			d.String(&t.ToStateData)
+		default:
+			d.Unknown(jsonKeysActionDataFilter)
+		}
+	}
+}
+
+// jsonKeysActionDataFilter keys of the properties of the ActionDataFilter
+var jsonKeysActionDataFilter = []string{"fromStateData", "results", "toStateData"}
+
+// MarshalJSON implements json.Marshaler
+func (t Auth) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Auth like encoding/json
+func (t *Auth) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.Name)
+	b = append(b, ',')
+	if len(t.Scheme) != 0 {
+		b = append(b, `"scheme":`...)
+		b = jsoncodec.AppendString(b, string(t.Scheme))
+		b = append(b, ',')
+	}
+	b = append(b, `"properties":`...)
+	if b, err = appendJSONValue(b, t.Properties); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// appendJSON appends the JSON of the BaseAuthProperties like encoding/json
+func (t *BaseAuthProperties) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.Common.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Common.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.Secret) != 0 {
+		b = append(b, `"secret":`...)
+		b = jsoncodec.AppendString(b, t.Secret)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// appendJSON appends the JSON of the BaseDataCondition like encoding/json
+func (t *BaseDataCondition) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.Name) != 0 {
+		b = append(b, `"name":`...)
+		b = jsoncodec.AppendString(b, t.Name)
+		b = append(b, ',')
+	}
+	b = append(b, `"condition":`...)
+	b = jsoncodec.AppendString(b, t.Condition)
+	b = append(b, ',')
+	if len(t.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// decodeJSON decodes the BaseDataCondition like encoding/json, the decoder failing on what it doesn't handle
+func (t *BaseDataCondition) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "name":
+			d.String(&t.Name)
+		case "condition":
+			d.String(&t.Condition)
+		case "metadata":
+			d.Value(&t.Metadata)
+		default:
+			d.Unknown(jsonKeysBaseDataCondition)
+		}
+	}
+}
+
+// jsonKeysBaseDataCondition keys of the properties of the BaseDataCondition
+var jsonKeysBaseDataCondition = []string{"name", "condition", "metadata"}
+
+// appendJSON appends the JSON of the BaseEventCondition like encoding/json
+func (t *BaseEventCondition) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.Name) != 0 {
+		b = append(b, `"name":`...)
+		b = jsoncodec.AppendString(b, t.Name)
+		b = append(b, ',')
+	}
+	b = append(b, `"eventRef":`...)
+	b = jsoncodec.AppendString(b, t.EventRef)
+	b = append(b, ',')
+	b = append(b, `"eventDataFilter":`...)
+	if b, err = t.EventDataFilter.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// decodeJSON decodes the BaseEventCondition like encoding/json, the decoder failing on what it doesn't handle
+func (t *BaseEventCondition) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "name":
+			d.String(&t.Name)
+		case "eventRef":
+			d.String(&t.EventRef)
+		case "eventDataFilter":
+			t.EventDataFilter.decodeJSON(d)
+		case "metadata":
+			d.Value(&t.Metadata)
+		default:
+			d.Unknown(jsonKeysBaseEventCondition)
+		}
+	}
+}
+
+// jsonKeysBaseEventCondition keys of the properties of the BaseEventCondition
+var jsonKeysBaseEventCondition = []string{"name", "eventRef", "eventDataFilter", "metadata"}
+
+// appendJSON appends the JSON of the BaseState like encoding/json
+func (t *BaseState) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.ID) != 0 {
+		b = append(b, `"id":`...)
+		b = jsoncodec.AppendString(b, t.ID)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.Name)
+	b = append(b, ',')
+	b = append(b, `"type":`...)
+	b = jsoncodec.AppendString(b, string(t.Type))
+	b = append(b, ',')
+	if len(t.OnErrors) != 0 {
+		b = append(b, `"onErrors":`...)
+		if t.OnErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.OnErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.OnErrors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.Transition != nil {
+		b = append(b, `"transition":`...)
+		if b, err = t.Transition.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.StateDataFilter != nil {
+		b = append(b, `"stateDataFilter":`...)
+		if b, err = t.StateDataFilter.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.CompensatedBy) != 0 {
+		b = append(b, `"compensatedBy":`...)
+		b = jsoncodec.AppendString(b, t.CompensatedBy)
+		b = append(b, ',')
+	}
+	if t.UsedForCompensation {
+		b = append(b, `"usedForCompensation":`...)
+		b = jsoncodec.AppendBool(b, bool(t.UsedForCompensation))
+		b = append(b, ',')
+	}
+	if t.End != nil {
+		b = append(b, `"end":`...)
+		if b, err = t.End.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.Metadata != nil {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// decodeJSON decodes the BaseState like encoding/json, the decoder failing on what it doesn't handle
+func (t *BaseState) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "id":
+			d.String(&t.ID)
+		case "name":
+			d.String(&t.Name)
+		case "type":
+			d.String((*string)(&t.Type))
+		case "onErrors":
+			if d.Null() {
+				t.OnErrors = nil
+			} else {
+				s := t.OnErrors
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, OnError{})
+					}
+					s[i].decodeJSON(d)
+					i++
+				}
+				if s == nil {
+					s = []OnError{}
+				}
+				t.OnErrors = s[:i]
+			}
+		case "transition":
+			if d.Null() {
+				t.Transition = nil
+			} else {
+				if t.Transition == nil {
+					t.Transition = new(Transition)
+				}
+				d.Unmarshaler(t.Transition)
+			}
+		case "stateDataFilter":
+			if d.Null() {
+				t.StateDataFilter = nil
+			} else {
+				if t.StateDataFilter == nil {
+					t.StateDataFilter = new(StateDataFilter)
+				}
+				t.StateDataFilter.decodeJSON(d)
+			}
+		case "compensatedBy":
+			d.String(&t.CompensatedBy)
+		case "usedForCompensation":
+			d.Bool(&t.UsedForCompensation)
+		case "end":
+			if d.Null() {
+				t.End = nil
+			} else {
+				if t.End == nil {
+					t.End = new(End)
+				}
+				d.Unmarshaler(t.End)
+			}
+		case "metadata":
+			d.Value(&t.Metadata)
+		default:
+			d.Unknown(jsonKeysBaseState)
+		}
+	}
+}
+
+// jsonKeysBaseState keys of the properties of the BaseState
+var jsonKeysBaseState = []string{"id", "name", "type", "onErrors", "transition", "stateDataFilter", "compensatedBy", "usedForCompensation", "end", "metadata"}
+
+// appendJSON appends the JSON of the BaseSwitchState like encoding/json
+func (t *BaseSwitchState) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseState.ID) != 0 {
+		b = append(b, `"id":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.ID)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.BaseState.Name)
+	b = append(b, ',')
+	b = append(b, `"type":`...)
+	b = jsoncodec.AppendString(b, string(t.BaseState.Type))
+	b = append(b, ',')
+	if len(t.BaseState.OnErrors) != 0 {
+		b = append(b, `"onErrors":`...)
+		if t.BaseState.OnErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseState.OnErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.BaseState.OnErrors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Transition != nil {
+		b = append(b, `"transition":`...)
+		if b, err = t.BaseState.Transition.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.StateDataFilter != nil {
+		b = append(b, `"stateDataFilter":`...)
+		if b, err = t.BaseState.StateDataFilter.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseState.CompensatedBy) != 0 {
+		b = append(b, `"compensatedBy":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.CompensatedBy)
+		b = append(b, ',')
+	}
+	if t.BaseState.UsedForCompensation {
+		b = append(b, `"usedForCompensation":`...)
+		b = jsoncodec.AppendBool(b, bool(t.BaseState.UsedForCompensation))
+		b = append(b, ',')
+	}
+	if t.BaseState.End != nil {
+		b = append(b, `"end":`...)
+		if b, err = t.BaseState.End.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Metadata != nil {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseState.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"defaultCondition":`...)
+	if b, err = t.DefaultCondition.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// decodeJSON decodes the BaseSwitchState like encoding/json, the decoder failing on what it doesn't handle
+func (t *BaseSwitchState) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "id":
+			d.String(&t.BaseState.ID)
+		case "name":
+			d.String(&t.BaseState.Name)
+		case "type":
+			d.String((*string)(&t.BaseState.Type))
+		case "onErrors":
+			if d.Null() {
+				t.BaseState.OnErrors = nil
+			} else {
+				s := t.BaseState.OnErrors
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, OnError{})
+					}
+					s[i].decodeJSON(d)
+					i++
+				}
+				if s == nil {
+					s = []OnError{}
+				}
+				t.BaseState.OnErrors = s[:i]
+			}
+		case "transition":
+			if d.Null() {
+				t.BaseState.Transition = nil
+			} else {
+				if t.BaseState.Transition == nil {
+					t.BaseState.Transition = new(Transition)
+				}
+				d.Unmarshaler(t.BaseState.Transition)
+			}
+		case "stateDataFilter":
+			if d.Null() {
+				t.BaseState.StateDataFilter = nil
+			} else {
+				if t.BaseState.StateDataFilter == nil {
+					t.BaseState.StateDataFilter = new(StateDataFilter)
+				}
+				t.BaseState.StateDataFilter.decodeJSON(d)
+			}
+		case "compensatedBy":
+			d.String(&t.BaseState.CompensatedBy)
+		case "usedForCompensation":
+			d.Bool(&t.BaseState.UsedForCompensation)
+		case "end":
+			if d.Null() {
+				t.BaseState.End = nil
+			} else {
+				if t.BaseState.End == nil {
+					t.BaseState.End = new(End)
+				}
+				d.Unmarshaler(t.BaseState.End)
+			}
+		case "metadata":
+			d.Value(&t.BaseState.Metadata)
+		case "defaultCondition":
+			t.DefaultCondition.decodeJSON(d)
+		default:
+			d.Unknown(jsonKeysBaseSwitchState)
+		}
+	}
+}
+
+// jsonKeysBaseSwitchState keys of the properties of the BaseSwitchState
+var jsonKeysBaseSwitchState = []string{"id", "name", "type", "onErrors", "transition", "stateDataFilter", "compensatedBy", "usedForCompensation", "end", "metadata", "defaultCondition"}
+
+// appendJSON appends the JSON of the BaseWorkflow like encoding/json
+func (t *BaseWorkflow) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"id":`...)
+	b = jsoncodec.AppendString(b, t.ID)
+	b = append(b, ',')
+	if len(t.Key) != 0 {
+		b = append(b, `"key":`...)
+		b = jsoncodec.AppendString(b, t.Key)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.Name)
+	b = append(b, ',')
+	if len(t.Description) != 0 {
+		b = append(b, `"description":`...)
+		b = jsoncodec.AppendString(b, t.Description)
+		b = append(b, ',')
+	}
+	b = append(b, `"version":`...)
+	b = jsoncodec.AppendString(b, t.Version)
+	b = append(b, ',')
+	b = append(b, `"start":`...)
+	if b, err = t.Start.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.Annotations) != 0 {
+		b = append(b, `"annotations":`...)
+		if t.Annotations == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.Annotations {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = jsoncodec.AppendString(b, t.Annotations[i])
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.DataInputSchema != nil {
+		b = append(b, `"dataInputSchema":`...)
+		if b, err = t.DataInputSchema.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.SpecVersion) != 0 {
+		b = append(b, `"specVersion":`...)
+		b = jsoncodec.AppendString(b, t.SpecVersion)
+		b = append(b, ',')
+	}
+	if len(t.Secrets) != 0 {
+		b = append(b, `"secrets":`...)
+		if t.Secrets == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.Secrets {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = jsoncodec.AppendString(b, t.Secrets[i])
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.Constants != nil {
+		b = append(b, `"constants":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Constants); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.ExpressionLang) != 0 {
+		b = append(b, `"expressionLang":`...)
+		b = jsoncodec.AppendString(b, t.ExpressionLang)
+		b = append(b, ',')
+	}
+	if t.Timeouts != nil {
+		b = append(b, `"timeouts":`...)
+		if b, err = t.Timeouts.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.Errors) != 0 {
+		b = append(b, `"errors":`...)
+		if t.Errors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.Errors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.Errors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.KeepActive {
+		b = append(b, `"keepActive":`...)
+		b = jsoncodec.AppendBool(b, bool(t.KeepActive))
+		b = append(b, ',')
+	}
+	if len(t.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.AutoRetries {
+		b = append(b, `"autoRetries":`...)
+		b = jsoncodec.AppendBool(b, bool(t.AutoRetries))
+		b = append(b, ',')
+	}
+	b = append(b, `"auth":`...)
+	if b, err = jsoncodec.AppendValue(b, t.Auth); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// decodeJSON decodes the BaseWorkflow like encoding/json, the decoder failing on what it doesn't handle
+func (t *BaseWorkflow) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "id":
+			d.String(&t.ID)
+		case "key":
+			d.String(&t.Key)
+		case "name":
+			d.String(&t.Name)
+		case "description":
+			d.String(&t.Description)
+		case "version":
+			d.String(&t.Version)
+		case "start":
+			if d.Null() {
+				t.Start = nil
+			} else {
+				if t.Start == nil {
+					t.Start = new(Start)
+				}
+				d.Unmarshaler(t.Start)
+			}
+		case "annotations":
+			if d.Null() {
+				t.Annotations = nil
+			} else {
+				s := t.Annotations
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, "")
+					}
+					d.String(&s[i])
+					i++
+				}
+				if s == nil {
+					s = []string{}
+				}
+				t.Annotations = s[:i]
+			}
+		case "dataInputSchema":
+			if d.Null() {
+				t.DataInputSchema = nil
+			} else {
+				if t.DataInputSchema == nil {
+					t.DataInputSchema = new(DataInputSchema)
+				}
+				d.Unmarshaler(t.DataInputSchema)
+			}
+		case "specVersion":
+			d.String(&t.SpecVersion)
+		case "secrets":
+			d.Unmarshaler(&t.Secrets)
+		case "constants":
+			if d.Null() {
+				t.Constants = nil
+			} else {
+				if t.Constants == nil {
+					t.Constants = new(Constants)
+				}
+				d.Unmarshaler(t.Constants)
+			}
+		case "expressionLang":
+			d.String(&t.ExpressionLang)
+		case "timeouts":
+			if d.Null() {
+				t.Timeouts = nil
+			} else {
+				if t.Timeouts == nil {
+					t.Timeouts = new(Timeouts)
+				}
+				d.Unmarshaler(t.Timeouts)
+			}
+		case "errors":
+			if d.Null() {
+				t.Errors = nil
+			} else {
+				s := t.Errors
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, Error{})
+					}
+					s[i].decodeJSON(d)
+					i++
+				}
+				if s == nil {
+					s = []Error{}
+				}
+				t.Errors = s[:i]
+			}
+		case "keepActive":
+			d.Bool(&t.KeepActive)
+		case "metadata":
+			d.Value(&t.Metadata)
+		case "autoRetries":
+			d.Bool(&t.AutoRetries)
+		case "auth":
+			d.Unmarshaler(&t.Auth)
+		default:
+			d.Unknown(jsonKeysBaseWorkflow)
+		}
+	}
+}
+
+// jsonKeysBaseWorkflow keys of the properties of the BaseWorkflow
+var jsonKeysBaseWorkflow = []string{"id", "key", "name", "description", "version", "start", "annotations", "dataInputSchema", "specVersion", "secrets", "constants", "expressionLang", "timeouts", "errors", "keepActive", "metadata", "autoRetries", "auth"}
+
+// MarshalJSON implements json.Marshaler
+func (t BasicAuthProperties) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the BasicAuthProperties like encoding/json
+func (t *BasicAuthProperties) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseAuthProperties.Common.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseAuthProperties.Common.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseAuthProperties.Secret) != 0 {
+		b = append(b, `"secret":`...)
+		b = jsoncodec.AppendString(b, t.BaseAuthProperties.Secret)
+		b = append(b, ',')
+	}
+	b = append(b, `"username":`...)
+	b = jsoncodec.AppendString(b, t.Username)
+	b = append(b, ',')
+	b = append(b, `"password":`...)
+	b = jsoncodec.AppendString(b, t.Password)
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t BearerAuthProperties) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the BearerAuthProperties like encoding/json
+func (t *BearerAuthProperties) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseAuthProperties.Common.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseAuthProperties.Common.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseAuthProperties.Secret) != 0 {
+		b = append(b, `"secret":`...)
+		b = jsoncodec.AppendString(b, t.BaseAuthProperties.Secret)
+		b = append(b, ',')
+	}
+	b = append(b, `"token":`...)
+	b = jsoncodec.AppendString(b, t.Token)
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t Branch) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Branch like encoding/json
+func (t *Branch) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.Name)
+	b = append(b, ',')
+	b = append(b, `"actions":`...)
+	if t.Actions == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range t.Actions {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if b, err = t.Actions[i].appendJSON(b); err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	b = append(b, `"timeouts":`...)
+	if b, err = t.Timeouts.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Branch) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain Branch
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the Branch like encoding/json, the decoder failing on what it doesn't handle
+func (t *Branch) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "name":
+			d.String(&t.Name)
+		case "actions":
+			if d.Null() {
+				t.Actions = nil
+			} else {
+				s := t.Actions
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, Action{})
+					}
+					s[i].decodeJSON(d)
+					i++
+				}
+				if s == nil {
+					s = []Action{}
+				}
+				t.Actions = s[:i]
+			}
+		case "timeouts":
+			t.Timeouts.decodeJSON(d)
+		default:
+			d.Unknown(jsonKeysBranch)
+		}
+	}
+}
+
+// jsonKeysBranch keys of the properties of the Branch
+var jsonKeysBranch = []string{"name", "actions", "timeouts"}
+
+// MarshalJSON implements json.Marshaler
+func (t BranchTimeouts) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the BranchTimeouts like encoding/json
+func (t *BranchTimeouts) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '{')
+	if len(t.ActionExecTimeout) != 0 {
+		b = append(b, `"actionExecTimeout":`...)
+		b = jsoncodec.AppendString(b, t.ActionExecTimeout)
+		b = append(b, ',')
+	}
+	if len(t.BranchExecTimeout) != 0 {
+		b = append(b, `"branchExecTimeout":`...)
+		b = jsoncodec.AppendString(b, t.BranchExecTimeout)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *BranchTimeouts) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain BranchTimeouts
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the BranchTimeouts like encoding/json, the decoder failing on what it doesn't handle
+func (t *BranchTimeouts) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "actionExecTimeout":
+			d.String(&t.ActionExecTimeout)
+		case "branchExecTimeout":
+			d.String(&t.BranchExecTimeout)
+		default:
+			d.Unknown(jsonKeysBranchTimeouts)
+		}
+	}
+}
+
+// jsonKeysBranchTimeouts keys of the properties of the BranchTimeouts
+var jsonKeysBranchTimeouts = []string{"actionExecTimeout", "branchExecTimeout"}
+
+// MarshalJSON implements json.Marshaler
+func (t CallbackState) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the CallbackState like encoding/json
+func (t *CallbackState) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseState.ID) != 0 {
+		b = append(b, `"id":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.ID)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.BaseState.Name)
+	b = append(b, ',')
+	b = append(b, `"type":`...)
+	b = jsoncodec.AppendString(b, string(t.BaseState.Type))
+	b = append(b, ',')
+	if len(t.BaseState.OnErrors) != 0 {
+		b = append(b, `"onErrors":`...)
+		if t.BaseState.OnErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseState.OnErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.BaseState.OnErrors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Transition != nil {
+		b = append(b, `"transition":`...)
+		if b, err = t.BaseState.Transition.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.StateDataFilter != nil {
+		b = append(b, `"stateDataFilter":`...)
+		if b, err = t.BaseState.StateDataFilter.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseState.CompensatedBy) != 0 {
+		b = append(b, `"compensatedBy":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.CompensatedBy)
+		b = append(b, ',')
+	}
+	if t.BaseState.UsedForCompensation {
+		b = append(b, `"usedForCompensation":`...)
+		b = jsoncodec.AppendBool(b, bool(t.BaseState.UsedForCompensation))
+		b = append(b, ',')
+	}
+	if t.BaseState.End != nil {
+		b = append(b, `"end":`...)
+		if b, err = t.BaseState.End.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Metadata != nil {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseState.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"action":`...)
+	if b, err = t.Action.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, `"eventRef":`...)
+	b = jsoncodec.AppendString(b, t.EventRef)
+	b = append(b, ',')
+	b = append(b, `"timeouts":`...)
+	if b, err = t.Timeouts.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, `"eventDataFilter":`...)
+	if b, err = t.EventDataFilter.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *CallbackState) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain CallbackState
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the CallbackState like encoding/json, the decoder failing on what it doesn't handle
+func (t *CallbackState) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "id":
+			d.String(&t.BaseState.ID)
+		case "name":
+			d.String(&t.BaseState.Name)
+		case "type":
+			d.String((*string)(&t.BaseState.Type))
+		case "onErrors":
+			if d.Null() {
+				t.BaseState.OnErrors = nil
+			} else {
+				s := t.BaseState.OnErrors
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, OnError{})
+					}
+					s[i].decodeJSON(d)
+					i++
+				}
+				if s == nil {
+					s = []OnError{}
+				}
+				t.BaseState.OnErrors = s[:i]
+			}
+		case "transition":
+			if d.Null() {
+				t.BaseState.Transition = nil
+			} else {
+				if t.BaseState.Transition == nil {
+					t.BaseState.Transition = new(Transition)
+				}
+				d.Unmarshaler(t.BaseState.Transition)
+			}
+		case "stateDataFilter":
+			if d.Null() {
+				t.BaseState.StateDataFilter = nil
+			} else {
+				if t.BaseState.StateDataFilter == nil {
+					t.BaseState.StateDataFilter = new(StateDataFilter)
+				}
+				t.BaseState.StateDataFilter.decodeJSON(d)
+			}
+		case "compensatedBy":
+			d.String(&t.BaseState.CompensatedBy)
+		case "usedForCompensation":
+			d.Bool(&t.BaseState.UsedForCompensation)
+		case "end":
+			if d.Null() {
+				t.BaseState.End = nil
+			} else {
+				if t.BaseState.End == nil {
+					t.BaseState.End = new(End)
+				}
+				d.Unmarshaler(t.BaseState.End)
+			}
+		case "metadata":
+			d.Value(&t.BaseState.Metadata)
+		case "action":
+			t.Action.decodeJSON(d)
+		case "eventRef":
+			d.String(&t.EventRef)
+		case "timeouts":
+			t.Timeouts.decodeJSON(d)
+		case "eventDataFilter":
+			t.EventDataFilter.decodeJSON(d)
+		default:
+			d.Unknown(jsonKeysCallbackState)
+		}
+	}
+}
+
+// jsonKeysCallbackState keys of the properties of the CallbackState
+var jsonKeysCallbackState = []string{"id", "name", "type", "onErrors", "transition", "stateDataFilter", "compensatedBy", "usedForCompensation", "end", "metadata", "action", "eventRef", "timeouts", "eventDataFilter"}
+
+// MarshalJSON implements json.Marshaler
+func (t CallbackStateTimeout) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the CallbackStateTimeout like encoding/json
+func (t *CallbackStateTimeout) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"stateExecTimeout":`...)
+	if b, err = t.StateExecTimeout.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.ActionExecTimeout) != 0 {
+		b = append(b, `"actionExecTimeout":`...)
+		b = jsoncodec.AppendString(b, t.ActionExecTimeout)
+		b = append(b, ',')
+	}
+	if len(t.EventTimeout) != 0 {
+		b = append(b, `"eventTimeout":`...)
+		b = jsoncodec.AppendString(b, t.EventTimeout)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *CallbackStateTimeout) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain CallbackStateTimeout
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the CallbackStateTimeout like encoding/json, the decoder failing on what it doesn't handle
+func (t *CallbackStateTimeout) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "stateExecTimeout":
+			d.Unmarshaler(&t.StateExecTimeout)
+		case "actionExecTimeout":
+			d.String(&t.ActionExecTimeout)
+		case "eventTimeout":
+			d.String(&t.EventTimeout)
+		default:
+			d.Unknown(jsonKeysCallbackStateTimeout)
+		}
+	}
+}
+
+// jsonKeysCallbackStateTimeout keys of the properties of the CallbackStateTimeout
+var jsonKeysCallbackStateTimeout = []string{"stateExecTimeout", "actionExecTimeout", "eventTimeout"}
+
+// appendJSON appends the JSON of the Common like encoding/json
+func (t *Common) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// decodeJSON decodes the Common like encoding/json, the decoder failing on what it doesn't handle
+func (t *Common) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "metadata":
+			d.Value(&t.Metadata)
+		default:
+			d.Unknown(jsonKeysCommon)
+		}
+	}
+}
+
+// jsonKeysCommon keys of the properties of the Common
+var jsonKeysCommon = []string{"metadata"}
+
+// MarshalJSON implements json.Marshaler
+func (t ContinueAs) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the ContinueAs like encoding/json
+func (t *ContinueAs) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"workflowId":`...)
+	b = jsoncodec.AppendString(b, t.WorkflowRef.WorkflowID)
+	b = append(b, ',')
+	if len(t.WorkflowRef.Version) != 0 {
+		b = append(b, `"version":`...)
+		b = jsoncodec.AppendString(b, t.WorkflowRef.Version)
+		b = append(b, ',')
+	}
+	if t.Data != nil {
+		b = append(b, `"data":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Data); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"workflowExecTimeout":`...)
+	if b, err = t.WorkflowExecTimeout.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t Correlation) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Correlation like encoding/json
+func (t *Correlation) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '{')
+	b = append(b, `"contextAttributeName":`...)
+	b = jsoncodec.AppendString(b, t.ContextAttributeName)
+	b = append(b, ',')
+	if len(t.ContextAttributeValue) != 0 {
+		b = append(b, `"contextAttributeValue":`...)
+		b = jsoncodec.AppendString(b, t.ContextAttributeValue)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Correlation) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain Correlation
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the Correlation like encoding/json, the decoder failing on what it doesn't handle
+func (t *Correlation) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "contextAttributeName":
+			d.String(&t.ContextAttributeName)
+		case "contextAttributeValue":
+			d.String(&t.ContextAttributeValue)
+		default:
+			d.Unknown(jsonKeysCorrelation)
+		}
+	}
+}
+
+// jsonKeysCorrelation keys of the properties of the Correlation
+var jsonKeysCorrelation = []string{"contextAttributeName", "contextAttributeValue"}
+
+// MarshalJSON implements json.Marshaler
+func (t Cron) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Cron like encoding/json
+func (t *Cron) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '{')
+	b = append(b, `"expression":`...)
+	b = jsoncodec.AppendString(b, t.Expression)
+	b = append(b, ',')
+	if len(t.ValidUntil) != 0 {
+		b = append(b, `"validUntil":`...)
+		b = jsoncodec.AppendString(b, t.ValidUntil)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t DataBasedSwitchState) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the DataBasedSwitchState like encoding/json
+func (t *DataBasedSwitchState) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseSwitchState.BaseState.ID) != 0 {
+		b = append(b, `"id":`...)
+		b = jsoncodec.AppendString(b, t.BaseSwitchState.BaseState.ID)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.BaseSwitchState.BaseState.Name)
+	b = append(b, ',')
+	b = append(b, `"type":`...)
+	b = jsoncodec.AppendString(b, string(t.BaseSwitchState.BaseState.Type))
+	b = append(b, ',')
+	if len(t.BaseSwitchState.BaseState.OnErrors) != 0 {
+		b = append(b, `"onErrors":`...)
+		if t.BaseSwitchState.BaseState.OnErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseSwitchState.BaseState.OnErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.BaseSwitchState.BaseState.OnErrors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseSwitchState.BaseState.Transition != nil {
+		b = append(b, `"transition":`...)
+		if b, err = t.BaseSwitchState.BaseState.Transition.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseSwitchState.BaseState.StateDataFilter != nil {
+		b = append(b, `"stateDataFilter":`...)
+		if b, err = t.BaseSwitchState.BaseState.StateDataFilter.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseSwitchState.BaseState.CompensatedBy) != 0 {
+		b = append(b, `"compensatedBy":`...)
+		b = jsoncodec.AppendString(b, t.BaseSwitchState.BaseState.CompensatedBy)
+		b = append(b, ',')
+	}
+	if t.BaseSwitchState.BaseState.UsedForCompensation {
+		b = append(b, `"usedForCompensation":`...)
+		b = jsoncodec.AppendBool(b, bool(t.BaseSwitchState.BaseState.UsedForCompensation))
+		b = append(b, ',')
+	}
+	if t.BaseSwitchState.BaseState.End != nil {
+		b = append(b, `"end":`...)
+		if b, err = t.BaseSwitchState.BaseState.End.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseSwitchState.BaseState.Metadata != nil {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseSwitchState.BaseState.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"defaultCondition":`...)
+	if b, err = t.BaseSwitchState.DefaultCondition.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, `"dataConditions":`...)
+	if t.DataConditions == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range t.DataConditions {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if b, err = appendJSONValue(b, t.DataConditions[i]); err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	b = append(b, `"timeouts":`...)
+	if b, err = t.Timeouts.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t DataBasedSwitchStateTimeout) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the DataBasedSwitchStateTimeout like encoding/json
+func (t *DataBasedSwitchStateTimeout) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"stateExecTimeout":`...)
+	if b, err = t.StateExecTimeout.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *DataBasedSwitchStateTimeout) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain DataBasedSwitchStateTimeout
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the DataBasedSwitchStateTimeout like encoding/json, the decoder failing on what it doesn't handle
+func (t *DataBasedSwitchStateTimeout) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "stateExecTimeout":
+			d.Unmarshaler(&t.StateExecTimeout)
+		default:
+			d.Unknown(jsonKeysDataBasedSwitchStateTimeout)
+		}
+	}
+}
+
+// jsonKeysDataBasedSwitchStateTimeout keys of the properties of the DataBasedSwitchStateTimeout
+var jsonKeysDataBasedSwitchStateTimeout = []string{"stateExecTimeout"}
+
+// MarshalJSON implements json.Marshaler
+func (t DataInputSchema) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the DataInputSchema like encoding/json
+func (t *DataInputSchema) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"schema":`...)
+	b = jsoncodec.AppendString(b, t.Schema)
+	b = append(b, ',')
+	b = append(b, `"failOnValidationErrors":`...)
+	if b, err = jsoncodec.AppendValue(b, t.FailOnValidationErrors); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t DefaultCondition) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the DefaultCondition like encoding/json
+func (t *DefaultCondition) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"transition":`...)
+	if b, err = t.Transition.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, `"end":`...)
+	if b, err = t.End.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *DefaultCondition) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain DefaultCondition
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the DefaultCondition like encoding/json, the decoder failing on what it doesn't handle
+func (t *DefaultCondition) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "transition":
+			d.Unmarshaler(&t.Transition)
+		case "end":
+			d.Unmarshaler(&t.End)
+		default:
+			d.Unknown(jsonKeysDefaultCondition)
+		}
+	}
+}
+
+// jsonKeysDefaultCondition keys of the properties of the DefaultCondition
+var jsonKeysDefaultCondition = []string{"transition", "end"}
+
+// MarshalJSON implements json.Marshaler
+func (t DelayState) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the DelayState like encoding/json
+func (t *DelayState) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseState.ID) != 0 {
+		b = append(b, `"id":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.ID)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.BaseState.Name)
+	b = append(b, ',')
+	b = append(b, `"type":`...)
+	b = jsoncodec.AppendString(b, string(t.BaseState.Type))
+	b = append(b, ',')
+	if len(t.BaseState.OnErrors) != 0 {
+		b = append(b, `"onErrors":`...)
+		if t.BaseState.OnErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseState.OnErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.BaseState.OnErrors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Transition != nil {
+		b = append(b, `"transition":`...)
+		if b, err = t.BaseState.Transition.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.StateDataFilter != nil {
+		b = append(b, `"stateDataFilter":`...)
+		if b, err = t.BaseState.StateDataFilter.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseState.CompensatedBy) != 0 {
+		b = append(b, `"compensatedBy":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.CompensatedBy)
+		b = append(b, ',')
+	}
+	if t.BaseState.UsedForCompensation {
+		b = append(b, `"usedForCompensation":`...)
+		b = jsoncodec.AppendBool(b, bool(t.BaseState.UsedForCompensation))
+		b = append(b, ',')
+	}
+	if t.BaseState.End != nil {
+		b = append(b, `"end":`...)
+		if b, err = t.BaseState.End.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Metadata != nil {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseState.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"timeDelay":`...)
+	b = jsoncodec.AppendString(b, t.TimeDelay)
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *DelayState) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain DelayState
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the DelayState like encoding/json, the decoder failing on what it doesn't handle
+func (t *DelayState) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "id":
+			d.String(&t.BaseState.ID)
+		case "name":
+			d.String(&t.BaseState.Name)
+		case "type":
+			d.String((*string)(&t.BaseState.Type))
+		case "onErrors":
+			if d.Null() {
+				t.BaseState.OnErrors = nil
+			} else {
+				s := t.BaseState.OnErrors
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, OnError{})
+					}
+					s[i].decodeJSON(d)
+					i++
+				}
+				if s == nil {
+					s = []OnError{}
+				}
+				t.BaseState.OnErrors = s[:i]
+			}
+		case "transition":
+			if d.Null() {
+				t.BaseState.Transition = nil
+			} else {
+				if t.BaseState.Transition == nil {
+					t.BaseState.Transition = new(Transition)
+				}
+				d.Unmarshaler(t.BaseState.Transition)
+			}
+		case "stateDataFilter":
+			if d.Null() {
+				t.BaseState.StateDataFilter = nil
+			} else {
+				if t.BaseState.StateDataFilter == nil {
+					t.BaseState.StateDataFilter = new(StateDataFilter)
+				}
+				t.BaseState.StateDataFilter.decodeJSON(d)
+			}
+		case "compensatedBy":
+			d.String(&t.BaseState.CompensatedBy)
+		case "usedForCompensation":
+			d.Bool(&t.BaseState.UsedForCompensation)
+		case "end":
+			if d.Null() {
+				t.BaseState.End = nil
+			} else {
+				if t.BaseState.End == nil {
+					t.BaseState.End = new(End)
+				}
+				d.Unmarshaler(t.BaseState.End)
+			}
+		case "metadata":
+			d.Value(&t.BaseState.Metadata)
+		case "timeDelay":
+			d.String(&t.TimeDelay)
+		default:
+			d.Unknown(jsonKeysDelayState)
+		}
+	}
+}
+
+// jsonKeysDelayState keys of the properties of the DelayState
+var jsonKeysDelayState = []string{"id", "name", "type", "onErrors", "transition", "stateDataFilter", "compensatedBy", "usedForCompensation", "end", "metadata", "timeDelay"}
+
+// MarshalJSON implements json.Marshaler
+func (t End) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the End like encoding/json
+func (t *End) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if t.Terminate {
+		b = append(b, `"terminate":`...)
+		b = jsoncodec.AppendBool(b, bool(t.Terminate))
+		b = append(b, ',')
+	}
+	if len(t.ProduceEvents) != 0 {
+		b = append(b, `"produceEvents":`...)
+		if t.ProduceEvents == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.ProduceEvents {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.ProduceEvents[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.Compensate {
+		b = append(b, `"compensate":`...)
+		b = jsoncodec.AppendBool(b, bool(t.Compensate))
+		b = append(b, ',')
+	}
+	if t.ContinueAs != nil {
+		b = append(b, `"continueAs":`...)
+		if b, err = t.ContinueAs.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t EndDataCondition) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the EndDataCondition like encoding/json
+func (t *EndDataCondition) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseDataCondition.Name) != 0 {
+		b = append(b, `"name":`...)
+		b = jsoncodec.AppendString(b, t.BaseDataCondition.Name)
+		b = append(b, ',')
+	}
+	b = append(b, `"condition":`...)
+	b = jsoncodec.AppendString(b, t.BaseDataCondition.Condition)
+	b = append(b, ',')
+	if len(t.BaseDataCondition.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseDataCondition.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"end":`...)
+	if b, err = t.End.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *EndDataCondition) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain EndDataCondition
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the EndDataCondition like encoding/json, the decoder failing on what it doesn't handle
+func (t *EndDataCondition) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "name":
+			d.String(&t.BaseDataCondition.Name)
+		case "condition":
+			d.String(&t.BaseDataCondition.Condition)
+		case "metadata":
+			d.Value(&t.BaseDataCondition.Metadata)
+		case "end":
+			d.Unmarshaler(&t.End)
+		default:
+			d.Unknown(jsonKeysEndDataCondition)
+		}
+	}
+}
+
+// jsonKeysEndDataCondition keys of the properties of the EndDataCondition
+var jsonKeysEndDataCondition = []string{"name", "condition", "metadata", "end"}
+
+// MarshalJSON implements json.Marshaler
+func (t EndEventCondition) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the EndEventCondition like encoding/json
+func (t *EndEventCondition) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseEventCondition.Name) != 0 {
+		b = append(b, `"name":`...)
+		b = jsoncodec.AppendString(b, t.BaseEventCondition.Name)
+		b = append(b, ',')
+	}
+	b = append(b, `"eventRef":`...)
+	b = jsoncodec.AppendString(b, t.BaseEventCondition.EventRef)
+	b = append(b, ',')
+	b = append(b, `"eventDataFilter":`...)
+	if b, err = t.BaseEventCondition.EventDataFilter.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.BaseEventCondition.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseEventCondition.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"end":`...)
+	if b, err = t.End.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *EndEventCondition) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain EndEventCondition
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the EndEventCondition like encoding/json, the decoder failing on what it doesn't handle
+func (t *EndEventCondition) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "name":
+			d.String(&t.BaseEventCondition.Name)
+		case "eventRef":
+			d.String(&t.BaseEventCondition.EventRef)
+		case "eventDataFilter":
+			t.BaseEventCondition.EventDataFilter.decodeJSON(d)
+		case "metadata":
+			d.Value(&t.BaseEventCondition.Metadata)
+		case "end":
+			d.Unmarshaler(&t.End)
+		default:
+			d.Unknown(jsonKeysEndEventCondition)
+		}
+	}
+}
+
+// jsonKeysEndEventCondition keys of the properties of the EndEventCondition
+var jsonKeysEndEventCondition = []string{"name", "eventRef", "eventDataFilter", "metadata", "end"}
+
+// MarshalJSON implements json.Marshaler
+func (t Error) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Error like encoding/json
+func (t *Error) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '{')
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.Name)
+	b = append(b, ',')
+	if len(t.Code) != 0 {
+		b = append(b, `"code":`...)
+		b = jsoncodec.AppendString(b, t.Code)
+		b = append(b, ',')
+	}
+	if len(t.Description) != 0 {
+		b = append(b, `"description":`...)
+		b = jsoncodec.AppendString(b, t.Description)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Error) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain Error
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the Error like encoding/json, the decoder failing on what it doesn't handle
+func (t *Error) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "name":
+			d.String(&t.Name)
+		case "code":
+			d.String(&t.Code)
+		case "description":
+			d.String(&t.Description)
+		default:
+			d.Unknown(jsonKeysError)
+		}
+	}
+}
+
+// jsonKeysError keys of the properties of the Error
+var jsonKeysError = []string{"name", "code", "description"}
+
+// MarshalJSON implements json.Marshaler
+func (t Event) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Event like encoding/json
+func (t *Event) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.Common.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Common.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.Name)
+	b = append(b, ',')
+	if len(t.Source) != 0 {
+		b = append(b, `"source":`...)
+		b = jsoncodec.AppendString(b, t.Source)
+		b = append(b, ',')
+	}
+	b = append(b, `"type":`...)
+	b = jsoncodec.AppendString(b, t.Type)
+	b = append(b, ',')
+	if len(t.Kind) != 0 {
+		b = append(b, `"kind":`...)
+		b = jsoncodec.AppendString(b, string(t.Kind))
+		b = append(b, ',')
+	}
+	if t.DataOnly {
+		b = append(b, `"dataOnly":`...)
+		b = jsoncodec.AppendBool(b, bool(t.DataOnly))
+		b = append(b, ',')
+	}
+	if len(t.Correlation) != 0 {
+		b = append(b, `"correlation":`...)
+		if t.Correlation == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.Correlation {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.Correlation[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Event) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain Event
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the Event like encoding/json, the decoder failing on what it doesn't handle
+func (t *Event) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "metadata":
+			d.Value(&t.Common.Metadata)
+		case "name":
+			d.String(&t.Name)
+		case "source":
+			d.String(&t.Source)
+		case "type":
+			d.String(&t.Type)
+		case "kind":
+			d.String((*string)(&t.Kind))
+		case "dataOnly":
+			d.Bool(&t.DataOnly)
+		case "correlation":
+			if d.Null() {
+				t.Correlation = nil
+			} else {
+				s := t.Correlation
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, Correlation{})
+					}
+					s[i].decodeJSON(d)
+					i++
+				}
+				if s == nil {
+					s = []Correlation{}
+				}
+				t.Correlation = s[:i]
+			}
+		default:
+			d.Unknown(jsonKeysEvent)
+		}
+	}
+}
+
+// jsonKeysEvent keys of the properties of the Event
+var jsonKeysEvent = []string{"metadata", "name", "source", "type", "kind", "dataOnly", "correlation"}
+
+// MarshalJSON implements json.Marshaler
+func (t EventBasedSwitchState) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the EventBasedSwitchState like encoding/json
+func (t *EventBasedSwitchState) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseSwitchState.BaseState.ID) != 0 {
+		b = append(b, `"id":`...)
+		b = jsoncodec.AppendString(b, t.BaseSwitchState.BaseState.ID)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.BaseSwitchState.BaseState.Name)
+	b = append(b, ',')
+	b = append(b, `"type":`...)
+	b = jsoncodec.AppendString(b, string(t.BaseSwitchState.BaseState.Type))
+	b = append(b, ',')
+	if len(t.BaseSwitchState.BaseState.OnErrors) != 0 {
+		b = append(b, `"onErrors":`...)
+		if t.BaseSwitchState.BaseState.OnErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseSwitchState.BaseState.OnErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.BaseSwitchState.BaseState.OnErrors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseSwitchState.BaseState.Transition != nil {
+		b = append(b, `"transition":`...)
+		if b, err = t.BaseSwitchState.BaseState.Transition.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseSwitchState.BaseState.StateDataFilter != nil {
+		b = append(b, `"stateDataFilter":`...)
+		if b, err = t.BaseSwitchState.BaseState.StateDataFilter.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseSwitchState.BaseState.CompensatedBy) != 0 {
+		b = append(b, `"compensatedBy":`...)
+		b = jsoncodec.AppendString(b, t.BaseSwitchState.BaseState.CompensatedBy)
+		b = append(b, ',')
+	}
+	if t.BaseSwitchState.BaseState.UsedForCompensation {
+		b = append(b, `"usedForCompensation":`...)
+		b = jsoncodec.AppendBool(b, bool(t.BaseSwitchState.BaseState.UsedForCompensation))
+		b = append(b, ',')
+	}
+	if t.BaseSwitchState.BaseState.End != nil {
+		b = append(b, `"end":`...)
+		if b, err = t.BaseSwitchState.BaseState.End.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseSwitchState.BaseState.Metadata != nil {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseSwitchState.BaseState.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"defaultCondition":`...)
+	if b, err = t.BaseSwitchState.DefaultCondition.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, `"eventConditions":`...)
+	if t.EventConditions == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range t.EventConditions {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if b, err = appendJSONValue(b, t.EventConditions[i]); err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	b = append(b, `"timeouts":`...)
+	if b, err = t.Timeouts.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t EventBasedSwitchStateTimeout) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the EventBasedSwitchStateTimeout like encoding/json
+func (t *EventBasedSwitchStateTimeout) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"stateExecTimeout":`...)
+	if b, err = t.StateExecTimeout.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.EventTimeout) != 0 {
+		b = append(b, `"eventTimeout":`...)
+		b = jsoncodec.AppendString(b, t.EventTimeout)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *EventBasedSwitchStateTimeout) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain EventBasedSwitchStateTimeout
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the EventBasedSwitchStateTimeout like encoding/json, the decoder failing on what it doesn't handle
+func (t *EventBasedSwitchStateTimeout) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "stateExecTimeout":
+			d.Unmarshaler(&t.StateExecTimeout)
+		case "eventTimeout":
+			d.String(&t.EventTimeout)
+		default:
+			d.Unknown(jsonKeysEventBasedSwitchStateTimeout)
+		}
+	}
+}
+
+// jsonKeysEventBasedSwitchStateTimeout keys of the properties of the EventBasedSwitchStateTimeout
+var jsonKeysEventBasedSwitchStateTimeout = []string{"stateExecTimeout", "eventTimeout"}
+
+// MarshalJSON implements json.Marshaler
+func (t EventDataFilter) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the EventDataFilter like encoding/json
+func (t *EventDataFilter) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '{')
+	if len(t.Data) != 0 {
+		b = append(b, `"data":`...)
+		b = jsoncodec.AppendString(b, t.Data)
+		b = append(b, ',')
+	}
+	if len(t.ToStateData) != 0 {
+		b = append(b, `"toStateData":`...)
+		b = jsoncodec.AppendString(b, t.ToStateData)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *EventDataFilter) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain EventDataFilter
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the EventDataFilter like encoding/json, the decoder failing on what it doesn't handle
+func (t *EventDataFilter) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "data":
+			d.String(&t.Data)
+		case "toStateData":
+			d.String(&t.ToStateData)
+		default:
+			d.Unknown(jsonKeysEventDataFilter)
+		}
+	}
+}
+
+// jsonKeysEventDataFilter keys of the properties of the EventDataFilter
+var jsonKeysEventDataFilter = []string{"data", "toStateData"}
+
+// MarshalJSON implements json.Marshaler
+func (t EventRef) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the EventRef like encoding/json
+func (t *EventRef) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"triggerEventRef":`...)
+	b = jsoncodec.AppendString(b, t.TriggerEventRef)
+	b = append(b, ',')
+	b = append(b, `"resultEventRef":`...)
+	b = jsoncodec.AppendString(b, t.ResultEventRef)
+	b = append(b, ',')
+	if t.Data != nil {
+		b = append(b, `"data":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Data); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.ContextAttributes) != 0 {
+		b = append(b, `"contextAttributes":`...)
+		if b, err = jsoncodec.AppendValue(b, t.ContextAttributes); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t EventState) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the EventState like encoding/json
+func (t *EventState) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseState.ID) != 0 {
+		b = append(b, `"id":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.ID)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.BaseState.Name)
+	b = append(b, ',')
+	b = append(b, `"type":`...)
+	b = jsoncodec.AppendString(b, string(t.BaseState.Type))
+	b = append(b, ',')
+	if len(t.BaseState.OnErrors) != 0 {
+		b = append(b, `"onErrors":`...)
+		if t.BaseState.OnErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseState.OnErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.BaseState.OnErrors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Transition != nil {
+		b = append(b, `"transition":`...)
+		if b, err = t.BaseState.Transition.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.StateDataFilter != nil {
+		b = append(b, `"stateDataFilter":`...)
+		if b, err = t.BaseState.StateDataFilter.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseState.CompensatedBy) != 0 {
+		b = append(b, `"compensatedBy":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.CompensatedBy)
+		b = append(b, ',')
+	}
+	if t.BaseState.UsedForCompensation {
+		b = append(b, `"usedForCompensation":`...)
+		b = jsoncodec.AppendBool(b, bool(t.BaseState.UsedForCompensation))
+		b = append(b, ',')
+	}
+	if t.BaseState.End != nil {
+		b = append(b, `"end":`...)
+		if b, err = t.BaseState.End.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Metadata != nil {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseState.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"exclusive":`...)
+	b = jsoncodec.AppendBool(b, bool(t.Exclusive))
+	b = append(b, ',')
+	b = append(b, `"onEvents":`...)
+	if t.OnEvents == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range t.OnEvents {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if b, err = t.OnEvents[i].appendJSON(b); err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	b = append(b, `"timeouts":`...)
+	if b, err = t.Timeout.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t EventStateTimeout) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the EventStateTimeout like encoding/json
+func (t *EventStateTimeout) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"stateExecTimeout":`...)
+	if b, err = t.StateExecTimeout.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.ActionExecTimeout) != 0 {
+		b = append(b, `"actionExecTimeout":`...)
+		b = jsoncodec.AppendString(b, t.ActionExecTimeout)
+		b = append(b, ',')
+	}
+	if len(t.EventTimeout) != 0 {
+		b = append(b, `"eventTimeout":`...)
+		b = jsoncodec.AppendString(b, t.EventTimeout)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *EventStateTimeout) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain EventStateTimeout
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the EventStateTimeout like encoding/json, the decoder failing on what it doesn't handle
+func (t *EventStateTimeout) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "stateExecTimeout":
+			d.Unmarshaler(&t.StateExecTimeout)
+		case "actionExecTimeout":
+			d.String(&t.ActionExecTimeout)
+		case "eventTimeout":
+			d.String(&t.EventTimeout)
+		default:
+			d.Unknown(jsonKeysEventStateTimeout)
+		}
+	}
+}
+
+// jsonKeysEventStateTimeout keys of the properties of the EventStateTimeout
+var jsonKeysEventStateTimeout = []string{"stateExecTimeout", "actionExecTimeout", "eventTimeout"}
+
+// MarshalJSON implements json.Marshaler
+func (t ForEachState) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the ForEachState like encoding/json
+func (t *ForEachState) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseState.ID) != 0 {
+		b = append(b, `"id":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.ID)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.BaseState.Name)
+	b = append(b, ',')
+	b = append(b, `"type":`...)
+	b = jsoncodec.AppendString(b, string(t.BaseState.Type))
+	b = append(b, ',')
+	if len(t.BaseState.OnErrors) != 0 {
+		b = append(b, `"onErrors":`...)
+		if t.BaseState.OnErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseState.OnErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.BaseState.OnErrors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Transition != nil {
+		b = append(b, `"transition":`...)
+		if b, err = t.BaseState.Transition.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.StateDataFilter != nil {
+		b = append(b, `"stateDataFilter":`...)
+		if b, err = t.BaseState.StateDataFilter.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseState.CompensatedBy) != 0 {
+		b = append(b, `"compensatedBy":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.CompensatedBy)
+		b = append(b, ',')
+	}
+	if t.BaseState.UsedForCompensation {
+		b = append(b, `"usedForCompensation":`...)
+		b = jsoncodec.AppendBool(b, bool(t.BaseState.UsedForCompensation))
+		b = append(b, ',')
+	}
+	if t.BaseState.End != nil {
+		b = append(b, `"end":`...)
+		if b, err = t.BaseState.End.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Metadata != nil {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseState.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"inputCollection":`...)
+	b = jsoncodec.AppendString(b, t.InputCollection)
+	b = append(b, ',')
+	if len(t.OutputCollection) != 0 {
+		b = append(b, `"outputCollection":`...)
+		b = jsoncodec.AppendString(b, t.OutputCollection)
+		b = append(b, ',')
+	}
+	b = append(b, `"iterationParam":`...)
+	b = jsoncodec.AppendString(b, t.IterationParam)
+	b = append(b, ',')
+	b = append(b, `"batchSize":`...)
+	if b, err = jsoncodec.AppendValue(b, t.BatchSize); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.Actions) != 0 {
+		b = append(b, `"actions":`...)
+		if t.Actions == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.Actions {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.Actions[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"timeouts":`...)
+	if b, err = t.Timeouts.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.Mode) != 0 {
+		b = append(b, `"mode":`...)
+		b = jsoncodec.AppendString(b, string(t.Mode))
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t ForEachStateTimeout) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the ForEachStateTimeout like encoding/json
+func (t *ForEachStateTimeout) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"stateExecTimeout":`...)
+	if b, err = t.StateExecTimeout.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.ActionExecTimeout) != 0 {
+		b = append(b, `"actionExecTimeout":`...)
+		b = jsoncodec.AppendString(b, t.ActionExecTimeout)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *ForEachStateTimeout) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain ForEachStateTimeout
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the ForEachStateTimeout like encoding/json, the decoder failing on what it doesn't handle
+func (t *ForEachStateTimeout) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "stateExecTimeout":
+			d.Unmarshaler(&t.StateExecTimeout)
+		case "actionExecTimeout":
+			d.String(&t.ActionExecTimeout)
+		default:
+			d.Unknown(jsonKeysForEachStateTimeout)
+		}
+	}
+}
+
+// jsonKeysForEachStateTimeout keys of the properties of the ForEachStateTimeout
+var jsonKeysForEachStateTimeout = []string{"stateExecTimeout", "actionExecTimeout"}
+
+// MarshalJSON implements json.Marshaler
+func (t Function) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Function like encoding/json
+func (t *Function) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.Common.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Common.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.Name)
+	b = append(b, ',')
+	b = append(b, `"operation":`...)
+	b = jsoncodec.AppendString(b, t.Operation)
+	b = append(b, ',')
+	if len(t.Type) != 0 {
+		b = append(b, `"type":`...)
+		b = jsoncodec.AppendString(b, string(t.Type))
+		b = append(b, ',')
+	}
+	if len(t.AuthRef) != 0 {
+		b = append(b, `"authRef":`...)
+		b = jsoncodec.AppendString(b, t.AuthRef)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Function) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain Function
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the Function like encoding/json, the decoder failing on what it doesn't handle
+func (t *Function) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "metadata":
+			d.Value(&t.Common.Metadata)
+		case "name":
+			d.String(&t.Name)
+		case "operation":
+			d.String(&t.Operation)
+		case "type":
+			d.String((*string)(&t.Type))
+		case "authRef":
+			d.String(&t.AuthRef)
+		default:
+			d.Unknown(jsonKeysFunction)
+		}
+	}
+}
+
+// jsonKeysFunction keys of the properties of the Function
+var jsonKeysFunction = []string{"metadata", "name", "operation", "type", "authRef"}
+
+// MarshalJSON implements json.Marshaler
+func (t FunctionRef) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the FunctionRef like encoding/json
+func (t *FunctionRef) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"refName":`...)
+	b = jsoncodec.AppendString(b, t.RefName)
+	b = append(b, ',')
+	if len(t.Arguments) != 0 {
+		b = append(b, `"arguments":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Arguments); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.SelectionSet) != 0 {
+		b = append(b, `"selectionSet":`...)
+		b = jsoncodec.AppendString(b, t.SelectionSet)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t InjectState) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the InjectState like encoding/json
+func (t *InjectState) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseState.ID) != 0 {
+		b = append(b, `"id":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.ID)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.BaseState.Name)
+	b = append(b, ',')
+	b = append(b, `"type":`...)
+	b = jsoncodec.AppendString(b, string(t.BaseState.Type))
+	b = append(b, ',')
+	if len(t.BaseState.OnErrors) != 0 {
+		b = append(b, `"onErrors":`...)
+		if t.BaseState.OnErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseState.OnErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.BaseState.OnErrors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Transition != nil {
+		b = append(b, `"transition":`...)
+		if b, err = t.BaseState.Transition.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.StateDataFilter != nil {
+		b = append(b, `"stateDataFilter":`...)
+		if b, err = t.BaseState.StateDataFilter.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseState.CompensatedBy) != 0 {
+		b = append(b, `"compensatedBy":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.CompensatedBy)
+		b = append(b, ',')
+	}
+	if t.BaseState.UsedForCompensation {
+		b = append(b, `"usedForCompensation":`...)
+		b = jsoncodec.AppendBool(b, bool(t.BaseState.UsedForCompensation))
+		b = append(b, ',')
+	}
+	if t.BaseState.End != nil {
+		b = append(b, `"end":`...)
+		if b, err = t.BaseState.End.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Metadata != nil {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseState.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"data":`...)
+	if b, err = jsoncodec.AppendValue(b, t.Data); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, `"timeouts":`...)
+	if b, err = t.Timeouts.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *InjectState) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain InjectState
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the InjectState like encoding/json, the decoder failing on what it doesn't handle
+func (t *InjectState) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "id":
+			d.String(&t.BaseState.ID)
+		case "name":
+			d.String(&t.BaseState.Name)
+		case "type":
+			d.String((*string)(&t.BaseState.Type))
+		case "onErrors":
+			if d.Null() {
+				t.BaseState.OnErrors = nil
+			} else {
+				s := t.BaseState.OnErrors
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, OnError{})
+					}
+					s[i].decodeJSON(d)
+					i++
+				}
+				if s == nil {
+					s = []OnError{}
+				}
+				t.BaseState.OnErrors = s[:i]
+			}
+		case "transition":
+			if d.Null() {
+				t.BaseState.Transition = nil
+			} else {
+				if t.BaseState.Transition == nil {
+					t.BaseState.Transition = new(Transition)
+				}
+				d.Unmarshaler(t.BaseState.Transition)
+			}
+		case "stateDataFilter":
+			if d.Null() {
+				t.BaseState.StateDataFilter = nil
+			} else {
+				if t.BaseState.StateDataFilter == nil {
+					t.BaseState.StateDataFilter = new(StateDataFilter)
+				}
+				t.BaseState.StateDataFilter.decodeJSON(d)
+			}
+		case "compensatedBy":
+			d.String(&t.BaseState.CompensatedBy)
+		case "usedForCompensation":
+			d.Bool(&t.BaseState.UsedForCompensation)
+		case "end":
+			if d.Null() {
+				t.BaseState.End = nil
+			} else {
+				if t.BaseState.End == nil {
+					t.BaseState.End = new(End)
+				}
+				d.Unmarshaler(t.BaseState.End)
+			}
+		case "metadata":
+			d.Value(&t.BaseState.Metadata)
+		case "data":
+			d.Value(&t.Data)
+		case "timeouts":
+			t.Timeouts.decodeJSON(d)
+		default:
+			d.Unknown(jsonKeysInjectState)
+		}
+	}
+}
+
+// jsonKeysInjectState keys of the properties of the InjectState
+var jsonKeysInjectState = []string{"id", "name", "type", "onErrors", "transition", "stateDataFilter", "compensatedBy", "usedForCompensation", "end", "metadata", "data", "timeouts"}
+
+// MarshalJSON implements json.Marshaler
+func (t InjectStateTimeout) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the InjectStateTimeout like encoding/json
+func (t *InjectStateTimeout) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"stateExecTimeout":`...)
+	if b, err = t.StateExecTimeout.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *InjectStateTimeout) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain InjectStateTimeout
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the InjectStateTimeout like encoding/json, the decoder failing on what it doesn't handle
+func (t *InjectStateTimeout) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "stateExecTimeout":
+			d.Unmarshaler(&t.StateExecTimeout)
+		default:
+			d.Unknown(jsonKeysInjectStateTimeout)
+		}
+	}
+}
+
+// jsonKeysInjectStateTimeout keys of the properties of the InjectStateTimeout
+var jsonKeysInjectStateTimeout = []string{"stateExecTimeout"}
+
+// MarshalJSON implements json.Marshaler
+func (t OAuth2AuthProperties) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the OAuth2AuthProperties like encoding/json
+func (t *OAuth2AuthProperties) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseAuthProperties.Common.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseAuthProperties.Common.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseAuthProperties.Secret) != 0 {
+		b = append(b, `"secret":`...)
+		b = jsoncodec.AppendString(b, t.BaseAuthProperties.Secret)
+		b = append(b, ',')
+	}
+	if len(t.Authority) != 0 {
+		b = append(b, `"authority":`...)
+		b = jsoncodec.AppendString(b, t.Authority)
+		b = append(b, ',')
+	}
+	b = append(b, `"grantType":`...)
+	b = jsoncodec.AppendString(b, string(t.GrantType))
+	b = append(b, ',')
+	b = append(b, `"clientId":`...)
+	b = jsoncodec.AppendString(b, t.ClientID)
+	b = append(b, ',')
+	if len(t.ClientSecret) != 0 {
+		b = append(b, `"clientSecret":`...)
+		b = jsoncodec.AppendString(b, t.ClientSecret)
+		b = append(b, ',')
+	}
+	if len(t.Scopes) != 0 {
+		b = append(b, `"scopes":`...)
+		if t.Scopes == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.Scopes {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = jsoncodec.AppendString(b, t.Scopes[i])
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if len(t.Username) != 0 {
+		b = append(b, `"username":`...)
+		b = jsoncodec.AppendString(b, t.Username)
+		b = append(b, ',')
+	}
+	if len(t.Password) != 0 {
+		b = append(b, `"password":`...)
+		b = jsoncodec.AppendString(b, t.Password)
+		b = append(b, ',')
+	}
+	if len(t.Audiences) != 0 {
+		b = append(b, `"audiences":`...)
+		if t.Audiences == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.Audiences {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = jsoncodec.AppendString(b, t.Audiences[i])
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if len(t.SubjectToken) != 0 {
+		b = append(b, `"subjectToken":`...)
+		b = jsoncodec.AppendString(b, t.SubjectToken)
+		b = append(b, ',')
+	}
+	if len(t.RequestedSubject) != 0 {
+		b = append(b, `"requestedSubject":`...)
+		b = jsoncodec.AppendString(b, t.RequestedSubject)
+		b = append(b, ',')
+	}
+	if len(t.RequestedIssuer) != 0 {
+		b = append(b, `"requestedIssuer":`...)
+		b = jsoncodec.AppendString(b, t.RequestedIssuer)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t OnError) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the OnError like encoding/json
+func (t *OnError) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.ErrorRef) != 0 {
+		b = append(b, `"errorRef":`...)
+		b = jsoncodec.AppendString(b, t.ErrorRef)
+		b = append(b, ',')
+	}
+	if len(t.ErrorRefs) != 0 {
+		b = append(b, `"errorRefs":`...)
+		if t.ErrorRefs == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.ErrorRefs {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = jsoncodec.AppendString(b, t.ErrorRefs[i])
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.Transition != nil {
+		b = append(b, `"transition":`...)
+		if b, err = t.Transition.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.End != nil {
+		b = append(b, `"end":`...)
+		if b, err = t.End.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *OnError) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain OnError
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the OnError like encoding/json, the decoder failing on what it doesn't handle
+func (t *OnError) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "errorRef":
+			d.String(&t.ErrorRef)
+		case "errorRefs":
+			if d.Null() {
+				t.ErrorRefs = nil
+			} else {
+				s := t.ErrorRefs
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, "")
+					}
+					d.String(&s[i])
+					i++
+				}
+				if s == nil {
+					s = []string{}
+				}
+				t.ErrorRefs = s[:i]
+			}
+		case "transition":
+			if d.Null() {
+				t.Transition = nil
+			} else {
+				if t.Transition == nil {
+					t.Transition = new(Transition)
+				}
+				d.Unmarshaler(t.Transition)
+			}
+		case "end":
+			if d.Null() {
+				t.End = nil
+			} else {
+				if t.End == nil {
+					t.End = new(End)
+				}
+				d.Unmarshaler(t.End)
+			}
+		default:
+			d.Unknown(jsonKeysOnError)
+		}
+	}
+}
+
+// jsonKeysOnError keys of the properties of the OnError
+var jsonKeysOnError = []string{"errorRef", "errorRefs", "transition", "end"}
+
+// MarshalJSON implements json.Marshaler
+func (t OnEvents) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the OnEvents like encoding/json
+func (t *OnEvents) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"eventRefs":`...)
+	if t.EventRefs == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range t.EventRefs {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = jsoncodec.AppendString(b, t.EventRefs[i])
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	if len(t.ActionMode) != 0 {
+		b = append(b, `"actionMode":`...)
+		b = jsoncodec.AppendString(b, string(t.ActionMode))
+		b = append(b, ',')
+	}
+	if len(t.Actions) != 0 {
+		b = append(b, `"actions":`...)
+		if t.Actions == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.Actions {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.Actions[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"eventDataFilter":`...)
+	if b, err = t.EventDataFilter.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *OnEvents) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain OnEvents
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the OnEvents like encoding/json, the decoder failing on what it doesn't handle
+func (t *OnEvents) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "eventRefs":
+			if d.Null() {
+				t.EventRefs = nil
+			} else {
+				s := t.EventRefs
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, "")
+					}
+					d.String(&s[i])
+					i++
+				}
+				if s == nil {
+					s = []string{}
+				}
+				t.EventRefs = s[:i]
+			}
+		case "actionMode":
+			d.String((*string)(&t.ActionMode))
+		case "actions":
+			if d.Null() {
+				t.Actions = nil
+			} else {
+				s := t.Actions
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, Action{})
+					}
+					s[i].decodeJSON(d)
+					i++
+				}
+				if s == nil {
+					s = []Action{}
+				}
+				t.Actions = s[:i]
+			}
+		case "eventDataFilter":
+			t.EventDataFilter.decodeJSON(d)
+		default:
+			d.Unknown(jsonKeysOnEvents)
+		}
+	}
+}
+
+// jsonKeysOnEvents keys of the properties of the OnEvents
+var jsonKeysOnEvents = []string{"eventRefs", "actionMode", "actions", "eventDataFilter"}
+
+// MarshalJSON implements json.Marshaler
+func (t OperationState) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the OperationState like encoding/json
+func (t *OperationState) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseState.ID) != 0 {
+		b = append(b, `"id":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.ID)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.BaseState.Name)
+	b = append(b, ',')
+	b = append(b, `"type":`...)
+	b = jsoncodec.AppendString(b, string(t.BaseState.Type))
+	b = append(b, ',')
+	if len(t.BaseState.OnErrors) != 0 {
+		b = append(b, `"onErrors":`...)
+		if t.BaseState.OnErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseState.OnErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.BaseState.OnErrors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Transition != nil {
+		b = append(b, `"transition":`...)
+		if b, err = t.BaseState.Transition.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.StateDataFilter != nil {
+		b = append(b, `"stateDataFilter":`...)
+		if b, err = t.BaseState.StateDataFilter.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseState.CompensatedBy) != 0 {
+		b = append(b, `"compensatedBy":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.CompensatedBy)
+		b = append(b, ',')
+	}
+	if t.BaseState.UsedForCompensation {
+		b = append(b, `"usedForCompensation":`...)
+		b = jsoncodec.AppendBool(b, bool(t.BaseState.UsedForCompensation))
+		b = append(b, ',')
+	}
+	if t.BaseState.End != nil {
+		b = append(b, `"end":`...)
+		if b, err = t.BaseState.End.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Metadata != nil {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseState.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.ActionMode) != 0 {
+		b = append(b, `"actionMode":`...)
+		b = jsoncodec.AppendString(b, string(t.ActionMode))
+		b = append(b, ',')
+	}
+	b = append(b, `"actions":`...)
+	if t.Actions == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range t.Actions {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if b, err = t.Actions[i].appendJSON(b); err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	b = append(b, `"timeouts":`...)
+	if b, err = t.Timeouts.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *OperationState) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain OperationState
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the OperationState like encoding/json, the decoder failing on what it doesn't handle
+func (t *OperationState) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "id":
+			d.String(&t.BaseState.ID)
+		case "name":
+			d.String(&t.BaseState.Name)
+		case "type":
+			d.String((*string)(&t.BaseState.Type))
+		case "onErrors":
+			if d.Null() {
+				t.BaseState.OnErrors = nil
+			} else {
+				s := t.BaseState.OnErrors
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, OnError{})
+					}
+					s[i].decodeJSON(d)
+					i++
+				}
+				if s == nil {
+					s = []OnError{}
+				}
+				t.BaseState.OnErrors = s[:i]
+			}
+		case "transition":
+			if d.Null() {
+				t.BaseState.Transition = nil
+			} else {
+				if t.BaseState.Transition == nil {
+					t.BaseState.Transition = new(Transition)
+				}
+				d.Unmarshaler(t.BaseState.Transition)
+			}
+		case "stateDataFilter":
+			if d.Null() {
+				t.BaseState.StateDataFilter = nil
+			} else {
+				if t.BaseState.StateDataFilter == nil {
+					t.BaseState.StateDataFilter = new(StateDataFilter)
+				}
+				t.BaseState.StateDataFilter.decodeJSON(d)
+			}
+		case "compensatedBy":
+			d.String(&t.BaseState.CompensatedBy)
+		case "usedForCompensation":
+			d.Bool(&t.BaseState.UsedForCompensation)
+		case "end":
+			if d.Null() {
+				t.BaseState.End = nil
+			} else {
+				if t.BaseState.End == nil {
+					t.BaseState.End = new(End)
+				}
+				d.Unmarshaler(t.BaseState.End)
+			}
+		case "metadata":
+			d.Value(&t.BaseState.Metadata)
+		case "actionMode":
+			d.String((*string)(&t.ActionMode))
+		case "actions":
+			if d.Null() {
+				t.Actions = nil
+			} else {
+				s := t.Actions
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, Action{})
+					}
+					s[i].decodeJSON(d)
+					i++
+				}
+				if s == nil {
+					s = []Action{}
+				}
+				t.Actions = s[:i]
+			}
+		case "timeouts":
+			t.Timeouts.decodeJSON(d)
+		default:
+			d.Unknown(jsonKeysOperationState)
+		}
+	}
+}
+
+// jsonKeysOperationState keys of the properties of the OperationState
+var jsonKeysOperationState = []string{"id", "name", "type", "onErrors", "transition", "stateDataFilter", "compensatedBy", "usedForCompensation", "end", "metadata", "actionMode", "actions", "timeouts"}
+
+// MarshalJSON implements json.Marshaler
+func (t OperationStateTimeout) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the OperationStateTimeout like encoding/json
+func (t *OperationStateTimeout) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"stateExecTimeout":`...)
+	if b, err = t.StateExecTimeout.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.ActionExecTimeout) != 0 {
+		b = append(b, `"actionExecTimeout":`...)
+		b = jsoncodec.AppendString(b, t.ActionExecTimeout)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *OperationStateTimeout) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain OperationStateTimeout
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the OperationStateTimeout like encoding/json, the decoder failing on what it doesn't handle
+func (t *OperationStateTimeout) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "stateExecTimeout":
+			d.Unmarshaler(&t.StateExecTimeout)
+		case "actionExecTimeout":
+			d.String(&t.ActionExecTimeout)
+		default:
+			d.Unknown(jsonKeysOperationStateTimeout)
+		}
+	}
+}
+
+// jsonKeysOperationStateTimeout keys of the properties of the OperationStateTimeout
+var jsonKeysOperationStateTimeout = []string{"stateExecTimeout", "actionExecTimeout"}
+
+// MarshalJSON implements json.Marshaler
+func (t ParallelState) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the ParallelState like encoding/json
+func (t *ParallelState) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseState.ID) != 0 {
+		b = append(b, `"id":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.ID)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.BaseState.Name)
+	b = append(b, ',')
+	b = append(b, `"type":`...)
+	b = jsoncodec.AppendString(b, string(t.BaseState.Type))
+	b = append(b, ',')
+	if len(t.BaseState.OnErrors) != 0 {
+		b = append(b, `"onErrors":`...)
+		if t.BaseState.OnErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseState.OnErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.BaseState.OnErrors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Transition != nil {
+		b = append(b, `"transition":`...)
+		if b, err = t.BaseState.Transition.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.StateDataFilter != nil {
+		b = append(b, `"stateDataFilter":`...)
+		if b, err = t.BaseState.StateDataFilter.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseState.CompensatedBy) != 0 {
+		b = append(b, `"compensatedBy":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.CompensatedBy)
+		b = append(b, ',')
+	}
+	if t.BaseState.UsedForCompensation {
+		b = append(b, `"usedForCompensation":`...)
+		b = jsoncodec.AppendBool(b, bool(t.BaseState.UsedForCompensation))
+		b = append(b, ',')
+	}
+	if t.BaseState.End != nil {
+		b = append(b, `"end":`...)
+		if b, err = t.BaseState.End.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Metadata != nil {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseState.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"branches":`...)
+	if t.Branches == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range t.Branches {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if b, err = t.Branches[i].appendJSON(b); err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	if len(t.CompletionType) != 0 {
+		b = append(b, `"completionType":`...)
+		b = jsoncodec.AppendString(b, string(t.CompletionType))
+		b = append(b, ',')
+	}
+	b = append(b, `"numCompleted":`...)
+	if b, err = jsoncodec.AppendValue(b, t.NumCompleted); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, `"timeouts":`...)
+	if b, err = t.Timeouts.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t ParallelStateTimeout) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the ParallelStateTimeout like encoding/json
+func (t *ParallelStateTimeout) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"stateExecTimeout":`...)
+	if b, err = t.StateExecTimeout.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.BranchExecTimeout) != 0 {
+		b = append(b, `"branchExecTimeout":`...)
+		b = jsoncodec.AppendString(b, t.BranchExecTimeout)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *ParallelStateTimeout) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain ParallelStateTimeout
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the ParallelStateTimeout like encoding/json, the decoder failing on what it doesn't handle
+func (t *ParallelStateTimeout) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "stateExecTimeout":
+			d.Unmarshaler(&t.StateExecTimeout)
+		case "branchExecTimeout":
+			d.String(&t.BranchExecTimeout)
+		default:
+			d.Unknown(jsonKeysParallelStateTimeout)
+		}
+	}
+}
+
+// jsonKeysParallelStateTimeout keys of the properties of the ParallelStateTimeout
+var jsonKeysParallelStateTimeout = []string{"stateExecTimeout", "branchExecTimeout"}
+
+// MarshalJSON implements json.Marshaler
+func (t ProduceEvent) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the ProduceEvent like encoding/json
+func (t *ProduceEvent) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"eventRef":`...)
+	b = jsoncodec.AppendString(b, t.EventRef)
+	b = append(b, ',')
+	if t.Data != nil {
+		b = append(b, `"data":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Data); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.ContextAttributes) != 0 {
+		b = append(b, `"contextAttributes":`...)
+		if b, err = jsoncodec.AppendValue(b, t.ContextAttributes); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *ProduceEvent) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain ProduceEvent
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the ProduceEvent like encoding/json, the decoder failing on what it doesn't handle
+func (t *ProduceEvent) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "eventRef":
+			d.String(&t.EventRef)
+		case "data":
+			d.Value(&t.Data)
+		case "contextAttributes":
+			d.Value(&t.ContextAttributes)
+		default:
+			d.Unknown(jsonKeysProduceEvent)
+		}
+	}
+}
+
+// jsonKeysProduceEvent keys of the properties of the ProduceEvent
+var jsonKeysProduceEvent = []string{"eventRef", "data", "contextAttributes"}
+
+// MarshalJSON implements json.Marshaler
+func (t Retry) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Retry like encoding/json
+func (t *Retry) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.Name)
+	b = append(b, ',')
+	if len(t.Delay) != 0 {
+		b = append(b, `"delay":`...)
+		b = jsoncodec.AppendString(b, t.Delay)
+		b = append(b, ',')
+	}
+	if len(t.MaxDelay) != 0 {
+		b = append(b, `"maxDelay":`...)
+		b = jsoncodec.AppendString(b, t.MaxDelay)
+		b = append(b, ',')
+	}
+	if len(t.Increment) != 0 {
+		b = append(b, `"increment":`...)
+		b = jsoncodec.AppendString(b, t.Increment)
+		b = append(b, ',')
+	}
+	if t.Multiplier != nil {
+		b = append(b, `"multiplier":`...)
+		if b, err = jsoncodec.AppendValue(b, t.Multiplier); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"maxAttempts":`...)
+	if b, err = jsoncodec.AppendValue(b, t.MaxAttempts); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, `"jitter":`...)
+	if b, err = jsoncodec.AppendValue(b, t.Jitter); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t Schedule) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Schedule like encoding/json
+func (t *Schedule) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.Interval) != 0 {
+		b = append(b, `"interval":`...)
+		b = jsoncodec.AppendString(b, t.Interval)
+		b = append(b, ',')
+	}
+	if t.Cron != nil {
+		b = append(b, `"cron":`...)
+		if b, err = t.Cron.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.Timezone) != 0 {
+		b = append(b, `"timezone":`...)
+		b = jsoncodec.AppendString(b, t.Timezone)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t Sleep) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Sleep like encoding/json
+func (t *Sleep) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '{')
+	if len(t.Before) != 0 {
+		b = append(b, `"before":`...)
+		b = jsoncodec.AppendString(b, t.Before)
+		b = append(b, ',')
+	}
+	if len(t.After) != 0 {
+		b = append(b, `"after":`...)
+		b = jsoncodec.AppendString(b, t.After)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Sleep) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain Sleep
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the Sleep like encoding/json, the decoder failing on what it doesn't handle
+func (t *Sleep) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "before":
+			d.String(&t.Before)
+		case "after":
+			d.String(&t.After)
+		default:
+			d.Unknown(jsonKeysSleep)
+		}
+	}
+}
+
+// jsonKeysSleep keys of the properties of the Sleep
+var jsonKeysSleep = []string{"before", "after"}
+
+// MarshalJSON implements json.Marshaler
+func (t SleepState) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the SleepState like encoding/json
+func (t *SleepState) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseState.ID) != 0 {
+		b = append(b, `"id":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.ID)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.BaseState.Name)
+	b = append(b, ',')
+	b = append(b, `"type":`...)
+	b = jsoncodec.AppendString(b, string(t.BaseState.Type))
+	b = append(b, ',')
+	if len(t.BaseState.OnErrors) != 0 {
+		b = append(b, `"onErrors":`...)
+		if t.BaseState.OnErrors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseState.OnErrors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.BaseState.OnErrors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Transition != nil {
+		b = append(b, `"transition":`...)
+		if b, err = t.BaseState.Transition.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.StateDataFilter != nil {
+		b = append(b, `"stateDataFilter":`...)
+		if b, err = t.BaseState.StateDataFilter.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseState.CompensatedBy) != 0 {
+		b = append(b, `"compensatedBy":`...)
+		b = jsoncodec.AppendString(b, t.BaseState.CompensatedBy)
+		b = append(b, ',')
+	}
+	if t.BaseState.UsedForCompensation {
+		b = append(b, `"usedForCompensation":`...)
+		b = jsoncodec.AppendBool(b, bool(t.BaseState.UsedForCompensation))
+		b = append(b, ',')
+	}
+	if t.BaseState.End != nil {
+		b = append(b, `"end":`...)
+		if b, err = t.BaseState.End.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseState.Metadata != nil {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseState.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"duration":`...)
+	b = jsoncodec.AppendString(b, t.Duration)
+	b = append(b, ',')
+	b = append(b, `"timeouts":`...)
+	if b, err = t.Timeouts.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *SleepState) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain SleepState
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the SleepState like encoding/json, the decoder failing on what it doesn't handle
+func (t *SleepState) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "id":
+			d.String(&t.BaseState.ID)
+		case "name":
+			d.String(&t.BaseState.Name)
+		case "type":
+			d.String((*string)(&t.BaseState.Type))
+		case "onErrors":
+			if d.Null() {
+				t.BaseState.OnErrors = nil
+			} else {
+				s := t.BaseState.OnErrors
+				i := 0
+				for more := d.Array(); more; more = d.Item() {
+					if i == len(s) {
+						s = append(s, OnError{})
+					}
+					s[i].decodeJSON(d)
+					i++
+				}
+				if s == nil {
+					s = []OnError{}
+				}
+				t.BaseState.OnErrors = s[:i]
+			}
+		case "transition":
+			if d.Null() {
+				t.BaseState.Transition = nil
+			} else {
+				if t.BaseState.Transition == nil {
+					t.BaseState.Transition = new(Transition)
+				}
+				d.Unmarshaler(t.BaseState.Transition)
+			}
+		case "stateDataFilter":
+			if d.Null() {
+				t.BaseState.StateDataFilter = nil
+			} else {
+				if t.BaseState.StateDataFilter == nil {
+					t.BaseState.StateDataFilter = new(StateDataFilter)
+				}
+				t.BaseState.StateDataFilter.decodeJSON(d)
+			}
+		case "compensatedBy":
+			d.String(&t.BaseState.CompensatedBy)
+		case "usedForCompensation":
+			d.Bool(&t.BaseState.UsedForCompensation)
+		case "end":
+			if d.Null() {
+				t.BaseState.End = nil
+			} else {
+				if t.BaseState.End == nil {
+					t.BaseState.End = new(End)
+				}
+				d.Unmarshaler(t.BaseState.End)
+			}
+		case "metadata":
+			d.Value(&t.BaseState.Metadata)
+		case "duration":
+			d.String(&t.Duration)
+		case "timeouts":
+			t.Timeouts.decodeJSON(d)
+		default:
+			d.Unknown(jsonKeysSleepState)
+		}
+	}
+}
+
+// jsonKeysSleepState keys of the properties of the SleepState
+var jsonKeysSleepState = []string{"id", "name", "type", "onErrors", "transition", "stateDataFilter", "compensatedBy", "usedForCompensation", "end", "metadata", "duration", "timeouts"}
+
+// MarshalJSON implements json.Marshaler
+func (t SleepStateTimeout) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the SleepStateTimeout like encoding/json
+func (t *SleepStateTimeout) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"stateExecTimeout":`...)
+	if b, err = t.StateExecTimeout.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *SleepStateTimeout) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain SleepStateTimeout
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the SleepStateTimeout like encoding/json, the decoder failing on what it doesn't handle
+func (t *SleepStateTimeout) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "stateExecTimeout":
+			d.Unmarshaler(&t.StateExecTimeout)
+		default:
+			d.Unknown(jsonKeysSleepStateTimeout)
+		}
+	}
+}
+
+// jsonKeysSleepStateTimeout keys of the properties of the SleepStateTimeout
+var jsonKeysSleepStateTimeout = []string{"stateExecTimeout"}
+
+// MarshalJSON implements json.Marshaler
+func (t Start) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Start like encoding/json
+func (t *Start) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"stateName":`...)
+	b = jsoncodec.AppendString(b, t.StateName)
+	b = append(b, ',')
+	if t.Schedule != nil {
+		b = append(b, `"schedule":`...)
+		if b, err = t.Schedule.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t StateDataFilter) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the StateDataFilter like encoding/json
+func (t *StateDataFilter) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '{')
+	if len(t.Input) != 0 {
+		b = append(b, `"input":`...)
+		b = jsoncodec.AppendString(b, t.Input)
+		b = append(b, ',')
+	}
+	if len(t.Output) != 0 {
+		b = append(b, `"output":`...)
+		b = jsoncodec.AppendString(b, t.Output)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *StateDataFilter) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain StateDataFilter
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the StateDataFilter like encoding/json, the decoder failing on what it doesn't handle
+func (t *StateDataFilter) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "input":
+			d.String(&t.Input)
+		case "output":
+			d.String(&t.Output)
+		default:
+			d.Unknown(jsonKeysStateDataFilter)
+		}
+	}
+}
+
+// jsonKeysStateDataFilter keys of the properties of the StateDataFilter
+var jsonKeysStateDataFilter = []string{"input", "output"}
+
+// MarshalJSON implements json.Marshaler
+func (t StateExecTimeout) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the StateExecTimeout like encoding/json
+func (t *StateExecTimeout) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '{')
+	if len(t.Single) != 0 {
+		b = append(b, `"single":`...)
+		b = jsoncodec.AppendString(b, t.Single)
+		b = append(b, ',')
+	}
+	b = append(b, `"total":`...)
+	b = jsoncodec.AppendString(b, t.Total)
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t Timeouts) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Timeouts like encoding/json
+func (t *Timeouts) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if t.WorkflowExecTimeout != nil {
+		b = append(b, `"workflowExecTimeout":`...)
+		if b, err = t.WorkflowExecTimeout.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.StateExecTimeout != nil {
+		b = append(b, `"stateExecTimeout":`...)
+		if b, err = t.StateExecTimeout.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.ActionExecTimeout) != 0 {
+		b = append(b, `"actionExecTimeout":`...)
+		b = jsoncodec.AppendString(b, t.ActionExecTimeout)
+		b = append(b, ',')
+	}
+	if len(t.BranchExecTimeout) != 0 {
+		b = append(b, `"branchExecTimeout":`...)
+		b = jsoncodec.AppendString(b, t.BranchExecTimeout)
+		b = append(b, ',')
+	}
+	if len(t.EventTimeout) != 0 {
+		b = append(b, `"eventTimeout":`...)
+		b = jsoncodec.AppendString(b, t.EventTimeout)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t Transition) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Transition like encoding/json
+func (t *Transition) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"nextState":`...)
+	b = jsoncodec.AppendString(b, t.NextState)
+	b = append(b, ',')
+	if len(t.ProduceEvents) != 0 {
+		b = append(b, `"produceEvents":`...)
+		if t.ProduceEvents == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.ProduceEvents {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.ProduceEvents[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.Compensate {
+		b = append(b, `"compensate":`...)
+		b = jsoncodec.AppendBool(b, bool(t.Compensate))
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t TransitionDataCondition) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the TransitionDataCondition like encoding/json
+func (t *TransitionDataCondition) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseDataCondition.Name) != 0 {
+		b = append(b, `"name":`...)
+		b = jsoncodec.AppendString(b, t.BaseDataCondition.Name)
+		b = append(b, ',')
+	}
+	b = append(b, `"condition":`...)
+	b = jsoncodec.AppendString(b, t.BaseDataCondition.Condition)
+	b = append(b, ',')
+	if len(t.BaseDataCondition.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseDataCondition.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"transition":`...)
+	if b, err = t.Transition.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *TransitionDataCondition) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain TransitionDataCondition
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the TransitionDataCondition like encoding/json, the decoder failing on what it doesn't handle
+func (t *TransitionDataCondition) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "name":
+			d.String(&t.BaseDataCondition.Name)
+		case "condition":
+			d.String(&t.BaseDataCondition.Condition)
+		case "metadata":
+			d.Value(&t.BaseDataCondition.Metadata)
+		case "transition":
+			d.Unmarshaler(&t.Transition)
+		default:
+			d.Unknown(jsonKeysTransitionDataCondition)
+		}
+	}
+}
+
+// jsonKeysTransitionDataCondition keys of the properties of the TransitionDataCondition
+var jsonKeysTransitionDataCondition = []string{"name", "condition", "metadata", "transition"}
+
+// MarshalJSON implements json.Marshaler
+func (t TransitionEventCondition) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the TransitionEventCondition like encoding/json
+func (t *TransitionEventCondition) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if len(t.BaseEventCondition.Name) != 0 {
+		b = append(b, `"name":`...)
+		b = jsoncodec.AppendString(b, t.BaseEventCondition.Name)
+		b = append(b, ',')
+	}
+	b = append(b, `"eventRef":`...)
+	b = jsoncodec.AppendString(b, t.BaseEventCondition.EventRef)
+	b = append(b, ',')
+	b = append(b, `"eventDataFilter":`...)
+	if b, err = t.BaseEventCondition.EventDataFilter.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.BaseEventCondition.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseEventCondition.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, `"transition":`...)
+	if b, err = t.Transition.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *TransitionEventCondition) UnmarshalJSON(data []byte) error {
+	saved := *t
+	d := jsoncodec.NewDecoder(data)
+	t.decodeJSON(&d)
+	if d.End() {
+		return nil
+	}
+	// the errors, and the keys matching the properties regardless of their case, are left to encoding/json
+	*t = saved
+	type plain TransitionEventCondition
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+// decodeJSON decodes the TransitionEventCondition like encoding/json, the decoder failing on what it doesn't handle
+func (t *TransitionEventCondition) decodeJSON(d *jsoncodec.Decoder) {
+	if d.Null() {
+		return
+	}
+	for more := d.Object(); more; more = d.Next() {
+		switch string(d.Key()) {
+		case "name":
+			d.String(&t.BaseEventCondition.Name)
+		case "eventRef":
+			d.String(&t.BaseEventCondition.EventRef)
+		case "eventDataFilter":
+			t.BaseEventCondition.EventDataFilter.decodeJSON(d)
+		case "metadata":
+			d.Value(&t.BaseEventCondition.Metadata)
+		case "transition":
+			d.Unmarshaler(&t.Transition)
+		default:
+			d.Unknown(jsonKeysTransitionEventCondition)
+		}
+	}
+}
+
+// jsonKeysTransitionEventCondition keys of the properties of the TransitionEventCondition
+var jsonKeysTransitionEventCondition = []string{"name", "eventRef", "eventDataFilter", "metadata", "transition"}
+
+// MarshalJSON implements json.Marshaler
+func (t Workflow) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the Workflow like encoding/json
+func (t *Workflow) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	b = append(b, `"id":`...)
+	b = jsoncodec.AppendString(b, t.BaseWorkflow.ID)
+	b = append(b, ',')
+	if len(t.BaseWorkflow.Key) != 0 {
+		b = append(b, `"key":`...)
+		b = jsoncodec.AppendString(b, t.BaseWorkflow.Key)
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = jsoncodec.AppendString(b, t.BaseWorkflow.Name)
+	b = append(b, ',')
+	if len(t.BaseWorkflow.Description) != 0 {
+		b = append(b, `"description":`...)
+		b = jsoncodec.AppendString(b, t.BaseWorkflow.Description)
+		b = append(b, ',')
+	}
+	b = append(b, `"version":`...)
+	b = jsoncodec.AppendString(b, t.BaseWorkflow.Version)
+	b = append(b, ',')
+	b = append(b, `"start":`...)
+	if b, err = t.BaseWorkflow.Start.appendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if len(t.BaseWorkflow.Annotations) != 0 {
+		b = append(b, `"annotations":`...)
+		if t.BaseWorkflow.Annotations == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseWorkflow.Annotations {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = jsoncodec.AppendString(b, t.BaseWorkflow.Annotations[i])
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseWorkflow.DataInputSchema != nil {
+		b = append(b, `"dataInputSchema":`...)
+		if b, err = t.BaseWorkflow.DataInputSchema.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseWorkflow.SpecVersion) != 0 {
+		b = append(b, `"specVersion":`...)
+		b = jsoncodec.AppendString(b, t.BaseWorkflow.SpecVersion)
+		b = append(b, ',')
+	}
+	if len(t.BaseWorkflow.Secrets) != 0 {
+		b = append(b, `"secrets":`...)
+		if t.BaseWorkflow.Secrets == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseWorkflow.Secrets {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = jsoncodec.AppendString(b, t.BaseWorkflow.Secrets[i])
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseWorkflow.Constants != nil {
+		b = append(b, `"constants":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseWorkflow.Constants); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseWorkflow.ExpressionLang) != 0 {
+		b = append(b, `"expressionLang":`...)
+		b = jsoncodec.AppendString(b, t.BaseWorkflow.ExpressionLang)
+		b = append(b, ',')
+	}
+	if t.BaseWorkflow.Timeouts != nil {
+		b = append(b, `"timeouts":`...)
+		if b, err = t.BaseWorkflow.Timeouts.appendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if len(t.BaseWorkflow.Errors) != 0 {
+		b = append(b, `"errors":`...)
+		if t.BaseWorkflow.Errors == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.BaseWorkflow.Errors {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.BaseWorkflow.Errors[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if t.BaseWorkflow.KeepActive {
+		b = append(b, `"keepActive":`...)
+		b = jsoncodec.AppendBool(b, bool(t.BaseWorkflow.KeepActive))
+		b = append(b, ',')
+	}
+	if len(t.BaseWorkflow.Metadata) != 0 {
+		b = append(b, `"metadata":`...)
+		if b, err = jsoncodec.AppendValue(b, t.BaseWorkflow.Metadata); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if t.BaseWorkflow.AutoRetries {
+		b = append(b, `"autoRetries":`...)
+		b = jsoncodec.AppendBool(b, bool(t.BaseWorkflow.AutoRetries))
+		b = append(b, ',')
+	}
+	b = append(b, `"auth":`...)
+	if b, err = jsoncodec.AppendValue(b, t.BaseWorkflow.Auth); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, `"states":`...)
+	if t.States == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range t.States {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if b, err = appendJSONValue(b, t.States[i]); err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	if len(t.Events) != 0 {
+		b = append(b, `"events":`...)
+		if t.Events == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.Events {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.Events[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if len(t.Functions) != 0 {
+		b = append(b, `"functions":`...)
+		if t.Functions == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.Functions {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.Functions[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	if len(t.Retries) != 0 {
+		b = append(b, `"retries":`...)
+		if t.Retries == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range t.Retries {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if b, err = t.Retries[i].appendJSON(b); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t WorkflowExecTimeout) MarshalJSON() ([]byte, error) {
+	return t.appendJSON(make([]byte, 0, 128))
+}
+
+// appendJSON appends the JSON of the WorkflowExecTimeout like encoding/json
+func (t *WorkflowExecTimeout) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '{')
+	if len(t.Duration) != 0 {
+		b = append(b, `"duration":`...)
+		b = jsoncodec.AppendString(b, t.Duration)
+		b = append(b, ',')
+	}
+	if t.Interrupt {
+		b = append(b, `"interrupt":`...)
+		b = jsoncodec.AppendBool(b, bool(t.Interrupt))
+		b = append(b, ',')
+	}
+	if len(t.RunBefore) != 0 {
+		b = append(b, `"runBefore":`...)
+		b = jsoncodec.AppendString(b, t.RunBefore)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// appendJSON appends the JSON of the WorkflowRef like encoding/json
+func (t *WorkflowRef) appendJSON(b []byte) ([]byte, error) {
+	if t == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '{')
+	b = append(b, `"workflowId":`...)
+	b = jsoncodec.AppendString(b, t.WorkflowID)
+	b = append(b, ',')
+	if len(t.Version) != 0 {
+		b = append(b, `"version":`...)
+		b = jsoncodec.AppendString(b, t.Version)
+		b = append(b, ',')
+	}
+	// the comma after the last property, if any, closes the object
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b, nil
+	}
+	return append(b, '}'), nil
+}
+
+// appendJSONValue appends the JSON of the value of an interface, encoded by its generated method if it has one
+func appendJSONValue(b []byte, v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case *Action:
+		return v.appendJSON(b)
+	case Action:
+		return v.appendJSON(b)
+	case *ActionDataFilter:
+		return v.appendJSON(b)
+	case ActionDataFilter:
+		return v.appendJSON(b)
+	case *Auth:
+		return v.appendJSON(b)
+	case Auth:
+		return v.appendJSON(b)
+	case *BasicAuthProperties:
+		return v.appendJSON(b)
+	case BasicAuthProperties:
+		return v.appendJSON(b)
+	case *BearerAuthProperties:
+		return v.appendJSON(b)
+	case BearerAuthProperties:
+		return v.appendJSON(b)
+	case *Branch:
+		return v.appendJSON(b)
+	case Branch:
+		return v.appendJSON(b)
+	case *BranchTimeouts:
+		return v.appendJSON(b)
+	case BranchTimeouts:
+		return v.appendJSON(b)
+	case *CallbackState:
+		return v.appendJSON(b)
+	case CallbackState:
+		return v.appendJSON(b)
+	case *CallbackStateTimeout:
+		return v.appendJSON(b)
+	case CallbackStateTimeout:
+		return v.appendJSON(b)
+	case *ContinueAs:
+		return v.appendJSON(b)
+	case ContinueAs:
+		return v.appendJSON(b)
+	case *Correlation:
+		return v.appendJSON(b)
+	case Correlation:
+		return v.appendJSON(b)
+	case *Cron:
+		return v.appendJSON(b)
+	case Cron:
+		return v.appendJSON(b)
+	case *DataBasedSwitchState:
+		return v.appendJSON(b)
+	case DataBasedSwitchState:
+		return v.appendJSON(b)
+	case *DataBasedSwitchStateTimeout:
+		return v.appendJSON(b)
+	case DataBasedSwitchStateTimeout:
+		return v.appendJSON(b)
+	case *DataInputSchema:
+		return v.appendJSON(b)
+	case DataInputSchema:
+		return v.appendJSON(b)
+	case *DefaultCondition:
+		return v.appendJSON(b)
+	case DefaultCondition:
+		return v.appendJSON(b)
+	case *DelayState:
+		return v.appendJSON(b)
+	case DelayState:
+		return v.appendJSON(b)
+	case *End:
+		return v.appendJSON(b)
+	case End:
+		return v.appendJSON(b)
+	case *EndDataCondition:
+		return v.appendJSON(b)
+	case EndDataCondition:
+		return v.appendJSON(b)
+	case *EndEventCondition:
+		return v.appendJSON(b)
+	case EndEventCondition:
+		return v.appendJSON(b)
+	case *Error:
+		return v.appendJSON(b)
+	case Error:
+		return v.appendJSON(b)
+	case *Event:
+		return v.appendJSON(b)
+	case Event:
+		return v.appendJSON(b)
+	case *EventBasedSwitchState:
+		return v.appendJSON(b)
+	case EventBasedSwitchState:
+		return v.appendJSON(b)
+	case *EventBasedSwitchStateTimeout:
+		return v.appendJSON(b)
+	case EventBasedSwitchStateTimeout:
+		return v.appendJSON(b)
+	case *EventDataFilter:
+		return v.appendJSON(b)
+	case EventDataFilter:
+		return v.appendJSON(b)
+	case *EventRef:
+		return v.appendJSON(b)
+	case EventRef:
+		return v.appendJSON(b)
+	case *EventState:
+		return v.appendJSON(b)
+	case EventState:
+		return v.appendJSON(b)
+	case *EventStateTimeout:
+		return v.appendJSON(b)
+	case EventStateTimeout:
+		return v.appendJSON(b)
+	case *ForEachState:
+		return v.appendJSON(b)
+	case ForEachState:
+		return v.appendJSON(b)
+	case *ForEachStateTimeout:
+		return v.appendJSON(b)
+	case ForEachStateTimeout:
+		return v.appendJSON(b)
+	case *Function:
+		return v.appendJSON(b)
+	case Function:
+		return v.appendJSON(b)
+	case *FunctionRef:
+		return v.appendJSON(b)
+	case FunctionRef:
+		return v.appendJSON(b)
+	case *InjectState:
+		return v.appendJSON(b)
+	case InjectState:
+		return v.appendJSON(b)
+	case *InjectStateTimeout:
+		return v.appendJSON(b)
+	case InjectStateTimeout:
+		return v.appendJSON(b)
+	case *OAuth2AuthProperties:
+		return v.appendJSON(b)
+	case OAuth2AuthProperties:
+		return v.appendJSON(b)
+	case *OnError:
+		return v.appendJSON(b)
+	case OnError:
+		return v.appendJSON(b)
+	case *OnEvents:
+		return v.appendJSON(b)
+	case OnEvents:
+		return v.appendJSON(b)
+	case *OperationState:
+		return v.appendJSON(b)
+	case OperationState:
+		return v.appendJSON(b)
+	case *OperationStateTimeout:
+		return v.appendJSON(b)
+	case OperationStateTimeout:
+		return v.appendJSON(b)
+	case *ParallelState:
+		return v.appendJSON(b)
+	case ParallelState:
+		return v.appendJSON(b)
+	case *ParallelStateTimeout:
+		return v.appendJSON(b)
+	case ParallelStateTimeout:
+		return v.appendJSON(b)
+	case *ProduceEvent:
+		return v.appendJSON(b)
+	case ProduceEvent:
+		return v.appendJSON(b)
+	case *Retry:
+		return v.appendJSON(b)
+	case Retry:
+		return v.appendJSON(b)
+	case *Schedule:
+		return v.appendJSON(b)
+	case Schedule:
+		return v.appendJSON(b)
+	case *Sleep:
+		return v.appendJSON(b)
+	case Sleep:
+		return v.appendJSON(b)
+	case *SleepState:
+		return v.appendJSON(b)
+	case SleepState:
+		return v.appendJSON(b)
+	case *SleepStateTimeout:
+		return v.appendJSON(b)
+	case SleepStateTimeout:
+		return v.appendJSON(b)
+	case *Start:
+		return v.appendJSON(b)
+	case Start:
+		return v.appendJSON(b)
+	case *StateDataFilter:
+		return v.appendJSON(b)
+	case StateDataFilter:
+		return v.appendJSON(b)
+	case *StateExecTimeout:
+		return v.appendJSON(b)
+	case StateExecTimeout:
+		return v.appendJSON(b)
+	case *Timeouts:
+		return v.appendJSON(b)
+	case Timeouts:
+		return v.appendJSON(b)
+	case *Transition:
+		return v.appendJSON(b)
+	case Transition:
+		return v.appendJSON(b)
+	case *TransitionDataCondition:
+		return v.appendJSON(b)
+	case TransitionDataCondition:
+		return v.appendJSON(b)
+	case *TransitionEventCondition:
+		return v.appendJSON(b)
+	case TransitionEventCondition:
+		return v.appendJSON(b)
+	case *Workflow:
+		return v.appendJSON(b)
+	case Workflow:
+		return v.appendJSON(b)
+	case *WorkflowExecTimeout:
+		return v.appendJSON(b)
+	case WorkflowExecTimeout:
+		return v.appendJSON(b)
+	}
+	return jsoncodec.AppendValue(b, v)
+}
@@ -0,0 +1,289 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package overlay builds the environment specific variants of a workflow from a base workflow and overlays patching
+// it, e.g. the function URIs, timeouts and retries of production, in the spirit of kustomize.
+package overlay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/serverlessworkflow/sdk-go/v2/integrity"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// directive property of the patch elements, see Merge
+	directive = "$patch"
+	// directiveDelete removes the element matching the patch element from the named definitions
+	directiveDelete = "delete"
+	// directiveReplace replaces the value instead of merging the patch into it
+	directiveReplace = "replace"
+)
+
+// Overlay environment specific changes to a base workflow, usually loaded from a YAML file, e.g.
+//
+//	base: ../../base/order.sw.yaml
+//	patches:
+//	  - functions:
+//	      - name: storeOrder
+//	        operation: https://orders.example.com/openapi.json#storeOrder
+//	    retries:
+//	      - name: default
+//	        maxAttempts: 10
+type Overlay struct {
+	// Base path of the base workflow, or of the overlay extended by this one, relative to the overlay file if not
+	// absolute
+	Base string `json:"base"`
+	// Patches partial workflow documents merged in turn into the base, see Merge
+	Patches []map[string]interface{} `json:"patches,omitempty"`
+}
+
+// Load loads the overlay file in the given path
+func Load(path string) (*Overlay, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	overlay := &Overlay{}
+	if err := yaml.UnmarshalStrict(data, overlay); err != nil {
+		return nil, fmt.Errorf("invalid overlay %s: %w", path, err)
+	}
+	if len(overlay.Base) == 0 {
+		return nil, fmt.Errorf("invalid overlay %s: base is required", path)
+	}
+	return overlay, nil
+}
+
+// Build builds the workflow of the overlay file in the given path: the base workflow, or the workflow of the base
+// overlay, patched by the overlay. The workflow is validated by the parser and integrity.Validate.
+func Build(path string) (*model.Workflow, error) {
+	document, err := buildDocument(path, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	workflow, err := parser.FromJSONSource(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workflow built from %s: %w", path, err)
+	}
+	if errs := integrity.Validate(workflow); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid workflow built from %s: %w", path, errs)
+	}
+	return workflow, nil
+}
+
+// buildDocument returns the workflow document built from the overlay, or the workflow document if the file isn't an
+// overlay
+func buildDocument(path string, visited map[string]bool) (map[string]interface{}, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if visited[abs] {
+		return nil, fmt.Errorf("overlay cycle through %s", path)
+	}
+	visited[abs] = true
+
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	document := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if _, ok := document["base"]; !ok {
+		return document, nil
+	}
+	overlay, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	basePath := filepath.FromSlash(overlay.Base)
+	if !filepath.IsAbs(basePath) {
+		basePath = filepath.Join(filepath.Dir(path), basePath)
+	}
+	base, err := buildDocument(basePath, visited)
+	if err != nil {
+		return nil, err
+	}
+	return overlay.Apply(base)
+}
+
+// Apply merges the patches of the overlay in turn into the workflow document
+func (o *Overlay) Apply(document map[string]interface{}) (map[string]interface{}, error) {
+	for i, patch := range o.Patches {
+		var err error
+		if document, err = Merge(document, patch); err != nil {
+			return nil, fmt.Errorf("patch %d: %w", i+1, err)
+		}
+	}
+	return document, nil
+}
+
+// Merge merges the patch into the workflow document, like a JSON merge patch (RFC 7386) with the named definitions
+// merged by name:
+//
+//   - objects are merged recursively and a null value removes the property
+//   - arrays of objects with a name, e.g. the states, functions or retries, are merged element by element: the
+//     elements of the patch are merged into the elements of the document with the same name, or appended
+//   - other arrays and values replace those of the document
+//
+// A '$patch: delete' property removes the element with the same name from the document, and '$patch: replace'
+// replaces an object instead of merging it. The document is modified in place.
+func Merge(document, patch map[string]interface{}) (map[string]interface{}, error) {
+	merged, err := merge(document, patch, "")
+	if err != nil {
+		return nil, err
+	}
+	return merged.(map[string]interface{}), nil
+}
+
+func merge(value, patch interface{}, path string) (interface{}, error) {
+	switch p := patch.(type) {
+	case map[string]interface{}:
+		m, ok := value.(map[string]interface{})
+		if !ok || p[directive] == directiveReplace {
+			return strip(p, path)
+		}
+		if d, ok := p[directive]; ok {
+			return nil, fmt.Errorf("%s: unknown %s directive %v", pathOrRoot(path), directive, d)
+		}
+		for key, v := range p {
+			if v == nil {
+				delete(m, key)
+				continue
+			}
+			merged, err := merge(m[key], v, join(path, key))
+			if err != nil {
+				return nil, err
+			}
+			m[key] = merged
+		}
+		return m, nil
+	case []interface{}:
+		if s, ok := value.([]interface{}); ok && named(s) && named(p) {
+			return mergeNamed(s, p, path)
+		}
+		return strip(p, path)
+	}
+	return patch, nil
+}
+
+// mergeNamed merges the elements of the patch into the elements with the same name
+func mergeNamed(values, patch []interface{}, path string) (interface{}, error) {
+	for _, element := range patch {
+		p := element.(map[string]interface{})
+		name := p["name"].(string)
+		index := -1
+		for i, v := range values {
+			if v.(map[string]interface{})["name"] == name {
+				index = i
+				break
+			}
+		}
+		elementPath := fmt.Sprintf("%s[name=%s]", path, name)
+		if p[directive] == directiveDelete {
+			if index < 0 {
+				return nil, fmt.Errorf("%s: nothing to delete", elementPath)
+			}
+			values = append(values[:index], values[index+1:]...)
+			continue
+		}
+		if index < 0 {
+			added, err := strip(p, elementPath)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, added)
+			continue
+		}
+		merged, err := merge(values[index], p, elementPath)
+		if err != nil {
+			return nil, err
+		}
+		values[index] = merged
+	}
+	return values, nil
+}
+
+// strip removes the directives of the patch value, returning the value added to the document
+func strip(value interface{}, path string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		stripped := make(map[string]interface{}, len(v))
+		for key, property := range v {
+			if key == directive {
+				if property != directiveReplace {
+					return nil, fmt.Errorf("%s: %s directive %v not applicable", pathOrRoot(path), directive, property)
+				}
+				continue
+			}
+			s, err := strip(property, join(path, key))
+			if err != nil {
+				return nil, err
+			}
+			stripped[key] = s
+		}
+		return stripped, nil
+	case []interface{}:
+		stripped := make([]interface{}, len(v))
+		for i, element := range v {
+			s, err := strip(element, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			stripped[i] = s
+		}
+		return stripped, nil
+	}
+	return value, nil
+}
+
+// named whether the array only holds objects with a name
+func named(values []interface{}) bool {
+	for _, v := range values {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := m["name"].(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func join(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
+
+func pathOrRoot(path string) string {
+	if len(path) == 0 {
+		return "workflow"
+	}
+	return path
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package overlay
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBuild(t *testing.T) {
+	workflow, err := Build("testdata/prod/overlay.yaml")
+	require.NoError(t, err)
+	require.Len(t, workflow.Functions, 2)
+	assert.Equal(t, "https://orders.example.com/openapi.json#storeOrder", workflow.Functions[0].Operation)
+	assert.Equal(t, "https://notifications.example.com/openapi.json#notify", workflow.Functions[1].Operation)
+	assert.Equal(t, 10, workflow.Retries[0].MaxAttempts.IntValue())
+	assert.Equal(t, "PT1S", workflow.Retries[0].Delay)
+	store := workflow.States[0].(*model.OperationState)
+	require.Len(t, store.Actions, 1)
+	assert.Equal(t, "PT30S", store.Timeouts.StateExecTimeout.Total)
+	assert.Equal(t, "Notify", store.Transition.NextState)
+
+	workflow, err = Build("testdata/prod-eu/overlay.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "https://orders.eu.example.com/openapi.json#storeOrder", workflow.Functions[0].Operation)
+	assert.Equal(t, "1.0-eu", workflow.Version)
+	assert.Equal(t, 10, workflow.Retries[0].MaxAttempts.IntValue())
+
+	workflow, err = Build("testdata/base/order.sw.yaml")
+	require.NoError(t, err)
+	assert.Len(t, workflow.Functions, 3)
+}
+
+func TestBuildErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "overlay")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	_, err = Build(write("a.yaml", "base: b.yaml\n"))
+	assert.Error(t, err)
+	write("b.yaml", "base: a.yaml\n")
+	_, err = Build(filepath.Join(dir, "a.yaml"))
+	assert.EqualError(t, err, "overlay cycle through "+filepath.Join(dir, "a.yaml"))
+
+	// the built workflow is validated, deleting a state breaks the transition
+	base := "base: " + mustAbs(t, "testdata/base/order.sw.yaml") + "\n"
+	_, err = Build(write("broken.yaml", base+"patches:\n- states:\n  - name: Notify\n    $patch: delete\n"))
+	assert.Contains(t, err.Error(), "states[0].transition.nextState")
+	_, err = Build(write("unknown.yaml", base+"bases: []\n"))
+	assert.Contains(t, err.Error(), "invalid overlay")
+	_, err = Build(write("missing.yaml", base+"patches:\n- functions:\n  - name: other\n    $patch: delete\n"))
+	assert.EqualError(t, err, "patch 1: functions[name=other]: nothing to delete")
+}
+
+func TestMerge(t *testing.T) {
+	document := parse(t, `
+states:
+- name: A
+  type: operation
+  actions: [{functionRef: f}, {functionRef: g}]
+  metadata: {owner: team-a, tier: gold}
+- name: B
+  type: sleep
+constants: {a: 1}
+`)
+	merged, err := Merge(document, parse(t, `
+states:
+- name: A
+  actions: [{functionRef: h}]
+  metadata: {tier: null, region: eu}
+- name: C
+  type: inject
+  data: {$patch: replace, x: 1}
+constants: {$patch: replace, b: 2}
+`))
+	require.NoError(t, err)
+	assert.Equal(t, parse(t, `
+states:
+- name: A
+  type: operation
+  actions: [{functionRef: h}]
+  metadata: {owner: team-a, region: eu}
+- name: B
+  type: sleep
+- name: C
+  type: inject
+  data: {x: 1}
+constants: {b: 2}
+`), merged)
+
+	_, err = Merge(parse(t, `states: [{name: A}]`), parse(t, `states: [{name: A, $patch: remove}]`))
+	assert.EqualError(t, err, "states[name=A]: unknown $patch directive remove")
+	_, err = Merge(parse(t, `functions: functions.json`), parse(t, `functions: [{name: A, $patch: delete}]`))
+	assert.EqualError(t, err, "functions[0]: $patch directive delete not applicable")
+}
+
+func parse(t *testing.T, source string) map[string]interface{} {
+	document := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(source), &document))
+	return document
+}
+
+func mustAbs(t *testing.T, path string) string {
+	abs, err := filepath.Abs(path)
+	require.NoError(t, err)
+	return abs
+}
@@ -0,0 +1,269 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+	"github.com/serverlessworkflow/sdk-go/v2/validator"
+	"sigs.k8s.io/yaml"
+)
+
+// generateWorkflow generates a valid workflow with the given number of states, cycling through operation, switch,
+// event and inject states calling a handful of functions with retries
+func generateWorkflow(states int) []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteString(`{"id": "generated", "name": "Generated", "version": "1.0", "specVersion": "0.8", "start": "State0",
+"functions": [{"name": "store", "operation": "http://api.example.com/openapi.json#store"},
+  {"name": "price", "type": "rpc", "operation": "pricing.proto#Pricing#Price"}],
+"events": [{"name": "Approved", "source": "approvals", "type": "order.approved"}],
+"retries": [{"name": "Default", "delay": "PT1S", "maxAttempts": 3, "multiplier": 2}],
+"states": [`)
+	for i := 0; i < states; i++ {
+		if i > 0 {
+			buf.WriteString(",\n")
+		}
+		next := fmt.Sprintf(`"transition": "State%d"`, i+1)
+		if i == states-1 {
+			next = `"end": true`
+		}
+		switch i % 4 {
+		case 0:
+			fmt.Fprintf(buf, `{"name": "State%d", "type": "operation", "actions": [
+  {"name": "store", "functionRef": {"refName": "store", "arguments": {"order": "${ .order }", "index": %d}}, "retryRef": "Default"},
+  {"name": "price", "functionRef": "price", "actionDataFilter": {"results": "${ .price }"}}], %s}`, i, i, next)
+		case 1:
+			end := `"end": true`
+			if i < states-1 {
+				end = fmt.Sprintf(`"transition": {"nextState": "State%d"}`, i+1)
+			}
+			fmt.Fprintf(buf, `{"name": "State%d", "type": "switch", "dataConditions": [
+  {"condition": "${ .total > %d }", %s}], "defaultCondition": {%s}}`, i, i, end, end)
+		case 2:
+			fmt.Fprintf(buf, `{"name": "State%d", "type": "event", "onEvents": [{"eventRefs": ["Approved"],
+  "actions": [{"functionRef": "store"}]}], "timeouts": {"eventTimeout": "PT1H"}, %s}`, i, next)
+		default:
+			fmt.Fprintf(buf, `{"name": "State%d", "type": "inject", "data": {"step": %d, "tags": ["a", "b"]}, %s}`, i, i, next)
+		}
+	}
+	buf.WriteString("]}")
+	return buf.Bytes()
+}
+
+// benchmarkFiles the workflow files of the benchmarks: small and medium sized test workflows, and generated
+// workflows of 1,000 states in JSON and YAML
+func benchmarkFiles(b *testing.B) map[string]string {
+	dir := b.TempDir()
+	generated := generateWorkflow(1000)
+	generatedYAML, err := yaml.JSONToYAML(generated)
+	if err != nil {
+		b.Fatal(err)
+	}
+	files := map[string]string{
+		"small":          "./testdata/workflows/greetings.sw.json",
+		"medium":         "./testdata/workflows/purchaseorderworkflow.sw.json",
+		"generated.json": filepath.Join(dir, "generated.sw.json"),
+		"generated.yaml": filepath.Join(dir, "generated.sw.yaml"),
+	}
+	if err := ioutil.WriteFile(files["generated.json"], generated, 0600); err != nil {
+		b.Fatal(err)
+	}
+	if err := ioutil.WriteFile(files["generated.yaml"], generatedYAML, 0600); err != nil {
+		b.Fatal(err)
+	}
+	return files
+}
+
+var benchmarkSizes = []string{"small", "medium", "generated.json", "generated.yaml"}
+
+func BenchmarkFromFile(b *testing.B) {
+	files := benchmarkFiles(b)
+	for _, size := range benchmarkSizes {
+		b.Run(size, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := FromFile(files[size]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkRetained reports the heap retained by the workflows held in memory, as tools loading whole workspaces do
+func BenchmarkRetained(b *testing.B) {
+	files := benchmarkFiles(b)
+	for _, size := range []string{"medium", "generated.json"} {
+		b.Run(strings.TrimSuffix(size, ".json"), func(b *testing.B) {
+			var before, after runtime.MemStats
+			workflows := make([]*model.Workflow, b.N)
+			runtime.GC()
+			runtime.ReadMemStats(&before)
+			for i := range workflows {
+				workflow, err := FromFile(files[size])
+				if err != nil {
+					b.Fatal(err)
+				}
+				workflows[i] = workflow
+			}
+			runtime.GC()
+			runtime.ReadMemStats(&after)
+			b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/float64(b.N), "retained-B/workflow")
+			runtime.KeepAlive(workflows)
+		})
+	}
+}
+
+func BenchmarkCacheFromFile(b *testing.B) {
+	files := benchmarkFiles(b)
+	for _, size := range benchmarkSizes {
+		b.Run(size, func(b *testing.B) {
+			c := NewCache(nil)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := c.FromFile(files[size]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFromFileLazy(b *testing.B) {
+	files := benchmarkFiles(b)
+	for _, size := range benchmarkSizes {
+		b.Run(size, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				workflow, err := FromFileLazy(files[size])
+				if err != nil {
+					b.Fatal(err)
+				}
+				// tools typically look at the start state only
+				if _, err := workflow.State(workflow.Start.StateName); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFromJSONStream(b *testing.B) {
+	files := benchmarkFiles(b)
+	for _, size := range []string{"small", "medium", "generated.json"} {
+		b.Run(strings.TrimSuffix(size, ".json"), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				file, err := os.Open(files[size])
+				if err != nil {
+					b.Fatal(err)
+				}
+				_, err = FromJSONStream(file, func(int, model.State) error { return nil })
+				file.Close()
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	files := benchmarkFiles(b)
+	// the model of both generated files is the same, marshal only one of them
+	for _, size := range []string{"small", "medium", "generated.json"} {
+		workflow, err := FromFile(files[size])
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, format := range []serializer.Format{serializer.FormatJSON, serializer.FormatYAML} {
+			opts := serializer.CanonicalOptions(format)
+			b.Run(strings.TrimSuffix(size, ".json")+"/"+string(format), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := serializer.Marshal(workflow, opts); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	files := benchmarkFiles(b)
+	// the validators with custom validations validate the tags
+	tags := validator.New()
+	if err := tags.RegisterPattern("ticket", "^[A-Z]+-[0-9]+$"); err != nil {
+		b.Fatal(err)
+	}
+	for _, size := range []string{"small", "medium", "generated.json"} {
+		workflow, err := FromFile(files[size])
+		if err != nil {
+			b.Fatal(err)
+		}
+		size = strings.TrimSuffix(size, ".json")
+		b.Run(size+"/generated", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := workflow.Validate(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(size+"/tags", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := tags.Struct(workflow); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkIncrementalUpdate edits a state of the generated workflows, as editors do on every key stroke
+func BenchmarkIncrementalUpdate(b *testing.B) {
+	files := benchmarkFiles(b)
+	for _, size := range []string{"generated.json", "generated.yaml"} {
+		source, err := ioutil.ReadFile(files[size])
+		if err != nil {
+			b.Fatal(err)
+		}
+		inc := NewIncremental(strings.HasSuffix(size, ".yaml"))
+		if _, err := inc.Parse(source); err != nil {
+			b.Fatal(err)
+		}
+		offset := bytes.Index(source, []byte("State500"))
+		b.Run(size, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				// rewrites the name of the state
+				if _, err := inc.Update(Edit{Offset: offset + 5, Length: 3, Text: "500"}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/serverlessworkflow/sdk-go/v2/internal/document"
+)
+
+// maxPooledBuffer capacity above which the buffers aren't returned to the pool, a few huge workflows mustn't keep
+// their memory alive
+const maxPooledBuffer = 4 << 20
+
+// bufferPool scratch buffers holding the file contents and the JSON converted from YAML while parsing. Services
+// parsing workflows per request reuse them instead of allocating buffers the size of the workflow every time. The
+// model never retains the source it's decoded from, the buffers can be reused as soon as the parsing returns.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bufferPool.Put(buf)
+	}
+}
+
+// readFile reads the file into the buffer
+func readFile(buf *bytes.Buffer, r io.Reader, size int64) error {
+	if size > 0 && size < maxPooledBuffer {
+		// one more byte for ReadFrom to see the end of the file without growing the buffer
+		buf.Grow(int(size) + bytes.MinRead)
+	}
+	_, err := buf.ReadFrom(r)
+	return err
+}
+
+// yamlToJSON converts the YAML source to JSON into the buffer, walking the yaml.v3 node tree: the keys keep the order
+// of the document, the anchors, aliases and merge keys are resolved and the errors report the line of the invalid node
+func yamlToJSON(buf *bytes.Buffer, source []byte) error {
+	return document.YAMLToJSON(buf, source)
+}
@@ -0,0 +1,65 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestYAMLToJSON(t *testing.T) {
+	files, err := filepath.Glob("./testdata/workflows/*.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, file := range files {
+		source, err := ioutil.ReadFile(file)
+		require.NoError(t, err)
+		expected, err := yaml.YAMLToJSON(source)
+		require.NoError(t, err)
+		buf := new(bytes.Buffer)
+		require.NoError(t, yamlToJSON(buf, source))
+		assert.JSONEq(t, string(expected), buf.String(), file)
+	}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, yamlToJSON(buf, []byte("1: one\n2.5: two\ntrue: three\nlist: [{a: 1}]\n")))
+	assert.JSONEq(t, `{"1": "one", "2.5": "two", "true": "three", "list": [{"a": 1}]}`, buf.String())
+	assert.EqualError(t, yamlToJSON(buf, []byte("name: a\n~: null\n")), "yaml: line 2: unsupported map key null")
+}
+
+// TestPooledBuffers checks the workflows don't retain the pooled buffers they're parsed from
+func TestPooledBuffers(t *testing.T) {
+	files, err := filepath.Glob("./testdata/workflows/*.sw.*")
+	require.NoError(t, err)
+	parsed := map[string]*model.Workflow{}
+	for _, file := range files {
+		if workflow, err := FromFile(file); err == nil {
+			parsed[file] = workflow
+		}
+	}
+	require.NotEmpty(t, parsed)
+	for file, workflow := range parsed {
+		reparsed, err := FromFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, reparsed, workflow, file)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"github.com/serverlessworkflow/sdk-go/v2/internal/cache"
+	"github.com/serverlessworkflow/sdk-go/v2/metrics"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+// Store storage of the parsed workflows of a Cache, keyed by the hash of their source. The stores keep their own
+// copies of the workflows, the Cache never modifies the workflows it puts or gets.
+type Store interface {
+	Get(key string) (*model.Workflow, bool)
+	Put(key string, workflow *model.Workflow)
+}
+
+// memoryStore Store keeping the most recently used workflows in memory
+type memoryStore struct {
+	lru *cache.LRU
+}
+
+// NewMemoryStore returns a Store keeping the size most recently used workflows in memory
+func NewMemoryStore(size int) Store {
+	return &memoryStore{lru: cache.New(size)}
+}
+
+func (s *memoryStore) Get(key string) (*model.Workflow, bool) {
+	workflow, ok := s.lru.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return workflow.(*model.Workflow), true
+}
+
+func (s *memoryStore) Put(key string, workflow *model.Workflow) {
+	s.lru.Add(key, workflow)
+}
+
+// Cache parse cache of the workflows, keyed by the hash of their source: a workflow whose source didn't change since
+// it was parsed is returned without being decoded and validated again. Tools reloading repositories of mostly
+// unchanged workflows parse only the modified files. The workflows returned are deep copies of the cached ones, the
+// callers can modify them. The files referenced by the workflows, e.g. the events or functions definitions given as
+// files, aren't part of the key: a change to these files alone isn't seen. A Cache is safe for concurrent use if its
+// Store is.
+type Cache struct {
+	Store Store
+	// Parser parser of the workflows not cached yet, default parses like the package functions. A cache must be used
+	// with a single parser, the key of the workflows doesn't include the validations of the parser.
+	Parser *Parser
+}
+
+// NewCache returns a parse cache backed by the store, an in memory store of 1,024 workflows if nil
+func NewCache(store Store) *Cache {
+	if store == nil {
+		store = NewMemoryStore(1024)
+	}
+	return &Cache{Store: store}
+}
+
+// FromYAMLSource parses the given Serverless Workflow YAML source like FromYAMLSource, unless it was already parsed
+func (c *Cache) FromYAMLSource(source []byte) (*model.Workflow, error) {
+	return c.parse(formatYAML, source)
+}
+
+// FromJSONSource parses the given Serverless Workflow JSON source like FromJSONSource, unless it was already parsed
+func (c *Cache) FromJSONSource(source []byte) (*model.Workflow, error) {
+	return c.parse(formatJSON, source)
+}
+
+// FromFile parses the given Serverless Workflow file like FromFile, unless a file with the same content was already
+// parsed. The file is read every time, only its decoding and validation are saved.
+func (c *Cache) FromFile(path string) (*model.Workflow, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := readWorkflowFile(buf, path, c.parser().Limits); err != nil {
+		return nil, newError(path, nil, err)
+	}
+	format := formatJSON
+	if isYAML(path) {
+		format = formatYAML
+	}
+	workflow, err := c.parse(format, buf.Bytes())
+	return workflow, newError(path, nil, err)
+}
+
+func (c *Cache) parse(format string, source []byte) (*model.Workflow, error) {
+	key := cache.Key([]byte(format), source)
+	p := c.parser()
+	if workflow, ok := c.Store.Get(key); ok {
+		p.count(metrics.CacheHits)
+		return workflow.DeepCopy(), nil
+	}
+	p.count(metrics.CacheMisses)
+	workflow := &model.Workflow{}
+	var err error
+	if format == formatYAML {
+		err = p.decodeYAML(source, workflow)
+	} else {
+		err = p.decodeJSON(source, workflow)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.Store.Put(key, workflow.DeepCopy())
+	return workflow, nil
+}
+
+func (c *Cache) parser() *Parser {
+	if c.Parser == nil {
+		return defaultParser
+	}
+	return c.Parser
+}
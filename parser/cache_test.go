@@ -0,0 +1,90 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingStore memory store counting the hits
+type countingStore struct {
+	Store
+	hits int
+}
+
+func (s *countingStore) Get(key string) (*model.Workflow, bool) {
+	workflow, ok := s.Store.Get(key)
+	if ok {
+		s.hits++
+	}
+	return workflow, ok
+}
+
+func TestCache(t *testing.T) {
+	store := &countingStore{Store: NewMemoryStore(8)}
+	c := NewCache(store)
+	for _, file := range []string{"./testdata/workflows/greetings.sw.json", "./testdata/workflows/greetings.sw.yaml"} {
+		expected, err := FromFile(file)
+		require.NoError(t, err)
+		workflow, err := c.FromFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, expected, workflow)
+
+		// the cached workflow isn't shared with the callers
+		workflow.Name = "Modified"
+		cached, err := c.FromFile(file)
+		require.NoError(t, err)
+		assert.True(t, expected.Equal(cached))
+		assert.NotSame(t, cached.States[0], expected.States[0])
+	}
+	assert.Equal(t, 2, store.hits)
+
+	source, err := ioutil.ReadFile("./testdata/workflows/greetings.sw.json")
+	require.NoError(t, err)
+	_, err = c.FromJSONSource(source)
+	require.NoError(t, err)
+	assert.Equal(t, 3, store.hits)
+	// the same bytes in another format are another workflow
+	_, err = c.FromYAMLSource(source)
+	require.NoError(t, err)
+	assert.Equal(t, 3, store.hits)
+}
+
+func TestCacheModifiedFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "greeting.sw.json")
+	source := `{"id": "greeting", "name": "Greeting", "specVersion": "0.8", "start": "Greet",
+  "states": [{"name": "Greet", "type": "inject", "data": {"greeting": "hello"}, "end": true}]}`
+	c := NewCache(nil)
+	require.NoError(t, ioutil.WriteFile(file, []byte(source), 0600))
+	workflow, err := c.FromFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "Greeting", workflow.Name)
+
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{"id": "greeting", "specVersion": "0.8", "states": []}`), 0600))
+	_, err = c.FromFile(file)
+	assert.Error(t, err)
+	// the invalid workflows aren't cached
+	_, err = c.FromFile(file)
+	assert.Error(t, err)
+
+	_, err = c.FromFile(filepath.Join(t.TempDir(), "missing.sw.json"))
+	assert.Error(t, err)
+}
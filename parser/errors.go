@@ -0,0 +1,255 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// Error error of the parsing of a workflow, in the context of the failure: its source, the property and the state
+// and action holding it. The parser functions return their errors as *Error, the original error being unwrapped by
+// errors.Is and errors.As, e.g. to a *model.SchemaError or a *LimitError.
+type Error struct {
+	// Source file path or URL of the workflow, empty if parsed from memory
+	Source string
+	// Pointer JSON pointer of the failing property, see RFC 6901, empty if unknown
+	Pointer string
+	// State name of the state holding the failing property, if any
+	State string
+	// Action name of the action holding the failing property, if any
+	Action string
+	Err    error
+}
+
+// Error returns the message of the error prefixed with its context, e.g.
+// "greetings.sw.json: /states/0/actions/0/functionRef (state Greet, action greet): ..."
+func (e *Error) Error() string {
+	var location, holders []string
+	for _, s := range []string{e.Source, e.Pointer} {
+		if len(s) > 0 {
+			location = append(location, s)
+		}
+	}
+	if len(e.State) > 0 {
+		holders = append(holders, "state "+e.State)
+	}
+	if len(e.Action) > 0 {
+		holders = append(holders, "action "+e.Action)
+	}
+	prefix := strings.Join(location, ": ")
+	if len(holders) > 0 {
+		prefix = strings.TrimSpace(prefix + " (" + strings.Join(holders, ", ") + ")")
+	}
+	if len(prefix) == 0 {
+		return e.Err.Error()
+	}
+	return prefix + ": " + e.Err.Error()
+}
+
+// Unwrap ...
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// newError returns the error in the context of the workflow source, read from the given file or URL. data is the JSON
+// of the workflow, the state and action being named from it if not nil.
+func newError(source string, data []byte, err error) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*Error); ok {
+		if len(e.Source) == 0 {
+			e.Source = source
+		}
+		return e
+	}
+	e := &Error{Source: source, Pointer: errorPointer(data, err), Err: err}
+	if len(e.Pointer) > 0 && data != nil {
+		e.State, e.Action = names(data, e.Pointer)
+	}
+	return e
+}
+
+// errorPointer returns the JSON pointer of the property failing with the error, empty if unknown
+func errorPointer(data []byte, err error) string {
+	var decodeErr *model.DecodeError
+	if errors.As(err, &decodeErr) {
+		return decodeErr.Pointer
+	}
+	var schemaErr *model.SchemaError
+	if errors.As(err, &schemaErr) && len(schemaErr.Errors) > 0 {
+		namespaces := make([]string, 0, len(schemaErr.Errors))
+		for _, fieldErr := range schemaErr.Errors {
+			namespaces = append(namespaces, fieldErr.NameNamespace)
+		}
+		sort.Strings(namespaces)
+		return namespacePointer(namespaces[0])
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) && data != nil {
+		return syntaxPointer(data)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && len(typeErr.Field) > 0 {
+		return "/" + strings.Replace(typeErr.Field, ".", "/", -1)
+	}
+	return ""
+}
+
+// namespacePointer converts the namespace of a validation error into the JSON pointer of the property, e.g.
+// Workflow.States[0].BaseState.Name into /states/0/name
+func namespacePointer(namespace string) string {
+	var b strings.Builder
+	for i, segment := range strings.Split(namespace, ".") {
+		var indexes []string
+		if j := strings.IndexByte(segment, '['); j >= 0 {
+			indexes = strings.Split(strings.TrimSuffix(segment[j+1:], "]"), "][")
+			segment = segment[:j]
+		}
+		if i == 0 || strings.HasPrefix(segment, "Base") || segment == "Common" {
+			continue
+		}
+		b.WriteByte('/')
+		if strings.ToUpper(segment) == segment {
+			// acronyms, e.g. ID
+			b.WriteString(strings.ToLower(segment))
+		} else {
+			r, size := utf8.DecodeRuneInString(segment)
+			b.WriteRune(unicode.ToLower(r))
+			b.WriteString(segment[size:])
+		}
+		for _, index := range indexes {
+			b.WriteByte('/')
+			b.WriteString(index)
+		}
+	}
+	return b.String()
+}
+
+// pointerFrame object or array of the JSON read by syntaxPointer
+type pointerFrame struct {
+	array bool
+	// index of the current element of an array, -1 before the first one
+	index int
+	// key of the current member of an object, value being true once the key is read
+	key   string
+	value bool
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// syntaxPointer returns the JSON pointer of the value whose syntax is invalid in the JSON data
+func syntaxPointer(data []byte) string {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var stack []pointerFrame
+	// valueEnd updates the parent of a value once read
+	valueEnd := func() {
+		if len(stack) > 0 && !stack[len(stack)-1].array {
+			stack[len(stack)-1].value = false
+		}
+	}
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			if len(stack) > 0 && stack[len(stack)-1].array {
+				// the invalid value is the next element
+				stack[len(stack)-1].index++
+			}
+			break
+		}
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.array {
+				if token != json.Delim(']') {
+					top.index++
+				}
+			} else if !top.value && token != json.Delim('}') {
+				top.key, top.value = token.(string), true
+				continue
+			}
+		}
+		switch token {
+		case json.Delim('{'):
+			stack = append(stack, pointerFrame{})
+		case json.Delim('['):
+			stack = append(stack, pointerFrame{array: true, index: -1})
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+			valueEnd()
+		default:
+			valueEnd()
+		}
+	}
+	var b strings.Builder
+	for _, frame := range stack {
+		switch {
+		case frame.array && frame.index >= 0:
+			b.WriteString("/" + strconv.Itoa(frame.index))
+		case !frame.array && frame.value:
+			b.WriteString("/" + pointerEscaper.Replace(frame.key))
+		}
+	}
+	return b.String()
+}
+
+// names returns the names of the state and action holding the value at the JSON pointer of the workflow JSON data
+func names(data []byte, pointer string) (state, action string) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", ""
+	}
+	key := ""
+	for _, token := range strings.Split(pointer, "/")[1:] {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return state, action
+			}
+			value = v[i]
+		default:
+			return state, action
+		}
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			key = token
+			continue
+		}
+		name, _ := object["name"].(string)
+		switch key {
+		case "states":
+			state, action = name, ""
+		case "actions":
+			action = name
+		}
+		if token == "action" {
+			action = name
+		}
+		key = token
+	}
+	return state, action
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	err := errors.New("failed")
+	assert.EqualError(t, &Error{Err: err}, "failed")
+	assert.EqualError(t, &Error{Source: "a.sw.json", Err: err}, "a.sw.json: failed")
+	assert.EqualError(t, &Error{Pointer: "/states/0", State: "Greet", Err: err}, "/states/0 (state Greet): failed")
+	assert.EqualError(t, &Error{Source: "a.sw.json", Pointer: "/states/0/actions/1", State: "Greet", Action: "hello", Err: err},
+		"a.sw.json: /states/0/actions/1 (state Greet, action hello): failed")
+	assert.EqualError(t, &Error{State: "Greet", Err: err}, "(state Greet): failed")
+}
+
+func TestParserErrorContext(t *testing.T) {
+	_, err := FromJSONSource([]byte(`{"id": "a", "states": [{"name": "Greet", "type": "inject", "end": true},
+  {"name": "Wait", "type": "wait"}]}`))
+	var parseErr *Error
+	require.True(t, errors.As(err, &parseErr), "%v", err)
+	assert.Equal(t, &Error{Pointer: "/states/1", State: "Wait", Err: parseErr.Err}, parseErr)
+	assert.True(t, errors.Is(err, model.ErrUnknownStateType))
+	assert.EqualError(t, err, "/states/1 (state Wait): state wait not supported")
+
+	_, err = FromYAMLSource([]byte("id: a\nstates:\n- name: Greet\n  type: inject\n  data:\n    a: [1\n"))
+	require.True(t, errors.As(err, &parseErr), "%v", err)
+	assert.Empty(t, parseErr.Pointer)
+
+	_, err = FromJSONSource([]byte(`{"id": "a", "states": [{"name": "Greet", "type": "inject", "data": {"a": }}]}`))
+	require.True(t, errors.As(err, &parseErr), "%v", err)
+	assert.Equal(t, "/states/0/data/a", parseErr.Pointer)
+	var syntaxErr *json.SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+
+	_, err = FromJSONSource([]byte(`{"id": 3}`))
+	require.True(t, errors.As(err, &parseErr), "%v", err)
+	assert.Equal(t, "/id", parseErr.Pointer)
+
+	file := "./testdata/workflows/witherrors/applicationrequest.authdupl.json"
+	for _, parse := range []func(string) (*model.Workflow, error){FromFile, NewCache(NewMemoryStore(1)).FromFile} {
+		_, err = parse(file)
+		require.True(t, errors.As(err, &parseErr), "%v", err)
+		assert.Equal(t, file, parseErr.Source)
+		assert.Equal(t, "/auth/name", parseErr.Pointer)
+		var schemaErr *model.SchemaError
+		assert.True(t, errors.As(err, &schemaErr))
+	}
+
+	_, err = FromFile("./testdata/workflows/missing.sw.json")
+	require.True(t, errors.As(err, &parseErr), "%v", err)
+	assert.Equal(t, "./testdata/workflows/missing.sw.json", parseErr.Source)
+
+	_, err = (&Parser{Limits: Limits{MaxSize: 8}}).FromJSONSource([]byte(`{"id": "a"}`))
+	require.True(t, errors.As(err, &parseErr), "%v", err)
+	assertLimit(t, "MaxSize", err)
+
+	_, err = NewIncremental(false).Parse([]byte(`{"id": "a", "states": [{"name": "Wait", "type": "wait"}]}`))
+	require.True(t, errors.As(err, &parseErr), "%v", err)
+	assert.Equal(t, "Wait", parseErr.State)
+}
+
+func TestNamespacePointer(t *testing.T) {
+	assert.Equal(t, "/name", namespacePointer("Workflow.BaseWorkflow.Name"))
+	assert.Equal(t, "/id", namespacePointer("Workflow.BaseWorkflow.ID"))
+	assert.Equal(t, "/states/0/actions/1/functionRef/refName",
+		namespacePointer("Workflow.States[0].Actions[1].FunctionRef.RefName"))
+}
+
+func TestSyntaxPointer(t *testing.T) {
+	assert.Equal(t, "/id", syntaxPointer([]byte(`{"id": }`)))
+	assert.Equal(t, "/states/1", syntaxPointer([]byte(`{"states": [{}, ]}`)))
+	assert.Equal(t, "/a~1b/0", syntaxPointer([]byte(`{"a/b": [tru]}`)))
+}
+
+func TestNames(t *testing.T) {
+	data := []byte(`{"states": [{"name": "Greet", "actions": [{"name": "a"}, {"name": "b", "functionRef": {}}]},
+  {"name": "Callback", "action": {"name": "c", "functionRef": {}}}]}`)
+	state, action := names(data, "/states/0/actions/1/functionRef")
+	assert.Equal(t, "Greet", state)
+	assert.Equal(t, "b", action)
+	state, action = names(data, "/states/1/action/functionRef")
+	assert.Equal(t, "Callback", state)
+	assert.Equal(t, "c", action)
+	state, action = names(data, "/states/0/name")
+	assert.Equal(t, "Greet", state)
+	assert.Empty(t, action)
+	state, action = names(data, "/name")
+	assert.Empty(t, state)
+	assert.Empty(t, action)
+}
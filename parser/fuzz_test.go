@@ -0,0 +1,95 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package parser
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// fuzzLimits limits of the fuzzed workflows, keeping the inputs generated by the fuzzer fast to parse
+var fuzzLimits = Limits{MaxSize: 1 << 20, MaxDepth: 64, MaxStates: 256, MaxActions: 1024}
+
+// addCorpus seeds the fuzz target with the workflows of the testdata of the given extensions, the ones fetching URLs
+// excepted
+func addCorpus(f *testing.F, exts ...string) {
+	for _, ext := range exts {
+		files, err := filepath.Glob(filepath.Join("testdata", "workflows", "*"+ext))
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, file := range files {
+			if strings.Contains(file, ".url.") {
+				continue
+			}
+			source, err := ioutil.ReadFile(file)
+			if err != nil {
+				f.Fatal(err)
+			}
+			f.Add(source)
+		}
+	}
+}
+
+// checkRoundTrip checks that a parsed workflow marshals to JSON parsing into the same JSON
+func checkRoundTrip(t *testing.T, p *Parser, workflow *model.Workflow) {
+	data, err := workflow.MarshalJSON()
+	if err != nil {
+		t.Fatalf("parsed workflow doesn't marshal: %v", err)
+	}
+	again, err := p.FromJSONSource(data)
+	if err != nil {
+		t.Fatalf("marshaled workflow doesn't parse: %v\n%s", err, data)
+	}
+	dataAgain, err := again.MarshalJSON()
+	if err != nil {
+		t.Fatalf("parsed workflow doesn't marshal: %v", err)
+	}
+	if !bytes.Equal(data, dataAgain) {
+		t.Fatalf("workflow changed by a round trip:\n%s\n%s", data, dataAgain)
+	}
+}
+
+func FuzzFromJSONSource(f *testing.F) {
+	addCorpus(f, extJSON)
+	p := &Parser{Limits: fuzzLimits}
+	f.Fuzz(func(t *testing.T, source []byte) {
+		workflow, err := p.FromJSONSource(source)
+		if err != nil {
+			return
+		}
+		checkRoundTrip(t, p, workflow)
+	})
+}
+
+func FuzzFromYAMLSource(f *testing.F) {
+	addCorpus(f, extYAML, extYML)
+	p := &Parser{Limits: fuzzLimits}
+	f.Fuzz(func(t *testing.T, source []byte) {
+		workflow, err := p.FromYAMLSource(source)
+		if err != nil {
+			return
+		}
+		checkRoundTrip(t, p, workflow)
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the tests")
+
+// TestMarshalGolden checks that the test workflows marshal to their golden files, recorded with the reflection of
+// encoding/json, and that their JSON decodes and marshals back unchanged
+func TestMarshalGolden(t *testing.T) {
+	files, err := filepath.Glob("testdata/workflows/*.*")
+	require.NoError(t, err)
+	for _, file := range files {
+		// the remote definitions may change
+		if strings.Contains(file, ".url.") {
+			continue
+		}
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			workflow, err := FromFile(file)
+			require.NoError(t, err)
+			data, err := json.Marshal(workflow)
+			require.NoError(t, err)
+			indented := new(bytes.Buffer)
+			require.NoError(t, json.Indent(indented, data, "", "  "))
+			indented.WriteByte('\n')
+
+			golden := filepath.Join("testdata", "golden", filepath.Base(file)+".json")
+			if *update {
+				require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0755))
+				require.NoError(t, ioutil.WriteFile(golden, indented.Bytes(), 0644))
+			}
+			expected, err := ioutil.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), indented.String())
+
+			// the raw values, e.g. the constants, are compacted by the first marshaling
+			decoded := &model.Workflow{}
+			require.NoError(t, json.Unmarshal(data, decoded))
+			again, err := json.Marshal(decoded)
+			require.NoError(t, err)
+			assert.Equal(t, string(data), string(again))
+		})
+	}
+}
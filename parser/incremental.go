@@ -0,0 +1,313 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// Edit change of a source, the bytes from Offset to Offset+Length being replaced by Text
+type Edit struct {
+	Offset int
+	Length int
+	Text   string
+}
+
+// DiffEdit returns the edit changing the old source into the new one, spanning from their first to their last
+// different bytes, e.g. for the editors sending the whole text of the documents
+func DiffEdit(old, new []byte) Edit {
+	prefix := 0
+	for prefix < len(old) && prefix < len(new) && old[prefix] == new[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(new)-prefix && old[len(old)-1-suffix] == new[len(new)-1-suffix] {
+		suffix++
+	}
+	return Edit{Offset: prefix, Length: len(old) - prefix - suffix, Text: string(new[prefix : len(new)-suffix])}
+}
+
+// span range of a value in a JSON document
+type span struct {
+	start, end int
+}
+
+// Incremental parser of a workflow source kept across its edits, e.g. by editors and file watchers. An update decodes
+// again the changed parts of the workflow only: an edit of a JSON source within a state decodes that state only, and
+// other edits decode the top level properties and states whose JSON changed. The unchanged states of the updated
+// workflow are those of the previous one, the workflows returned must be treated as read only. The limits of the
+// parser apply to every update, but its timeout. An Incremental isn't safe for concurrent use.
+type Incremental struct {
+	parser *Parser
+	yaml   bool
+	source []byte
+
+	// members raw JSON of the top level properties but the states, nil if the source couldn't be split
+	members map[string]json.RawMessage
+	// raw JSON of the states, and their spans in the source if it's JSON
+	raw    [][]byte
+	spans  []span
+	states []model.State
+	base   *model.Workflow
+}
+
+// NewIncremental returns an incremental parser of JSON sources, or YAML sources if yaml is true, validating the
+// workflows with the default validator
+func NewIncremental(yaml bool) *Incremental {
+	return defaultParser.NewIncremental(yaml)
+}
+
+// NewIncremental returns an incremental parser of JSON sources, or YAML sources if yaml is true, validating the
+// workflows with the validator of the parser
+func (p *Parser) NewIncremental(yaml bool) *Incremental {
+	return &Incremental{parser: p, yaml: yaml}
+}
+
+// Source returns the current source, edits included
+func (i *Incremental) Source() []byte {
+	return i.source
+}
+
+// Parse parses the whole source, replacing the current one
+func (i *Incremental) Parse(source []byte) (_ *model.Workflow, err error) {
+	defer i.parser.measure(time.Now(), -1, &err)
+	defer i.locate(&err)
+	i.source = append([]byte(nil), source...)
+	i.members, i.raw, i.spans, i.states, i.base = nil, nil, nil, nil, nil
+	return i.update()
+}
+
+// Update applies the edits to the source, in order, each edit's offset being in the source edited by the previous
+// ones, and parses the changed parts of the workflow
+func (i *Incremental) Update(edits ...Edit) (_ *model.Workflow, err error) {
+	defer i.parser.measure(time.Now(), -1, &err)
+	defer i.locate(&err)
+	index, delta, inState := -1, 0, !i.yaml && i.spans != nil
+	for _, edit := range edits {
+		if edit.Offset < 0 || edit.Length < 0 || edit.Offset+edit.Length > len(i.source) {
+			return nil, fmt.Errorf("edit of %d bytes at %d is out of the source of %d bytes", edit.Length, edit.Offset, len(i.source))
+		}
+		if inState {
+			index, inState = i.stateAt(edit, index, delta)
+		}
+		delta += len(edit.Text) - edit.Length
+		i.source = append(i.source[:edit.Offset], append([]byte(edit.Text), i.source[edit.Offset+edit.Length:]...)...)
+	}
+	if err := i.parser.Limits.checkSize(int64(len(i.source))); err != nil {
+		i.members, i.spans = nil, nil
+		return nil, err
+	}
+	if inState && index >= 0 {
+		if err := i.parser.Limits.checkJSON(i.source); err != nil {
+			i.members, i.spans = nil, nil
+			return nil, err
+		}
+		if workflow, ok := i.updateState(index, delta); ok {
+			return i.validate(workflow)
+		}
+	}
+	return i.update()
+}
+
+// stateAt returns the index of the state containing the edit, strictly so that its braces aren't edited, if it's the
+// same as the one of the previous edits. delta is the length the previous edits added to the state.
+func (i *Incremental) stateAt(edit Edit, previous, delta int) (int, bool) {
+	for index, s := range i.spans {
+		end := s.end
+		if index == previous {
+			end += delta
+		}
+		if edit.Offset > s.start && edit.Offset+edit.Length < end {
+			return index, previous < 0 || previous == index
+		}
+	}
+	return -1, false
+}
+
+// updateState decodes the edited state, returns false if it's no longer a single state
+func (i *Incremental) updateState(index, delta int) (*model.Workflow, bool) {
+	s := span{start: i.spans[index].start, end: i.spans[index].end + delta}
+	raw := append([]byte(nil), i.source[s.start:s.end]...)
+	state, err := model.UnmarshalState(raw)
+	if err != nil {
+		return nil, false
+	}
+	i.raw[index], i.spans[index], i.states[index] = raw, s, state
+	for j := index + 1; j < len(i.spans); j++ {
+		i.spans[j].start += delta
+		i.spans[j].end += delta
+	}
+	return i.workflow(), true
+}
+
+// update splits the source into its top level properties and states, and decodes those that changed
+func (i *Incremental) update() (*model.Workflow, error) {
+	data := i.source
+	if i.yaml {
+		buf := getBuffer()
+		defer putBuffer(buf)
+		if err := yamlToJSON(buf, i.source); err != nil {
+			i.members = nil
+			return nil, err
+		}
+		data = buf.Bytes()
+	}
+	if err := i.parser.Limits.checkJSON(data); err != nil {
+		i.members, i.spans = nil, nil
+		return nil, err
+	}
+	members, statesSpan, spans, err := splitWorkflow(data)
+	if err != nil || statesSpan == nil {
+		// the decoding reports the error
+		i.members, i.spans = nil, nil
+		return i.decode(data)
+	}
+
+	if i.base == nil || !sameMembers(i.members, members) {
+		// the workflow but its states, decoded with no state
+		without := make([]byte, 0, len(data))
+		without = append(append(append(without, data[:statesSpan.start]...), "[]"...), data[statesSpan.end:]...)
+		base := &model.Workflow{}
+		if err := json.Unmarshal(without, base); err != nil {
+			// the decoding of the whole source reports the error at its offset in the source
+			i.members = nil
+			return i.decode(data)
+		}
+		i.base = base
+	}
+	previous := make(map[string]model.State, len(i.states))
+	for j, raw := range i.raw {
+		previous[string(raw)] = i.states[j]
+	}
+	raw := make([][]byte, len(spans))
+	states := make([]model.State, len(spans))
+	for j, s := range spans {
+		raw[j] = append([]byte(nil), data[s.start:s.end]...)
+		if state, ok := previous[string(raw[j])]; ok {
+			states[j] = state
+		} else if states[j], err = model.UnmarshalState(raw[j]); err != nil {
+			i.members = nil
+			return nil, &model.DecodeError{Pointer: "/states/" + strconv.Itoa(j), Err: err}
+		}
+	}
+	i.members, i.raw, i.states = members, raw, states
+	i.spans = nil
+	if !i.yaml {
+		i.spans = spans
+	}
+	return i.validate(i.workflow())
+}
+
+// locate returns the error as an *Error located in the current source
+func (i *Incremental) locate(err *error) {
+	var data []byte
+	if !i.yaml {
+		data = i.source
+	}
+	*err = newError("", data, *err)
+}
+
+// workflow assembles the workflow from its decoded parts
+func (i *Incremental) workflow() *model.Workflow {
+	workflow := &model.Workflow{
+		BaseWorkflow: i.base.BaseWorkflow,
+		States:       append([]model.State(nil), i.states...),
+		Events:       i.base.Events,
+		Functions:    i.base.Functions,
+		Retries:      i.base.Retries,
+	}
+	workflow.Reindex()
+	return workflow
+}
+
+// decode decodes the whole JSON source
+func (i *Incremental) decode(data []byte) (*model.Workflow, error) {
+	workflow := &model.Workflow{}
+	if err := i.parser.decode(data, workflow); err != nil {
+		return nil, err
+	}
+	return workflow, nil
+}
+
+// validate validates the workflow, returned unless invalid as the parser functions do
+func (i *Incremental) validate(workflow *model.Workflow) (*model.Workflow, error) {
+	if err := i.parser.validate(workflow); err != nil {
+		return nil, err
+	}
+	return workflow, nil
+}
+
+// splitWorkflow returns the raw JSON of the top level properties of the workflow but the states, the span of the
+// states and the spans of each state
+func splitWorkflow(data []byte) (map[string]json.RawMessage, *span, []span, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if token, err := dec.Token(); err != nil || token != json.Delim('{') {
+		return nil, nil, nil, fmt.Errorf("invalid workflow: expected an object")
+	}
+	members := map[string]json.RawMessage{}
+	var statesSpan *span
+	var spans []span
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		key := token.(string)
+		if key != "states" {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, nil, nil, err
+			}
+			members[key] = raw
+			continue
+		}
+		if token, err := dec.Token(); err != nil || token != json.Delim('[') {
+			return nil, nil, nil, fmt.Errorf("invalid workflow: states must be an array")
+		}
+		start := int(dec.InputOffset()) - 1
+		spans = spans[:0]
+		for dec.More() {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, nil, nil, err
+			}
+			end := int(dec.InputOffset())
+			spans = append(spans, span{start: end - len(raw), end: end})
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, nil, nil, err
+		}
+		statesSpan = &span{start: start, end: int(dec.InputOffset())}
+	}
+	return members, statesSpan, spans, nil
+}
+
+func sameMembers(a, b map[string]json.RawMessage) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, raw := range a {
+		if other, ok := b[key]; !ok || !bytes.Equal(raw, other) {
+			return false
+		}
+	}
+	return true
+}
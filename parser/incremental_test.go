@@ -0,0 +1,148 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+// replace returns the edit replacing the first occurrence of old in the source
+func replace(t *testing.T, source []byte, old, new string) Edit {
+	offset := bytes.Index(source, []byte(old))
+	require.GreaterOrEqual(t, offset, 0, old)
+	return Edit{Offset: offset, Length: len(old), Text: new}
+}
+
+// apply returns the source with the edit applied
+func apply(source []byte, edit Edit) []byte {
+	return append(append(append([]byte(nil), source[:edit.Offset]...), edit.Text...), source[edit.Offset+edit.Length:]...)
+}
+
+func TestDiffEdit(t *testing.T) {
+	assert.Equal(t, Edit{Offset: 2, Length: 1, Text: "xy"}, DiffEdit([]byte("abcde"), []byte("abxyde")))
+	assert.Equal(t, Edit{Offset: 5, Length: 0, Text: "f"}, DiffEdit([]byte("abcde"), []byte("abcdef")))
+	assert.Equal(t, Edit{Offset: 0, Length: 2, Text: ""}, DiffEdit([]byte("abcde"), []byte("cde")))
+	assert.Equal(t, Edit{Offset: 3, Length: 0, Text: ""}, DiffEdit([]byte("abc"), []byte("abc")))
+	// repeated bytes are matched by the prefix first
+	assert.Equal(t, Edit{Offset: 2, Length: 0, Text: "a"}, DiffEdit([]byte("aa"), []byte("aaa")))
+}
+
+func TestIncrementalState(t *testing.T) {
+	source := generateWorkflow(8)
+	inc := NewIncremental(false)
+	previous, err := inc.Parse(source)
+	require.NoError(t, err)
+
+	// edits within a state decode that state only
+	first := replace(t, source, `"index": 4}`, `"index": 40}`)
+	second := replace(t, apply(source, first), `"State4", "type": "operation"`, `"State4", "type": "operation", "usedForCompensation": false`)
+	workflow, err := inc.Update(first, second)
+	require.NoError(t, err)
+	for i, state := range workflow.States {
+		if i == 4 {
+			assert.NotSame(t, previous.States[i], state)
+		} else {
+			assert.Same(t, previous.States[i], state)
+		}
+	}
+	expected, err := FromJSONSource(inc.Source())
+	require.NoError(t, err)
+	assert.Equal(t, expected, workflow)
+
+	// the spans of the following states moved with the edit
+	workflow, err = inc.Update(replace(t, inc.Source(), `"step": 7`, `"step": 70`))
+	require.NoError(t, err)
+	expected, err = FromJSONSource(inc.Source())
+	require.NoError(t, err)
+	assert.Equal(t, expected, workflow)
+	assert.Same(t, previous.States[3], workflow.States[3])
+}
+
+func TestIncrementalSections(t *testing.T) {
+	source := generateWorkflow(8)
+	inc := NewIncremental(false)
+	previous, err := inc.Parse(source)
+	require.NoError(t, err)
+
+	// edits of the other properties keep the states
+	workflow, err := inc.Update(replace(t, source, `"name": "Generated"`, `"name": "Renamed"`))
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", workflow.Name)
+	for i, state := range workflow.States {
+		assert.Same(t, previous.States[i], state)
+	}
+
+	// a removed state, the state count changing
+	first := replace(t, inc.Source(), `"transition": "State7"`, `"end": true`)
+	source = apply(inc.Source(), first)
+	start := bytes.Index(source, []byte(`{"name": "State7"`))
+	workflow, err = inc.Update(first, Edit{Offset: start - 2, Length: len(source) - start + 2, Text: "]}"})
+	require.NoError(t, err)
+	require.Len(t, workflow.States, 7)
+	expected, err := FromJSONSource(inc.Source())
+	require.NoError(t, err)
+	assert.Equal(t, expected, workflow)
+	assert.Same(t, previous.States[0], workflow.States[0])
+	_, ok := workflow.State("State7")
+	assert.False(t, ok)
+}
+
+func TestIncrementalInvalid(t *testing.T) {
+	source := generateWorkflow(4)
+	inc := NewIncremental(false)
+	previous, err := inc.Parse(source)
+	require.NoError(t, err)
+
+	// a syntax error, then a state that's no longer valid
+	edit := replace(t, source, `"type": "switch"`, `"type": `)
+	_, err = inc.Update(edit)
+	assert.Error(t, err)
+	_, err = inc.Update(Edit{Offset: edit.Offset, Length: len(edit.Text), Text: `"type": "unknown"`})
+	assert.Error(t, err)
+	_, err = inc.Update(replace(t, inc.Source(), `"start": "State0"`, `"start": ""`))
+	assert.Error(t, err)
+	_, err = inc.Update(replace(t, inc.Source(), `"unknown"`, `"switch"`))
+	assert.Error(t, err)
+
+	// fixed, the unchanged states are kept
+	workflow, err := inc.Update(replace(t, inc.Source(), `"start": ""`, `"start": "State0"`))
+	require.NoError(t, err)
+	assert.Equal(t, previous, workflow)
+	assert.Same(t, previous.States[2], workflow.States[2])
+
+	_, err = inc.Update(Edit{Offset: len(inc.Source()), Length: 1})
+	assert.Error(t, err)
+}
+
+func TestIncrementalYAML(t *testing.T) {
+	source, err := yaml.JSONToYAML(generateWorkflow(8))
+	require.NoError(t, err)
+	inc := NewIncremental(true)
+	previous, err := inc.Parse(source)
+	require.NoError(t, err)
+
+	workflow, err := inc.Update(replace(t, source, "step: 7", "step: 70"))
+	require.NoError(t, err)
+	expected, err := FromYAMLSource(inc.Source())
+	require.NoError(t, err)
+	assert.Equal(t, expected, workflow)
+	assert.NotSame(t, previous.States[7], workflow.States[7])
+	assert.Same(t, previous.States[6], workflow.States[6])
+}
@@ -0,0 +1,198 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Limits guards of the parsing of untrusted workflows, e.g. posted by the users of a service, against the documents
+// made to exhaust its memory or CPU. The zero values don't limit.
+type Limits struct {
+	// MaxSize maximum size in bytes of the source, before its conversion from YAML
+	MaxSize int64
+	// MaxDepth maximum nesting depth of the objects and arrays
+	MaxDepth int
+	// MaxStates maximum number of states
+	MaxStates int
+	// MaxActions maximum number of actions, counted in all the states, branches and event handlers
+	MaxActions int
+	// Timeout maximum duration of the decoding and validation. The parsing abandoned when timing out completes in the
+	// background, the other limits bounding its cost.
+	Timeout time.Duration
+}
+
+// LimitError error of a workflow exceeding the limits of the parser
+type LimitError struct {
+	// Limit name of the Limits field exceeded, e.g. MaxStates
+	Limit string
+	// Max value of the limit
+	Max interface{}
+}
+
+// Error ...
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("workflow exceeds the %s limit of %v", e.Limit, e.Max)
+}
+
+func (l Limits) checkSize(size int64) error {
+	if l.MaxSize > 0 && size > l.MaxSize {
+		return &LimitError{Limit: "MaxSize", Max: l.MaxSize}
+	}
+	return nil
+}
+
+// limitReader returns the reader of a source, failing once it read more than the maximum size
+func (l Limits) limitReader(r io.Reader) io.Reader {
+	if l.MaxSize <= 0 {
+		return r
+	}
+	return &sizeReader{r: io.LimitReader(r, l.MaxSize+1), limits: l}
+}
+
+type sizeReader struct {
+	r      io.Reader
+	limits Limits
+	read   int64
+}
+
+func (r *sizeReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.read += int64(n)
+	if sizeErr := r.limits.checkSize(r.read); sizeErr != nil {
+		return n, sizeErr
+	}
+	return n, err
+}
+
+// withTimeout calls fn with the source, returning a LimitError if it doesn't return before the timeout. fn is given a
+// copy of the source then, the caller being free to reuse it once timed out.
+func (l Limits) withTimeout(source []byte, fn func(source []byte) error) error {
+	if l.Timeout <= 0 {
+		return fn(source)
+	}
+	source = append([]byte(nil), source...)
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(source)
+	}()
+	timer := time.NewTimer(l.Timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return &LimitError{Limit: "Timeout", Max: l.Timeout}
+	}
+}
+
+// container kinds of the scanned objects and arrays
+const (
+	object = iota
+	array
+	statesArray
+	actionsArray
+)
+
+// checkJSON checks the nesting depth, states and actions of the JSON source before it's decoded, scanning it once
+// without allocating beyond the nesting. The invalid JSON is left to the decoding to report.
+func (l Limits) checkJSON(data []byte) error {
+	if l.MaxDepth <= 0 && l.MaxStates <= 0 && l.MaxActions <= 0 {
+		return nil
+	}
+	var stack []int
+	var key []byte
+	states, actions := 0, 0
+	// value whether the next token starts a value, isKey whether it's the key of an object member
+	value, isKey := true, false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case ':':
+			value = true
+			continue
+		case ',':
+			if len(stack) > 0 && stack[len(stack)-1] == object {
+				isKey = true
+			} else {
+				value = true
+			}
+			continue
+		case '}', ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			value = false
+			continue
+		}
+
+		if value && len(stack) > 0 {
+			switch stack[len(stack)-1] {
+			case statesArray:
+				if states++; l.MaxStates > 0 && states > l.MaxStates {
+					return &LimitError{Limit: "MaxStates", Max: l.MaxStates}
+				}
+			case actionsArray:
+				if actions++; l.MaxActions > 0 && actions > l.MaxActions {
+					return &LimitError{Limit: "MaxActions", Max: l.MaxActions}
+				}
+			}
+		}
+		switch c {
+		case '"':
+			start := i + 1
+			for i++; i < len(data) && data[i] != '"'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+			if isKey {
+				key = data[start:min(i, len(data))]
+			}
+		case '{', '[':
+			kind := object
+			if c == '[' {
+				kind = array
+				if value && len(stack) > 0 && stack[len(stack)-1] == object {
+					switch {
+					case len(stack) == 1 && string(key) == "states":
+						kind = statesArray
+					case string(key) == "actions":
+						kind = actionsArray
+					}
+				}
+			}
+			if stack = append(stack, kind); l.MaxDepth > 0 && len(stack) > l.MaxDepth {
+				return &LimitError{Limit: "MaxDepth", Max: l.MaxDepth}
+			}
+			isKey = kind == object
+			value = kind != object
+			continue
+		}
+		value, isKey = false, false
+	}
+	return nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
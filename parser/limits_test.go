@@ -0,0 +1,119 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+// assertLimit asserts that the error is a LimitError of the limit
+func assertLimit(t *testing.T, limit string, err error) {
+	var limitErr *LimitError
+	if assert.True(t, errors.As(err, &limitErr), "%v", err) {
+		assert.Equal(t, limit, limitErr.Limit)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	// 8 states of 6 actions, nested 7 levels deep in the functionRef arguments
+	source := generateWorkflow(8)
+	sourceYAML, err := yaml.JSONToYAML(source)
+	require.NoError(t, err)
+	_, err = (&Parser{Limits: Limits{MaxSize: int64(len(source)), MaxDepth: 7, MaxStates: 8, MaxActions: 6}}).FromJSONSource(source)
+	require.NoError(t, err)
+
+	for limit, limits := range map[string]Limits{
+		"MaxSize":    {MaxSize: int64(len(source)) - 1},
+		"MaxDepth":   {MaxDepth: 6},
+		"MaxStates":  {MaxStates: 7},
+		"MaxActions": {MaxActions: 5},
+	} {
+		t.Run(limit, func(t *testing.T) {
+			p := &Parser{Limits: limits}
+			_, err := p.FromJSONSource(source)
+			assertLimit(t, limit, err)
+			_, err = p.FromJSONSourceLazy(source)
+			assertLimit(t, limit, err)
+			if limit != "MaxSize" {
+				_, err = p.FromYAMLSource(sourceYAML)
+				assertLimit(t, limit, err)
+			}
+		})
+	}
+
+	// strings and escapes aren't structure
+	_, err = (&Parser{Limits: Limits{MaxDepth: 3, MaxStates: 1}}).FromJSONSource([]byte(`{"name": "[[\"{", "states": [{"name": "A]\\\"}, {", "type": "inject", "end": true}]}`))
+	require.Error(t, err)
+	var limitErr *LimitError
+	assert.False(t, errors.As(err, &limitErr))
+}
+
+func TestLimitsFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "generated.sw.json")
+	source := generateWorkflow(8)
+	require.NoError(t, ioutil.WriteFile(file, source, 0600))
+	_, err := (&Parser{Limits: Limits{MaxSize: int64(len(source))}}).FromFile(file)
+	require.NoError(t, err)
+	_, err = (&Parser{Limits: Limits{MaxSize: 100}}).FromFile(file)
+	assertLimit(t, "MaxSize", err)
+	_, err = (&Cache{Store: NewMemoryStore(1), Parser: &Parser{Limits: Limits{MaxStates: 1}}}).FromFile(file)
+	assertLimit(t, "MaxStates", err)
+}
+
+func TestLimitsStream(t *testing.T) {
+	source := string(generateWorkflow(8))
+	calls := 0
+	_, err := (&Parser{Limits: Limits{MaxStates: 2}}).FromJSONStream(strings.NewReader(source), func(int, model.State) error {
+		calls++
+		return nil
+	})
+	assertLimit(t, "MaxStates", err)
+	assert.Equal(t, 2, calls)
+	_, err = (&Parser{Limits: Limits{MaxSize: 100}}).FromJSONStream(strings.NewReader(source), func(int, model.State) error {
+		return nil
+	})
+	assertLimit(t, "MaxSize", err)
+}
+
+func TestLimitsTimeout(t *testing.T) {
+	source := generateWorkflow(1000)
+	_, err := (&Parser{Limits: Limits{Timeout: time.Nanosecond}}).FromJSONSource(source)
+	assertLimit(t, "Timeout", err)
+	_, err = (&Parser{Limits: Limits{Timeout: time.Minute}}).FromJSONSource(source)
+	assert.NoError(t, err)
+}
+
+func TestLimitsIncremental(t *testing.T) {
+	source := generateWorkflow(8)
+	inc := (&Parser{Limits: Limits{MaxActions: 6}}).NewIncremental(false)
+	_, err := inc.Parse(source)
+	require.NoError(t, err)
+	// an action added within a state
+	edit := replace(t, source, `"actions": [`, `"actions": [{"functionRef": "store"}, `)
+	_, err = inc.Update(edit)
+	assertLimit(t, "MaxActions", err)
+	_, err = inc.Update(Edit{Offset: edit.Offset, Length: len(edit.Text), Text: `"actions": [`})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/serverlessworkflow/sdk-go/v2/logging"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// definitionKinds kinds of the definitions that may be read from a file referenced by the workflow
+var definitionKinds = []string{"events", "functions", "retries", "errors"}
+
+// logDecoded logs the references resolved and the defaults applied by the decoding of the JSON source, if the parser
+// has a logger
+func (p *Parser) logDecoded(source []byte) {
+	if p.Logger == nil {
+		return
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(source, &members); err != nil {
+		return
+	}
+	for _, kind := range definitionKinds {
+		var ref string
+		if raw, ok := members[kind]; ok && json.Unmarshal(raw, &ref) == nil {
+			p.Logger.Debug("definitions reference resolved", logging.Kind, kind, logging.Ref, ref)
+		}
+	}
+	if _, ok := members["expressionLang"]; !ok {
+		p.Logger.Debug("default applied", logging.Pointer, "/expressionLang", logging.Value, model.DefaultExpressionLang)
+	}
+	var states []map[string]json.RawMessage
+	if err := json.Unmarshal(members["states"], &states); err != nil {
+		return
+	}
+	for i, state := range states {
+		var stateType model.StateType
+		if err := json.Unmarshal(state["type"], &stateType); err != nil || stateType != model.StateTypeEvent {
+			continue
+		}
+		if _, ok := state["exclusive"]; !ok {
+			p.Logger.Debug("default applied", logging.Pointer, "/states/"+strconv.Itoa(i)+"/exclusive", logging.Value, true)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/logging"
+	"github.com/serverlessworkflow/sdk-go/v2/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventLogger Logger keeping the events as text
+type eventLogger struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *eventLogger) Debug(msg string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, fmt.Sprint(append([]interface{}{msg}, args...)...))
+}
+
+func TestParserLogger(t *testing.T) {
+	logger := &eventLogger{}
+	p := &Parser{Logger: logger, ValidationCache: validator.NewMemoryCache(1)}
+	_, err := p.FromFile("./testdata/workflows/eventbasedgreeting.sw.p.json")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		fmt.Sprint("definitions reference resolved", logging.Kind, "events", logging.Ref, "testdata/eventbasedgreetingevents.json"),
+		fmt.Sprint("default applied", logging.Pointer, "/expressionLang", logging.Value, "jq"),
+		fmt.Sprint("default applied", logging.Pointer, "/states/0/exclusive", logging.Value, true),
+	}, logger.events)
+
+	logger.events = nil
+	_, err = p.FromFile("./testdata/workflows/eventbasedgreeting.sw.p.json")
+	require.NoError(t, err)
+	require.Len(t, logger.events, 4)
+	assert.Contains(t, logger.events[3], "validation skipped")
+
+	// no logger, no events
+	_, err = FromFile("./testdata/workflows/eventbasedgreeting.sw.p.json")
+	assert.NoError(t, err)
+}
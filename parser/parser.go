@@ -15,16 +15,20 @@
 package parser
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/serverlessworkflow/sdk-go/v2/validator"
-	"io/ioutil"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/serverlessworkflow/sdk-go/v2/logging"
+	"github.com/serverlessworkflow/sdk-go/v2/metrics"
 	"github.com/serverlessworkflow/sdk-go/v2/model"
-	"sigs.k8s.io/yaml"
+	val "gopkg.in/go-playground/validator.v8"
 )
 
 const (
@@ -35,40 +39,293 @@ const (
 
 var supportedExt = []string{extYAML, extYML, extJSON}
 
+// Parser parses the workflows and validates them with its Validator. The validations registered on the validator of
+// a Parser don't affect the other parsers. A Parser is safe for concurrent use, validations being registrable on its
+// Validator while it parses.
+type Parser struct {
+	// Validator validator of the workflows, default is validator.Default()
+	Validator *validator.Validator
+	// Limits of the workflows parsed, none by default
+	Limits Limits
+	// Metrics records the parses, their durations and failures if not nil, see the names of the metrics package
+	Metrics metrics.Recorder
+	// Logger logs the debug events of the parses if not nil, e.g. the references resolved and the defaults applied,
+	// and the validations skipped by the ValidationCache
+	Logger logging.Logger
+	// ValidationCache keys of the workflows validated if not nil, the workflows whose fingerprint was already validated
+	// with the rules of the Validator being accepted without being validated again, see validator.Memo
+	ValidationCache validator.MemoCache
+}
+
+// defaultParser parser of the package functions
+var defaultParser = &Parser{}
+
+// New returns a parser validating the workflows with the given validator, e.g. validator.New() with its own
+// validations registered
+func New(v *validator.Validator) *Parser {
+	return &Parser{Validator: v}
+}
+
 // FromYAMLSource parses the given Serverless Workflow YAML source into the Workflow type.
 func FromYAMLSource(source []byte) (workflow *model.Workflow, err error) {
-	var jsonBytes []byte
-	if jsonBytes, err = yaml.YAMLToJSON(source); err != nil {
-		return nil, err
-	}
-	return FromJSONSource(jsonBytes)
+	return defaultParser.FromYAMLSource(source)
 }
 
 // FromJSONSource parses the given Serverless Workflow JSON source into the Workflow type.
 func FromJSONSource(source []byte) (workflow *model.Workflow, err error) {
-	workflow = &model.Workflow{}
-	if err := json.Unmarshal(source, workflow); err != nil {
+	return defaultParser.FromJSONSource(source)
+}
+
+// FromFile parses the given Serverless Workflow file into the Workflow type.
+func FromFile(path string) (*model.Workflow, error) {
+	return defaultParser.FromFile(path)
+}
+
+// FromYAMLSourceLazy parses the given Serverless Workflow YAML source into a LazyWorkflow, decoding the states on
+// their first access.
+func FromYAMLSourceLazy(source []byte) (*model.LazyWorkflow, error) {
+	return defaultParser.FromYAMLSourceLazy(source)
+}
+
+// FromJSONSourceLazy parses the given Serverless Workflow JSON source into a LazyWorkflow, decoding the states on
+// their first access.
+func FromJSONSourceLazy(source []byte) (*model.LazyWorkflow, error) {
+	return defaultParser.FromJSONSourceLazy(source)
+}
+
+// FromFileLazy parses the given Serverless Workflow file into a LazyWorkflow, decoding the states on their first
+// access.
+func FromFileLazy(path string) (*model.LazyWorkflow, error) {
+	return defaultParser.FromFileLazy(path)
+}
+
+// FromJSONStream parses the Serverless Workflow JSON read from r, calling fn with every state as soon as it's decoded
+// instead of keeping the states, so that very large workflows are parsed without the whole document in memory. The
+// rest of the workflow is validated once read, after the states were handed to fn.
+func FromJSONStream(r io.Reader, fn model.StateFunc) (*model.StreamedWorkflow, error) {
+	return defaultParser.FromJSONStream(r, fn)
+}
+
+// FromYAMLSource parses the given Serverless Workflow YAML source into the Workflow type.
+func (p *Parser) FromYAMLSource(source []byte) (*model.Workflow, error) {
+	workflow := &model.Workflow{}
+	if err := p.decodeYAML(source, workflow); err != nil {
 		return nil, err
 	}
-	if err := validator.GetValidator().Struct(workflow); err != nil {
+	return workflow, nil
+}
+
+// FromJSONSource parses the given Serverless Workflow JSON source into the Workflow type.
+func (p *Parser) FromJSONSource(source []byte) (*model.Workflow, error) {
+	workflow := &model.Workflow{}
+	if err := p.decodeJSON(source, workflow); err != nil {
 		return nil, err
 	}
 	return workflow, nil
 }
 
 // FromFile parses the given Serverless Workflow file into the Workflow type.
-func FromFile(path string) (*model.Workflow, error) {
-	if err := checkFilePath(path); err != nil {
+func (p *Parser) FromFile(path string) (*model.Workflow, error) {
+	workflow := &model.Workflow{}
+	if err := p.decodeFile(path, workflow); err != nil {
 		return nil, err
 	}
-	fileBytes, err := ioutil.ReadFile(filepath.Clean(path))
-	if err != nil {
+	return workflow, nil
+}
+
+// FromYAMLSourceLazy parses the given Serverless Workflow YAML source into a LazyWorkflow, decoding the states on
+// their first access.
+func (p *Parser) FromYAMLSourceLazy(source []byte) (*model.LazyWorkflow, error) {
+	workflow := &model.LazyWorkflow{}
+	if err := p.decodeYAML(source, workflow); err != nil {
 		return nil, err
 	}
-	if strings.HasSuffix(path, extYAML) || strings.HasSuffix(path, extYML) {
-		return FromYAMLSource(fileBytes)
+	return workflow, nil
+}
+
+// FromJSONSourceLazy parses the given Serverless Workflow JSON source into a LazyWorkflow, decoding the states on
+// their first access.
+func (p *Parser) FromJSONSourceLazy(source []byte) (*model.LazyWorkflow, error) {
+	workflow := &model.LazyWorkflow{}
+	if err := p.decodeJSON(source, workflow); err != nil {
+		return nil, err
+	}
+	return workflow, nil
+}
+
+// FromFileLazy parses the given Serverless Workflow file into a LazyWorkflow, decoding the states on their first
+// access.
+func (p *Parser) FromFileLazy(path string) (*model.LazyWorkflow, error) {
+	workflow := &model.LazyWorkflow{}
+	if err := p.decodeFile(path, workflow); err != nil {
+		return nil, err
+	}
+	return workflow, nil
+}
+
+// FromJSONStream parses the Serverless Workflow JSON read from r like the FromJSONStream function.
+// The size, states and timeout limits are checked as the states are decoded, not the nesting depth and actions.
+func (p *Parser) FromJSONStream(r io.Reader, fn model.StateFunc) (_ *model.StreamedWorkflow, err error) {
+	defer p.measure(time.Now(), -1, &err)
+	limits := p.Limits
+	var deadline time.Time
+	if limits.Timeout > 0 {
+		deadline = time.Now().Add(limits.Timeout)
+	}
+	workflow, err := model.DecodeStates(json.NewDecoder(limits.limitReader(r)), func(index int, state model.State) error {
+		if limits.MaxStates > 0 && index >= limits.MaxStates {
+			return &LimitError{Limit: "MaxStates", Max: limits.MaxStates}
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return &LimitError{Limit: "Timeout", Max: limits.Timeout}
+		}
+		return fn(index, state)
+	})
+	if err != nil {
+		return nil, newError("", nil, err)
+	}
+	if err := p.validate(workflow); err != nil {
+		return nil, newError("", nil, err)
 	}
-	return FromJSONSource(fileBytes)
+	return workflow, nil
+}
+
+func (p *Parser) validator() *validator.Validator {
+	if p.Validator == nil {
+		return validator.Default()
+	}
+	return p.Validator
+}
+
+// measure records a parse of the source of the given size, negative if unknown, started at start and failed if *err
+// isn't nil. It's deferred by the parses, with the error they return.
+func (p *Parser) measure(start time.Time, size int, err *error) {
+	if p.Metrics == nil {
+		return
+	}
+	p.Metrics.Add(metrics.Parses, 1)
+	if *err != nil {
+		p.Metrics.Add(metrics.ParseFailures, 1)
+	}
+	p.Metrics.Observe(metrics.ParseDuration, time.Since(start).Seconds())
+	if size >= 0 {
+		p.Metrics.Observe(metrics.ParseSize, float64(size))
+	}
+}
+
+// count increments the counter of the given name of the metrics, if any
+func (p *Parser) count(name string) {
+	if p.Metrics != nil {
+		p.Metrics.Add(name, 1)
+	}
+}
+
+// validate validates the decoded workflow, recording the validation in the metrics
+func (p *Parser) validate(workflow interface{}) error {
+	if p.Metrics == nil {
+		return p.validateStruct(workflow)
+	}
+	start := time.Now()
+	err := p.validateStruct(workflow)
+	p.Metrics.Observe(metrics.ValidationDuration, time.Since(start).Seconds())
+	if err != nil {
+		p.Metrics.Add(metrics.ValidationFailures, 1)
+	}
+	return err
+}
+
+// validateStruct validates the workflow with the validator, unless the validation cache holds it. The errors of the
+// fields are returned as a *model.SchemaError.
+func (p *Parser) validateStruct(workflow interface{}) error {
+	var err error
+	if p.ValidationCache == nil {
+		err = p.validator().Struct(workflow)
+	} else {
+		memo := validator.NewMemo(p.validator(), p.ValidationCache)
+		memo.Logger = p.Logger
+		err = memo.Struct(workflow)
+	}
+	if errs, ok := err.(val.ValidationErrors); ok {
+		return &model.SchemaError{Errors: errs}
+	}
+	return err
+}
+
+// decodeYAML decodes and validates the YAML source into the workflow
+func (p *Parser) decodeYAML(source []byte, workflow interface{}) (err error) {
+	defer p.measure(time.Now(), len(source), &err)
+	if err := p.Limits.checkSize(int64(len(source))); err != nil {
+		return newError("", nil, err)
+	}
+	return newError("", nil, p.Limits.withTimeout(source, func(source []byte) error {
+		buf := getBuffer()
+		defer putBuffer(buf)
+		if err := yamlToJSON(buf, source); err != nil {
+			return err
+		}
+		return p.decode(buf.Bytes(), workflow)
+	}))
+}
+
+// decodeJSON decodes and validates the JSON source into the workflow
+func (p *Parser) decodeJSON(source []byte, workflow interface{}) (err error) {
+	defer p.measure(time.Now(), len(source), &err)
+	if err := p.Limits.checkSize(int64(len(source))); err != nil {
+		return newError("", nil, err)
+	}
+	return newError("", nil, p.Limits.withTimeout(source, func(source []byte) error {
+		return p.decode(source, workflow)
+	}))
+}
+
+// decode checks the limits of the JSON source, then decodes and validates it into the workflow. The errors are
+// returned as *Error, located in the source.
+func (p *Parser) decode(source []byte, workflow interface{}) error {
+	if err := p.Limits.checkJSON(source); err != nil {
+		return newError("", source, err)
+	}
+	if err := json.Unmarshal(source, workflow); err != nil {
+		return newError("", source, err)
+	}
+	p.logDecoded(source)
+	return newError("", source, p.validate(workflow))
+}
+
+// decodeFile decodes and validates the file into the workflow, the errors being returned as *Error with the path
+func (p *Parser) decodeFile(path string, workflow interface{}) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := readWorkflowFile(buf, path, p.Limits); err != nil {
+		return newError(path, nil, err)
+	}
+	if isYAML(path) {
+		return newError(path, nil, p.decodeYAML(buf.Bytes(), workflow))
+	}
+	return newError(path, nil, p.decodeJSON(buf.Bytes(), workflow))
+}
+
+// readWorkflowFile checks the path and reads the file into the buffer, up to the maximum size of the limits
+func readWorkflowFile(buf *bytes.Buffer, path string, limits Limits) error {
+	if err := checkFilePath(path); err != nil {
+		return err
+	}
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	var size int64
+	if info, err := file.Stat(); err == nil {
+		size = info.Size()
+	}
+	if err := limits.checkSize(size); err != nil {
+		return err
+	}
+	return readFile(buf, limits.limitReader(file), size)
+}
+
+func isYAML(path string) bool {
+	return strings.HasSuffix(path, extYAML) || strings.HasSuffix(path, extYML)
 }
 
 // checkFilePath verifies if the file exists in the given path and if it's supported by the parser package
@@ -15,12 +15,20 @@
 package parser
 
 import (
+	"errors"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/serverlessworkflow/sdk-go/v2/metrics"
 	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/validator"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	val "gopkg.in/go-playground/validator.v8"
 )
 
 func TestBasicValidation(t *testing.T) {
@@ -49,6 +57,28 @@ func TestCustomValidators(t *testing.T) {
 	}
 }
 
+func TestValidatorRegistrationsRun(t *testing.T) {
+	// the registrations on the validator replace the generated validation functions, see validator.GetValidator for
+	// the ones made on the default validator
+	v := validator.New()
+	v.RegisterStructValidation(func(v *val.Validate, structLevel *val.StructLevel) {
+		if structLevel.CurrentStruct.Interface().(model.Workflow).ID == "rejected" {
+			structLevel.ReportError(reflect.ValueOf("rejected"), "ID", "id", "notrejected")
+		}
+	}, model.Workflow{})
+	p := New(v)
+	source := `{"id": "%s", "name": "Order", "specVersion": "0.7", "start": "Notify",
+"states": [{"name": "Notify", "type": "inject", "data": {}, "end": true}]}`
+
+	_, err := p.FromJSONSource([]byte(strings.Replace(source, "%s", "rejected", 1)))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "notrejected")
+	_, err = p.FromYAMLSource([]byte(strings.Replace(source, "%s", "rejected", 1)))
+	assert.Error(t, err)
+	_, err = p.FromJSONSource([]byte(strings.Replace(source, "%s", "order", 1)))
+	assert.NoError(t, err)
+}
+
 func TestFromFile(t *testing.T) {
 	files := map[string]func(*testing.T, *model.Workflow){
 		"./testdata/workflows/greetings.sw.json": func(t *testing.T, w *model.Workflow) {
@@ -249,3 +279,187 @@ func TestFromFile(t *testing.T) {
 		f(t, workflow)
 	}
 }
+
+func TestFromFileLazy(t *testing.T) {
+	for _, file := range []string{"./testdata/workflows/greetings.sw.json", "./testdata/workflows/greetings.sw.yaml"} {
+		lazy, err := FromFileLazy(file)
+		require.NoError(t, err)
+		assert.Equal(t, "greeting", lazy.ID)
+		state, err := lazy.State("Greet")
+		require.NoError(t, err)
+		assert.Equal(t, "greetingFunction", state.(*model.OperationState).Actions[0].FunctionRef.RefName)
+
+		workflow, err := lazy.Workflow()
+		require.NoError(t, err)
+		expected, err := FromFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, expected, workflow)
+	}
+
+	_, err := FromJSONSourceLazy([]byte(`{"id": "greeting", "specVersion": "0.8", "start": "Greet", "states": []}`))
+	assert.Error(t, err)
+}
+
+func TestFromYAMLSourceBooleans(t *testing.T) {
+	// the workflows written for the YAML 1.1 decoding
+	workflow, err := FromYAMLSource([]byte(`
+id: order
+name: Order
+specVersion: "0.8"
+start: Store
+events:
+  - name: Ordered
+    type: order.created
+    dataOnly: no
+  - name: Refunded
+    type: order.refunded
+    dataOnly: on
+states:
+  - name: Store
+    type: inject
+    data:
+      stored: yes
+      label: "yes"
+    usedForCompensation: yes
+    end: true
+`))
+	require.NoError(t, err)
+	assert.False(t, workflow.Events[0].DataOnly)
+	assert.True(t, workflow.Events[1].DataOnly)
+	state := workflow.States[0].(*model.InjectState)
+	assert.True(t, state.UsedForCompensation)
+	assert.Equal(t, map[string]interface{}{"stored": true, "label": "yes"}, state.Data)
+}
+
+func TestFromJSONStream(t *testing.T) {
+	file, err := os.Open("./testdata/workflows/purchaseorderworkflow.sw.json")
+	require.NoError(t, err)
+	defer file.Close()
+	var states []model.State
+	workflow, err := FromJSONStream(file, func(index int, state model.State) error {
+		states = append(states, state)
+		return nil
+	})
+	require.NoError(t, err)
+	expected, err := FromFile("./testdata/workflows/purchaseorderworkflow.sw.json")
+	require.NoError(t, err)
+	assert.Equal(t, expected.BaseWorkflow, workflow.BaseWorkflow)
+	assert.Equal(t, expected.Events, workflow.Events)
+	assert.Equal(t, expected.States, states)
+	assert.Equal(t, len(states), workflow.StateCount)
+
+	_, err = FromJSONStream(strings.NewReader(`{"id": "greeting", "specVersion": "0.8", "start": "Greet", "states": []}`), func(int, model.State) error { return nil })
+	assert.Error(t, err)
+}
+
+func TestParserValidator(t *testing.T) {
+	v := validator.New()
+	v.RegisterStructValidation(func(v *val.Validate, structLevel *val.StructLevel) {
+		// the greeting workflows are reserved
+		if structLevel.CurrentStruct.Interface().(model.Workflow).ID == "greeting" {
+			structLevel.ReportError(reflect.ValueOf("greeting"), "ID", "id", "reserved")
+		}
+	}, model.Workflow{})
+	p := New(v)
+
+	_, err := p.FromFile("./testdata/workflows/greetings.sw.json")
+	assert.Error(t, err)
+	workflow, err := p.FromFile("./testdata/workflows/applicationrequest.json")
+	require.NoError(t, err)
+	assert.Equal(t, "applicantrequest", workflow.ID)
+
+	// the other parsers aren't affected
+	_, err = FromFile("./testdata/workflows/greetings.sw.json")
+	assert.NoError(t, err)
+	_, err = New(validator.New()).FromFile("./testdata/workflows/greetings.sw.json")
+	assert.NoError(t, err)
+	_, err = (&Cache{Store: NewMemoryStore(1), Parser: p}).FromFile("./testdata/workflows/greetings.sw.json")
+	assert.Error(t, err)
+}
+
+func TestParserErrors(t *testing.T) {
+	_, err := FromJSONSource([]byte(`{"id": "sleep", "version": "1.0", "specVersion": "0.8",
+  "start": "Wait", "states": [{"name": "Wait", "type": "sleep", "duration": "PT1M", "end": true}]}`))
+	var schemaErr *model.SchemaError
+	require.True(t, errors.As(err, &schemaErr))
+	assert.False(t, errors.Is(err, model.ErrInvalidDuration))
+	var fieldErrs val.ValidationErrors
+	require.True(t, errors.As(err, &fieldErrs))
+	assert.Len(t, fieldErrs, 1)
+
+	_, err = FromJSONSource([]byte(`{"id": "sleep", "states": [{"name": "Wait", "type": "wait"}]}`))
+	assert.True(t, errors.Is(err, model.ErrUnknownStateType))
+}
+
+func TestParserMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	p := &Parser{Metrics: registry}
+	source, err := ioutil.ReadFile("./testdata/workflows/greetings.sw.json")
+	require.NoError(t, err)
+	_, err = p.FromJSONSource(source)
+	require.NoError(t, err)
+	invalid := []byte(`{"id": "greeting", "states": [{"name": "Greet", "type": "inject", "end": true}]}`)
+	_, err = p.FromJSONSource(invalid)
+	assert.Error(t, err)
+	_, err = p.FromJSONSource([]byte(`{`))
+	assert.Error(t, err)
+
+	assert.Equal(t, float64(3), registry.Counter(metrics.Parses))
+	assert.Equal(t, float64(2), registry.Counter(metrics.ParseFailures))
+	assert.Equal(t, float64(1), registry.Counter(metrics.ValidationFailures))
+	duration, _ := registry.Histogram(metrics.ParseDuration)
+	assert.Equal(t, uint64(3), duration.Count)
+	validation, _ := registry.Histogram(metrics.ValidationDuration)
+	assert.Equal(t, uint64(2), validation.Count)
+	size, _ := registry.Histogram(metrics.ParseSize)
+	assert.Equal(t, float64(len(source)+len(invalid)+1), size.Sum)
+
+	c := &Cache{Store: NewMemoryStore(8), Parser: p}
+	for i := 0; i < 2; i++ {
+		_, err = c.FromJSONSource(source)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, float64(1), registry.Counter(metrics.CacheHits))
+	assert.Equal(t, float64(1), registry.Counter(metrics.CacheMisses))
+}
+
+// countingMemoCache memo cache counting the keys found
+type countingMemoCache struct {
+	validator.MemoCache
+	hits int
+}
+
+func (c *countingMemoCache) Contains(key string) bool {
+	ok := c.MemoCache.Contains(key)
+	if ok {
+		c.hits++
+	}
+	return ok
+}
+
+func TestParserValidationCache(t *testing.T) {
+	memo := &countingMemoCache{MemoCache: validator.NewMemoryCache(8)}
+	p := &Parser{ValidationCache: memo}
+	for _, file := range []string{"./testdata/workflows/greetings.sw.json", "./testdata/workflows/greetings.sw.json"} {
+		expected, err := FromFile(file)
+		require.NoError(t, err)
+		workflow, err := p.FromFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, expected, workflow)
+	}
+	assert.Equal(t, 1, memo.hits)
+
+	// the rules of another validator are another cache entry
+	custom := validator.New()
+	require.NoError(t, custom.RegisterPattern("lowercase", "^[a-z]+$"))
+	_, err := (&Parser{Validator: custom, ValidationCache: memo}).FromFile("./testdata/workflows/greetings.sw.json")
+	require.NoError(t, err)
+	assert.Equal(t, 1, memo.hits)
+
+	invalid := []byte(`{"id": "greeting", "states": [{"name": "Greet", "type": "inject", "end": true}]}`)
+	for i := 0; i < 2; i++ {
+		_, err = p.FromJSONSource(invalid)
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 1, memo.hits)
+}
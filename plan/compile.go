@@ -0,0 +1,423 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v2/diagram"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/retry"
+	"github.com/serverlessworkflow/sdk-go/v2/timeouts"
+)
+
+// conditionDefault label of the edges of the default conditions
+const conditionDefault = "default"
+
+// Compile compiles the workflow, which should be validated first, into its execution plan. The references to
+// undefined states, functions, events, errors and retries and the invalid timeouts and retries are reported as errors.
+func Compile(workflow *model.Workflow) (*Plan, error) {
+	c := &compiler{
+		plan: &Plan{
+			Workflow:  workflow,
+			Functions: workflow.Functions,
+			Events:    workflow.Events,
+			Errors:    workflow.Errors,
+			states:    map[string]int{},
+		},
+		functions: map[string]int{},
+		events:    map[string]int{},
+		errors:    map[string]int{},
+		retries:   map[string]int{},
+	}
+	for i, function := range workflow.Functions {
+		c.functions[function.Name] = i
+	}
+	for i, event := range workflow.Events {
+		c.events[event.Name] = i
+	}
+	for i, e := range workflow.Errors {
+		c.errors[e.Name] = i
+	}
+	for i := range workflow.Retries {
+		r := &workflow.Retries[i]
+		backoff, err := retry.NewBackoff(*r)
+		if err != nil {
+			return nil, fmt.Errorf("retries[%d]: %w", i, err)
+		}
+		c.retries[r.Name] = i
+		c.plan.Retries = append(c.plan.Retries, Retry{Model: r, Backoff: backoff})
+	}
+	for i, state := range workflow.States {
+		if _, ok := c.plan.states[state.GetName()]; ok {
+			return nil, fmt.Errorf("states[%d]: state %s defined twice", i, state.GetName())
+		}
+		c.plan.states[state.GetName()] = i
+	}
+
+	if workflow.Start == nil {
+		return nil, fmt.Errorf("start: not defined")
+	}
+	start, ok := c.plan.states[workflow.Start.StateName]
+	if !ok {
+		return nil, fmt.Errorf("start: state %s not defined", workflow.Start.StateName)
+	}
+	c.plan.Start = start
+
+	c.plan.States = make([]State, len(workflow.States))
+	for i, state := range workflow.States {
+		compiled, err := c.state(i, state)
+		if err != nil {
+			return nil, fmt.Errorf("states[%s]: %w", state.GetName(), err)
+		}
+		c.plan.States[i] = compiled
+	}
+	for i := range c.plan.States {
+		for _, edge := range c.plan.States[i].Edges {
+			if edge.To != End {
+				c.plan.States[edge.To].Incoming = appendUnique(c.plan.States[edge.To].Incoming, i)
+			}
+		}
+	}
+	return c.plan, nil
+}
+
+type compiler struct {
+	plan      *Plan
+	functions map[string]int
+	events    map[string]int
+	errors    map[string]int
+	retries   map[string]int
+}
+
+func (c *compiler) state(index int, state model.State) (State, error) {
+	name := state.GetName()
+	t, err := timeouts.ForState(c.plan.Workflow, name)
+	if err != nil {
+		return State{}, err
+	}
+	compiled := State{
+		Index:         index,
+		Name:          name,
+		Type:          state.GetType(),
+		Model:         state,
+		Timeouts:      t,
+		Event:         None,
+		CompensatedBy: None,
+	}
+	if compensatedBy := state.GetCompensatedBy(); len(compensatedBy) > 0 {
+		if compiled.CompensatedBy, err = c.stateRef(compensatedBy); err != nil {
+			return State{}, fmt.Errorf("compensatedBy: %w", err)
+		}
+	}
+
+	switch s := state.(type) {
+	case *model.OperationState:
+		compiled.Actions, err = c.actions("actions", s.Actions, t)
+	case *model.ForEachState:
+		compiled.Actions, err = c.actions("actions", s.Actions, t)
+	case *model.CallbackState:
+		action, err := c.action("action", &s.Action, t)
+		if err != nil {
+			return State{}, err
+		}
+		compiled.Actions = []Action{action}
+		if compiled.Event, err = c.event("eventRef", s.EventRef); err != nil {
+			return State{}, err
+		}
+	case *model.EventState:
+		for i := range s.OnEvents {
+			handler, err := c.handler(fmt.Sprintf("onEvents[%d]", i), &s.OnEvents[i], t)
+			if err != nil {
+				return State{}, err
+			}
+			compiled.Handlers = append(compiled.Handlers, handler)
+		}
+	case *model.ParallelState:
+		for _, b := range s.Branches {
+			branch := Branch{Name: b.Name}
+			if branch.Timeouts, err = timeouts.ForBranch(c.plan.Workflow, name, b.Name); err != nil {
+				return State{}, err
+			}
+			if branch.Actions, err = c.actions("branches["+b.Name+"].actions", b.Actions, branch.Timeouts); err != nil {
+				return State{}, err
+			}
+			compiled.Branches = append(compiled.Branches, branch)
+		}
+	}
+	if err != nil {
+		return State{}, err
+	}
+
+	if compiled.Edges, err = c.edges(state); err != nil {
+		return State{}, err
+	}
+	return compiled, nil
+}
+
+func (c *compiler) handler(path string, onEvents *model.OnEvents, t timeouts.Timeouts) (Handler, error) {
+	handler := Handler{Model: onEvents}
+	for i, ref := range onEvents.EventRefs {
+		event, err := c.event(fmt.Sprintf("%s.eventRefs[%d]", path, i), ref)
+		if err != nil {
+			return Handler{}, err
+		}
+		handler.Events = append(handler.Events, event)
+	}
+	actions, err := c.actions(path+".actions", onEvents.Actions, t)
+	if err != nil {
+		return Handler{}, err
+	}
+	handler.Actions = actions
+	return handler, nil
+}
+
+func (c *compiler) actions(path string, actions []model.Action, t timeouts.Timeouts) ([]Action, error) {
+	compiled := make([]Action, len(actions))
+	for i := range actions {
+		action, err := c.action(fmt.Sprintf("%s[%d]", path, i), &actions[i], t)
+		if err != nil {
+			return nil, err
+		}
+		compiled[i] = action
+	}
+	return compiled, nil
+}
+
+func (c *compiler) action(path string, action *model.Action, t timeouts.Timeouts) (Action, error) {
+	a := Action{
+		Name:     action.Name,
+		Model:    action,
+		Function: None,
+		Trigger:  None,
+		Result:   None,
+		Retry:    None,
+		Timeouts: t,
+	}
+	var err error
+	if len(action.FunctionRef.RefName) > 0 {
+		index, ok := c.functions[action.FunctionRef.RefName]
+		if !ok {
+			return Action{}, fmt.Errorf("%s.functionRef: function %s not defined", path, action.FunctionRef.RefName)
+		}
+		a.Function = index
+	}
+	if len(action.EventRef.GetTriggerEventRef()) > 0 {
+		if a.Trigger, err = c.event(path+".eventRef.triggerEventRef", action.EventRef.TriggerEventRef); err != nil {
+			return Action{}, err
+		}
+	}
+	if len(action.EventRef.GetResultEventRef()) > 0 {
+		if a.Result, err = c.event(path+".eventRef.resultEventRef", action.EventRef.ResultEventRef); err != nil {
+			return Action{}, err
+		}
+	}
+	if len(action.RetryRef) > 0 {
+		index, ok := c.retries[action.RetryRef]
+		if !ok {
+			return Action{}, fmt.Errorf("%s.retryRef: retry %s not defined", path, action.RetryRef)
+		}
+		a.Retry = index
+	}
+	if a.NonRetryableErrors, err = c.errorRefs(path+".nonRetryableErrors", action.NonRetryableErrors); err != nil {
+		return Action{}, err
+	}
+	if a.RetryableErrors, err = c.errorRefs(path+".retryableErrors", action.RetryableErrors); err != nil {
+		return Action{}, err
+	}
+	return a, nil
+}
+
+func (c *compiler) edges(state model.State) ([]Edge, error) {
+	var edges []Edge
+	switch s := state.(type) {
+	case *model.DataBasedSwitchState:
+		for i, condition := range s.DataConditions {
+			edge := Edge{Kind: diagram.EdgeCondition, Label: label(condition.GetName(), condition.GetCondition()), Condition: condition.GetCondition(), Event: None}
+			var err error
+			switch cond := condition.(type) {
+			case *model.TransitionDataCondition:
+				err = c.transition(&edge, &cond.Transition)
+			case *model.EndDataCondition:
+				err = c.end(&edge, &cond.End)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("dataConditions[%d]: %w", i, err)
+			}
+			edges = append(edges, edge)
+		}
+		edge, err := c.defaultCondition(&s.DefaultCondition)
+		if err != nil {
+			return nil, err
+		}
+		edges = append(edges, edge)
+	case *model.EventBasedSwitchState:
+		for i, condition := range s.EventConditions {
+			event, err := c.event(fmt.Sprintf("eventConditions[%d].eventRef", i), condition.GetEventRef())
+			if err != nil {
+				return nil, err
+			}
+			edge := Edge{Kind: diagram.EdgeCondition, Label: label(condition.GetName(), condition.GetEventRef()), Event: event}
+			switch cond := condition.(type) {
+			case *model.TransitionEventCondition:
+				err = c.transition(&edge, &cond.Transition)
+			case *model.EndEventCondition:
+				err = c.end(&edge, &cond.End)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("eventConditions[%d]: %w", i, err)
+			}
+			edges = append(edges, edge)
+		}
+		edge, err := c.defaultCondition(&s.DefaultCondition)
+		if err != nil {
+			return nil, err
+		}
+		edges = append(edges, edge)
+	default:
+		edge := Edge{Kind: diagram.EdgeTransition, Event: None}
+		var err error
+		if transition := state.GetTransition(); transition != nil && len(transition.NextState) > 0 {
+			err = c.transition(&edge, transition)
+		} else if end := state.GetEnd(); end != nil {
+			err = c.end(&edge, end)
+		} else {
+			err = fmt.Errorf("neither transitions nor ends the workflow")
+		}
+		if err != nil {
+			return nil, err
+		}
+		edges = append(edges, edge)
+	}
+
+	for i := range state.GetOnErrors() {
+		onError := &state.GetOnErrors()[i]
+		path := fmt.Sprintf("onErrors[%d]", i)
+		edge := Edge{Kind: diagram.EdgeError, Event: None}
+		refs := onError.ErrorRefs
+		if len(onError.ErrorRef) > 0 {
+			refs = append([]string{onError.ErrorRef}, refs...)
+		}
+		var err error
+		if edge.Errors, err = c.errorRefs(path, refs); err != nil {
+			return nil, err
+		}
+		edge.Label = onError.ErrorRef
+		if len(onError.ErrorRefs) > 0 {
+			edge.Label = fmt.Sprint(onError.ErrorRefs)
+		}
+		switch {
+		case onError.Transition != nil && len(onError.Transition.NextState) > 0:
+			err = c.transition(&edge, onError.Transition)
+		case onError.End != nil:
+			err = c.end(&edge, onError.End)
+		default:
+			err = fmt.Errorf("neither transitions nor ends the workflow")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		edges = append(edges, edge)
+	}
+	return edges, nil
+}
+
+func (c *compiler) defaultCondition(condition *model.DefaultCondition) (Edge, error) {
+	edge := Edge{Kind: diagram.EdgeCondition, Label: conditionDefault, Event: None, Default: true}
+	var err error
+	if len(condition.Transition.NextState) > 0 {
+		err = c.transition(&edge, &condition.Transition)
+	} else {
+		err = c.end(&edge, &condition.End)
+	}
+	if err != nil {
+		return Edge{}, fmt.Errorf("defaultCondition: %w", err)
+	}
+	return edge, nil
+}
+
+func (c *compiler) transition(edge *Edge, transition *model.Transition) error {
+	var err error
+	if edge.To, err = c.stateRef(transition.NextState); err != nil {
+		return fmt.Errorf("transition: %w", err)
+	}
+	if edge.Produce, err = c.produce("transition", transition.ProduceEvents); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *compiler) end(edge *Edge, end *model.End) error {
+	edge.To, edge.End = End, end
+	var err error
+	edge.Produce, err = c.produce("end", end.ProduceEvents)
+	return err
+}
+
+func (c *compiler) produce(path string, events []model.ProduceEvent) ([]Produce, error) {
+	var produce []Produce
+	for i := range events {
+		event, err := c.event(fmt.Sprintf("%s.produceEvents[%d].eventRef", path, i), events[i].EventRef)
+		if err != nil {
+			return nil, err
+		}
+		produce = append(produce, Produce{Event: event, Model: &events[i]})
+	}
+	return produce, nil
+}
+
+func (c *compiler) stateRef(name string) (int, error) {
+	index, ok := c.plan.states[name]
+	if !ok {
+		return None, fmt.Errorf("state %s not defined", name)
+	}
+	return index, nil
+}
+
+func (c *compiler) event(path, name string) (int, error) {
+	index, ok := c.events[name]
+	if !ok {
+		return None, fmt.Errorf("%s: event %s not defined", path, name)
+	}
+	return index, nil
+}
+
+func (c *compiler) errorRefs(path string, refs []string) ([]int, error) {
+	var indexes []int
+	for _, ref := range refs {
+		index, ok := c.errors[ref]
+		if !ok {
+			return nil, fmt.Errorf("%s: error %s not defined", path, ref)
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+func label(name, fallback string) string {
+	if len(name) > 0 {
+		return name
+	}
+	return fallback
+}
+
+func appendUnique(values []int, value int) []int {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
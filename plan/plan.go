@@ -0,0 +1,159 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plan compiles validated workflows into execution plans: index based graphs of the states, with the
+// references to the states, functions, events, errors and retries resolved to indexes, the timeouts precomputed and
+// the transitions as adjacency lists. Runtimes executing a workflow many times walk its plan instead of looking up
+// the names in the model on every step.
+package plan
+
+import (
+	"github.com/serverlessworkflow/sdk-go/v2/diagram"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/retry"
+	"github.com/serverlessworkflow/sdk-go/v2/timeouts"
+)
+
+const (
+	// End target index of the edges ending the workflow
+	End = -1
+	// None index of the missing references, e.g. the function of an action calling a subflow
+	None = -1
+)
+
+// Plan execution plan of a workflow. A plan is never modified once compiled, it's safe to share between goroutines
+// and must be treated as read only: its slices and the model it points to are those of the compiled plan.
+type Plan struct {
+	// Workflow compiled
+	Workflow *model.Workflow
+	// Start index of the start state
+	Start int
+	// States indexed by position in the workflow
+	States    []State
+	Functions []model.Function
+	Events    []model.Event
+	Errors    []model.Error
+	Retries   []Retry
+
+	states map[string]int
+}
+
+// State compiled state
+type State struct {
+	// Index of the state in the plan
+	Index int
+	Name  string
+	Type  model.StateType
+	// Model state compiled
+	Model model.State
+	// Timeouts effective timeouts of the state
+	Timeouts timeouts.Timeouts
+	// Actions of the operation, foreach and callback states
+	Actions []Action
+	// Handlers event handlers of the event states
+	Handlers []Handler
+	// Branches of the parallel states
+	Branches []Branch
+	// Event index of the event consumed by a callback state, None otherwise
+	Event int
+	// Edges ways to leave the state, in order: the switch conditions then the default condition, or the transition
+	// or end, then the error handlers
+	Edges []Edge
+	// Incoming indexes of the states with an edge to the state, in order, including the state itself for loops
+	Incoming []int
+	// CompensatedBy index of the state compensating the state, None if not compensated
+	CompensatedBy int
+}
+
+// Action compiled action
+type Action struct {
+	Name string
+	// Model action compiled
+	Model *model.Action
+	// Function index of the function called, None if the action doesn't call a function
+	Function int
+	// Trigger index of the event produced by the action, None if the action doesn't produce an event
+	Trigger int
+	// Result index of the event the action waits for, None if the action doesn't wait for an event
+	Result int
+	// Retry index of the retry definition of the action, None if not defined
+	Retry int
+	// NonRetryableErrors indexes of the errors for which the action isn't retried
+	NonRetryableErrors []int
+	// RetryableErrors indexes of the errors for which the action is retried
+	RetryableErrors []int
+	// Timeouts effective timeouts of the action
+	Timeouts timeouts.Timeouts
+}
+
+// Handler compiled event handler of an event state
+type Handler struct {
+	// Events indexes of the events consumed
+	Events  []int
+	Actions []Action
+	// Model event handler compiled
+	Model *model.OnEvents
+}
+
+// Branch compiled branch of a parallel state
+type Branch struct {
+	Name    string
+	Actions []Action
+	// Timeouts effective timeouts of the branch
+	Timeouts timeouts.Timeouts
+}
+
+// Edge way to leave a state
+type Edge struct {
+	Kind diagram.EdgeKind
+	// To index of the target state, End if the edge ends the workflow
+	To int
+	// End definition of an edge ending the workflow
+	End *model.End
+	// Produce events produced when taking the edge
+	Produce []Produce
+	// Label name, or expression or event reference if unnamed, of a switch condition, 'default' for the default
+	// condition
+	Label string
+	// Condition expression of a data condition
+	Condition string
+	// Event index of the event of an event condition, None otherwise
+	Event int
+	// Default whether the edge is the default condition of a switch state
+	Default bool
+	// Errors indexes of the errors handled by an error edge
+	Errors []int
+}
+
+// Produce event produced when taking an edge
+type Produce struct {
+	// Event index of the event produced
+	Event int
+	// Model definition of the produced event
+	Model *model.ProduceEvent
+}
+
+// Retry compiled retry definition
+type Retry struct {
+	// Model retry definition compiled
+	Model *model.Retry
+	// Backoff delays between the attempts
+	Backoff *retry.Backoff
+}
+
+// StateIndex returns the index of the state with the given name
+func (p *Plan) StateIndex(name string) (int, bool) {
+	index, ok := p.states[name]
+	return index, ok
+}
@@ -0,0 +1,186 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/diagram"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderWorkflow = `id: order
+name: Order
+version: '1.0'
+specVersion: '0.8'
+start: Check
+timeouts:
+  actionExecTimeout: PT30S
+events:
+  - name: Paid
+    type: order.paid
+    source: payments
+  - name: Shipped
+    type: order.shipped
+    source: carrier
+    kind: produced
+errors:
+  - name: OutOfStock
+    code: '409'
+  - name: Declined
+    code: '402'
+retries:
+  - name: Twice
+    delay: PT1S
+    maxAttempts: 2
+functions:
+  - name: reserve
+    operation: https://stock.example.com/openapi.json#reserve
+  - name: charge
+    operation: https://payments.example.com/openapi.json#charge
+states:
+  - name: Check
+    type: switch
+    dataConditions:
+      - name: empty
+        condition: ${ .items | length == 0 }
+        end: true
+    defaultCondition:
+      transition: Process
+  - name: Process
+    type: parallel
+    branches:
+      - name: stock
+        timeouts:
+          actionExecTimeout: PT5S
+        actions:
+          - name: reserve
+            functionRef: reserve
+            retryRef: Twice
+            nonRetryableErrors: [OutOfStock]
+      - name: payment
+        actions:
+          - functionRef: charge
+    onErrors:
+      - errorRefs: [OutOfStock, Declined]
+        transition: Check
+    compensatedBy: Refund
+    transition: Wait
+  - name: Wait
+    type: switch
+    eventConditions:
+      - eventRef: Paid
+        transition:
+          nextState: Wait
+          produceEvents:
+            - eventRef: Shipped
+    defaultCondition:
+      end: true
+  - name: Refund
+    type: operation
+    usedForCompensation: true
+    actions:
+      - functionRef: charge
+    end: true
+`
+
+func TestCompile(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+	p, err := Compile(workflow)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, p.Start)
+	require.Len(t, p.States, 4)
+	index, ok := p.StateIndex("Wait")
+	assert.True(t, ok)
+	assert.Equal(t, 2, index)
+	_, ok = p.StateIndex("Missing")
+	assert.False(t, ok)
+
+	check := p.States[0]
+	assert.Equal(t, []Edge{
+		{Kind: diagram.EdgeCondition, To: End, End: check.Edges[0].End, Label: "empty", Condition: "${ .items | length == 0 }", Event: None},
+		{Kind: diagram.EdgeCondition, To: 1, Label: "default", Event: None, Default: true},
+	}, check.Edges)
+	assert.Equal(t, []int{1}, check.Incoming)
+
+	process := p.States[1]
+	assert.Equal(t, 3, process.CompensatedBy)
+	require.Len(t, process.Branches, 2)
+	reserve := process.Branches[0].Actions[0]
+	assert.Equal(t, "reserve", reserve.Name)
+	assert.Equal(t, 0, reserve.Function)
+	assert.Equal(t, 0, reserve.Retry)
+	assert.Equal(t, None, reserve.Trigger)
+	assert.Equal(t, []int{0}, reserve.NonRetryableErrors)
+	assert.Equal(t, 5*time.Second, reserve.Timeouts.Action.Duration)
+	assert.Equal(t, 30*time.Second, process.Branches[1].Actions[0].Timeouts.Action.Duration)
+	assert.Equal(t, 1, process.Branches[1].Actions[0].Function)
+	require.Len(t, process.Edges, 2)
+	assert.Equal(t, Edge{Kind: diagram.EdgeTransition, To: 2, Event: None}, process.Edges[0])
+	assert.Equal(t, Edge{Kind: diagram.EdgeError, To: 0, Label: "[OutOfStock Declined]", Event: None, Errors: []int{0, 1}}, process.Edges[1])
+
+	wait := p.States[2]
+	require.Len(t, wait.Edges, 2)
+	assert.Equal(t, 0, wait.Edges[0].Event)
+	assert.Equal(t, 2, wait.Edges[0].To)
+	require.Len(t, wait.Edges[0].Produce, 1)
+	assert.Equal(t, 1, wait.Edges[0].Produce[0].Event)
+	assert.Equal(t, End, wait.Edges[1].To)
+	assert.Equal(t, []int{1, 2}, wait.Incoming)
+
+	refund := p.States[3]
+	assert.Empty(t, refund.Incoming)
+	assert.Equal(t, 1, refund.Actions[0].Function)
+	assert.Equal(t, 30*time.Second, refund.Timeouts.Action.Duration)
+	assert.Equal(t, 2, p.Retries[0].Backoff.MaxAttempts)
+}
+
+func TestCompileErrors(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+
+	workflow.Functions = workflow.Functions[:1]
+	_, err = Compile(workflow)
+	assert.EqualError(t, err, "states[Process]: branches[payment].actions[0].functionRef: function charge not defined")
+
+	workflow, err = parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+	workflow.Errors = workflow.Errors[:1]
+	_, err = Compile(workflow)
+	assert.EqualError(t, err, "states[Process]: onErrors[0]: error Declined not defined")
+
+	workflow, err = parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+	workflow.Events = workflow.Events[:1]
+	_, err = Compile(workflow)
+	assert.EqualError(t, err, "states[Wait]: eventConditions[0]: transition.produceEvents[0].eventRef: event Shipped not defined")
+
+	workflow, err = parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+	workflow.States = workflow.States[:3]
+	_, err = Compile(workflow)
+	assert.EqualError(t, err, "states[Process]: compensatedBy: state Refund not defined")
+
+	workflow, err = parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+	workflow.Timeouts.ActionExecTimeout = "30s"
+	_, err = Compile(workflow)
+	assert.EqualError(t, err, "states[Process]: timeouts.actionExecTimeout: invalid ISO 8601 duration 30s")
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+)
+
+// CUECommand CUE command used to evaluate CUE policies
+var CUECommand = "cue"
+
+// CUE policy written in CUE and evaluated with 'cue vet', see CUECommand. The workflow document must unify with the
+// policy, each error reported by CUE is a violation, e.g.
+//
+//	functions: [...{
+//	    authRef: string
+//	}]
+type CUE struct {
+	// Files CUE files or packages the workflow document is vetted against
+	Files []string
+}
+
+// Evaluate ...
+func (c *CUE) Evaluate(ctx context.Context, input *Input) ([]Violation, error) {
+	args := append(append([]string{"vet", "-c"}, c.Files...), "json:", "-")
+	_, stderr, err := execute(ctx, CUECommand, "evaluating CUE policies requires CUE", args, input.Document)
+	if err == nil {
+		return nil, nil
+	}
+	if _, ok := err.(*exec.ExitError); !ok || len(strings.TrimSpace(string(stderr))) == 0 {
+		return nil, commandError(CUECommand, err, stderr)
+	}
+	var violations []Violation
+	for _, line := range strings.Split(string(stderr), "\n") {
+		// error positions are indented under their error
+		if len(strings.TrimSpace(line)) == 0 || line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		violation := Violation{Policy: strings.Join(c.Files, ","), Message: strings.TrimSuffix(line, ":")}
+		if parts := strings.SplitN(violation.Message, ": ", 2); len(parts) == 2 && !strings.Contains(parts[0], " ") {
+			violation.Path, violation.Message = cuePath(parts[0]), parts[1]
+		}
+		violations = append(violations, violation)
+	}
+	return violations, nil
+}
+
+// cuePath converts a CUE path into a JSON path, e.g. 'functions.0.authRef' into 'functions[0].authRef'
+func cuePath(path string) string {
+	var converted strings.Builder
+	for i, part := range strings.Split(path, ".") {
+		if len(part) > 0 && strings.Trim(part, "0123456789") == "" {
+			converted.WriteString("[" + part + "]")
+			continue
+		}
+		if i > 0 {
+			converted.WriteString(".")
+		}
+		converted.WriteString(part)
+	}
+	return converted.String()
+}
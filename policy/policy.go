@@ -0,0 +1,137 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy evaluates organizational rules, such as "all REST calls must use an authRef", against workflows.
+// Policies are written in Go, or in Rego or CUE and evaluated with the OPA or CUE command line tools.
+package policy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+// Violation policy rule broken by a workflow
+type Violation struct {
+	// Policy name of the broken policy or rule
+	Policy string `json:"policy,omitempty"`
+	// Path JSON path of the violating property if known, e.g. 'functions[0].authRef'
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// String ...
+func (v Violation) String() string {
+	message := v.Message
+	if len(v.Path) > 0 {
+		message = v.Path + ": " + message
+	}
+	if len(v.Policy) > 0 {
+		message += " (" + v.Policy + ")"
+	}
+	return message
+}
+
+// Input workflow evaluated by a policy
+type Input struct {
+	Workflow *model.Workflow
+	// Document normalized JSON document of the workflow, the input of Rego and CUE policies
+	Document []byte
+}
+
+// Policy organizational rules a workflow must follow
+type Policy interface {
+	// Evaluate returns the violations of the rules by the workflow. The error is only set if the policy couldn't be
+	// evaluated.
+	Evaluate(ctx context.Context, input *Input) ([]Violation, error)
+}
+
+// Func policy written in Go
+type Func func(ctx context.Context, workflow *model.Workflow) ([]Violation, error)
+
+// Evaluate ...
+func (f Func) Evaluate(ctx context.Context, input *Input) ([]Violation, error) {
+	return f(ctx, input.Workflow)
+}
+
+// Evaluate evaluates the policies against the workflow and returns their violations, in the policies order
+func Evaluate(ctx context.Context, workflow *model.Workflow, policies ...Policy) ([]Violation, error) {
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	document, err := serializer.Marshal(workflow, serializer.Options{Format: serializer.FormatJSON, Normalize: true})
+	if err != nil {
+		return nil, err
+	}
+	input := &Input{Workflow: workflow, Document: document}
+	var violations []Violation
+	for _, policy := range policies {
+		found, err := policy.Evaluate(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating policy: %w", err)
+		}
+		violations = append(violations, found...)
+	}
+	return violations, nil
+}
+
+// execute runs the command with the input and returns its standard output and error
+func execute(ctx context.Context, command, missing string, args []string, input []byte) ([]byte, []byte, error) {
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", missing, err)
+	}
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	stderr := new(bytes.Buffer)
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	return out, stderr.Bytes(), err
+}
+
+func commandError(command string, err error, stderr []byte) error {
+	if _, ok := err.(*exec.ExitError); !ok {
+		return err
+	}
+	return fmt.Errorf("%s failed: %w: %s", command, err, strings.TrimSpace(string(stderr)))
+}
+
+// FromFiles returns the policies of the Rego and CUE files, one policy per language
+func FromFiles(files []string) ([]Policy, error) {
+	rego, cue := &Rego{}, &CUE{}
+	for _, file := range files {
+		switch filepath.Ext(file) {
+		case ".rego":
+			rego.Files = append(rego.Files, file)
+		case ".cue":
+			cue.Files = append(cue.Files, file)
+		default:
+			return nil, fmt.Errorf("unsupported policy file %s, expected .rego or .cue", file)
+		}
+	}
+	var policies []Policy
+	if len(rego.Files) > 0 {
+		policies = append(policies, rego)
+	}
+	if len(cue.Files) > 0 {
+		policies = append(policies, cue)
+	}
+	return policies, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWorkflow() *model.Workflow {
+	return &model.Workflow{
+		BaseWorkflow: model.BaseWorkflow{
+			ID:          "order",
+			Name:        "Order",
+			SpecVersion: "0.7",
+			Start:       &model.Start{StateName: "Store"},
+		},
+		Functions: []model.Function{{Name: "store", Operation: "http://orders#store"}},
+		States: []model.State{
+			&model.OperationState{
+				BaseState: model.BaseState{Name: "Store", Type: model.StateTypeOperation, End: &model.End{Terminate: true}},
+				Actions:   []model.Action{{FunctionRef: model.FunctionRef{RefName: "store"}}},
+			},
+		},
+	}
+}
+
+// fakeCommand writes an executable script in the directory and returns its path
+func fakeCommand(t *testing.T, dir, name, script string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script), 0700))
+	return path
+}
+
+func TestEvaluateFunc(t *testing.T) {
+	authRef := Func(func(ctx context.Context, workflow *model.Workflow) ([]Violation, error) {
+		var violations []Violation
+		for i, function := range workflow.Functions {
+			if len(function.AuthRef) == 0 {
+				violations = append(violations, Violation{Policy: "auth", Path: fmt.Sprintf("functions[%d].authRef", i), Message: "authRef is required"})
+			}
+		}
+		return violations, nil
+	})
+	violations, err := Evaluate(context.Background(), testWorkflow(), authRef)
+	assert.NoError(t, err)
+	assert.Equal(t, []Violation{{Policy: "auth", Path: "functions[0].authRef", Message: "authRef is required"}}, violations)
+	assert.Equal(t, "functions[0].authRef: authRef is required (auth)", violations[0].String())
+
+	failing := Func(func(ctx context.Context, workflow *model.Workflow) ([]Violation, error) {
+		return nil, fmt.Errorf("unavailable")
+	})
+	_, err = Evaluate(context.Background(), testWorkflow(), authRef, failing)
+	assert.EqualError(t, err, "evaluating policy: unavailable")
+}
+
+func TestRego(t *testing.T) {
+	defer func(command string) { OPACommand = command }(OPACommand)
+	dir, err := ioutil.TempDir("", "policy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the fake opa checks it gets the workflow document and returns canned results
+	OPACommand = fakeCommand(t, dir, "opa", `grep '"order"' > /dev/null || exit 2
+echo "$@" > `+filepath.Join(dir, "args")+`
+echo '{"result": [{"expressions": [{"value": ["function store must use an authRef", {"msg": "no terminate", "path": "states[0].end", "rule": "terminate"}]}]}]}'
+`)
+	violations, err := Evaluate(context.Background(), testWorkflow(), &Rego{Files: []string{"auth.rego"}})
+	require.NoError(t, err)
+	assert.Equal(t, []Violation{
+		{Policy: DefaultRegoQuery, Message: "function store must use an authRef"},
+		{Policy: "terminate", Path: "states[0].end", Message: "no terminate"},
+	}, violations)
+	args, err := ioutil.ReadFile(filepath.Join(dir, "args"))
+	require.NoError(t, err)
+	assert.Equal(t, "eval --format json --stdin-input --data auth.rego data.workflow.deny\n", string(args))
+
+	OPACommand = fakeCommand(t, dir, "opa", "echo 'rego_parse_error' >&2\nexit 1\n")
+	_, err = Evaluate(context.Background(), testWorkflow(), &Rego{Files: []string{"auth.rego"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "rego_parse_error")
+
+	OPACommand = "missing-opa"
+	_, err = Evaluate(context.Background(), testWorkflow(), &Rego{})
+	assert.Error(t, err)
+}
+
+func TestCUE(t *testing.T) {
+	defer func(command string) { CUECommand = command }(CUECommand)
+	dir, err := ioutil.TempDir("", "policy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	CUECommand = fakeCommand(t, dir, "cue", `echo 'functions.0.authRef: incomplete value string:' >&2
+echo '    ./auth.cue:2:14' >&2
+exit 1
+`)
+	violations, err := (&CUE{Files: []string{"auth.cue"}}).Evaluate(context.Background(), &Input{Workflow: testWorkflow()})
+	require.NoError(t, err)
+	assert.Equal(t, []Violation{{Policy: "auth.cue", Path: "functions[0].authRef", Message: "incomplete value string"}}, violations)
+
+	CUECommand = fakeCommand(t, dir, "cue", "exit 0\n")
+	violations, err = (&CUE{Files: []string{"auth.cue"}}).Evaluate(context.Background(), &Input{Workflow: testWorkflow()})
+	assert.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestFromFiles(t *testing.T) {
+	policies, err := FromFiles([]string{"a.rego", "b.cue", "c.rego"})
+	require.NoError(t, err)
+	assert.Equal(t, []Policy{&Rego{Files: []string{"a.rego", "c.rego"}}, &CUE{Files: []string{"b.cue"}}}, policies)
+
+	_, err = FromFiles([]string{"policy.yaml"})
+	assert.EqualError(t, err, "unsupported policy file policy.yaml, expected .rego or .cue")
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// OPACommand Open Policy Agent command used to evaluate Rego policies
+var OPACommand = "opa"
+
+// DefaultRegoQuery query evaluated if not set in the Rego policy, a set of denial messages by convention
+const DefaultRegoQuery = "data.workflow.deny"
+
+// Rego policy written in Rego and evaluated with the OPA command, see OPACommand. The query results are the
+// violations, either messages or objects with 'msg' and optionally 'path' and 'rule' properties, e.g.
+//
+//	package workflow
+//
+//	deny[msg] {
+//	    function := input.functions[_]
+//	    not function.authRef
+//	    msg := sprintf("function %s must use an authRef", [function.name])
+//	}
+type Rego struct {
+	// Files Rego files or directories loaded by OPA
+	Files []string
+	// Query evaluated against the workflow document, DefaultRegoQuery if empty
+	Query string
+}
+
+// Evaluate ...
+func (r *Rego) Evaluate(ctx context.Context, input *Input) ([]Violation, error) {
+	query := r.Query
+	if len(query) == 0 {
+		query = DefaultRegoQuery
+	}
+	args := []string{"eval", "--format", "json", "--stdin-input"}
+	for _, file := range r.Files {
+		args = append(args, "--data", file)
+	}
+	args = append(args, query)
+	out, stderr, err := execute(ctx, OPACommand, "evaluating Rego policies requires OPA", args, input.Document)
+	if err != nil {
+		return nil, commandError(OPACommand, err, stderr)
+	}
+
+	var result struct {
+		Result []struct {
+			Expressions []struct {
+				Value json.RawMessage `json:"value"`
+			} `json:"expressions"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("invalid %s output: %w", OPACommand, err)
+	}
+	var violations []Violation
+	for _, r := range result.Result {
+		for _, expression := range r.Expressions {
+			var values []interface{}
+			if err := json.Unmarshal(expression.Value, &values); err != nil {
+				return nil, fmt.Errorf("query %s must return a set of violations: %w", query, err)
+			}
+			for _, value := range values {
+				violations = append(violations, regoViolation(query, value))
+			}
+		}
+	}
+	return violations, nil
+}
+
+// regoViolation converts a query result to a violation
+func regoViolation(query string, value interface{}) Violation {
+	violation := Violation{Policy: query}
+	switch v := value.(type) {
+	case string:
+		violation.Message = v
+	case map[string]interface{}:
+		violation.Message, _ = v["msg"].(string)
+		violation.Path, _ = v["path"].(string)
+		if rule, ok := v["rule"].(string); ok && len(rule) > 0 {
+			violation.Policy = rule
+		}
+	}
+	if len(violation.Message) == 0 {
+		data, _ := json.Marshal(value)
+		violation.Message = string(data)
+	}
+	return violation
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package profile checks whether workflows fit the capabilities of the runtime meant to run them, e.g. the state
+// types, expression languages and function types it supports.
+package profile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"sigs.k8s.io/yaml"
+)
+
+// Profile capabilities of a workflow runtime, usually loaded from a YAML file. Empty lists and zero limits mean any
+// value is supported, e.g.
+//
+//	name: my-engine
+//	stateTypes: [operation, switch, sleep]
+//	functionTypes: [rest, expression]
+//	maxParallelBranches: 4
+type Profile struct {
+	// Name of the runtime, used in the messages
+	Name string `json:"name,omitempty"`
+	// SpecVersions supported specification versions
+	SpecVersions []string `json:"specVersions,omitempty"`
+	// StateTypes supported state types
+	StateTypes []model.StateType `json:"stateTypes,omitempty"`
+	// ExpressionLangs supported expression languages
+	ExpressionLangs []string `json:"expressionLangs,omitempty"`
+	// FunctionTypes supported function types
+	FunctionTypes []model.FunctionType `json:"functionTypes,omitempty"`
+	// AuthSchemes supported authentication schemes
+	AuthSchemes []model.AuthType `json:"authSchemes,omitempty"`
+	// MaxParallelBranches maximum number of branches of a parallel state
+	MaxParallelBranches int `json:"maxParallelBranches,omitempty"`
+}
+
+// Incompatibility workflow definition the runtime can't run
+type Incompatibility struct {
+	// Path JSON path of the unsupported property, e.g. 'states[0].type'
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// String ...
+func (i Incompatibility) String() string {
+	return i.Path + ": " + i.Message
+}
+
+// Load loads the profile file in the given path
+func Load(path string) (*Profile, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	profile := &Profile{}
+	if err := yaml.UnmarshalStrict(data, profile); err != nil {
+		return nil, fmt.Errorf("invalid profile %s: %w", path, err)
+	}
+	if profile.MaxParallelBranches < 0 {
+		return nil, fmt.Errorf("invalid profile %s: maxParallelBranches must not be negative", path)
+	}
+	return profile, nil
+}
+
+// Check returns the definitions of the workflow the runtime described by the profile doesn't support. The workflow
+// fits the profile if there are none.
+func Check(workflow *model.Workflow, profile *Profile) []Incompatibility {
+	c := &check{profile: profile}
+	if len(workflow.SpecVersion) > 0 && !containsString(profile.SpecVersions, workflow.SpecVersion) {
+		c.report("specVersion", "spec version %s", workflow.SpecVersion)
+	}
+	lang := workflow.ExpressionLang
+	if len(lang) == 0 {
+		lang = model.DefaultExpressionLang
+	}
+	if !containsString(profile.ExpressionLangs, lang) {
+		c.report("expressionLang", "expression language %s", lang)
+	}
+	for i, auth := range workflow.Auth.Defs {
+		scheme := auth.Scheme
+		if len(scheme) == 0 {
+			scheme = model.AuthTypeBasic
+		}
+		if !contains(len(profile.AuthSchemes), func(j int) bool { return profile.AuthSchemes[j] == scheme }) {
+			c.report(fmt.Sprintf("auth[%d].scheme", i), "auth scheme %s", scheme)
+		}
+	}
+	for i, function := range workflow.Functions {
+		functionType := function.Type
+		if len(functionType) == 0 {
+			functionType = model.FunctionTypeREST
+		}
+		if !contains(len(profile.FunctionTypes), func(j int) bool { return profile.FunctionTypes[j] == functionType }) {
+			c.report(fmt.Sprintf("functions[%d].type", i), "function type %s", functionType)
+		}
+	}
+	for i, state := range workflow.States {
+		stateType := state.GetType()
+		if !contains(len(profile.StateTypes), func(j int) bool { return profile.StateTypes[j] == stateType }) {
+			c.report(fmt.Sprintf("states[%d].type", i), "state type %s", stateType)
+		}
+		if parallel, ok := state.(*model.ParallelState); ok && profile.MaxParallelBranches > 0 && len(parallel.Branches) > profile.MaxParallelBranches {
+			c.errs = append(c.errs, Incompatibility{
+				Path:    fmt.Sprintf("states[%d].branches", i),
+				Message: fmt.Sprintf("%d parallel branches, %s supports at most %d", len(parallel.Branches), c.runtime(), profile.MaxParallelBranches),
+			})
+		}
+	}
+	return c.errs
+}
+
+type check struct {
+	profile *Profile
+	errs    []Incompatibility
+}
+
+// report adds the incompatibility of an unsupported value, described by the format and args
+func (c *check) report(path, format string, args ...interface{}) {
+	c.errs = append(c.errs, Incompatibility{Path: path, Message: fmt.Sprintf(format, args...) + " is not supported by " + c.runtime()})
+}
+
+func (c *check) runtime() string {
+	if len(c.profile.Name) > 0 {
+		return c.profile.Name
+	}
+	return "the runtime"
+}
+
+// contains tells whether any of the n supported values matches, or if all of them are supported when n is zero
+func contains(n int, match func(i int) bool) bool {
+	if n == 0 {
+		return true
+	}
+	for i := 0; i < n; i++ {
+		if match(i) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	return contains(len(values), func(i int) bool { return values[i] == value })
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWorkflow() *model.Workflow {
+	return &model.Workflow{
+		BaseWorkflow: model.BaseWorkflow{
+			ID:          "order",
+			Name:        "Order",
+			SpecVersion: "0.7",
+			Start:       &model.Start{StateName: "Store"},
+			Auth:        model.AuthDefinitions{Defs: []model.Auth{{Name: "token", Scheme: model.AuthTypeBearer}}},
+		},
+		Functions: []model.Function{
+			{Name: "store", Operation: "http://orders#store"},
+			{Name: "total", Operation: ".price * .quantity", Type: model.FunctionTypeExpression},
+		},
+		States: []model.State{
+			&model.ParallelState{
+				BaseState: model.BaseState{Name: "Store", Type: model.StateTypeParallel, Transition: &model.Transition{NextState: "Wait"}},
+				Branches:  []model.Branch{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+			},
+			&model.SleepState{
+				BaseState: model.BaseState{Name: "Wait", Type: model.StateTypeSleep, End: &model.End{Terminate: true}},
+				Duration:  "PT1M",
+			},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	assert.Empty(t, Check(testWorkflow(), &Profile{}))
+
+	profile := &Profile{
+		Name:                "engine",
+		SpecVersions:        []string{"0.8"},
+		StateTypes:          []model.StateType{model.StateTypeParallel, model.StateTypeOperation},
+		ExpressionLangs:     []string{"jq"},
+		FunctionTypes:       []model.FunctionType{model.FunctionTypeREST},
+		AuthSchemes:         []model.AuthType{model.AuthTypeBasic},
+		MaxParallelBranches: 2,
+	}
+	incompatibilities := Check(testWorkflow(), profile)
+	assert.Equal(t, []Incompatibility{
+		{Path: "specVersion", Message: "spec version 0.7 is not supported by engine"},
+		{Path: "auth[0].scheme", Message: "auth scheme bearer is not supported by engine"},
+		{Path: "functions[1].type", Message: "function type expression is not supported by engine"},
+		{Path: "states[0].branches", Message: "3 parallel branches, engine supports at most 2"},
+		{Path: "states[1].type", Message: "state type sleep is not supported by engine"},
+	}, incompatibilities)
+	assert.Equal(t, "specVersion: spec version 0.7 is not supported by engine", incompatibilities[0].String())
+
+	workflow := testWorkflow()
+	workflow.ExpressionLang = "jsonpath"
+	assert.Equal(t, []Incompatibility{{Path: "expressionLang", Message: "expression language jsonpath is not supported by the runtime"}},
+		Check(workflow, &Profile{ExpressionLangs: []string{"jq"}}))
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "profile")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "engine.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte("name: engine\nstateTypes: [operation, switch]\nmaxParallelBranches: 4\n"), 0600))
+	profile, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, &Profile{Name: "engine", StateTypes: []model.StateType{"operation", "switch"}, MaxParallelBranches: 4}, profile)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("stateTypes: [operation]\nmaxStates: 4\n"), 0600))
+	_, err = Load(path)
+	assert.Error(t, err)
+	require.NoError(t, ioutil.WriteFile(path, []byte("maxParallelBranches: -1\n"), 0600))
+	_, err = Load(path)
+	assert.EqualError(t, err, "invalid profile "+path+": maxParallelBranches must not be negative")
+}
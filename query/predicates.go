@@ -0,0 +1,155 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// actionPredicate matches the action item, or the state items having a matching action
+func actionPredicate(match func(item *Item, action *model.Action) bool) Predicate {
+	return func(item *Item) bool {
+		if item.Action != nil {
+			return match(item, item.Action)
+		}
+		for _, action := range model.GetActions(item.State) {
+			if match(item, action) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// StateType matches the states of the given type, and their actions
+func StateType(stateType model.StateType) Predicate {
+	return func(item *Item) bool {
+		return item.State.GetType() == stateType
+	}
+}
+
+// StateName matches the state with the given name, and its actions
+func StateName(name string) Predicate {
+	return func(item *Item) bool {
+		return item.State.GetName() == name
+	}
+}
+
+// InvokesFunction matches the actions calling the function
+func InvokesFunction(name string) Predicate {
+	return actionPredicate(func(_ *Item, action *model.Action) bool {
+		return action.FunctionRef.RefName == name
+	})
+}
+
+// InvokesSubflow matches the actions running the workflow as subflow, of any version
+func InvokesSubflow(workflowID string) Predicate {
+	return actionPredicate(func(_ *Item, action *model.Action) bool {
+		return action.SubFlowRef.WorkflowID == workflowID
+	})
+}
+
+// HasRetryRef matches the actions with a retry strategy, Not(HasRetryRef()) the ones without
+func HasRetryRef() Predicate {
+	return actionPredicate(func(_ *Item, action *model.Action) bool {
+		return len(action.RetryRef) > 0
+	})
+}
+
+// UsesRetry matches the actions retried with the given retry strategy
+func UsesRetry(name string) Predicate {
+	return actionPredicate(func(_ *Item, action *model.Action) bool {
+		return action.RetryRef == name
+	})
+}
+
+// ConsumesEventType matches the states waiting for events of the CloudEvent type, and the actions waiting for them as
+// result
+func ConsumesEventType(eventType string) Predicate {
+	return func(item *Item) bool {
+		is := func(name string) bool {
+			return isEventType(item.Workflow, name, eventType)
+		}
+		if item.Action != nil {
+			return is(item.Action.EventRef.GetResultEventRef())
+		}
+		switch s := item.State.(type) {
+		case *model.EventState:
+			for _, onEvent := range s.OnEvents {
+				for _, ref := range onEvent.EventRefs {
+					if is(ref) {
+						return true
+					}
+				}
+			}
+		case *model.CallbackState:
+			if is(s.EventRef) {
+				return true
+			}
+		case *model.EventBasedSwitchState:
+			for _, condition := range s.EventConditions {
+				if is(condition.GetEventRef()) {
+					return true
+				}
+			}
+		}
+		for _, action := range model.GetActions(item.State) {
+			if is(action.EventRef.GetResultEventRef()) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ProducesEventType matches the states producing events of the CloudEvent type when they transition or end, and the
+// actions triggering them
+func ProducesEventType(eventType string) Predicate {
+	return func(item *Item) bool {
+		is := func(name string) bool {
+			return isEventType(item.Workflow, name, eventType)
+		}
+		if item.Action != nil {
+			return is(item.Action.EventRef.GetTriggerEventRef())
+		}
+		var produced []model.ProduceEvent
+		if transition := item.State.GetTransition(); transition != nil {
+			produced = append(produced, transition.ProduceEvents...)
+		}
+		if end := item.State.GetEnd(); end != nil {
+			produced = append(produced, end.ProduceEvents...)
+		}
+		for _, produce := range produced {
+			if is(produce.EventRef) {
+				return true
+			}
+		}
+		for _, action := range model.GetActions(item.State) {
+			if is(action.EventRef.GetTriggerEventRef()) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// isEventType tells whether the event with the given name is defined with the CloudEvent type
+func isEventType(workflow *model.Workflow, name, eventType string) bool {
+	if len(name) == 0 {
+		return false
+	}
+	event, ok := workflow.Event(name)
+	return ok && event.Type == eventType
+}
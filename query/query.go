@@ -0,0 +1,153 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/workspace"
+)
+
+// Item state or action of a workflow visited by a query
+type Item struct {
+	// Workflow the item belongs to, and File the workflow was loaded from when querying a workspace
+	Workflow *model.Workflow
+	File     string
+	State    model.State
+	// Action nil when the item is a state
+	Action *model.Action
+	// Path of the item in the workflow, e.g. 'states[0].actions[1]'
+	Path string
+}
+
+// Predicate tells whether an item matches. Predicates about actions applied to a state match if any action of the
+// state matches.
+type Predicate func(item *Item) bool
+
+// And matches the items matching all the predicates
+func And(predicates ...Predicate) Predicate {
+	return func(item *Item) bool {
+		for _, p := range predicates {
+			if !p(item) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches the items matching any of the predicates
+func Or(predicates ...Predicate) Predicate {
+	return func(item *Item) bool {
+		for _, p := range predicates {
+			if p(item) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches the items not matching the predicate
+func Not(predicate Predicate) Predicate {
+	return func(item *Item) bool {
+		return !predicate(item)
+	}
+}
+
+// States returns the states of the workflow matching the predicate
+func States(workflow *model.Workflow, predicate Predicate) []Item {
+	var items []Item
+	for i, state := range workflow.States {
+		item := Item{Workflow: workflow, State: state, Path: fmt.Sprintf("states[%d]", i)}
+		if predicate(&item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Actions returns the actions of the workflow matching the predicate, including the ones of event handlers and
+// parallel branches
+func Actions(workflow *model.Workflow, predicate Predicate) []Item {
+	var items []Item
+	for i, state := range workflow.States {
+		forEachAction(state, fmt.Sprintf("states[%d]", i), func(path string, action *model.Action) {
+			item := Item{Workflow: workflow, State: state, Action: action, Path: path}
+			if predicate(&item) {
+				items = append(items, item)
+			}
+		})
+	}
+	return items
+}
+
+// WorkspaceStates returns the states matching the predicate of all the workspace workflows
+func WorkspaceStates(w *workspace.Workspace, predicate Predicate) []Item {
+	return inWorkspace(w, predicate, States)
+}
+
+// WorkspaceActions returns the actions matching the predicate of all the workspace workflows
+func WorkspaceActions(w *workspace.Workspace, predicate Predicate) []Item {
+	return inWorkspace(w, predicate, Actions)
+}
+
+// Workflows returns the workspace workflows having at least a state matching the predicate
+func Workflows(w *workspace.Workspace, predicate Predicate) []*workspace.Workflow {
+	var workflows []*workspace.Workflow
+	for _, workflow := range w.Workflows {
+		if len(States(workflow.Workflow, predicate)) > 0 {
+			workflows = append(workflows, workflow)
+		}
+	}
+	return workflows
+}
+
+func inWorkspace(w *workspace.Workspace, predicate Predicate, query func(*model.Workflow, Predicate) []Item) []Item {
+	var items []Item
+	for _, workflow := range w.Workflows {
+		for _, item := range query(workflow.Workflow, predicate) {
+			item.File = workflow.File
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// forEachAction calls fn with every action of the state and its path
+func forEachAction(state model.State, path string, fn func(path string, action *model.Action)) {
+	each := func(path string, actions []model.Action) {
+		for i := range actions {
+			fn(fmt.Sprintf("%s[%d]", path, i), &actions[i])
+		}
+	}
+	switch s := state.(type) {
+	case *model.OperationState:
+		each(path+".actions", s.Actions)
+	case *model.ForEachState:
+		each(path+".actions", s.Actions)
+	case *model.ParallelState:
+		for i, branch := range s.Branches {
+			each(fmt.Sprintf("%s.branches[%d].actions", path, i), branch.Actions)
+		}
+	case *model.EventState:
+		for i, onEvent := range s.OnEvents {
+			each(fmt.Sprintf("%s.onEvents[%d].actions", path, i), onEvent.Actions)
+		}
+	case *model.CallbackState:
+		fn(path+".action", &s.Action)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderWorkflow = `id: order
+name: Order
+version: '1.0'
+specVersion: '0.7'
+start: Receive
+retries:
+  - name: threeTimes
+    maxAttempts: 3
+events:
+  - name: OrderCreated
+    type: order.created
+  - name: PaymentRequested
+    type: payment.requested
+    kind: produced
+  - name: PaymentDone
+    type: payment.done
+  - name: OrderShipped
+    type: order.shipped
+    kind: produced
+functions:
+  - name: storeOrder
+    operation: http://orders.json#store
+  - name: ship
+    operation: http://shipping.json#ship
+states:
+  - name: Receive
+    type: event
+    onEvents:
+      - eventRefs: [OrderCreated]
+        actions:
+          - functionRef: storeOrder
+            retryRef: threeTimes
+    transition: Pay
+  - name: Pay
+    type: operation
+    actions:
+      - eventRef:
+          triggerEventRef: PaymentRequested
+          resultEventRef: PaymentDone
+      - functionRef: storeOrder
+    transition: Ship
+  - name: Ship
+    type: parallel
+    branches:
+      - name: Ship
+        actions:
+          - functionRef: ship
+      - name: Store
+        actions:
+          - functionRef: storeOrder
+            retryRef: threeTimes
+    end:
+      produceEvents:
+        - eventRef: OrderShipped
+`
+
+func paths(items []Item) []string {
+	var paths []string
+	for _, item := range items {
+		paths = append(paths, item.Path)
+	}
+	return paths
+}
+
+func TestQuery(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"states[0]", "states[1]", "states[2]"}, paths(States(workflow, InvokesFunction("storeOrder"))))
+	assert.Equal(t, []string{"states[0].onEvents[0].actions[0]", "states[1].actions[1]", "states[2].branches[1].actions[0]"},
+		paths(Actions(workflow, InvokesFunction("storeOrder"))))
+	assert.Equal(t, []string{"states[1].actions[0]", "states[1].actions[1]", "states[2].branches[0].actions[0]"},
+		paths(Actions(workflow, Not(HasRetryRef()))))
+	assert.Equal(t, []string{"states[1].actions[1]"},
+		paths(Actions(workflow, And(Not(HasRetryRef()), InvokesFunction("storeOrder")))))
+	assert.Equal(t, []string{"states[0]", "states[2]"}, paths(States(workflow, UsesRetry("threeTimes"))))
+	assert.Equal(t, []string{"states[1]", "states[2]"}, paths(States(workflow, Or(StateName("Pay"), StateType(model.StateTypeParallel)))))
+
+	assert.Equal(t, []string{"states[0]"}, paths(States(workflow, ConsumesEventType("order.created"))))
+	assert.Equal(t, []string{"states[1]"}, paths(States(workflow, ConsumesEventType("payment.done"))))
+	assert.Equal(t, []string{"states[1].actions[0]"}, paths(Actions(workflow, ConsumesEventType("payment.done"))))
+	assert.Equal(t, []string{"states[1]"}, paths(States(workflow, ProducesEventType("payment.requested"))))
+	assert.Equal(t, []string{"states[2]"}, paths(States(workflow, ProducesEventType("order.shipped"))))
+	assert.Empty(t, States(workflow, ConsumesEventType("order.shipped")))
+
+	items := Actions(workflow, InvokesFunction("ship"))
+	require.Len(t, items, 1)
+	assert.Equal(t, workflow, items[0].Workflow)
+	assert.Equal(t, "Ship", items[0].State.GetName())
+	assert.Equal(t, "ship", items[0].Action.FunctionRef.RefName)
+}
+
+func TestQueryWorkspace(t *testing.T) {
+	w, err := workspace.Load([]string{
+		"../parser/testdata/workflows/eventbasedgreeting.sw.json",
+		"../parser/testdata/workflows/applicationrequest.json",
+	})
+	require.NoError(t, err)
+
+	workflows := Workflows(w, ConsumesEventType("greetingEventType"))
+	require.Len(t, workflows, 1)
+	assert.Equal(t, "eventbasedgreeting", workflows[0].Workflow.ID)
+
+	items := WorkspaceActions(w, InvokesFunction("sendRejectionEmailFunction"))
+	require.Len(t, items, 1)
+	assert.Equal(t, "../parser/testdata/workflows/applicationrequest.json", items[0].File)
+	assert.Equal(t, "states[2].actions[0]", items[0].Path)
+	assert.Len(t, WorkspaceStates(w, StateType(model.StateTypeOperation)), 2)
+}
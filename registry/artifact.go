@@ -0,0 +1,238 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+)
+
+const (
+	// ArtifactType artifact type of the workflow artifacts
+	ArtifactType = "application/vnd.serverlessworkflow.workflow.v1"
+	// ConfigMediaType media type of the artifact config, describing the packaged workflow
+	ConfigMediaType = "application/vnd.serverlessworkflow.config.v1+json"
+	// WorkflowJSONMediaType media type of the layer holding a JSON workflow definition
+	WorkflowJSONMediaType = "application/vnd.serverlessworkflow.workflow.v1+json"
+	// WorkflowYAMLMediaType media type of the layer holding a YAML workflow definition
+	WorkflowYAMLMediaType = "application/vnd.serverlessworkflow.workflow.v1+yaml"
+	// ResourceMediaType media type of the layers holding the files referenced by the workflow
+	ResourceMediaType = "application/vnd.serverlessworkflow.resource.v1"
+	// ManifestMediaType OCI image manifest media type
+	ManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+
+	// AnnotationTitle file name of a layer
+	AnnotationTitle = "org.opencontainers.image.title"
+	// AnnotationCreated creation date of the artifact
+	AnnotationCreated = "org.opencontainers.image.created"
+	// AnnotationSource URL of the source the workflow was built from
+	AnnotationSource = "org.opencontainers.image.source"
+	// AnnotationRevision source control revision the workflow was built from
+	AnnotationRevision = "org.opencontainers.image.revision"
+	// AnnotationVersion version of the packaged workflow
+	AnnotationVersion = "org.opencontainers.image.version"
+
+	digestAlgorithm = "sha256"
+)
+
+// File file packaged in an artifact
+type File struct {
+	// Name path relative to the workflow file
+	Name      string
+	MediaType string
+	Data      []byte
+}
+
+// Artifact workflow definition and the local files it references, e.g. OpenAPI documents and schemas
+type Artifact struct {
+	Workflow  File
+	Resources []File
+	// Annotations of the artifact manifest, e.g. AnnotationSource and AnnotationRevision for provenance
+	Annotations map[string]string
+	// Config describes the packaged workflow
+	Config Config
+}
+
+// Config artifact config blob
+type Config struct {
+	ID          string `json:"id,omitempty"`
+	Key         string `json:"key,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Version     string `json:"version,omitempty"`
+	SpecVersion string `json:"specVersion,omitempty"`
+}
+
+// Descriptor OCI content descriptor
+type Descriptor struct {
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest OCI image manifest
+type Manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// NewArtifact packages the workflow file in the given path along with the local files referenced by its functions
+// operations and its data input schema
+func NewArtifact(workflowPath string) (*Artifact, error) {
+	workflow, err := parser.FromFile(workflowPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(filepath.Clean(workflowPath))
+	if err != nil {
+		return nil, err
+	}
+	mediaType := WorkflowJSONMediaType
+	if ext := filepath.Ext(workflowPath); ext == ".yaml" || ext == ".yml" {
+		mediaType = WorkflowYAMLMediaType
+	}
+	artifact := &Artifact{
+		Workflow: File{Name: filepath.Base(workflowPath), MediaType: mediaType, Data: data},
+		Config: Config{
+			ID:          workflow.ID,
+			Key:         workflow.Key,
+			Name:        workflow.Name,
+			Version:     workflow.Version,
+			SpecVersion: workflow.SpecVersion,
+		},
+		Annotations: map[string]string{AnnotationCreated: time.Now().UTC().Format(time.RFC3339)},
+	}
+	if len(workflow.Version) > 0 {
+		artifact.Annotations[AnnotationVersion] = workflow.Version
+	}
+	baseDir := filepath.Dir(workflowPath)
+	for _, name := range LocalReferences(workflow) {
+		data, err := ioutil.ReadFile(filepath.Join(baseDir, filepath.FromSlash(name)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to package %s: %w", name, err)
+		}
+		artifact.Resources = append(artifact.Resources, File{Name: name, MediaType: ResourceMediaType, Data: data})
+	}
+	return artifact, nil
+}
+
+// LocalReferences returns the sorted relative paths of the local files referenced by the workflow
+func LocalReferences(workflow *model.Workflow) []string {
+	references := map[string]bool{}
+	add := func(uri string) {
+		if i := strings.Index(uri, "#"); i >= 0 {
+			uri = uri[:i]
+		}
+		parsed, err := url.Parse(uri)
+		if err != nil || len(uri) == 0 {
+			return
+		}
+		if len(parsed.Scheme) > 0 && parsed.Scheme != "file" {
+			return
+		}
+		name := parsed.Host + parsed.Path
+		if path.IsAbs(name) || strings.HasPrefix(path.Clean(name), "..") {
+			// only the files next to the workflow are packaged
+			return
+		}
+		references[path.Clean(name)] = true
+	}
+	for _, function := range workflow.Functions {
+		if function.Type != model.FunctionTypeExpression {
+			add(function.Operation)
+		}
+	}
+	if workflow.DataInputSchema != nil {
+		add(workflow.DataInputSchema.Schema)
+	}
+	var names []string
+	for name := range references {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// manifest builds the artifact manifest and the blobs it references, keyed by digest
+func (a *Artifact) manifest() ([]byte, map[string][]byte, error) {
+	blobs := map[string][]byte{}
+	config, err := json.Marshal(a.Config)
+	if err != nil {
+		return nil, nil, err
+	}
+	manifest := Manifest{
+		SchemaVersion: 2,
+		MediaType:     ManifestMediaType,
+		ArtifactType:  ArtifactType,
+		Config:        descriptor(ConfigMediaType, config, nil),
+		Annotations:   a.Annotations,
+	}
+	blobs[manifest.Config.Digest] = config
+	for _, file := range append([]File{a.Workflow}, a.Resources...) {
+		layer := descriptor(file.MediaType, file.Data, map[string]string{AnnotationTitle: file.Name})
+		manifest.Layers = append(manifest.Layers, layer)
+		blobs[layer.Digest] = file.Data
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, blobs, nil
+}
+
+// WriteTo writes the workflow and its resources in the given directory
+func (a *Artifact) WriteTo(dir string) error {
+	for _, file := range append([]File{a.Workflow}, a.Resources...) {
+		target := filepath.Join(dir, filepath.FromSlash(file.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(filepath.Separator)) {
+			return fmt.Errorf("invalid file name %s", file.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(target, file.Data, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func descriptor(mediaType string, data []byte, annotations map[string]string) Descriptor {
+	return Descriptor{MediaType: mediaType, Digest: Digest(data), Size: int64(len(data)), Annotations: annotations}
+}
+
+// Digest returns the sha256 digest of the given content, as 'sha256:<hex>'
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return digestAlgorithm + ":" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,198 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+)
+
+// DigestHeader response header of a catalog holding the digest of the workflow definition, as 'sha256:<hex>'
+const DigestHeader = "Workflow-Digest"
+
+// Catalog client of an HTTP workflow registry, serving the workflow definitions in JSON or YAML by id and version:
+//
+//	GET <URL>/workflows/<id>/versions/<version>  the version of the workflow
+//	GET <URL>/workflows/<id>                     the latest version of the workflow
+//
+// The definitions are checked against the DigestHeader of the response if set, and against the pinned Digests. The
+// versions are immutable: they're cached once fetched, in memory and in the CacheDir if set, while the latest
+// versions are revalidated with their ETag on every fetch. A Catalog is safe for concurrent use.
+type Catalog struct {
+	// URL base URL of the registry
+	URL string
+	// HTTPClient default is http.DefaultClient
+	HTTPClient *http.Client
+	// Token bearer token sent to the registry if set, otherwise the Username and Password if set
+	Token    string
+	Username string
+	Password string
+	// Digests expected digests of the workflows, by id and version as 'order@1.0', e.g. from a lock file
+	Digests map[string]string
+	// CacheDir directory caching the fetched versions between the processes, not cached on disk if empty
+	CacheDir string
+
+	mutex  sync.Mutex
+	cached map[string]*cachedWorkflow
+}
+
+type cachedWorkflow struct {
+	workflow *model.Workflow
+	etag     string
+}
+
+// Fetch returns the workflow with the given id and version, the latest version if the version is empty. The returned
+// workflow is a copy the caller may modify.
+func (c *Catalog) Fetch(ctx context.Context, id, version string) (*model.Workflow, error) {
+	if len(id) == 0 {
+		return nil, fmt.Errorf("workflow id is required")
+	}
+	key := id
+	if len(version) > 0 {
+		key += "@" + version
+	}
+	c.mutex.Lock()
+	cached := c.cached[key]
+	c.mutex.Unlock()
+	if cached != nil && len(version) > 0 {
+		return cached.workflow.DeepCopy(), nil
+	}
+	if cached == nil && len(version) > 0 && len(c.CacheDir) > 0 {
+		if data, err := ioutil.ReadFile(c.cacheFile(key)); err == nil {
+			// the cache directory may have been tampered with, the pinned digests are checked again
+			if workflow, err := c.decode(key, id, version, data, ""); err == nil {
+				c.store(key, &cachedWorkflow{workflow: workflow})
+				return workflow.DeepCopy(), nil
+			}
+		}
+	}
+
+	target := c.url(id, version)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json, application/yaml")
+	if cached != nil && len(cached.etag) > 0 {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+	if len(c.Token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	} else if len(c.Username) > 0 {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		if cached != nil {
+			return cached.workflow.DeepCopy(), nil
+		}
+		return nil, statusError("get "+target, resp)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("workflow %s is not in the registry", key)
+	default:
+		return nil, statusError("get "+target, resp)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	workflow, err := c.decode(key, id, version, data, resp.Header.Get(DigestHeader))
+	if err != nil {
+		return nil, err
+	}
+	c.store(key, &cachedWorkflow{workflow: workflow, etag: resp.Header.Get("ETag")})
+	if len(version) > 0 && len(c.CacheDir) > 0 {
+		if err := os.MkdirAll(c.CacheDir, 0755); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(c.cacheFile(key), data, 0644); err != nil {
+			return nil, err
+		}
+	}
+	return workflow.DeepCopy(), nil
+}
+
+// Resolve returns the workflow referenced by a subflow or continueAs reference, e.g. for the Remote of a
+// workspace.Workspace
+func (c *Catalog) Resolve(ref model.WorkflowRef) (*model.Workflow, error) {
+	return c.Fetch(context.Background(), ref.WorkflowID, ref.Version)
+}
+
+// decode checks the integrity of the workflow definition and parses it
+func (c *Catalog) decode(key, id, version string, data []byte, digest string) (*model.Workflow, error) {
+	actual := Digest(data)
+	if len(digest) > 0 && digest != actual {
+		return nil, fmt.Errorf("workflow %s digest %s doesn't match the digest %s sent by the registry", key, actual, digest)
+	}
+	if pinned, ok := c.Digests[key]; ok && pinned != actual {
+		return nil, fmt.Errorf("workflow %s digest %s doesn't match the pinned digest %s", key, actual, pinned)
+	}
+	workflow, err := parser.FromYAMLSource(data)
+	if err != nil {
+		return nil, fmt.Errorf("workflow %s: %w", key, err)
+	}
+	if workflow.ID != id || (len(version) > 0 && workflow.Version != version) {
+		return nil, fmt.Errorf("registry returned the workflow %s version %s instead of %s", workflow.ID, workflow.Version, key)
+	}
+	if pinned, ok := c.Digests[id+"@"+workflow.Version]; ok && len(version) == 0 && pinned != actual {
+		return nil, fmt.Errorf("workflow %s@%s digest %s doesn't match the pinned digest %s", id, workflow.Version, actual, pinned)
+	}
+	return workflow, nil
+}
+
+func (c *Catalog) store(key string, cached *cachedWorkflow) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.cached == nil {
+		c.cached = map[string]*cachedWorkflow{}
+	}
+	c.cached[key] = cached
+}
+
+func (c *Catalog) url(id, version string) string {
+	target := strings.TrimSuffix(c.URL, "/") + "/workflows/" + url.PathEscape(id)
+	if len(version) > 0 {
+		target += "/versions/" + url.PathEscape(version)
+	}
+	return target
+}
+
+// cacheFile path of the cached version, escaped since the ids and versions may hold path separators
+func (c *Catalog) cacheFile(key string) string {
+	return filepath.Join(c.CacheDir, url.PathEscape(key))
+}
+
+func (c *Catalog) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
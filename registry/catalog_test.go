@@ -0,0 +1,123 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeCatalog serves the greeting workflow definition, as version 1.0 and as the latest version, requiring a token
+func newFakeCatalog(t *testing.T, requests *int32) *httptest.Server {
+	data, err := ioutil.ReadFile("testdata/greetings.sw.yaml")
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if req.Header.Get("Authorization") != "Bearer t0k3n" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch req.URL.Path {
+		case "/workflows/greeting/versions/1.0", "/workflows/greeting":
+		case "/workflows/greeting/versions/2.0":
+			// returns the wrong version
+		case "/workflows/tampered/versions/1.0":
+			w.Header().Set(DigestHeader, Digest([]byte("something else")))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if strings.HasPrefix(req.URL.Path, "/workflows/greeting") {
+			w.Header().Set(DigestHeader, Digest(data))
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCatalogFetch(t *testing.T) {
+	var requests int32
+	server := newFakeCatalog(t, &requests)
+	catalog := &Catalog{URL: server.URL + "/", Token: "t0k3n"}
+
+	workflow, err := catalog.Fetch(context.Background(), "greeting", "1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Greeting Workflow", workflow.Name)
+	// modifying the workflow doesn't modify the cached one
+	workflow.Name = "modified"
+	workflow, err = catalog.Fetch(context.Background(), "greeting", "1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Greeting Workflow", workflow.Name)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// the latest version is revalidated
+	_, err = catalog.Fetch(context.Background(), "greeting", "")
+	require.NoError(t, err)
+	workflow, err = catalog.Fetch(context.Background(), "greeting", "")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", workflow.Version)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	_, err = catalog.Fetch(context.Background(), "shipping", "1.0")
+	assert.EqualError(t, err, "workflow shipping@1.0 is not in the registry")
+	_, err = catalog.Fetch(context.Background(), "greeting", "2.0")
+	assert.EqualError(t, err, "registry returned the workflow greeting version 1.0 instead of greeting@2.0")
+	_, err = (&Catalog{URL: server.URL}).Fetch(context.Background(), "greeting", "1.0")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "401 Unauthorized")
+}
+
+func TestCatalogIntegrity(t *testing.T) {
+	var requests int32
+	server := newFakeCatalog(t, &requests)
+	catalog := &Catalog{URL: server.URL, Token: "t0k3n", Digests: map[string]string{"greeting@1.0": Digest([]byte("pinned"))}}
+	_, err := catalog.Fetch(context.Background(), "greeting", "1.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't match the pinned digest "+Digest([]byte("pinned")))
+	// the latest version is checked against the digest pinned for its version
+	_, err = catalog.Fetch(context.Background(), "greeting", "")
+	assert.Error(t, err)
+
+	_, err = catalog.Fetch(context.Background(), "tampered", "1.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't match the digest "+Digest([]byte("something else"))+" sent by the registry")
+}
+
+func TestCatalogCacheDir(t *testing.T) {
+	var requests int32
+	server := newFakeCatalog(t, &requests)
+	dir := t.TempDir()
+	_, err := (&Catalog{URL: server.URL, Token: "t0k3n", CacheDir: dir}).Fetch(context.Background(), "greeting", "1.0")
+	require.NoError(t, err)
+	workflow, err := (&Catalog{URL: server.URL, Token: "t0k3n", CacheDir: dir}).Resolve(model.WorkflowRef{WorkflowID: "greeting", Version: "1.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "greeting", workflow.ID)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
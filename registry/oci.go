@@ -0,0 +1,375 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// DefaultTag tag used when the reference has neither a tag nor a digest
+	DefaultTag = "latest"
+
+	headerAuthenticate = "Www-Authenticate"
+	headerLocation     = "Location"
+)
+
+// Reference reference to an artifact in a registry, e.g. 'ghcr.io/acme/workflows/order:1.0' or
+// 'localhost:5000/order@sha256:...'
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses the given artifact reference
+func ParseReference(ref string) (Reference, error) {
+	r := Reference{}
+	slash := strings.Index(ref, "/")
+	if slash <= 0 {
+		return r, fmt.Errorf("reference %s must include the registry host", ref)
+	}
+	r.Registry, ref = ref[:slash], ref[slash+1:]
+	if i := strings.Index(ref, "@"); i >= 0 {
+		r.Digest, ref = ref[i+1:], ref[:i]
+		if !strings.HasPrefix(r.Digest, digestAlgorithm+":") {
+			return r, fmt.Errorf("digest %s not supported, only %s digests are", r.Digest, digestAlgorithm)
+		}
+	}
+	if i := strings.LastIndex(ref, ":"); i >= 0 {
+		r.Tag, ref = ref[i+1:], ref[:i]
+	}
+	if len(ref) == 0 || strings.ToLower(ref) != ref {
+		return r, fmt.Errorf("invalid repository %s", ref)
+	}
+	r.Repository = ref
+	if len(r.Tag) == 0 && len(r.Digest) == 0 {
+		r.Tag = DefaultTag
+	}
+	return r, nil
+}
+
+// String ...
+func (r Reference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if len(r.Tag) > 0 {
+		s += ":" + r.Tag
+	}
+	if len(r.Digest) > 0 {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// version tag or digest identifying the manifest, the digest takes precedence
+func (r Reference) version() string {
+	if len(r.Digest) > 0 {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// Client pushes and pulls workflow artifacts using the OCI distribution API
+type Client struct {
+	// HTTPClient default is http.DefaultClient
+	HTTPClient *http.Client
+	// Username and Password credentials used to authenticate, if the registry requires them
+	Username string
+	Password string
+	// PlainHTTP uses http instead of https, e.g. for local registries
+	PlainHTTP bool
+
+	// tokens bearer tokens by scope
+	tokens map[string]string
+}
+
+// Push uploads the artifact blobs and its manifest, tagged with the reference tag. Returns the manifest digest.
+func (c *Client) Push(ctx context.Context, ref Reference, artifact *Artifact) (string, error) {
+	manifest, blobs, err := artifact.manifest()
+	if err != nil {
+		return "", err
+	}
+	for digest, data := range blobs {
+		if err := c.pushBlob(ctx, ref, digest, data); err != nil {
+			return "", err
+		}
+	}
+	digest := Digest(manifest)
+	if len(ref.Digest) > 0 && ref.Digest != digest {
+		return "", fmt.Errorf("manifest digest %s doesn't match the reference digest %s", digest, ref.Digest)
+	}
+	tag := ref.Tag
+	if len(tag) == 0 {
+		tag = digest
+	}
+	resp, err := c.do(ctx, ref, http.MethodPut, c.url(ref, "manifests", tag), manifest, map[string]string{"Content-Type": ManifestMediaType})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", statusError("push manifest", resp)
+	}
+	return digest, nil
+}
+
+// Pull downloads the artifact with the given reference and verifies the digests of its content. Returns the artifact
+// and its manifest digest.
+func (c *Client) Pull(ctx context.Context, ref Reference) (*Artifact, string, error) {
+	data, err := c.get(ctx, ref, c.url(ref, "manifests", ref.version()), ManifestMediaType)
+	if err != nil {
+		return nil, "", err
+	}
+	digest := Digest(data)
+	if len(ref.Digest) > 0 && ref.Digest != digest {
+		return nil, "", fmt.Errorf("manifest digest %s doesn't match the reference digest %s", digest, ref.Digest)
+	}
+	manifest := Manifest{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, "", fmt.Errorf("invalid manifest: %w", err)
+	}
+	if manifest.Config.MediaType != ConfigMediaType {
+		return nil, "", fmt.Errorf("%s is not a workflow artifact, config media type is %s", ref, manifest.Config.MediaType)
+	}
+	if len(manifest.Layers) == 0 {
+		return nil, "", fmt.Errorf("%s has no workflow layer", ref)
+	}
+
+	artifact := &Artifact{Annotations: manifest.Annotations}
+	config, err := c.pullBlob(ctx, ref, manifest.Config)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := json.Unmarshal(config, &artifact.Config); err != nil {
+		return nil, "", fmt.Errorf("invalid config: %w", err)
+	}
+	for i, layer := range manifest.Layers {
+		content, err := c.pullBlob(ctx, ref, layer)
+		if err != nil {
+			return nil, "", err
+		}
+		file := File{Name: layer.Annotations[AnnotationTitle], MediaType: layer.MediaType, Data: content}
+		if i == 0 {
+			artifact.Workflow = file
+		} else {
+			artifact.Resources = append(artifact.Resources, file)
+		}
+	}
+	return artifact, digest, nil
+}
+
+func (c *Client) pushBlob(ctx context.Context, ref Reference, digest string, data []byte) error {
+	resp, err := c.do(ctx, ref, http.MethodHead, c.url(ref, "blobs", digest), nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		// already uploaded
+		return nil
+	}
+
+	resp, err = c.do(ctx, ref, http.MethodPost, c.url(ref, "blobs", "uploads/"), nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return statusError("start blob upload", resp)
+	}
+	location, err := resp.Request.URL.Parse(resp.Header.Get(headerLocation))
+	if err != nil {
+		return fmt.Errorf("invalid upload location: %w", err)
+	}
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	resp, err = c.do(ctx, ref, http.MethodPut, location.String(), data, map[string]string{"Content-Type": "application/octet-stream"})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return statusError("upload blob", resp)
+	}
+	return nil
+}
+
+func (c *Client) pullBlob(ctx context.Context, ref Reference, desc Descriptor) ([]byte, error) {
+	data, err := c.get(ctx, ref, c.url(ref, "blobs", desc.Digest), "")
+	if err != nil {
+		return nil, err
+	}
+	if digest := Digest(data); digest != desc.Digest {
+		return nil, fmt.Errorf("blob digest %s doesn't match the expected digest %s", digest, desc.Digest)
+	}
+	return data, nil
+}
+
+func (c *Client) get(ctx context.Context, ref Reference, target, accept string) ([]byte, error) {
+	var headers map[string]string
+	if len(accept) > 0 {
+		headers = map[string]string{"Accept": accept}
+	}
+	resp, err := c.do(ctx, ref, http.MethodGet, target, nil, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("get "+target, resp)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (c *Client) url(ref Reference, kind, version string) string {
+	scheme := "https"
+	if c.PlainHTTP {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s/%s", scheme, ref.Registry, ref.Repository, kind, version)
+}
+
+// do sends the request, authenticating with a bearer token or basic auth if the registry challenges it
+func (c *Client) do(ctx context.Context, ref Reference, method, target string, body []byte, headers map[string]string) (*http.Response, error) {
+	scope := "repository:" + ref.Repository + ":pull"
+	if method != http.MethodGet && method != http.MethodHead {
+		scope += ",push"
+	}
+	resp, err := c.send(ctx, method, target, body, headers, c.tokens[scope])
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	challenge := resp.Header.Get(headerAuthenticate)
+	resp.Body.Close()
+	authorization, err := c.authorize(ctx, challenge, scope)
+	if err != nil {
+		return nil, err
+	}
+	if c.tokens == nil {
+		c.tokens = map[string]string{}
+	}
+	c.tokens[scope] = authorization
+	return c.send(ctx, method, target, body, headers, authorization)
+}
+
+func (c *Client) send(ctx context.Context, method, target string, body []byte, headers map[string]string, authorization string) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if len(authorization) > 0 {
+		req.Header.Set("Authorization", authorization)
+	}
+	return c.httpClient().Do(req)
+}
+
+// authorize returns the Authorization header answering the given challenge
+func (c *Client) authorize(ctx context.Context, challenge, scope string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "basic":
+		if len(c.Username) == 0 {
+			return "", fmt.Errorf("registry requires credentials")
+		}
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth(c.Username, c.Password)
+		return req.Header.Get("Authorization"), nil
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || len(params["realm"]) == 0 {
+			return "", fmt.Errorf("invalid bearer challenge %s", challenge)
+		}
+		query := realm.Query()
+		if service, ok := params["service"]; ok {
+			query.Set("service", service)
+		}
+		query.Set("scope", scope)
+		realm.RawQuery = query.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+		if err != nil {
+			return "", err
+		}
+		if len(c.Username) > 0 {
+			req.SetBasicAuth(c.Username, c.Password)
+		}
+		resp, err := c.httpClient().Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", statusError("get token", resp)
+		}
+		token := struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}{}
+		if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+			return "", fmt.Errorf("invalid token response: %w", err)
+		}
+		if len(token.Token) == 0 {
+			token.Token = token.AccessToken
+		}
+		return "Bearer " + token.Token, nil
+	}
+	return "", fmt.Errorf("authentication challenge %s not supported", challenge)
+}
+
+// parseChallenge parses a WWW-Authenticate header such as 'Bearer realm="https://auth",service="registry"'
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	scheme := strings.ToLower(parts[0])
+	if len(parts) == 1 {
+		return scheme, params
+	}
+	for _, param := range strings.Split(parts[1], ",") {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) == 2 {
+			params[strings.ToLower(kv[0])] = strings.Trim(kv[1], `"`)
+		}
+	}
+	return scheme, params
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func statusError(operation string, resp *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("%s failed: %s %s", operation, resp.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,173 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRegistry in-memory registry requiring a bearer token
+type fakeRegistry struct {
+	sync.Mutex
+	server    *httptest.Server
+	blobs     map[string][]byte
+	manifests map[string][]byte
+}
+
+func newFakeRegistry() *fakeRegistry {
+	r := &fakeRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serve))
+	return r
+}
+
+func (r *fakeRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+	if req.URL.Path == "/token" {
+		user, password, _ := req.BasicAuth()
+		if user != "user" || password != "secret" || req.URL.Query().Get("service") != "fake" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"token": "t0k3n"}`))
+		return
+	}
+	if req.Header.Get("Authorization") != "Bearer t0k3n" {
+		w.Header().Set("Www-Authenticate", `Bearer realm="`+r.server.URL+`/token",service="fake"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	path := strings.TrimPrefix(req.URL.Path, "/v2/workflows/greeting/")
+	body, _ := ioutil.ReadAll(req.Body)
+	switch {
+	case path == "blobs/uploads/" && req.Method == http.MethodPost:
+		w.Header().Set("Location", "/v2/workflows/greeting/blobs/uploads/1?state=x")
+		w.WriteHeader(http.StatusAccepted)
+	case strings.HasPrefix(path, "blobs/uploads/") && req.Method == http.MethodPut:
+		r.blobs[req.URL.Query().Get("digest")] = body
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(path, "blobs/"):
+		blob, ok := r.blobs[strings.TrimPrefix(path, "blobs/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(blob)
+	case strings.HasPrefix(path, "manifests/") && req.Method == http.MethodPut:
+		r.manifests[strings.TrimPrefix(path, "manifests/")] = body
+		r.manifests[Digest(body)] = body
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(path, "manifests/"):
+		manifest, ok := r.manifests[strings.TrimPrefix(path, "manifests/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", ManifestMediaType)
+		_, _ = w.Write(manifest)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestParseReference(t *testing.T) {
+	ref, err := ParseReference("ghcr.io/acme/workflows/order:1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, Reference{Registry: "ghcr.io", Repository: "acme/workflows/order", Tag: "1.0"}, ref)
+
+	ref, err = ParseReference("localhost:5000/order@sha256:abc")
+	assert.NoError(t, err)
+	assert.Equal(t, Reference{Registry: "localhost:5000", Repository: "order", Digest: "sha256:abc"}, ref)
+	assert.Equal(t, "localhost:5000/order@sha256:abc", ref.String())
+
+	ref, err = ParseReference("localhost:5000/order")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultTag, ref.Tag)
+
+	_, err = ParseReference("order:1.0")
+	assert.Error(t, err)
+	_, err = ParseReference("localhost/Order")
+	assert.Error(t, err)
+	_, err = ParseReference("localhost/order@md5:abc")
+	assert.Error(t, err)
+}
+
+func TestNewArtifact(t *testing.T) {
+	artifact, err := NewArtifact("./testdata/greetings.sw.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "greetings.sw.yaml", artifact.Workflow.Name)
+	assert.Equal(t, WorkflowYAMLMediaType, artifact.Workflow.MediaType)
+	assert.Equal(t, Config{ID: "greeting", Name: "Greeting Workflow", Version: "1.0", SpecVersion: "0.7"}, artifact.Config)
+	assert.Len(t, artifact.Resources, 1)
+	assert.Equal(t, "myapis/greetingapis.json", artifact.Resources[0].Name)
+	assert.Equal(t, "1.0", artifact.Annotations[AnnotationVersion])
+}
+
+func TestPushPull(t *testing.T) {
+	registry := newFakeRegistry()
+	defer registry.server.Close()
+	host := strings.TrimPrefix(registry.server.URL, "http://")
+
+	artifact, err := NewArtifact("./testdata/greetings.sw.yaml")
+	assert.NoError(t, err)
+	artifact.Annotations[AnnotationSource] = "https://github.com/acme/workflows"
+
+	client := &Client{Username: "user", Password: "secret", PlainHTTP: true}
+	ref, err := ParseReference(host + "/workflows/greeting:1.0")
+	assert.NoError(t, err)
+	digest, err := client.Push(context.Background(), ref, artifact)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(digest, "sha256:"))
+	assert.Len(t, registry.blobs, 3)
+
+	pulled, pulledDigest, err := (&Client{Username: "user", Password: "secret", PlainHTTP: true}).Pull(context.Background(), ref)
+	assert.NoError(t, err)
+	assert.Equal(t, digest, pulledDigest)
+	assert.Equal(t, artifact.Workflow, pulled.Workflow)
+	assert.Equal(t, artifact.Resources, pulled.Resources)
+	assert.Equal(t, artifact.Config, pulled.Config)
+	assert.Equal(t, "https://github.com/acme/workflows", pulled.Annotations[AnnotationSource])
+
+	ref.Tag, ref.Digest = "", digest
+	_, _, err = client.Pull(context.Background(), ref)
+	assert.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "registry")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, pulled.WriteTo(dir))
+	_, err = os.Stat(filepath.Join(dir, "myapis", "greetingapis.json"))
+	assert.NoError(t, err)
+
+	// corrupted blob
+	for d := range registry.blobs {
+		registry.blobs[d] = []byte("corrupted")
+	}
+	_, _, err = client.Pull(context.Background(), ref)
+	assert.Error(t, err)
+
+	_, _, err = (&Client{PlainHTTP: true}).Pull(context.Background(), ref)
+	assert.Error(t, err)
+}
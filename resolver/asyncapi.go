@@ -0,0 +1,263 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+const (
+	// AsyncAPIActionPublish the application publishes messages to the channel
+	AsyncAPIActionPublish = "publish"
+	// AsyncAPIActionSubscribe the application receives messages from the channel
+	AsyncAPIActionSubscribe = "subscribe"
+)
+
+// AsyncAPIOperation operation resolved from the AsyncAPI document referenced by an asyncapi function
+type AsyncAPIOperation struct {
+	// OperationID unique operation identifier in the AsyncAPI document
+	OperationID string
+	// Channel name of the channel the operation belongs to
+	Channel string
+	// Action either publish or subscribe
+	Action string
+	// Summary short description of the operation
+	Summary string
+	// Servers where the channel is available
+	Servers []AsyncAPIServer
+	// Messages that can be exchanged by the operation. More than one if the message is defined with oneOf
+	Messages []AsyncAPIMessage
+}
+
+// AsyncAPIServer ...
+type AsyncAPIServer struct {
+	Name     string
+	URL      string `json:"url"`
+	Protocol string `json:"protocol"`
+}
+
+// AsyncAPIMessage ...
+type AsyncAPIMessage struct {
+	Name         string          `json:"name,omitempty"`
+	Title        string          `json:"title,omitempty"`
+	ContentType  string          `json:"contentType,omitempty"`
+	SchemaFormat string          `json:"schemaFormat,omitempty"`
+	Headers      json.RawMessage `json:"headers,omitempty"`
+	// Payload schema of the message payload, by default a JSON Schema. See SchemaFormat
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// AsyncAPIResolver resolves the operations of asyncapi functions. Documents are loaded once and kept in memory.
+type AsyncAPIResolver struct {
+	cache *documentCache
+}
+
+// NewAsyncAPIResolver creates a resolver that loads the documents with the given Loader. If nil, NewLoader("", nil) is used.
+func NewAsyncAPIResolver(loader Loader) *AsyncAPIResolver {
+	return &AsyncAPIResolver{cache: newDocumentCache(loader)}
+}
+
+// Resolve finds the operation referenced by the given asyncapi function
+func (r *AsyncAPIResolver) Resolve(function model.Function) (*AsyncAPIOperation, error) {
+	if function.Type != model.FunctionTypeAsyncAPI {
+		return nil, fmt.Errorf("function %s is not an asyncapi function", function.Name)
+	}
+	uri, fragments, err := splitOperation(function.Operation, 1)
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", function.Name, err)
+	}
+	doc, err := r.cache.get(uri, decodeAsyncAPIDocument)
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", function.Name, err)
+	}
+	operation, err := doc.(*asyncAPIDocument).operation(fragments[0])
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", function.Name, err)
+	}
+	return operation, nil
+}
+
+// ResolveAll resolves every asyncapi function declared in the workflow, keyed by function name
+func (r *AsyncAPIResolver) ResolveAll(workflow *model.Workflow) (map[string]*AsyncAPIOperation, error) {
+	operations := map[string]*AsyncAPIOperation{}
+	for _, function := range workflow.Functions {
+		if function.Type != model.FunctionTypeAsyncAPI {
+			continue
+		}
+		operation, err := r.Resolve(function)
+		if err != nil {
+			return nil, err
+		}
+		operations[function.Name] = operation
+	}
+	return operations, nil
+}
+
+type asyncAPIDocument struct {
+	AsyncAPI   string                                `json:"asyncapi"`
+	Servers    map[string]AsyncAPIServer             `json:"servers"`
+	Channels   map[string]map[string]json.RawMessage `json:"channels"`
+	Components struct {
+		Messages map[string]json.RawMessage `json:"messages"`
+		Schemas  map[string]json.RawMessage `json:"schemas"`
+	} `json:"components"`
+}
+
+type asyncAPIOperationObject struct {
+	OperationID string          `json:"operationId"`
+	Summary     string          `json:"summary"`
+	Message     json.RawMessage `json:"message"`
+}
+
+func decodeAsyncAPIDocument(data []byte) (interface{}, error) {
+	doc := &asyncAPIDocument{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	if len(doc.AsyncAPI) == 0 {
+		return nil, fmt.Errorf("not an AsyncAPI document, 'asyncapi' version is required")
+	}
+	return doc, nil
+}
+
+func (d *asyncAPIDocument) operation(operationID string) (*AsyncAPIOperation, error) {
+	channels := make([]string, 0, len(d.Channels))
+	for channel := range d.Channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	for _, channel := range channels {
+		item := d.Channels[channel]
+		for _, action := range []string{AsyncAPIActionPublish, AsyncAPIActionSubscribe} {
+			raw, ok := item[action]
+			if !ok {
+				continue
+			}
+			op := asyncAPIOperationObject{}
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf("invalid operation %s %s: %w", action, channel, err)
+			}
+			if op.OperationID != operationID {
+				continue
+			}
+			return d.resolveOperation(channel, action, item, op)
+		}
+	}
+	return nil, fmt.Errorf("operationId %s not found in the AsyncAPI document", operationID)
+}
+
+func (d *asyncAPIDocument) resolveOperation(channel, action string, item map[string]json.RawMessage, op asyncAPIOperationObject) (*AsyncAPIOperation, error) {
+	operation := &AsyncAPIOperation{OperationID: op.OperationID, Channel: channel, Action: action, Summary: op.Summary}
+
+	// the channel object shares the map with the operations, the servers key restricts where it's available
+	var names []string
+	if servers, ok := item["servers"]; ok {
+		if err := json.Unmarshal(servers, &names); err != nil {
+			return nil, err
+		}
+	}
+	if len(names) == 0 {
+		for name := range d.Servers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	for _, name := range names {
+		server, ok := d.Servers[name]
+		if !ok {
+			return nil, fmt.Errorf("channel %s: server %s not found", channel, name)
+		}
+		server.Name = name
+		operation.Servers = append(operation.Servers, server)
+	}
+
+	if len(op.Message) > 0 {
+		messages, err := d.messages(op.Message)
+		if err != nil {
+			return nil, fmt.Errorf("operation %s: %w", op.OperationID, err)
+		}
+		operation.Messages = messages
+	}
+	return operation, nil
+}
+
+func (d *asyncAPIDocument) messages(raw json.RawMessage) ([]AsyncAPIMessage, error) {
+	raw, err := d.dereference(raw)
+	if err != nil {
+		return nil, err
+	}
+	oneOf := struct {
+		OneOf []json.RawMessage `json:"oneOf"`
+	}{}
+	if err := json.Unmarshal(raw, &oneOf); err != nil {
+		return nil, err
+	}
+	if len(oneOf.OneOf) == 0 {
+		oneOf.OneOf = []json.RawMessage{raw}
+	}
+	var messages []AsyncAPIMessage
+	for _, rawMessage := range oneOf.OneOf {
+		rawMessage, err := d.dereference(rawMessage)
+		if err != nil {
+			return nil, err
+		}
+		message := AsyncAPIMessage{}
+		if err := json.Unmarshal(rawMessage, &message); err != nil {
+			return nil, err
+		}
+		if len(message.Payload) > 0 {
+			if message.Payload, err = d.dereference(message.Payload); err != nil {
+				return nil, err
+			}
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// dereference resolves local references to reusable messages and schemas
+func (d *asyncAPIDocument) dereference(raw json.RawMessage) (json.RawMessage, error) {
+	var ref map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &ref); err != nil {
+		return raw, nil
+	}
+	refValue, ok := ref[refKey]
+	if !ok {
+		return raw, nil
+	}
+	var pointer string
+	if err := json.Unmarshal(refValue, &pointer); err != nil {
+		return nil, err
+	}
+	var target map[string]json.RawMessage
+	switch {
+	case strings.HasPrefix(pointer, "#/components/messages/"):
+		target = d.Components.Messages
+	case strings.HasPrefix(pointer, "#/components/schemas/"):
+		target = d.Components.Schemas
+	default:
+		return nil, fmt.Errorf("reference %s not supported", pointer)
+	}
+	resolved, ok := target[pointer[strings.LastIndex(pointer, "/")+1:]]
+	if !ok {
+		return nil, fmt.Errorf("reference %s not found", pointer)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,305 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+const (
+	// GraphQLOperationQuery ...
+	GraphQLOperationQuery = "query"
+	// GraphQLOperationMutation ...
+	GraphQLOperationMutation = "mutation"
+
+	introspectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    types {
+      name
+      fields(includeDeprecated: true) {
+        name
+        args { name type { ...TypeRef } defaultValue }
+        type { ...TypeRef }
+      }
+    }
+  }
+}
+fragment TypeRef on __Type { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } } }`
+
+	typeKindNonNull = "NON_NULL"
+	typeKindList    = "LIST"
+)
+
+// GraphQLField query or mutation resolved from the schema of the endpoint referenced by a graphql function
+type GraphQLField struct {
+	// Name of the query or mutation
+	Name string
+	// Operation either query or mutation
+	Operation string
+	// Arguments accepted by the field
+	Arguments []GraphQLArgument
+	// Type returned by the field in GraphQL notation, e.g. [User!]!
+	Type string
+}
+
+// GraphQLArgument ...
+type GraphQLArgument struct {
+	Name string
+	// Type in GraphQL notation, e.g. ID!
+	Type string
+	// Required if the argument type is non null and has no default value
+	Required bool
+}
+
+// GraphQLResolver resolves the fields of graphql functions by introspecting the schema of the referenced endpoints.
+// Schemas are fetched once and kept in memory.
+type GraphQLResolver struct {
+	client *http.Client
+	loader Loader
+	lock   sync.Mutex
+	cache  map[string]*graphQLSchema
+}
+
+// NewGraphQLResolver creates a resolver that introspects http(s) endpoints with the given client.
+// Other URIs are read with the given loader and must contain an introspection query result, which is useful to
+// validate against a schema snapshot. nil client and loader default to http.DefaultClient and NewLoader("", nil).
+func NewGraphQLResolver(client *http.Client, loader Loader) *GraphQLResolver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if loader == nil {
+		loader = NewLoader("", nil)
+	}
+	return &GraphQLResolver{client: client, loader: loader, cache: map[string]*graphQLSchema{}}
+}
+
+// Resolve finds the query or mutation referenced by the given graphql function
+func (r *GraphQLResolver) Resolve(function model.Function) (*GraphQLField, error) {
+	if function.Type != model.FunctionTypeGraphQL {
+		return nil, fmt.Errorf("function %s is not a graphql function", function.Name)
+	}
+	uri, fragments, err := splitOperation(function.Operation, 2)
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", function.Name, err)
+	}
+	operation, name := fragments[0], fragments[1]
+	if operation != GraphQLOperationQuery && operation != GraphQLOperationMutation {
+		return nil, fmt.Errorf("function %s: operation must be either %s or %s, got %s", function.Name, GraphQLOperationQuery, GraphQLOperationMutation, operation)
+	}
+	schema, err := r.schema(uri)
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", function.Name, err)
+	}
+	field, ok := schema.field(operation, name)
+	if !ok {
+		return nil, fmt.Errorf("function %s: %s %s not found in the schema of %s", function.Name, operation, name, uri)
+	}
+	return field, nil
+}
+
+// Validate verifies that the function reference only uses arguments declared by the resolved field and
+// that every required argument is given
+func (r *GraphQLResolver) Validate(function model.Function, ref model.FunctionRef) error {
+	field, err := r.Resolve(function)
+	if err != nil {
+		return err
+	}
+	declared := map[string]GraphQLArgument{}
+	for _, argument := range field.Arguments {
+		declared[argument.Name] = argument
+	}
+	var names []string
+	for name := range ref.Arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := declared[name]; !ok {
+			return fmt.Errorf("function %s: argument %s not declared by %s %s", function.Name, name, field.Operation, field.Name)
+		}
+	}
+	for _, argument := range field.Arguments {
+		if _, ok := ref.Arguments[argument.Name]; argument.Required && !ok {
+			return fmt.Errorf("function %s: required argument %s of %s %s is missing", function.Name, argument.Name, field.Operation, field.Name)
+		}
+	}
+	return nil
+}
+
+// ValidateWorkflow validates every action of the workflow that invokes a graphql function
+func (r *GraphQLResolver) ValidateWorkflow(workflow *model.Workflow) error {
+	functions := map[string]model.Function{}
+	for _, function := range workflow.Functions {
+		if function.Type == model.FunctionTypeGraphQL {
+			functions[function.Name] = function
+		}
+	}
+	for _, state := range workflow.States {
+		for _, action := range model.GetActions(state) {
+			function, ok := functions[action.FunctionRef.RefName]
+			if !ok {
+				continue
+			}
+			if err := r.Validate(function, action.FunctionRef); err != nil {
+				return fmt.Errorf("state %s: %w", state.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+type graphQLTypeRef struct {
+	Kind   string          `json:"kind"`
+	Name   string          `json:"name"`
+	OfType *graphQLTypeRef `json:"ofType"`
+}
+
+func (t *graphQLTypeRef) String() string {
+	if t == nil {
+		return ""
+	}
+	switch t.Kind {
+	case typeKindNonNull:
+		return t.OfType.String() + "!"
+	case typeKindList:
+		return "[" + t.OfType.String() + "]"
+	}
+	return t.Name
+}
+
+type graphQLSchema struct {
+	QueryType *struct {
+		Name string `json:"name"`
+	} `json:"queryType"`
+	MutationType *struct {
+		Name string `json:"name"`
+	} `json:"mutationType"`
+	Types []struct {
+		Name   string `json:"name"`
+		Fields []struct {
+			Name string `json:"name"`
+			Args []struct {
+				Name string          `json:"name"`
+				Type *graphQLTypeRef `json:"type"`
+				// DefaultValue GraphQL literal of the default value, nil without default
+				DefaultValue *string `json:"defaultValue"`
+			} `json:"args"`
+			Type *graphQLTypeRef `json:"type"`
+		} `json:"fields"`
+	} `json:"types"`
+}
+
+type graphQLIntrospectionResponse struct {
+	Data struct {
+		Schema *graphQLSchema `json:"__schema"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (s *graphQLSchema) field(operation, name string) (*GraphQLField, bool) {
+	typeName := ""
+	if operation == GraphQLOperationQuery && s.QueryType != nil {
+		typeName = s.QueryType.Name
+	} else if operation == GraphQLOperationMutation && s.MutationType != nil {
+		typeName = s.MutationType.Name
+	}
+	if len(typeName) == 0 {
+		return nil, false
+	}
+	for _, t := range s.Types {
+		if t.Name != typeName {
+			continue
+		}
+		for _, f := range t.Fields {
+			if f.Name != name {
+				continue
+			}
+			field := &GraphQLField{Name: f.Name, Operation: operation, Type: f.Type.String()}
+			for _, arg := range f.Args {
+				field.Arguments = append(field.Arguments, GraphQLArgument{
+					Name:     arg.Name,
+					Type:     arg.Type.String(),
+					Required: arg.Type != nil && arg.Type.Kind == typeKindNonNull && arg.DefaultValue == nil,
+				})
+			}
+			return field, true
+		}
+	}
+	return nil, false
+}
+
+func (r *GraphQLResolver) schema(uri string) (*graphQLSchema, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if schema, ok := r.cache[uri]; ok {
+		return schema, nil
+	}
+	var data []byte
+	var err error
+	if parsed, parseErr := url.Parse(uri); parseErr == nil && (parsed.Scheme == schemeHTTP || parsed.Scheme == schemeHTTPS) {
+		data, err = r.introspect(uri)
+	} else {
+		data, err = r.loader.Load(uri)
+	}
+	if err != nil {
+		return nil, err
+	}
+	response := graphQLIntrospectionResponse{}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("invalid introspection result from %s: %w", uri, err)
+	}
+	if len(response.Errors) > 0 {
+		var messages []string
+		for _, e := range response.Errors {
+			messages = append(messages, e.Message)
+		}
+		return nil, fmt.Errorf("introspection of %s failed: %s", uri, strings.Join(messages, "; "))
+	}
+	if response.Data.Schema == nil {
+		return nil, fmt.Errorf("invalid introspection result from %s: no schema", uri)
+	}
+	r.cache[uri] = response.Data.Schema
+	return response.Data.Schema, nil
+}
+
+func (r *GraphQLResolver) introspect(endpoint string) ([]byte, error) {
+	body, err := json.Marshal(map[string]string{"query": introspectionQuery})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Post(endpoint, contentTypeJSON, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to introspect %s: %s", endpoint, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultTimeout timeout of the requests of the loaders created without client
+const DefaultTimeout = 30 * time.Second
+
+// defaultClient client of the loaders created without client, unlike http.DefaultClient its requests time out
+var defaultClient = &http.Client{Timeout: DefaultTimeout}
+
+const (
+	schemeFile  = "file"
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+)
+
+// Loader loads the raw content of the document referenced by a function operation
+type Loader interface {
+	Load(uri string) ([]byte, error)
+}
+
+// LoaderFunc adapts a function to the Loader interface
+type LoaderFunc func(uri string) ([]byte, error)
+
+// Load ...
+func (f LoaderFunc) Load(uri string) ([]byte, error) {
+	return f(uri)
+}
+
+// NewLoader creates a Loader that reads http(s) URIs with the given client and files relative to baseDir.
+// If client is nil, a client timing out after DefaultTimeout is used.
+func NewLoader(baseDir string, client *http.Client) Loader {
+	if client == nil {
+		client = defaultClient
+	}
+	return &defaultLoader{baseDir: baseDir, client: client}
+}
+
+type defaultLoader struct {
+	baseDir string
+	client  *http.Client
+}
+
+func (l *defaultLoader) Load(uri string) ([]byte, error) {
+	parsed, err := url.Parse(uri)
+	if err == nil && (parsed.Scheme == schemeHTTP || parsed.Scheme == schemeHTTPS) {
+		resp, err := l.client.Get(uri)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch %s: %s", uri, resp.Status)
+		}
+		return ioutil.ReadAll(resp.Body)
+	}
+	path := uri
+	if err == nil && parsed.Scheme == schemeFile {
+		path = parsed.Host + parsed.Path
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(l.baseDir, path)
+	}
+	return ioutil.ReadFile(filepath.Clean(path))
+}
+
+// documentCache keeps the decoded documents by URI, so functions sharing the same document load it only once. The
+// lock isn't held while loading: the documents are loaded concurrently, the gets of a document being loaded waiting
+// for it.
+type documentCache struct {
+	loader Loader
+	lock   sync.Mutex
+	docs   map[string]*cachedDocument
+}
+
+// cachedDocument document of the cache, loaded once done is closed
+type cachedDocument struct {
+	done chan struct{}
+	doc  interface{}
+	err  error
+}
+
+func newDocumentCache(loader Loader) *documentCache {
+	if loader == nil {
+		loader = NewLoader("", nil)
+	}
+	return &documentCache{loader: loader, docs: map[string]*cachedDocument{}}
+}
+
+// get returns the document in the given URI decoded by decode, which receives the document converted to JSON
+func (c *documentCache) get(uri string, decode func(data []byte) (interface{}, error)) (interface{}, error) {
+	c.lock.Lock()
+	cached, ok := c.docs[uri]
+	if !ok {
+		cached = &cachedDocument{done: make(chan struct{})}
+		c.docs[uri] = cached
+	}
+	c.lock.Unlock()
+	if ok {
+		<-cached.done
+		return cached.doc, cached.err
+	}
+
+	defer close(cached.done)
+	cached.doc, cached.err = c.load(uri, decode)
+	if cached.err != nil {
+		// the next gets load the document again
+		c.lock.Lock()
+		delete(c.docs, uri)
+		c.lock.Unlock()
+	}
+	return cached.doc, cached.err
+}
+
+// load loads the document in the given URI and decodes it
+func (c *documentCache) load(uri string, decode func(data []byte) (interface{}, error)) (interface{}, error) {
+	raw, err := c.loader.Load(uri)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is valid YAML, so both formats are supported
+	data, err := yaml.YAMLToJSON(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document %s: %w", uri, err)
+	}
+	doc, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document %s: %w", uri, err)
+	}
+	return doc, nil
+}
+
+// splitOperation splits the function operation in the document URI and the fragments after '#'
+func splitOperation(operation string, fragments int) (string, []string, error) {
+	parts := strings.Split(operation, "#")
+	if len(parts) != fragments+1 {
+		return "", nil, fmt.Errorf("invalid operation '%s', expected format is <uri>%s", operation, strings.Repeat("#<name>", fragments))
+	}
+	for _, part := range parts {
+		if len(part) == 0 {
+			return "", nil, fmt.Errorf("invalid operation '%s', empty segment", operation)
+		}
+	}
+	return parts[0], parts[1:], nil
+}
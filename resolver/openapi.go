@@ -0,0 +1,350 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+const (
+	parameterInBody = "body"
+	contentTypeJSON = "application/json"
+	refKey          = "$ref"
+)
+
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// OpenAPIOperation operation resolved from the OpenAPI (or Swagger 2.0) document referenced by a rest function
+type OpenAPIOperation struct {
+	// OperationID unique operation identifier in the OpenAPI document
+	OperationID string
+	// Method HTTP method in upper case
+	Method string
+	// Path templated path of the operation, e.g. /pets/{petId}
+	Path string
+	// Servers base URLs where the operation is served
+	Servers []string
+	// Summary short description of the operation
+	Summary string
+	// Parameters path, query, header and cookie parameters (including the ones defined at path level)
+	Parameters []OpenAPIParameter
+	// RequestBody the operation request body, if any
+	RequestBody *OpenAPIRequestBody
+}
+
+// OpenAPIParameter ...
+type OpenAPIParameter struct {
+	Name        string          `json:"name"`
+	In          string          `json:"in"`
+	Required    bool            `json:"required,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Schema      json.RawMessage `json:"schema,omitempty"`
+}
+
+// OpenAPIRequestBody ...
+type OpenAPIRequestBody struct {
+	Required bool
+	// ContentTypes media types accepted by the operation
+	ContentTypes []string
+	// Schema JSON schema of the JSON content, or of the first media type if JSON is not accepted
+	Schema json.RawMessage
+}
+
+// Parameter returns the parameter with the given name
+func (o *OpenAPIOperation) Parameter(name string) (*OpenAPIParameter, bool) {
+	for i := range o.Parameters {
+		if o.Parameters[i].Name == name {
+			return &o.Parameters[i], true
+		}
+	}
+	return nil, false
+}
+
+// OpenAPIResolver resolves the operations of rest functions. Documents are loaded once and kept in memory.
+type OpenAPIResolver struct {
+	cache *documentCache
+}
+
+// NewOpenAPIResolver creates a resolver that loads the documents with the given Loader. If nil, NewLoader("", nil) is used.
+func NewOpenAPIResolver(loader Loader) *OpenAPIResolver {
+	return &OpenAPIResolver{cache: newDocumentCache(loader)}
+}
+
+// Resolve finds the operation referenced by the given rest function
+func (r *OpenAPIResolver) Resolve(function model.Function) (*OpenAPIOperation, error) {
+	if len(function.Type) > 0 && function.Type != model.FunctionTypeREST {
+		return nil, fmt.Errorf("function %s is not a rest function", function.Name)
+	}
+	uri, fragments, err := splitOperation(function.Operation, 1)
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", function.Name, err)
+	}
+	doc, err := r.cache.get(uri, decodeOpenAPIDocument)
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", function.Name, err)
+	}
+	operation, err := doc.(*openAPIDocument).operation(fragments[0])
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", function.Name, err)
+	}
+	return operation, nil
+}
+
+// ResolveAll resolves every rest function declared in the workflow, keyed by function name
+func (r *OpenAPIResolver) ResolveAll(workflow *model.Workflow) (map[string]*OpenAPIOperation, error) {
+	operations := map[string]*OpenAPIOperation{}
+	for _, function := range workflow.Functions {
+		if len(function.Type) > 0 && function.Type != model.FunctionTypeREST {
+			continue
+		}
+		operation, err := r.Resolve(function)
+		if err != nil {
+			return nil, err
+		}
+		operations[function.Name] = operation
+	}
+	return operations, nil
+}
+
+type openAPIDocument struct {
+	OpenAPI string `json:"openapi"`
+	Swagger string `json:"swagger"`
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Host       string                                `json:"host"`
+	BasePath   string                                `json:"basePath"`
+	Schemes    []string                              `json:"schemes"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Parameters map[string]json.RawMessage            `json:"parameters"`
+	Components struct {
+		Parameters    map[string]json.RawMessage `json:"parameters"`
+		RequestBodies map[string]json.RawMessage `json:"requestBodies"`
+	} `json:"components"`
+}
+
+type openAPIOperationObject struct {
+	OperationID string            `json:"operationId"`
+	Summary     string            `json:"summary"`
+	Parameters  []json.RawMessage `json:"parameters"`
+	RequestBody json.RawMessage   `json:"requestBody"`
+	Consumes    []string          `json:"consumes"`
+	Servers     []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+}
+
+type openAPIRequestBodyObject struct {
+	Required bool `json:"required"`
+	Content  map[string]struct {
+		Schema json.RawMessage `json:"schema"`
+	} `json:"content"`
+}
+
+func decodeOpenAPIDocument(data []byte) (interface{}, error) {
+	doc := &openAPIDocument{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	if len(doc.OpenAPI) == 0 && len(doc.Swagger) == 0 {
+		return nil, fmt.Errorf("not an OpenAPI document, 'openapi' or 'swagger' version is required")
+	}
+	return doc, nil
+}
+
+func (d *openAPIDocument) operation(operationID string) (*OpenAPIOperation, error) {
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		item := d.Paths[path]
+		for _, method := range openAPIMethods {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+			op := openAPIOperationObject{}
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf("invalid operation %s %s: %w", method, path, err)
+			}
+			if op.OperationID != operationID {
+				continue
+			}
+			return d.resolveOperation(path, method, item, op)
+		}
+	}
+	return nil, fmt.Errorf("operationId %s not found in the OpenAPI document", operationID)
+}
+
+func (d *openAPIDocument) resolveOperation(path, method string, item map[string]json.RawMessage, op openAPIOperationObject) (*OpenAPIOperation, error) {
+	operation := &OpenAPIOperation{
+		OperationID: op.OperationID,
+		Method:      strings.ToUpper(method),
+		Path:        path,
+		Summary:     op.Summary,
+		Servers:     d.servers(),
+	}
+	if len(op.Servers) > 0 {
+		operation.Servers = nil
+		for _, server := range op.Servers {
+			operation.Servers = append(operation.Servers, server.URL)
+		}
+	}
+
+	var rawParameters []json.RawMessage
+	if pathParameters, ok := item["parameters"]; ok {
+		if err := json.Unmarshal(pathParameters, &rawParameters); err != nil {
+			return nil, err
+		}
+	}
+	rawParameters = append(rawParameters, op.Parameters...)
+	// operation level parameters override the path level ones with the same name and location
+	index := map[string]int{}
+	for _, raw := range rawParameters {
+		parameter, err := d.parameter(raw)
+		if err != nil {
+			return nil, fmt.Errorf("operation %s: %w", op.OperationID, err)
+		}
+		if parameter.In == parameterInBody {
+			operation.RequestBody = &OpenAPIRequestBody{Required: parameter.Required, ContentTypes: op.Consumes, Schema: parameter.Schema}
+			continue
+		}
+		key := parameter.In + ":" + parameter.Name
+		if i, ok := index[key]; ok {
+			operation.Parameters[i] = *parameter
+			continue
+		}
+		index[key] = len(operation.Parameters)
+		operation.Parameters = append(operation.Parameters, *parameter)
+	}
+
+	if len(op.RequestBody) > 0 {
+		body, err := d.requestBody(op.RequestBody)
+		if err != nil {
+			return nil, fmt.Errorf("operation %s: %w", op.OperationID, err)
+		}
+		operation.RequestBody = body
+	}
+	return operation, nil
+}
+
+func (d *openAPIDocument) servers() []string {
+	var servers []string
+	for _, server := range d.Servers {
+		servers = append(servers, server.URL)
+	}
+	if len(d.Host) > 0 {
+		schemes := d.Schemes
+		if len(schemes) == 0 {
+			schemes = []string{schemeHTTPS}
+		}
+		for _, scheme := range schemes {
+			servers = append(servers, scheme+"://"+d.Host+d.BasePath)
+		}
+	}
+	return servers
+}
+
+func (d *openAPIDocument) parameter(raw json.RawMessage) (*OpenAPIParameter, error) {
+	raw, err := d.dereference(raw)
+	if err != nil {
+		return nil, err
+	}
+	parameter := &OpenAPIParameter{}
+	if err := json.Unmarshal(raw, parameter); err != nil {
+		return nil, err
+	}
+	if len(parameter.Schema) == 0 && parameter.In != parameterInBody {
+		// Swagger 2.0 describes non body parameters types inline
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, err
+		}
+		schema := map[string]json.RawMessage{}
+		for _, key := range []string{"type", "format", "items", "enum", "default"} {
+			if value, ok := fields[key]; ok {
+				schema[key] = value
+			}
+		}
+		if len(schema) > 0 {
+			if parameter.Schema, err = json.Marshal(schema); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return parameter, nil
+}
+
+func (d *openAPIDocument) requestBody(raw json.RawMessage) (*OpenAPIRequestBody, error) {
+	raw, err := d.dereference(raw)
+	if err != nil {
+		return nil, err
+	}
+	object := openAPIRequestBodyObject{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, err
+	}
+	body := &OpenAPIRequestBody{Required: object.Required}
+	for contentType := range object.Content {
+		body.ContentTypes = append(body.ContentTypes, contentType)
+	}
+	sort.Strings(body.ContentTypes)
+	if content, ok := object.Content[contentTypeJSON]; ok {
+		body.Schema = content.Schema
+	} else if len(body.ContentTypes) > 0 {
+		body.Schema = object.Content[body.ContentTypes[0]].Schema
+	}
+	return body, nil
+}
+
+// dereference resolves local references to reusable parameters and request bodies
+func (d *openAPIDocument) dereference(raw json.RawMessage) (json.RawMessage, error) {
+	var ref map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &ref); err != nil {
+		return nil, err
+	}
+	refValue, ok := ref[refKey]
+	if !ok {
+		return raw, nil
+	}
+	var pointer string
+	if err := json.Unmarshal(refValue, &pointer); err != nil {
+		return nil, err
+	}
+	var target map[string]json.RawMessage
+	name := pointer[strings.LastIndex(pointer, "/")+1:]
+	switch {
+	case strings.HasPrefix(pointer, "#/components/parameters/"):
+		target = d.Components.Parameters
+	case strings.HasPrefix(pointer, "#/components/requestBodies/"):
+		target = d.Components.RequestBodies
+	case strings.HasPrefix(pointer, "#/parameters/"):
+		target = d.Parameters
+	default:
+		return nil, fmt.Errorf("reference %s not supported", pointer)
+	}
+	resolved, ok := target[name]
+	if !ok {
+		return nil, fmt.Errorf("reference %s not found", pointer)
+	}
+	return resolved, nil
+}
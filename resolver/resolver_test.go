@@ -0,0 +1,221 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPIResolver(t *testing.T) {
+	resolver := NewOpenAPIResolver(NewLoader("./testdata", nil))
+
+	operation, err := resolver.Resolve(model.Function{Name: "listPets", Operation: "petstore.yaml#listPets"})
+	assert.NoError(t, err)
+	assert.Equal(t, "GET", operation.Method)
+	assert.Equal(t, "/pets", operation.Path)
+	assert.Equal(t, []string{"https://petstore.example.com/v1"}, operation.Servers)
+	limit, ok := operation.Parameter("limit")
+	assert.True(t, ok)
+	assert.Equal(t, "query", limit.In)
+	assert.JSONEq(t, `{"type": "integer"}`, string(limit.Schema))
+
+	operation, err = resolver.Resolve(model.Function{Name: "showPet", Operation: "petstore.yaml#showPetById", Type: model.FunctionTypeREST})
+	assert.NoError(t, err)
+	assert.Len(t, operation.Parameters, 2)
+	assert.Equal(t, "petId", operation.Parameters[0].Name)
+	assert.True(t, operation.Parameters[0].Required)
+
+	operation, err = resolver.Resolve(model.Function{Name: "createPet", Operation: "petstore.yaml#createPet"})
+	assert.NoError(t, err)
+	assert.Equal(t, "POST", operation.Method)
+	assert.True(t, operation.RequestBody.Required)
+	assert.Equal(t, []string{"application/json"}, operation.RequestBody.ContentTypes)
+
+	operation, err = resolver.Resolve(model.Function{Name: "addPet", Operation: "petstore.swagger.json#addPet"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://petstore.example.com/v2"}, operation.Servers)
+	assert.NotNil(t, operation.RequestBody)
+	assert.Len(t, operation.Parameters, 1)
+	assert.JSONEq(t, `{"type": "boolean"}`, string(operation.Parameters[0].Schema))
+
+	_, err = resolver.Resolve(model.Function{Name: "unknown", Operation: "petstore.yaml#deletePet"})
+	assert.Error(t, err)
+	_, err = resolver.Resolve(model.Function{Name: "invalid", Operation: "petstore.yaml"})
+	assert.Error(t, err)
+	_, err = resolver.Resolve(model.Function{Name: "expr", Operation: ".pets", Type: model.FunctionTypeExpression})
+	assert.Error(t, err)
+}
+
+func TestOpenAPIResolverResolveAll(t *testing.T) {
+	workflow := &model.Workflow{Functions: []model.Function{
+		{Name: "listPets", Operation: "petstore.yaml#listPets"},
+		{Name: "addPet", Operation: "petstore.swagger.json#addPet", Type: model.FunctionTypeREST},
+		{Name: "count", Operation: ".pets | length", Type: model.FunctionTypeExpression},
+	}}
+	operations, err := NewOpenAPIResolver(NewLoader("./testdata", nil)).ResolveAll(workflow)
+	assert.NoError(t, err)
+	assert.Len(t, operations, 2)
+	assert.Equal(t, "addPet", operations["addPet"].OperationID)
+}
+
+func TestAsyncAPIResolver(t *testing.T) {
+	resolver := NewAsyncAPIResolver(NewLoader("./testdata", nil))
+
+	operation, err := resolver.Resolve(model.Function{Name: "measured", Operation: "streetlights.yaml#onLightMeasured", Type: model.FunctionTypeAsyncAPI})
+	assert.NoError(t, err)
+	assert.Equal(t, "light/measured", operation.Channel)
+	assert.Equal(t, AsyncAPIActionPublish, operation.Action)
+	assert.Equal(t, []AsyncAPIServer{{Name: "production", URL: "broker.example.com:9092", Protocol: "kafka"}}, operation.Servers)
+	assert.Len(t, operation.Messages, 1)
+	assert.Equal(t, "application/json", operation.Messages[0].ContentType)
+	assert.JSONEq(t, `{"type": "object", "properties": {"lumens": {"type": "integer"}}}`, string(operation.Messages[0].Payload))
+
+	operation, err = resolver.Resolve(model.Function{Name: "turn", Operation: "streetlights.yaml#turnLight", Type: model.FunctionTypeAsyncAPI})
+	assert.NoError(t, err)
+	assert.Equal(t, AsyncAPIActionSubscribe, operation.Action)
+	assert.Len(t, operation.Servers, 2)
+	assert.Len(t, operation.Messages, 2)
+	assert.Equal(t, "turnOff", operation.Messages[1].Name)
+
+	_, err = resolver.Resolve(model.Function{Name: "dim", Operation: "streetlights.yaml#dimLight", Type: model.FunctionTypeAsyncAPI})
+	assert.Error(t, err)
+	_, err = resolver.Resolve(model.Function{Name: "rest", Operation: "streetlights.yaml#turnLight"})
+	assert.Error(t, err)
+}
+
+func TestRPCResolver(t *testing.T) {
+	resolver := NewRPCResolver(NewLoader("./testdata", nil))
+
+	method, err := resolver.Resolve(model.Function{Name: "getUser", Operation: "file://userservice.proto#UserService#GetUser", Type: model.FunctionTypeRPC})
+	assert.NoError(t, err)
+	assert.Equal(t, "/users.UserService/GetUser", method.FullMethodName())
+	assert.Equal(t, "users.GetUserRequest", method.InputType().GetFullyQualifiedName())
+	assert.NotNil(t, method.InputType().FindFieldByName("id"))
+	assert.Equal(t, "users.User", method.OutputType().GetFullyQualifiedName())
+
+	method, err = resolver.Resolve(model.Function{Name: "listUsers", Operation: "userservice.proto#users.UserService#ListUsers", Type: model.FunctionTypeRPC})
+	assert.NoError(t, err)
+	assert.Equal(t, "google.protobuf.Empty", method.InputType().GetFullyQualifiedName())
+
+	_, err = resolver.Resolve(model.Function{Name: "deleteUser", Operation: "userservice.proto#UserService#DeleteUser", Type: model.FunctionTypeRPC})
+	assert.Error(t, err)
+	_, err = resolver.Resolve(model.Function{Name: "groups", Operation: "userservice.proto#GroupService#ListGroups", Type: model.FunctionTypeRPC})
+	assert.Error(t, err)
+	_, err = resolver.Resolve(model.Function{Name: "invalid", Operation: "userservice.proto#UserService", Type: model.FunctionTypeRPC})
+	assert.Error(t, err)
+}
+
+func TestGraphQLResolver(t *testing.T) {
+	introspection, err := ioutil.ReadFile("./testdata/graphql-introspection.json")
+	assert.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		_, _ = w.Write(introspection)
+	}))
+	defer server.Close()
+	resolver := NewGraphQLResolver(server.Client(), NewLoader("./testdata", nil))
+
+	hero := model.Function{Name: "hero", Operation: server.URL + "#query#hero", Type: model.FunctionTypeGraphQL}
+	field, err := resolver.Resolve(hero)
+	assert.NoError(t, err)
+	assert.Equal(t, "Character", field.Type)
+	assert.Equal(t, []GraphQLArgument{{Name: "episode", Type: "Episode"}}, field.Arguments)
+
+	review := model.Function{Name: "review", Operation: "graphql-introspection.json#mutation#createReview", Type: model.FunctionTypeGraphQL}
+	field, err = resolver.Resolve(review)
+	assert.NoError(t, err)
+	assert.Equal(t, "[Review]", field.Type)
+	assert.Equal(t, "Int!", field.Arguments[1].Type)
+	assert.True(t, field.Arguments[1].Required)
+	assert.Equal(t, GraphQLArgument{Name: "public", Type: "Boolean!"}, field.Arguments[2])
+
+	assert.NoError(t, resolver.Validate(review, model.FunctionRef{RefName: "review", Arguments: map[string]interface{}{"episode": "JEDI", "stars": 5}}))
+	assert.NoError(t, resolver.Validate(review, model.FunctionRef{RefName: "review", Arguments: map[string]interface{}{"episode": "JEDI", "stars": 5, "public": false}}))
+	assert.Error(t, resolver.Validate(review, model.FunctionRef{RefName: "review", Arguments: map[string]interface{}{"episode": "JEDI"}}))
+	assert.Error(t, resolver.Validate(hero, model.FunctionRef{RefName: "hero", Arguments: map[string]interface{}{"id": "1"}}))
+
+	_, err = resolver.Resolve(model.Function{Name: "droid", Operation: server.URL + "#query#droid", Type: model.FunctionTypeGraphQL})
+	assert.Error(t, err)
+	_, err = resolver.Resolve(model.Function{Name: "sub", Operation: server.URL + "#subscription#hero", Type: model.FunctionTypeGraphQL})
+	assert.Error(t, err)
+
+	workflow := &model.Workflow{
+		Functions: []model.Function{review},
+		States: []model.State{&model.OperationState{
+			BaseState: model.BaseState{Name: "Review"},
+			Actions:   []model.Action{{FunctionRef: model.FunctionRef{RefName: "review", Arguments: map[string]interface{}{"stars": 5}}}},
+		}},
+	}
+	assert.Error(t, resolver.ValidateWorkflow(workflow))
+}
+
+func TestDocumentCache(t *testing.T) {
+	assert.Equal(t, DefaultTimeout, NewLoader("", nil).(*defaultLoader).client.Timeout)
+
+	// the load of slow.json waits for fast.json to be loaded, which would deadlock under the lock of the cache
+	slowLoading, fastLoaded := make(chan struct{}), make(chan struct{})
+	loads := map[string]int{}
+	var lock sync.Mutex
+	cache := newDocumentCache(LoaderFunc(func(uri string) ([]byte, error) {
+		lock.Lock()
+		loads[uri]++
+		failed := loads[uri] == 1 && uri == "failing.json"
+		lock.Unlock()
+		switch {
+		case uri == "slow.json":
+			close(slowLoading)
+			<-fastLoaded
+		case failed:
+			return nil, errors.New("unavailable")
+		}
+		return []byte(`{"uri": "` + uri + `"}`), nil
+	}))
+	decode := func(data []byte) (interface{}, error) {
+		return string(data), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			doc, err := cache.get("slow.json", decode)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"uri":"slow.json"}`, doc)
+		}()
+	}
+	<-slowLoading
+	doc, err := cache.get("fast.json", decode)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"uri":"fast.json"}`, doc)
+	close(fastLoaded)
+	wg.Wait()
+	assert.Equal(t, 1, loads["slow.json"])
+
+	// the failed loads aren't cached
+	_, err = cache.get("failing.json", decode)
+	assert.EqualError(t, err, "unavailable")
+	doc, err = cache.get("failing.json", decode)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"uri":"failing.json"}`, doc)
+}
@@ -0,0 +1,144 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// RPCMethod gRPC method resolved from the proto file referenced by a rpc function
+type RPCMethod struct {
+	File    *desc.FileDescriptor
+	Service *desc.ServiceDescriptor
+	Method  *desc.MethodDescriptor
+}
+
+// FullMethodName the method name as used by gRPC clients, e.g. /mypackage.UserService/ListUsers
+func (m *RPCMethod) FullMethodName() string {
+	return fmt.Sprintf("/%s/%s", m.Service.GetFullyQualifiedName(), m.Method.GetName())
+}
+
+// InputType descriptor of the request message
+func (m *RPCMethod) InputType() *desc.MessageDescriptor {
+	return m.Method.GetInputType()
+}
+
+// OutputType descriptor of the response message
+func (m *RPCMethod) OutputType() *desc.MessageDescriptor {
+	return m.Method.GetOutputType()
+}
+
+// RPCResolver resolves the methods of rpc functions. Proto files are parsed once and kept in memory.
+type RPCResolver struct {
+	loader Loader
+	lock   sync.Mutex
+	files  map[string]*desc.FileDescriptor
+}
+
+// NewRPCResolver creates a resolver that loads the proto files, and their imports, with the given Loader.
+// If nil, NewLoader("", nil) is used. Imports are resolved relative to the directory of the importing file;
+// the well-known google/protobuf types are always available.
+func NewRPCResolver(loader Loader) *RPCResolver {
+	if loader == nil {
+		loader = NewLoader("", nil)
+	}
+	return &RPCResolver{loader: loader, files: map[string]*desc.FileDescriptor{}}
+}
+
+// Resolve finds the service method referenced by the given rpc function
+func (r *RPCResolver) Resolve(function model.Function) (*RPCMethod, error) {
+	if function.Type != model.FunctionTypeRPC {
+		return nil, fmt.Errorf("function %s is not a rpc function", function.Name)
+	}
+	uri, fragments, err := splitOperation(function.Operation, 2)
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", function.Name, err)
+	}
+	file, err := r.file(uri)
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", function.Name, err)
+	}
+	service := findService(file, fragments[0])
+	if service == nil {
+		return nil, fmt.Errorf("function %s: service %s not found in %s", function.Name, fragments[0], uri)
+	}
+	method := service.FindMethodByName(fragments[1])
+	if method == nil {
+		return nil, fmt.Errorf("function %s: method %s not found in service %s", function.Name, fragments[1], service.GetFullyQualifiedName())
+	}
+	return &RPCMethod{File: file, Service: service, Method: method}, nil
+}
+
+// ResolveAll resolves every rpc function declared in the workflow, keyed by function name
+func (r *RPCResolver) ResolveAll(workflow *model.Workflow) (map[string]*RPCMethod, error) {
+	methods := map[string]*RPCMethod{}
+	for _, function := range workflow.Functions {
+		if function.Type != model.FunctionTypeRPC {
+			continue
+		}
+		method, err := r.Resolve(function)
+		if err != nil {
+			return nil, err
+		}
+		methods[function.Name] = method
+	}
+	return methods, nil
+}
+
+func (r *RPCResolver) file(uri string) (*desc.FileDescriptor, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if file, ok := r.files[uri]; ok {
+		return file, nil
+	}
+	base := uri[:strings.LastIndex(uri, "/")+1]
+	parser := protoparse.Parser{
+		Accessor: func(filename string) (io.ReadCloser, error) {
+			data, err := r.loader.Load(base + filename)
+			if err != nil {
+				return nil, err
+			}
+			return ioutil.NopCloser(bytes.NewReader(data)), nil
+		},
+	}
+	files, err := parser.ParseFiles(strings.TrimPrefix(uri, base))
+	if err != nil {
+		return nil, err
+	}
+	r.files[uri] = files[0]
+	return files[0], nil
+}
+
+// findService finds the service by its simple or fully qualified name
+func findService(file *desc.FileDescriptor, name string) *desc.ServiceDescriptor {
+	if service := file.FindService(name); service != nil {
+		return service
+	}
+	for _, service := range file.GetServices() {
+		if service.GetName() == name {
+			return service
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry computes when the actions are retried according to the retry definitions of the workflows.
+package retry
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/util/floatstr"
+	"github.com/serverlessworkflow/sdk-go/v2/util/iso8601"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Backoff delays between the attempts of a retry definition. The delay before the retry attempt n, the first retry
+// being the attempt 1, is
+//
+//	delay * multiplier^(n-1) + increment * (n-1)
+//
+// plus or minus a random jitter, at most the maximum delay. The jitter of each attempt is drawn from the seed of the
+// backoff, the delays being the same for every call.
+type Backoff struct {
+	// Delay before the first retry attempt
+	Delay time.Duration
+	// MaxDelay maximum delay, no maximum if 0
+	MaxDelay time.Duration
+	// Increment added to the delay on each attempt
+	Increment time.Duration
+	// Multiplier of the delay on each attempt, 1 if 0
+	Multiplier float64
+	// MaxAttempts maximum number of attempts, counting the initial attempt like the specification does: the action is
+	// retried at most MaxAttempts-1 times
+	MaxAttempts int
+	// JitterRatio maximum jitter relative to the delay, between 0 and 1
+	JitterRatio float64
+	// JitterDuration maximum jitter
+	JitterDuration time.Duration
+	// Seed of the random jitters
+	Seed int64
+}
+
+// NewBackoff returns the backoff of the retry definition, seeded with the current time
+func NewBackoff(retry model.Retry) (*Backoff, error) {
+	b := &Backoff{Multiplier: 1, Seed: time.Now().UnixNano()}
+	var err error
+	if b.Delay, err = duration(retry.Delay); err != nil {
+		return nil, fmt.Errorf("retry %s: invalid delay: %w", retry.Name, err)
+	}
+	if b.MaxDelay, err = duration(retry.MaxDelay); err != nil {
+		return nil, fmt.Errorf("retry %s: invalid maxDelay: %w", retry.Name, err)
+	}
+	if b.Increment, err = duration(retry.Increment); err != nil {
+		return nil, fmt.Errorf("retry %s: invalid increment: %w", retry.Name, err)
+	}
+	if retry.Multiplier != nil {
+		if b.Multiplier, err = number(*retry.Multiplier); err != nil || b.Multiplier < 1 {
+			return nil, fmt.Errorf("retry %s: invalid multiplier %s", retry.Name, retry.Multiplier.String())
+		}
+	}
+	if b.MaxAttempts, err = attempts(retry.MaxAttempts); err != nil {
+		return nil, fmt.Errorf("retry %s: invalid maxAttempts %s", retry.Name, retry.MaxAttempts.String())
+	}
+	switch retry.Jitter.Type {
+	case floatstr.Float:
+		b.JitterRatio = float64(retry.Jitter.FloatVal)
+	case floatstr.String:
+		if b.JitterDuration, err = duration(retry.Jitter.StrVal); err != nil {
+			return nil, fmt.Errorf("retry %s: invalid jitter: %w", retry.Name, err)
+		}
+	}
+	if b.JitterRatio < 0 || b.JitterRatio > 1 {
+		return nil, fmt.Errorf("retry %s: invalid jitter %v, expected a ratio between 0 and 1", retry.Name, b.JitterRatio)
+	}
+	return b, nil
+}
+
+// WithSeed returns a copy of the backoff drawing the jitters from the seed, e.g. for deterministic tests
+func (b *Backoff) WithSeed(seed int64) *Backoff {
+	seeded := *b
+	seeded.Seed = seed
+	return &seeded
+}
+
+// DelayOf returns the delay before the retry attempt, the first retry being the attempt 1
+func (b *Backoff) DelayOf(attempt int) time.Duration {
+	if attempt < 1 {
+		return 0
+	}
+	multiplier := b.Multiplier
+	if multiplier == 0 {
+		multiplier = 1
+	}
+	delay := float64(b.Delay)*math.Pow(multiplier, float64(attempt-1)) + float64(b.Increment)*float64(attempt-1)
+
+	jitter := b.JitterRatio*delay + float64(b.JitterDuration)
+	if jitter > 0 {
+		// the same attempt of a backoff always draws the same jitter
+		// #nosec
+		random := rand.New(rand.NewSource(b.Seed + int64(attempt)))
+		delay += (random.Float64()*2 - 1) * jitter
+	}
+	if b.MaxDelay > 0 && delay > float64(b.MaxDelay) {
+		return b.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	if delay >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
+
+// Next returns when the retry attempt is due, the previous attempt having failed at the given time, and false if
+// the attempts are exhausted: the retry attempt n being the attempt n+1, it must be lower than MaxAttempts
+func (b *Backoff) Next(attempt int, failedAt time.Time) (time.Time, bool) {
+	if attempt < 1 || attempt >= b.MaxAttempts {
+		return time.Time{}, false
+	}
+	return failedAt.Add(b.DelayOf(attempt)), true
+}
+
+// ShouldRetry checks whether the retry attempt should run at the given time, the previous attempt having failed at
+// failedAt: the attempts aren't exhausted and its delay elapsed
+func (b *Backoff) ShouldRetry(attempt int, failedAt, at time.Time) bool {
+	next, ok := b.Next(attempt, failedAt)
+	return ok && !at.Before(next)
+}
+
+// Schedule returns the times of the retry attempts if every attempt fails immediately, the initial attempt failing at
+// the given time
+func (b *Backoff) Schedule(start time.Time) []time.Time {
+	var schedule []time.Time
+	at := start
+	for attempt := 1; attempt < b.MaxAttempts; attempt++ {
+		at = at.Add(b.DelayOf(attempt))
+		schedule = append(schedule, at)
+	}
+	return schedule
+}
+
+func duration(s string) (time.Duration, error) {
+	if len(s) == 0 {
+		return 0, nil
+	}
+	return iso8601.ParseDuration(s)
+}
+
+func number(f floatstr.Float32OrString) (float64, error) {
+	if f.Type == floatstr.String {
+		return strconv.ParseFloat(f.StrVal, 64)
+	}
+	return float64(f.FloatVal), nil
+}
+
+func attempts(maxAttempts intstr.IntOrString) (int, error) {
+	value := maxAttempts.IntValue()
+	if maxAttempts.Type == intstr.String {
+		var err error
+		if value, err = strconv.Atoi(maxAttempts.StrVal); err != nil {
+			return 0, err
+		}
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("negative")
+	}
+	return value, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/util/floatstr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestBackoff(t *testing.T) {
+	multiplier := floatstr.FromFloat(2)
+	b, err := NewBackoff(model.Retry{
+		Name:        "default",
+		Delay:       "PT1S",
+		MaxDelay:    "PT10S",
+		Increment:   "PT0.5S",
+		Multiplier:  &multiplier,
+		MaxAttempts: intstr.FromInt(5),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), b.DelayOf(0))
+	assert.Equal(t, time.Second, b.DelayOf(1))
+	assert.Equal(t, 2500*time.Millisecond, b.DelayOf(2))
+	assert.Equal(t, 5*time.Second, b.DelayOf(3))
+	assert.Equal(t, 9500*time.Millisecond, b.DelayOf(4))
+	assert.Equal(t, 10*time.Second, b.DelayOf(5))
+
+	start := time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, []time.Time{
+		start.Add(time.Second),
+		start.Add(3500 * time.Millisecond),
+		start.Add(8500 * time.Millisecond),
+		start.Add(18 * time.Second),
+	}, b.Schedule(start))
+
+	next, ok := b.Next(2, start)
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(2500*time.Millisecond), next)
+	// the initial attempt counts, the retry attempt 4 being the fifth and last attempt
+	_, ok = b.Next(4, start)
+	assert.True(t, ok)
+	_, ok = b.Next(5, start)
+	assert.False(t, ok)
+	assert.False(t, b.ShouldRetry(2, start, start.Add(2*time.Second)))
+	assert.True(t, b.ShouldRetry(2, start, start.Add(3*time.Second)))
+	assert.False(t, b.ShouldRetry(5, start, start.Add(time.Hour)))
+}
+
+func TestBackoffJitter(t *testing.T) {
+	b, err := NewBackoff(model.Retry{Name: "default", Delay: "PT10S", MaxAttempts: intstr.FromString("3"), Jitter: floatstr.FromFloat(0.5)})
+	require.NoError(t, err)
+	seeded := b.WithSeed(42)
+	for attempt := 1; attempt <= 3; attempt++ {
+		delay := seeded.DelayOf(attempt)
+		assert.GreaterOrEqual(t, int64(delay), int64(5*time.Second))
+		assert.LessOrEqual(t, int64(delay), int64(15*time.Second))
+		// the same seed draws the same jitters
+		assert.Equal(t, delay, b.WithSeed(42).DelayOf(attempt))
+	}
+	assert.NotEqual(t, seeded.Schedule(time.Time{}), b.WithSeed(43).Schedule(time.Time{}))
+	start := time.Now()
+	next, _ := seeded.Next(1, start)
+	assert.True(t, seeded.ShouldRetry(1, start, next))
+	assert.False(t, seeded.ShouldRetry(1, start, next.Add(-time.Nanosecond)))
+
+	b, err = NewBackoff(model.Retry{Name: "default", Delay: "PT10S", MaxAttempts: intstr.FromInt(1), Jitter: floatstr.FromString("PT1S")})
+	require.NoError(t, err)
+	delay := b.WithSeed(1).DelayOf(1)
+	assert.GreaterOrEqual(t, int64(delay), int64(9*time.Second))
+	assert.LessOrEqual(t, int64(delay), int64(11*time.Second))
+	// a single attempt is never retried
+	_, ok := b.Next(1, start)
+	assert.False(t, ok)
+	assert.Empty(t, b.Schedule(start))
+}
+
+func TestNewBackoffErrors(t *testing.T) {
+	invalid := floatstr.FromString("fast")
+	cases := map[string]model.Retry{
+		"retry r: invalid delay: invalid ISO 8601 duration 1s":                                       {Name: "r", Delay: "1s", MaxAttempts: intstr.FromInt(1)},
+		"retry r: invalid maxDelay: invalid ISO 8601 duration PT":                                    {Name: "r", MaxDelay: "PT", MaxAttempts: intstr.FromInt(1)},
+		"retry r: invalid multiplier fast":                                                           {Name: "r", Multiplier: &invalid, MaxAttempts: intstr.FromInt(1)},
+		"retry r: invalid maxAttempts many":                                                          {Name: "r", MaxAttempts: intstr.FromString("many")},
+		"retry r: invalid jitter 2, expected a ratio between 0 and 1":                                {Name: "r", MaxAttempts: intstr.FromInt(1), Jitter: floatstr.FromFloat(2)},
+		"retry r: invalid jitter: duration P1M in years or months depends on the date it applies to": {Name: "r", MaxAttempts: intstr.FromInt(1), Jitter: floatstr.FromString("P1M")},
+	}
+	for expected, retry := range cases {
+		_, err := NewBackoff(retry)
+		assert.EqualError(t, err, expected)
+	}
+}
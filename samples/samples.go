@@ -0,0 +1,121 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package samples holds the examples of the specification as ready-made workflows, for the tests and demos of the
+// projects built on the SDK: every sample is a JSON definition, parsed into a new workflow on each call so the callers
+// can modify it.
+package samples
+
+import (
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+)
+
+// names of the samples, the IDs of their workflows
+const (
+	// HelloWorld injects a static greeting
+	HelloWorld = "helloworld"
+	// Greeting greets a person with a function
+	Greeting = "greeting"
+	// EventBasedGreeting greets the person of a consumed event
+	EventBasedGreeting = "eventbasedgreeting"
+	// SolveMathProblems solves a collection of expressions with a foreach state
+	SolveMathProblems = "solvemathproblems"
+	// ParallelExec runs two subflows in parallel
+	ParallelExec = "parallelexec"
+	// CheckVisaStatus transitions on the consumed events with an event based switch
+	CheckVisaStatus = "checkvisastatus"
+	// ApplicantRequest decides on a request with a data based switch
+	ApplicantRequest = "applicantrequest"
+	// ProvisionOrders handles the errors of a function
+	ProvisionOrders = "provisionorders"
+	// JobMonitoring polls the status of a job, sleeping between the polls
+	JobMonitoring = "jobmonitoring"
+	// SendCloudEventOnProvision produces an event when the workflow ends
+	SendCloudEventOnProvision = "sendcloudeventonprovision"
+	// PatientVitalsWorkflow consumes correlated events
+	PatientVitalsWorkflow = "patientVitalsWorkflow"
+	// CheckInbox starts on a cron schedule
+	CheckInbox = "checkInbox"
+	// RoomReadings accumulates the readings of a room until a workflow timeout
+	RoomReadings = "roomreadings"
+)
+
+// Sample example workflow of the specification
+type Sample struct {
+	// Name of the sample, the ID of its workflow, e.g. HelloWorld
+	Name string
+	// Title of the example in the specification, e.g. 'Hello World'
+	Title string
+	// Source JSON definition of the workflow
+	Source []byte
+}
+
+// Workflow parses the source of the sample into a new workflow. It panics if the source doesn't parse, the samples
+// being checked by the tests of the package.
+func (s Sample) Workflow() *model.Workflow {
+	workflow, err := parser.FromJSONSource(s.Source)
+	if err != nil {
+		panic(fmt.Sprintf("samples: %s: %v", s.Name, err))
+	}
+	return workflow
+}
+
+// All returns the samples, in the order of the examples of the specification
+func All() []Sample {
+	samples := make([]Sample, len(sources))
+	for i, source := range sources {
+		samples[i] = source.sample()
+	}
+	return samples
+}
+
+// Get returns the sample of the name, false if there is none
+func Get(name string) (Sample, bool) {
+	for _, source := range sources {
+		if source.name == name {
+			return source.sample(), true
+		}
+	}
+	return Sample{}, false
+}
+
+// Workflow parses the sample of the name into a new workflow, nil if there is none
+func Workflow(name string) *model.Workflow {
+	sample, ok := Get(name)
+	if !ok {
+		return nil
+	}
+	return sample.Workflow()
+}
+
+// Names returns the names of the samples, in the order of the examples of the specification
+func Names() []string {
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.name
+	}
+	return names
+}
+
+// source definition of a sample, copied into every Sample so the callers can't modify it
+type source struct {
+	name, title, json string
+}
+
+func (s source) sample() Sample {
+	return Sample{Name: s.name, Title: s.title, Source: []byte(s.json)}
+}
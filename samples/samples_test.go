@@ -0,0 +1,57 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package samples
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/integrity"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/swtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSamples(t *testing.T) {
+	require.Len(t, All(), len(Names()))
+	for _, sample := range All() {
+		t.Run(sample.Name, func(t *testing.T) {
+			workflow := swtest.RoundTrip(t, sample.Source)
+			require.NotNil(t, workflow)
+			assert.Equal(t, sample.Name, workflow.ID)
+			assert.NotEmpty(t, sample.Title)
+			assert.Empty(t, integrity.Validate(workflow))
+			assert.True(t, json.Valid(sample.Source))
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	sample, ok := Get(HelloWorld)
+	require.True(t, ok)
+	assert.Equal(t, "Hello World", sample.Title)
+	_, ok = Get("unknown")
+	assert.False(t, ok)
+	assert.Nil(t, Workflow("unknown"))
+
+	// the samples and workflows are copies
+	sample.Source[0] = '['
+	again, _ := Get(HelloWorld)
+	assert.Equal(t, byte('{'), again.Source[0])
+	workflow := Workflow(Greeting)
+	workflow.States[0].(*model.OperationState).Name = "Renamed"
+	assert.Equal(t, "Greet", Workflow(Greeting).States[0].GetName())
+}
@@ -0,0 +1,845 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package samples
+
+// sources definitions of the samples, the examples of the specification 0.7
+var sources = []source{
+	{name: HelloWorld, title: "Hello World", json: `{
+  "id": "helloworld",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "name": "Hello World Workflow",
+  "description": "Inject Hello World",
+  "start": "Hello State",
+  "states": [
+    {
+      "name": "Hello State",
+      "type": "inject",
+      "data": {
+        "result": "Hello World!"
+      },
+      "end": true
+    }
+  ]
+}`},
+	{name: Greeting, title: "Greeting", json: `{
+  "id": "greeting",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "name": "Greeting Workflow",
+  "description": "Greet Someone",
+  "start": "Greet",
+  "functions": [
+    {
+      "name": "greetingFunction",
+      "operation": "file://myapis/greetingapis.json#greeting"
+    }
+  ],
+  "states": [
+    {
+      "name": "Greet",
+      "type": "operation",
+      "actions": [
+        {
+          "functionRef": {
+            "refName": "greetingFunction",
+            "arguments": {
+              "name": "${ .person.name }"
+            }
+          },
+          "actionDataFilter": {
+            "results": "${ .greeting }"
+          }
+        }
+      ],
+      "end": true
+    }
+  ]
+}`},
+	{name: EventBasedGreeting, title: "Event Based Greeting", json: `{
+  "id": "eventbasedgreeting",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "name": "Event Based Greeting Workflow",
+  "description": "Event Based Greeting",
+  "start": "Greet",
+  "events": [
+    {
+      "name": "GreetingEvent",
+      "type": "greetingEventType",
+      "source": "greetingEventSource"
+    }
+  ],
+  "functions": [
+    {
+      "name": "greetingFunction",
+      "operation": "file://myapis/greetingapis.json#greeting"
+    }
+  ],
+  "states": [
+    {
+      "name": "Greet",
+      "type": "event",
+      "onEvents": [
+        {
+          "eventRefs": [
+            "GreetingEvent"
+          ],
+          "eventDataFilter": {
+            "data": "${ .data.greet }"
+          },
+          "actions": [
+            {
+              "functionRef": {
+                "refName": "greetingFunction",
+                "arguments": {
+                  "name": "${ .greet.name }"
+                }
+              }
+            }
+          ]
+        }
+      ],
+      "stateDataFilter": {
+        "output": "${ .payload.greeting }"
+      },
+      "end": true
+    }
+  ]
+}`},
+	{name: SolveMathProblems, title: "Solving Math Problems", json: `{
+  "id": "solvemathproblems",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "name": "Solve Math Problems Workflow",
+  "description": "Solve math problems",
+  "start": "Solve",
+  "functions": [
+    {
+      "name": "solveMathExpressionFunction",
+      "operation": "http://myapis.org/mapthapis.json#solveExpression"
+    }
+  ],
+  "states": [
+    {
+      "name": "Solve",
+      "type": "foreach",
+      "inputCollection": "${ .expressions }",
+      "iterationParam": "singleexpression",
+      "outputCollection": "${ .results }",
+      "actions": [
+        {
+          "functionRef": {
+            "refName": "solveMathExpressionFunction",
+            "arguments": {
+              "expression": "${ .singleexpression }"
+            }
+          }
+        }
+      ],
+      "stateDataFilter": {
+        "output": "${ .results }"
+      },
+      "end": true
+    }
+  ]
+}`},
+	{name: ParallelExec, title: "Parallel Execution", json: `{
+  "id": "parallelexec",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "name": "Parallel Execution Workflow",
+  "description": "Executes two branches in parallel",
+  "start": "ParallelExec",
+  "states": [
+    {
+      "name": "ParallelExec",
+      "type": "parallel",
+      "completionType": "allOf",
+      "branches": [
+        {
+          "name": "ShortDelayBranch",
+          "actions": [
+            {
+              "subFlowRef": "shortdelayworkflowid"
+            }
+          ]
+        },
+        {
+          "name": "LongDelayBranch",
+          "actions": [
+            {
+              "subFlowRef": "longdelayworkflowid"
+            }
+          ]
+        }
+      ],
+      "end": true
+    }
+  ]
+}`},
+	{name: CheckVisaStatus, title: "Event Based Transitions", json: `{
+  "id": "checkvisastatus",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "name": "Check Visa Status Workflow",
+  "description": "Determine if applicant's visa was approved or rejected",
+  "start": "CheckVisaStatus",
+  "events": [
+    {
+      "name": "visaApprovedEvent",
+      "type": "VisaApproved",
+      "source": "visaCheckSource"
+    },
+    {
+      "name": "visaRejectedEvent",
+      "type": "VisaRejected",
+      "source": "visaCheckSource"
+    }
+  ],
+  "states": [
+    {
+      "name": "CheckVisaStatus",
+      "type": "switch",
+      "eventConditions": [
+        {
+          "eventRef": "visaApprovedEvent",
+          "transition": "HandleApprovedVisa"
+        },
+        {
+          "eventRef": "visaRejectedEvent",
+          "transition": "HandleRejectedVisa"
+        }
+      ],
+      "eventTimeout": "PT1H",
+      "defaultCondition": {
+        "transition": "HandleNoVisaDecision"
+      }
+    },
+    {
+      "name": "HandleApprovedVisa",
+      "type": "operation",
+      "actions": [
+        {
+          "subFlowRef": "handleApprovedVisaWorkflowID"
+        }
+      ],
+      "end": true
+    },
+    {
+      "name": "HandleRejectedVisa",
+      "type": "operation",
+      "actions": [
+        {
+          "subFlowRef": "handleRejectedVisaWorkflowID"
+        }
+      ],
+      "end": true
+    },
+    {
+      "name": "HandleNoVisaDecision",
+      "type": "operation",
+      "actions": [
+        {
+          "subFlowRef": "handleNoVisaDecisionWorkflowId"
+        }
+      ],
+      "end": true
+    }
+  ]
+}`},
+	{name: ApplicantRequest, title: "Applicant Request Decision", json: `{
+  "id": "applicantrequest",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "name": "Applicant Request Decision Workflow",
+  "description": "Determine if applicant request is valid",
+  "start": "CheckApplication",
+  "functions": [
+    {
+      "name": "sendRejectionEmailFunction",
+      "operation": "http://myapis.org/applicationapi.json#emailRejection"
+    }
+  ],
+  "states": [
+    {
+      "name": "CheckApplication",
+      "type": "switch",
+      "dataConditions": [
+        {
+          "condition": "${ .applicants | .age >= 18 }",
+          "transition": "StartApplication"
+        },
+        {
+          "condition": "${ .applicants | .age < 18 }",
+          "transition": "RejectApplication"
+        }
+      ],
+      "defaultCondition": {
+        "transition": "RejectApplication"
+      }
+    },
+    {
+      "name": "StartApplication",
+      "type": "operation",
+      "actions": [
+        {
+          "subFlowRef": "startApplicationWorkflowId"
+        }
+      ],
+      "end": true
+    },
+    {
+      "name": "RejectApplication",
+      "type": "operation",
+      "actionMode": "sequential",
+      "actions": [
+        {
+          "functionRef": {
+            "refName": "sendRejectionEmailFunction",
+            "arguments": {
+              "applicant": "${ .applicant }"
+            }
+          }
+        }
+      ],
+      "end": true
+    }
+  ]
+}`},
+	{name: ProvisionOrders, title: "Provision Orders", json: `{
+  "id": "provisionorders",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "name": "Provision Orders",
+  "description": "Provision Orders and handle errors thrown",
+  "start": "ProvisionOrder",
+  "functions": [
+    {
+      "name": "provisionOrderFunction",
+      "operation": "http://myapis.org/provisioningapi.json#doProvision"
+    }
+  ],
+  "errors": [
+    {
+      "name": "Missing order id"
+    },
+    {
+      "name": "Missing order item"
+    },
+    {
+      "name": "Missing order quantity"
+    }
+  ],
+  "states": [
+    {
+      "name": "ProvisionOrder",
+      "type": "operation",
+      "actionMode": "sequential",
+      "actions": [
+        {
+          "functionRef": {
+            "refName": "provisionOrderFunction",
+            "arguments": {
+              "order": "${ .order }"
+            }
+          }
+        }
+      ],
+      "stateDataFilter": {
+        "output": "${ .exceptions }"
+      },
+      "transition": "ApplyOrder",
+      "onErrors": [
+        {
+          "errorRef": "Missing order id",
+          "transition": "MissingId"
+        },
+        {
+          "errorRef": "Missing order item",
+          "transition": "MissingItem"
+        },
+        {
+          "errorRef": "Missing order quantity",
+          "transition": "MissingQuantity"
+        }
+      ]
+    },
+    {
+      "name": "MissingId",
+      "type": "operation",
+      "actions": [
+        {
+          "subFlowRef": "handleMissingIdExceptionWorkflow"
+        }
+      ],
+      "end": true
+    },
+    {
+      "name": "MissingItem",
+      "type": "operation",
+      "actions": [
+        {
+          "subFlowRef": "handleMissingItemExceptionWorkflow"
+        }
+      ],
+      "end": true
+    },
+    {
+      "name": "MissingQuantity",
+      "type": "operation",
+      "actions": [
+        {
+          "subFlowRef": "handleMissingQuantityExceptionWorkflow"
+        }
+      ],
+      "end": true
+    },
+    {
+      "name": "ApplyOrder",
+      "type": "operation",
+      "actions": [
+        {
+          "subFlowRef": "applyOrderWorkflowId"
+        }
+      ],
+      "end": true
+    }
+  ]
+}`},
+	{name: JobMonitoring, title: "Monitor Job", json: `{
+  "id": "jobmonitoring",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "name": "Job Monitoring",
+  "description": "Monitor finished execution of a submitted job",
+  "start": "SubmitJob",
+  "functions": [
+    {
+      "name": "submitJob",
+      "operation": "http://myapis.org/monitorapi.json#doSubmit"
+    },
+    {
+      "name": "checkJobStatus",
+      "operation": "http://myapis.org/monitorapi.json#checkStatus"
+    },
+    {
+      "name": "reportJobSuceeded",
+      "operation": "http://myapis.org/monitorapi.json#reportSucceeded"
+    },
+    {
+      "name": "reportJobFailed",
+      "operation": "http://myapis.org/monitorapi.json#reportFailure"
+    }
+  ],
+  "states": [
+    {
+      "name": "SubmitJob",
+      "type": "operation",
+      "actionMode": "sequential",
+      "actions": [
+        {
+          "functionRef": {
+            "refName": "submitJob",
+            "arguments": {
+              "name": "${ .job.name }"
+            }
+          },
+          "actionDataFilter": {
+            "results": "${ .jobuid }"
+          }
+        }
+      ],
+      "stateDataFilter": {
+        "output": "${ .jobuid }"
+      },
+      "transition": "WaitForCompletion"
+    },
+    {
+      "name": "WaitForCompletion",
+      "type": "sleep",
+      "duration": "PT5S",
+      "transition": "GetJobStatus"
+    },
+    {
+      "name": "GetJobStatus",
+      "type": "operation",
+      "actionMode": "sequential",
+      "actions": [
+        {
+          "functionRef": {
+            "refName": "checkJobStatus",
+            "arguments": {
+              "name": "${ .jobuid }"
+            }
+          },
+          "actionDataFilter": {
+            "results": "${ .jobstatus }"
+          }
+        }
+      ],
+      "stateDataFilter": {
+        "output": "${ .jobstatus }"
+      },
+      "transition": "DetermineCompletion"
+    },
+    {
+      "name": "DetermineCompletion",
+      "type": "switch",
+      "dataConditions": [
+        {
+          "condition": "${ .jobStatus == \"SUCCEEDED\" }",
+          "transition": "JobSucceeded"
+        },
+        {
+          "condition": "${ .jobStatus == \"FAILED\" }",
+          "transition": "JobFailed"
+        }
+      ],
+      "defaultCondition": {
+        "transition": "WaitForCompletion"
+      }
+    },
+    {
+      "name": "JobSucceeded",
+      "type": "operation",
+      "actionMode": "sequential",
+      "actions": [
+        {
+          "functionRef": {
+            "refName": "reportJobSuceeded",
+            "arguments": {
+              "name": "${ .jobuid }"
+            }
+          }
+        }
+      ],
+      "end": true
+    },
+    {
+      "name": "JobFailed",
+      "type": "operation",
+      "actionMode": "sequential",
+      "actions": [
+        {
+          "functionRef": {
+            "refName": "reportJobFailed",
+            "arguments": {
+              "name": "${ .jobuid }"
+            }
+          }
+        }
+      ],
+      "end": true
+    }
+  ]
+}`},
+	{name: SendCloudEventOnProvision, title: "Send CloudEvent On Workflow Completion", json: `{
+  "id": "sendcloudeventonprovision",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "name": "Send CloudEvent on provision completion",
+  "start": "ProvisionOrdersState",
+  "events": [
+    {
+      "name": "provisioningCompleteEvent",
+      "type": "provisionCompleteType",
+      "kind": "produced"
+    }
+  ],
+  "functions": [
+    {
+      "name": "provisionOrderFunction",
+      "operation": "http://myapis.org/provisioning.json#doProvision"
+    }
+  ],
+  "states": [
+    {
+      "name": "ProvisionOrdersState",
+      "type": "foreach",
+      "inputCollection": "${ .orders }",
+      "iterationParam": "singleorder",
+      "outputCollection": "${ .provisionedOrders }",
+      "actions": [
+        {
+          "functionRef": {
+            "refName": "provisionOrderFunction",
+            "arguments": {
+              "order": "${ .singleorder }"
+            }
+          }
+        }
+      ],
+      "end": {
+        "produceEvents": [
+          {
+            "eventRef": "provisioningCompleteEvent",
+            "data": "${ .provisionedOrders }"
+          }
+        ]
+      }
+    }
+  ]
+}`},
+	{name: PatientVitalsWorkflow, title: "Monitor Patient Vital Signs", json: `{
+  "id": "patientVitalsWorkflow",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "name": "Monitor Patient Vitals",
+  "start": "MonitorVitals",
+  "events": [
+    {
+      "name": "HighBodyTemperature",
+      "type": "org.monitor.highBodyTemp",
+      "source": "monitoringSource",
+      "correlation": [
+        {
+          "contextAttributeName": "patientId"
+        }
+      ]
+    },
+    {
+      "name": "HighBloodPressure",
+      "type": "org.monitor.highBloodPressure",
+      "source": "monitoringSource",
+      "correlation": [
+        {
+          "contextAttributeName": "patientId"
+        }
+      ]
+    },
+    {
+      "name": "HighRespirationRate",
+      "type": "org.monitor.highRespirationRate",
+      "source": "monitoringSource",
+      "correlation": [
+        {
+          "contextAttributeName": "patientId"
+        }
+      ]
+    }
+  ],
+  "functions": [
+    {
+      "name": "callPulmonologist",
+      "operation": "http://myapis.org/patientapis.json#callPulmonologist"
+    },
+    {
+      "name": "sendTylenolOrder",
+      "operation": "http://myapis.org/patientapis.json#tylenolOrder"
+    },
+    {
+      "name": "callNurse",
+      "operation": "http://myapis.org/patientapis.json#callNurse"
+    }
+  ],
+  "states": [
+    {
+      "name": "MonitorVitals",
+      "type": "event",
+      "exclusive": true,
+      "onEvents": [
+        {
+          "eventRefs": [
+            "HighBodyTemperature"
+          ],
+          "actions": [
+            {
+              "functionRef": {
+                "refName": "sendTylenolOrder",
+                "arguments": {
+                  "patientid": "${ .patientId }"
+                }
+              }
+            }
+          ]
+        },
+        {
+          "eventRefs": [
+            "HighBloodPressure"
+          ],
+          "actions": [
+            {
+              "functionRef": {
+                "refName": "callNurse",
+                "arguments": {
+                  "patientid": "${ .patientId }"
+                }
+              }
+            }
+          ]
+        },
+        {
+          "eventRefs": [
+            "HighRespirationRate"
+          ],
+          "actions": [
+            {
+              "functionRef": {
+                "refName": "callPulmonologist",
+                "arguments": {
+                  "patientid": "${ .patientId }"
+                }
+              }
+            }
+          ]
+        }
+      ],
+      "end": {
+        "terminate": true
+      }
+    }
+  ]
+}`},
+	{name: CheckInbox, title: "Check Inbox Periodically", json: `{
+  "id": "checkInbox",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "name": "Check Inbox Workflow",
+  "description": "Periodically Check Inbox",
+  "start": {
+    "stateName": "CheckInbox",
+    "schedule": {
+      "cron": "0 0/15 * * * ?"
+    }
+  },
+  "functions": [
+    {
+      "name": "checkInboxFunction",
+      "operation": "http://myapis.org/inboxapi.json#checkNewMessages"
+    },
+    {
+      "name": "sendTextFunction",
+      "operation": "http://myapis.org/inboxapi.json#sendText"
+    }
+  ],
+  "states": [
+    {
+      "name": "CheckInbox",
+      "type": "operation",
+      "actionMode": "sequential",
+      "actions": [
+        {
+          "functionRef": "checkInboxFunction"
+        }
+      ],
+      "transition": "SendTextForHighPriority"
+    },
+    {
+      "name": "SendTextForHighPriority",
+      "type": "foreach",
+      "inputCollection": "${ .messages }",
+      "iterationParam": "singlemessage",
+      "actions": [
+        {
+          "functionRef": {
+            "refName": "sendTextFunction",
+            "arguments": {
+              "message": "${ .singlemessage }"
+            }
+          }
+        }
+      ],
+      "end": true
+    }
+  ]
+}`},
+	{name: RoomReadings, title: "Accumulate Room Readings", json: `{
+  "id": "roomreadings",
+  "version": "1.0",
+  "specVersion": "0.7",
+  "name": "Room Temp and Humidity Workflow",
+  "start": "ConsumeReading",
+  "timeouts": {
+    "workflowExecTimeout": {
+      "duration": "PT1H",
+      "runBefore": "GenerateReport"
+    }
+  },
+  "keepActive": true,
+  "events": [
+    {
+      "name": "TemperatureEvent",
+      "type": "my.home.sensors",
+      "source": "/home/rooms/+",
+      "correlation": [
+        {
+          "contextAttributeName": "roomId"
+        }
+      ]
+    },
+    {
+      "name": "HumidityEvent",
+      "type": "my.home.sensors",
+      "source": "/home/rooms/+",
+      "correlation": [
+        {
+          "contextAttributeName": "roomId"
+        }
+      ]
+    }
+  ],
+  "functions": [
+    {
+      "name": "LogReading",
+      "operation": "http.myorg.io/ordersservices.json#logreading"
+    },
+    {
+      "name": "ProduceReport",
+      "operation": "http.myorg.io/ordersservices.json#produceReport"
+    }
+  ],
+  "states": [
+    {
+      "name": "ConsumeReading",
+      "type": "event",
+      "onEvents": [
+        {
+          "eventRefs": [
+            "TemperatureEvent",
+            "HumidityEvent"
+          ],
+          "actions": [
+            {
+              "functionRef": "LogReading"
+            }
+          ],
+          "eventDataFilter": {
+            "toStateData": "${ .readings }"
+          }
+        }
+      ],
+      "end": true
+    },
+    {
+      "name": "GenerateReport",
+      "type": "operation",
+      "actions": [
+        {
+          "functionRef": {
+            "refName": "ProduceReport",
+            "arguments": {
+              "data": "${ .readings }"
+            }
+          }
+        }
+      ],
+      "end": {
+        "terminate": true
+      }
+    }
+  ]
+}`},
+}
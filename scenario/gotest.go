@@ -0,0 +1,99 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenario
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"unicode"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// GoTest renders the scenarios as a table driven Go test stub of the given package. The stub describes the choices of
+// each scenario, the test author fills in the inputs leading to them and runs the workflow.
+func GoTest(workflow *model.Workflow, scenarios []Scenario, pkg string) ([]byte, error) {
+	states := map[string]model.State{}
+	for _, state := range workflow.States {
+		states[state.GetName()] = state
+	}
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "// Scenarios of the workflow %s, fill in the inputs leading to each one and run the workflow.\n\n", workflow.ID)
+	fmt.Fprintf(buf, "package %s\n\n", pkg)
+	buf.WriteString("import \"testing\"\n\n")
+	fmt.Fprintf(buf, "func Test%sScenarios(t *testing.T) {\n", goName(workflow.ID))
+	buf.WriteString("scenarios := []struct {\nname string\ninput map[string]interface{}\npath []string\n}{\n")
+	for _, scenario := range scenarios {
+		buf.WriteString("{\n")
+		for _, choice := range scenario.Choices {
+			fmt.Fprintf(buf, "// %s\n", describe(choice, states[choice.State]))
+		}
+		fmt.Fprintf(buf, "name: %q,\n", scenario.Name)
+		buf.WriteString("// TODO: input leading to the choices above\n")
+		buf.WriteString("input: map[string]interface{}{},\n")
+		quoted := make([]string, len(scenario.Path))
+		for i, state := range scenario.Path {
+			quoted[i] = fmt.Sprintf("%q", state)
+		}
+		fmt.Fprintf(buf, "path: []string{%s},\n", strings.Join(quoted, ", "))
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+	buf.WriteString("for _, scenario := range scenarios {\n")
+	buf.WriteString("scenario := scenario\n")
+	buf.WriteString("t.Run(scenario.name, func(t *testing.T) {\n")
+	buf.WriteString("t.Skip(\"TODO: run the workflow with scenario.input and check it visits scenario.path\")\n")
+	buf.WriteString("})\n}\n}\n")
+	return format.Source(buf.Bytes())
+}
+
+// describe tells what must happen in the state for the choice to be taken
+func describe(choice Choice, state model.State) string {
+	_, events := state.(*model.EventBasedSwitchState)
+	switch {
+	case len(choice.Error) > 0:
+		return fmt.Sprintf("%s: fails with %s", choice.State, choice.Error)
+	case choice.Default && events:
+		return fmt.Sprintf("%s: no event arrives in time", choice.State)
+	case choice.Default:
+		return fmt.Sprintf("%s: none of the conditions hold", choice.State)
+	case len(choice.Event) > 0:
+		return fmt.Sprintf("%s: %s is the first event to arrive", choice.State, choice.Event)
+	}
+	return fmt.Sprintf("%s: %s is the first condition to hold", choice.State, choice.Condition)
+}
+
+// goName converts the workflow id into an exported Go identifier, e.g. 'order-processing' into 'OrderProcessing'
+func goName(id string) string {
+	var name strings.Builder
+	upper := true
+	for _, r := range id {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		name.WriteRune(r)
+	}
+	if name.Len() == 0 || unicode.IsDigit([]rune(name.String())[0]) {
+		return "Workflow" + name.String()
+	}
+	return name.String()
+}
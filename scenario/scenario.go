@@ -0,0 +1,222 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenario
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/coverage"
+	"github.com/serverlessworkflow/sdk-go/v2/diagram"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// DefaultMaxScenarios maximum number of scenarios generated if not set in the options
+const DefaultMaxScenarios = 100
+
+// Choice what must happen in a state for the execution to follow a scenario
+type Choice struct {
+	State string `json:"state"`
+	// Name of the switch condition, or its expression or event if unnamed, 'default' for the default condition
+	Name string `json:"name,omitempty"`
+	// Condition data condition expression that must be the first to hold
+	Condition string `json:"condition,omitempty"`
+	// Event event that must be the first to arrive
+	Event string `json:"event,omitempty"`
+	// Default none of the data conditions hold, or no event arrives in time
+	Default bool `json:"default,omitempty"`
+	// Error error the state must fail with
+	Error string `json:"error,omitempty"`
+}
+
+// Scenario path from the start to the end of the workflow, and the choices leading to it
+type Scenario struct {
+	Name    string   `json:"name"`
+	Choices []Choice `json:"choices,omitempty"`
+	// Path states visited in order
+	Path []string `json:"path"`
+}
+
+// Trace returns the trace of an execution following the scenario, see coverage.Analyze
+func (s Scenario) Trace(workflowID string) coverage.Trace {
+	trace := coverage.Trace{Name: s.Name, WorkflowID: workflowID, Ended: true}
+	choices := 0
+	for _, state := range s.Path {
+		step := coverage.Step{State: state}
+		if choices < len(s.Choices) && s.Choices[choices].State == state {
+			step.Condition = s.Choices[choices].Name
+			step.Error = s.Choices[choices].Error
+			choices++
+		}
+		trace.Steps = append(trace.Steps, step)
+	}
+	return trace
+}
+
+// Options ...
+type Options struct {
+	// Errors generate the scenarios where states fail and their error handlers are taken
+	Errors bool
+	// MaxScenarios maximum number of scenarios, DefaultMaxScenarios if zero
+	MaxScenarios int
+}
+
+// branch a way to leave a state
+type branch struct {
+	to     string
+	choice *Choice
+}
+
+// Generate enumerates the paths from the start to the end of the workflow through its switch conditions, and
+// optionally its error handlers. Loops are followed at most once per scenario, by taking each branch at most once.
+// At most MaxScenarios are generated.
+func Generate(workflow *model.Workflow, opts Options) []Scenario {
+	if opts.MaxScenarios <= 0 {
+		opts.MaxScenarios = DefaultMaxScenarios
+	}
+	g := &generator{opts: opts, states: map[string]model.State{}, taken: map[string]bool{}}
+	for _, state := range workflow.States {
+		g.states[state.GetName()] = state
+	}
+	if workflow.Start != nil {
+		g.visit(workflow.Start.StateName)
+	}
+	return g.scenarios
+}
+
+type generator struct {
+	opts      Options
+	states    map[string]model.State
+	scenarios []Scenario
+	// path and choices of the scenario being built, taken branches keyed by state and index, e.g. 'Check[1]'
+	path    []string
+	choices []Choice
+	taken   map[string]bool
+}
+
+func (g *generator) visit(name string) {
+	if len(g.scenarios) >= g.opts.MaxScenarios {
+		return
+	}
+	if name == diagram.EndNodeID {
+		g.scenarios = append(g.scenarios, Scenario{
+			Name:    scenarioName(g.choices),
+			Choices: append([]Choice(nil), g.choices...),
+			Path:    append([]string(nil), g.path...),
+		})
+		return
+	}
+	state, ok := g.states[name]
+	if !ok {
+		return
+	}
+	g.path = append(g.path, name)
+	for i, b := range branches(state, g.opts.Errors) {
+		key := fmt.Sprintf("%s[%d]", name, i)
+		if g.taken[key] {
+			continue
+		}
+		g.taken[key] = true
+		if b.choice != nil {
+			g.choices = append(g.choices, *b.choice)
+		}
+		g.visit(b.to)
+		if b.choice != nil {
+			g.choices = g.choices[:len(g.choices)-1]
+		}
+		delete(g.taken, key)
+	}
+	g.path = g.path[:len(g.path)-1]
+}
+
+// branches returns the ways to leave the state, in the order they are defined
+func branches(state model.State, errors bool) []branch {
+	var branches []branch
+	name := state.GetName()
+	target := func(transition model.Transition) string {
+		if len(transition.NextState) > 0 {
+			return transition.NextState
+		}
+		return diagram.EndNodeID
+	}
+	if transition := state.GetTransition(); transition != nil && len(transition.NextState) > 0 {
+		branches = append(branches, branch{to: transition.NextState})
+	} else if state.GetEnd() != nil {
+		branches = append(branches, branch{to: diagram.EndNodeID})
+	}
+
+	switch s := state.(type) {
+	case *model.DataBasedSwitchState:
+		for _, condition := range s.DataConditions {
+			choice := &Choice{State: name, Name: condition.GetName(), Condition: condition.GetCondition()}
+			if len(choice.Name) == 0 {
+				choice.Name = choice.Condition
+			}
+			switch c := condition.(type) {
+			case *model.TransitionDataCondition:
+				branches = append(branches, branch{to: c.Transition.NextState, choice: choice})
+			case *model.EndDataCondition:
+				branches = append(branches, branch{to: diagram.EndNodeID, choice: choice})
+			}
+		}
+		branches = append(branches, branch{to: target(s.DefaultCondition.Transition), choice: &Choice{State: name, Name: "default", Default: true}})
+	case *model.EventBasedSwitchState:
+		for _, condition := range s.EventConditions {
+			choice := &Choice{State: name, Name: condition.GetName(), Event: condition.GetEventRef()}
+			if len(choice.Name) == 0 {
+				choice.Name = choice.Event
+			}
+			switch c := condition.(type) {
+			case *model.TransitionEventCondition:
+				branches = append(branches, branch{to: c.Transition.NextState, choice: choice})
+			case *model.EndEventCondition:
+				branches = append(branches, branch{to: diagram.EndNodeID, choice: choice})
+			}
+		}
+		branches = append(branches, branch{to: target(s.DefaultCondition.Transition), choice: &Choice{State: name, Name: "default", Default: true}})
+	}
+
+	if errors {
+		for _, onError := range state.GetOnErrors() {
+			// the handler is taken for any of its errors, the first one is enough
+			choice := &Choice{State: name, Error: onError.ErrorRef}
+			if len(onError.ErrorRefs) > 0 {
+				choice.Error = onError.ErrorRefs[0]
+			}
+			if onError.Transition != nil && len(onError.Transition.NextState) > 0 {
+				branches = append(branches, branch{to: onError.Transition.NextState, choice: choice})
+			} else if onError.End != nil {
+				branches = append(branches, branch{to: diagram.EndNodeID, choice: choice})
+			}
+		}
+	}
+	return branches
+}
+
+// scenarioName names the scenario after its choices, e.g. 'CheckApplication=adult, Store=error Timeout'
+func scenarioName(choices []Choice) string {
+	if len(choices) == 0 {
+		return "main path"
+	}
+	parts := make([]string, len(choices))
+	for i, choice := range choices {
+		if len(choice.Error) > 0 {
+			parts[i] = choice.State + "=error " + choice.Error
+		} else {
+			parts[i] = choice.State + "=" + choice.Name
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,167 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenario
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/coverage"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const applicationWorkflow = `id: application
+name: Application
+version: '1.0'
+specVersion: '0.7'
+start: Check
+events:
+  - name: Approved
+    type: application.approved
+errors:
+  - name: Failed
+    code: '500'
+functions:
+  - name: store
+    operation: http://applications.json#store
+states:
+  - name: Check
+    type: switch
+    dataConditions:
+      - name: adult
+        condition: ${ .age >= 18 }
+        transition: Wait
+      - condition: ${ .age < 18 }
+        end: true
+    defaultCondition:
+      transition: Retry
+  - name: Retry
+    type: sleep
+    duration: PT1M
+    transition: Check
+  - name: Wait
+    type: switch
+    eventConditions:
+      - eventRef: Approved
+        transition: Store
+    defaultCondition:
+      end: true
+  - name: Store
+    type: operation
+    actions:
+      - functionRef: store
+    onErrors:
+      - errorRef: Failed
+        end: true
+    end: true
+`
+
+func testWorkflow(t *testing.T) *model.Workflow {
+	workflow, err := parser.FromYAMLSource([]byte(applicationWorkflow))
+	require.NoError(t, err)
+	return workflow
+}
+
+func TestGenerate(t *testing.T) {
+	scenarios := Generate(testWorkflow(t), Options{})
+	var names []string
+	for _, scenario := range scenarios {
+		names = append(names, scenario.Name)
+	}
+	assert.Equal(t, []string{
+		"Check=adult, Wait=Approved",
+		"Check=adult, Wait=default",
+		"Check=${ .age < 18 }",
+		"Check=default, Check=adult, Wait=Approved",
+		"Check=default, Check=adult, Wait=default",
+		"Check=default, Check=${ .age < 18 }",
+	}, names)
+	assert.Equal(t, Scenario{
+		Name: "Check=adult, Wait=Approved",
+		Choices: []Choice{
+			{State: "Check", Name: "adult", Condition: "${ .age >= 18 }"},
+			{State: "Wait", Name: "Approved", Event: "Approved"},
+		},
+		Path: []string{"Check", "Wait", "Store"},
+	}, scenarios[0])
+	assert.Equal(t, []string{"Check", "Retry", "Check", "Wait", "Store"}, scenarios[3].Path)
+
+	assert.Len(t, Generate(testWorkflow(t), Options{MaxScenarios: 2}), 2)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	workflow := testWorkflow(t)
+	scenarios := Generate(workflow, Options{Errors: true})
+	require.Len(t, scenarios, 8)
+	assert.Equal(t, "Check=adult, Wait=Approved, Store=error Failed", scenarios[1].Name)
+	assert.Equal(t, Choice{State: "Store", Error: "Failed"}, scenarios[1].Choices[2])
+
+	// following every scenario covers every branch
+	var traces []coverage.Trace
+	for _, scenario := range scenarios {
+		traces = append(traces, scenario.Trace(workflow.ID))
+	}
+	report := coverage.Analyze(workflow, traces)
+	assert.Empty(t, report.Mismatches)
+	assert.Equal(t, 1.0, report.BranchRatio())
+}
+
+func TestGoTest(t *testing.T) {
+	workflow := testWorkflow(t)
+	source, err := GoTest(workflow, Generate(workflow, Options{Errors: true})[:2], "application_test")
+	require.NoError(t, err)
+	assert.Equal(t, `// Scenarios of the workflow application, fill in the inputs leading to each one and run the workflow.
+
+package application_test
+
+import "testing"
+
+func TestApplicationScenarios(t *testing.T) {
+	scenarios := []struct {
+		name  string
+		input map[string]interface{}
+		path  []string
+	}{
+		{
+			// Check: ${ .age >= 18 } is the first condition to hold
+			// Wait: Approved is the first event to arrive
+			name: "Check=adult, Wait=Approved",
+			// TODO: input leading to the choices above
+			input: map[string]interface{}{},
+			path:  []string{"Check", "Wait", "Store"},
+		},
+		{
+			// Check: ${ .age >= 18 } is the first condition to hold
+			// Wait: Approved is the first event to arrive
+			// Store: fails with Failed
+			name: "Check=adult, Wait=Approved, Store=error Failed",
+			// TODO: input leading to the choices above
+			input: map[string]interface{}{},
+			path:  []string{"Check", "Wait", "Store"},
+		},
+	}
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(t *testing.T) {
+			t.Skip("TODO: run the workflow with scenario.input and check it visits scenario.path")
+		})
+	}
+}
+`, string(source))
+	assert.Equal(t, "OrderProcessingV2", goName("order-processing.v2"))
+	assert.Equal(t, "Workflow1st", goName("1st"))
+}
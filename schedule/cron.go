@@ -0,0 +1,212 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchYears how far ahead the next time of a cron expression is searched, e.g. for February 29th
+const cronSearchYears = 5
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var dayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "second", min: 0, max: 59},
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: monthNames},
+	{name: "day of week", min: 0, max: 7, names: dayNames},
+}
+
+// cron parsed cron expression, each field being the set of the values it matches
+type cron struct {
+	seconds, minutes, hours, days, months, weekdays uint64
+	// anyDay whether the day of month or the day of week is '*' or '?', the days matching both fields then,
+	// and either of them otherwise
+	anyDay bool
+}
+
+// parseCron parses the cron expression: five fields, minute, hour, day of month, month and day of week, six fields
+// with the seconds first or a descriptor such as '@daily'. The fields are lists of values, ranges and steps, e.g.
+// '1-5', '*/15' or '0/15', the months and days of week can be named, e.g. 'JAN' or 'MON', and the days of week are
+// numbered from 0 to 7, Sunday being either 0 or 7.
+func parseCron(expression string) (*cron, error) {
+	expression = strings.TrimSpace(expression)
+	if descriptor, ok := cronDescriptors[strings.ToLower(expression)]; ok {
+		expression = descriptor
+	}
+	fields := strings.Fields(expression)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 or 6 fields, got %d", expression, len(fields))
+	}
+
+	sets := make([]uint64, len(fields))
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expression, err)
+		}
+		sets[i] = set
+	}
+	c := &cron{
+		seconds:  sets[0],
+		minutes:  sets[1],
+		hours:    sets[2],
+		days:     sets[3],
+		months:   sets[4],
+		weekdays: sets[5],
+		anyDay:   isAny(fields[3]) || isAny(fields[5]),
+	}
+	// Sunday is both 0 and 7
+	if c.weekdays&(1<<7) != 0 {
+		c.weekdays |= 1
+	}
+	return c, nil
+}
+
+func parseCronField(field string, f cronField) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			rangePart = item[:i]
+			var err error
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in the %s field %s", f.name, field)
+			}
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case isAny(rangePart):
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = cronValue(bounds[0], f); err != nil {
+				return 0, err
+			}
+			if high, err = cronValue(bounds[1], f); err != nil {
+				return 0, err
+			}
+			if high < low {
+				return 0, fmt.Errorf("invalid range %s in the %s field", rangePart, f.name)
+			}
+		default:
+			var err error
+			if low, err = cronValue(rangePart, f); err != nil {
+				return 0, err
+			}
+			// a single value with a step, e.g. '0/15', runs up to the maximum
+			if !strings.Contains(item, "/") {
+				high = low
+			}
+		}
+		for value := low; value <= high; value += step {
+			set |= 1 << uint(value)
+		}
+	}
+	return set, nil
+}
+
+func cronValue(s string, f cronField) (int, error) {
+	if value, ok := f.names[strings.ToUpper(s)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(s)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("invalid value %s in the %s field, expected %d to %d", s, f.name, f.min, f.max)
+	}
+	return value, nil
+}
+
+func isAny(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// next returns the first time matching the cron expression strictly after the given time, in its location, and
+// false if none matches within the next years. The times skipped by daylight saving time changes don't match.
+func (c *cron) next(after time.Time) (time.Time, bool) {
+	t := after.Add(time.Second - time.Duration(after.Nanosecond()))
+	limit := t.AddDate(cronSearchYears, 0, 0)
+	for t.Before(limit) {
+		if !has(c.months, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(c.hours, t.Hour()) {
+			t = t.Add(time.Hour - time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+			continue
+		}
+		if !has(c.minutes, t.Minute()) {
+			t = t.Add(time.Minute - time.Duration(t.Second())*time.Second)
+			continue
+		}
+		if !has(c.seconds, t.Second()) {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+func (c *cron) matchesDay(t time.Time) bool {
+	day, weekday := has(c.days, t.Day()), has(c.weekdays, int(t.Weekday()))
+	if c.anyDay {
+		return day && weekday
+	}
+	return day || weekday
+}
+
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
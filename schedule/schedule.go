@@ -0,0 +1,166 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schedule computes when the workflow instances are created according to the start schedule of the
+// workflows, either a cron expression or an ISO 8601 repeating interval, in the timezone of the schedule.
+package schedule
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/util/iso8601"
+)
+
+const (
+	maxInt = int(^uint(0) >> 1)
+	minInt = -maxInt - 1
+
+	// approximate lengths of the calendar units, to estimate the repetition of a repeating interval at a given time
+	approximateYear  = 365.2425 * 24 * 3600
+	approximateMonth = approximateYear / 12
+)
+
+// Schedule occurrences of a workflow start schedule
+type Schedule struct {
+	// Location timezone of the schedule, UTC by default
+	Location *time.Location
+	// ValidUntil time after which the cron expression doesn't occur anymore, zero if always valid
+	ValidUntil time.Time
+	// Anchor time the repeating intervals defined by their duration only are counted from, midnight on January 1st
+	// 1970 in the schedule location by default, or e.g. the time the workflow is deployed
+	Anchor time.Time
+
+	cron     *cron
+	interval *iso8601.RepeatingInterval
+}
+
+// New returns the occurrences of the schedule, defined by either a cron expression or a repeating interval
+func New(schedule model.Schedule) (*Schedule, error) {
+	s := &Schedule{Location: time.UTC}
+	if len(schedule.Timezone) > 0 {
+		location, err := time.LoadLocation(schedule.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %s: %w", schedule.Timezone, err)
+		}
+		s.Location = location
+	}
+	s.Anchor = time.Date(1970, time.January, 1, 0, 0, 0, 0, s.Location)
+
+	if schedule.Cron != nil && len(schedule.Interval) > 0 {
+		return nil, fmt.Errorf("schedule defines both an interval and a cron expression")
+	}
+	switch {
+	case schedule.Cron != nil:
+		c, err := parseCron(schedule.Cron.Expression)
+		if err != nil {
+			return nil, err
+		}
+		s.cron = c
+		if len(schedule.Cron.ValidUntil) > 0 {
+			if s.ValidUntil, err = iso8601.ParseTime(schedule.Cron.ValidUntil, s.Location); err != nil {
+				return nil, fmt.Errorf("invalid validUntil: %w", err)
+			}
+		}
+	case len(schedule.Interval) > 0:
+		interval, err := iso8601.ParseRepeatingInterval(schedule.Interval, s.Location)
+		if err != nil {
+			return nil, err
+		}
+		s.interval = &interval
+	default:
+		return nil, fmt.Errorf("schedule defines neither an interval nor a cron expression")
+	}
+	return s, nil
+}
+
+// NextOccurrence returns the first occurrence of the schedule strictly after the given time, in the location of the
+// schedule, and false if the schedule doesn't occur anymore
+func (s *Schedule) NextOccurrence(after time.Time) (time.Time, bool) {
+	after = after.In(s.Location)
+	if s.cron != nil {
+		next, ok := s.cron.next(after)
+		if !ok || (!s.ValidUntil.IsZero() && next.After(s.ValidUntil)) {
+			return time.Time{}, false
+		}
+		return next, true
+	}
+	return s.nextRepetition(after)
+}
+
+// Occurrences returns the occurrences of the schedule from the given time, included, until the given time, excluded
+func (s *Schedule) Occurrences(from, until time.Time) []time.Time {
+	var occurrences []time.Time
+	for next, ok := s.NextOccurrence(from.Add(-1)); ok && next.Before(until); next, ok = s.NextOccurrence(next) {
+		occurrences = append(occurrences, next)
+	}
+	return occurrences
+}
+
+// nextRepetition returns the start of the first repetition of the interval strictly after the given time. The
+// repetition n starts at anchor + n * duration: the anchor being the start of the interval and n going from 0, or
+// the end of the interval and n going up to -1, or the anchor of the schedule if the interval has neither.
+func (s *Schedule) nextRepetition(after time.Time) (time.Time, bool) {
+	interval := s.interval
+	anchor, first, last := s.Anchor, minInt, maxInt
+	switch {
+	case !interval.Start.IsZero():
+		anchor, first = interval.Start, 0
+		if interval.Repetitions != iso8601.Unbounded {
+			last = interval.Repetitions - 1
+		}
+	case !interval.End.IsZero():
+		anchor, last = interval.End, -1
+		if interval.Repetitions != iso8601.Unbounded {
+			first = -interval.Repetitions
+		}
+	case interval.Repetitions != iso8601.Unbounded:
+		first, last = 0, interval.Repetitions-1
+	}
+	anchor = anchor.In(s.Location)
+	if first > last {
+		return time.Time{}, false
+	}
+
+	repetition := func(n int) time.Time {
+		return interval.Duration.Scale(float64(n)).AddTo(anchor)
+	}
+	// estimate the repetition from the approximate duration, then step to the first one after the time
+	d := interval.Duration
+	seconds := (d.Years*approximateYear + d.Months*approximateMonth) +
+		((d.Weeks*7+d.Days)*24+d.Hours)*3600 + d.Minutes*60 + d.Seconds
+	n := clamp(math.Floor(after.Sub(anchor).Seconds()/seconds), first, last)
+	for n > first && repetition(n).After(after) {
+		n--
+	}
+	for !repetition(n).After(after) {
+		if n == last {
+			return time.Time{}, false
+		}
+		n++
+	}
+	return repetition(n), true
+}
+
+func clamp(f float64, min, max int) int {
+	if f < float64(min) {
+		return min
+	}
+	if f > float64(max) {
+		return max
+	}
+	return int(f)
+}
@@ -0,0 +1,175 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cronSchedule(t *testing.T, expression, timezone string) *Schedule {
+	s, err := New(model.Schedule{Cron: &model.Cron{Expression: expression}, Timezone: timezone})
+	require.NoError(t, err)
+	return s
+}
+
+func TestCronNextOccurrence(t *testing.T) {
+	after := time.Date(2022, time.March, 1, 13, 7, 30, 500, time.UTC)
+	cases := map[string]time.Time{
+		"0 0/15 * * * ?":      time.Date(2022, time.March, 1, 13, 15, 0, 0, time.UTC),
+		"*/5 * * * *":         time.Date(2022, time.March, 1, 13, 10, 0, 0, time.UTC),
+		"* * * * * *":         time.Date(2022, time.March, 1, 13, 7, 31, 0, time.UTC),
+		"0 9 * * MON-FRI":     time.Date(2022, time.March, 2, 9, 0, 0, 0, time.UTC),
+		"30 12 * * sun":       time.Date(2022, time.March, 6, 12, 30, 0, 0, time.UTC),
+		"0 0 29 2 *":          time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		"0 0 1,15 * *":        time.Date(2022, time.March, 15, 0, 0, 0, 0, time.UTC),
+		"0 0 13 * 5":          time.Date(2022, time.March, 4, 0, 0, 0, 0, time.UTC),
+		"0 0 1 JAN ?":         time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"@hourly":             time.Date(2022, time.March, 1, 14, 0, 0, 0, time.UTC),
+		"@weekly":             time.Date(2022, time.March, 6, 0, 0, 0, 0, time.UTC),
+		"0 0 0 * * 7":         time.Date(2022, time.March, 6, 0, 0, 0, 0, time.UTC),
+		"10-20/5 8 13 * * *":  time.Date(2022, time.March, 1, 13, 8, 10, 0, time.UTC),
+		"0 0 12 31 APR-JUN ?": time.Date(2022, time.May, 31, 12, 0, 0, 0, time.UTC),
+	}
+	for expression, expected := range cases {
+		next, ok := cronSchedule(t, expression, "").NextOccurrence(after)
+		assert.True(t, ok, expression)
+		assert.Equal(t, expected, next, expression)
+	}
+
+	_, ok := cronSchedule(t, "0 0 31 2 *", "").NextOccurrence(after)
+	assert.False(t, ok)
+}
+
+func TestCronTimezone(t *testing.T) {
+	s := cronSchedule(t, "0 30 2 * * *", "America/New_York")
+	// 2:30 doesn't exist on the day the clocks go forward
+	next, ok := s.NextOccurrence(time.Date(2022, time.March, 13, 0, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, "2022-03-14T02:30:00-04:00", next.Format(time.RFC3339))
+
+	s = cronSchedule(t, "0 9 * * *", "Europe/Paris")
+	next, ok = s.NextOccurrence(time.Date(2022, time.March, 1, 9, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, "2022-03-02T09:00:00+01:00", next.Format(time.RFC3339))
+	assert.Equal(t, s.Location, next.Location())
+}
+
+func TestCronValidUntil(t *testing.T) {
+	s, err := New(model.Schedule{Cron: &model.Cron{Expression: "0 0 * * *", ValidUntil: "2022-03-03T00:00:00Z"}})
+	require.NoError(t, err)
+	occurrences := s.Occurrences(time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, []time.Time{
+		time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, time.March, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, time.March, 3, 0, 0, 0, 0, time.UTC),
+	}, occurrences)
+}
+
+func TestIntervalOccurrences(t *testing.T) {
+	from := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+	until := from.AddDate(0, 0, 1)
+	cases := map[string][]string{
+		"R3/2022-03-01T13:00:00Z/PT1H": {"2022-03-01T13:00:00Z", "2022-03-01T14:00:00Z", "2022-03-01T15:00:00Z"},
+		"R2/PT6H/2022-03-02T00:00:00Z": {"2022-03-01T12:00:00Z", "2022-03-01T18:00:00Z"},
+		"R/PT8H":                       {"2022-03-01T00:00:00Z", "2022-03-01T08:00:00Z", "2022-03-01T16:00:00Z"},
+		"R/2022-02-28T22:00:00Z/2022-03-01T04:00:00Z": {
+			"2022-03-01T04:00:00Z", "2022-03-01T10:00:00Z", "2022-03-01T16:00:00Z", "2022-03-01T22:00:00Z",
+		},
+		"R2/2022-02-01T00:00:00Z/PT1H": nil,
+	}
+	for interval, expected := range cases {
+		s, err := New(model.Schedule{Interval: interval})
+		require.NoError(t, err, interval)
+		var occurrences []string
+		for _, occurrence := range s.Occurrences(from, until) {
+			occurrences = append(occurrences, occurrence.Format(time.RFC3339))
+		}
+		assert.Equal(t, expected, occurrences, interval)
+	}
+}
+
+func TestIntervalCalendar(t *testing.T) {
+	s, err := New(model.Schedule{Interval: "R/2022-01-31T10:00:00/P1M", Timezone: "Europe/Paris"})
+	require.NoError(t, err)
+	next, ok := s.NextOccurrence(time.Date(2022, time.March, 15, 0, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	// months are added to the start, not to the previous occurrence
+	assert.Equal(t, "2022-03-31T10:00:00+02:00", next.Format(time.RFC3339))
+
+	s, err = New(model.Schedule{Interval: "R/P1D", Timezone: "Europe/Paris"})
+	require.NoError(t, err)
+	next, ok = s.NextOccurrence(time.Date(2022, time.March, 26, 22, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, "2022-03-27T00:00:00+01:00", next.Format(time.RFC3339))
+	next, ok = s.NextOccurrence(next)
+	require.True(t, ok)
+	assert.Equal(t, "2022-03-28T00:00:00+02:00", next.Format(time.RFC3339))
+
+	s.Anchor = time.Date(2022, time.March, 1, 10, 0, 0, 0, s.Location)
+	next, ok = s.NextOccurrence(time.Date(2022, time.March, 26, 23, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, "2022-03-27T10:00:00+02:00", next.Format(time.RFC3339))
+}
+
+func TestIntervalEnds(t *testing.T) {
+	s, err := New(model.Schedule{Interval: "R2/2022-03-01T00:00:00Z/PT1H"})
+	require.NoError(t, err)
+	next, ok := s.NextOccurrence(time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2022, time.March, 1, 1, 0, 0, 0, time.UTC), next)
+	_, ok = s.NextOccurrence(next)
+	assert.False(t, ok)
+
+	s, err = New(model.Schedule{Interval: "R0/PT1H"})
+	require.NoError(t, err)
+	_, ok = s.NextOccurrence(time.Time{})
+	assert.False(t, ok)
+}
+
+func TestInvalidSchedules(t *testing.T) {
+	cases := map[string]model.Schedule{
+		"schedule defines neither an interval nor a cron expression": {},
+		"schedule defines both an interval and a cron expression": {
+			Interval: "R/PT1H", Cron: &model.Cron{Expression: "@daily"},
+		},
+		`invalid timezone Mars/Olympus: unknown time zone Mars/Olympus`:  {Interval: "R/PT1H", Timezone: "Mars/Olympus"},
+		`invalid cron expression "* * *": expected 5 or 6 fields, got 3`: {Cron: &model.Cron{Expression: "* * *"}},
+		`invalid cron expression "61 * * * *": invalid value 61 in the minute field, expected 0 to 59`: {
+			Cron: &model.Cron{Expression: "61 * * * *"},
+		},
+		`invalid cron expression "0 0 * * MON-XYZ": invalid value XYZ in the day of week field, expected 0 to 7`: {
+			Cron: &model.Cron{Expression: "0 0 * * MON-XYZ"},
+		},
+		`invalid cron expression "*/0 * * * *": invalid step in the minute field */0`: {
+			Cron: &model.Cron{Expression: "*/0 * * * *"},
+		},
+		`invalid cron expression "0 5-1 * * *": invalid range 5-1 in the hour field`: {
+			Cron: &model.Cron{Expression: "0 5-1 * * *"},
+		},
+		"invalid validUntil: invalid ISO 8601 date time tomorrow": {
+			Cron: &model.Cron{Expression: "@daily", ValidUntil: "tomorrow"},
+		},
+		"invalid ISO 8601 repeating interval PT1H": {Interval: "PT1H"},
+	}
+	for message, schedule := range cases {
+		_, err := New(schedule)
+		assert.EqualError(t, err, message)
+	}
+}
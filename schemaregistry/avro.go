@@ -0,0 +1,352 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaregistry
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/jsonschema"
+)
+
+// avroSchema parsed Avro schema, the nodes being the decoded JSON of the schema
+type avroSchema struct {
+	root interface{}
+	// named records, enums and fixed types by full name
+	named map[string]map[string]interface{}
+}
+
+// avroNamedTypes types of the nodes defining a name
+var avroNamedTypes = map[string]bool{"record": true, "error": true, "enum": true, "fixed": true}
+
+// isAvro checks whether the source looks like an Avro schema rather than a JSON schema
+func isAvro(source []byte) bool {
+	var doc interface{}
+	if err := json.Unmarshal(source, &doc); err != nil {
+		return false
+	}
+	switch doc := doc.(type) {
+	case []interface{}:
+		return true
+	case map[string]interface{}:
+		t, _ := doc["type"].(string)
+		return avroNamedTypes[t]
+	}
+	return false
+}
+
+func parseAvro(source []byte) (*avroSchema, error) {
+	schema := &avroSchema{named: map[string]map[string]interface{}{}}
+	if err := json.Unmarshal(source, &schema.root); err != nil {
+		return nil, fmt.Errorf("invalid Avro schema: %w", err)
+	}
+	if err := schema.register(schema.root, ""); err != nil {
+		return nil, fmt.Errorf("invalid Avro schema: %w", err)
+	}
+	return schema, nil
+}
+
+// register indexes the named types defined under the node
+func (s *avroSchema) register(node interface{}, namespace string) error {
+	switch node := node.(type) {
+	case []interface{}:
+		for _, branch := range node {
+			if err := s.register(branch, namespace); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		t, _ := node["type"].(string)
+		if !avroNamedTypes[t] {
+			if items, ok := node["items"]; ok {
+				return s.register(items, namespace)
+			}
+			if values, ok := node["values"]; ok {
+				return s.register(values, namespace)
+			}
+			return s.register(node["type"], namespace)
+		}
+		name, _ := node["name"].(string)
+		if len(name) == 0 {
+			return fmt.Errorf("%s without name", t)
+		}
+		if ns, ok := node["namespace"].(string); ok {
+			namespace = ns
+		}
+		full := fullName(name, namespace)
+		if i := strings.LastIndex(full, "."); i >= 0 {
+			namespace = full[:i]
+		}
+		s.named[full] = node
+		node["namespace"] = namespace
+		fields, _ := node["fields"].([]interface{})
+		for _, field := range fields {
+			field, ok := field.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("invalid field in record %s", full)
+			}
+			if err := s.register(field["type"], namespace); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func fullName(name, namespace string) string {
+	if strings.Contains(name, ".") || len(namespace) == 0 {
+		return name
+	}
+	return namespace + "." + name
+}
+
+func (s *avroSchema) validate(payload interface{}) []jsonschema.ValidationError {
+	v := &avroValidation{schema: s}
+	v.validate(s.root, payload, "", "")
+	return v.errors
+}
+
+type avroValidation struct {
+	schema *avroSchema
+	errors []jsonschema.ValidationError
+}
+
+func (v *avroValidation) fail(path string, format string, args ...interface{}) {
+	v.errors = append(v.errors, jsonschema.ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// resolve returns the named type referenced in the given namespace, nil if not a reference
+func (v *avroValidation) resolve(name, namespace string) map[string]interface{} {
+	if named, ok := v.schema.named[fullName(name, namespace)]; ok {
+		return named
+	}
+	return v.schema.named[name]
+}
+
+func (v *avroValidation) validate(node interface{}, value interface{}, path, namespace string) {
+	switch node := node.(type) {
+	case string:
+		if named := v.resolve(node, namespace); named != nil {
+			v.validate(named, value, path, namespace)
+			return
+		}
+		v.validatePrimitive(node, value, path)
+	case []interface{}:
+		v.validateUnion(node, value, path, namespace)
+	case map[string]interface{}:
+		t, ok := node["type"].(string)
+		if !ok {
+			v.validate(node["type"], value, path, namespace)
+			return
+		}
+		if ns, ok := node["namespace"].(string); ok && avroNamedTypes[t] {
+			namespace = ns
+		}
+		switch t {
+		case "record", "error":
+			v.validateRecord(node, value, path, namespace)
+		case "enum":
+			symbol, ok := value.(string)
+			symbols, _ := node["symbols"].([]interface{})
+			if !ok {
+				v.fail(path, "expected a symbol of enum %s, got %s", node["name"], kind(value))
+				return
+			}
+			for _, s := range symbols {
+				if s == symbol {
+					return
+				}
+			}
+			v.fail(path, "%q is not a symbol of enum %s", symbol, node["name"])
+		case "array":
+			items, ok := value.([]interface{})
+			if !ok {
+				v.fail(path, "expected an array, got %s", kind(value))
+				return
+			}
+			for i, item := range items {
+				v.validate(node["items"], item, path+"["+strconv.Itoa(i)+"]", namespace)
+			}
+		case "map":
+			entries, ok := value.(map[string]interface{})
+			if !ok {
+				v.fail(path, "expected a map, got %s", kind(value))
+				return
+			}
+			for _, key := range sortedKeys(entries) {
+				v.validate(node["values"], entries[key], join(path, key), namespace)
+			}
+		case "fixed":
+			size, _ := node["size"].(float64)
+			bytes, ok := value.(string)
+			if !ok {
+				v.fail(path, "expected fixed %s, got %s", node["name"], kind(value))
+			} else if len([]rune(bytes)) != int(size) {
+				v.fail(path, "fixed %s is %v bytes, got %d", node["name"], size, len([]rune(bytes)))
+			}
+		default:
+			// primitive types annotated with a logical type
+			v.validate(t, value, path, namespace)
+		}
+	default:
+		v.fail(path, "invalid schema node %v", node)
+	}
+}
+
+func (v *avroValidation) validatePrimitive(t string, value interface{}, path string) {
+	valid := false
+	switch t {
+	case "null":
+		valid = value == nil
+	case "boolean":
+		_, valid = value.(bool)
+	case "int", "long":
+		number, ok := value.(float64)
+		valid = ok && number == math.Trunc(number)
+		if valid && t == "int" && (number < math.MinInt32 || number > math.MaxInt32) {
+			v.fail(path, "%v overflows an int", number)
+			return
+		}
+	case "float", "double":
+		_, valid = value.(float64)
+	case "string", "bytes":
+		_, valid = value.(string)
+	default:
+		v.fail(path, "unknown type %s", t)
+		return
+	}
+	if !valid {
+		v.fail(path, "expected %s, got %s", t, kind(value))
+	}
+}
+
+func (v *avroValidation) validateRecord(node map[string]interface{}, value interface{}, path, namespace string) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		v.fail(path, "expected record %s, got %s", node["name"], kind(value))
+		return
+	}
+	fields, _ := node["fields"].([]interface{})
+	declared := map[string]bool{}
+	for _, field := range fields {
+		field := field.(map[string]interface{})
+		name, _ := field["name"].(string)
+		declared[name] = true
+		fieldValue, ok := object[name]
+		if !ok {
+			if _, hasDefault := field["default"]; !hasDefault {
+				v.fail(path, "missing field %s", name)
+			}
+			continue
+		}
+		v.validate(field["type"], fieldValue, join(path, name), namespace)
+	}
+	for _, name := range sortedKeys(object) {
+		if !declared[name] {
+			v.fail(path, "field %s is not defined in record %s", name, node["name"])
+		}
+	}
+}
+
+// validateUnion accepts the values matching one of the branches, and the values of the Avro JSON encoding wrapped in
+// an object naming their branch
+func (v *avroValidation) validateUnion(branches []interface{}, value interface{}, path, namespace string) {
+	for _, branch := range branches {
+		if v.matches(branch, value, path, namespace) {
+			return
+		}
+	}
+	if object, ok := value.(map[string]interface{}); ok && len(object) == 1 {
+		for name, wrapped := range object {
+			for _, branch := range branches {
+				if v.branchName(branch, namespace) == fullName(name, namespace) || v.branchName(branch, namespace) == name {
+					v.validate(branch, wrapped, path, namespace)
+					return
+				}
+			}
+		}
+	}
+	names := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		names = append(names, v.branchName(branch, namespace))
+	}
+	v.fail(path, "%s doesn't match any type of the union [%s]", kind(value), strings.Join(names, ", "))
+}
+
+func (v *avroValidation) matches(node interface{}, value interface{}, path, namespace string) bool {
+	nested := &avroValidation{schema: v.schema}
+	nested.validate(node, value, path, namespace)
+	return len(nested.errors) == 0
+}
+
+// branchName name of a union branch in the Avro JSON encoding: the full name of the named types, the type otherwise
+func (v *avroValidation) branchName(node interface{}, namespace string) string {
+	switch node := node.(type) {
+	case string:
+		if named := v.resolve(node, namespace); named != nil {
+			return v.branchName(named, namespace)
+		}
+		return node
+	case map[string]interface{}:
+		t, ok := node["type"].(string)
+		if !ok {
+			return v.branchName(node["type"], namespace)
+		}
+		if avroNamedTypes[t] {
+			ns, _ := node["namespace"].(string)
+			return fullName(node["name"].(string), ns)
+		}
+		return t
+	}
+	return fmt.Sprint(node)
+}
+
+// kind describes the JSON type of a value in the messages
+func kind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case float64:
+		return "a number"
+	case string:
+		return "a string"
+	case []interface{}:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func join(path, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return path + "." + name
+}
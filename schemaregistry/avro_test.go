@@ -0,0 +1,80 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaregistry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvro(t *testing.T) {
+	assert.True(t, isAvro([]byte(orderAvro)))
+	assert.False(t, isAvro([]byte(paymentJSONSchema)))
+
+	schema, err := parseAvro([]byte(`{
+  "type": "record",
+  "name": "Order",
+  "namespace": "com.acme",
+  "fields": [
+    {"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "PAID"]}},
+    {"name": "lines", "type": {"type": "array", "items": {
+      "type": "record", "name": "Line", "fields": [{"name": "sku", "type": "string"}, {"name": "quantity", "type": "int"}]
+    }}},
+    {"name": "tags", "type": {"type": "map", "values": "string"}, "default": {}},
+    {"name": "digest", "type": {"type": "fixed", "name": "Digest", "size": 4}},
+    {"name": "created", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+    {"name": "parent", "type": ["null", "Order"], "default": null},
+    {"name": "line", "type": ["null", "com.acme.Line"], "default": null}
+  ]
+}`))
+	require.NoError(t, err)
+	assert.Contains(t, schema.named, "com.acme.Order")
+	assert.Contains(t, schema.named, "com.acme.Line")
+
+	valid := map[string]interface{}{
+		"status":  "NEW",
+		"lines":   []interface{}{map[string]interface{}{"sku": "a", "quantity": float64(2)}},
+		"digest":  "abcd",
+		"created": float64(1660000000000),
+		"line":    map[string]interface{}{"com.acme.Line": map[string]interface{}{"sku": "b", "quantity": float64(1)}},
+	}
+	assert.Empty(t, schema.validate(valid))
+
+	errs := schema.validate(map[string]interface{}{
+		"status":  "LOST",
+		"lines":   []interface{}{map[string]interface{}{"sku": "a", "quantity": 1.5}},
+		"tags":    map[string]interface{}{"gift": true},
+		"digest":  "abc",
+		"created": "yesterday",
+		"parent":  "none",
+	})
+	var messages []string
+	for _, e := range errs {
+		messages = append(messages, e.Error())
+	}
+	assert.Equal(t, []string{
+		`status: "LOST" is not a symbol of enum Status`,
+		"lines[0].quantity: expected int, got a number",
+		"tags.gift: expected string, got a boolean",
+		"digest: fixed Digest is 4 bytes, got 3",
+		"created: expected long, got a string",
+		"parent: a string doesn't match any type of the union [null, com.acme.Order]",
+	}, messages)
+
+	_, err = parseAvro([]byte(`{"type": "record", "fields": []}`))
+	assert.EqualError(t, err, "invalid Avro schema: record without name")
+}
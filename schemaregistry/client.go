@@ -0,0 +1,224 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schemaregistry resolves the data schemas of the events from Confluent and Apicurio schema registries and
+// validates sample payloads against them, catching the drift between the contracts of the producers and consumers
+// when the workflows are defined rather than when the events flow.
+package schemaregistry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/serverlessworkflow/sdk-go/v2/resolver"
+)
+
+// Format format of a data schema, named as the schema types of the registries
+type Format string
+
+const (
+	// FormatAvro Apache Avro schema
+	FormatAvro Format = "AVRO"
+	// FormatJSONSchema JSON schema
+	FormatJSONSchema Format = "JSON"
+	// FormatProtobuf Protocol Buffers schema, the payloads being the JSON mapping of the message
+	FormatProtobuf Format = "PROTOBUF"
+
+	// confluentContentType media type of the Confluent schema registry API
+	confluentContentType = "application/vnd.schemaregistry.v1+json"
+	// apicurioTypeHeader response header of the Apicurio registry holding the artifact type
+	apicurioTypeHeader = "X-Registry-ArtifactType"
+)
+
+var (
+	// confluentPath paths of the schemas in the Confluent API, also served by the compatibility API of Apicurio
+	confluentPath = regexp.MustCompile(`/(subjects/[^/]+/versions/[^/]+|schemas/ids/[0-9]+)/?$`)
+	// apicurioPath paths of the artifacts in the Apicurio API
+	apicurioPath = regexp.MustCompile(`/apis/registry/v[0-9]+/groups/[^/]+/artifacts/`)
+)
+
+// Client resolves the data schemas referenced by URI:
+//
+//	<registry>/subjects/<subject>/versions/<version|latest>   schema of a subject in a Confluent registry
+//	<registry>/schemas/ids/<id>                                schema by id in a Confluent registry
+//	<registry>/apis/registry/v2/groups/<group>/artifacts/<id>  artifact of an Apicurio registry, optionally with
+//	                                                           /versions/<version>
+//
+// Other URIs are loaded with the Loader, their format guessed from the extension, .avsc for Avro and .proto for
+// Protobuf, or the content. The fragment of the URI of a Protobuf schema names the message of the payloads, the first
+// message of the file if empty. The resolved schemas are cached, a Client is safe for concurrent use.
+type Client struct {
+	// HTTPClient default is http.DefaultClient
+	HTTPClient *http.Client
+	// Token bearer token sent to the registries if set, otherwise the Username and Password if set, e.g. the API key
+	// and secret of Confluent Cloud
+	Token    string
+	Username string
+	Password string
+	// Loader loads the URIs that aren't registry schemas, default loads files relative to the working directory and
+	// http(s) URIs
+	Loader resolver.Loader
+
+	mutex   sync.Mutex
+	schemas map[string]*Schema
+}
+
+// confluentSchema schema in the responses of the Confluent API
+type confluentSchema struct {
+	Schema string `json:"schema"`
+	// SchemaType empty for Avro schemas
+	SchemaType Format `json:"schemaType,omitempty"`
+}
+
+// Fetch resolves and compiles the schema referenced by the given URI
+func (c *Client) Fetch(ctx context.Context, uri string) (*Schema, error) {
+	c.mutex.Lock()
+	schema := c.schemas[uri]
+	c.mutex.Unlock()
+	if schema != nil {
+		return schema, nil
+	}
+	location, message := uri, ""
+	if i := strings.Index(uri, "#"); i >= 0 {
+		location, message = uri[:i], uri[i+1:]
+	}
+	var format Format
+	var source []byte
+	parsed, err := url.Parse(location)
+	switch {
+	case err == nil && confluentPath.MatchString(parsed.Path):
+		format, source, err = c.fetchConfluent(ctx, location)
+	case err == nil && apicurioPath.MatchString(parsed.Path):
+		format, source, err = c.fetchApicurio(ctx, location)
+	default:
+		source, err = c.loader().Load(location)
+		format = guessFormat(location, source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	schema, err = compile(uri, format, source, message)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", uri, err)
+	}
+	c.mutex.Lock()
+	if c.schemas == nil {
+		c.schemas = map[string]*Schema{}
+	}
+	c.schemas[uri] = schema
+	c.mutex.Unlock()
+	return schema, nil
+}
+
+func (c *Client) fetchConfluent(ctx context.Context, location string) (Format, []byte, error) {
+	resp, err := c.get(ctx, location, confluentContentType+", application/json")
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+	schema := confluentSchema{}
+	if err := json.NewDecoder(resp.Body).Decode(&schema); err != nil {
+		return "", nil, fmt.Errorf("invalid response of %s: %w", location, err)
+	}
+	if len(schema.SchemaType) == 0 {
+		schema.SchemaType = FormatAvro
+	}
+	return schema.SchemaType, []byte(schema.Schema), nil
+}
+
+func (c *Client) fetchApicurio(ctx context.Context, location string) (Format, []byte, error) {
+	resp, err := c.get(ctx, location, "*/*")
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+	source, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, err
+	}
+	format := Format(resp.Header.Get(apicurioTypeHeader))
+	if len(format) == 0 {
+		format = guessFormat(location, source)
+	}
+	return format, source, nil
+}
+
+// get requests the location, returning the response if successful
+func (c *Client) get(ctx context.Context, location, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if len(c.Token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	} else if len(c.Username) > 0 {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("get %s failed: %s %s", location, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
+
+func (c *Client) loader() resolver.Loader {
+	if c.Loader == nil {
+		return resolver.NewLoader("", c.HTTPClient)
+	}
+	return c.Loader
+}
+
+// guessFormat guesses the format of a schema from the extension of its location, or from its content
+func guessFormat(location string, source []byte) Format {
+	switch path.Ext(location) {
+	case ".avsc":
+		return FormatAvro
+	case ".proto":
+		return FormatProtobuf
+	case ".json", ".yaml", ".yml":
+		if isAvro(source) {
+			return FormatAvro
+		}
+		return FormatJSONSchema
+	}
+	trimmed := bytes.TrimSpace(source)
+	if !bytes.HasPrefix(trimmed, []byte("{")) && !bytes.HasPrefix(trimmed, []byte("[")) &&
+		(bytes.Contains(trimmed, []byte("syntax")) || bytes.Contains(trimmed, []byte("message "))) {
+		return FormatProtobuf
+	}
+	if isAvro(source) {
+		return FormatAvro
+	}
+	return FormatJSONSchema
+}
@@ -0,0 +1,197 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderAvro = `{
+  "type": "record",
+  "name": "OrderCreated",
+  "namespace": "com.acme.orders",
+  "fields": [
+    {"name": "id", "type": "string"},
+    {"name": "total", "type": "double"},
+    {"name": "note", "type": ["null", "string"], "default": null}
+  ]
+}`
+
+const shipmentProto = `syntax = "proto3";
+package acme.shipping;
+
+message Address {
+  string city = 1;
+}
+
+message Shipped {
+  string order_id = 1;
+  Address address = 2;
+}
+`
+
+const paymentJSONSchema = `{
+  "type": "object",
+  "required": ["orderId", "amount"],
+  "properties": {"orderId": {"type": "string"}, "amount": {"type": "number", "minimum": 0}}
+}`
+
+func newRegistry(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/subjects/orders-value/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", confluentContentType)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"subject": "orders-value", "version": 3, "id": 7, "schema": orderAvro})
+	})
+	mux.HandleFunc("/schemas/ids/8", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"schemaType": "PROTOBUF", "schema": shipmentProto})
+	})
+	mux.HandleFunc("/apis/registry/v2/groups/acme/artifacts/payment/versions/2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(apicurioTypeHeader, "JSON")
+		_, _ = w.Write([]byte(paymentJSONSchema))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetch(t *testing.T) {
+	server := newRegistry(t)
+	client := &Client{Token: "secret"}
+
+	schema, err := client.Fetch(context.Background(), server.URL+"/subjects/orders-value/versions/latest")
+	require.NoError(t, err)
+	assert.Equal(t, FormatAvro, schema.Format)
+	cached, err := client.Fetch(context.Background(), server.URL+"/subjects/orders-value/versions/latest")
+	require.NoError(t, err)
+	assert.Same(t, schema, cached)
+
+	schema, err = client.Fetch(context.Background(), server.URL+"/schemas/ids/8#Shipped")
+	require.NoError(t, err)
+	assert.Equal(t, FormatProtobuf, schema.Format)
+	assert.Equal(t, "acme.shipping.Shipped", schema.message.GetFullyQualifiedName())
+	schema, err = client.Fetch(context.Background(), server.URL+"/schemas/ids/8")
+	require.NoError(t, err)
+	assert.Equal(t, "acme.shipping.Address", schema.message.GetFullyQualifiedName())
+	_, err = client.Fetch(context.Background(), server.URL+"/schemas/ids/8#Missing")
+	assert.EqualError(t, err, server.URL+"/schemas/ids/8#Missing: message Missing not defined")
+
+	schema, err = client.Fetch(context.Background(), server.URL+"/apis/registry/v2/groups/acme/artifacts/payment/versions/2")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSONSchema, schema.Format)
+
+	_, err = client.Fetch(context.Background(), server.URL+"/subjects/missing-value/versions/1")
+	assert.Error(t, err)
+
+	client.Loader = resolver.LoaderFunc(func(uri string) ([]byte, error) {
+		return []byte(orderAvro), nil
+	})
+	schema, err = client.Fetch(context.Background(), "schemas/order.json")
+	require.NoError(t, err)
+	assert.Equal(t, FormatAvro, schema.Format)
+}
+
+func TestFetchSharesCompiledSchemas(t *testing.T) {
+	loader := resolver.LoaderFunc(func(uri string) ([]byte, error) {
+		return []byte(shipmentProto), nil
+	})
+	schema, err := (&Client{Loader: loader}).Fetch(context.Background(), "schemas/shipping.proto#Shipped")
+	require.NoError(t, err)
+	other, err := (&Client{Loader: loader}).Fetch(context.Background(), "schemas/v2/shipping.proto#Shipped")
+	require.NoError(t, err)
+	assert.Equal(t, "schemas/shipping.proto#Shipped", schema.URI)
+	assert.Equal(t, "schemas/v2/shipping.proto#Shipped", other.URI)
+	assert.Same(t, schema.message, other.message)
+}
+
+func TestValidateSamples(t *testing.T) {
+	server := newRegistry(t)
+	workflow, err := parser.FromYAMLSource([]byte(`
+id: order
+name: Order
+version: '1.0'
+specVersion: '0.8'
+start: Wait
+events:
+- name: OrderCreated
+  source: orders
+  type: order.created
+  metadata:
+    dataSchema: ` + server.URL + `/subjects/orders-value/versions/latest
+- name: OrderShipped
+  source: shipping
+  type: order.shipped
+  metadata:
+    dataSchema: ` + server.URL + `/schemas/ids/8#acme.shipping.Shipped
+- name: OrderPaid
+  source: payments
+  type: order.paid
+  metadata:
+    dataSchema: ` + server.URL + `/apis/registry/v2/groups/acme/artifacts/payment/versions/2
+- name: OrderCancelled
+  source: orders
+  type: order.cancelled
+states:
+- name: Wait
+  type: event
+  onEvents:
+  - eventRefs: [OrderCreated, OrderShipped, OrderPaid, OrderCancelled]
+  end: true
+`))
+	require.NoError(t, err)
+	client := &Client{Token: "secret"}
+
+	problems, err := client.ValidateSamples(context.Background(), workflow, map[string][]interface{}{
+		"OrderCreated": {
+			map[string]interface{}{"id": "1", "total": 10, "note": map[string]interface{}{"string": "gift"}},
+			map[string]interface{}{"id": 1, "discount": 5},
+		},
+		"OrderShipped": {
+			map[string]interface{}{"orderId": "1", "address": map[string]interface{}{"city": "Oslo"}},
+			map[string]interface{}{"orderId": "1", "address": map[string]interface{}{"zip": "0150"}},
+		},
+		"OrderPaid": {
+			map[string]interface{}{"orderId": "1", "amount": -1},
+		},
+	})
+	require.NoError(t, err)
+	var messages []string
+	for _, problem := range problems {
+		messages = append(messages, problem.String())
+	}
+	require.Len(t, messages, 5)
+	assert.Equal(t, []string{
+		"event OrderCreated sample 1: id: expected string, got a number",
+		"event OrderCreated sample 1: missing field total",
+		"event OrderCreated sample 1: field discount is not defined in record OrderCreated",
+		"event OrderPaid sample 0: amount: -1 is less than the minimum 0",
+	}, messages[:4])
+	assert.Contains(t, messages[4], "event OrderShipped sample 1: not a valid acme.shipping.Shipped:")
+	assert.Contains(t, messages[4], "zip")
+
+	_, err = client.ValidateSamples(context.Background(), workflow, map[string][]interface{}{"OrderCancelled": {nil}})
+	assert.EqualError(t, err, "event OrderCancelled has no dataSchema metadata")
+	_, err = client.ValidateSamples(context.Background(), workflow, map[string][]interface{}{"OrderLost": {nil}})
+	assert.Error(t, err)
+}
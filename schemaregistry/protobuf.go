@@ -0,0 +1,74 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaregistry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/serverlessworkflow/sdk-go/v2/jsonschema"
+)
+
+// protobufFile name of the schema file given to the parser
+const protobufFile = "schema.proto"
+
+// parseProtobuf parses a Protobuf schema, returning the given message or the first message of the file if empty. Only
+// the well-known types can be imported.
+func parseProtobuf(source []byte, message string) (*desc.MessageDescriptor, error) {
+	parser := protoparse.Parser{
+		Accessor: func(filename string) (io.ReadCloser, error) {
+			if filename != protobufFile {
+				return nil, fmt.Errorf("import %s not supported", filename)
+			}
+			return ioutil.NopCloser(bytes.NewReader(source)), nil
+		},
+	}
+	files, err := parser.ParseFiles(protobufFile)
+	if err != nil {
+		return nil, err
+	}
+	file := files[0]
+	if len(message) == 0 {
+		if len(file.GetMessageTypes()) == 0 {
+			return nil, fmt.Errorf("no message defined")
+		}
+		return file.GetMessageTypes()[0], nil
+	}
+	if md := file.FindMessage(message); md != nil {
+		return md, nil
+	}
+	if md := file.FindMessage(file.GetPackage() + "." + message); md != nil {
+		return md, nil
+	}
+	return nil, fmt.Errorf("message %s not defined", message)
+}
+
+// validateProtobuf checks that the payload is the JSON mapping of the message
+func validateProtobuf(message *desc.MessageDescriptor, payload interface{}) []jsonschema.ValidationError {
+	data, err := json.Marshal(payload)
+	if err == nil {
+		err = dynamic.NewMessage(message).UnmarshalJSON(data)
+	}
+	if err != nil {
+		return []jsonschema.ValidationError{{Message: fmt.Sprintf("not a valid %s: %v", message.GetFullyQualifiedName(), err)}}
+	}
+	return nil
+}
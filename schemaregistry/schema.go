@@ -0,0 +1,97 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaregistry
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/serverlessworkflow/sdk-go/v2/internal/cache"
+	"github.com/serverlessworkflow/sdk-go/v2/jsonschema"
+)
+
+// compiled schemas keyed by the hash of their format, source and message, shared by the clients so that the same
+// schema served under different URIs, or fetched again by another client, is compiled once
+var compiled = cache.New(256)
+
+// Schema compiled data schema of an event
+type Schema struct {
+	// URI the schema was resolved from
+	URI    string
+	Format Format
+	// Source of the schema as served by the registry
+	Source []byte
+
+	json    *jsonschema.Schema
+	avro    *avroSchema
+	message *desc.MessageDescriptor
+}
+
+// compile parses the source of the schema in the given format, message naming the Protobuf message of the payloads
+func compile(uri string, format Format, source []byte, message string) (*Schema, error) {
+	key := cache.Key([]byte(format), source, []byte(message))
+	shared, err := compiled.GetOrCompile(key, func() (interface{}, error) {
+		return compileSource(format, source, message)
+	})
+	if err != nil {
+		return nil, err
+	}
+	schema := *shared.(*Schema)
+	schema.URI = uri
+	return &schema, nil
+}
+
+// compileSource parses the source of the schema in the given format
+func compileSource(format Format, source []byte, message string) (*Schema, error) {
+	schema := &Schema{Format: format, Source: source}
+	var err error
+	switch format {
+	case FormatJSONSchema:
+		schema.json, err = jsonschema.Compile(source)
+	case FormatAvro:
+		schema.avro, err = parseAvro(source)
+	case FormatProtobuf:
+		schema.message, err = parseProtobuf(source, message)
+	default:
+		err = fmt.Errorf("schema format %s not supported", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// Validate checks a payload, decoded from JSON, against the schema. The payloads of Avro schemas are either the JSON
+// encoding of Avro or plain JSON, without the type names wrapping the values of the unions, and the payloads of
+// Protobuf schemas are the JSON mapping of the message.
+func (s *Schema) Validate(payload interface{}) []jsonschema.ValidationError {
+	switch {
+	case s.json != nil:
+		return s.json.Validate(payload)
+	case s.avro != nil:
+		// the Avro validation expects the types produced by json.Unmarshal
+		data, err := json.Marshal(payload)
+		if err == nil {
+			err = json.Unmarshal(data, &payload)
+		}
+		if err != nil {
+			return []jsonschema.ValidationError{{Message: err.Error()}}
+		}
+		return s.avro.validate(payload)
+	default:
+		return validateProtobuf(s.message, payload)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/serverlessworkflow/sdk-go/v2/codegen"
+	"github.com/serverlessworkflow/sdk-go/v2/events"
+	"github.com/serverlessworkflow/sdk-go/v2/jsonschema"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// Problem violation of the data schema of an event by one of its sample payloads
+type Problem struct {
+	Event string
+	// Sample index of the sample payload of the event
+	Sample int
+	// Schema URI of the data schema
+	Schema string
+	jsonschema.ValidationError
+}
+
+// String ...
+func (p Problem) String() string {
+	return fmt.Sprintf("event %s sample %d: %s", p.Event, p.Sample, p.ValidationError.Error())
+}
+
+// ValidateSamples validates the sample payloads, by event name, against the data schemas referenced by the
+// 'dataSchema' metadata of the events of the workflow. The events without samples aren't checked, samples of events
+// not defined or without data schema are an error.
+func (c *Client) ValidateSamples(ctx context.Context, workflow *model.Workflow, samples map[string][]interface{}) ([]Problem, error) {
+	names := make([]string, 0, len(samples))
+	for name := range samples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var problems []Problem
+	for _, name := range names {
+		event, err := events.FindEvent(workflow, name)
+		if err != nil {
+			return nil, err
+		}
+		uri, _ := event.Metadata[codegen.EventDataSchemaKey].(string)
+		if len(uri) == 0 {
+			return nil, fmt.Errorf("event %s has no %s metadata", name, codegen.EventDataSchemaKey)
+		}
+		schema, err := c.Fetch(ctx, uri)
+		if err != nil {
+			return nil, fmt.Errorf("event %s: %w", name, err)
+		}
+		for i, sample := range samples[name] {
+			for _, violation := range schema.Validate(sample) {
+				problems = append(problems, Problem{Event: name, Sample: i, Schema: uri, ValidationError: violation})
+			}
+		}
+	}
+	return problems, nil
+}
@@ -0,0 +1,265 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serializer
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/internal/document"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// Format serialization format of workflow definitions
+type Format string
+
+const (
+	// FormatJSON ...
+	FormatJSON Format = "json"
+	// FormatYAML ...
+	FormatYAML Format = "yaml"
+
+	defaultIndent = 2
+)
+
+// opaqueKeys properties holding user data, written as they are
+var opaqueKeys = map[string]bool{
+	"arguments":         true,
+	"data":              true,
+	"metadata":          true,
+	"constants":         true,
+	"contextAttributes": true,
+	"parameters":        true,
+}
+
+// Options options to serialize workflow definitions. Empty properties are never written.
+type Options struct {
+	// Format of the output. Default is JSON
+	Format Format
+	// Indent number of spaces used to indent JSON. Default is 2
+	Indent int
+	// Shorthand writes the short form of the definitions that support it, e.g. a transition as the next state name
+	Shorthand bool
+	// Normalize omits the properties set to their default value, e.g. the 'jq' expression language
+	Normalize bool
+	// SpecVersion target specification version. Default is the workflow version. Converting to another version
+	// is not supported yet
+	SpecVersion string
+}
+
+// CanonicalOptions options of the canonical format of workflow definitions: the short forms and no default values
+func CanonicalOptions(format Format) Options {
+	return Options{Format: format, Indent: defaultIndent, Shorthand: true, Normalize: true}
+}
+
+// FormatFromPath returns the format of the given file based on its extension
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	}
+	return "", fmt.Errorf("format of %s unknown, supported extensions are .json, .yaml and .yml", path)
+}
+
+// Marshal serializes the workflow definition with the given options
+func Marshal(workflow *model.Workflow, opts Options) ([]byte, error) {
+	if len(opts.SpecVersion) > 0 && opts.SpecVersion != workflow.SpecVersion {
+		return nil, fmt.Errorf("conversion from spec version %s to %s is not supported", workflow.SpecVersion, opts.SpecVersion)
+	}
+	// the generated method is called directly, json.Marshal would validate and compact its JSON again
+	data, err := workflow.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	tree, err := document.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	root, _ := prune(tree, "").(document.Object)
+	if opts.Normalize {
+		root = normalize(root)
+	}
+	if opts.Shorthand {
+		root = shorthand(root, "").(document.Object)
+	}
+
+	buf := new(bytes.Buffer)
+	switch opts.Format {
+	case FormatYAML:
+		err = document.WriteYAML(buf, root)
+	case FormatJSON, "":
+		indent := opts.Indent
+		if indent <= 0 {
+			indent = defaultIndent
+		}
+		err = document.WriteJSON(buf, root, strings.Repeat(" ", indent), 0)
+		buf.WriteString("\n")
+	default:
+		err = fmt.Errorf("format %s not supported", opts.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// prune removes the null and empty values the model writes for the properties it doesn't omit
+func prune(value interface{}, key string) interface{} {
+	if opaqueKeys[key] {
+		return value
+	}
+	switch v := value.(type) {
+	case document.Object:
+		result := make(document.Object, 0, len(v))
+		for _, m := range v {
+			pruned := prune(m.Value, m.Key)
+			if isEmpty(pruned) {
+				if m.Key == "end" {
+					// an end without properties only ends the execution path
+					result = append(result, document.Member{Key: m.Key, Value: true})
+				}
+				continue
+			}
+			result = append(result, document.Member{Key: m.Key, Value: pruned})
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = prune(item, "")
+		}
+		return result
+	}
+	return value
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(v) == 0
+	case document.Object:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// defaults default values of the properties, by definition kind
+var defaults = map[string]map[string]interface{}{
+	"workflow":               {"expressionLang": model.DefaultExpressionLang},
+	"functions":              {"type": string(model.FunctionTypeREST)},
+	"events":                 {"kind": string(model.EventKindConsumed)},
+	"auth":                   {"scheme": string(model.AuthTypeBasic)},
+	"dataInputSchema":        {"failOnValidationErrors": true},
+	"onEvents":               {"actionMode": "sequential"},
+	model.StateTypeOperation: {"actionMode": "sequential"},
+	model.StateTypeEvent:     {"exclusive": true},
+	model.StateTypeParallel:  {"completionType": string(model.CompletionTypeAllOf)},
+	model.StateTypeForEach:   {"mode": string(model.ForEachModeTypeParallel)},
+}
+
+// normalize removes the properties set to their default value
+func normalize(root document.Object) document.Object {
+	root = withoutDefaults(root, "workflow")
+	for i, m := range root {
+		switch m.Key {
+		case "functions", "events", "auth":
+			kind := m.Key
+			root[i].Value = mapObjects(m.Value, func(o document.Object) document.Object { return withoutDefaults(o, kind) })
+		case "dataInputSchema":
+			if o, ok := m.Value.(document.Object); ok {
+				root[i].Value = withoutDefaults(o, "dataInputSchema")
+			}
+		case "states":
+			root[i].Value = mapObjects(m.Value, func(state document.Object) document.Object {
+				stateType, _ := state.Get("type")
+				state = withoutDefaults(state, fmt.Sprint(stateType))
+				for j, sm := range state {
+					if sm.Key == "onEvents" {
+						state[j].Value = mapObjects(sm.Value, func(o document.Object) document.Object { return withoutDefaults(o, "onEvents") })
+					}
+				}
+				return state
+			})
+		}
+	}
+	return root
+}
+
+func withoutDefaults(o document.Object, kind string) document.Object {
+	for key, value := range defaults[kind] {
+		if current, ok := o.Get(key); ok && current == value {
+			o = o.Without(key)
+		}
+	}
+	return o
+}
+
+func mapObjects(value interface{}, f func(document.Object) document.Object) interface{} {
+	array, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+	for i, item := range array {
+		if o, ok := item.(document.Object); ok {
+			array[i] = f(o)
+		}
+	}
+	return array
+}
+
+// shorthands definitions that can be written as a single string, by property name, and the property holding the string
+var shorthands = map[string]string{
+	"start":       "stateName",
+	"transition":  "nextState",
+	"functionRef": "refName",
+	"subFlowRef":  "workflowId",
+}
+
+// shorthand replaces the definitions by their short form where possible
+func shorthand(value interface{}, key string) interface{} {
+	if opaqueKeys[key] {
+		return value
+	}
+	switch v := value.(type) {
+	case document.Object:
+		if property, ok := shorthands[key]; ok && len(v) == 1 && v[0].Key == property {
+			return v[0].Value
+		}
+		if key == "dataInputSchema" {
+			failOnErrors, set := v.Get("failOnValidationErrors")
+			if schema, ok := v.Get("schema"); ok && (!set || failOnErrors == true) {
+				return schema
+			}
+		}
+		for i, m := range v {
+			v[i].Value = shorthand(m.Value, m.Key)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = shorthand(item, "")
+		}
+		return v
+	}
+	return value
+}
@@ -0,0 +1,117 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serializer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	files, err := filepath.Glob("../parser/testdata/workflows/*.sw.*")
+	assert.NoError(t, err)
+	options := []Options{
+		{},
+		{Format: FormatYAML},
+		{Shorthand: true, Normalize: true},
+		{Format: FormatYAML, Shorthand: true, Normalize: true, Indent: 4},
+	}
+	for _, file := range files {
+		workflow, err := parser.FromFile(file)
+		if err != nil {
+			// references files relative to the parser directory
+			continue
+		}
+		for _, opts := range options {
+			data, err := Marshal(workflow, opts)
+			assert.NoError(t, err)
+			var converted *model.Workflow
+			if opts.Format == FormatYAML {
+				converted, err = parser.FromYAMLSource(data)
+			} else {
+				converted, err = parser.FromJSONSource(data)
+			}
+			if !assert.NoError(t, err, "%s %+v", file, opts) {
+				continue
+			}
+			// empty definitions and, when normalizing, default values are omitted: the model can differ but must
+			// serialize the same
+			again, err := Marshal(converted, opts)
+			assert.NoError(t, err)
+			assert.Equal(t, string(data), string(again), "%s %+v", file, opts)
+		}
+	}
+}
+
+func TestMarshalPreservesModel(t *testing.T) {
+	workflow, err := parser.FromFile("../parser/testdata/workflows/eventbasedgreetingnonexclusive.sw.json")
+	assert.NoError(t, err)
+	data, err := Marshal(workflow, Options{Format: FormatYAML, Shorthand: true})
+	assert.NoError(t, err)
+	converted, err := parser.FromYAMLSource(data)
+	assert.NoError(t, err)
+	assert.Equal(t, workflow, converted)
+}
+
+func TestMarshal(t *testing.T) {
+	workflow, err := parser.FromFile("../parser/testdata/workflows/greetings.sw.yaml")
+	assert.NoError(t, err)
+
+	data, err := Marshal(workflow, Options{Format: FormatYAML, Shorthand: true, Normalize: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `id: greeting
+name: Greeting Workflow
+description: Greet Someone
+version: "1.0"
+start: Greet
+specVersion: "0.7"
+states:
+- name: Greet
+  type: operation
+  end:
+    terminate: true
+  actions:
+  - functionRef: greetingFunction
+functions:
+- name: greetingFunction
+  operation: file://myapis/greetingapis.json#greeting
+`, string(data))
+
+	data, err = Marshal(workflow, Options{Indent: 1})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "{\n \"id\": \"greeting\",\n")
+	assert.Contains(t, string(data), `"expressionLang": "jq"`)
+	assert.Contains(t, string(data), `"actionMode": "sequential"`)
+
+	_, err = Marshal(workflow, Options{SpecVersion: "0.8"})
+	assert.Error(t, err)
+	_, err = Marshal(workflow, Options{Format: "toml"})
+	assert.Error(t, err)
+}
+
+func TestFormatFromPath(t *testing.T) {
+	format, err := FormatFromPath("workflow.sw.YML")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatYAML, format)
+	format, err = FormatFromPath("workflow.json")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+	_, err = FormatFromPath("workflow.toml")
+	assert.Error(t, err)
+}
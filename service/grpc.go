@@ -0,0 +1,179 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v2/diagram"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+	"github.com/serverlessworkflow/sdk-go/v2/service/servicepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var serializerFormats = map[servicepb.Format]serializer.Format{
+	servicepb.Format_FORMAT_JSON: serializer.FormatJSON,
+	servicepb.Format_FORMAT_YAML: serializer.FormatYAML,
+}
+
+// NewGRPCServer returns the implementation of the gRPC service, to register with
+// servicepb.RegisterWorkflowServiceServer. The invalid workflows are rejected by Convert and Graph with the code
+// InvalidArgument and the ValidateResponse in the details of the status, the size of the workflows is limited by the
+// MaxBodySize of the options besides the limits of the grpc.Server.
+func NewGRPCServer(opts Options) servicepb.WorkflowServiceServer {
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = DefaultMaxBodySize
+	}
+	return &grpcServer{service: &service{opts: opts}}
+}
+
+type grpcServer struct {
+	servicepb.UnimplementedWorkflowServiceServer
+	service *service
+}
+
+func (g *grpcServer) Validate(ctx context.Context, request *servicepb.ValidateRequest) (*servicepb.ValidateResponse, error) {
+	_, response, err := g.check(ctx, request.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (g *grpcServer) Convert(ctx context.Context, request *servicepb.ConvertRequest) (*servicepb.ConvertResponse, error) {
+	workflow, err := g.valid(ctx, request.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	if request.Indent < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid indent %d", request.Indent)
+	}
+	opts := serializer.Options{
+		Format:      serializerFormats[request.Format],
+		Shorthand:   request.Shorthand,
+		Normalize:   request.Normalize,
+		SpecVersion: request.SpecVersion,
+		Indent:      int(request.Indent),
+	}
+	switch request.Format {
+	case servicepb.Format_FORMAT_UNSPECIFIED:
+		opts.Format = otherFormat(request.Workflow)
+	case servicepb.Format_FORMAT_JSON, servicepb.Format_FORMAT_YAML:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown format %d", request.Format)
+	}
+
+	data, err := serializer.Marshal(workflow, opts)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	response := &servicepb.ConvertResponse{Workflow: data, Format: servicepb.Format_FORMAT_JSON}
+	if opts.Format == serializer.FormatYAML {
+		response.Format = servicepb.Format_FORMAT_YAML
+	}
+	return response, nil
+}
+
+func (g *grpcServer) Graph(ctx context.Context, request *servicepb.GraphRequest) (*servicepb.GraphResponse, error) {
+	workflow, err := g.valid(ctx, request.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	opts := diagram.Options{Highlight: request.Highlight, States: request.States}
+	var render func(*model.Workflow, diagram.Options) ([]byte, error)
+	switch request.Format {
+	case servicepb.GraphFormat_GRAPH_FORMAT_UNSPECIFIED:
+		graph := diagram.New(workflow)
+		if len(opts.States) > 0 {
+			if graph, err = graph.Subset(opts.States); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		}
+		return graphMessage(graphResponse(graph)), nil
+	case servicepb.GraphFormat_GRAPH_FORMAT_MERMAID:
+		render = diagram.Mermaid
+	case servicepb.GraphFormat_GRAPH_FORMAT_DOT:
+		render = diagram.DOT
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown format %d", request.Format)
+	}
+	data, err := render(workflow, opts)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &servicepb.GraphResponse{Diagram: string(data)}, nil
+}
+
+// check parses and validates the workflow sent, failing with a status error if it's missing or too large
+func (g *grpcServer) check(ctx context.Context, source []byte) (*model.Workflow, *servicepb.ValidateResponse, error) {
+	if int64(len(source)) > g.service.opts.MaxBodySize {
+		return nil, nil, status.Errorf(codes.ResourceExhausted, "workflows are limited to %d bytes", g.service.opts.MaxBodySize)
+	}
+	if len(bytes.TrimSpace(source)) == 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "no workflow sent")
+	}
+	workflow, results, err := g.service.check(ctx, source)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	return workflow, validateMessage(results), nil
+}
+
+// valid returns the workflow sent, failing with the code InvalidArgument and the validation results in the details if
+// it's invalid
+func (g *grpcServer) valid(ctx context.Context, source []byte) (*model.Workflow, error) {
+	workflow, response, err := g.check(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Valid {
+		invalid := status.New(codes.InvalidArgument, fmt.Sprintf("invalid workflow: %s", response.Results[0].Message))
+		if detailed, err := invalid.WithDetails(response); err == nil {
+			invalid = detailed
+		}
+		return nil, invalid.Err()
+	}
+	return workflow, nil
+}
+
+func validateMessage(results []ValidationResult) *servicepb.ValidateResponse {
+	response := &servicepb.ValidateResponse{Valid: len(results) == 0}
+	for _, result := range results {
+		response.Results = append(response.Results, &servicepb.ValidationResult{
+			Path:        result.Path,
+			Message:     result.Message,
+			Line:        int32(result.Line),
+			Column:      int32(result.Column),
+			Policy:      result.Policy,
+			Suggestions: result.Suggestions,
+		})
+	}
+	return response
+}
+
+func graphMessage(graph *GraphResponse) *servicepb.GraphResponse {
+	response := &servicepb.GraphResponse{}
+	for _, node := range graph.Nodes {
+		response.Nodes = append(response.Nodes, &servicepb.GraphNode{Id: node.ID, Kind: string(node.Kind), Label: node.Label, Type: string(node.Type)})
+	}
+	for _, edge := range graph.Edges {
+		response.Edges = append(response.Edges, &servicepb.GraphEdge{From: edge.From, To: edge.To, Kind: string(edge.Kind), Label: edge.Label})
+	}
+	return response
+}
@@ -0,0 +1,122 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/service/servicepb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dial serves the gRPC service in memory, returning a client connected to it
+func dial(t *testing.T, opts Options) servicepb.WorkflowServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	servicepb.RegisterWorkflowServiceServer(server, NewGRPCServer(opts))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return servicepb.NewWorkflowServiceClient(conn)
+}
+
+func TestGRPCValidate(t *testing.T) {
+	client := dial(t, Options{})
+	response, err := client.Validate(context.Background(), &servicepb.ValidateRequest{Workflow: []byte(greetingWorkflow)})
+	require.NoError(t, err)
+	assert.True(t, response.Valid)
+	assert.Empty(t, response.Results)
+
+	broken := strings.Replace(greetingWorkflow, "functionRef: greetingFunction", "functionRef: greetFunction", 1)
+	response, err = client.Validate(context.Background(), &servicepb.ValidateRequest{Workflow: []byte(broken)})
+	require.NoError(t, err)
+	assert.False(t, response.Valid)
+	require.Len(t, response.Results, 1)
+	result := response.Results[0]
+	assert.Equal(t, "states[0].actions[0].functionRef.refName", result.Path)
+	assert.Equal(t, []string{"greetingFunction"}, result.Suggestions)
+	assert.Equal(t, int32(13), result.Line)
+	assert.Equal(t, int32(9), result.Column)
+
+	_, err = client.Validate(context.Background(), &servicepb.ValidateRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = dial(t, Options{MaxBodySize: 100}).Validate(context.Background(), &servicepb.ValidateRequest{Workflow: []byte(greetingWorkflow)})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestGRPCConvert(t *testing.T) {
+	client := dial(t, Options{})
+	response, err := client.Convert(context.Background(), &servicepb.ConvertRequest{Workflow: []byte(greetingWorkflow)})
+	require.NoError(t, err)
+	assert.Equal(t, servicepb.Format_FORMAT_JSON, response.Format)
+	assert.Contains(t, string(response.Workflow), `"id": "greeting"`)
+
+	response, err = client.Convert(context.Background(), &servicepb.ConvertRequest{Workflow: response.Workflow})
+	require.NoError(t, err)
+	assert.Equal(t, servicepb.Format_FORMAT_YAML, response.Format)
+	assert.Contains(t, string(response.Workflow), "id: greeting\n")
+
+	response, err = client.Convert(context.Background(), &servicepb.ConvertRequest{Workflow: []byte(greetingWorkflow), Format: servicepb.Format_FORMAT_YAML, Shorthand: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(response.Workflow), "functionRef: greetingFunction\n")
+
+	_, err = client.Convert(context.Background(), &servicepb.ConvertRequest{Workflow: []byte(greetingWorkflow), Indent: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Convert(context.Background(), &servicepb.ConvertRequest{Workflow: []byte(strings.Replace(greetingWorkflow, "start: Greet", "start: Greeting", 1))})
+	invalid := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, invalid.Code())
+	require.Len(t, invalid.Details(), 1)
+	details, ok := invalid.Details()[0].(*servicepb.ValidateResponse)
+	require.True(t, ok)
+	assert.False(t, details.Valid)
+	assert.Equal(t, "start.stateName", details.Results[0].Path)
+}
+
+func TestGRPCGraph(t *testing.T) {
+	client := dial(t, Options{})
+	response, err := client.Graph(context.Background(), &servicepb.GraphRequest{Workflow: []byte(greetingWorkflow)})
+	require.NoError(t, err)
+	require.Len(t, response.Nodes, 3)
+	assert.Equal(t, "Greet", response.Nodes[1].Id)
+	assert.Equal(t, "operation", response.Nodes[1].Type)
+	require.Len(t, response.Edges, 2)
+	assert.Equal(t, "start", response.Edges[0].From)
+	assert.Equal(t, "Greet", response.Edges[0].To)
+	assert.Empty(t, response.Diagram)
+
+	response, err = client.Graph(context.Background(), &servicepb.GraphRequest{Workflow: []byte(greetingWorkflow), Format: servicepb.GraphFormat_GRAPH_FORMAT_MERMAID})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(response.Diagram, "flowchart"), response.Diagram)
+	assert.Empty(t, response.Nodes)
+
+	_, err = client.Graph(context.Background(), &servicepb.GraphRequest{Workflow: []byte(greetingWorkflow), States: []string{"Missing"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "state Missing is not defined", status.Convert(err).Message())
+}
@@ -0,0 +1,349 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package service exposes the validation, conversion and diagrams of the SDK as an HTTP or gRPC service, so that the
+// tools written in other languages reuse this implementation.
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/annotation"
+	"github.com/serverlessworkflow/sdk-go/v2/diagram"
+	"github.com/serverlessworkflow/sdk-go/v2/integrity"
+	"github.com/serverlessworkflow/sdk-go/v2/metrics"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/policy"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+// DefaultMaxBodySize maximum size of the workflows posted if not set in the options
+const DefaultMaxBodySize = 3 << 20
+
+// Options ...
+type Options struct {
+	// Policies the workflows must follow, besides the SDK validation
+	Policies []policy.Policy
+	// MaxBodySize maximum size in bytes of the workflows posted, DefaultMaxBodySize if zero
+	MaxBodySize int64
+	// Limits of the parsing of the workflows posted, e.g. their nesting depth and states, none by default
+	Limits parser.Limits
+	// Metrics records the parses of the workflows posted if not nil, e.g. a metrics.Registry
+	Metrics metrics.Recorder
+}
+
+// ValidationResult error found in a workflow
+type ValidationResult struct {
+	// Path JSON path of the invalid property if known, e.g. 'states[0].transition.nextState'
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+	// Line and Column of the invalid property in the workflow posted, zero if unknown
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+	// Policy name of the policy broken, empty for the SDK validation errors
+	Policy string `json:"policy,omitempty"`
+	// Suggestions defined names likely meant by a reference to an undefined name, the closest first
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// ValidationResponse response of the validation of a workflow
+type ValidationResponse struct {
+	Valid   bool               `json:"valid"`
+	Results []ValidationResult `json:"results"`
+}
+
+// ErrorResponse response of the requests that failed
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// GraphResponse control flow graph of a workflow, see diagram.Graph
+type GraphResponse struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode ...
+type GraphNode struct {
+	ID    string           `json:"id"`
+	Kind  diagram.NodeKind `json:"kind"`
+	Label string           `json:"label"`
+	Type  model.StateType  `json:"type,omitempty"`
+}
+
+// GraphEdge ...
+type GraphEdge struct {
+	From  string           `json:"from"`
+	To    string           `json:"to"`
+	Kind  diagram.EdgeKind `json:"kind"`
+	Label string           `json:"label,omitempty"`
+}
+
+// NewHandler returns the http.Handler of the service. The workflows are posted as JSON or YAML to the endpoints:
+//
+//	POST /validate  validates the workflow, returning a ValidationResponse
+//	POST /convert   converts the workflow, with the query parameters format (json or yaml, the other format than the
+//	                workflow by default), shorthand, normalize, specVersion and indent, see serializer.Options
+//	POST /graph     returns the control flow graph of the workflow, with the query parameter format, json for a
+//	                GraphResponse by default, mermaid or dot, and the states and highlight parameters, see diagram.Options
+//
+// The invalid workflows are rejected by /convert and /graph with the status 422 and a ValidationResponse, the other
+// errors are returned as an ErrorResponse.
+func NewHandler(opts Options) http.Handler {
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = DefaultMaxBodySize
+	}
+	s := &service{opts: opts}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/validate", s.post(s.validate))
+	mux.HandleFunc("/convert", s.post(s.convert))
+	mux.HandleFunc("/graph", s.post(s.graph))
+	return mux
+}
+
+type service struct {
+	opts Options
+}
+
+// post returns the handler reading the workflow posted, parsing it and passing it to the endpoint handler with its
+// validation results
+func (s *service) post(handle func(w http.ResponseWriter, r *http.Request, source []byte, workflow *model.Workflow, results []ValidationResult)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, "workflows must be posted")
+			return
+		}
+		source, err := ioutil.ReadAll(io.LimitReader(r.Body, s.opts.MaxBodySize+1))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if int64(len(source)) > s.opts.MaxBodySize {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("workflows are limited to %d bytes", s.opts.MaxBodySize))
+			return
+		}
+		if len(bytes.TrimSpace(source)) == 0 {
+			writeError(w, http.StatusBadRequest, "no workflow posted")
+			return
+		}
+
+		workflow, results, err := s.check(r.Context(), source)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		handle(w, r, source, workflow, results)
+	}
+}
+
+// check parses the workflow and validates it against the SDK and the policies, returning a nil workflow if it can't
+// be parsed. The error is returned if the policies can't be evaluated.
+func (s *service) check(ctx context.Context, source []byte) (*model.Workflow, []ValidationResult, error) {
+	locator := annotation.NewLocator(source)
+	workflow, err := (&parser.Parser{Limits: s.opts.Limits, Metrics: s.opts.Metrics}).FromYAMLSource(source)
+	var results []ValidationResult
+	if err != nil {
+		workflow, results = nil, parseResults(err)
+	} else {
+		for _, e := range integrity.Validate(workflow) {
+			results = append(results, ValidationResult{Path: e.Path, Message: e.Message, Suggestions: e.Suggestions})
+		}
+		violations, err := policy.Evaluate(ctx, workflow, s.opts.Policies...)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, violation := range violations {
+			results = append(results, ValidationResult{Path: violation.Path, Message: violation.Message, Policy: violation.Policy})
+		}
+	}
+	for i := range results {
+		results[i].Line, results[i].Column = locator.Locate(results[i].Path)
+	}
+	return workflow, results, nil
+}
+
+func (s *service) validate(w http.ResponseWriter, _ *http.Request, _ []byte, _ *model.Workflow, results []ValidationResult) {
+	writeJSON(w, http.StatusOK, validationResponse(results))
+}
+
+func (s *service) convert(w http.ResponseWriter, r *http.Request, source []byte, workflow *model.Workflow, results []ValidationResult) {
+	if len(results) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, validationResponse(results))
+		return
+	}
+	query := r.URL.Query()
+	opts := serializer.Options{Format: serializer.Format(query.Get("format")), SpecVersion: query.Get("specVersion")}
+	switch opts.Format {
+	case "":
+		opts.Format = otherFormat(source)
+	case serializer.FormatJSON, serializer.FormatYAML:
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown format %s, expected json or yaml", opts.Format))
+		return
+	}
+	var err error
+	if opts.Shorthand, err = boolParameter(query.Get("shorthand")); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid shorthand: "+err.Error())
+		return
+	}
+	if opts.Normalize, err = boolParameter(query.Get("normalize")); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid normalize: "+err.Error())
+		return
+	}
+	if indent := query.Get("indent"); len(indent) > 0 {
+		if opts.Indent, err = strconv.Atoi(indent); err != nil || opts.Indent < 0 {
+			writeError(w, http.StatusBadRequest, "invalid indent "+indent)
+			return
+		}
+	}
+
+	data, err := serializer.Marshal(workflow, opts)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	contentType := "application/json"
+	if opts.Format == serializer.FormatYAML {
+		contentType = "application/yaml"
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(data)
+}
+
+func (s *service) graph(w http.ResponseWriter, r *http.Request, _ []byte, workflow *model.Workflow, results []ValidationResult) {
+	if len(results) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, validationResponse(results))
+		return
+	}
+	query := r.URL.Query()
+	opts := diagram.Options{Highlight: splitList(query.Get("highlight")), States: splitList(query.Get("states"))}
+	var render func(*model.Workflow, diagram.Options) ([]byte, error)
+	switch format := query.Get("format"); format {
+	case "", "json":
+		graph := diagram.New(workflow)
+		if len(opts.States) > 0 {
+			var err error
+			if graph, err = graph.Subset(opts.States); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		writeJSON(w, http.StatusOK, graphResponse(graph))
+		return
+	case "mermaid":
+		render = diagram.Mermaid
+	case "dot":
+		render = diagram.DOT
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown format %s, expected json, mermaid or dot", format))
+		return
+	}
+	data, err := render(workflow, opts)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write(data)
+}
+
+// parseResults returns the validation results of the error returned by the parser
+func parseResults(err error) []ValidationResult {
+	var schemaErr *model.SchemaError
+	if !errors.As(err, &schemaErr) {
+		return []ValidationResult{{Message: err.Error()}}
+	}
+	var results []ValidationResult
+	for _, fieldErr := range schemaErr.Errors {
+		// the embedded base workflow is an implementation detail
+		path := strings.TrimPrefix(fieldErr.NameNamespace, "BaseWorkflow.")
+		results = append(results, ValidationResult{Path: path, Message: fmt.Sprintf("failed on the '%s' validation", fieldErr.Tag)})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Path < results[j].Path })
+	return results
+}
+
+// otherFormat returns the other format than the one of the workflow source
+func otherFormat(source []byte) serializer.Format {
+	if bytes.HasPrefix(bytes.TrimSpace(source), []byte("{")) {
+		return serializer.FormatYAML
+	}
+	return serializer.FormatJSON
+}
+
+func validationResponse(results []ValidationResult) *ValidationResponse {
+	if results == nil {
+		results = []ValidationResult{}
+	}
+	return &ValidationResponse{Valid: len(results) == 0, Results: results}
+}
+
+func graphResponse(graph *diagram.Graph) *GraphResponse {
+	response := &GraphResponse{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	for _, node := range graph.Nodes {
+		n := GraphNode{ID: node.ID, Kind: node.Kind, Label: node.Label}
+		if node.State != nil {
+			n.Type = node.State.GetType()
+		}
+		response.Nodes = append(response.Nodes, n)
+	}
+	for _, edge := range graph.Edges {
+		response.Edges = append(response.Edges, GraphEdge{From: edge.From, To: edge.To, Kind: edge.Kind, Label: edge.Label})
+	}
+	return response
+}
+
+func boolParameter(value string) (bool, error) {
+	if len(value) == 0 {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, &ErrorResponse{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,166 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const greetingWorkflow = `id: greeting
+name: Greeting
+version: '1.0'
+specVersion: '0.8'
+start: Greet
+functions:
+  - name: greetingFunction
+    operation: file://myapis/greetingapis.json#greeting
+states:
+  - name: Greet
+    type: operation
+    actions:
+      - functionRef: greetingFunction
+    end: true
+`
+
+func post(t *testing.T, handler http.Handler, target, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, target, strings.NewReader(body)))
+	return recorder
+}
+
+func TestValidate(t *testing.T) {
+	handler := NewHandler(Options{})
+	recorder := post(t, handler, "/validate", greetingWorkflow)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"valid": true, "results": []}`, recorder.Body.String())
+
+	broken := strings.Replace(greetingWorkflow, "functionRef: greetingFunction", "functionRef: greetFunction", 1)
+	recorder = post(t, handler, "/validate", broken)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	response := &ValidationResponse{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), response))
+	assert.False(t, response.Valid)
+	require.Len(t, response.Results, 1)
+	result := response.Results[0]
+	assert.Equal(t, "states[0].actions[0].functionRef.refName", result.Path)
+	assert.Equal(t, []string{"greetingFunction"}, result.Suggestions)
+	assert.Equal(t, 13, result.Line)
+	assert.Equal(t, 9, result.Column)
+
+	recorder = post(t, handler, "/validate", "states: [")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	response = &ValidationResponse{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), response))
+	assert.False(t, response.Valid)
+	assert.NotEmpty(t, response.Results)
+}
+
+func TestValidatePolicies(t *testing.T) {
+	noGreeting := policy.Func(func(_ context.Context, workflow *model.Workflow) ([]policy.Violation, error) {
+		return []policy.Violation{{Policy: "naming", Path: "functions[0].name", Message: "greetings are forbidden"}}, nil
+	})
+	recorder := post(t, NewHandler(Options{Policies: []policy.Policy{noGreeting}}), "/validate", greetingWorkflow)
+	assert.JSONEq(t, `{"valid": false, "results": [
+		{"path": "functions[0].name", "message": "greetings are forbidden", "policy": "naming", "line": 7, "column": 5}
+	]}`, recorder.Body.String())
+}
+
+func TestConvert(t *testing.T) {
+	handler := NewHandler(Options{})
+	recorder := post(t, handler, "/convert", greetingWorkflow)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	converted := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &converted))
+	assert.Equal(t, "greeting", converted["id"])
+
+	recorder = post(t, handler, "/convert", recorder.Body.String())
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/yaml", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), "id: greeting\n")
+
+	recorder = post(t, handler, "/convert?format=yaml&shorthand=true", greetingWorkflow)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "functionRef: greetingFunction\n")
+
+	recorder = post(t, handler, "/convert?format=xml", greetingWorkflow)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.JSONEq(t, `{"error": "unknown format xml, expected json or yaml"}`, recorder.Body.String())
+	recorder = post(t, handler, "/convert?normalize=maybe", greetingWorkflow)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = post(t, handler, "/convert", strings.Replace(greetingWorkflow, "start: Greet", "start: Greeting", 1))
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	response := &ValidationResponse{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), response))
+	assert.False(t, response.Valid)
+	assert.Equal(t, "start.stateName", response.Results[0].Path)
+}
+
+func TestGraph(t *testing.T) {
+	handler := NewHandler(Options{})
+	recorder := post(t, handler, "/graph", greetingWorkflow)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{
+		"nodes": [
+			{"id": "start", "kind": "start", "label": "Start"},
+			{"id": "Greet", "kind": "state", "label": "Greet", "type": "operation"},
+			{"id": "end", "kind": "end", "label": "End"}
+		],
+		"edges": [
+			{"from": "start", "to": "Greet", "kind": "transition"},
+			{"from": "Greet", "to": "end", "kind": "transition"}
+		]
+	}`, recorder.Body.String())
+
+	recorder = post(t, handler, "/graph?format=mermaid", greetingWorkflow)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, strings.HasPrefix(recorder.Body.String(), "flowchart"), recorder.Body.String())
+
+	recorder = post(t, handler, "/graph?states=Missing", greetingWorkflow)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.JSONEq(t, `{"error": "state Missing is not defined"}`, recorder.Body.String())
+}
+
+func TestValidateLimits(t *testing.T) {
+	recorder := post(t, NewHandler(Options{Limits: parser.Limits{MaxDepth: 3}}), "/validate", greetingWorkflow)
+	assert.JSONEq(t, `{"valid": false, "results": [{"message": "workflow exceeds the MaxDepth limit of 3"}]}`, recorder.Body.String())
+	recorder = post(t, NewHandler(Options{Limits: parser.Limits{MaxStates: 1}}), "/validate", greetingWorkflow)
+	assert.JSONEq(t, `{"valid": true, "results": []}`, recorder.Body.String())
+}
+
+func TestRequests(t *testing.T) {
+	handler := NewHandler(Options{MaxBodySize: 100})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/validate", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	assert.Equal(t, http.MethodPost, recorder.Header().Get("Allow"))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post(t, handler, "/validate", greetingWorkflow).Code)
+	assert.Equal(t, http.StatusBadRequest, post(t, handler, "/validate", " ").Code)
+	assert.Equal(t, http.StatusNotFound, post(t, handler, "/lint", greetingWorkflow).Code)
+}
@@ -0,0 +1,156 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v2/expression"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/retry"
+)
+
+// runActions runs the actions one after the other, returning the state data with their results merged
+func (s *simulator) runActions(ctx context.Context, actions []model.Action, step *Step, data interface{}) (interface{}, error) {
+	for i, action := range actions {
+		var err error
+		if data, _, err = s.runAction(ctx, action, step, data); err != nil {
+			return nil, fmt.Errorf("actions[%d]: %w", i, err)
+		}
+	}
+	return data, nil
+}
+
+// runAction runs the action, returning the state data with its results merged and its filtered results
+func (s *simulator) runAction(ctx context.Context, action model.Action, step *Step, data interface{}) (interface{}, interface{}, error) {
+	if len(action.Name) > 0 {
+		step.Actions = append(step.Actions, action.Name)
+	}
+	input, err := expression.ActionInput(ctx, s.opts.Evaluator, &action.ActionDataFilter, data, s.vars)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var results interface{}
+	switch {
+	case len(action.FunctionRef.RefName) > 0:
+		name := action.FunctionRef.RefName
+		stub, ok := s.opts.Functions[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("function %s has no stub", name)
+		}
+		arguments, err := expression.EvaluateArguments(ctx, s.opts.Evaluator, action.FunctionRef.Arguments, input, s.vars)
+		if err != nil {
+			return nil, nil, fmt.Errorf("function %s arguments: %w", name, err)
+		}
+		if results, err = s.call(ctx, action, stub, arguments); err != nil {
+			return nil, nil, err
+		}
+	case len(action.EventRef.GetTriggerEventRef()) > 0:
+		if err := s.produce(ctx, []model.ProduceEvent{{EventRef: action.EventRef.TriggerEventRef, Data: action.EventRef.Data}}, input); err != nil {
+			return nil, nil, err
+		}
+		if len(action.EventRef.GetResultEventRef()) > 0 {
+			event, ok := s.consume(action.EventRef.ResultEventRef)
+			if !ok {
+				return nil, nil, fmt.Errorf("no event %s to consume", action.EventRef.ResultEventRef)
+			}
+			results = event.Data
+		}
+	case len(action.SubFlowRef.WorkflowID) > 0:
+		stub, ok := s.opts.Subflows[action.SubFlowRef.WorkflowID]
+		if !ok {
+			return nil, nil, fmt.Errorf("subflow %s has no stub", action.SubFlowRef.WorkflowID)
+		}
+		if results, err = s.call(ctx, action, stub, input); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	results, err = expression.EvaluateFilter(ctx, s.opts.Evaluator, action.ActionDataFilter.Results, results, s.vars)
+	if err != nil {
+		return nil, nil, fmt.Errorf("actionDataFilter.results: %w", err)
+	}
+	merge := &model.ActionDataFilter{ToStateData: action.ActionDataFilter.ToStateData}
+	if data, err = expression.MergeActionResults(ctx, s.opts.Evaluator, merge, data, results, s.vars); err != nil {
+		return nil, nil, err
+	}
+	return data, results, nil
+}
+
+// call calls the stub of the action, calling it again while it fails up to the maximum attempts of the retry
+// definition of the action, without waiting
+func (s *simulator) call(ctx context.Context, action model.Action, stub Function, input interface{}) (interface{}, error) {
+	retries := 0
+	if len(action.RetryRef) > 0 {
+		for _, r := range s.workflow.Retries {
+			if r.Name != action.RetryRef {
+				continue
+			}
+			backoff, err := retry.NewBackoff(r)
+			if err != nil {
+				return nil, err
+			}
+			// the maximum attempts count the initial attempt
+			retries = backoff.MaxAttempts - 1
+		}
+	}
+	for attempt := 0; ; attempt++ {
+		results, err := stub(ctx, input)
+		if err == nil || attempt >= retries || !retryable(action, err) {
+			return results, err
+		}
+	}
+}
+
+// retryable whether the action is retried when failing with the error: any error but the non retryable ones, or only
+// the retryable ones if defined
+func retryable(action model.Action, err error) bool {
+	var e *Error
+	if !errors.As(err, &e) {
+		return len(action.RetryableErrors) == 0
+	}
+	if len(action.RetryableErrors) > 0 {
+		return contains(action.RetryableErrors, e.Ref)
+	}
+	return !contains(action.NonRetryableErrors, e.Ref)
+}
+
+// produce records the events produced, their data being evaluated against the state data
+func (s *simulator) produce(ctx context.Context, events []model.ProduceEvent, data interface{}) error {
+	for _, produce := range events {
+		var value interface{}
+		var err error
+		switch d := produce.Data.(type) {
+		case string:
+			if expression.IsExpression(d) {
+				value, err = s.opts.Evaluator.Evaluate(ctx, d, data, s.vars)
+			} else {
+				value = d
+			}
+		case map[string]interface{}:
+			value, err = expression.EvaluateArguments(ctx, s.opts.Evaluator, d, data, s.vars)
+		default:
+			value = d
+		}
+		if err != nil {
+			return fmt.Errorf("event %s data: %w", produce.EventRef, err)
+		}
+		s.result.Produced = append(s.result.Produced, Event{Name: produce.EventRef, Data: value})
+	}
+	return nil
+}
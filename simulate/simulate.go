@@ -0,0 +1,468 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package simulate dry runs workflows: it walks a workflow from its start with stub functions and events, evaluating
+// the data filters and the switch conditions with the expression engine, to test the definitions before deploying
+// them.
+package simulate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v2/coverage"
+	"github.com/serverlessworkflow/sdk-go/v2/expression"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// DefaultMaxSteps maximum number of states visited if not set in the options, to stop looping workflows
+const DefaultMaxSteps = 1000
+
+// conditionDefault name of the default condition of the switch states in the steps
+const conditionDefault = "default"
+
+// Function stub of a function or a subflow, returning the results of the action. It's called with the evaluated
+// arguments of a function, or with the action input data of a subflow.
+type Function func(ctx context.Context, input interface{}) (interface{}, error)
+
+// Static returns a stub always returning the given results
+func Static(results interface{}) Function {
+	return func(context.Context, interface{}) (interface{}, error) {
+		return results, nil
+	}
+}
+
+// Error workflow error raised by a stub, handled by the onErrors definitions of the state referencing it
+type Error struct {
+	// Ref name of the error definition of the workflow
+	Ref string
+}
+
+// Error ...
+func (e *Error) Error() string {
+	return "error " + e.Ref
+}
+
+// Fail returns a stub always raising the error with the given name
+func Fail(ref string) Function {
+	return func(context.Context, interface{}) (interface{}, error) {
+		return nil, &Error{Ref: ref}
+	}
+}
+
+// Event event consumed or produced by the workflow
+type Event struct {
+	// Name of the event definition of the workflow
+	Name string      `json:"name"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+// Options ...
+type Options struct {
+	// Functions stubs of the functions, by name
+	Functions map[string]Function
+	// Subflows stubs of the subflows, by workflow id
+	Subflows map[string]Function
+	// Events events arriving, consumed in order by the states waiting for them
+	Events []Event
+	// Secrets values of the secrets the expressions can reference
+	Secrets map[string]string
+	// Evaluator of the expressions, jq if nil
+	Evaluator expression.Evaluator
+	// MaxSteps maximum number of states visited, DefaultMaxSteps if zero
+	MaxSteps int
+}
+
+// Step state visited by the simulation
+type Step struct {
+	State string `json:"state"`
+	// Actions names of the named actions run by the state
+	Actions []string `json:"actions,omitempty"`
+	// Condition name, or expression or event reference if unnamed, of the switch condition taken, 'default' for the
+	// default condition
+	Condition string `json:"condition,omitempty"`
+	// Error reference of the error the state failed with, if handled
+	Error string `json:"error,omitempty"`
+	// Input state data after the input filter
+	Input interface{} `json:"input,omitempty"`
+	// Output state data after the output filter
+	Output interface{} `json:"output,omitempty"`
+}
+
+// Result of a simulation
+type Result struct {
+	Steps []Step `json:"steps"`
+	// Output workflow data output
+	Output interface{} `json:"output,omitempty"`
+	// Produced events produced by the workflow, in order
+	Produced []Event `json:"produced,omitempty"`
+	// Ended true if the workflow ended, false if the simulation failed
+	Ended bool `json:"ended"`
+}
+
+// Path returns the names of the states visited, in order
+func (r *Result) Path() []string {
+	path := make([]string, len(r.Steps))
+	for i, step := range r.Steps {
+		path[i] = step.State
+	}
+	return path
+}
+
+// Trace returns the trace of the simulation, see coverage.Analyze
+func (r *Result) Trace(workflowID string) coverage.Trace {
+	trace := coverage.Trace{WorkflowID: workflowID, Ended: r.Ended}
+	for _, step := range r.Steps {
+		trace.Steps = append(trace.Steps, coverage.Step{
+			State:     step.State,
+			Actions:   step.Actions,
+			Condition: step.Condition,
+			Error:     step.Error,
+		})
+	}
+	return trace
+}
+
+// Run simulates the workflow with the JSON input, returning the path taken and the final data. The actions call
+// the stubs of the options, without waiting for their sleeps or retry delays, the actions and the branches run one
+// after the other, and the states waiting for events consume the events of the options. The result of a failed
+// simulation holds the steps up to the failing state.
+func Run(ctx context.Context, workflow *model.Workflow, input interface{}, opts Options) (*Result, error) {
+	if opts.Evaluator == nil {
+		opts.Evaluator = expression.NewJQ()
+	}
+	if opts.MaxSteps <= 0 {
+		opts.MaxSteps = DefaultMaxSteps
+	}
+	vars, err := expression.WorkflowVariables(workflow, opts.Secrets)
+	if err != nil {
+		return nil, err
+	}
+	s := &simulator{
+		workflow: workflow,
+		opts:     opts,
+		vars:     vars,
+		events:   append([]Event(nil), opts.Events...),
+		states:   map[string]model.State{},
+		result:   &Result{},
+	}
+	for _, state := range workflow.States {
+		s.states[state.GetName()] = state
+	}
+
+	var next string
+	if workflow.Start != nil {
+		next = workflow.Start.StateName
+	} else if len(workflow.States) > 0 {
+		next = workflow.States[0].GetName()
+	}
+	data := input
+	for len(next) > 0 {
+		if err := ctx.Err(); err != nil {
+			return s.result, err
+		}
+		if len(s.result.Steps) >= opts.MaxSteps {
+			return s.result, fmt.Errorf("exceeded %d steps, the workflow may loop", opts.MaxSteps)
+		}
+		state, ok := s.states[next]
+		if !ok {
+			return s.result, fmt.Errorf("state %s not found", next)
+		}
+		if next, data, err = s.visit(ctx, state, data); err != nil {
+			return s.result, fmt.Errorf("state %s: %w", state.GetName(), err)
+		}
+	}
+	s.result.Output = data
+	s.result.Ended = true
+	return s.result, nil
+}
+
+type simulator struct {
+	workflow *model.Workflow
+	opts     Options
+	vars     expression.Variables
+	// events queue of the events not consumed yet
+	events []Event
+	states map[string]model.State
+	result *Result
+}
+
+// visit runs the state, returning the next state, empty at the end of the workflow, and the state data output
+func (s *simulator) visit(ctx context.Context, state model.State, data interface{}) (string, interface{}, error) {
+	step := Step{State: state.GetName()}
+	input, err := expression.FilterStateInput(ctx, s.opts.Evaluator, state.GetStateDataFilter(), data, s.vars)
+	if err != nil {
+		return "", nil, err
+	}
+	step.Input = input
+
+	transition, end := state.GetTransition(), state.GetEnd()
+	output, err := s.run(ctx, state, &step, input)
+	if err != nil {
+		onError, ref, ok := s.handler(state, err)
+		if !ok {
+			s.result.Steps = append(s.result.Steps, step)
+			return "", nil, err
+		}
+		step.Error = ref
+		transition, end, output = onError.Transition, onError.End, input
+	} else if len(step.Condition) > 0 {
+		transition, end = s.taken(state, step.Condition)
+	}
+
+	if output, err = expression.FilterStateOutput(ctx, s.opts.Evaluator, state.GetStateDataFilter(), output, s.vars); err != nil {
+		s.result.Steps = append(s.result.Steps, step)
+		return "", nil, err
+	}
+	step.Output = output
+	s.result.Steps = append(s.result.Steps, step)
+
+	switch {
+	case transition != nil && len(transition.NextState) > 0:
+		if err := s.produce(ctx, transition.ProduceEvents, output); err != nil {
+			return "", nil, err
+		}
+		return transition.NextState, output, nil
+	case end != nil:
+		return "", output, s.produce(ctx, end.ProduceEvents, output)
+	}
+	return "", nil, fmt.Errorf("neither transitions nor ends the workflow")
+}
+
+// run runs the state on its input, returning the state data
+func (s *simulator) run(ctx context.Context, state model.State, step *Step, data interface{}) (interface{}, error) {
+	switch st := state.(type) {
+	case *model.OperationState:
+		return s.runActions(ctx, st.Actions, step, data)
+	case *model.EventState:
+		return s.runEventState(ctx, st, step, data)
+	case *model.CallbackState:
+		data, _, err := s.runAction(ctx, st.Action, step, data)
+		if err != nil {
+			return nil, err
+		}
+		event, ok := s.consume(st.EventRef)
+		if !ok {
+			return nil, fmt.Errorf("no event %s to consume", st.EventRef)
+		}
+		return expression.MergeEventData(ctx, s.opts.Evaluator, &st.EventDataFilter, data, event.Data, s.vars)
+	case *model.ForEachState:
+		return s.runForEach(ctx, st, step, data)
+	case *model.ParallelState:
+		for _, branch := range st.Branches {
+			var err error
+			if data, err = s.runActions(ctx, branch.Actions, step, data); err != nil {
+				return nil, fmt.Errorf("branch %s: %w", branch.Name, err)
+			}
+		}
+		return data, nil
+	case *model.InjectState:
+		return expression.MergeData(data, map[string]interface{}(st.Data)), nil
+	case *model.DataBasedSwitchState:
+		for _, condition := range st.DataConditions {
+			holds, err := expression.EvaluateCondition(ctx, s.opts.Evaluator, condition.GetCondition(), data, s.vars)
+			if err != nil {
+				return nil, fmt.Errorf("condition %s: %w", conditionName(condition.GetName(), condition.GetCondition()), err)
+			}
+			if holds {
+				step.Condition = conditionName(condition.GetName(), condition.GetCondition())
+				return data, nil
+			}
+		}
+		step.Condition = conditionDefault
+		return data, nil
+	case *model.EventBasedSwitchState:
+		for i, event := range s.events {
+			for _, condition := range st.EventConditions {
+				if condition.GetEventRef() != event.Name {
+					continue
+				}
+				s.events = append(s.events[:i:i], s.events[i+1:]...)
+				step.Condition = conditionName(condition.GetName(), condition.GetEventRef())
+				filter := condition.GetEventDataFilter()
+				return expression.MergeEventData(ctx, s.opts.Evaluator, &filter, data, event.Data, s.vars)
+			}
+		}
+		// no event arrives before the timeout
+		step.Condition = conditionDefault
+		return data, nil
+	}
+	return data, nil
+}
+
+func (s *simulator) runEventState(ctx context.Context, state *model.EventState, step *Step, data interface{}) (interface{}, error) {
+	handle := func(onEvents model.OnEvents, events []Event) (interface{}, error) {
+		for _, event := range events {
+			var err error
+			if data, err = expression.MergeEventData(ctx, s.opts.Evaluator, &onEvents.EventDataFilter, data, event.Data, s.vars); err != nil {
+				return nil, err
+			}
+		}
+		return s.runActions(ctx, onEvents.Actions, step, data)
+	}
+
+	if state.Exclusive {
+		for i, event := range s.events {
+			for _, onEvents := range state.OnEvents {
+				if contains(onEvents.EventRefs, event.Name) {
+					s.events = append(s.events[:i:i], s.events[i+1:]...)
+					return handle(onEvents, []Event{event})
+				}
+			}
+		}
+		return nil, fmt.Errorf("no event to consume")
+	}
+	for _, onEvents := range state.OnEvents {
+		events := make([]Event, 0, len(onEvents.EventRefs))
+		for _, ref := range onEvents.EventRefs {
+			event, ok := s.consume(ref)
+			if !ok {
+				return nil, fmt.Errorf("no event %s to consume", ref)
+			}
+			events = append(events, event)
+		}
+		var err error
+		if data, err = handle(onEvents, events); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// runForEach runs the actions for each element of the input collection, on the state data holding the element in
+// the iteration parameter. The results of the actions of each iteration are added to the output collection, the
+// state data being otherwise left unchanged.
+func (s *simulator) runForEach(ctx context.Context, state *model.ForEachState, step *Step, data interface{}) (interface{}, error) {
+	collection, err := s.opts.Evaluator.Evaluate(ctx, state.InputCollection, data, s.vars)
+	if err != nil {
+		return nil, fmt.Errorf("inputCollection: %w", err)
+	}
+	elements, ok := collection.([]interface{})
+	if !ok && collection != nil {
+		return nil, fmt.Errorf("inputCollection %s isn't an array", state.InputCollection)
+	}
+	object, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the state data isn't an object, the iteration parameter can't be added")
+	}
+
+	outputs := make([]interface{}, 0, len(elements))
+	for i, element := range elements {
+		iteration := make(map[string]interface{}, len(object)+1)
+		for key, value := range object {
+			iteration[key] = value
+		}
+		iteration[state.IterationParam] = element
+		var output interface{}
+		for _, action := range state.Actions {
+			var results interface{}
+			var err error
+			if _, results, err = s.runAction(ctx, action, step, iteration); err != nil {
+				return nil, fmt.Errorf("iteration %d: %w", i, err)
+			}
+			output = expression.MergeData(output, results)
+		}
+		outputs = append(outputs, output)
+	}
+	if len(state.OutputCollection) == 0 {
+		return data, nil
+	}
+	filter := &model.ActionDataFilter{ToStateData: state.OutputCollection}
+	merged, err := expression.MergeActionResults(ctx, s.opts.Evaluator, filter, data, outputs, s.vars)
+	if err != nil {
+		return nil, fmt.Errorf("outputCollection: %w", err)
+	}
+	return merged, nil
+}
+
+// handler returns the error handler of the state handling the error, if any, and the reference of the error
+func (s *simulator) handler(state model.State, err error) (model.OnError, string, bool) {
+	var e *Error
+	if !errors.As(err, &e) {
+		return model.OnError{}, "", false
+	}
+	for _, onError := range state.GetOnErrors() {
+		if onError.ErrorRef == e.Ref || contains(onError.ErrorRefs, e.Ref) {
+			return onError, e.Ref, true
+		}
+	}
+	return model.OnError{}, "", false
+}
+
+// taken returns the transition or the end of the switch condition with the given name
+func (s *simulator) taken(state model.State, condition string) (*model.Transition, *model.End) {
+	target := func(transition model.Transition, end model.End) (*model.Transition, *model.End) {
+		if len(transition.NextState) > 0 {
+			return &transition, nil
+		}
+		return nil, &end
+	}
+	switch st := state.(type) {
+	case *model.DataBasedSwitchState:
+		for _, c := range st.DataConditions {
+			if conditionName(c.GetName(), c.GetCondition()) != condition {
+				continue
+			}
+			switch c := c.(type) {
+			case *model.TransitionDataCondition:
+				return &c.Transition, nil
+			case *model.EndDataCondition:
+				return nil, &c.End
+			}
+		}
+		return target(st.DefaultCondition.Transition, st.DefaultCondition.End)
+	case *model.EventBasedSwitchState:
+		for _, c := range st.EventConditions {
+			if conditionName(c.GetName(), c.GetEventRef()) != condition {
+				continue
+			}
+			switch c := c.(type) {
+			case *model.TransitionEventCondition:
+				return &c.Transition, nil
+			case *model.EndEventCondition:
+				return nil, &c.End
+			}
+		}
+		return target(st.DefaultCondition.Transition, st.DefaultCondition.End)
+	}
+	return state.GetTransition(), state.GetEnd()
+}
+
+// consume removes the first event with the given name from the queue
+func (s *simulator) consume(name string) (Event, bool) {
+	for i, event := range s.events {
+		if event.Name == name {
+			s.events = append(s.events[:i:i], s.events[i+1:]...)
+			return event, true
+		}
+	}
+	return Event{}, false
+}
+
+func conditionName(name, fallback string) string {
+	if len(name) > 0 {
+		return name
+	}
+	return fallback
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,273 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/coverage"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderWorkflow = `id: order
+name: Order
+version: '1.0'
+specVersion: '0.8'
+start: Check
+events:
+  - name: Paid
+    type: order.paid
+    source: payments
+  - name: Shipped
+    type: order.shipped
+    source: carrier
+    kind: produced
+errors:
+  - name: OutOfStock
+    code: '409'
+retries:
+  - name: Twice
+    delay: PT1S
+    maxAttempts: 2
+functions:
+  - name: reserve
+    operation: https://stock.example.com/openapi.json#reserve
+  - name: charge
+    operation: https://payments.example.com/openapi.json#charge
+states:
+  - name: Check
+    type: switch
+    dataConditions:
+      - name: empty
+        condition: ${ .items | length == 0 }
+        end: true
+      - condition: ${ .total > 100 }
+        transition: Reserve
+    defaultCondition:
+      transition: Reserve
+  - name: Reserve
+    type: foreach
+    inputCollection: ${ .items }
+    iterationParam: item
+    outputCollection: ${ .reservations }
+    actions:
+      - name: reserve
+        functionRef:
+          refName: reserve
+          arguments:
+            sku: ${ .item }
+        retryRef: Twice
+    onErrors:
+      - errorRef: OutOfStock
+        end: true
+    transition: Pay
+  - name: Pay
+    type: operation
+    stateDataFilter:
+      output: ${ {reservations, receipt} }
+    actions:
+      - name: charge
+        functionRef:
+          refName: charge
+          arguments:
+            amount: ${ .total }
+        actionDataFilter:
+          results: ${ .receipt }
+          toStateData: ${ .receipt }
+    transition: Wait
+  - name: Wait
+    type: switch
+    eventConditions:
+      - eventRef: Paid
+        eventDataFilter:
+          toStateData: ${ .payment }
+        transition:
+          nextState: Done
+          produceEvents:
+            - eventRef: Shipped
+              data: ${ .receipt }
+    defaultCondition:
+      end: true
+  - name: Done
+    type: inject
+    data:
+      status: done
+    end: true
+`
+
+func TestRun(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+
+	reservations := 0
+	opts := Options{
+		Functions: map[string]Function{
+			"reserve": func(_ context.Context, input interface{}) (interface{}, error) {
+				reservations++
+				return map[string]interface{}{"reserved": input.(map[string]interface{})["sku"]}, nil
+			},
+			"charge": Static(map[string]interface{}{"receipt": "R-1", "ignored": true}),
+		},
+		Events: []Event{{Name: "Paid", Data: map[string]interface{}{"method": "card"}}},
+	}
+	input := map[string]interface{}{"items": []interface{}{"a", "b"}, "total": 150}
+	result, err := Run(context.Background(), workflow, input, opts)
+	require.NoError(t, err)
+	assert.True(t, result.Ended)
+	assert.Equal(t, []string{"Check", "Reserve", "Pay", "Wait", "Done"}, result.Path())
+	assert.Equal(t, 2, reservations)
+	assert.Equal(t, map[string]interface{}{
+		"reservations": []interface{}{
+			map[string]interface{}{"reserved": "a"},
+			map[string]interface{}{"reserved": "b"},
+		},
+		"receipt": "R-1",
+		"payment": map[string]interface{}{"method": "card"},
+		"status":  "done",
+	}, result.Output)
+	assert.Equal(t, []Event{{Name: "Shipped", Data: "R-1"}}, result.Produced)
+	assert.Equal(t, "${ .total > 100 }", result.Steps[0].Condition)
+	assert.Equal(t, []string{"reserve", "reserve"}, result.Steps[1].Actions)
+	assert.Equal(t, "Paid", result.Steps[3].Condition)
+
+	report := coverage.Analyze(workflow, []coverage.Trace{result.Trace(workflow.ID)})
+	assert.Empty(t, report.Mismatches)
+	assert.Equal(t, 1.0, report.StateRatio())
+}
+
+func TestRunDefaultConditions(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+
+	opts := Options{Functions: map[string]Function{"reserve": Static(nil), "charge": Static(nil)}}
+	result, err := Run(context.Background(), workflow, map[string]interface{}{"items": []interface{}{"a"}, "total": 10}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Check", "Reserve", "Pay", "Wait"}, result.Path())
+	assert.Equal(t, "default", result.Steps[0].Condition)
+	assert.Equal(t, "default", result.Steps[3].Condition)
+	assert.Empty(t, result.Produced)
+
+	result, err = Run(context.Background(), workflow, map[string]interface{}{"items": []interface{}{}}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Check"}, result.Path())
+	assert.Equal(t, "empty", result.Steps[0].Condition)
+}
+
+func TestRunErrors(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+
+	attempts := 0
+	opts := Options{Functions: map[string]Function{
+		"reserve": func(ctx context.Context, input interface{}) (interface{}, error) {
+			attempts++
+			return Fail("OutOfStock")(ctx, input)
+		},
+	}}
+	input := map[string]interface{}{"items": []interface{}{"a"}, "total": 10.0}
+	result, err := Run(context.Background(), workflow, input, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, []string{"Check", "Reserve"}, result.Path())
+	assert.Equal(t, "OutOfStock", result.Steps[1].Error)
+	assert.Equal(t, input, result.Output)
+
+	opts.Functions["reserve"] = Static(nil)
+	result, err = Run(context.Background(), workflow, input, opts)
+	assert.EqualError(t, err, "state Pay: actions[0]: function charge has no stub")
+	assert.False(t, result.Ended)
+	assert.Equal(t, []string{"Check", "Reserve", "Pay"}, result.Path())
+
+	failure := errors.New("connection refused")
+	opts.Functions["charge"] = func(context.Context, interface{}) (interface{}, error) {
+		return nil, failure
+	}
+	_, err = Run(context.Background(), workflow, input, opts)
+	assert.True(t, errors.Is(err, failure))
+}
+
+func TestRunEvents(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(`id: approval
+name: Approval
+version: '1.0'
+specVersion: '0.8'
+start: Submit
+events:
+  - name: Approved
+    type: approved
+  - name: Signed
+    type: signed
+  - name: Request
+    type: request
+    kind: produced
+  - name: Response
+    type: response
+functions:
+  - name: notify
+    operation: https://notify.example.com/openapi.json#notify
+states:
+  - name: Submit
+    type: callback
+    action:
+      functionRef: notify
+    eventRef: Approved
+    eventDataFilter:
+      toStateData: ${ .approval }
+    transition: Sign
+  - name: Sign
+    type: event
+    exclusive: false
+    onEvents:
+      - eventRefs: [Signed, Approved]
+        actions:
+          - eventRef:
+              triggerEventRef: Request
+              resultEventRef: Response
+              data: ${ .approval }
+    transition: Loop
+  - name: Loop
+    type: switch
+    dataConditions:
+      - condition: ${ true }
+        transition: Loop
+    defaultCondition:
+      end: true
+`))
+	require.NoError(t, err)
+
+	opts := Options{
+		Functions: map[string]Function{"notify": Static(nil)},
+		Events: []Event{
+			{Name: "Signed", Data: map[string]interface{}{"by": "alice"}},
+			{Name: "Approved", Data: "yes"},
+			{Name: "Response", Data: map[string]interface{}{"ok": true}},
+		},
+		MaxSteps: 5,
+	}
+	result, err := Run(context.Background(), workflow, map[string]interface{}{}, opts)
+	assert.EqualError(t, err, "state Sign: no event Approved to consume")
+	assert.Equal(t, []string{"Submit", "Sign"}, result.Path())
+
+	opts.Events = append(opts.Events, Event{Name: "Approved", Data: map[string]interface{}{"checked": true}})
+	result, err = Run(context.Background(), workflow, map[string]interface{}{}, opts)
+	assert.EqualError(t, err, "exceeded 5 steps, the workflow may loop")
+	assert.Equal(t, []string{"Submit", "Sign", "Loop", "Loop", "Loop"}, result.Path())
+	assert.Equal(t, map[string]interface{}{"approval": "yes", "by": "alice", "checked": true, "ok": true}, result.Steps[1].Output)
+	assert.Equal(t, []Event{{Name: "Request", Data: "yes"}}, result.Produced)
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swtest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/diff"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+// UpdateEnv environment variable updating the snapshots when set to a non empty value, e.g. SWTEST_UPDATE=1 go test
+const UpdateEnv = "SWTEST_UPDATE"
+
+// Snapshot checks the workflow against its snapshot file, see Checker.Snapshot
+func Snapshot(t testing.TB, path string, workflow *model.Workflow) bool {
+	t.Helper()
+	return defaultChecker.Snapshot(t, path, workflow)
+}
+
+// Snapshot checks that the canonical serialization of the workflow, in the format of the extension of the path, is
+// the one recorded in the snapshot file, e.g. to catch the unexpected changes of a transform. The snapshot is recorded
+// if the file doesn't exist, or updated if the UpdateEnv environment variable is set. It fails the test with the
+// changes from the snapshot to the workflow, without stopping it, and returns whether the workflow matches.
+func (c Checker) Snapshot(t testing.TB, path string, workflow *model.Workflow) bool {
+	t.Helper()
+	format, err := serializer.FormatFromPath(path)
+	if err != nil {
+		t.Errorf("snapshot: %v", err)
+		return false
+	}
+	data, err := serializer.Marshal(workflow, serializer.CanonicalOptions(format))
+	if err != nil {
+		t.Errorf("workflow doesn't marshal: %v", err)
+		return false
+	}
+	recorded, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) || len(os.Getenv(UpdateEnv)) > 0 {
+		if err := record(path, data); err != nil {
+			t.Errorf("snapshot %s not recorded: %v", path, err)
+			return false
+		}
+		t.Logf("snapshot %s recorded", path)
+		return true
+	}
+	if err != nil {
+		t.Errorf("snapshot %s not read: %v", path, err)
+		return false
+	}
+	if bytes.Equal(recorded, data) {
+		return true
+	}
+
+	snapshot, err := c.parser().FromYAMLSource(recorded)
+	if err != nil {
+		t.Errorf("snapshot %s doesn't parse, run the test with %s=1 to update it: %v", path, UpdateEnv, err)
+		return false
+	}
+	changes, err := diff.Compare(snapshot, workflow, diff.Options{Order: true, Defaults: true})
+	if err != nil {
+		t.Errorf("workflow doesn't compare to the snapshot %s: %v", path, err)
+		return false
+	}
+	if len(changes) == 0 {
+		// the same workflow serialized differently, e.g. a snapshot edited by hand
+		t.Errorf("workflow serialized differently than the snapshot %s, run the test with %s=1 to update it:\n%s", path, UpdateEnv, data)
+		return false
+	}
+	t.Errorf("workflow differs from the snapshot %s, run the test with %s=1 to update it:\n%s", path, UpdateEnv, diff.Text(changes))
+	return false
+}
+
+// record writes the snapshot file, creating its directory
+func record(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
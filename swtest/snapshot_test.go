@@ -0,0 +1,76 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swtest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swtest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	workflow := RoundTrip(t, []byte(`
+id: greeting
+name: Greeting
+version: '1.0'
+specVersion: '0.8'
+start: Greet
+states:
+- name: Greet
+  type: inject
+  data:
+    greeting: Hello
+  end: true
+`))
+	path := filepath.Join(dir, "snapshots", "greeting.sw.yaml")
+	assert.True(t, Snapshot(t, path, workflow))
+	recorded, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(recorded), "id: greeting")
+	assert.True(t, Snapshot(t, path, workflow))
+
+	workflow.States[0].(*model.InjectState).Name = "Hello"
+	workflow.Start.StateName = "Hello"
+	ft := &failingT{TB: t}
+	assert.False(t, Snapshot(ft, path, workflow))
+	assert.Contains(t, ft.message, "workflow differs from the snapshot "+path)
+	assert.Contains(t, ft.message, `~ start.stateName: "Greet" -> "Hello"`)
+	assert.Contains(t, ft.message, UpdateEnv+"=1")
+
+	require.NoError(t, ioutil.WriteFile(path, append(recorded, "\n"...), 0644))
+	ft = &failingT{TB: t}
+	workflow = RoundTrip(t, recorded)
+	assert.False(t, Snapshot(ft, path, workflow))
+	assert.Contains(t, ft.message, "serialized differently")
+
+	require.NoError(t, os.Setenv(UpdateEnv, "1"))
+	defer os.Unsetenv(UpdateEnv)
+	assert.True(t, Snapshot(t, path, workflow))
+	updated, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, recorded, updated)
+
+	ft = &failingT{TB: t}
+	assert.False(t, Snapshot(ft, filepath.Join(dir, "greeting.txt"), workflow))
+	assert.Contains(t, ft.message, "format of")
+}
@@ -0,0 +1,146 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package swtest checks the round trips of workflows in tests, e.g. those of the extensions of the SDK: a workflow
+// parsed, marshaled and parsed again must be the same workflow, and marshal to the same JSON. It also checks workflows
+// against snapshots of their serialization, e.g. the output of transforms.
+package swtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/diff"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+)
+
+// Checker checks the round trips with its Parser
+type Checker struct {
+	// Parser of the workflows, e.g. with the validator of the extension, default is the one of the parser functions
+	Parser *parser.Parser
+}
+
+// defaultChecker checker of the package functions
+var defaultChecker = Checker{}
+
+// RoundTrip checks the round trip of the JSON or YAML source, see Checker.RoundTrip
+func RoundTrip(t testing.TB, source []byte) *model.Workflow {
+	t.Helper()
+	return defaultChecker.RoundTrip(t, source)
+}
+
+// RoundTripFile checks the round trip of the JSON or YAML file, see Checker.RoundTripFile
+func RoundTripFile(t testing.TB, path string) *model.Workflow {
+	t.Helper()
+	return defaultChecker.RoundTripFile(t, path)
+}
+
+// RoundTripWorkflow checks the round trip of the workflow, see Checker.RoundTripWorkflow
+func RoundTripWorkflow(t testing.TB, workflow *model.Workflow) *model.Workflow {
+	t.Helper()
+	return defaultChecker.RoundTripWorkflow(t, workflow)
+}
+
+// RoundTrip parses the JSON or YAML source and checks the round trip of the workflow, failing the test if the source
+// doesn't parse. It returns the parsed workflow, or nil if the test failed with a testing.TB not stopping it.
+func (c Checker) RoundTrip(t testing.TB, source []byte) *model.Workflow {
+	t.Helper()
+	// the JSON sources are YAML too
+	workflow, err := c.parser().FromYAMLSource(source)
+	if err != nil {
+		t.Fatalf("source doesn't parse: %v", err)
+		return nil
+	}
+	return c.RoundTripWorkflow(t, workflow)
+}
+
+// RoundTripFile parses the JSON or YAML file and checks the round trip of the workflow, failing the test if the file
+// doesn't parse. It returns the parsed workflow, or nil if the test failed with a testing.TB not stopping it.
+func (c Checker) RoundTripFile(t testing.TB, path string) *model.Workflow {
+	t.Helper()
+	workflow, err := c.parser().FromFile(path)
+	if err != nil {
+		t.Fatalf("file %s doesn't parse: %v", path, err)
+		return nil
+	}
+	return c.RoundTripWorkflow(t, workflow)
+}
+
+// RoundTripWorkflow marshals the workflow to JSON, parses it and marshals the parsed workflow again. It fails the test
+// if the workflow doesn't marshal, the JSON doesn't parse, the parsed workflow differs from the workflow or marshals to
+// other JSON, reporting the differences. It returns the parsed workflow, or nil if the test failed with a testing.TB not
+// stopping it.
+func (c Checker) RoundTripWorkflow(t testing.TB, workflow *model.Workflow) *model.Workflow {
+	t.Helper()
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		t.Fatalf("workflow doesn't marshal: %v", err)
+		return nil
+	}
+	parsed, err := c.parser().FromJSONSource(data)
+	if err != nil {
+		t.Fatalf("marshaled workflow doesn't parse: %v\n%s", err, data)
+		return nil
+	}
+	if !workflow.Equal(parsed) {
+		t.Fatalf("workflow changed by a round trip:\n%s", differences(workflow, parsed, data))
+		return nil
+	}
+	dataAgain, err := json.Marshal(parsed)
+	if err != nil {
+		t.Fatalf("parsed workflow doesn't marshal: %v", err)
+		return nil
+	}
+	if !bytes.Equal(data, dataAgain) {
+		t.Fatalf("JSON changed by a round trip:\n%s\n%s", data, dataAgain)
+		return nil
+	}
+	return parsed
+}
+
+// Equal checks that the workflows are equal for the options, e.g. ignoring the order of the definitions with a name
+// and the properties set to their default value with the zero diff.Options, failing the test with the first divergence
+// and all the changes otherwise, without stopping it. It returns whether the workflows are equal.
+func Equal(t testing.TB, expected, actual *model.Workflow, opts diff.Options) bool {
+	t.Helper()
+	changes, err := diff.Compare(expected, actual, opts)
+	if err != nil {
+		t.Errorf("workflows don't compare: %v", err)
+		return false
+	}
+	if len(changes) > 0 {
+		t.Errorf("workflows differ at %s:\n%s", changes[0].Path, diff.Text(changes))
+		return false
+	}
+	return true
+}
+
+func (c Checker) parser() *parser.Parser {
+	if c.Parser == nil {
+		return parser.New(nil)
+	}
+	return c.Parser
+}
+
+// differences describes the differences of the workflows, the properties changed if the diff finds some, the JSON of
+// both workflows otherwise
+func differences(workflow, parsed *model.Workflow, data []byte) string {
+	if changes, err := diff.Compare(workflow, parsed, diff.Options{Order: true, Defaults: true}); err == nil && len(changes) > 0 {
+		return diff.Text(changes)
+	}
+	parsedData, _ := json.Marshal(parsed)
+	return string(data) + "\n" + string(parsedData)
+}
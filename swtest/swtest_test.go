@@ -0,0 +1,141 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swtest
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/diff"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	val "gopkg.in/go-playground/validator.v8"
+)
+
+// failingT records the failure of the checks
+type failingT struct {
+	testing.TB
+	message string
+}
+
+func (t *failingT) Helper() {}
+
+func (t *failingT) Fatalf(format string, args ...interface{}) {
+	t.message = fmt.Sprintf(format, args...)
+}
+
+func (t *failingT) Errorf(format string, args ...interface{}) {
+	t.message = fmt.Sprintf(format, args...)
+}
+
+func TestRoundTripFile(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			workflow := RoundTripFile(t, file)
+			assert.NotEmpty(t, workflow.States)
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	workflow := RoundTrip(t, []byte(`
+id: greeting
+name: Greeting
+version: '1.0'
+specVersion: '0.8'
+start: Greet
+states:
+- name: Greet
+  type: inject
+  data:
+    greeting: Hello
+  end: true
+`))
+	assert.Equal(t, "greeting", workflow.ID)
+
+	ft := &failingT{TB: t}
+	assert.Nil(t, RoundTrip(ft, []byte(`{"id": "greeting"}`)))
+	assert.Contains(t, ft.message, "source doesn't parse")
+
+	ft = &failingT{TB: t}
+	assert.Nil(t, RoundTripFile(ft, "missing.json"))
+	assert.Contains(t, ft.message, "file missing.json doesn't parse")
+}
+
+func TestRoundTripWorkflow(t *testing.T) {
+	workflow := &model.Workflow{
+		BaseWorkflow: model.BaseWorkflow{ID: "greeting", Name: "Greeting", Version: "1.0", SpecVersion: "0.8",
+			Start: &model.Start{StateName: "Greet"}, ExpressionLang: model.DefaultExpressionLang},
+		States: []model.State{&model.InjectState{
+			BaseState: model.BaseState{Name: "Greet", Type: model.StateTypeInject, End: &model.End{Terminate: true}},
+			Data:      map[string]interface{}{"greeting": "Hello"},
+		}},
+	}
+	parsed := RoundTripWorkflow(t, workflow)
+	assert.True(t, workflow.Equal(parsed))
+
+	// the default expression language is set by the parser
+	workflow.ExpressionLang = ""
+	ft := &failingT{TB: t}
+	assert.Nil(t, RoundTripWorkflow(ft, workflow))
+	assert.Contains(t, ft.message, "workflow changed by a round trip:\n+ expressionLang: \"jq\"\n")
+}
+
+func TestEqual(t *testing.T) {
+	expected := RoundTripFile(t, filepath.Join("testdata", "eventbasedgreeting.sw.json"))
+	actual := RoundTripFile(t, filepath.Join("testdata", "eventbasedgreeting.sw.json"))
+	unused := model.Event{Name: "Unused", Type: "unused", Kind: model.EventKindConsumed}
+	actual.Events = append([]model.Event{unused}, actual.Events...)
+	actual.Description = "Changed"
+
+	ft := &failingT{TB: t}
+	assert.False(t, Equal(ft, expected, actual, diff.Options{}))
+	assert.Equal(t, "workflows differ at description:\n"+
+		"~ description: \"Event Based Greeting\" -> \"Changed\"\n"+
+		"+ events[Unused]: {name: Unused, ...}\n", ft.message)
+
+	// the definitions at other positions are equal, unless comparing the order
+	actual.Description = expected.Description
+	expected.Events = append(expected.Events, unused)
+	assert.True(t, Equal(t, expected, actual, diff.Options{}))
+	ft = &failingT{TB: t}
+	assert.False(t, Equal(ft, expected, actual, diff.Options{Order: true}))
+	assert.Contains(t, ft.message, "workflows differ at events[GreetingEvent]:\n> events[GreetingEvent]: 0 -> 1\n")
+}
+
+func TestChecker(t *testing.T) {
+	v := validator.New()
+	v.RegisterStructValidation(func(v *val.Validate, structLevel *val.StructLevel) {
+		// the greeting workflows are reserved
+		if structLevel.CurrentStruct.Interface().(model.Workflow).ID == "greeting" {
+			structLevel.ReportError(reflect.ValueOf("greeting"), "ID", "id", "reserved")
+		}
+	}, model.Workflow{})
+	checker := Checker{Parser: parser.New(v)}
+
+	workflow := checker.RoundTripFile(t, filepath.Join("testdata", "applicationrequest.json"))
+	assert.Equal(t, "applicantrequest", workflow.ID)
+	ft := &failingT{TB: t}
+	assert.Nil(t, checker.RoundTripFile(ft, filepath.Join("testdata", "greetings.sw.json")))
+	assert.Contains(t, ft.message, "doesn't parse")
+}
@@ -0,0 +1,248 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package timeouts resolves the timeouts applying to the states, actions and branches of a workflow from the
+// timeouts defined at the workflow, state and branch levels.
+package timeouts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/util/iso8601"
+)
+
+// Origin level defining a timeout
+type Origin string
+
+const (
+	// OriginNone the timeout isn't defined, it's unlimited
+	OriginNone Origin = ""
+	// OriginWorkflow the timeout is defined by the timeouts of the workflow
+	OriginWorkflow Origin = "workflow"
+	// OriginState the timeout is defined by the timeouts of the state
+	OriginState Origin = "state"
+	// OriginBranch the timeout is defined by the timeouts of the branch
+	OriginBranch Origin = "branch"
+)
+
+// Timeout effective timeout
+type Timeout struct {
+	// Duration of the timeout, 0 if unlimited
+	Duration time.Duration
+	// Value ISO 8601 duration defining the timeout
+	Value string
+	// Origin level defining the timeout
+	Origin Origin
+}
+
+// Unlimited whether no timeout applies
+func (t Timeout) Unlimited() bool {
+	return t.Duration == 0
+}
+
+// Timeouts effective timeouts of a state or of a branch of a parallel state. The timeouts not applying to the state,
+// e.g. the action timeout of a sleep state, are unlimited.
+type Timeouts struct {
+	// Workflow execution timeout of the whole workflow
+	Workflow Timeout
+	// Interrupt whether the workflow execution is interrupted when timing out
+	Interrupt bool
+	// RunBefore state run before the workflow execution ends when timing out
+	RunBefore string
+	// StateTotal state execution timeout, including the retries
+	StateTotal Timeout
+	// StateSingle state execution timeout, not including the retries
+	StateSingle Timeout
+	// Action execution timeout of each action
+	Action Timeout
+	// Branch execution timeout of each branch
+	Branch Timeout
+	// Event timeout waiting for the events to consume
+	Event Timeout
+}
+
+// ForState returns the effective timeouts of the state with the given name: the state timeouts, defaulting to the
+// workflow ones. The action timeout applies to the states running actions, the branch timeout to the parallel
+// states and the event timeout to the states consuming events.
+func ForState(workflow *model.Workflow, name string) (Timeouts, error) {
+	state := findState(workflow, name)
+	if state == nil {
+		return Timeouts{}, fmt.Errorf("state %s not found", name)
+	}
+	r := &resolver{path: "states[" + name + "].timeouts"}
+	t := r.workflowTimeouts(workflow)
+	var defaults model.Timeouts
+	if workflow.Timeouts != nil {
+		defaults = *workflow.Timeouts
+	}
+	var stateExec model.StateExecTimeout
+	var action, branch, event *string
+	switch s := state.(type) {
+	case *model.OperationState:
+		stateExec, action = s.Timeouts.StateExecTimeout, &s.Timeouts.ActionExecTimeout
+	case *model.EventState:
+		stateExec, action, event = s.Timeout.StateExecTimeout, &s.Timeout.ActionExecTimeout, &s.Timeout.EventTimeout
+	case *model.CallbackState:
+		stateExec, action, event = s.Timeouts.StateExecTimeout, &s.Timeouts.ActionExecTimeout, &s.Timeouts.EventTimeout
+	case *model.ForEachState:
+		stateExec, action = s.Timeouts.StateExecTimeout, &s.Timeouts.ActionExecTimeout
+	case *model.ParallelState:
+		stateExec, branch = s.Timeouts.StateExecTimeout, &s.Timeouts.BranchExecTimeout
+	case *model.EventBasedSwitchState:
+		stateExec, event = s.Timeouts.StateExecTimeout, &s.Timeouts.EventTimeout
+	case *model.DataBasedSwitchState:
+		stateExec = s.Timeouts.StateExecTimeout
+	case *model.InjectState:
+		stateExec = s.Timeouts.StateExecTimeout
+	case *model.SleepState:
+		stateExec = s.Timeouts.StateExecTimeout
+	}
+
+	var stateDefaults model.StateExecTimeout
+	if defaults.StateExecTimeout != nil {
+		stateDefaults = *defaults.StateExecTimeout
+	}
+	t.StateTotal = r.layered("stateExecTimeout.total", stateExec.Total, stateDefaults.Total, OriginState)
+	t.StateSingle = r.layered("stateExecTimeout.single", stateExec.Single, stateDefaults.Single, OriginState)
+	if action != nil {
+		t.Action = r.layered("actionExecTimeout", *action, defaults.ActionExecTimeout, OriginState)
+	}
+	if branch != nil {
+		t.Branch = r.layered("branchExecTimeout", *branch, defaults.BranchExecTimeout, OriginState)
+	}
+	if event != nil {
+		t.Event = r.layered("eventTimeout", *event, defaults.EventTimeout, OriginState)
+	}
+	return t, r.err
+}
+
+// ForBranch returns the effective timeouts of the branch with the given name of a parallel state: the branch
+// timeouts, defaulting to the parallel state ones, themselves defaulting to the workflow ones. The action timeout
+// applies to the actions of the branch.
+func ForBranch(workflow *model.Workflow, stateName, branchName string) (Timeouts, error) {
+	t, err := ForState(workflow, stateName)
+	if err != nil {
+		return Timeouts{}, err
+	}
+	parallel, ok := findState(workflow, stateName).(*model.ParallelState)
+	if !ok {
+		return Timeouts{}, fmt.Errorf("state %s isn't a parallel state", stateName)
+	}
+	for _, branch := range parallel.Branches {
+		if branch.Name != branchName {
+			continue
+		}
+		r := &resolver{path: "states[" + stateName + "].branches[" + branchName + "].timeouts"}
+		var defaults model.Timeouts
+		if workflow.Timeouts != nil {
+			defaults = *workflow.Timeouts
+		}
+		t.Branch = r.override("branchExecTimeout", branch.Timeouts.BranchExecTimeout, t.Branch)
+		t.Action = r.layered("actionExecTimeout", branch.Timeouts.ActionExecTimeout, defaults.ActionExecTimeout, OriginBranch)
+		return t, r.err
+	}
+	return Timeouts{}, fmt.Errorf("branch %s not found in state %s", branchName, stateName)
+}
+
+// ForAction returns the effective timeouts of the action with the given index in the state, the actions of the event
+// handlers of the event states being counted in turn. Since the actions have no timeouts of their own, they are those
+// of the state.
+func ForAction(workflow *model.Workflow, stateName string, index int) (Timeouts, error) {
+	t, err := ForState(workflow, stateName)
+	if err != nil {
+		return Timeouts{}, err
+	}
+	count := 0
+	switch s := findState(workflow, stateName).(type) {
+	case *model.OperationState:
+		count = len(s.Actions)
+	case *model.ForEachState:
+		count = len(s.Actions)
+	case *model.CallbackState:
+		count = 1
+	case *model.EventState:
+		for _, onEvents := range s.OnEvents {
+			count += len(onEvents.Actions)
+		}
+	default:
+		return Timeouts{}, fmt.Errorf("state %s has no actions", stateName)
+	}
+	if index < 0 || index >= count {
+		return Timeouts{}, fmt.Errorf("state %s has no action %d", stateName, index)
+	}
+	return t, nil
+}
+
+type resolver struct {
+	path string
+	err  error
+}
+
+func (r *resolver) workflowTimeouts(workflow *model.Workflow) Timeouts {
+	t := Timeouts{}
+	if workflow.Timeouts == nil || workflow.Timeouts.WorkflowExecTimeout == nil {
+		return t
+	}
+	exec := workflow.Timeouts.WorkflowExecTimeout
+	t.Interrupt, t.RunBefore = exec.Interrupt, exec.RunBefore
+	if exec.Duration == model.UnlimitedTimeout {
+		return t
+	}
+	workflowResolver := &resolver{path: "timeouts"}
+	t.Workflow = workflowResolver.timeout("workflowExecTimeout.duration", exec.Duration, OriginWorkflow)
+	r.err = workflowResolver.err
+	return t
+}
+
+// layered returns the timeout defined by the value, at the given origin, or else by the workflow default
+func (r *resolver) layered(property, value, workflowDefault string, origin Origin) Timeout {
+	if len(value) > 0 {
+		return r.timeout(property, value, origin)
+	}
+	if len(workflowDefault) > 0 {
+		defaults := &resolver{path: "timeouts"}
+		t := defaults.timeout(property, workflowDefault, OriginWorkflow)
+		if r.err == nil {
+			r.err = defaults.err
+		}
+		return t
+	}
+	return Timeout{}
+}
+
+// override returns the timeout defined by the branch value, or else the inherited one
+func (r *resolver) override(property, value string, inherited Timeout) Timeout {
+	if len(value) > 0 {
+		return r.timeout(property, value, OriginBranch)
+	}
+	return inherited
+}
+
+func (r *resolver) timeout(property, value string, origin Origin) Timeout {
+	d, err := iso8601.ParseDuration(value)
+	if err != nil {
+		if r.err == nil {
+			r.err = fmt.Errorf("%s.%s: %w", r.path, property, err)
+		}
+		return Timeout{}
+	}
+	return Timeout{Duration: d, Value: value, Origin: origin}
+}
+
+func findState(workflow *model.Workflow, name string) model.State {
+	state, _ := workflow.State(name)
+	return state
+}
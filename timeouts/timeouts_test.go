@@ -0,0 +1,176 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeouts
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderWorkflow = `id: order
+name: Order
+version: '1.0'
+specVersion: '0.8'
+start: Store
+timeouts:
+  workflowExecTimeout:
+    duration: PT1H
+    interrupt: true
+    runBefore: Cleanup
+  stateExecTimeout: PT5M
+  actionExecTimeout: PT30S
+  branchExecTimeout: PT2M
+  eventTimeout: PT10M
+events:
+  - name: paid
+    type: order.paid
+    source: payments
+functions:
+  - name: storeOrder
+    operation: https://orders.example.com/openapi.json#storeOrder
+states:
+  - name: Store
+    type: operation
+    timeouts:
+      stateExecTimeout:
+        total: PT10M
+        single: PT2M
+      actionExecTimeout: PT1M
+    actions:
+      - functionRef: storeOrder
+    transition: Ship
+  - name: Ship
+    type: parallel
+    timeouts:
+      branchExecTimeout: PT3M
+    branches:
+      - name: carrier
+        timeouts:
+          actionExecTimeout: PT15S
+        actions:
+          - functionRef: storeOrder
+      - name: warehouse
+        timeouts:
+          branchExecTimeout: PT1M
+        actions:
+          - functionRef: storeOrder
+    transition: Wait
+  - name: Wait
+    type: event
+    onEvents:
+      - eventRefs: [paid]
+        actions:
+          - functionRef: storeOrder
+    transition: Cleanup
+  - name: Cleanup
+    type: sleep
+    duration: PT1S
+    end: true
+`
+
+func TestForState(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+
+	store, err := ForState(workflow, "Store")
+	require.NoError(t, err)
+	assert.Equal(t, Timeout{Duration: time.Hour, Value: "PT1H", Origin: OriginWorkflow}, store.Workflow)
+	assert.True(t, store.Interrupt)
+	assert.Equal(t, "Cleanup", store.RunBefore)
+	assert.Equal(t, Timeout{Duration: 10 * time.Minute, Value: "PT10M", Origin: OriginState}, store.StateTotal)
+	assert.Equal(t, Timeout{Duration: 2 * time.Minute, Value: "PT2M", Origin: OriginState}, store.StateSingle)
+	assert.Equal(t, Timeout{Duration: time.Minute, Value: "PT1M", Origin: OriginState}, store.Action)
+	assert.True(t, store.Branch.Unlimited())
+	assert.True(t, store.Event.Unlimited())
+
+	wait, err := ForState(workflow, "Wait")
+	require.NoError(t, err)
+	assert.Equal(t, Timeout{Duration: 5 * time.Minute, Value: "PT5M", Origin: OriginWorkflow}, wait.StateTotal)
+	assert.True(t, wait.StateSingle.Unlimited())
+	assert.Equal(t, Timeout{Duration: 30 * time.Second, Value: "PT30S", Origin: OriginWorkflow}, wait.Action)
+	assert.Equal(t, Timeout{Duration: 10 * time.Minute, Value: "PT10M", Origin: OriginWorkflow}, wait.Event)
+
+	cleanup, err := ForState(workflow, "Cleanup")
+	require.NoError(t, err)
+	assert.Equal(t, OriginWorkflow, cleanup.StateTotal.Origin)
+	assert.True(t, cleanup.Action.Unlimited())
+	assert.Equal(t, OriginNone, cleanup.Action.Origin)
+
+	_, err = ForState(workflow, "Missing")
+	assert.EqualError(t, err, "state Missing not found")
+}
+
+func TestForBranch(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+
+	ship, err := ForState(workflow, "Ship")
+	require.NoError(t, err)
+	assert.Equal(t, Timeout{Duration: 3 * time.Minute, Value: "PT3M", Origin: OriginState}, ship.Branch)
+	assert.True(t, ship.Action.Unlimited())
+
+	carrier, err := ForBranch(workflow, "Ship", "carrier")
+	require.NoError(t, err)
+	assert.Equal(t, Timeout{Duration: 3 * time.Minute, Value: "PT3M", Origin: OriginState}, carrier.Branch)
+	assert.Equal(t, Timeout{Duration: 15 * time.Second, Value: "PT15S", Origin: OriginBranch}, carrier.Action)
+
+	warehouse, err := ForBranch(workflow, "Ship", "warehouse")
+	require.NoError(t, err)
+	assert.Equal(t, Timeout{Duration: time.Minute, Value: "PT1M", Origin: OriginBranch}, warehouse.Branch)
+	assert.Equal(t, Timeout{Duration: 30 * time.Second, Value: "PT30S", Origin: OriginWorkflow}, warehouse.Action)
+
+	_, err = ForBranch(workflow, "Ship", "missing")
+	assert.EqualError(t, err, "branch missing not found in state Ship")
+	_, err = ForBranch(workflow, "Store", "carrier")
+	assert.EqualError(t, err, "state Store isn't a parallel state")
+}
+
+func TestForAction(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+
+	action, err := ForAction(workflow, "Store", 0)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, action.Action.Duration)
+	action, err = ForAction(workflow, "Wait", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, action.Action.Duration)
+
+	_, err = ForAction(workflow, "Store", 1)
+	assert.EqualError(t, err, "state Store has no action 1")
+	_, err = ForAction(workflow, "Cleanup", 0)
+	assert.EqualError(t, err, "state Cleanup has no actions")
+}
+
+func TestInvalidTimeouts(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(orderWorkflow))
+	require.NoError(t, err)
+	workflow.Timeouts.ActionExecTimeout = "30s"
+	_, err = ForState(workflow, "Wait")
+	assert.EqualError(t, err, "timeouts.actionExecTimeout: invalid ISO 8601 duration 30s")
+	assert.True(t, errors.Is(err, model.ErrInvalidDuration))
+	_, err = ForState(workflow, "Store")
+	assert.NoError(t, err)
+
+	workflow.Timeouts.WorkflowExecTimeout.Duration = "1h"
+	_, err = ForState(workflow, "Store")
+	assert.EqualError(t, err, "timeouts.workflowExecTimeout.duration: invalid ISO 8601 duration 1h")
+}
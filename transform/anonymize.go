@@ -0,0 +1,324 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// kinds of the names renamed by Anonymize, the generic names being the kind followed by a number, e.g. state1
+const (
+	kindState     = "state"
+	kindFunction  = "function"
+	kindEvent     = "event"
+	kindRetry     = "retry"
+	kindError     = "error"
+	kindAuth      = "auth"
+	kindAction    = "action"
+	kindBranch    = "branch"
+	kindCondition = "condition"
+	kindSecret    = "secret"
+	kindWorkflow  = "workflow"
+)
+
+// redacted value of the strings removed by Anonymize
+const redacted = "redacted"
+
+var (
+	// definitionKinds kinds of the elements of the arrays of definitions, named by their name property
+	definitionKinds = map[string]string{
+		"states":          kindState,
+		"functions":       kindFunction,
+		"events":          kindEvent,
+		"retries":         kindRetry,
+		"errors":          kindError,
+		"auth":            kindAuth,
+		"actions":         kindAction,
+		"branches":        kindBranch,
+		"dataConditions":  kindCondition,
+		"eventConditions": kindCondition,
+	}
+	// referenceKinds kinds of the names referenced by the properties, in strings or arrays of strings
+	referenceKinds = map[string]string{
+		"nextState":          kindState,
+		"stateName":          kindState,
+		"compensatedBy":      kindState,
+		"runBefore":          kindState,
+		"refName":            kindFunction,
+		"eventRef":           kindEvent,
+		"eventRefs":          kindEvent,
+		"triggerEventRef":    kindEvent,
+		"resultEventRef":     kindEvent,
+		"retryRef":           kindRetry,
+		"errorRef":           kindError,
+		"errorRefs":          kindError,
+		"retryableErrors":    kindError,
+		"nonRetryableErrors": kindError,
+		"authRef":            kindAuth,
+		"workflowId":         kindWorkflow,
+	}
+	// stripped properties describing the workflow, dropped
+	stripped = map[string]bool{"description": true, "annotations": true, "metadata": true}
+	// inlineData properties holding data, whose values are redacted but keys kept
+	inlineData = map[string]bool{"data": true, "arguments": true, "constants": true, "contextAttributeValue": true}
+	// credentials properties of the authentication definitions holding credentials
+	credentials = map[string]bool{
+		"username": true, "password": true, "token": true, "clientId": true, "clientSecret": true,
+		"subjectToken": true, "actorToken": true, "requestedSubject": true, "requestedIssuer": true,
+	}
+	secretReference = regexp.MustCompile(`\$SECRETS\.([A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// Anonymize returns a copy of the workflow safe to share, e.g. attached to a public bug report, with the same
+// structure and validity. The states, actions, branches, switch conditions, functions, events, retries, errors,
+// authentications, secrets and referenced workflows are renamed to generic names, e.g. state1, and their references
+// follow. The id and name of the workflow become generic, its description, annotations and metadata are dropped, the
+// URLs and the operations of the functions are replaced with example.com ones, the graphql operations keeping whether
+// they're a query or a mutation, and the credentials and the inline data, e.g. the data of the
+// inject states, the arguments of the functions and the constants, are redacted: their strings become 'redacted' and
+// their numbers 0, their keys being kept. The expressions are kept, with the secrets they reference renamed, since they
+// are the likely cause of the failures reproduced.
+func Anonymize(workflow *model.Workflow) (*model.Workflow, error) {
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var document map[string]interface{}
+	if err := dec.Decode(&document); err != nil {
+		return nil, err
+	}
+	a := &anonymizer{names: map[string]map[string]string{}}
+	a.anonymize(document)
+	if data, err = json.Marshal(document); err != nil {
+		return nil, err
+	}
+	anonymized := &model.Workflow{}
+	if err := json.Unmarshal(data, anonymized); err != nil {
+		return nil, err
+	}
+	return anonymized, nil
+}
+
+type anonymizer struct {
+	// names generic names by kind and name
+	names map[string]map[string]string
+}
+
+// rename returns the generic name of the name of the kind, the next one of the kind if it's new. Empty names stay
+// empty.
+func (a *anonymizer) rename(kind, name string) string {
+	if len(name) == 0 {
+		return name
+	}
+	names, ok := a.names[kind]
+	if !ok {
+		names = map[string]string{}
+		a.names[kind] = names
+	}
+	generic, ok := names[name]
+	if !ok {
+		generic = kind + strconv.Itoa(len(names)+1)
+		names[name] = generic
+	}
+	return generic
+}
+
+// anonymize anonymizes the JSON document of the workflow
+func (a *anonymizer) anonymize(document map[string]interface{}) {
+	// the definitions are named in order before being referenced
+	for _, key := range []string{"states", "functions", "events", "retries", "errors", "auth"} {
+		if definitions, ok := document[key].([]interface{}); ok {
+			for _, definition := range definitions {
+				if object, ok := definition.(map[string]interface{}); ok {
+					if name, ok := object["name"].(string); ok {
+						a.rename(definitionKinds[key], name)
+					}
+				}
+			}
+		}
+	}
+	if secrets, ok := document["secrets"].([]interface{}); ok {
+		for i, secret := range secrets {
+			if name, ok := secret.(string); ok {
+				secrets[i] = a.rename(kindSecret, name)
+			}
+		}
+	}
+	for key, value := range document {
+		switch key {
+		case "id", "key":
+			document[key] = kindWorkflow
+		case "name":
+			document[key] = "Workflow"
+		case "secrets":
+		case "dataInputSchema":
+			document[key] = a.schema(value)
+		default:
+			if stripped[key] {
+				delete(document, key)
+				continue
+			}
+			document[key] = a.value(key, value)
+		}
+	}
+}
+
+// value returns the anonymized value of the property of the given key
+func (a *anonymizer) value(key string, value interface{}) interface{} {
+	if inlineData[key] {
+		return a.redact(value)
+	}
+	if kind, ok := referenceKinds[key]; ok {
+		switch v := value.(type) {
+		case string:
+			return a.rename(kind, v)
+		case []interface{}:
+			for i, name := range v {
+				if s, ok := name.(string); ok {
+					v[i] = a.rename(kind, s)
+				}
+			}
+			return v
+		}
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		a.object(key, v)
+	case []interface{}:
+		for i, element := range v {
+			if object, ok := element.(map[string]interface{}); ok {
+				a.definition(definitionKinds[key], object)
+			} else {
+				v[i] = a.value("", element)
+			}
+		}
+	case string:
+		return a.text(v)
+	}
+	return value
+}
+
+// object anonymizes the properties of the object of the given key
+func (a *anonymizer) object(key string, object map[string]interface{}) {
+	for k, v := range object {
+		if stripped[k] {
+			delete(object, k)
+			continue
+		}
+		if key == "properties" && credentials[k] {
+			if s, ok := v.(string); ok && !isExpression(s) {
+				object[k] = redacted
+				continue
+			}
+		}
+		object[k] = a.value(k, v)
+	}
+}
+
+// definition anonymizes the element of an array, a definition of the given kind if not empty
+func (a *anonymizer) definition(kind string, object map[string]interface{}) {
+	name, _ := object["name"].(string)
+	// the operations hold URLs replaced by the anonymization of the properties
+	operation, _ := object["operation"].(string)
+	a.object("", object)
+	if len(kind) == 0 || len(name) == 0 {
+		return
+	}
+	generic := a.rename(kind, name)
+	object["name"] = generic
+	switch kind {
+	case kindFunction:
+		if len(operation) > 0 && object["type"] != string(model.FunctionTypeExpression) {
+			parts := strings.Split(operation, "#")
+			parts[0] = "https://example.com/" + generic + ".json"
+			for i := 1; i < len(parts); i++ {
+				// the graphql operations are a query or a mutation followed by its name
+				if i > 1 || object["type"] != string(model.FunctionTypeGraphQL) {
+					parts[i] = generic
+				}
+			}
+			object["operation"] = strings.Join(parts, "#")
+		}
+	case kindEvent:
+		if _, ok := object["source"].(string); ok {
+			object["source"] = "https://example.com/" + generic
+		}
+		if _, ok := object["type"].(string); ok {
+			object["type"] = "com.example." + generic
+		}
+	}
+}
+
+// schema returns the anonymized data input schema, a URI or an object with the URI
+func (a *anonymizer) schema(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return "https://example.com/schema.json"
+	case map[string]interface{}:
+		if _, ok := v["schema"].(string); ok {
+			v["schema"] = "https://example.com/schema.json"
+		}
+	}
+	return value
+}
+
+// text returns the anonymized string: the expressions with their secrets renamed, the URLs replaced
+func (a *anonymizer) text(s string) string {
+	if isExpression(s) {
+		return secretReference.ReplaceAllStringFunc(s, func(ref string) string {
+			return "$SECRETS." + a.rename(kindSecret, strings.TrimPrefix(ref, "$SECRETS."))
+		})
+	}
+	if strings.Contains(s, "://") {
+		return "https://example.com"
+	}
+	return s
+}
+
+// redact returns the inline data with its strings redacted, but the expressions, and its numbers zeroed
+func (a *anonymizer) redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, element := range v {
+			v[key] = a.redact(element)
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = a.redact(element)
+		}
+	case string:
+		if isExpression(v) {
+			return a.text(v)
+		}
+		return redacted
+	case json.Number:
+		return json.Number("0")
+	}
+	return value
+}
+
+// isExpression checks whether the string is a workflow expression, e.g. '${ .name }'
+func isExpression(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "${") || strings.HasPrefix(s, "$SECRETS")
+}
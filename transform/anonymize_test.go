@@ -0,0 +1,142 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/integrity"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnonymize(t *testing.T) {
+	workflow, err := parser.FromYAMLSource([]byte(`id: order
+name: Order
+description: Orders of ACME
+version: '1.0'
+specVersion: '0.8'
+start: Pay
+timeouts:
+  workflowExecTimeout: { duration: PT1H, runBefore: Ship }
+metadata:
+  owner: payments@acme.com
+secrets: [paymentKey]
+constants:
+  shop: { name: ACME, floor: 3 }
+auth:
+  - name: paymentAuth
+    scheme: basic
+    properties: { username: acme, password: hunter2 }
+functions:
+  - name: charge
+    operation: https://payments.acme.com/openapi.json#charge
+    authRef: paymentAuth
+  - name: total
+    type: expression
+    operation: .items | length
+  - name: stock
+    type: graphql
+    operation: https://stock.acme.com/graphql#query#inventory
+events:
+  - name: Paid
+    source: https://payments.acme.com
+    type: com.acme.paid
+states:
+  - name: Pay
+    type: operation
+    actions:
+      - name: chargeCard
+        functionRef:
+          refName: charge
+          arguments: { card: '4111 1111 1111 1111', key: '${ $SECRETS.paymentKey }', amount: 12 }
+    transition: Route
+  - name: Route
+    type: switch
+    dataConditions:
+      - name: acmeExpress
+        condition: '${ .express }'
+        transition: Ship
+    defaultCondition: { transition: Ship }
+  - name: Ship
+    type: inject
+    data: { carrier: ACME Express }
+    end:
+      produceEvents:
+        - eventRef: Paid
+`))
+	require.NoError(t, err)
+	original, err := json.Marshal(workflow)
+	require.NoError(t, err)
+
+	anonymized, err := Anonymize(workflow)
+	require.NoError(t, err)
+	after, err := json.Marshal(workflow)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(original), string(after), "the workflow is modified")
+
+	data, err := json.Marshal(anonymized)
+	require.NoError(t, err)
+	for _, secret := range []string{"ACME", "acme", "Orders", "Pay", "Ship", "charge", "Paid", "hunter2", "4111", "paymentKey", "owner", "inventory", "stock"} {
+		assert.NotContains(t, string(data), secret)
+	}
+	assert.Equal(t, "workflow", anonymized.ID)
+	assert.Equal(t, "state1", anonymized.Start.StateName)
+	assert.Equal(t, []string{"secret1"}, []string(anonymized.Secrets))
+	operation := anonymized.States[0].(*model.OperationState)
+	assert.Equal(t, "action1", operation.Actions[0].Name)
+	assert.Equal(t, "function1", operation.Actions[0].FunctionRef.RefName)
+	assert.Equal(t, map[string]interface{}{"card": "redacted", "key": "${ $SECRETS.secret1 }", "amount": float64(0)},
+		operation.Actions[0].FunctionRef.Arguments)
+	assert.Equal(t, "state2", operation.Transition.NextState)
+	assert.Equal(t, "state3", anonymized.Timeouts.WorkflowExecTimeout.RunBefore)
+	condition := anonymized.States[1].(*model.DataBasedSwitchState).DataConditions[0]
+	assert.Equal(t, "condition1", condition.GetName())
+	assert.Equal(t, "state3", condition.(*model.TransitionDataCondition).Transition.NextState)
+	assert.Equal(t, "https://example.com/function1.json#function1", anonymized.Functions[0].Operation)
+	assert.Equal(t, "auth1", anonymized.Functions[0].AuthRef)
+	assert.Equal(t, ".items | length", anonymized.Functions[1].Operation)
+	assert.Equal(t, "https://example.com/function3.json#query#function3", anonymized.Functions[2].Operation)
+	assert.Equal(t, model.Event{Name: "event1", Source: "https://example.com/event1", Type: "com.example.event1", Kind: anonymized.Events[0].Kind,
+		DataOnly: anonymized.Events[0].DataOnly}, anonymized.Events[0])
+	assert.Equal(t, &model.BasicAuthProperties{Username: "redacted", Password: "redacted"}, anonymized.Auth.Defs[0].Properties)
+	assert.Equal(t, "event1", anonymized.States[2].GetEnd().ProduceEvents[0].EventRef)
+	assert.Empty(t, anonymized.Metadata)
+	assert.Empty(t, anonymized.Description)
+}
+
+func TestAnonymizeKeepsValidity(t *testing.T) {
+	files, err := filepath.Glob("../parser/testdata/workflows/*.sw.*")
+	require.NoError(t, err)
+	for _, file := range files {
+		workflow, err := parser.FromFile(file)
+		if err != nil {
+			continue
+		}
+		anonymized, err := Anonymize(workflow)
+		require.NoError(t, err, file)
+		assert.NoError(t, validator.Struct(anonymized), file)
+		assert.Len(t, integrity.Validate(anonymized), len(integrity.Validate(workflow)), file)
+		assert.Len(t, anonymized.States, len(workflow.States), file)
+		for i, state := range workflow.States {
+			assert.Equal(t, state.GetType(), anonymized.States[i].GetType(), file)
+		}
+	}
+}
@@ -0,0 +1,494 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// Resolver returns the workflow referenced by a subflow action, e.g. workspace.Workspace.Resolve
+type Resolver func(ref model.WorkflowRef) (*model.Workflow, error)
+
+// InlineSubflows returns a copy of the workflow where the subflow actions of the operation states are replaced with
+// the states of the referenced workflows, recursively, for runtimes that don't support subflows.
+//
+// The inlined states are named after the calling state and the subflow, e.g. 'Pay.payment.Charge', and the actions
+// following a subflow action move to a state named after the index of the first one, e.g. 'Pay.2'. The end of the
+// subflow transitions to what follows the action, the fromStateData and results filters of the action become the
+// input filter of the subflow start state and the output filter of its end states, and the error handlers of the
+// calling state apply to the inlined states too. The definitions of the subflows, such as functions and events, are
+// added to the workflow; the workflow level settings of the subflows, such as timeouts, are not inlined.
+//
+// Subflow actions can't be inlined in other state types, when run in parallel with other actions or with a
+// toStateData filter, and subflows can't continue as another workflow. Since the subflow states operate on the
+// state data instead of a copy, a fromStateData filter replaces the state data rather than selecting a part of it.
+func InlineSubflows(workflow *model.Workflow, resolve Resolver) (*model.Workflow, error) {
+	in := &inliner{resolve: resolve}
+	return in.inline(workflow)
+}
+
+type inliner struct {
+	resolve Resolver
+	// calls keys of the workflows being inlined, the outermost first, to detect cycles
+	calls []string
+}
+
+// exit where the execution continues after inlined states, a transition or the end of the workflow
+type exit struct {
+	transition *model.Transition
+	end        *model.End
+}
+
+func (in *inliner) inline(workflow *model.Workflow) (*model.Workflow, error) {
+	key := workflow.ID
+	if len(workflow.Version) > 0 {
+		key += "@" + workflow.Version
+	}
+	for _, call := range in.calls {
+		if call == key {
+			return nil, fmt.Errorf("subflow cycle %s -> %s", strings.Join(in.calls, " -> "), key)
+		}
+	}
+	in.calls = append(in.calls, key)
+	defer func() { in.calls = in.calls[:len(in.calls)-1] }()
+
+	flat, err := copyWorkflow(workflow)
+	if err != nil {
+		return nil, err
+	}
+	var states []model.State
+	// states whose first action is a subflow are replaced by the subflow start state
+	renames := map[string]string{}
+	for i, state := range flat.States {
+		inlined, entry, err := in.inlineState(flat, state)
+		if err != nil {
+			return nil, fmt.Errorf("states[%d].%w", i, err)
+		}
+		if entry != state.GetName() {
+			renames[state.GetName()] = entry
+		}
+		states = append(states, inlined...)
+	}
+	rename := func(name string) string {
+		if renamed, ok := renames[name]; ok {
+			return renamed
+		}
+		return name
+	}
+	names := map[string]bool{}
+	for _, state := range states {
+		if names[state.GetName()] {
+			return nil, fmt.Errorf("inlined state name %s is already used", state.GetName())
+		}
+		names[state.GetName()] = true
+		renameStateReferences(state, rename)
+	}
+	if flat.Start != nil {
+		flat.Start.StateName = rename(flat.Start.StateName)
+	}
+	flat.States = states
+	return flat, nil
+}
+
+// group consecutive actions of an operation state, a subflow action is always alone in its group
+type group struct {
+	// start index of the first action in the state
+	start   int
+	actions []model.Action
+	subflow bool
+}
+
+// inlineState returns the states replacing the given one and the name of the first one to run
+func (in *inliner) inlineState(flat *model.Workflow, state model.State) ([]model.State, string, error) {
+	op, ok := state.(*model.OperationState)
+	if !ok {
+		for _, action := range model.GetActions(state) {
+			if len(action.SubFlowRef.WorkflowID) > 0 {
+				return nil, "", fmt.Errorf("subFlowRef: subflows of %s states can't be inlined", state.GetType())
+			}
+		}
+		return []model.State{state}, state.GetName(), nil
+	}
+
+	var groups []group
+	subflows := map[string]int{}
+	for i, action := range op.Actions {
+		if len(action.SubFlowRef.WorkflowID) > 0 {
+			groups = append(groups, group{start: i, actions: []model.Action{action}, subflow: true})
+			subflows[action.SubFlowRef.WorkflowID]++
+		} else if len(groups) == 0 || groups[len(groups)-1].subflow {
+			groups = append(groups, group{start: i, actions: []model.Action{action}})
+		} else {
+			groups[len(groups)-1].actions = append(groups[len(groups)-1].actions, action)
+		}
+	}
+	if len(subflows) == 0 {
+		return []model.State{state}, state.GetName(), nil
+	}
+	if op.ActionMode == model.ActionModeParallel && len(op.Actions) > 1 {
+		return nil, "", fmt.Errorf("actions: subflows of parallel actions can't be inlined")
+	}
+
+	// build the states from the last one, each one continues with the entry of the next one
+	var states []model.State
+	var entry string
+	next := exit{transition: op.Transition, end: op.End}
+	for g := len(groups) - 1; g >= 0; g-- {
+		group := groups[g]
+		var input, output string
+		if op.StateDataFilter != nil {
+			if g == 0 {
+				input = op.StateDataFilter.Input
+			}
+			if g == len(groups)-1 {
+				output = op.StateDataFilter.Output
+			}
+		}
+
+		if !group.subflow {
+			piece := &model.OperationState{
+				BaseState: model.BaseState{
+					Name:                op.Name,
+					Type:                model.StateTypeOperation,
+					OnErrors:            copyOnErrors(op.OnErrors),
+					Transition:          next.transition,
+					End:                 next.end,
+					CompensatedBy:       op.CompensatedBy,
+					UsedForCompensation: op.UsedForCompensation,
+					Metadata:            op.Metadata,
+				},
+				ActionMode: op.ActionMode,
+				Actions:    group.actions,
+				Timeouts:   op.Timeouts,
+			}
+			if g == 0 {
+				piece.ID = op.ID
+			} else {
+				piece.Name = fmt.Sprintf("%s.%d", op.Name, group.start)
+			}
+			if len(input) > 0 || len(output) > 0 {
+				piece.StateDataFilter = &model.StateDataFilter{Input: input, Output: output}
+			}
+			states = append([]model.State{piece}, states...)
+			entry = piece.Name
+		} else {
+			inlined, subEntry, err := in.inlineSubflow(flat, op, group, next, input, output, subflows)
+			if err != nil {
+				return nil, "", fmt.Errorf("actions[%d].subFlowRef: %w", group.start, err)
+			}
+			states = append(inlined, states...)
+			entry = subEntry
+		}
+		next = exit{transition: &model.Transition{NextState: entry}}
+	}
+	return states, entry, nil
+}
+
+// inlineSubflow returns the states of the subflow called by the action of the group, and the name of its start state
+func (in *inliner) inlineSubflow(flat *model.Workflow, op *model.OperationState, group group, next exit, input, output string, subflows map[string]int) ([]model.State, string, error) {
+	action := group.actions[0]
+	filter := action.ActionDataFilter
+	if len(filter.ToStateData) > 0 {
+		return nil, "", fmt.Errorf("subflows with a toStateData filter can't be inlined")
+	}
+	if len(input) > 0 && len(filter.FromStateData) > 0 {
+		return nil, "", fmt.Errorf("the fromStateData filter can't be combined with the state input filter")
+	}
+	if len(output) > 0 && len(filter.Results) > 0 {
+		return nil, "", fmt.Errorf("the results filter can't be combined with the state output filter")
+	}
+	input += filter.FromStateData
+	output += filter.Results
+
+	subflow, err := in.resolve(action.SubFlowRef)
+	if err != nil {
+		return nil, "", err
+	}
+	if subflow, err = in.inline(subflow); err != nil {
+		return nil, "", fmt.Errorf("workflow %s: %w", action.SubFlowRef.WorkflowID, err)
+	}
+	if subflow.Start == nil {
+		return nil, "", fmt.Errorf("workflow %s has no start state", subflow.ID)
+	}
+	if err := mergeDefinitions(flat, subflow); err != nil {
+		return nil, "", err
+	}
+
+	prefix := op.Name + "." + action.SubFlowRef.WorkflowID + "."
+	if subflows[action.SubFlowRef.WorkflowID] > 1 {
+		prefix = fmt.Sprintf("%s.%s-%d.", op.Name, action.SubFlowRef.WorkflowID, group.start)
+	}
+	for _, state := range subflow.States {
+		base := baseState(state)
+		start := base.Name == subflow.Start.StateName
+		ends := base.End != nil
+		renameStateReferences(state, func(name string) string { return prefix + name })
+		base.Name = prefix + base.Name
+		if len(base.ID) > 0 {
+			base.ID = prefix + base.ID
+		}
+		if len(output) > 0 && hasEndConditions(state) {
+			return nil, "", fmt.Errorf("the results filter can't be applied to the end conditions of state %s", base.Name)
+		}
+		if err := replaceEnds(state, next); err != nil {
+			return nil, "", fmt.Errorf("state %s: %w", base.Name, err)
+		}
+		if err := addFilter(base, start, input, ends, output); err != nil {
+			return nil, "", fmt.Errorf("state %s: %w", base.Name, err)
+		}
+		base.OnErrors = append(base.OnErrors, copyOnErrors(op.OnErrors)...)
+		if op.UsedForCompensation {
+			base.UsedForCompensation = true
+		}
+	}
+	return subflow.States, prefix + subflow.Start.StateName, nil
+}
+
+// addFilter adds the input filter to the subflow start state and the output filter to its end states
+func addFilter(base *model.BaseState, start bool, input string, ends bool, output string) error {
+	if (!start || len(input) == 0) && (!ends || len(output) == 0) {
+		return nil
+	}
+	if base.StateDataFilter == nil {
+		base.StateDataFilter = &model.StateDataFilter{}
+	}
+	if start && len(input) > 0 {
+		if len(base.StateDataFilter.Input) > 0 {
+			return fmt.Errorf("the fromStateData filter can't be combined with the state input filter")
+		}
+		base.StateDataFilter.Input = input
+	}
+	if ends && len(output) > 0 {
+		if len(base.StateDataFilter.Output) > 0 {
+			return fmt.Errorf("the results filter can't be combined with the state output filter")
+		}
+		base.StateDataFilter.Output = output
+	}
+	return nil
+}
+
+func hasEndConditions(state model.State) bool {
+	switch s := state.(type) {
+	case *model.DataBasedSwitchState:
+		for _, condition := range s.DataConditions {
+			if _, ok := condition.(*model.EndDataCondition); ok {
+				return true
+			}
+		}
+		return len(s.DefaultCondition.Transition.NextState) == 0
+	case *model.EventBasedSwitchState:
+		for _, condition := range s.EventConditions {
+			if _, ok := condition.(*model.EndEventCondition); ok {
+				return true
+			}
+		}
+		return len(s.DefaultCondition.Transition.NextState) == 0
+	}
+	return false
+}
+
+// replaceEnds replaces the ends of the subflow state, including the ones of its error handlers and conditions, with
+// the exit
+func replaceEnds(state model.State, next exit) error {
+	base := baseState(state)
+	var err error
+	if base.End != nil {
+		if base.Transition, base.End, err = convertEnd(*base.End, next); err != nil {
+			return err
+		}
+	}
+	for i := range base.OnErrors {
+		if onError := &base.OnErrors[i]; onError.End != nil {
+			if onError.Transition, onError.End, err = convertEnd(*onError.End, next); err != nil {
+				return err
+			}
+		}
+	}
+	switch s := state.(type) {
+	case *model.DataBasedSwitchState:
+		if err := replaceDefaultEnd(&s.DefaultCondition, next); err != nil {
+			return err
+		}
+		for i, condition := range s.DataConditions {
+			if c, ok := condition.(*model.EndDataCondition); ok {
+				transition, end, err := convertEnd(c.End, next)
+				if err != nil {
+					return err
+				}
+				if transition != nil {
+					s.DataConditions[i] = &model.TransitionDataCondition{BaseDataCondition: c.BaseDataCondition, Transition: *transition}
+				} else {
+					c.End = *end
+				}
+			}
+		}
+	case *model.EventBasedSwitchState:
+		if err := replaceDefaultEnd(&s.DefaultCondition, next); err != nil {
+			return err
+		}
+		for i, condition := range s.EventConditions {
+			if c, ok := condition.(*model.EndEventCondition); ok {
+				transition, end, err := convertEnd(c.End, next)
+				if err != nil {
+					return err
+				}
+				if transition != nil {
+					s.EventConditions[i] = &model.TransitionEventCondition{BaseEventCondition: c.BaseEventCondition, Transition: *transition}
+				} else {
+					c.End = *end
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func replaceDefaultEnd(condition *model.DefaultCondition, next exit) error {
+	if len(condition.Transition.NextState) > 0 {
+		return nil
+	}
+	transition, end, err := convertEnd(condition.End, next)
+	if err != nil {
+		return err
+	}
+	if transition != nil {
+		condition.Transition, condition.End = *transition, model.End{}
+	} else {
+		condition.End = *end
+	}
+	return nil
+}
+
+// convertEnd converts the end of a subflow into the exit, keeping the events it produces and its compensation.
+// Terminating a subflow only ends the subflow.
+func convertEnd(end model.End, next exit) (*model.Transition, *model.End, error) {
+	if end.ContinueAs != nil && len(end.ContinueAs.WorkflowID) > 0 {
+		return nil, nil, fmt.Errorf("subflows continuing as another workflow can't be inlined")
+	}
+	if next.transition != nil {
+		return &model.Transition{
+			NextState:     next.transition.NextState,
+			ProduceEvents: concatEvents(end.ProduceEvents, next.transition.ProduceEvents),
+			Compensate:    end.Compensate || next.transition.Compensate,
+		}, nil, nil
+	}
+	converted := &model.End{Compensate: end.Compensate, ProduceEvents: end.ProduceEvents}
+	if next.end != nil {
+		converted.Terminate = next.end.Terminate
+		converted.Compensate = converted.Compensate || next.end.Compensate
+		converted.ProduceEvents = concatEvents(end.ProduceEvents, next.end.ProduceEvents)
+		converted.ContinueAs = next.end.ContinueAs
+	}
+	return nil, converted, nil
+}
+
+func concatEvents(a, b []model.ProduceEvent) []model.ProduceEvent {
+	if len(a) == 0 {
+		return b
+	}
+	return append(append([]model.ProduceEvent{}, a...), b...)
+}
+
+// copyOnErrors copies the error handlers, so that renaming the states of one copy doesn't affect the others
+func copyOnErrors(onErrors []model.OnError) []model.OnError {
+	copies := make([]model.OnError, len(onErrors))
+	for i, onError := range onErrors {
+		copies[i] = onError
+		if onError.Transition != nil {
+			transition := *onError.Transition
+			copies[i].Transition = &transition
+		}
+		if onError.End != nil {
+			end := *onError.End
+			copies[i].End = &end
+		}
+	}
+	if len(copies) == 0 {
+		return nil
+	}
+	return copies
+}
+
+// mergeDefinitions adds the definitions of the subflow missing from the workflow, the ones defined in both must
+// be identical
+func mergeDefinitions(workflow, subflow *model.Workflow) error {
+	merges := []struct {
+		kind     string
+		from, to interface{}
+	}{
+		{"function", subflow.Functions, &workflow.Functions},
+		{"event", subflow.Events, &workflow.Events},
+		{"error", subflow.Errors, &workflow.Errors},
+		{"retry", subflow.Retries, &workflow.Retries},
+		{"auth", subflow.Auth.Defs, &workflow.Auth.Defs},
+	}
+	for _, merge := range merges {
+		if err := mergeNamed(merge.kind, merge.from, merge.to); err != nil {
+			return fmt.Errorf("%w in workflow %s and %s", err, subflow.ID, workflow.ID)
+		}
+	}
+	for _, secret := range subflow.Secrets {
+		found := false
+		for _, s := range workflow.Secrets {
+			found = found || s == secret
+		}
+		if !found {
+			workflow.Secrets = append(workflow.Secrets, secret)
+		}
+	}
+	if subflow.Constants != nil && len(subflow.Constants.Data) > 0 {
+		if workflow.Constants == nil {
+			workflow.Constants = &model.Constants{}
+		}
+		if workflow.Constants.Data == nil {
+			workflow.Constants.Data = map[string]json.RawMessage{}
+		}
+		for name, value := range subflow.Constants.Data {
+			if existing, ok := workflow.Constants.Data[name]; ok && string(existing) != string(value) {
+				return fmt.Errorf("constant %s is defined differently in workflow %s and %s", name, subflow.ID, workflow.ID)
+			}
+			workflow.Constants.Data[name] = value
+		}
+	}
+	return nil
+}
+
+// mergeNamed appends to the slice to points to the elements of the slice from whose Name isn't in it yet
+func mergeNamed(kind string, from, to interface{}) error {
+	src, dst := reflect.ValueOf(from), reflect.ValueOf(to).Elem()
+	for i := 0; i < src.Len(); i++ {
+		item := src.Index(i)
+		name := item.FieldByName("Name").String()
+		found := false
+		for j := 0; j < dst.Len(); j++ {
+			if dst.Index(j).FieldByName("Name").String() != name {
+				continue
+			}
+			if !reflect.DeepEqual(dst.Index(j).Interface(), item.Interface()) {
+				return fmt.Errorf("%s %s is defined differently", kind, name)
+			}
+			found = true
+		}
+		if !found {
+			dst.Set(reflect.Append(dst, item))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/integrity"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderWorkflow = `id: order
+name: Order
+version: '1.0'
+specVersion: '0.7'
+start: Pay
+errors:
+  - name: Failed
+    code: '500'
+functions:
+  - name: reserve
+    operation: http://orders.json#reserve
+  - name: notify
+    operation: http://orders.json#notify
+states:
+  - name: Pay
+    type: operation
+    actions:
+      - functionRef: reserve
+      - subFlowRef: payment
+        actionDataFilter:
+          fromStateData: ${ .payment }
+          results: ${ .receipt }
+      - functionRef: notify
+    onErrors:
+      - errorRef: Failed
+        transition: Fail
+    transition: Ship
+  - name: Ship
+    type: operation
+    actions:
+      - subFlowRef: shipping
+    end: true
+  - name: Fail
+    type: inject
+    data:
+      failed: true
+    end: true
+`
+
+const paymentWorkflow = `id: payment
+name: Payment
+version: '1.0'
+specVersion: '0.7'
+start: Authorize
+events:
+  - name: Captured
+    type: payment.captured
+    kind: produced
+functions:
+  - name: capture
+    operation: http://payment.json#capture
+  - name: notify
+    operation: http://orders.json#notify
+states:
+  - name: Authorize
+    type: switch
+    dataConditions:
+      - condition: ${ .authorized }
+        transition: Capture
+    defaultCondition:
+      transition: Decline
+  - name: Decline
+    type: inject
+    data:
+      declined: true
+    end: true
+  - name: Capture
+    type: operation
+    actions:
+      - functionRef: capture
+    end:
+      produceEvents:
+        - eventRef: Captured
+`
+
+const shippingWorkflow = `id: shipping
+name: Shipping
+version: '1.0'
+specVersion: '0.7'
+start: Wait
+states:
+  - name: Wait
+    type: sleep
+    duration: PT1H
+    end: true
+`
+
+func parseWorkflows(t *testing.T, sources ...string) []*model.Workflow {
+	var workflows []*model.Workflow
+	for _, source := range sources {
+		workflow, err := parser.FromYAMLSource([]byte(source))
+		require.NoError(t, err)
+		workflows = append(workflows, workflow)
+	}
+	return workflows
+}
+
+func TestInlineSubflows(t *testing.T) {
+	workflows := parseWorkflows(t, orderWorkflow, paymentWorkflow, shippingWorkflow)
+	flat, err := InlineSubflows(workflows[0], workspace.New(workflows...).Resolve)
+	require.NoError(t, err)
+	assert.Empty(t, integrity.Validate(flat))
+	assert.Len(t, workflows[0].States, 3, "the input workflow must not be modified")
+
+	var names []string
+	for _, state := range flat.States {
+		names = append(names, state.GetName())
+	}
+	assert.Equal(t, []string{"Pay", "Pay.payment.Authorize", "Pay.payment.Decline", "Pay.payment.Capture", "Pay.2", "Ship.shipping.Wait", "Fail"}, names)
+	assert.Equal(t, "Pay", flat.Start.StateName)
+
+	pay := flat.States[0].(*model.OperationState)
+	assert.Len(t, pay.Actions, 1)
+	assert.Equal(t, "Pay.payment.Authorize", pay.Transition.NextState)
+
+	authorize := flat.States[1].(*model.DataBasedSwitchState)
+	assert.Equal(t, "Pay.payment.Capture", authorize.DataConditions[0].(*model.TransitionDataCondition).Transition.NextState)
+	assert.Equal(t, "Pay.payment.Decline", authorize.DefaultCondition.Transition.NextState)
+	assert.Equal(t, &model.StateDataFilter{Input: "${ .payment }"}, authorize.StateDataFilter)
+	assert.Equal(t, "Fail", authorize.OnErrors[0].Transition.NextState)
+
+	decline := flat.States[2].(*model.InjectState)
+	assert.Equal(t, &model.Transition{NextState: "Pay.2"}, decline.Transition)
+	assert.Equal(t, &model.StateDataFilter{Output: "${ .receipt }"}, decline.StateDataFilter)
+
+	capture := flat.States[3].(*model.OperationState)
+	assert.Nil(t, capture.End)
+	assert.Equal(t, &model.Transition{NextState: "Pay.2", ProduceEvents: []model.ProduceEvent{{EventRef: "Captured"}}}, capture.Transition)
+	assert.Equal(t, &model.StateDataFilter{Output: "${ .receipt }"}, capture.StateDataFilter)
+
+	rest := flat.States[4].(*model.OperationState)
+	assert.Equal(t, "notify", rest.Actions[0].FunctionRef.RefName)
+	assert.Equal(t, "Ship.shipping.Wait", rest.Transition.NextState, "references to a state starting with a subflow go to the subflow")
+	assert.NotNil(t, flat.States[5].GetEnd())
+
+	assert.Len(t, flat.Functions, 3)
+	assert.Equal(t, "Captured", flat.Events[0].Name)
+}
+
+func TestInlineSubflowsErrors(t *testing.T) {
+	workflows := parseWorkflows(t, orderWorkflow, paymentWorkflow, shippingWorkflow)
+	order, payment := workflows[0], workflows[1]
+	resolve := workspace.New(workflows...).Resolve
+
+	payment.Functions[1].Operation = "http://payment.json#notify"
+	_, err := InlineSubflows(order, resolve)
+	assert.EqualError(t, err, "states[0].actions[1].subFlowRef: function notify is defined differently in workflow payment and order")
+	payment.Functions[1].Operation = "http://orders.json#notify"
+
+	payment.States[2].(*model.OperationState).Actions[0] = model.Action{SubFlowRef: model.WorkflowRef{WorkflowID: "order"}}
+	_, err = InlineSubflows(order, resolve)
+	assert.EqualError(t, err, "states[0].actions[1].subFlowRef: workflow payment: states[2].actions[0].subFlowRef: workflow order: subflow cycle order@1.0 -> payment@1.0 -> order@1.0")
+	payment.States[2].(*model.OperationState).Actions[0] = model.Action{FunctionRef: model.FunctionRef{RefName: "capture"}}
+
+	pay := order.States[0].(*model.OperationState)
+	pay.Actions[1].ActionDataFilter.ToStateData = "${ .payment }"
+	_, err = InlineSubflows(order, resolve)
+	assert.EqualError(t, err, "states[0].actions[1].subFlowRef: subflows with a toStateData filter can't be inlined")
+	pay.Actions[1].ActionDataFilter.ToStateData = ""
+
+	pay.ActionMode = model.ActionModeParallel
+	_, err = InlineSubflows(order, resolve)
+	assert.EqualError(t, err, "states[0].actions: subflows of parallel actions can't be inlined")
+	pay.ActionMode = ""
+
+	authorize := payment.States[0].(*model.DataBasedSwitchState)
+	authorize.DefaultCondition = model.DefaultCondition{}
+	_, err = InlineSubflows(order, resolve)
+	assert.EqualError(t, err, "states[0].actions[1].subFlowRef: the results filter can't be applied to the end conditions of state Pay.payment.Authorize")
+	authorize.DefaultCondition.Transition.NextState = "Decline"
+
+	_, err = InlineSubflows(order, workspace.New(order, payment).Resolve)
+	assert.EqualError(t, err, "states[1].actions[0].subFlowRef: workflow shipping is not in the workspace")
+
+	order.States[1] = &model.ForEachState{
+		BaseState: model.BaseState{Name: "Ship", Type: model.StateTypeForEach, End: &model.End{}},
+		Actions:   []model.Action{{SubFlowRef: model.WorkflowRef{WorkflowID: "shipping"}}},
+	}
+	_, err = InlineSubflows(order, resolve)
+	assert.EqualError(t, err, "states[1].subFlowRef: subflows of foreach states can't be inlined")
+}
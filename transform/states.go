@@ -0,0 +1,95 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"encoding/json"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// copyWorkflow deep copies the workflow so that transforms never modify their input
+func copyWorkflow(workflow *model.Workflow) (*model.Workflow, error) {
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		return nil, err
+	}
+	c := &model.Workflow{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// baseState returns the properties common to every state type
+func baseState(state model.State) *model.BaseState {
+	switch s := state.(type) {
+	case *model.DelayState:
+		return &s.BaseState
+	case *model.EventState:
+		return &s.BaseState
+	case *model.OperationState:
+		return &s.BaseState
+	case *model.ParallelState:
+		return &s.BaseState
+	case *model.InjectState:
+		return &s.BaseState
+	case *model.ForEachState:
+		return &s.BaseState
+	case *model.CallbackState:
+		return &s.BaseState
+	case *model.SleepState:
+		return &s.BaseState
+	case *model.EventBasedSwitchState:
+		return &s.BaseState
+	case *model.DataBasedSwitchState:
+		return &s.BaseState
+	}
+	return nil
+}
+
+// renameStateReferences replaces the names of the states referenced by the state, in its transitions, error
+// handlers, switch conditions and compensation, with the result of rename
+func renameStateReferences(state model.State, rename func(name string) string) {
+	renameTransition := func(transition *model.Transition) {
+		if transition != nil && len(transition.NextState) > 0 {
+			transition.NextState = rename(transition.NextState)
+		}
+	}
+	base := baseState(state)
+	renameTransition(base.Transition)
+	if len(base.CompensatedBy) > 0 {
+		base.CompensatedBy = rename(base.CompensatedBy)
+	}
+	for i := range base.OnErrors {
+		renameTransition(base.OnErrors[i].Transition)
+	}
+	switch s := state.(type) {
+	case *model.DataBasedSwitchState:
+		renameTransition(&s.DefaultCondition.Transition)
+		for _, condition := range s.DataConditions {
+			if c, ok := condition.(*model.TransitionDataCondition); ok {
+				renameTransition(&c.Transition)
+			}
+		}
+	case *model.EventBasedSwitchState:
+		renameTransition(&s.DefaultCondition.Transition)
+		for _, condition := range s.EventConditions {
+			if c, ok := condition.(*model.TransitionEventCondition); ok {
+				renameTransition(&c.Transition)
+			}
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"unicode/utf8"
 )
 
 // Float32OrString is a type that can hold a float32 or a string.
@@ -60,16 +61,40 @@ func Parse(val string) Float32OrString {
 	return FromFloat(float32(f))
 }
 
-// UnmarshalJSON implements the json.Unmarshaller interface.
+// UnmarshalJSON implements the json.Unmarshaller interface. The floats, and the strings of ASCII characters without
+// escape sequences, are decoded directly rather than through encoding/json.
 func (floatstr *Float32OrString) UnmarshalJSON(value []byte) error {
 	if value[0] == '"' {
 		floatstr.Type = String
+		if s, ok := unquote(value); ok {
+			floatstr.StrVal = s
+			return nil
+		}
 		return json.Unmarshal(value, &floatstr.StrVal)
 	}
 	floatstr.Type = Float
+	if f, err := strconv.ParseFloat(string(value), 32); err == nil {
+		floatstr.FloatVal = float32(f)
+		return nil
+	}
+	// null, or an invalid value for which encoding/json reports the error
 	return json.Unmarshal(value, &floatstr.FloatVal)
 }
 
+// unquote returns the content of the JSON string if it has ASCII characters only, and no escape sequence
+func unquote(value []byte) (string, bool) {
+	if len(value) < 2 || value[len(value)-1] != '"' {
+		return "", false
+	}
+	value = value[1 : len(value)-1]
+	for _, c := range value {
+		if c < ' ' || c == '"' || c == '\\' || c >= utf8.RuneSelf {
+			return "", false
+		}
+	}
+	return string(value), true
+}
+
 // MarshalJSON implements the json.Marshaller interface.
 func (floatstr Float32OrString) MarshalJSON() ([]byte, error) {
 	switch floatstr.Type {
@@ -46,6 +46,10 @@ func TestIntOrStringUnmarshalJSON(t *testing.T) {
 	}{
 		{"{\"val\": 123.123}", FromFloat(123.123)},
 		{"{\"val\": \"123.123\"}", FromString("123.123")},
+		{"{\"val\": \"PT1\\u0053\"}", FromString("PT1S")},
+		{"{\"val\": \"d\u00e9lai\"}", FromString("d\u00e9lai")},
+		{"{\"val\": 1e-1}", FromFloat(0.1)},
+		{"{\"val\": null}", FromFloat(0)},
 	}
 
 	for _, c := range cases {
@@ -59,6 +63,28 @@ func TestIntOrStringUnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestIntOrStringUnmarshalJSONErrors(t *testing.T) {
+	for _, input := range []string{`{"val": true}`, `{"val": 1e100}`, `{"val": {}}`} {
+		var result FloatOrStringHolder
+		if err := json.Unmarshal([]byte(input), &result); err == nil {
+			t.Errorf("Expected an error unmarshalling input '%v', got %+v", input, result)
+		}
+	}
+}
+
+func TestIntOrStringUnmarshalJSONAllocations(t *testing.T) {
+	var f Float32OrString
+	value := []byte("0.25")
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := f.UnmarshalJSON(value); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocation unmarshalling a float, got %v", allocs)
+	}
+}
+
 func TestIntOrStringMarshalJSON(t *testing.T) {
 	cases := []struct {
 		input  Float32OrString
@@ -0,0 +1,205 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package iso8601 parses the ISO 8601 durations of the workflows, e.g. the retry delays and the timeouts.
+package iso8601
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration ISO 8601 duration, e.g. 'P1DT12H' or 'PT0.5S'
+type Duration struct {
+	Years   float64
+	Months  float64
+	Weeks   float64
+	Days    float64
+	Hours   float64
+	Minutes float64
+	Seconds float64
+}
+
+// ErrInvalidDuration the errors of the durations not valid ISO 8601 durations match it with errors.Is
+var ErrInvalidDuration = errors.New("invalid ISO 8601 duration")
+
+// durationError duration not valid, matching ErrInvalidDuration
+type durationError struct {
+	value string
+	// err cause of the error if any, e.g. the error parsing a number
+	err error
+}
+
+// Error ...
+func (e *durationError) Error() string {
+	if e.err != nil {
+		return fmt.Sprintf("invalid ISO 8601 duration %s: %v", e.value, e.err)
+	}
+	return "invalid ISO 8601 duration " + e.value
+}
+
+// Is ...
+func (e *durationError) Is(target error) bool {
+	return target == ErrInvalidDuration
+}
+
+// Unwrap ...
+func (e *durationError) Unwrap() error {
+	return e.err
+}
+
+// designators of the components of the durations, in order, those from index clock following the 'T'
+const (
+	designators = "YMWDHMS"
+	clock       = 4
+)
+
+// Parse parses the ISO 8601 duration, the designators being case insensitive. The durations are scanned without
+// allocating, but for the fractions written with a comma, e.g. 'PT1,5S'.
+func Parse(s string) (Duration, error) {
+	d := Duration{}
+	components := [...]*float64{&d.Years, &d.Months, &d.Weeks, &d.Days, &d.Hours, &d.Minutes, &d.Seconds}
+	value := strings.TrimSpace(s)
+	if len(value) < 2 || upper(value[0]) != 'P' {
+		return Duration{}, &durationError{value: s}
+	}
+	// next index of the next designator allowed, components must follow their order
+	next, found, inClock := 0, false, false
+	for i := 1; i < len(value); {
+		if upper(value[i]) == 'T' {
+			if inClock || i == len(value)-1 {
+				return Duration{}, &durationError{value: s}
+			}
+			next, inClock = clock, true
+			i++
+			continue
+		}
+		end := scanNumber(value, i)
+		if end == i || end == len(value) {
+			return Duration{}, &durationError{value: s}
+		}
+		last := clock
+		if inClock {
+			last = len(designators)
+		}
+		for next < last && designators[next] != upper(value[end]) {
+			next++
+		}
+		if next == last {
+			return Duration{}, &durationError{value: s}
+		}
+		number := value[i:end]
+		if strings.IndexByte(number, ',') >= 0 {
+			number = strings.Replace(number, ",", ".", 1)
+		}
+		f, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return Duration{}, &durationError{value: s, err: err}
+		}
+		*components[next] = f
+		next++
+		found = true
+		i = end + 1
+	}
+	if !found {
+		return Duration{}, &durationError{value: s}
+	}
+	return d, nil
+}
+
+// scanNumber returns the end of the number starting at the index, digits with an optional fraction, the index itself
+// if there's no valid number
+func scanNumber(s string, i int) int {
+	end := scanDigits(s, i)
+	if end == i || end == len(s) || (s[end] != '.' && s[end] != ',') {
+		return end
+	}
+	if fraction := scanDigits(s, end+1); fraction > end+1 {
+		return fraction
+	}
+	return i
+}
+
+func scanDigits(s string, i int) int {
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// ParseDuration parses the ISO 8601 duration into a time.Duration, the days being 24 hours long. Durations in years
+// or months are rejected, their length depending on the date they apply to, see Duration.AddTo.
+func ParseDuration(s string) (time.Duration, error) {
+	d, err := Parse(s)
+	if err != nil {
+		return 0, err
+	}
+	return d.Duration()
+}
+
+// Duration returns the duration as a time.Duration, the days being 24 hours long, an error if it has years or months
+func (d Duration) Duration() (time.Duration, error) {
+	if d.Years != 0 || d.Months != 0 {
+		return 0, fmt.Errorf("duration %s in years or months depends on the date it applies to", d)
+	}
+	seconds := ((d.Weeks*7+d.Days)*24+d.Hours)*3600 + d.Minutes*60 + d.Seconds
+	if seconds*float64(time.Second) > math.MaxInt64 {
+		return 0, fmt.Errorf("duration %s overflows", d)
+	}
+	return time.Duration(math.Round(seconds * float64(time.Second))), nil
+}
+
+// AddTo returns the time plus the duration, the years, months and days following the calendar of the time location,
+// e.g. 'P1M' added to January 31st is March 3rd, or 2nd in a leap year, like time.AddDate. Fractional years, months,
+// weeks and days are approximated to 365, 30, 7 and 1 days of 24 hours.
+func (d Duration) AddTo(t time.Time) time.Time {
+	years, yearsFraction := math.Modf(d.Years)
+	months, monthsFraction := math.Modf(d.Months)
+	weeks, weeksFraction := math.Modf(d.Weeks)
+	days, daysFraction := math.Modf(d.Days)
+	t = t.AddDate(int(years), int(months), int(weeks)*7+int(days))
+	hours := (yearsFraction*365+monthsFraction*30+weeksFraction*7+daysFraction)*24 + d.Hours
+	seconds := hours*3600 + d.Minutes*60 + d.Seconds
+	return t.Add(time.Duration(math.Round(seconds * float64(time.Second))))
+}
+
+// String formats the duration, e.g. 'P1DT12H'
+func (d Duration) String() string {
+	format := func(value float64, unit string) string {
+		if value == 0 {
+			return ""
+		}
+		return strconv.FormatFloat(value, 'f', -1, 64) + unit
+	}
+	date := format(d.Years, "Y") + format(d.Months, "M") + format(d.Weeks, "W") + format(d.Days, "D")
+	clock := format(d.Hours, "H") + format(d.Minutes, "M") + format(d.Seconds, "S")
+	if len(clock) > 0 {
+		return "P" + date + "T" + clock
+	}
+	if len(date) == 0 {
+		return "PT0S"
+	}
+	return "P" + date
+}
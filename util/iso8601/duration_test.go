@@ -0,0 +1,88 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iso8601
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"PT1S":         time.Second,
+		"PT0.5S":       500 * time.Millisecond,
+		"PT1,5S":       1500 * time.Millisecond,
+		"PT2M":         2 * time.Minute,
+		"PT1H30M":      90 * time.Minute,
+		"P1D":          24 * time.Hour,
+		"P1DT12H":      36 * time.Hour,
+		"P2W":          14 * 24 * time.Hour,
+		"pt10s":        10 * time.Second,
+		"P0D":          0,
+		"P1DT1H1M1.1S": 25*time.Hour + time.Minute + 1100*time.Millisecond,
+	}
+	for s, expected := range cases {
+		d, err := ParseDuration(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, expected, d, s)
+	}
+
+	for _, s := range []string{"", "P", "PT", "P1DT", "P1Dt", "1S", "PT1", "PT-1S", "P1H", "PT1D", "P1S1D", "PT1.S", "P1DT1HT1M"} {
+		_, err := ParseDuration(s)
+		assert.True(t, errors.Is(err, ErrInvalidDuration), s)
+	}
+	_, err := ParseRepeatingInterval("R/2023-01-01T00:00:00Z/PT1X", time.UTC)
+	assert.True(t, errors.Is(err, ErrInvalidDuration))
+	_, err = ParseDuration("P1M")
+	assert.EqualError(t, err, "duration P1M in years or months depends on the date it applies to")
+}
+
+func TestAddTo(t *testing.T) {
+	start := time.Date(2022, time.January, 31, 10, 0, 0, 0, time.UTC)
+	d, err := Parse("P1M")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, time.March, 3, 10, 0, 0, 0, time.UTC), d.AddTo(start))
+	d, err = Parse("P1Y2DT1H0.5S")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, time.February, 2, 11, 0, 0, 500000000, time.UTC), d.AddTo(start))
+
+	// days follow the calendar across daylight saving time changes
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	d, err = Parse("P1D")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, time.March, 27, 12, 0, 0, 0, paris), d.AddTo(time.Date(2022, time.March, 26, 12, 0, 0, 0, paris)))
+}
+
+func TestString(t *testing.T) {
+	for _, s := range []string{"P1Y2M3W4DT5H6M7.5S", "P1D", "PT1H", "PT0S"} {
+		d, err := Parse(s)
+		require.NoError(t, err)
+		assert.Equal(t, s, d.String())
+	}
+}
+
+func TestParseAllocations(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := Parse("P1Y2M3W4DT5h6m7.5s"); err != nil {
+			t.Fatal(err)
+		}
+	})
+	assert.Zero(t, allocs)
+}
@@ -0,0 +1,124 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iso8601
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unbounded repetitions of a repeating interval without a number of repetitions, e.g. 'R/PT1H'
+const Unbounded = -1
+
+// timeLayouts layouts of the date times without a time offset, read in the location given to ParseTime
+var timeLayouts = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// ParseTime parses the ISO 8601 date time, e.g. '2022-03-01T13:00:00Z', the date times without a time offset being
+// read in the given location
+func ParseTime(s string, location *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t.In(location), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid ISO 8601 date time %s", s)
+}
+
+// RepeatingInterval ISO 8601 repeating interval, e.g. 'R5/2022-03-01T13:00:00Z/PT1H' or 'R/P1D'
+type RepeatingInterval struct {
+	// Repetitions number of intervals, Unbounded if not limited
+	Repetitions int
+	// Start of the first interval, zero if the interval is defined by its duration and end or by its duration only
+	Start time.Time
+	// End of the last interval, zero if the interval is defined by its start and duration or by its duration only
+	End time.Time
+	// Duration of each interval
+	Duration Duration
+}
+
+// ParseRepeatingInterval parses the ISO 8601 repeating interval, the date times without a time offset being read in the
+// given location. The interval is defined by its start and end, start and duration, duration and end or duration
+// only, the duration of an interval defined by its start and end being the time between them.
+func ParseRepeatingInterval(s string, location *time.Location) (RepeatingInterval, error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if len(parts) < 2 || len(parts) > 3 || !strings.HasPrefix(strings.ToUpper(parts[0]), "R") {
+		return RepeatingInterval{}, fmt.Errorf("invalid ISO 8601 repeating interval %s", s)
+	}
+	interval := RepeatingInterval{Repetitions: Unbounded}
+	if count := parts[0][1:]; len(count) > 0 {
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 0 {
+			return RepeatingInterval{}, fmt.Errorf("invalid repetitions in the ISO 8601 repeating interval %s", s)
+		}
+		interval.Repetitions = n
+	}
+
+	var err error
+	switch {
+	case len(parts) == 2:
+		interval.Duration, err = Parse(parts[1])
+	case isDuration(parts[1]):
+		if interval.Duration, err = Parse(parts[1]); err == nil {
+			interval.End, err = ParseTime(parts[2], location)
+		}
+	case isDuration(parts[2]):
+		if interval.Start, err = ParseTime(parts[1], location); err == nil {
+			interval.Duration, err = Parse(parts[2])
+		}
+	default:
+		if interval.Start, err = ParseTime(parts[1], location); err == nil {
+			interval.End, err = ParseTime(parts[2], location)
+		}
+		if err == nil && !interval.End.After(interval.Start) {
+			return RepeatingInterval{}, fmt.Errorf("the ISO 8601 repeating interval %s ends before it starts", s)
+		}
+		interval.Duration = Duration{Seconds: interval.End.Sub(interval.Start).Seconds()}
+		interval.End = time.Time{}
+	}
+	if err != nil {
+		return RepeatingInterval{}, fmt.Errorf("invalid ISO 8601 repeating interval %s: %w", s, err)
+	}
+	if interval.Duration == (Duration{}) {
+		return RepeatingInterval{}, fmt.Errorf("the ISO 8601 repeating interval %s has an empty duration", s)
+	}
+	return interval, nil
+}
+
+// Scale returns the duration multiplied by the factor, e.g. 'P1M' scaled by 3 is 'P3M'
+func (d Duration) Scale(factor float64) Duration {
+	return Duration{
+		Years:   d.Years * factor,
+		Months:  d.Months * factor,
+		Weeks:   d.Weeks * factor,
+		Days:    d.Days * factor,
+		Hours:   d.Hours * factor,
+		Minutes: d.Minutes * factor,
+		Seconds: d.Seconds * factor,
+	}
+}
+
+func isDuration(s string) bool {
+	return strings.HasPrefix(strings.ToUpper(strings.TrimSpace(s)), "P")
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTime(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	at, err := ParseTime("2022-03-01T13:00:00Z", paris)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(time.Date(2022, time.March, 1, 13, 0, 0, 0, time.UTC)))
+	assert.Equal(t, paris, at.Location())
+
+	at, err = ParseTime("2022-03-01T13:00:00", paris)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)))
+	at, err = ParseTime("2022-03-01", paris)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(time.Date(2022, time.February, 28, 23, 0, 0, 0, time.UTC)))
+
+	_, err = ParseTime("March 1st", time.UTC)
+	assert.EqualError(t, err, "invalid ISO 8601 date time March 1st")
+}
+
+func TestParseRepeatingInterval(t *testing.T) {
+	start := time.Date(2022, time.March, 1, 13, 0, 0, 0, time.UTC)
+	cases := map[string]RepeatingInterval{
+		"R5/2022-03-01T13:00:00Z/PT1H": {Repetitions: 5, Start: start, Duration: Duration{Hours: 1}},
+		"R/PT1H/2022-03-01T13:00:00Z":  {Repetitions: Unbounded, End: start, Duration: Duration{Hours: 1}},
+		"R2/2022-03-01T13:00:00Z/2022-03-01T13:30:00Z": {
+			Repetitions: 2, Start: start, Duration: Duration{Seconds: 1800},
+		},
+		"R/P1D":  {Repetitions: Unbounded, Duration: Duration{Days: 1}},
+		"r0/P1M": {Repetitions: 0, Duration: Duration{Months: 1}},
+	}
+	for s, expected := range cases {
+		interval, err := ParseRepeatingInterval(s, time.UTC)
+		require.NoError(t, err, s)
+		assert.Equal(t, expected, interval, s)
+	}
+
+	for s, message := range map[string]string{
+		"PT1H":                    "invalid ISO 8601 repeating interval PT1H",
+		"R/PT1H/PT1H/PT1H":        "invalid ISO 8601 repeating interval R/PT1H/PT1H/PT1H",
+		"Rx/PT1H":                 "invalid repetitions in the ISO 8601 repeating interval Rx/PT1H",
+		"R/PT0S":                  "the ISO 8601 repeating interval R/PT0S has an empty duration",
+		"R/2022-03-01/PT1":        "invalid ISO 8601 repeating interval R/2022-03-01/PT1: invalid ISO 8601 duration PT1",
+		"R/2022-03-02/2022-03-01": "the ISO 8601 repeating interval R/2022-03-02/2022-03-01 ends before it starts",
+	} {
+		_, err := ParseRepeatingInterval(s, time.UTC)
+		assert.EqualError(t, err, message, s)
+	}
+}
+
+func TestScale(t *testing.T) {
+	assert.Equal(t, Duration{Months: 3, Hours: -3}, Duration{Months: 1, Hours: -1}.Scale(3))
+}
@@ -0,0 +1,180 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v2/internal/cache"
+	"github.com/serverlessworkflow/sdk-go/v2/logging"
+)
+
+// processID random identifier of the process, so that the rules of the custom validations of a process are never
+// taken for those of another one sharing a MemoCache
+var processID = newProcessID()
+
+func newProcessID() string {
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(id[:])
+}
+
+// MemoCache keys of the values a Memo validated successfully. The implementations must be safe for concurrent use,
+// and may forget keys, the values then being validated again.
+type MemoCache interface {
+	// Contains whether the key was added
+	Contains(key string) bool
+	// Add adds the key
+	Add(key string)
+}
+
+// memoryCache MemoCache keeping the most recently used keys in memory
+type memoryCache struct {
+	lru *cache.LRU
+}
+
+// NewMemoryCache returns a MemoCache keeping the size most recently used keys in memory, e.g. for the controllers
+// reconciling the same workflows over and over
+func NewMemoryCache(size int) MemoCache {
+	return &memoryCache{lru: cache.New(size)}
+}
+
+func (c *memoryCache) Contains(key string) bool {
+	_, ok := c.lru.Get(key)
+	return ok
+}
+
+func (c *memoryCache) Add(key string) {
+	c.lru.Add(key, struct{}{})
+}
+
+// dirCache MemoCache keeping the keys as empty files of a directory
+type dirCache struct {
+	dir string
+}
+
+// NewDirCache returns a MemoCache keeping the keys as empty files of the directory, created if needed, e.g. a CI cache
+// restored between the runs. The keys failing to be written are forgotten. The directory is never cleaned up, it
+// should be cleared when upgrading the SDK, whose validations may change.
+func NewDirCache(dir string) MemoCache {
+	return &dirCache{dir: dir}
+}
+
+func (c *dirCache) Contains(key string) bool {
+	_, err := os.Stat(filepath.Join(c.dir, key))
+	return err == nil
+}
+
+func (c *dirCache) Add(key string) {
+	if err := os.MkdirAll(c.dir, 0750); err != nil {
+		return
+	}
+	_ = ioutil.WriteFile(filepath.Join(c.dir, key), nil, 0600)
+}
+
+// Fingerprint returns the fingerprint of the value, the hash of its type and JSON. The values marshaling to the same
+// JSON have the same fingerprint, the fields not marshaled are ignored.
+func Fingerprint(current interface{}) (string, error) {
+	data, err := json.Marshal(current)
+	if err != nil {
+		return "", err
+	}
+	return cache.Key([]byte(reflect.TypeOf(current).String()), data), nil
+}
+
+// Memo memoizes the validations of a Validator: a value whose fingerprint was validated successfully with the same
+// rules isn't validated again. The validators without custom validations share their rules, those with custom
+// validations have their own, changing with every registration. Invalid values are validated every time, to report
+// their errors, as are all the values once GetValidator handed out the validator.Validate of the default validator.
+// A Memo is safe for concurrent use if its Cache is.
+type Memo struct {
+	// Validator validator of the values, default is Default()
+	Validator *Validator
+	// Cache keys of the values validated
+	Cache MemoCache
+	// Logger of the validations skipped, default is the logger of the Validator
+	Logger logging.Logger
+}
+
+// NewMemo returns a memo of the validations of the validator, default is Default(), keeping the keys of the values
+// validated in the cache, an in memory cache of 4,096 keys if nil
+func NewMemo(v *Validator, c MemoCache) *Memo {
+	if c == nil {
+		c = NewMemoryCache(4096)
+	}
+	return &Memo{Validator: v, Cache: c}
+}
+
+// Struct validates the struct like Validator.Struct, unless its fingerprint was already validated with the same
+// rules. The structs that fail to marshal are always validated.
+func (m *Memo) Struct(current interface{}) error {
+	fingerprint, err := Fingerprint(current)
+	if err != nil {
+		return m.validator().Struct(current)
+	}
+	return m.StructFingerprint(current, fingerprint)
+}
+
+// StructFingerprint validates the struct like Struct, with the given fingerprint, e.g. the hash of its source or the
+// generation of the resource holding it, saving its computation. The fingerprint must change with the struct.
+func (m *Memo) StructFingerprint(current interface{}, fingerprint string) error {
+	// the rules of the key are those validating
+	rules := m.validator().snapshot()
+	if len(rules.key()) == 0 {
+		return rules.Struct(current)
+	}
+	key := memoKey(rules, fingerprint)
+	if m.Cache.Contains(key) {
+		if logger := m.logger(rules); logger != nil {
+			logger.Debug("validation skipped, already validated with the same rules", logging.Type, reflect.TypeOf(current).String(), logging.Fingerprint, fingerprint)
+		}
+		return nil
+	}
+	if err := rules.Struct(current); err != nil {
+		return err
+	}
+	m.Cache.Add(key)
+	return nil
+}
+
+// memoKey returns the key of the value of the given fingerprint validated with the rules
+func memoKey(rules *ruleSet, fingerprint string) string {
+	return cache.Key([]byte(rules.key()), []byte(fingerprint))
+}
+
+func (m *Memo) validator() *Validator {
+	if m.Validator == nil {
+		return Default()
+	}
+	return m.Validator
+}
+
+// logger returns the logger of the memo, nil if none
+func (m *Memo) logger(rules *ruleSet) logging.Logger {
+	if m.Logger == nil {
+		return rules.logger
+	}
+	return m.Logger
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/validator.v8"
+)
+
+func TestMemo(t *testing.T) {
+	v := New()
+	calls := 0
+	v.RegisterStructValidation(func(v *validator.Validate, structLevel *validator.StructLevel) {
+		calls++
+		positiveTotal(v, structLevel)
+	}, order{})
+	memo := NewMemo(v, nil)
+
+	require.NoError(t, memo.Struct(order{ID: "1", Total: 10}))
+	require.NoError(t, memo.Struct(order{ID: "1", Total: 10}))
+	assert.Equal(t, 1, calls)
+	require.NoError(t, memo.Struct(order{ID: "2", Total: 10}))
+	assert.Equal(t, 2, calls)
+
+	// the invalid values are validated every time
+	assert.Error(t, memo.Struct(order{ID: "3"}))
+	assert.Error(t, memo.Struct(order{ID: "3"}))
+	assert.Equal(t, 4, calls)
+
+	// new rules invalidate the values validated
+	require.NoError(t, v.RegisterValidation("never", func(*validator.Validate, reflect.Value, reflect.Value, reflect.Value, reflect.Type, reflect.Kind, string) bool {
+		return false
+	}))
+	require.NoError(t, memo.Struct(order{ID: "1", Total: 10}))
+	assert.Equal(t, 5, calls)
+
+	// so do other validators
+	other := New()
+	other.RegisterStructValidation(positiveTotal, order{})
+	otherMemo := &Memo{Validator: other, Cache: memo.Cache}
+	require.NoError(t, otherMemo.Struct(order{ID: "1", Total: 10}))
+	assert.Equal(t, 5, calls)
+	require.NoError(t, memo.Struct(order{ID: "1", Total: 10}))
+	assert.Equal(t, 5, calls)
+
+	assert.Equal(t, "default/"+strconv.Itoa(len(defaultRegistrations)), New().snapshot().key())
+	assert.Equal(t, New().snapshot().key(), New().snapshot().key())
+	assert.NotEqual(t, v.snapshot().key(), other.snapshot().key())
+}
+
+func TestMemoLogger(t *testing.T) {
+	var skipped []string
+	logger := logging.Func(func(msg string, args ...interface{}) {
+		skipped = append(skipped, args[3].(string))
+	})
+	fingerprint, err := Fingerprint(order{ID: "1"})
+	require.NoError(t, err)
+	memo := &Memo{Validator: New(), Cache: NewMemoryCache(1), Logger: logger}
+	require.NoError(t, memo.Struct(order{ID: "1"}))
+	assert.Empty(t, skipped)
+	require.NoError(t, memo.Struct(order{ID: "1"}))
+	assert.Equal(t, []string{fingerprint}, skipped)
+
+	// the logger of the validator by default
+	v := New()
+	v.SetLogger(logger)
+	memo = NewMemo(v, nil)
+	require.NoError(t, memo.Struct(order{ID: "1"}))
+	require.NoError(t, memo.Struct(order{ID: "1"}))
+	assert.Equal(t, []string{fingerprint, fingerprint}, skipped)
+}
+
+func TestMemoFingerprint(t *testing.T) {
+	first, err := Fingerprint(order{ID: "1"})
+	require.NoError(t, err)
+	second, err := Fingerprint(&order{ID: "1"})
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+	third, err := Fingerprint(order{ID: "1", Total: 1})
+	require.NoError(t, err)
+	assert.NotEqual(t, first, third)
+	_, err = Fingerprint(make(chan int))
+	assert.Error(t, err)
+
+	memo := NewMemo(nil, nil)
+	invalid := validatable{err: validator.ValidationErrors{}}
+	assert.Equal(t, invalid.err, memo.StructFingerprint(invalid, "fingerprint"))
+	memo.Cache.Add(memoKey(Default().snapshot(), "fingerprint"))
+	assert.NoError(t, memo.StructFingerprint(invalid, "fingerprint"))
+}
+
+func TestDirCache(t *testing.T) {
+	dir := t.TempDir()
+	c := NewDirCache(dir + "/memo")
+	assert.False(t, c.Contains("key"))
+	c.Add("key")
+	assert.True(t, c.Contains("key"))
+	assert.True(t, NewDirCache(dir+"/memo").Contains("key"))
+	assert.False(t, c.Contains("other"))
+}
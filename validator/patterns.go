@@ -0,0 +1,100 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/internal/cache"
+	"gopkg.in/go-playground/validator.v8"
+)
+
+// durationNumber number of a component of an ISO 8601 duration, the fraction being separated by a dot or a comma
+const durationNumber = `\d+(?:[.,]\d+)?`
+
+var (
+	// CronPattern cron expressions of five or six fields, or descriptors such as '@daily'. The values of the fields
+	// aren't checked, see the schedule package.
+	CronPattern = regexp.MustCompile(`(?i)^\s*(?:@(?:yearly|annually|monthly|weekly|daily|midnight|hourly)|[0-9A-Z*?/,-]+(?:\s+[0-9A-Z*?/,-]+){4,5})\s*$`)
+	// DurationPattern ISO 8601 durations of at least one component, e.g. 'PT1S' or 'P1DT12H'
+	DurationPattern = regexp.MustCompile(`(?i)^P(?:(?:` + durationComponents("YMWD") + `)(?:T(?:` +
+		durationComponents("HMS") + `))?|T(?:` + durationComponents("HMS") + `))$`)
+	// URIPattern absolute URIs, e.g. 'https://api.example.com/openapi.json#store' or 'file://functions.json'
+	URIPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*:\S+$`)
+)
+
+// patternTags validation tags of the predefined patterns, registered on every validator
+var patternTags = map[string]*regexp.Regexp{
+	"cron":            CronPattern,
+	"iso8601duration": DurationPattern,
+	"absoluteuri":     URIPattern,
+}
+
+// patterns regular expressions compiled by Pattern, shared by all the validators and their users
+var patterns = cache.New(1024)
+
+// durationComponents alternatives matching at least one of the components of the given designators, in order
+func durationComponents(designators string) string {
+	alternatives := make([]string, len(designators))
+	for i := range designators {
+		alternative := durationNumber + designators[i:i+1]
+		for _, designator := range designators[i+1:] {
+			alternative += "(?:" + durationNumber + string(designator) + ")?"
+		}
+		alternatives[i] = alternative
+	}
+	return strings.Join(alternatives, "|")
+}
+
+// Pattern returns the compiled regular expression, compiled once and shared with the validation tags registered by
+// RegisterPattern and the other users of the package, e.g. the naming rules of the linter. The invalid expressions
+// aren't cached.
+func Pattern(expr string) (*regexp.Regexp, error) {
+	compiled, err := patterns.GetOrCompile(expr, func() (interface{}, error) {
+		return regexp.Compile(expr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return compiled.(*regexp.Regexp), nil
+}
+
+// RegisterPattern registers a validation tag checking that the strings match the regular expression, compiled once
+// when registered rather than by every validation
+func (v *Validator) RegisterPattern(tag, expr string) error {
+	pattern, err := Pattern(expr)
+	if err != nil {
+		return err
+	}
+	return v.RegisterValidation(tag, matchPattern(pattern))
+}
+
+// registerPatternTags registers the validation tags of the predefined patterns, e.g. 'cron'
+func registerPatternTags(validate *validator.Validate) {
+	for tag, pattern := range patternTags {
+		if err := validate.RegisterValidation(tag, matchPattern(pattern)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// matchPattern validation of the strings matching the pattern, the fields of other kinds being invalid
+func matchPattern(pattern *regexp.Regexp) validator.Func {
+	return func(_ *validator.Validate, _, _, field reflect.Value, _ reflect.Type, kind reflect.Kind, _ string) bool {
+		return kind == reflect.String && pattern.MatchString(field.String())
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPredefinedPatterns(t *testing.T) {
+	for _, s := range []string{"0 */5 * * *", "0 0 12 * * MON-FRI", "@daily", " 15 10 ? * 6L "} {
+		assert.True(t, CronPattern.MatchString(s), s)
+	}
+	for _, s := range []string{"", "* * * *", "0 0 0 * * * *", "@often", "1 2 3 4 $"} {
+		assert.False(t, CronPattern.MatchString(s), s)
+	}
+	for _, s := range []string{"PT1S", "P1DT12H", "pt0.5s", "PT1,5S", "P2W", "P1Y2M3W4DT5H6M7S", "PT1H30M"} {
+		assert.True(t, DurationPattern.MatchString(s), s)
+	}
+	for _, s := range []string{"", "P", "PT", "P1DT", "PT1D", "P1H", "PT1S1M", "1S"} {
+		assert.False(t, DurationPattern.MatchString(s), s)
+	}
+	for _, s := range []string{"https://api.example.com/openapi.json#store", "file://functions.json", "urn:isbn:0451450523"} {
+		assert.True(t, URIPattern.MatchString(s), s)
+	}
+	for _, s := range []string{"", "functions.json", "http://api.example.com/open api.json", "1http://example.com"} {
+		assert.False(t, URIPattern.MatchString(s), s)
+	}
+}
+
+func TestPattern(t *testing.T) {
+	first, err := Pattern("^[a-z]+$")
+	require.NoError(t, err)
+	second, err := Pattern("^[a-z]+$")
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	_, err = Pattern("[a-z")
+	assert.Error(t, err)
+	assert.Error(t, New().RegisterPattern("lowercase", "[a-z"))
+}
+
+func TestMatchPattern(t *testing.T) {
+	match := matchPattern(CronPattern)
+	for value, valid := range map[interface{}]bool{"@hourly": true, "@often": false, 5: false} {
+		field := reflect.ValueOf(value)
+		assert.Equal(t, valid, match(nil, reflect.Value{}, reflect.Value{}, field, field.Type(), field.Kind(), ""), value)
+	}
+
+	v := New()
+	require.NoError(t, v.RegisterPattern("lowercase", "^[a-z]+$"))
+	assert.True(t, v.snapshot().custom)
+}
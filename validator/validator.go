@@ -14,17 +14,294 @@
 
 package validator
 
-import "gopkg.in/go-playground/validator.v8"
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/serverlessworkflow/sdk-go/v2/logging"
+	"gopkg.in/go-playground/validator.v8"
+)
 
 // TODO: expose a better validation message. See: https://pkg.go.dev/gopkg.in/go-playground/validator.v8#section-documentation
 
-var validate *validator.Validate
+// defaultValidator instance wrapping the default validator.Validate
+var defaultValidator *Validator
+
+// registration registers a validation on a validator.Validate, replayed on the new validator.Validate of every
+// registration
+type registration func(validate *validator.Validate) error
+
+func registerStructValidation(fn validator.StructLevelFunc, types []interface{}) registration {
+	return func(validate *validator.Validate) error {
+		validate.RegisterStructValidation(fn, types...)
+		return nil
+	}
+}
+
+var (
+	defaultsMutex sync.Mutex
+	// defaultRegistrations struct level validations registered on every validator, e.g. by the model package
+	defaultRegistrations []registration
+	// structLevels current *structLevelRules of the default struct level validations
+	structLevels atomic.Value
+	// validators number of validators created, identifying their rules
+	validators uint64
+)
+
+// structLevelTag tag name of the validator.Validate of the default struct level validations, no field having it
+const structLevelTag = "structlevel"
+
+// structLevelRules the default struct level validations, run by the generated functions. Never modified once stored.
+type structLevelRules struct {
+	// validate runs the struct level validations only, the fields having no structLevelTag
+	validate *validator.Validate
+	// types having struct level validations
+	types map[reflect.Type]bool
+}
 
 func init() {
-	validate = validator.New(&validator.Config{TagName: "validate"})
+	defaultValidator = &Validator{}
+	defaultValidator.rules.Store(&ruleSet{validate: newValidate()})
+	structLevels.Store(&structLevelRules{validate: validator.New(&validator.Config{TagName: structLevelTag})})
 }
 
-// GetValidator gets the default validator.Validate reference
-func GetValidator() *validator.Validate {
+func newValidate() *validator.Validate {
+	validate := validator.New(&validator.Config{TagName: "validate"})
+	registerPatternTags(validate)
 	return validate
 }
+
+// GetValidator gets the validator.Validate of the default validator. The registrations made on it directly can't be
+// told, so once it was handed out, even if nothing is registered on it, the default validator validates the tags with
+// it rather than with the generated functions, so that the validations registered on it run, e.g. when parsing with the
+// package functions of the parser, and its validations aren't memoized. This lasts for the whole process and makes the
+// validations with the default validator several times slower: don't call it unless registering on it.
+//
+// Registering on it is deprecated: validator.v8 requires the registrations to happen before validating, the
+// registrations on the default validator are then made on it too. Register with RegisterDefaultStructValidation or on
+// Default() instead, at any time.
+func GetValidator() *validator.Validate {
+	return defaultValidator.share()
+}
+
+// RegisterDefaultStructValidation registers a struct level validation on the default validator and on every
+// Validator created afterwards, e.g. the validations the model types always need. It's safe to call at any time, the
+// validations in progress ending with the validations they started with.
+func RegisterDefaultStructValidation(fn validator.StructLevelFunc, types ...interface{}) {
+	defaultsMutex.Lock()
+	defer defaultsMutex.Unlock()
+	r := registerStructValidation(fn, types)
+	defaultRegistrations = append(defaultRegistrations, r)
+	// struct validations can't fail
+	_ = defaultValidator.register(r, false, true)
+
+	current := structLevels.Load().(*structLevelRules)
+	rules := &structLevelRules{validate: validator.New(&validator.Config{TagName: structLevelTag}), types: map[reflect.Type]bool{}}
+	for _, registered := range defaultRegistrations {
+		_ = registered(rules.validate)
+	}
+	for t := range current.types {
+		rules.types[t] = true
+	}
+	for _, t := range types {
+		rules.types[indirect(reflect.TypeOf(t))] = true
+	}
+	structLevels.Store(rules)
+}
+
+// StructLevel runs the struct level validations registered by RegisterDefaultStructValidation for the type of the
+// struct, ignoring the validate tags, nil if there are none. The generated validation functions run them this way.
+func StructLevel(current interface{}) error {
+	rules := structLevels.Load().(*structLevelRules)
+	if !rules.types[indirect(reflect.TypeOf(current))] {
+		return nil
+	}
+	return rules.validate.Struct(current)
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// Validatable types with generated validation functions, e.g. the model types. See hack/validate
+type Validatable interface {
+	// Validate validates the value against the validate tags of its fields, returning validator.ValidationErrors
+	// like the default validator
+	Validate() error
+}
+
+// Struct validates the struct with its generated validation function if it has one, with the validate tags of its
+// fields through the default validator otherwise. The generated functions run the struct level validations registered
+// by RegisterDefaultStructValidation, the default validator falling back to the tags once other validations may have
+// been registered on it, see GetValidator.
+func Struct(current interface{}) error {
+	return defaultValidator.Struct(current)
+}
+
+// Validator validates the workflows with its own validator.Validate: the validations registered on a Validator don't
+// affect the other ones, e.g. those of the other libraries of the process. The zero value isn't usable, create the
+// validators with New. Validations can be registered at any time, e.g. by plugins loaded once the validator is in use:
+// every registration builds a new validator.Validate, swapped in once complete, the validations in progress ending
+// with the rules they started with.
+type Validator struct {
+	// mutex serializes the registrations
+	mutex sync.Mutex
+	// registrations validations registered, in order, the default struct validations first
+	registrations []registration
+	// rules current *ruleSet of the validator
+	rules atomic.Value
+	// id identifies the validator, for the rules of its custom validations
+	id uint64
+}
+
+// ruleSet snapshot of the rules of a Validator, never modified once stored
+type ruleSet struct {
+	validate *validator.Validate
+	// custom whether validations were registered on the validator, the generated functions not running them
+	custom bool
+	// shared whether validate was handed out by GetValidator, the validations registered on it being unknown
+	shared bool
+	// id of the validator and generation of its registrations, for the rules of its custom validations
+	id         uint64
+	generation uint64
+	// defaults number of default struct validations of the validator
+	defaults int
+	// logger of the debug events, nil if none
+	logger logging.Logger
+}
+
+// New returns a validator of the validate tags with the default struct level validations, those of the model types,
+// and the tags of the predefined patterns, 'cron', 'iso8601duration' and 'absoluteuri'
+func New() *Validator {
+	defaultsMutex.Lock()
+	defer defaultsMutex.Unlock()
+	v := &Validator{id: atomic.AddUint64(&validators, 1)}
+	v.registrations = append([]registration(nil), defaultRegistrations...)
+	validate := newValidate()
+	for _, r := range v.registrations {
+		// the default struct validations can't fail
+		_ = r(validate)
+	}
+	v.rules.Store(&ruleSet{validate: validate, id: v.id, defaults: len(v.registrations)})
+	return v
+}
+
+// Default returns the default validator, the one used by Struct and wrapping GetValidator()
+func Default() *Validator {
+	return defaultValidator
+}
+
+// RegisterStructValidation registers a struct level validation of the given types on the validator only
+func (v *Validator) RegisterStructValidation(fn validator.StructLevelFunc, types ...interface{}) {
+	// struct validations can't fail
+	_ = v.register(registerStructValidation(fn, types), true, false)
+}
+
+// RegisterValidation registers a validation tag on the validator only
+func (v *Validator) RegisterValidation(key string, fn validator.Func) error {
+	return v.register(func(validate *validator.Validate) error {
+		return validate.RegisterValidation(key, fn)
+	}, true, false)
+}
+
+// register adds the registration to the validator, swapping in a new validator.Validate with all the registrations.
+// The registrations failing are dropped, the validator being unchanged. custom tells the custom validations from the
+// default ones. The validator.Validate handed out by GetValidator is kept instead, with the registrations made on it
+// directly.
+func (v *Validator) register(r registration, custom, defaults bool) error {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	current := *v.snapshot()
+	validate := current.validate
+	if !current.shared {
+		validate = newValidate()
+		for _, previous := range v.registrations {
+			// the registrations already succeeded once
+			_ = previous(validate)
+		}
+	}
+	if err := r(validate); err != nil {
+		return err
+	}
+	v.registrations = append(v.registrations, r)
+	current.validate = validate
+	current.custom = current.custom || custom
+	if custom {
+		current.generation++
+	}
+	if defaults {
+		current.defaults++
+	}
+	v.rules.Store(&current)
+	return nil
+}
+
+// SetLogger sets the logger of the debug events of the validator, e.g. the validations done by the generated functions
+// instead of the validate tags, none if nil
+func (v *Validator) SetLogger(logger logging.Logger) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	current := *v.snapshot()
+	current.logger = logger
+	v.rules.Store(&current)
+}
+
+// share returns the validator.Validate of the validator, kept from then on. The validations registered on it directly
+// can't be told, the validator considers it has custom validations.
+func (v *Validator) share() *validator.Validate {
+	if rules := v.snapshot(); rules.shared {
+		return rules.validate
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	current := *v.snapshot()
+	if !current.shared {
+		current.shared = true
+		current.custom = true
+		current.generation++
+		v.rules.Store(&current)
+	}
+	return current.validate
+}
+
+// snapshot returns the current rules of the validator
+func (v *Validator) snapshot() *ruleSet {
+	return v.rules.Load().(*ruleSet)
+}
+
+// Struct validates the struct with its generated validation function if it has one and no validations but the default
+// struct level ones were registered on the validator, with the validate tags of its fields and the registered
+// validations otherwise
+func (v *Validator) Struct(current interface{}) error {
+	return v.snapshot().Struct(current)
+}
+
+// Struct validates the struct with the rules
+func (r *ruleSet) Struct(current interface{}) error {
+	if validatable, ok := current.(Validatable); ok && !r.custom {
+		if r.logger != nil {
+			r.logger.Debug("validate tags skipped, validating with the generated function", logging.Type, reflect.TypeOf(current).String())
+		}
+		return validatable.Validate()
+	}
+	return r.validate.Struct(current)
+}
+
+// key identifies the rules: the validators without custom validations share the rules of the default struct
+// validations they have, the others have their own rules, changing with every registration and unique to the process
+// since the registered functions can't be compared. The rules of a validator.Validate handed out by GetValidator
+// can't be identified, their key is empty.
+func (r *ruleSet) key() string {
+	if r.shared {
+		return ""
+	}
+	if !r.custom {
+		return "default/" + strconv.Itoa(r.defaults)
+	}
+	return processID + "/" + strconv.FormatUint(r.id, 10) + "/" + strconv.FormatUint(r.generation, 10)
+}
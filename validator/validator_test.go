@@ -0,0 +1,163 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/logging"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/go-playground/validator.v8"
+)
+
+type order struct {
+	ID    string
+	Total float64
+}
+
+func positiveTotal(v *validator.Validate, structLevel *validator.StructLevel) {
+	if structLevel.CurrentStruct.Interface().(order).Total <= 0 {
+		structLevel.ReportError(reflect.ValueOf(0), "Total", "total", "positive")
+	}
+}
+
+func TestValidatorIsolation(t *testing.T) {
+	first, second := New(), New()
+	first.RegisterStructValidation(positiveTotal, order{})
+
+	assert.Error(t, first.Struct(order{ID: "1"}))
+	assert.NoError(t, first.Struct(order{ID: "1", Total: 10}))
+	assert.NoError(t, second.Struct(order{ID: "1"}))
+	assert.NoError(t, Struct(order{ID: "1"}))
+	assert.NoError(t, Default().Struct(order{ID: "1"}))
+}
+
+type validatable struct {
+	err error
+}
+
+func (v validatable) Validate() error {
+	return v.err
+}
+
+func TestValidatorGeneratedFunctions(t *testing.T) {
+	invalid := validatable{err: validator.ValidationErrors{}}
+	assert.Equal(t, invalid.err, New().Struct(invalid))
+
+	// the generated functions don't run the registered validations, the tags are validated instead
+	custom := New()
+	assert.NoError(t, custom.RegisterValidation("positive", func(*validator.Validate, reflect.Value, reflect.Value, reflect.Value, reflect.Type, reflect.Kind, string) bool {
+		return true
+	}))
+	assert.NoError(t, custom.Struct(invalid))
+
+	var logged []interface{}
+	logger := New()
+	logger.SetLogger(logging.Func(func(msg string, args ...interface{}) {
+		logged = append(append(logged, msg), args...)
+	}))
+	assert.Equal(t, invalid.err, logger.Struct(invalid))
+	assert.Equal(t, []interface{}{"validate tags skipped, validating with the generated function", logging.Type, "validator.validatable"}, logged)
+}
+
+func TestValidatorConcurrentRegistrations(t *testing.T) {
+	v := New()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = v.Struct(order{ID: "1"})
+			}
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, v.RegisterPattern("ticket"+strconv.Itoa(i), "^[A-Z]+-[0-9]+$"))
+	}
+	v.RegisterStructValidation(positiveTotal, order{})
+	wg.Wait()
+	assert.Error(t, v.Struct(order{ID: "1"}))
+	assert.Equal(t, uint64(21), v.snapshot().generation)
+}
+
+func TestValidatorSnapshot(t *testing.T) {
+	v := New()
+	before := v.snapshot()
+	v.RegisterStructValidation(positiveTotal, order{})
+	// the validations started before the registration keep their rules
+	assert.NoError(t, before.Struct(order{ID: "1"}))
+	assert.Error(t, v.Struct(order{ID: "1"}))
+
+	// the registrations failing leave the validator unchanged
+	current := v.snapshot()
+	assert.Error(t, v.register(func(*validator.Validate) error { return errors.New("failed") }, true, false))
+	assert.Same(t, current, v.snapshot())
+	assert.Error(t, v.Struct(order{ID: "1"}))
+}
+
+type parcel struct {
+	Weight float64
+}
+
+func positiveWeight(v *validator.Validate, structLevel *validator.StructLevel) {
+	if structLevel.CurrentStruct.Interface().(parcel).Weight <= 0 {
+		structLevel.ReportError(reflect.ValueOf(0), "Weight", "weight", "positive")
+	}
+}
+
+func TestStructLevel(t *testing.T) {
+	assert.NoError(t, StructLevel(&parcel{}))
+	RegisterDefaultStructValidation(positiveWeight, parcel{})
+	errs, ok := StructLevel(&parcel{}).(validator.ValidationErrors)
+	assert.True(t, ok)
+	assert.Equal(t, "positive", errs["parcel.Weight"].Tag)
+	assert.NoError(t, StructLevel(parcel{Weight: 1}))
+	assert.NoError(t, StructLevel(order{}))
+}
+
+func TestSharedValidator(t *testing.T) {
+	invalid := validatable{err: validator.ValidationErrors{}}
+	assert.Equal(t, invalid.err, Default().Struct(invalid))
+	assert.False(t, Default().snapshot().custom)
+
+	// the validations registered on the shared validator.Validate can't be told, the tags are validated instead
+	shared := GetValidator()
+	assert.Same(t, shared, GetValidator())
+	assert.NoError(t, Struct(invalid))
+	assert.True(t, Default().snapshot().custom)
+	assert.Same(t, shared, Default().snapshot().validate)
+
+	// the registrations made on it directly are kept by the registrations on the default validator
+	shared.RegisterStructValidation(positiveTotal, order{})
+	assert.NoError(t, Default().RegisterPattern("ticket", "^[A-Z]+-[0-9]+$"))
+	assert.Same(t, shared, GetValidator())
+	assert.Error(t, Struct(order{ID: "1"}))
+
+	// and the validations aren't memoized, the rules being unknown
+	calls := 0
+	shared.RegisterStructValidation(func(*validator.Validate, *validator.StructLevel) {
+		calls++
+	}, parcel{})
+	memo := NewMemo(nil, nil)
+	assert.NoError(t, memo.Struct(parcel{Weight: 1}))
+	assert.NoError(t, memo.Struct(parcel{Weight: 1}))
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, Default().snapshot().key())
+}
@@ -0,0 +1,459 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package values
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/integrity"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/util/floatstr"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// placeholderPattern any placeholder, to report the unsupported ones
+	placeholderPattern = regexp.MustCompile(`\{\{.*?\}\}`)
+	// valuePattern '{{ .Values.name }}' placeholder, with an optional YAML default value: '{{ .Values.name | default 3 }}'
+	valuePattern = regexp.MustCompile(`^\{\{-?\s*\.Values\.([A-Za-z_][\w-]*(?:\.[A-Za-z_][\w-]*)*)\s*(?:\|\s*default\s+(.+?))?\s*-?\}\}$`)
+
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	intOrStringType = reflect.TypeOf(intstr.IntOrString{})
+	floatOrString   = reflect.TypeOf(floatstr.Float32OrString{})
+	stateType       = reflect.TypeOf((*model.State)(nil)).Elem()
+	dataCondition   = reflect.TypeOf((*model.DataCondition)(nil)).Elem()
+	eventCondition  = reflect.TypeOf((*model.EventCondition)(nil)).Elem()
+	authProperties  = reflect.TypeOf((*model.AuthProperties)(nil)).Elem()
+	authDefinitions = reflect.TypeOf(model.AuthDefinitions{})
+)
+
+// stateTypes implementations of the states by type, the switch states are told apart by their conditions
+var stateTypes = map[string]reflect.Type{
+	model.StateTypeDelay:     reflect.TypeOf(model.DelayState{}),
+	model.StateTypeEvent:     reflect.TypeOf(model.EventState{}),
+	model.StateTypeOperation: reflect.TypeOf(model.OperationState{}),
+	model.StateTypeParallel:  reflect.TypeOf(model.ParallelState{}),
+	model.StateTypeForEach:   reflect.TypeOf(model.ForEachState{}),
+	model.StateTypeInject:    reflect.TypeOf(model.InjectState{}),
+	model.StateTypeCallback:  reflect.TypeOf(model.CallbackState{}),
+	model.StateTypeSleep:     reflect.TypeOf(model.SleepState{}),
+}
+
+// authTypes implementations of the auth properties by scheme
+var authTypes = map[string]reflect.Type{
+	string(model.AuthTypeBasic):  reflect.TypeOf(model.BasicAuthProperties{}),
+	string(model.AuthTypeBearer): reflect.TypeOf(model.BearerAuthProperties{}),
+	string(model.AuthTypeOAuth2): reflect.TypeOf(model.OAuth2AuthProperties{}),
+}
+
+// Error placeholder that can't be rendered
+type Error struct {
+	// Path JSON path of the property holding the placeholder, e.g. 'retries[0].maxAttempts'
+	Path string
+	// Placeholder e.g. '{{ .Values.maxAttempts }}'
+	Placeholder string
+	// Message describes the error
+	Message string
+}
+
+// Error ...
+func (e *Error) Error() string {
+	return e.Path + ": " + e.Placeholder + " " + e.Message
+}
+
+// Errors placeholders that can't be rendered
+type Errors []*Error
+
+// Error ...
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// substitution placeholder replaced by a value
+type substitution struct {
+	path        []interface{}
+	placeholder string
+	value       interface{}
+}
+
+// Render replaces the placeholders of the workflow document with the values. A property holding only a placeholder
+// takes the value as is, e.g. a number or an object, while placeholders within a string are replaced by the string
+// form of the values. Errors holds the placeholders not bound to a value, the values of the wrong type for the
+// property they are substituted in, e.g. a string for a maximum number of attempts, and the unsupported placeholders.
+func Render(document map[string]interface{}, values Values) (map[string]interface{}, error) {
+	r := &renderer{values: values}
+	rendered := r.render(document, nil).(map[string]interface{})
+	for _, s := range r.substitutions {
+		if expected := checkType(rendered, s.path, s.value); len(expected) > 0 {
+			r.errs = append(r.errs, &Error{
+				Path:        formatPath(s.path),
+				Placeholder: s.placeholder,
+				Message:     fmt.Sprintf("is %s, expected %s", describe(s.value), expected),
+			})
+		}
+	}
+	if len(r.errs) > 0 {
+		sort.SliceStable(r.errs, func(i, j int) bool { return r.errs[i].Path < r.errs[j].Path })
+		return nil, r.errs
+	}
+	return rendered, nil
+}
+
+// RenderFile renders the workflow file in the given path with the values. The rendered workflow is validated by the
+// parser and integrity.Validate.
+func RenderFile(path string, values Values) (*model.Workflow, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	document := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	rendered, err := Render(document, values)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = json.Marshal(rendered); err != nil {
+		return nil, err
+	}
+	workflow, err := parser.FromJSONSource(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workflow rendered from %s: %w", path, err)
+	}
+	if errs := integrity.Validate(workflow); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid workflow rendered from %s: %w", path, errs)
+	}
+	return workflow, nil
+}
+
+type renderer struct {
+	values        Values
+	substitutions []substitution
+	errs          Errors
+}
+
+func (r *renderer) render(value interface{}, path []interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(v))
+		for key, property := range v {
+			rendered[key] = r.render(property, appendPath(path, key))
+		}
+		return rendered
+	case []interface{}:
+		rendered := make([]interface{}, len(v))
+		for i, element := range v {
+			rendered[i] = r.render(element, appendPath(path, i))
+		}
+		return rendered
+	case string:
+		return r.renderString(v, path)
+	}
+	return value
+}
+
+// renderString replaces the placeholders of the string, keeping the value as is if the string is a single placeholder
+func (r *renderer) renderString(s string, path []interface{}) interface{} {
+	placeholders := placeholderPattern.FindAllStringIndex(s, -1)
+	if len(placeholders) == 0 {
+		return s
+	}
+	if len(placeholders) == 1 && placeholders[0][0] == 0 && placeholders[0][1] == len(s) {
+		value, ok := r.lookup(s, path)
+		if !ok {
+			return s
+		}
+		r.substitutions = append(r.substitutions, substitution{path: path, placeholder: s, value: value})
+		return value
+	}
+	rendered := new(strings.Builder)
+	last := 0
+	for _, p := range placeholders {
+		rendered.WriteString(s[last:p[0]])
+		last = p[1]
+		placeholder := s[p[0]:p[1]]
+		value, ok := r.lookup(placeholder, path)
+		if !ok {
+			continue
+		}
+		switch value.(type) {
+		case map[string]interface{}, []interface{}, nil:
+			r.errs = append(r.errs, &Error{Path: formatPath(path), Placeholder: placeholder, Message: fmt.Sprintf("is %s, expected a string, number or boolean within a string", describe(value))})
+		default:
+			rendered.WriteString(formatScalar(value))
+		}
+	}
+	rendered.WriteString(s[last:])
+	result := rendered.String()
+	r.substitutions = append(r.substitutions, substitution{path: path, placeholder: s, value: result})
+	return result
+}
+
+// lookup returns the value of the placeholder, its default value if not bound
+func (r *renderer) lookup(placeholder string, path []interface{}) (interface{}, bool) {
+	match := valuePattern.FindStringSubmatch(placeholder)
+	if match == nil {
+		r.errs = append(r.errs, &Error{Path: formatPath(path), Placeholder: placeholder, Message: "not supported, expected {{ .Values.name }}"})
+		return nil, false
+	}
+	if value, ok := r.values.Lookup(match[1]); ok {
+		return value, true
+	}
+	if len(match[2]) > 0 {
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(match[2]), &value); err != nil {
+			r.errs = append(r.errs, &Error{Path: formatPath(path), Placeholder: placeholder, Message: fmt.Sprintf("has an invalid default value: %v", err)})
+			return nil, false
+		}
+		return value, true
+	}
+	r.errs = append(r.errs, &Error{Path: formatPath(path), Placeholder: placeholder, Message: "is not bound"})
+	return nil, false
+}
+
+// checkType returns what the property at the path of the document expects if the value doesn't fit, nothing if it
+// does or if the property type is unknown
+func checkType(document map[string]interface{}, path []interface{}, value interface{}) string {
+	t := reflect.TypeOf(model.Workflow{})
+	var parent interface{}
+	var current interface{} = document
+	for _, segment := range path {
+		t = implementation(t, current, parent)
+		if t == nil {
+			return ""
+		}
+		switch s := segment.(type) {
+		case string:
+			if t.Kind() == reflect.Struct {
+				if t = fieldType(t, s); t == nil {
+					return ""
+				}
+			} else if t.Kind() == reflect.Map {
+				t = t.Elem()
+			} else {
+				return ""
+			}
+			parent = current
+			current = current.(map[string]interface{})[s]
+		case int:
+			if t.Kind() != reflect.Slice {
+				return ""
+			}
+			t = t.Elem()
+			parent = current
+			current = current.([]interface{})[s]
+		}
+	}
+	t = implementation(t, current, parent)
+	if t == nil {
+		return ""
+	}
+	return expectedType(t, value)
+}
+
+// implementation dereferences the type and returns the implementation of the interfaces of the model held by the
+// value, nil if unknown
+func implementation(t reflect.Type, value, parent interface{}) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	m, _ := value.(map[string]interface{})
+	switch t {
+	case stateType:
+		stateType, _ := m["type"].(string)
+		if stateType == model.StateTypeSwitch {
+			if _, ok := m["eventConditions"]; ok {
+				return reflect.TypeOf(model.EventBasedSwitchState{})
+			}
+			return reflect.TypeOf(model.DataBasedSwitchState{})
+		}
+		return stateTypes[stateType]
+	case dataCondition:
+		if _, ok := m["end"]; ok {
+			return reflect.TypeOf(model.EndDataCondition{})
+		}
+		return reflect.TypeOf(model.TransitionDataCondition{})
+	case eventCondition:
+		if _, ok := m["end"]; ok {
+			return reflect.TypeOf(model.EndEventCondition{})
+		}
+		return reflect.TypeOf(model.TransitionEventCondition{})
+	case authDefinitions:
+		return reflect.TypeOf([]model.Auth{})
+	case authProperties:
+		scheme, _ := parent.(map[string]interface{})["scheme"].(string)
+		if t, ok := authTypes[scheme]; ok {
+			return t
+		}
+		return reflect.TypeOf(model.BasicAuthProperties{})
+	}
+	return t
+}
+
+// fieldType type of the struct field with the given JSON name, the embedded structs being flattened
+func fieldType(t reflect.Type, name string) reflect.Type {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Anonymous && len(tag) == 0 && field.Type.Kind() == reflect.Struct {
+			if embedded := fieldType(field.Type, name); embedded != nil {
+				return embedded
+			}
+			continue
+		}
+		if tag == name || (len(tag) == 0 && field.Name == name) {
+			return field.Type
+		}
+	}
+	return nil
+}
+
+// expectedType returns what the type expects if the value doesn't fit
+func expectedType(t reflect.Type, value interface{}) string {
+	switch {
+	case t == intOrStringType:
+		if isInteger(value) || isString(value) {
+			return ""
+		}
+		return "an integer or a string"
+	case t == floatOrString:
+		if isNumber(value) || isString(value) {
+			return ""
+		}
+		return "a number or a string"
+	case reflect.PtrTo(t).Implements(unmarshalerType):
+		// custom forms, e.g. a transition as the next state name, checked by the parser
+		return ""
+	}
+	switch t.Kind() {
+	case reflect.String:
+		if isString(value) {
+			return ""
+		}
+		return "a string"
+	case reflect.Bool:
+		if _, ok := value.(bool); ok {
+			return ""
+		}
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if isInteger(value) {
+			return ""
+		}
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		if isNumber(value) {
+			return ""
+		}
+		return "a number"
+	case reflect.Struct, reflect.Map:
+		if _, ok := value.(map[string]interface{}); ok {
+			return ""
+		}
+		return "an object"
+	case reflect.Slice, reflect.Array:
+		if _, ok := value.([]interface{}); ok {
+			return ""
+		}
+		// the definitions can be a file reference
+		if isString(value) && t.Elem().Kind() != reflect.String {
+			return ""
+		}
+		return "a list"
+	}
+	return ""
+}
+
+func isString(value interface{}) bool {
+	_, ok := value.(string)
+	return ok
+}
+
+func isNumber(value interface{}) bool {
+	_, ok := value.(float64)
+	return ok
+}
+
+func isInteger(value interface{}) bool {
+	f, ok := value.(float64)
+	return ok && f == math.Trunc(f)
+}
+
+// describe describes the value in the messages, e.g. 'the string "PT1S"'
+func describe(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("the string %q", v)
+	case bool:
+		return fmt.Sprintf("the boolean %v", v)
+	case float64:
+		return "the number " + formatScalar(v)
+	case []interface{}:
+		return "a list"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func formatScalar(value interface{}) string {
+	if f, ok := value.(float64); ok && f == math.Trunc(f) && math.Abs(f) < 1e15 {
+		return fmt.Sprintf("%d", int64(f))
+	}
+	return fmt.Sprint(value)
+}
+
+func appendPath(path []interface{}, segment interface{}) []interface{} {
+	appended := make([]interface{}, len(path), len(path)+1)
+	copy(appended, path)
+	return append(appended, segment)
+}
+
+// formatPath formats the path as a JSON path, e.g. 'states[0].transition'
+func formatPath(path []interface{}) string {
+	formatted := new(strings.Builder)
+	for _, segment := range path {
+		switch s := segment.(type) {
+		case string:
+			if formatted.Len() > 0 {
+				formatted.WriteString(".")
+			}
+			formatted.WriteString(s)
+		case int:
+			fmt.Fprintf(formatted, "[%d]", s)
+		}
+	}
+	if formatted.Len() == 0 {
+		return "workflow"
+	}
+	return formatted.String()
+}
@@ -0,0 +1,156 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package values
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRenderFile(t *testing.T) {
+	values, err := Load("testdata/values.yaml", "testdata/values-prod.yaml")
+	require.NoError(t, err)
+	workflow, err := RenderFile("testdata/order.sw.yaml", values)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", workflow.Version)
+	assert.False(t, workflow.KeepActive)
+	assert.Equal(t, "https://orders.example.com/openapi.json#storeOrder", workflow.Functions[0].Operation)
+	assert.Equal(t, 10, workflow.Retries[0].MaxAttempts.IntValue())
+	assert.Equal(t, "PT1S", workflow.Retries[0].Delay)
+	store := workflow.States[0].(*model.OperationState)
+	assert.Equal(t, "PT10S", store.Timeouts.StateExecTimeout.Total)
+
+	require.NoError(t, values.Set("version=1.0-eu"))
+	require.NoError(t, values.Set("keepActive=true"))
+	workflow, err = RenderFile("testdata/order.sw.yaml", values)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0-eu", workflow.Version)
+	assert.True(t, workflow.KeepActive)
+
+	_, err = RenderFile("testdata/order.sw.yaml", Values{})
+	assert.EqualError(t, err, "functions[0].operation: {{ .Values.orders.url }} is not bound\n"+
+		"retries[0].delay: {{ .Values.retries.delay }} is not bound\n"+
+		"retries[0].maxAttempts: {{ .Values.retries.maxAttempts }} is not bound\n"+
+		"states[0].timeouts.stateExecTimeout: {{ .Values.timeout }} is not bound")
+}
+
+func TestRenderTypes(t *testing.T) {
+	document := parse(t, `
+id: greeting
+specVersion: "0.8"
+start: Greet
+keepActive: '{{ .Values.keepActive }}'
+functions:
+  - name: greet
+    operation: '{{ .Values.url }}#greet'
+retries:
+  - name: default
+    maxAttempts: '{{ .Values.maxAttempts }}'
+    multiplier: '{{ .Values.multiplier }}'
+auth:
+  - name: basic
+    scheme: basic
+    properties:
+      username: '{{ .Values.username }}'
+states:
+  - name: Greet
+    type: switch
+    dataConditions:
+      - condition: ${ .ok }
+        transition: '{{ .Values.next }}'
+    defaultCondition:
+      end: true
+  - name: Wait
+    type: sleep
+    duration: '{{ .Values.duration }}'
+    end: true
+`)
+	valid := Values{
+		"keepActive":  true,
+		"url":         "http://localhost",
+		"maxAttempts": float64(3),
+		"multiplier":  1.5,
+		"username":    "admin",
+		"next":        "Wait",
+		"duration":    "PT1S",
+	}
+	rendered, err := Render(document, valid)
+	require.NoError(t, err)
+	assert.Equal(t, true, rendered["keepActive"])
+	functions := rendered["functions"].([]interface{})
+	assert.Equal(t, "http://localhost#greet", functions[0].(map[string]interface{})["operation"])
+	retries := rendered["retries"].([]interface{})
+	assert.Equal(t, float64(3), retries[0].(map[string]interface{})["maxAttempts"])
+
+	_, err = Render(document, Values{
+		"keepActive":  "yes",
+		"url":         []interface{}{"http://localhost"},
+		"maxAttempts": 1.5,
+		"multiplier":  true,
+		"username":    float64(1),
+		"next":        "Wait",
+		"duration":    map[string]interface{}{"seconds": float64(1)},
+	})
+	require.IsType(t, Errors{}, err)
+	assert.Equal(t, []string{
+		`auth[0].properties.username: {{ .Values.username }} is the number 1, expected a string`,
+		`functions[0].operation: {{ .Values.url }} is a list, expected a string, number or boolean within a string`,
+		`keepActive: {{ .Values.keepActive }} is the string "yes", expected a boolean`,
+		`retries[0].maxAttempts: {{ .Values.maxAttempts }} is the number 1.5, expected an integer or a string`,
+		`retries[0].multiplier: {{ .Values.multiplier }} is the boolean true, expected a number or a string`,
+		`states[1].duration: {{ .Values.duration }} is an object, expected a string`,
+	}, messages(err.(Errors)))
+}
+
+func TestRenderPlaceholders(t *testing.T) {
+	document := parse(t, `
+id: '{{ .Values.id | default greeting }}'
+name: '{{ .Values.id }} in {{ .Values.env | default dev }}'
+description: '{{ .Release.Name }}'
+version: '{{ .Values.version | default [1] }}'
+`)
+	rendered, err := Render(document, Values{"id": "hello"})
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		"description: {{ .Release.Name }} not supported, expected {{ .Values.name }}",
+		"version: {{ .Values.version | default [1] }} is a list, expected a string",
+	}, messages(err.(Errors)))
+	assert.Nil(t, rendered)
+
+	delete(document, "description")
+	delete(document, "version")
+	rendered, err = Render(document, Values{"id": "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", rendered["id"])
+	assert.Equal(t, "hello in dev", rendered["name"])
+}
+
+func parse(t *testing.T, source string) map[string]interface{} {
+	document := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(source), &document))
+	return document
+}
+
+func messages(errs Errors) []string {
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
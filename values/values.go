@@ -0,0 +1,110 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package values renders parameterized workflows, whose properties hold '{{ .Values.name }}' placeholders, with the
+// values of values files, in the spirit of Helm. Unlike text templating, every placeholder must be bound and the
+// values are checked against the type of the properties they are substituted in.
+package values
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Values values of the placeholders, nested by the dot separated names of the placeholders
+type Values map[string]interface{}
+
+// Load loads and merges the values files in the given paths, the values of the last files overriding the others
+func Load(paths ...string) (Values, error) {
+	values := Values{}
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, err
+		}
+		loaded := map[string]interface{}{}
+		if err := yaml.Unmarshal(data, &loaded); err != nil {
+			return nil, fmt.Errorf("invalid values file %s: %w", path, err)
+		}
+		values.Merge(loaded)
+	}
+	return values, nil
+}
+
+// Merge merges the values into the receiver, the given values overriding the receiver ones except for the nested
+// values, which are merged
+func (v Values) Merge(values map[string]interface{}) {
+	for key, value := range values {
+		if nested, ok := value.(map[string]interface{}); ok {
+			if current, ok := v[key].(map[string]interface{}); ok {
+				Values(current).Merge(nested)
+				continue
+			}
+		}
+		v[key] = value
+	}
+}
+
+// Set sets a value from an assignment such as 'retries.maxAttempts=10', the value being parsed as YAML, e.g. 10 is a
+// number, true a boolean and '[a, b]' a list
+func (v Values) Set(assignment string) error {
+	name, source := splitAssignment(assignment)
+	if len(name) == 0 || source == nil {
+		return fmt.Errorf("invalid assignment %s, expected name=value", assignment)
+	}
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(*source), &value); err != nil {
+		return fmt.Errorf("invalid value in %s: %w", assignment, err)
+	}
+	keys := strings.Split(name, ".")
+	current := v
+	for _, key := range keys[:len(keys)-1] {
+		nested, ok := current[key].(map[string]interface{})
+		if !ok {
+			nested = map[string]interface{}{}
+			current[key] = nested
+		}
+		current = nested
+	}
+	current[keys[len(keys)-1]] = value
+	return nil
+}
+
+// Lookup returns the value of the dot separated name, and whether it is bound
+func (v Values) Lookup(name string) (interface{}, bool) {
+	var value interface{} = map[string]interface{}(v)
+	for _, key := range strings.Split(name, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func splitAssignment(assignment string) (string, *string) {
+	i := strings.Index(assignment, "=")
+	if i < 0 {
+		return assignment, nil
+	}
+	value := assignment[i+1:]
+	return strings.TrimSpace(assignment[:i]), &value
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package values
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	values, err := Load("testdata/values.yaml", "testdata/values-prod.yaml")
+	require.NoError(t, err)
+	url, ok := values.Lookup("orders.url")
+	assert.True(t, ok)
+	assert.Equal(t, "https://orders.example.com", url)
+	maxAttempts, _ := values.Lookup("retries.maxAttempts")
+	assert.Equal(t, float64(10), maxAttempts)
+	delay, _ := values.Lookup("retries.delay")
+	assert.Equal(t, "PT1S", delay)
+	_, ok = values.Lookup("retries.backoff")
+	assert.False(t, ok)
+	_, ok = values.Lookup("timeout.total")
+	assert.False(t, ok)
+
+	_, err = Load("testdata/missing.yaml")
+	assert.Error(t, err)
+}
+
+func TestSet(t *testing.T) {
+	values := Values{"retries": map[string]interface{}{"delay": "PT1S"}}
+	require.NoError(t, values.Set("retries.maxAttempts=5"))
+	require.NoError(t, values.Set("version=1.0-eu"))
+	require.NoError(t, values.Set("keepActive=true"))
+	require.NoError(t, values.Set("roles=[admin, user]"))
+	assert.Equal(t, Values{
+		"retries":    map[string]interface{}{"delay": "PT1S", "maxAttempts": float64(5)},
+		"version":    "1.0-eu",
+		"keepActive": true,
+		"roles":      []interface{}{"admin", "user"},
+	}, values)
+
+	assert.EqualError(t, values.Set("version"), "invalid assignment version, expected name=value")
+	assert.Error(t, values.Set("=1"))
+}
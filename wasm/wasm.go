@@ -0,0 +1,195 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wasm validates, converts and draws workflows for the JavaScript code of the WebAssembly build of the SDK,
+// see cmd/sdk-wasm, so that the web based editors validate the workflows with the same rules as the backends without a
+// round trip. The functions take the workflow and JSON options as strings and return a Response to encode in JSON.
+package wasm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/annotation"
+	"github.com/serverlessworkflow/sdk-go/v2/diagram"
+	"github.com/serverlessworkflow/sdk-go/v2/integrity"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/serverlessworkflow/sdk-go/v2/serializer"
+)
+
+// Response result of a call from JavaScript
+type Response struct {
+	// Valid whether the workflow is valid, Convert and Graph only returning the validation results if not
+	Valid   bool               `json:"valid"`
+	Results []ValidationResult `json:"results"`
+	// Output workflow converted or diagram
+	Output string `json:"output,omitempty"`
+	// Error of the options or of the conversion or diagram of a valid workflow
+	Error string `json:"error,omitempty"`
+}
+
+// ValidationResult error found in a workflow
+type ValidationResult struct {
+	// Path JSON path of the invalid property if known, e.g. 'states[0].transition.nextState'
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+	// Line and Column of the invalid property in the workflow, zero if unknown
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+	// Suggestions defined names likely meant by a reference to an undefined name, the closest first
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// ConvertOptions options of Convert, see serializer.Options
+type ConvertOptions struct {
+	// Format json or yaml, the other format than the workflow if empty
+	Format      serializer.Format `json:"format"`
+	Shorthand   bool              `json:"shorthand"`
+	Normalize   bool              `json:"normalize"`
+	SpecVersion string            `json:"specVersion"`
+	Indent      int               `json:"indent"`
+}
+
+// GraphOptions options of Graph, see diagram.Options
+type GraphOptions struct {
+	// Format mermaid, the default, or dot
+	Format    string   `json:"format"`
+	States    []string `json:"states"`
+	Highlight []string `json:"highlight"`
+}
+
+// Validate validates the JSON or YAML workflow
+func Validate(source string) *Response {
+	_, response := validate(source)
+	return response
+}
+
+// Convert converts the JSON or YAML workflow with the JSON encoded ConvertOptions, empty for the defaults
+func Convert(source, options string) *Response {
+	opts := ConvertOptions{}
+	if err := decodeOptions(options, &opts); err != nil {
+		return &Response{Results: []ValidationResult{}, Error: err.Error()}
+	}
+	workflow, response := validate(source)
+	if !response.Valid {
+		return response
+	}
+	switch opts.Format {
+	case "":
+		opts.Format = serializer.FormatJSON
+		if strings.HasPrefix(strings.TrimSpace(source), "{") {
+			opts.Format = serializer.FormatYAML
+		}
+	case serializer.FormatJSON, serializer.FormatYAML:
+	default:
+		response.Error = fmt.Sprintf("unknown format %s, expected json or yaml", opts.Format)
+		return response
+	}
+	data, err := serializer.Marshal(workflow, serializer.Options{
+		Format:      opts.Format,
+		Shorthand:   opts.Shorthand,
+		Normalize:   opts.Normalize,
+		SpecVersion: opts.SpecVersion,
+		Indent:      opts.Indent,
+	})
+	if err != nil {
+		response.Error = err.Error()
+		return response
+	}
+	response.Output = string(data)
+	return response
+}
+
+// Graph draws the control flow graph of the JSON or YAML workflow with the JSON encoded GraphOptions, empty for the
+// defaults
+func Graph(source, options string) *Response {
+	opts := GraphOptions{}
+	if err := decodeOptions(options, &opts); err != nil {
+		return &Response{Results: []ValidationResult{}, Error: err.Error()}
+	}
+	workflow, response := validate(source)
+	if !response.Valid {
+		return response
+	}
+	var render func(*model.Workflow, diagram.Options) ([]byte, error)
+	switch opts.Format {
+	case "", "mermaid":
+		render = diagram.Mermaid
+	case "dot":
+		render = diagram.DOT
+	default:
+		response.Error = fmt.Sprintf("unknown format %s, expected mermaid or dot", opts.Format)
+		return response
+	}
+	data, err := render(workflow, diagram.Options{States: opts.States, Highlight: opts.Highlight})
+	if err != nil {
+		response.Error = err.Error()
+		return response
+	}
+	response.Output = string(data)
+	return response
+}
+
+// validate parses and validates the workflow, returning a nil workflow if it can't be parsed
+func validate(source string) (*model.Workflow, *Response) {
+	response := &Response{Results: []ValidationResult{}}
+	if len(strings.TrimSpace(source)) == 0 {
+		response.Results = append(response.Results, ValidationResult{Message: "no workflow"})
+		return nil, response
+	}
+	workflow, err := parser.FromYAMLSource([]byte(source))
+	if err != nil {
+		workflow, response.Results = nil, parseResults(err)
+	} else {
+		for _, e := range integrity.Validate(workflow) {
+			response.Results = append(response.Results, ValidationResult{Path: e.Path, Message: e.Message, Suggestions: e.Suggestions})
+		}
+	}
+	locator := annotation.NewLocator([]byte(source))
+	for i := range response.Results {
+		response.Results[i].Line, response.Results[i].Column = locator.Locate(response.Results[i].Path)
+	}
+	response.Valid = len(response.Results) == 0
+	return workflow, response
+}
+
+// parseResults returns the validation results of the error returned by the parser
+func parseResults(err error) []ValidationResult {
+	var schemaErr *model.SchemaError
+	if !errors.As(err, &schemaErr) {
+		return []ValidationResult{{Message: err.Error()}}
+	}
+	var results []ValidationResult
+	for _, fieldErr := range schemaErr.Errors {
+		// the embedded base workflow is an implementation detail
+		path := strings.TrimPrefix(fieldErr.NameNamespace, "BaseWorkflow.")
+		results = append(results, ValidationResult{Path: path, Message: fmt.Sprintf("failed on the '%s' validation", fieldErr.Tag)})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Path < results[j].Path })
+	return results
+}
+
+func decodeOptions(options string, opts interface{}) error {
+	if len(strings.TrimSpace(options)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(options), opts); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+	return nil
+}
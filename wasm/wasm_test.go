@@ -0,0 +1,95 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wasm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const greetingWorkflow = `id: greeting
+name: Greeting
+version: '1.0'
+specVersion: '0.8'
+start: Greet
+functions:
+  - name: greetingFunction
+    operation: file://myapis/greetingapis.json#greeting
+states:
+  - name: Greet
+    type: operation
+    actions:
+      - functionRef: greetingFunction
+    end: true
+`
+
+func TestValidate(t *testing.T) {
+	response := Validate(greetingWorkflow)
+	assert.True(t, response.Valid)
+	assert.Empty(t, response.Results)
+
+	response = Validate(strings.Replace(greetingWorkflow, "functionRef: greetingFunction", "functionRef: greetFunction", 1))
+	assert.False(t, response.Valid)
+	require.Len(t, response.Results, 1)
+	assert.Equal(t, ValidationResult{
+		Path:        "states[0].actions[0].functionRef.refName",
+		Message:     "function greetFunction is not defined, did you mean greetingFunction?",
+		Line:        13,
+		Column:      9,
+		Suggestions: []string{"greetingFunction"},
+	}, response.Results[0])
+
+	assert.False(t, Validate("states: [").Valid)
+	assert.False(t, Validate(" ").Valid)
+}
+
+func TestConvert(t *testing.T) {
+	response := Convert(greetingWorkflow, "")
+	assert.True(t, response.Valid)
+	assert.Empty(t, response.Error)
+	assert.Contains(t, response.Output, `"id": "greeting"`)
+
+	response = Convert(response.Output, "")
+	assert.Contains(t, response.Output, "id: greeting\n")
+
+	response = Convert(greetingWorkflow, `{"format": "yaml", "shorthand": true}`)
+	assert.Contains(t, response.Output, "functionRef: greetingFunction\n")
+
+	response = Convert(greetingWorkflow, `{"format": "xml"}`)
+	assert.Equal(t, "unknown format xml, expected json or yaml", response.Error)
+	assert.Empty(t, response.Output)
+	response = Convert(greetingWorkflow, `{"indent": "two"}`)
+	assert.Contains(t, response.Error, "invalid options")
+
+	response = Convert(strings.Replace(greetingWorkflow, "start: Greet", "start: Greeting", 1), "")
+	assert.False(t, response.Valid)
+	assert.Equal(t, "start.stateName", response.Results[0].Path)
+	assert.Empty(t, response.Output)
+}
+
+func TestGraph(t *testing.T) {
+	response := Graph(greetingWorkflow, "")
+	assert.True(t, response.Valid)
+	assert.True(t, strings.HasPrefix(response.Output, "flowchart"), response.Output)
+
+	response = Graph(greetingWorkflow, `{"format": "dot"}`)
+	assert.True(t, strings.HasPrefix(response.Output, "digraph"), response.Output)
+
+	response = Graph(greetingWorkflow, `{"states": ["Missing"]}`)
+	assert.Equal(t, "state Missing is not defined", response.Error)
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+// Delta changes of the diagnostics of a file
+type Delta struct {
+	Path string
+	// Removed the file was removed, its diagnostics are dropped
+	Removed bool
+	// Added diagnostics that were not reported before
+	Added []string
+	// Fixed diagnostics that are not reported anymore
+	Fixed []string
+}
+
+// Diagnostics keeps the last diagnostics of every file, so only their changes are reported
+type Diagnostics struct {
+	validate func(path string) []string
+	last     map[string][]string
+}
+
+// NewDiagnostics creates the diagnostics of the files validated by the given function, which returns one message per
+// problem found
+func NewDiagnostics(validate func(path string) []string) *Diagnostics {
+	return &Diagnostics{validate: validate, last: map[string][]string{}}
+}
+
+// Update validates the changed files again and returns the deltas of the files whose diagnostics changed
+func (d *Diagnostics) Update(changes []Change) []Delta {
+	var deltas []Delta
+	for _, change := range changes {
+		previous, known := d.last[change.Path]
+		if change.Removed {
+			if known {
+				delete(d.last, change.Path)
+				deltas = append(deltas, Delta{Path: change.Path, Removed: true, Fixed: previous})
+			}
+			continue
+		}
+		current := d.validate(change.Path)
+		d.last[change.Path] = current
+		delta := Delta{Path: change.Path, Added: subtract(current, previous), Fixed: subtract(previous, current)}
+		if len(delta.Added) > 0 || len(delta.Fixed) > 0 {
+			deltas = append(deltas, delta)
+		}
+	}
+	return deltas
+}
+
+// Messages returns the current diagnostics of the file
+func (d *Diagnostics) Messages(path string) []string {
+	return d.last[path]
+}
+
+// subtract returns the messages of a that are not in b
+func subtract(a, b []string) []string {
+	in := make(map[string]int, len(b))
+	for _, message := range b {
+		in[message]++
+	}
+	var result []string
+	for _, message := range a {
+		if in[message] > 0 {
+			in[message]--
+			continue
+		}
+		result = append(result, message)
+	}
+	return result
+}
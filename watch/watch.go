@@ -0,0 +1,101 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"context"
+	"os"
+	"sort"
+	"time"
+)
+
+// DefaultInterval interval between two scans of the watched files
+const DefaultInterval = 500 * time.Millisecond
+
+// Change file created, modified or removed
+type Change struct {
+	Path    string
+	Removed bool
+}
+
+// Watcher polls the files for changes. Polling is portable and cheap enough for the few files of a workflow project.
+type Watcher struct {
+	// List returns the files to watch. It's called on every scan, so new files are picked up.
+	List func() ([]string, error)
+	// Interval between two scans. Default is DefaultInterval
+	Interval time.Duration
+
+	files map[string]fileState
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watch calls fn with the changes found on every scan until the context is done. The first scan reports every file
+// as created. Errors listing the files stop the watch.
+func (w *Watcher) Watch(ctx context.Context, fn func(changes []Change)) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		changes, err := w.Scan()
+		if err != nil {
+			return err
+		}
+		if len(changes) > 0 {
+			fn(changes)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan lists the files and returns the ones that changed since the previous scan, sorted by path
+func (w *Watcher) Scan() ([]Change, error) {
+	paths, err := w.List()
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]fileState, len(paths))
+	var changes []Change
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			// removed between the listing and now, reported on the next scan
+			continue
+		}
+		state := fileState{modTime: info.ModTime(), size: info.Size()}
+		files[path] = state
+		if previous, ok := w.files[path]; !ok || previous != state {
+			changes = append(changes, Change{Path: path})
+		}
+	}
+	for path := range w.files {
+		if _, ok := files[path]; !ok {
+			changes = append(changes, Change{Path: path, Removed: true})
+		}
+	}
+	w.files = files
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	a, b := filepath.Join(dir, "a.sw.json"), filepath.Join(dir, "b.sw.json")
+	assert.NoError(t, ioutil.WriteFile(a, []byte("{}"), 0600))
+	assert.NoError(t, ioutil.WriteFile(b, []byte("{}"), 0600))
+
+	files := []string{a, b}
+	w := &Watcher{List: func() ([]string, error) { return files, nil }}
+	changes, err := w.Scan()
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{{Path: a}, {Path: b}}, changes)
+
+	changes, err = w.Scan()
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	assert.NoError(t, ioutil.WriteFile(b, []byte(`{"id": "b"}`), 0600))
+	assert.NoError(t, os.Remove(a))
+	files = []string{b}
+	changes, err = w.Scan()
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{{Path: a, Removed: true}, {Path: b}}, changes)
+}
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "a.sw.json")
+	assert.NoError(t, ioutil.WriteFile(file, []byte("{}"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &Watcher{List: func() ([]string, error) { return []string{file}, nil }, Interval: time.Millisecond}
+	var scans [][]Change
+	err = w.Watch(ctx, func(changes []Change) {
+		scans = append(scans, changes)
+		if len(scans) == 1 {
+			assert.NoError(t, ioutil.WriteFile(file, []byte(`{"id": "a"}`), 0600))
+		} else {
+			cancel()
+		}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]Change{{{Path: file}}, {{Path: file}}}, scans)
+
+	w = &Watcher{List: func() ([]string, error) { return nil, os.ErrNotExist }}
+	assert.Error(t, w.Watch(context.Background(), func([]Change) {}))
+}
+
+func TestDiagnostics(t *testing.T) {
+	results := map[string][]string{"a": {"start missing", "name missing"}, "b": nil}
+	d := NewDiagnostics(func(path string) []string { return results[path] })
+
+	assert.Equal(t, []Delta{{Path: "a", Added: []string{"start missing", "name missing"}}}, d.Update([]Change{{Path: "a"}, {Path: "b"}}))
+
+	results["a"] = []string{"name missing", "version missing"}
+	assert.Equal(t, []Delta{{Path: "a", Added: []string{"version missing"}, Fixed: []string{"start missing"}}}, d.Update([]Change{{Path: "a"}}))
+	assert.Equal(t, []string{"name missing", "version missing"}, d.Messages("a"))
+
+	assert.Empty(t, d.Update([]Change{{Path: "a"}}))
+	assert.Equal(t, []Delta{{Path: "a", Removed: true, Fixed: []string{"name missing", "version missing"}}}, d.Update([]Change{{Path: "a", Removed: true}}))
+	assert.Empty(t, d.Update([]Change{{Path: "c", Removed: true}}))
+}
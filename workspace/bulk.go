@@ -0,0 +1,111 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"context"
+	"sync"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// BulkResult workflow, or error, of a file parsed by LoadBulk
+type BulkResult struct {
+	// Index of the file in the files loaded
+	Index    int
+	File     string
+	Workflow *model.Workflow
+	Err      error
+}
+
+// BulkProgress progress of a LoadBulk
+type BulkProgress struct {
+	// Total number of files to parse
+	Total int
+	// Parsed number of files parsed, the failed ones included
+	Parsed int
+	// Failed number of files that couldn't be parsed
+	Failed int
+}
+
+// BulkOptions options of LoadBulk
+type BulkOptions struct {
+	LoadOptions
+	// Progress called with the progress after every file parsed, before its result is sent. The calls are made from a
+	// single goroutine, one at a time.
+	Progress func(BulkProgress)
+}
+
+// LoadBulk parses the workflow files in parallel like LoadWithOptions, but sends the results on the returned channel
+// as soon as they're parsed, in no particular order, instead of holding all the workflows in a workspace: catalog and
+// indexing services ingest repositories of any size in the memory of the results they're processing. The channel is
+// closed once every file was parsed, or when the context is done, the files not parsed yet being skipped. The caller
+// must receive the results until the channel is closed, or cancel the context.
+func LoadBulk(ctx context.Context, files []string, opts BulkOptions) <-chan BulkResult {
+	workers := opts.workers(len(files))
+	parse := opts.parse()
+	results := make(chan BulkResult, workers)
+	parsed := make(chan BulkResult, workers)
+	next := make(chan int)
+	go func() {
+		defer close(next)
+		for i := range files {
+			select {
+			case next <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				workflow, err := parse(files[i])
+				parsed <- BulkResult{Index: i, File: files[i], Workflow: workflow, Err: err}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(parsed)
+	}()
+
+	go func() {
+		defer close(results)
+		progress := BulkProgress{Total: len(files)}
+		for result := range parsed {
+			if ctx.Err() != nil {
+				// drained for the workers to stop
+				continue
+			}
+			progress.Parsed++
+			if result.Err != nil {
+				progress.Failed++
+			}
+			if opts.Progress != nil {
+				opts.Progress(progress)
+			}
+			select {
+			case results <- result:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBulk(t *testing.T) {
+	files := []string{
+		"../parser/testdata/workflows/greetings.sw.json",
+		"../parser/testdata/workflows/missing.json",
+		"../parser/testdata/workflows/applicationrequest.json",
+		"../parser/testdata/workflows/eventbasedgreeting.sw.json",
+		"../parser/testdata/workflows/missing.yaml",
+		"../parser/testdata/workflows/purchaseorderworkflow.sw.json",
+	}
+	for _, workers := range []int{1, 2, 16} {
+		var progress []BulkProgress
+		var results []BulkResult
+		opts := BulkOptions{LoadOptions: LoadOptions{Workers: workers}, Progress: func(p BulkProgress) {
+			progress = append(progress, p)
+		}}
+		for result := range LoadBulk(context.Background(), files, opts) {
+			results = append(results, result)
+		}
+		require.Len(t, results, len(files))
+		sort.Slice(results, func(i, j int) bool { return results[i].Index < results[j].Index })
+		for i, result := range results {
+			assert.Equal(t, files[i], result.File)
+			if i == 1 || i == 4 {
+				assert.Error(t, result.Err)
+				assert.Nil(t, result.Workflow)
+			} else {
+				assert.NoError(t, result.Err)
+				assert.NotNil(t, result.Workflow)
+			}
+		}
+		require.Len(t, progress, len(files))
+		last := progress[len(progress)-1]
+		assert.Equal(t, BulkProgress{Total: 6, Parsed: 6, Failed: 2}, last)
+		for i, p := range progress {
+			assert.Equal(t, i+1, p.Parsed)
+		}
+	}
+
+	var results []BulkResult
+	for result := range LoadBulk(context.Background(), nil, BulkOptions{}) {
+		results = append(results, result)
+	}
+	assert.Empty(t, results)
+}
+
+func TestLoadBulkCancel(t *testing.T) {
+	files := make([]string, 1000)
+	for i := range files {
+		files[i] = "../parser/testdata/workflows/greetings.sw.json"
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := 0
+	for range LoadBulk(ctx, files, BulkOptions{LoadOptions: LoadOptions{Workers: 4}}) {
+		if received++; received == 10 {
+			cancel()
+		}
+	}
+	// the results already parsed may still be received, not the rest of the files
+	assert.Less(t, received, len(files))
+}
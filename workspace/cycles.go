@@ -0,0 +1,81 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"strings"
+)
+
+// Cycle subflow invocation cycle, each dependency calls the workflow of the next one and the last one calls the
+// workflow of the first one
+type Cycle []*Dependency
+
+// String describes the cycle path, e.g. 'order@1.0 -> payment@1.0 -> order@1.0'
+func (c Cycle) String() string {
+	keys := make([]string, 0, len(c)+1)
+	for _, dependency := range c {
+		keys = append(keys, dependency.From.Key())
+	}
+	keys = append(keys, c[0].From.Key())
+	return strings.Join(keys, " -> ")
+}
+
+// Cycles detects the direct and transitive subflow invocation cycles, such as a workflow running itself or two
+// workflows running each other. ContinueAs dependencies are not invocations and are ignored. A workflow involved in
+// several cycles may not have every one of them reported, but every workflow involved in a cycle is in at least one.
+func (g *Graph) Cycles() []Cycle {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[*Workflow]int{}
+	var stack []*Dependency
+	var cycles []Cycle
+	var visit func(workflow *Workflow)
+	visit = func(workflow *Workflow) {
+		state[workflow] = visiting
+		for _, dependency := range g.Callees(workflow) {
+			if dependency.Kind != DependencySubFlow {
+				continue
+			}
+			for _, to := range dependency.To {
+				switch state[to] {
+				case unvisited:
+					stack = append(stack, dependency)
+					visit(to)
+					stack = stack[:len(stack)-1]
+				case visiting:
+					// the cycle starts where the path left the workflow, a workflow calling itself isn't in the path yet
+					start := len(stack)
+					for i, d := range stack {
+						if d.From == to {
+							start = i
+						}
+					}
+					cycle := append(Cycle{}, stack[start:]...)
+					cycles = append(cycles, append(cycle, dependency))
+				}
+			}
+		}
+		state[workflow] = visited
+	}
+	for _, workflow := range g.Workflows {
+		if state[workflow] == unvisited {
+			visit(workflow)
+		}
+	}
+	return cycles
+}
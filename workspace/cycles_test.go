@@ -0,0 +1,58 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func subflows(refs ...model.WorkflowRef) model.State {
+	state := &model.OperationState{BaseState: model.BaseState{Name: "Run"}}
+	for _, ref := range refs {
+		state.Actions = append(state.Actions, model.Action{SubFlowRef: ref})
+	}
+	return state
+}
+
+func TestCycles(t *testing.T) {
+	w := New(
+		testWorkflow("order", "1.0", subflows(model.WorkflowRef{WorkflowID: "payment"})),
+		testWorkflow("payment", "1.0", subflows(model.WorkflowRef{WorkflowID: "fraud"}, model.WorkflowRef{WorkflowID: "audit"})),
+		testWorkflow("fraud", "1.0", subflows(model.WorkflowRef{WorkflowID: "order", Version: "1.0"})),
+		testWorkflow("audit", "1.0", subflows(model.WorkflowRef{WorkflowID: "audit"})),
+		// calls another version of itself
+		testWorkflow("report", "1.0", subflows(model.WorkflowRef{WorkflowID: "report", Version: "2.0"})),
+		testWorkflow("report", "2.0"),
+		// continuing as itself is a loop, not a recursive invocation
+		testWorkflow("poll", "1.0", &model.SleepState{BaseState: model.BaseState{
+			Name: "Wait",
+			End:  &model.End{ContinueAs: &model.ContinueAs{WorkflowRef: model.WorkflowRef{WorkflowID: "poll"}}},
+		}}),
+	)
+	cycles := w.DependencyGraph().Cycles()
+	var paths []string
+	for _, cycle := range cycles {
+		paths = append(paths, cycle.String())
+	}
+	assert.Equal(t, []string{
+		"order@1.0 -> payment@1.0 -> fraud@1.0 -> order@1.0",
+		"audit@1.0 -> audit@1.0",
+	}, paths)
+	assert.Equal(t, "states[0].actions[0].subFlowRef", cycles[0][2].Path)
+	assert.Empty(t, New(testWorkflow("order", "1.0")).DependencyGraph().Cycles())
+}
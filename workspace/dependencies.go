@@ -0,0 +1,216 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+)
+
+// DependencyKind how a workflow depends on another
+type DependencyKind string
+
+const (
+	// DependencySubFlow the workflow runs the other one as a subflow action
+	DependencySubFlow DependencyKind = "subflow"
+	// DependencyContinueAs the workflow continues its execution as the other one
+	DependencyContinueAs DependencyKind = "continueAs"
+)
+
+// Dependency reference from a workflow to another one
+type Dependency struct {
+	Kind DependencyKind
+	From *Workflow
+	// Path of the reference in the workflow, e.g. 'states[0].actions[1].subFlowRef'
+	Path string
+	Ref  model.WorkflowRef
+	// To workflows of the workspace the reference resolves to, all the versions of the workflow if the reference
+	// has no version. Empty if the dependency is missing.
+	To []*Workflow
+}
+
+// Graph dependency graph of the workflows of a workspace
+type Graph struct {
+	Workflows    []*Workflow
+	Dependencies []*Dependency
+}
+
+// DependencyGraph builds the graph of the subflow and continueAs references between the workspace workflows
+func (w *Workspace) DependencyGraph() *Graph {
+	g := &Graph{Workflows: w.Workflows}
+	for _, workflow := range w.Workflows {
+		add := func(kind DependencyKind, path string, ref model.WorkflowRef) {
+			if len(ref.WorkflowID) == 0 {
+				return
+			}
+			g.Dependencies = append(g.Dependencies, &Dependency{
+				Kind: kind,
+				From: workflow,
+				Path: path,
+				Ref:  ref,
+				To:   w.Find(ref.WorkflowID, ref.Version),
+			})
+		}
+		forEachReference(workflow.Workflow, add)
+	}
+	return g
+}
+
+// forEachReference calls fn with every reference of the workflow to other workflows
+func forEachReference(workflow *model.Workflow, fn func(kind DependencyKind, path string, ref model.WorkflowRef)) {
+	actions := func(path string, actions []model.Action) {
+		for i, action := range actions {
+			fn(DependencySubFlow, fmt.Sprintf("%s[%d].subFlowRef", path, i), action.SubFlowRef)
+		}
+	}
+	end := func(path string, end *model.End) {
+		if end != nil && end.ContinueAs != nil {
+			fn(DependencyContinueAs, path+".continueAs", end.ContinueAs.WorkflowRef)
+		}
+	}
+	for i, state := range workflow.States {
+		path := fmt.Sprintf("states[%d]", i)
+		switch s := state.(type) {
+		case *model.OperationState:
+			actions(path+".actions", s.Actions)
+		case *model.ForEachState:
+			actions(path+".actions", s.Actions)
+		case *model.ParallelState:
+			for j, branch := range s.Branches {
+				actions(fmt.Sprintf("%s.branches[%d].actions", path, j), branch.Actions)
+			}
+		case *model.EventState:
+			for j, onEvent := range s.OnEvents {
+				actions(fmt.Sprintf("%s.onEvents[%d].actions", path, j), onEvent.Actions)
+			}
+		case *model.CallbackState:
+			fn(DependencySubFlow, path+".action.subFlowRef", s.Action.SubFlowRef)
+		}
+		end(path+".end", state.GetEnd())
+		for j, onError := range state.GetOnErrors() {
+			end(fmt.Sprintf("%s.onErrors[%d].end", path, j), onError.End)
+		}
+	}
+}
+
+// Callers returns the dependencies on the workflow with the given id, of any version
+func (g *Graph) Callers(id string) []*Dependency {
+	var callers []*Dependency
+	for _, dependency := range g.Dependencies {
+		if dependency.Ref.WorkflowID == id {
+			callers = append(callers, dependency)
+		}
+	}
+	return callers
+}
+
+// Callees returns the dependencies of the given workflow
+func (g *Graph) Callees(workflow *Workflow) []*Dependency {
+	var callees []*Dependency
+	for _, dependency := range g.Dependencies {
+		if dependency.From == workflow {
+			callees = append(callees, dependency)
+		}
+	}
+	return callees
+}
+
+// Problem dependency that can't be resolved in the workspace
+type Problem struct {
+	Dependency *Dependency
+	Message    string
+}
+
+// String ...
+func (p Problem) String() string {
+	return p.Dependency.Path + ": " + p.Message
+}
+
+// Problems returns the dependencies on workflows missing from the workspace, and on versions of a workflow that
+// aren't in the workspace
+func (g *Graph) Problems() []Problem {
+	var problems []Problem
+	for _, dependency := range g.Dependencies {
+		if len(dependency.To) > 0 {
+			continue
+		}
+		var versions []string
+		for _, workflow := range g.Workflows {
+			if workflow.Workflow.ID == dependency.Ref.WorkflowID {
+				versions = append(versions, workflow.Workflow.Version)
+			}
+		}
+		message := fmt.Sprintf("workflow %s is not in the workspace", dependency.Ref.WorkflowID)
+		if len(versions) > 0 {
+			sort.Strings(versions)
+			message = fmt.Sprintf("workflow %s version %s is not in the workspace, found %s",
+				dependency.Ref.WorkflowID, dependency.Ref.Version, strings.Join(versions, ", "))
+		}
+		problems = append(problems, Problem{Dependency: dependency, Message: message})
+	}
+	return problems
+}
+
+// DOT renders the graph as a Graphviz digraph, missing dependencies as dashed nodes and continueAs references as
+// dotted edges
+func (g *Graph) DOT() []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteString("digraph workspace {\n")
+	buf.WriteString("    node [shape=box, style=rounded];\n")
+	ids := map[string]string{}
+	node := func(key, attributes string) string {
+		id, ok := ids[key]
+		if !ok {
+			id = fmt.Sprintf("n%d", len(ids))
+			ids[key] = id
+			fmt.Fprintf(buf, "    %s [label=%s%s];\n", id, dotQuote(key), attributes)
+		}
+		return id
+	}
+	for _, workflow := range g.Workflows {
+		node(workflow.Key(), "")
+	}
+	for _, dependency := range g.Dependencies {
+		from := node(dependency.From.Key(), "")
+		var attributes []string
+		if dependency.Kind == DependencyContinueAs {
+			attributes = append(attributes, "style=dotted")
+		}
+		edge := func(to string) {
+			if len(attributes) > 0 {
+				fmt.Fprintf(buf, "    %s -> %s [%s];\n", from, to, strings.Join(attributes, ", "))
+			} else {
+				fmt.Fprintf(buf, "    %s -> %s;\n", from, to)
+			}
+		}
+		if len(dependency.To) == 0 {
+			edge(node(key(dependency.Ref.WorkflowID, dependency.Ref.Version), ", style=dashed, color=\"#d33\""))
+			continue
+		}
+		for _, to := range dependency.To {
+			edge(node(to.Key(), ""))
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+func dotQuote(s string) string {
+	return "\"" + strings.ReplaceAll(strings.ReplaceAll(s, "\\", "\\\\"), "\"", "\\\"") + "\""
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWorkflow(id, version string, states ...model.State) *model.Workflow {
+	return &model.Workflow{
+		BaseWorkflow: model.BaseWorkflow{ID: id, Name: id, Version: version},
+		States:       states,
+	}
+}
+
+func testWorkspace() *Workspace {
+	return New(
+		testWorkflow("order", "1.0",
+			&model.OperationState{
+				BaseState: model.BaseState{Name: "Pay"},
+				Actions: []model.Action{
+					{SubFlowRef: model.WorkflowRef{WorkflowID: "payment", Version: "1.0"}},
+					{SubFlowRef: model.WorkflowRef{WorkflowID: "shipping"}},
+				},
+			},
+			&model.ParallelState{
+				BaseState: model.BaseState{
+					Name: "Notify",
+					End:  &model.End{ContinueAs: &model.ContinueAs{WorkflowRef: model.WorkflowRef{WorkflowID: "archive"}}},
+				},
+				Branches: []model.Branch{{Name: "Pay", Actions: []model.Action{
+					{SubFlowRef: model.WorkflowRef{WorkflowID: "payment", Version: "3.0"}},
+				}}},
+			},
+		),
+		testWorkflow("payment", "1.0"),
+		testWorkflow("payment", "2.0"),
+		testWorkflow("archive", ""),
+	)
+}
+
+func TestDependencyGraph(t *testing.T) {
+	w := testWorkspace()
+	g := w.DependencyGraph()
+	require.Len(t, g.Dependencies, 4)
+	order, payment := w.Workflows[0], w.Workflows[1]
+
+	assert.Equal(t, &Dependency{
+		Kind: DependencySubFlow,
+		From: order,
+		Path: "states[0].actions[0].subFlowRef",
+		Ref:  model.WorkflowRef{WorkflowID: "payment", Version: "1.0"},
+		To:   []*Workflow{payment},
+	}, g.Dependencies[0])
+	assert.Equal(t, "states[1].branches[0].actions[0].subFlowRef", g.Dependencies[2].Path)
+	assert.Equal(t, DependencyContinueAs, g.Dependencies[3].Kind)
+	assert.Equal(t, "states[1].end.continueAs", g.Dependencies[3].Path)
+	assert.Equal(t, []*Workflow{w.Workflows[3]}, g.Dependencies[3].To)
+
+	assert.Equal(t, []*Dependency{g.Dependencies[0], g.Dependencies[2]}, g.Callers("payment"))
+	assert.Len(t, g.Callees(order), 4)
+	assert.Empty(t, g.Callees(payment))
+}
+
+func TestProblems(t *testing.T) {
+	var messages []string
+	for _, problem := range testWorkspace().DependencyGraph().Problems() {
+		messages = append(messages, problem.String())
+	}
+	assert.Equal(t, []string{
+		"states[0].actions[1].subFlowRef: workflow shipping is not in the workspace",
+		"states[1].branches[0].actions[0].subFlowRef: workflow payment version 3.0 is not in the workspace, found 1.0, 2.0",
+	}, messages)
+}
+
+func TestDOT(t *testing.T) {
+	assert.Equal(t, `digraph workspace {
+    node [shape=box, style=rounded];
+    n0 [label="order@1.0"];
+    n1 [label="payment@1.0"];
+    n2 [label="payment@2.0"];
+    n3 [label="archive"];
+    n0 -> n1;
+    n4 [label="shipping", style=dashed, color="#d33"];
+    n0 -> n4;
+    n5 [label="payment@3.0", style=dashed, color="#d33"];
+    n0 -> n5;
+    n0 -> n3 [style=dotted];
+}
+`, string(testWorkspace().DependencyGraph().DOT()))
+}
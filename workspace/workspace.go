@@ -0,0 +1,181 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v2/internal/pool"
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+)
+
+// Workflow parsed workflow of a workspace
+type Workflow struct {
+	// File the workflow was parsed from, empty if it wasn't loaded from a file
+	File     string
+	Workflow *model.Workflow
+}
+
+// Key identifies the workflow in the workspace, e.g. 'order@1.0', or 'order' if it has no version
+func (w *Workflow) Key() string {
+	return key(w.Workflow.ID, w.Workflow.Version)
+}
+
+func key(id, version string) string {
+	if len(version) == 0 {
+		return id
+	}
+	return id + "@" + version
+}
+
+// Resolver returns the workflow referenced by a subflow or continueAs reference, e.g. registry.Catalog.Resolve
+type Resolver func(ref model.WorkflowRef) (*model.Workflow, error)
+
+// Workspace set of workflows that can reference each other, e.g. the workflows of a repository
+type Workspace struct {
+	Workflows []*Workflow
+	// Remote resolves the references to the workflows missing from the workspace, e.g. from a registry, the
+	// workspace being made of its workflows only if nil
+	Remote Resolver
+}
+
+// FileError error parsing a workspace file
+type FileError struct {
+	File string
+	Err  error
+}
+
+// Error ...
+func (e *FileError) Error() string {
+	return e.File + ": " + e.Err.Error()
+}
+
+// Unwrap ...
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// Errors errors parsing the workspace files
+type Errors []*FileError
+
+// Error ...
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// New creates a workspace of already parsed workflows
+func New(workflows ...*model.Workflow) *Workspace {
+	w := &Workspace{}
+	for _, workflow := range workflows {
+		w.Workflows = append(w.Workflows, &Workflow{Workflow: workflow})
+	}
+	return w
+}
+
+// LoadOptions options of LoadWithOptions
+type LoadOptions struct {
+	// Workers number of files parsed in parallel, default is the number of CPUs. At most Workers files are read in
+	// memory at the same time.
+	Workers int
+	// Cache parse cache of the workflows if set, e.g. shared by the successive loads of a repository so that only the
+	// modified files are decoded again
+	Cache *parser.Cache
+	// Parser parser of the workflows, e.g. with limits for untrusted repositories, default parses like the package
+	// functions. Unused with a Cache, which parses with its own parser.
+	Parser *parser.Parser
+}
+
+// parse returns the function parsing the files with the options
+func (opts LoadOptions) parse() func(file string) (*model.Workflow, error) {
+	switch {
+	case opts.Cache != nil:
+		return opts.Cache.FromFile
+	case opts.Parser != nil:
+		return opts.Parser.FromFile
+	}
+	return parser.FromFile
+}
+
+// workers returns the number of workers parsing the files
+func (opts LoadOptions) workers(files int) int {
+	return pool.Workers(files, opts.Workers)
+}
+
+// Load parses the workflow files into a workspace, in parallel. The files that can't be parsed are reported as Errors,
+// the returned workspace always holds the rest.
+func Load(files []string) (*Workspace, error) {
+	return LoadWithOptions(files, LoadOptions{})
+}
+
+// LoadWithOptions parses the workflow files into a workspace like Load, with the given options. The workflows and
+// errors are in the order of the files whatever the number of workers.
+func LoadWithOptions(files []string, opts LoadOptions) (*Workspace, error) {
+	workers := opts.workers(len(files))
+	parse := opts.parse()
+	workflows := make([]*model.Workflow, len(files))
+	errs := make([]error, len(files))
+	pool.Run(len(files), workers, func(i int) {
+		workflows[i], errs[i] = parse(files[i])
+	})
+
+	w := &Workspace{}
+	var fileErrs Errors
+	for i, file := range files {
+		if errs[i] != nil {
+			fileErrs = append(fileErrs, &FileError{File: file, Err: errs[i]})
+			continue
+		}
+		w.Workflows = append(w.Workflows, &Workflow{File: file, Workflow: workflows[i]})
+	}
+	if len(fileErrs) > 0 {
+		return w, fileErrs
+	}
+	return w, nil
+}
+
+// Find returns the workflows with the given id and version, of any version if it's empty
+func (w *Workspace) Find(id, version string) []*Workflow {
+	var found []*Workflow
+	for _, workflow := range w.Workflows {
+		if workflow.Workflow.ID == id && (len(version) == 0 || workflow.Workflow.Version == version) {
+			found = append(found, workflow)
+		}
+	}
+	return found
+}
+
+// Resolve returns the workflow referenced by a subflow or continueAs reference. A reference without version must
+// match a single workflow of the workspace. The references to the workflows missing from the workspace are resolved
+// by the Remote resolver if set.
+func (w *Workspace) Resolve(ref model.WorkflowRef) (*model.Workflow, error) {
+	found := w.Find(ref.WorkflowID, ref.Version)
+	switch {
+	case len(found) == 1:
+		return found[0].Workflow, nil
+	case len(found) > 1:
+		return nil, fmt.Errorf("workflow %s has %d versions in the workspace, the reference must have a version", ref.WorkflowID, len(found))
+	case w.Remote != nil:
+		return w.Remote(ref)
+	case len(ref.Version) > 0:
+		return nil, fmt.Errorf("workflow %s version %s is not in the workspace", ref.WorkflowID, ref.Version)
+	}
+	return nil, fmt.Errorf("workflow %s is not in the workspace", ref.WorkflowID)
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v2/model"
+	"github.com/serverlessworkflow/sdk-go/v2/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	w, err := Load([]string{
+		"../parser/testdata/workflows/applicationrequest.json",
+		"../parser/testdata/workflows/missing.json",
+	})
+	assert.Error(t, err)
+	require.Len(t, w.Workflows, 1)
+	assert.Equal(t, "applicantrequest@1.0", w.Workflows[0].Key())
+	assert.Len(t, w.Find("applicantrequest", ""), 1)
+	assert.Empty(t, w.Find("applicantrequest", "2.0"))
+	errs, ok := err.(Errors)
+	require.True(t, ok)
+	assert.Equal(t, "../parser/testdata/workflows/missing.json", errs[0].File)
+}
+
+func TestLoadWithOptions(t *testing.T) {
+	files := []string{
+		"../parser/testdata/workflows/greetings.sw.json",
+		"../parser/testdata/workflows/missing.json",
+		"../parser/testdata/workflows/applicationrequest.json",
+		"../parser/testdata/workflows/eventbasedgreeting.sw.json",
+		"../parser/testdata/workflows/missing.yaml",
+		"../parser/testdata/workflows/purchaseorderworkflow.sw.json",
+	}
+	for _, workers := range []int{1, 2, 16} {
+		w, err := LoadWithOptions(files, LoadOptions{Workers: workers})
+		require.Len(t, w.Workflows, 4)
+		// the workflows and errors are in the order of the files
+		for i, file := range []string{files[0], files[2], files[3], files[5]} {
+			assert.Equal(t, file, w.Workflows[i].File)
+		}
+		errs, ok := err.(Errors)
+		require.True(t, ok)
+		require.Len(t, errs, 2)
+		assert.Equal(t, files[1], errs[0].File)
+		assert.Equal(t, files[4], errs[1].File)
+	}
+
+	w, err := LoadWithOptions(nil, LoadOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, w.Workflows)
+
+	cache := parser.NewCache(nil)
+	first, _ := LoadWithOptions(files, LoadOptions{Cache: cache})
+	second, _ := LoadWithOptions(files, LoadOptions{Cache: cache})
+	require.Len(t, second.Workflows, 4)
+	for i, workflow := range second.Workflows {
+		assert.True(t, first.Workflows[i].Workflow.Equal(workflow.Workflow))
+		assert.NotSame(t, first.Workflows[i].Workflow, workflow.Workflow)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	w := testWorkspace()
+	workflow, err := w.Resolve(model.WorkflowRef{WorkflowID: "payment", Version: "2.0"})
+	require.NoError(t, err)
+	assert.Equal(t, w.Workflows[2].Workflow, workflow)
+	workflow, err = w.Resolve(model.WorkflowRef{WorkflowID: "archive"})
+	require.NoError(t, err)
+	assert.Equal(t, "archive", workflow.ID)
+
+	_, err = w.Resolve(model.WorkflowRef{WorkflowID: "payment"})
+	assert.EqualError(t, err, "workflow payment has 2 versions in the workspace, the reference must have a version")
+	_, err = w.Resolve(model.WorkflowRef{WorkflowID: "payment", Version: "3.0"})
+	assert.EqualError(t, err, "workflow payment version 3.0 is not in the workspace")
+	_, err = w.Resolve(model.WorkflowRef{WorkflowID: "shipping"})
+	assert.EqualError(t, err, "workflow shipping is not in the workspace")
+}
+
+func TestResolveRemote(t *testing.T) {
+	w := testWorkspace()
+	var resolved []model.WorkflowRef
+	w.Remote = func(ref model.WorkflowRef) (*model.Workflow, error) {
+		resolved = append(resolved, ref)
+		return &model.Workflow{BaseWorkflow: model.BaseWorkflow{ID: ref.WorkflowID, Version: ref.Version}}, nil
+	}
+	workflow, err := w.Resolve(model.WorkflowRef{WorkflowID: "payment", Version: "2.0"})
+	require.NoError(t, err)
+	assert.Equal(t, w.Workflows[2].Workflow, workflow)
+	workflow, err = w.Resolve(model.WorkflowRef{WorkflowID: "shipping", Version: "1.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "shipping", workflow.ID)
+	_, err = w.Resolve(model.WorkflowRef{WorkflowID: "payment"})
+	assert.Error(t, err)
+	assert.Equal(t, []model.WorkflowRef{{WorkflowID: "shipping", Version: "1.0"}}, resolved)
+}